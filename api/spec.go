@@ -0,0 +1,9 @@
+package api
+
+import _ "embed"
+
+// Spec is the OpenAPI document `make swagger` writes next to this file. It is
+// compiled in so the docs route works wherever the binary runs.
+//
+//go:embed swagger.json
+var Spec []byte
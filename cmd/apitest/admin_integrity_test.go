@@ -0,0 +1,105 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestAdmin_Integrity_RequiresAdmin(t *testing.T) {
+	tokens := register(t, randomEmail(), "Regular User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.IntegrityReportModel](t, "GET", "/admin/integrity", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+
+	statusCode, _ = do[domain.IntegrityReportModel](t, "POST", "/admin/integrity/fix", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+}
+
+func TestAdmin_Integrity_FindsAndFixes(t *testing.T) {
+	admin := adminTokens(t)
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project "+randomString(8), "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	first := createBoardColumn(t, boardID, tokens.AccessToken, randomBoardColumnName())
+	second := createBoardColumn(t, boardID, tokens.AccessToken, randomBoardColumnName())
+	third := createBoardColumn(t, boardID, tokens.AccessToken, randomBoardColumnName())
+
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	ticketID := uuidToString(ticket.ID)
+	statusCode, moved := do[domain.TicketModel](t, "PATCH", "/tickets/"+ticketID+"/move-to-board", domain.TicketBoardMoveModel{
+		BoardID:       board.ID,
+		BoardColumnID: second.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("failed to move ticket: %v", moved.Error)
+	}
+
+	// what a manual edit could leave: a deleted column still holding a
+	// ticket, and two live columns sharing a position
+	ctx := context.Background()
+	if _, err := testPool.Exec(ctx, "UPDATE board_columns SET deleted_at = NOW() WHERE id = $1", second.ID); err != nil {
+		t.Fatalf("failed to delete column: %v", err)
+	}
+	if _, err := testPool.Exec(ctx, "UPDATE board_columns SET position = $2 WHERE id = $1", third.ID, first.Position); err != nil {
+		t.Fatalf("failed to move column: %v", err)
+	}
+
+	statusCode, resp := do[domain.IntegrityReportModel](t, "GET", "/admin/integrity", nil, admin.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	counts := map[string]int64{}
+	for _, c := range resp.Data.Checks {
+		counts[c.Check] = c.Count
+	}
+	if counts[domain.IntegrityOrphanedTickets] == 0 || counts[domain.IntegrityDuplicateColumnPositions] == 0 {
+		t.Fatalf("expected the orphaned ticket and the shared position to be found, got %+v", resp.Data.Checks)
+	}
+
+	statusCode, resp = do[domain.IntegrityReportModel](t, "POST", "/admin/integrity/fix", nil, admin.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Mode != domain.IntegrityModeFix {
+		t.Fatalf("expected fix mode, got %s", resp.Data.Mode)
+	}
+
+	statusCode, got := do[domain.TicketModel](t, "GET", "/tickets/"+ticketID, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || got.Data == nil {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+	if got.Data.BoardColumnID != first.ID {
+		t.Fatalf("expected the ticket in the default column, got %s", uuidToString(got.Data.BoardColumnID))
+	}
+
+	statusCode, cols := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || cols.Data == nil {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+	seen := map[int32]bool{}
+	for _, c := range cols.Data.Items {
+		if seen[c.Position] {
+			t.Fatalf("expected distinct positions after the fix, got %d twice", c.Position)
+		}
+		seen[c.Position] = true
+	}
+}
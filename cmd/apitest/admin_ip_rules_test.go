@@ -0,0 +1,117 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/ipfilter"
+)
+
+// resetIPRules restores the open rule set the suite starts with
+func resetIPRules(tb testing.TB) {
+	rs, err := ipfilter.Parse(ipfilter.Rules{})
+	if err != nil {
+		tb.Fatal(err)
+	}
+	testIPFilter.SetRules(rs)
+}
+
+func TestAdmin_IPRules_RequiresAdmin(t *testing.T) {
+	tokens := register(t, randomEmail(), "Regular User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.IPRulesModel](t, "GET", "/admin/ip-rules", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+
+	statusCode, _ = do[domain.IPRulesModel](t, "PUT", "/admin/ip-rules", domain.IPRulesModel{Deny: []string{"10.0.0.0/8"}}, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+}
+
+func TestAdmin_IPRules_Replace(t *testing.T) {
+	defer resetIPRules(t)
+	tokens := adminTokens(t)
+
+	statusCode, resp := do[domain.IPRulesModel](t, "PUT", "/admin/ip-rules", domain.IPRulesModel{
+		Allow: []string{"127.0.0.0/8", "::1"},
+		Deny:  []string{"10.1.2.3"},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	statusCode, resp = do[domain.IPRulesModel](t, "GET", "/admin/ip-rules", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Allow) != 2 || resp.Data.Allow[1] != "::1/128" {
+		t.Fatalf("expected normalised allow list, got %v", resp.Data.Allow)
+	}
+	if len(resp.Data.Deny) != 1 || resp.Data.Deny[0] != "10.1.2.3/32" {
+		t.Fatalf("expected normalised deny list, got %v", resp.Data.Deny)
+	}
+}
+
+func TestAdmin_IPRules_RejectsInvalidEntry(t *testing.T) {
+	tokens := adminTokens(t)
+
+	statusCode, resp := do[domain.IPRulesModel](t, "PUT", "/admin/ip-rules", domain.IPRulesModel{
+		Deny: []string{"10.0.0.0/40"},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "invalid_ip_rule" {
+		t.Fatalf("expected invalid_ip_rule, got %v", resp.Error)
+	}
+}
+
+func TestAdmin_IPRules_RefusesSelfLockout(t *testing.T) {
+	defer resetIPRules(t)
+	tokens := adminTokens(t)
+
+	statusCode, resp := do[domain.IPRulesModel](t, "PUT", "/admin/ip-rules", domain.IPRulesModel{
+		Deny: []string{"127.0.0.0/8", "::1"},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "ip_rules_lockout" {
+		t.Fatalf("expected ip_rules_lockout, got %v", resp.Error)
+	}
+
+	// the rejected rules were not applied
+	statusCode, _ = do[domain.IPRulesModel](t, "GET", "/admin/ip-rules", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+}
+
+func TestAdmin_IPRules_BlocksDeniedAddress(t *testing.T) {
+	defer resetIPRules(t)
+	tokens := adminTokens(t)
+
+	// set directly, the endpoint refuses rules that lock the caller out
+	rs, err := ipfilter.Parse(ipfilter.Rules{Deny: []string{"127.0.0.0/8", "::1"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	testIPFilter.SetRules(rs)
+
+	statusCode, resp := do[domain.IPRulesModel](t, "GET", "/admin/ip-rules", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "ip_blocked" {
+		t.Fatalf("expected ip_blocked, got %v", resp.Error)
+	}
+
+	// routes outside the filtered prefix are untouched
+	statusCode, _ = do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+}
@@ -0,0 +1,56 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestAdmin_Migrations_RequiresAdmin(t *testing.T) {
+	tokens := register(t, randomEmail(), "Regular User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.MigrationStatusModel](t, "GET", "/admin/migrations", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+}
+
+func TestAdmin_Migrations_ReportsPending(t *testing.T) {
+	tokens := adminTokens(t)
+
+	statusCode, resp := do[domain.MigrationStatusModel](t, "GET", "/admin/migrations", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Phases) != 2 || resp.Data.Phases[0].Phase != "expand" || resp.Data.Phases[1].Phase != "contract" {
+		t.Fatalf("expected the expand and contract phases, got %+v", resp.Data.Phases)
+	}
+	status := resp.Data.Phases[0]
+	if status.Version == 0 || status.Version != status.Latest || status.Dirty || len(status.Pending) != 0 {
+		t.Fatalf("expected a clean schema at the latest version, got %+v", status)
+	}
+	if contract := resp.Data.Phases[1]; contract.Dirty || len(contract.Pending) != 0 {
+		t.Fatalf("expected no contract migration pending, got %+v", contract)
+	}
+
+	// pretend the last migration failed halfway through
+	ctx := context.Background()
+	if _, err := testPool.Exec(ctx, "UPDATE schema_migrations SET version = $1, dirty = true", status.Latest-1); err != nil {
+		t.Fatalf("failed to rewind schema version: %v", err)
+	}
+	defer testPool.Exec(ctx, "UPDATE schema_migrations SET version = $1, dirty = false", status.Latest)
+
+	statusCode, resp = do[domain.MigrationStatusModel](t, "GET", "/admin/migrations", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	rewound := resp.Data.Phases[0]
+	if !rewound.Dirty || rewound.Version != status.Latest-1 || len(rewound.Pending) != 1 {
+		t.Fatalf("expected one pending migration on a dirty schema, got %+v", rewound)
+	}
+	if pending := rewound.Pending[0]; pending.Version != status.Latest || pending.Name == "" {
+		t.Fatalf("expected the latest migration pending, got %+v", pending)
+	}
+}
@@ -0,0 +1,143 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestAdmin_Purge_RequiresAdmin(t *testing.T) {
+	tokens := register(t, randomEmail(), "Regular User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.PurgeReportModel](t, "POST", "/admin/purge", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+}
+
+func TestAdmin_Purge_RemovesExpiredRows(t *testing.T) {
+	admin := adminTokens(t)
+
+	statusCode, resp := do[domain.PurgeReportModel](t, "POST", "/admin/purge", nil, admin.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity || resp.Error == nil || resp.Error.Code != "purge_disabled" {
+		t.Fatalf("expected 422 purge_disabled, got %d: %v", statusCode, resp.Error)
+	}
+
+	testRetentionConfig.AfterDays = 30
+	defer func() { testRetentionConfig.AfterDays = 0 }()
+
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	gone := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Gone Project", "private")
+
+	expired := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	recent := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	inside := createTicket(t, uuidToString(gone.ID), tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	if statusCode, upload := uploadAttachment(t, uuidToString(expired.ID), "file", "notes.txt", []byte("expired"), tokens.AccessToken); statusCode != http.StatusCreated {
+		t.Fatalf("failed to upload attachment: got %d, error: %v", statusCode, upload.Error)
+	}
+
+	for _, path := range []string{"/tickets/" + uuidToString(expired.ID), "/tickets/" + uuidToString(recent.ID), "/projects/" + uuidToString(gone.ID)} {
+		if statusCode, resp := do[any](t, "DELETE", path, nil, tokens.AccessToken); statusCode != http.StatusNoContent {
+			t.Fatalf("failed to delete %s: got %d, error: %v", path, statusCode, resp.Error)
+		}
+	}
+	ctx := context.Background()
+	if _, err := testPool.Exec(ctx, "UPDATE tickets SET deleted_at = NOW() - INTERVAL '31 days' WHERE id = $1", expired.ID); err != nil {
+		t.Fatalf("failed to age ticket: %v", err)
+	}
+	if _, err := testPool.Exec(ctx, "UPDATE projects SET deleted_at = NOW() - INTERVAL '31 days' WHERE id = $1", gone.ID); err != nil {
+		t.Fatalf("failed to age project: %v", err)
+	}
+
+	statusCode, resp = do[domain.PurgeReportModel](t, "POST", "/admin/purge", nil, admin.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Tickets < 1 || resp.Data.Projects < 1 || resp.Data.Attachments < 1 {
+		t.Fatalf("expected the aged ticket, project and attachment purged, got %+v", resp.Data)
+	}
+
+	exists := func(table string, id any) bool {
+		t.Helper()
+		var found bool
+		if err := testPool.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM "+table+" WHERE id = $1)", id).Scan(&found); err != nil {
+			t.Fatalf("failed to look up %s: %v", table, err)
+		}
+		return found
+	}
+	if exists("tickets", expired.ID) || exists("projects", gone.ID) || exists("tickets", inside.ID) {
+		t.Fatal("expected the aged ticket and project, with the project's tickets, to be gone")
+	}
+	if !exists("tickets", recent.ID) || !exists("projects", project.ID) {
+		t.Fatal("expected rows deleted recently or not at all to stay")
+	}
+}
+
+func TestAdmin_Purge_TrimsChanges(t *testing.T) {
+	admin := adminTokens(t)
+	testRetentionConfig.ChangesRetention = 24 * time.Hour
+	defer func() { testRetentionConfig.ChangesRetention = 0 }()
+
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+	statusCode, feed := do[domain.ChangeFeedModel](t, "GET", "/changes", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || feed.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, feed.Error)
+	}
+	start := strconv.FormatInt(feed.Data.Cursor, 10)
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+
+	if _, err := testPool.Exec(context.Background(), "UPDATE changes SET created_at = NOW() - INTERVAL '2 days'"); err != nil {
+		t.Fatalf("failed to age changes: %v", err)
+	}
+
+	statusCode, resp := do[domain.PurgeReportModel](t, "POST", "/admin/purge", nil, admin.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Changes < 1 || resp.Data.Tickets != 0 {
+		t.Fatalf("expected only changes trimmed, got %+v", resp.Data)
+	}
+
+	// the entries after the old cursor are gone, so it can not be resumed
+	statusCode, feed = do[domain.ChangeFeedModel](t, "GET", "/changes?after="+start, nil, tokens.AccessToken)
+	if statusCode != http.StatusGone || feed.Error == nil || feed.Error.Code != "cursor_expired" {
+		t.Fatalf("expected 410 cursor_expired for the feed, got %d: %v", statusCode, feed.Error)
+	}
+	statusCode, changes := do[domain.ProjectChangesModel](t, "GET", "/projects/"+projectID+"/changes?since="+start, nil, tokens.AccessToken)
+	if statusCode != http.StatusGone || changes.Error == nil || changes.Error.Code != "cursor_expired" {
+		t.Fatalf("expected 410 cursor_expired for the project poll, got %d: %v", statusCode, changes.Error)
+	}
+
+	// the newest entry is kept, so a fresh cursor does not go back and resumes
+	statusCode, feed = do[domain.ChangeFeedModel](t, "GET", "/changes", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || feed.Data == nil || strconv.FormatInt(feed.Data.Cursor, 10) == start {
+		t.Fatalf("expected a cursor past %s, got %d: %+v", start, statusCode, feed.Data)
+	}
+	statusCode, feed = do[domain.ChangeFeedModel](t, "GET", "/changes?after="+strconv.FormatInt(feed.Data.Cursor, 10), nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200 for a fresh cursor, got %d: %v", statusCode, feed.Error)
+	}
+}
@@ -0,0 +1,103 @@
+package apitest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestAdmin_Recording_RequiresAdmin(t *testing.T) {
+	tokens := register(t, randomEmail(), "Regular User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.RecordingModel](t, "GET", "/admin/recording", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+
+	statusCode, _ = do[domain.RecordingModel](t, "PUT", "/admin/recording", domain.RecordingStartModel{DurationSeconds: 60}, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+}
+
+func TestAdmin_Recording_RecordsSanitizedExchanges(t *testing.T) {
+	tokens := adminTokens(t)
+	defer do[any](t, "DELETE", "/admin/recording", nil, tokens.AccessToken)
+
+	statusCode, resp := do[domain.RecordingModel](t, "PUT", "/admin/recording", domain.RecordingStartModel{DurationSeconds: 60}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+	if !resp.Data.Enabled || !resp.Data.Active || resp.Data.Until == nil {
+		t.Fatalf("expected an active window, got %+v", resp.Data)
+	}
+
+	email := randomEmail()
+	register(t, email, "Recorded User", "RecordedPassword123!")
+
+	statusCode, resp = do[domain.RecordingModel](t, "GET", "/admin/recording", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+
+	var found *domain.RecordedExchangeModel
+	for i, ex := range resp.Data.Exchanges {
+		if ex.Path == "/auth/register" && strings.Contains(ex.RequestBody, email) {
+			found = &resp.Data.Exchanges[i]
+		}
+	}
+	if found == nil {
+		t.Fatalf("registration was not recorded, got %d exchanges", len(resp.Data.Exchanges))
+	}
+	if found.Method != "POST" || found.Status != http.StatusCreated {
+		t.Errorf("expected POST answered with 201, got %s %d", found.Method, found.Status)
+	}
+	if strings.Contains(found.RequestBody, "RecordedPassword123!") {
+		t.Errorf("password kept in request body: %s", found.RequestBody)
+	}
+	if strings.Contains(found.ResponseBody, "eyJ") {
+		t.Errorf("tokens kept in response body: %s", found.ResponseBody)
+	}
+}
+
+func TestAdmin_Recording_StopClears(t *testing.T) {
+	tokens := adminTokens(t)
+
+	statusCode, _ := do[domain.RecordingModel](t, "PUT", "/admin/recording", domain.RecordingStartModel{DurationSeconds: 60}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+	register(t, randomEmail(), "Recorded User", "SecurePassword123!")
+
+	statusCode, _ = do[any](t, "DELETE", "/admin/recording", nil, tokens.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	statusCode, resp := do[domain.RecordingModel](t, "GET", "/admin/recording", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+	if resp.Data.Active || len(resp.Data.Exchanges) != 0 {
+		t.Fatalf("expected recording stopped and cleared, got active=%v with %d exchanges", resp.Data.Active, len(resp.Data.Exchanges))
+	}
+}
+
+func TestAdmin_Recording_RejectsInvalidWindow(t *testing.T) {
+	tokens := adminTokens(t)
+
+	statusCode, _ := do[domain.RecordingModel](t, "PUT", "/admin/recording", domain.RecordingStartModel{DurationSeconds: 0}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+
+	statusCode, resp := do[domain.RecordingModel](t, "PUT", "/admin/recording", domain.RecordingStartModel{DurationSeconds: 7200}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "invalid_recording_window" {
+		t.Fatalf("expected invalid_recording_window, got %+v", resp.Error)
+	}
+}
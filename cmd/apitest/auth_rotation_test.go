@@ -0,0 +1,74 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func signedToken(t *testing.T, user domain.UserModel, secret string) string {
+	now := time.Now()
+	claims := domain.AuthTokenClaimModel{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.Email,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		ID:     user.ID,
+		Scopes: domain.DefaultUserScopes,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestAuth_Rotation_PreviousAccessSecretAccepted(t *testing.T) {
+	tokens := register(t, randomEmail(), "Rotated User", "SecurePassword123!")
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if me.Data == nil {
+		t.Fatal("expected user data")
+	}
+
+	token := signedToken(t, *me.Data, testAuthConfig.PreviousAccessTokenSecret)
+	statusCode, resp := do[domain.UserModel](t, "GET", "/users/me", nil, token)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	token = signedToken(t, *me.Data, "some-other-secret-32-chars-long-xxxx")
+	statusCode, _ = do[domain.UserModel](t, "GET", "/users/me", nil, token)
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401 for an unknown secret, got %d", statusCode)
+	}
+}
+
+func TestAuth_Rotation_PreviousRefreshSecretAccepted(t *testing.T) {
+	tokens := register(t, randomEmail(), "Rotated User", "SecurePassword123!")
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if me.Data == nil {
+		t.Fatal("expected user data")
+	}
+
+	statusCode, resp := do[domain.AuthModel](t, "POST", "/auth/refresh", domain.AuthRefreshModel{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: signedToken(t, *me.Data, testAuthConfig.PreviousRefreshTokenSecret),
+	}, "")
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	// new tokens are signed with the current secret
+	if _, err := jwt.Parse(resp.Data.AccessToken, func(*jwt.Token) (any, error) {
+		return []byte(testAuthConfig.AccessTokenSecret), nil
+	}); err != nil {
+		t.Fatalf("expected the new access token to use the current secret: %v", err)
+	}
+}
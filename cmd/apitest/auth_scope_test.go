@@ -0,0 +1,85 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func scopedToken(t *testing.T, user domain.UserModel, scopes ...string) string {
+	now := time.Now()
+	claims := domain.AuthTokenClaimModel{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   user.Email,
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+		ID:     user.ID,
+		Scopes: scopes,
+	}
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testAuthConfig.AccessTokenSecret))
+	if err != nil {
+		t.Fatalf("failed to sign token: %v", err)
+	}
+	return token
+}
+
+func TestAuth_Scope_GrantedScopeAllowed(t *testing.T) {
+	tokens := register(t, randomEmail(), "Scoped User", "SecurePassword123!")
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if me.Data == nil {
+		t.Fatal("expected user data")
+	}
+
+	token := scopedToken(t, *me.Data, domain.ScopeTicketsRead)
+
+	statusCode, resp := do[domain.TicketsPagedModel](t, "GET", "/tickets", nil, token)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+}
+
+func TestAuth_Scope_MissingScopeForbidden(t *testing.T) {
+	tokens := register(t, randomEmail(), "Scoped User", "SecurePassword123!")
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if me.Data == nil {
+		t.Fatal("expected user data")
+	}
+
+	token := scopedToken(t, *me.Data, domain.ScopeTicketsRead)
+
+	statusCode, resp := do[domain.TicketModel](t, "POST", "/tickets", domain.TicketCreateModel{}, token)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "insufficient_scope" {
+		t.Fatalf("expected insufficient_scope error, got %v", resp.Error)
+	}
+}
+
+func TestAuth_Scope_ResourceWildcard(t *testing.T) {
+	tokens := register(t, randomEmail(), "Scoped User", "SecurePassword123!")
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if me.Data == nil {
+		t.Fatal("expected user data")
+	}
+
+	token := scopedToken(t, *me.Data, "users:*")
+
+	statusCode, _ := do[domain.UserModel](t, "GET", "/users/me", nil, token)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+
+	statusCode, _ = do[domain.ProjectsPagedModel](t, "GET", "/projects", nil, token)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+}
@@ -338,6 +338,35 @@ func TestBoard_Reorder_MissingSprintId(t *testing.T) {
 	}
 }
 
+func TestBoard_Reorder_DuplicateBoard(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	sprintID := uuidToString(sprint.ID)
+
+	board1 := createBoard(t, sprintID, tokens.AccessToken, randomBoardName())
+	_ = createBoard(t, sprintID, tokens.AccessToken, randomBoardName())
+
+	// Same length as the sprint's boards, but board2 is missing
+	statusCode, resp := do[[]domain.BoardModel](t, "PATCH", "/boards/reorder?sprintId="+sprintID, domain.BoardReorderModel{
+		board1.ID,
+		board1.ID,
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for duplicate board, got %d: %v", statusCode, resp.Error)
+	}
+}
+
 func TestBoard_Reorder_Unauthenticated(t *testing.T) {
 	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
 
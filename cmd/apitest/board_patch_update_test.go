@@ -190,8 +190,8 @@ func TestBoard_Update_InvalidUUID(t *testing.T) {
 		Name: "Updated Board",
 	}, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
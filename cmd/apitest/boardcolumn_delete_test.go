@@ -20,6 +20,8 @@ func TestBoardColumn_Delete_Success(t *testing.T) {
 	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
 	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
 	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	// the first column is the board's default, which needs a replacement to go
+	createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, randomBoardColumnName())
 	column := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, randomBoardColumnName())
 
 	code, _ := do[interface{}](t, "DELETE", "/boards/"+uuidToString(board.ID)+"/columns/"+uuidToString(column.ID), nil, tokens.AccessToken)
@@ -3,6 +3,7 @@ package apitest_test
 import (
 	"net/http"
 	"testing"
+	"time"
 
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 )
@@ -229,8 +230,8 @@ func TestBoardColumn_List_InvalidBoardID(t *testing.T) {
 
 	statusCode, _ := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/invalid-id/columns", nil, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
@@ -325,3 +326,132 @@ func TestBoardColumn_List_Unauthenticated(t *testing.T) {
 		t.Fatalf("expected status 401, got %d", statusCode)
 	}
 }
+
+func TestBoardColumn_List_IncludeCounts(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	busy := createBoardColumn(t, boardID, tokens.AccessToken, "Busy")
+	createBoardColumn(t, boardID, tokens.AccessToken, "Empty")
+
+	statusCode, overdue := do[domain.TicketModel](t, "POST", "/tickets?projectId="+projectID, domain.TicketCreateModel{
+		Title:    randomTicketTitle(),
+		Type:     "task",
+		Priority: "high",
+		DueDate:  time.Now().AddDate(0, 0, -2),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || overdue.Data == nil {
+		t.Fatalf("failed to create overdue ticket: %d", statusCode)
+	}
+	onTime := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	for _, ticketID := range []string{uuidToString(overdue.Data.ID), uuidToString(onTime.ID)} {
+		statusCode, _ := do[domain.TicketModel](t, "PATCH", "/tickets/"+ticketID+"/move-board-column", domain.TicketBoardMoveModel{
+			BoardID:       board.ID,
+			BoardColumnID: busy.ID,
+		}, tokens.AccessToken)
+		if statusCode != http.StatusOK {
+			t.Fatalf("failed to move ticket: %d", statusCode)
+		}
+	}
+
+	statusCode, resp := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns?includeCounts=true", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if len(resp.Data.Items) != 2 {
+		t.Fatalf("expected 2 columns, got %d", len(resp.Data.Items))
+	}
+
+	first, second := resp.Data.Items[0], resp.Data.Items[1]
+	if first.TicketCount == nil || *first.TicketCount != 2 || first.OverdueCount == nil || *first.OverdueCount != 1 {
+		t.Fatalf("expected busy column to report 2 tickets and 1 overdue, got %v/%v", first.TicketCount, first.OverdueCount)
+	}
+	if second.TicketCount == nil || *second.TicketCount != 0 {
+		t.Fatalf("expected empty column to report 0 tickets, got %v", second.TicketCount)
+	}
+
+	// counts are opt-in
+	statusCode, plain := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || plain.Data == nil {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+	if plain.Data.Items[0].TicketCount != nil {
+		t.Fatal("expected no counts without includeCounts")
+	}
+}
+
+func TestBoardColumn_List_FilterByIDAndCategory(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	todo := createBoardColumn(t, boardID, tokens.AccessToken, "Todo")
+	review := createBoardColumn(t, boardID, tokens.AccessToken, "Review")
+	statusCode, doneResp := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Done",
+		Category: "done",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || doneResp.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, doneResp.Error)
+	}
+
+	statusCode, resp := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns?category=done", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 1 || resp.Data.Items[0].ID != doneResp.Data.ID {
+		t.Fatalf("expected only the done column, got %d items", len(resp.Data.Items))
+	}
+
+	statusCode, resp = do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns?id="+uuidToString(todo.ID)+"&id="+uuidToString(review.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 2 {
+		t.Fatalf("expected 2 columns, got %d", len(resp.Data.Items))
+	}
+}
+
+func TestBoardColumn_List_InvalidCategory(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+
+	statusCode, _ = do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+uuidToString(board.ID)+"/columns?category=blocked", nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
@@ -0,0 +1,170 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestBoardColumn_Move_BetweenNeighbours(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	col1 := createBoardColumn(t, boardID, tokens.AccessToken, "Column 1")
+	col2 := createBoardColumn(t, boardID, tokens.AccessToken, "Column 2")
+	col3 := createBoardColumn(t, boardID, tokens.AccessToken, "Column 3")
+
+	if col2.Position-col1.Position != 1024 {
+		t.Fatalf("expected columns to be created 1024 apart, got %d and %d", col1.Position, col2.Position)
+	}
+
+	// Column 3 goes between column 1 and column 2
+	statusCode, resp := do[domain.BoardColumnModel](t, "PATCH", "/boards/"+boardID+"/columns/"+uuidToString(col3.ID)+"/position", domain.BoardColumnPositionModel{
+		AfterID: col1.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Position <= col1.Position || resp.Data.Position >= col2.Position {
+		t.Fatalf("expected position between %d and %d, got %d", col1.Position, col2.Position, resp.Data.Position)
+	}
+
+	statusCode, list := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, list.Error)
+	}
+
+	order := []string{uuidToString(col1.ID), uuidToString(col3.ID), uuidToString(col2.ID)}
+	for i, item := range list.Data.Items {
+		if uuidToString(item.ID) != order[i] {
+			t.Fatalf("unexpected order at %d: got %s", i, uuidToString(item.ID))
+		}
+	}
+
+	// Neighbours were not rewritten
+	if list.Data.Items[0].Position != col1.Position || list.Data.Items[2].Position != col2.Position {
+		t.Fatal("expected sibling positions to be untouched")
+	}
+}
+
+func TestBoardColumn_Move_ToFront(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	col1 := createBoardColumn(t, boardID, tokens.AccessToken, "Column 1")
+	col2 := createBoardColumn(t, boardID, tokens.AccessToken, "Column 2")
+
+	statusCode, resp := do[domain.BoardColumnModel](t, "PATCH", "/boards/"+boardID+"/columns/"+uuidToString(col2.ID)+"/position", domain.BoardColumnPositionModel{}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Position >= col1.Position {
+		t.Fatalf("expected column 2 before column 1, got %d vs %d", resp.Data.Position, col1.Position)
+	}
+}
+
+func TestBoardColumn_Move_RepeatedSplitsCompact(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	first := createBoardColumn(t, boardID, tokens.AccessToken, "First")
+	_ = createBoardColumn(t, boardID, tokens.AccessToken, "Last")
+	a := createBoardColumn(t, boardID, tokens.AccessToken, "A")
+	b := createBoardColumn(t, boardID, tokens.AccessToken, "B")
+
+	// Alternately dropping A and B right after First halves the gap each time,
+	// 1024 runs out after about ten moves and forces a compaction
+	for i := range 14 {
+		moving := a
+		if i%2 == 1 {
+			moving = b
+		}
+		statusCode, resp := do[domain.BoardColumnModel](t, "PATCH", "/boards/"+boardID+"/columns/"+uuidToString(moving.ID)+"/position", domain.BoardColumnPositionModel{
+			AfterID: first.ID,
+		}, tokens.AccessToken)
+		if statusCode != http.StatusOK {
+			t.Fatalf("move %d: expected status 200, got %d: %v", i, statusCode, resp.Error)
+		}
+	}
+
+	statusCode, list := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, list.Error)
+	}
+	if uuidToString(list.Data.Items[0].ID) != uuidToString(first.ID) || uuidToString(list.Data.Items[1].ID) != uuidToString(b.ID) {
+		t.Fatal("expected First then B after the last move")
+	}
+	for i := 1; i < len(list.Data.Items); i++ {
+		if list.Data.Items[i].Position <= list.Data.Items[i-1].Position {
+			t.Fatalf("expected strictly increasing positions, got %d then %d", list.Data.Items[i-1].Position, list.Data.Items[i].Position)
+		}
+	}
+}
+
+func TestBoardColumn_Move_AfterSelf(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+	column := createBoardColumn(t, boardID, tokens.AccessToken, randomBoardColumnName())
+
+	statusCode, _ = do[domain.BoardColumnModel](t, "PATCH", "/boards/"+boardID+"/columns/"+uuidToString(column.ID)+"/position", domain.BoardColumnPositionModel{
+		AfterID: column.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
+
+func TestBoardColumn_Move_Unauthenticated(t *testing.T) {
+	boardID := "550e8400-e29b-41d4-a716-446655440000"
+	columnID := "550e8400-e29b-41d4-a716-446655440001"
+
+	statusCode, _ := do[domain.BoardColumnModel](t, "PATCH", "/boards/"+boardID+"/columns/"+columnID+"/position", domain.BoardColumnPositionModel{}, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
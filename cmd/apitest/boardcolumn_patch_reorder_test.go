@@ -51,15 +51,15 @@ func TestBoardColumn_Reorder_Success(t *testing.T) {
 		t.Fatalf("expected 3 columns in response, got %d", len(*reorderResp.Data))
 	}
 
-	// Verify positions were updated correctly
+	// Verify positions were updated correctly, spaced 1024 apart
 	if (*reorderResp.Data)[0].Position != 0 || (*reorderResp.Data)[0].ID != col3.ID {
 		t.Fatalf("expected col3 at position 0")
 	}
-	if (*reorderResp.Data)[1].Position != 1 || (*reorderResp.Data)[1].ID != col1.ID {
-		t.Fatalf("expected col1 at position 1")
+	if (*reorderResp.Data)[1].Position != 1024 || (*reorderResp.Data)[1].ID != col1.ID {
+		t.Fatalf("expected col1 at position 1024")
 	}
-	if (*reorderResp.Data)[2].Position != 2 || (*reorderResp.Data)[2].ID != col2.ID {
-		t.Fatalf("expected col2 at position 2")
+	if (*reorderResp.Data)[2].Position != 2048 || (*reorderResp.Data)[2].ID != col2.ID {
+		t.Fatalf("expected col2 at position 2048")
 	}
 
 	// Verify list endpoint returns same order
@@ -96,8 +96,8 @@ func TestBoardColumn_Reorder_InvalidBoardUUID(t *testing.T) {
 	reorderPayload := domain.BoardColumnReorderModel{stringToUUID(col1ID), stringToUUID(col2ID)}
 	statusCode, _ := do[[]domain.BoardColumnModel](t, "PATCH", "/boards/not-a-uuid/columns/reorder", reorderPayload, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
@@ -208,6 +208,73 @@ func TestBoardColumn_Reorder_PartialUpdate(t *testing.T) {
 	}
 }
 
+func TestBoardColumn_Reorder_DuplicateColumn(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+
+	col1 := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 1")
+	_ = createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 2")
+	col3 := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 3")
+
+	// Same length as the board's columns, but column 2 is missing
+	reorderPayload := domain.BoardColumnReorderModel{col1.ID, col1.ID, col3.ID}
+	statusCode, resp := do[[]domain.BoardColumnModel](t, "PATCH", "/boards/"+uuidToString(board.ID)+"/columns/reorder", reorderPayload, tokens.AccessToken)
+
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for duplicate column, got %d: %v", statusCode, resp.Error)
+	}
+}
+
+func TestBoardColumn_Reorder_AfterDelete(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	col1 := createBoardColumn(t, boardID, tokens.AccessToken, "Column 1")
+	col2 := createBoardColumn(t, boardID, tokens.AccessToken, "Column 2")
+	col3 := createBoardColumn(t, boardID, tokens.AccessToken, "Column 3")
+
+	code, _ := do[interface{}](t, "DELETE", "/boards/"+boardID+"/columns/"+uuidToString(col2.ID), nil, tokens.AccessToken)
+	if code != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", code)
+	}
+
+	// The deleted column no longer counts towards the board
+	statusCode, resp := do[[]domain.BoardColumnModel](t, "PATCH", "/boards/"+boardID+"/columns/reorder", domain.BoardColumnReorderModel{col3.ID, col1.ID}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(*resp.Data) != 2 || (*resp.Data)[0].ID != col3.ID {
+		t.Fatalf("expected column 3 first, got %v", *resp.Data)
+	}
+
+	// Including the deleted column is rejected
+	statusCode, resp = do[[]domain.BoardColumnModel](t, "PATCH", "/boards/"+boardID+"/columns/reorder", domain.BoardColumnReorderModel{col1.ID, col2.ID, col3.ID}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for deleted column, got %d: %v", statusCode, resp.Error)
+	}
+}
+
 func TestBoardColumn_Reorder_Unauthenticated(t *testing.T) {
 	boardID := "550e8400-e29b-41d4-a716-446655440000"
 	col1ID := "550e8400-e29b-41d4-a716-446655440001"
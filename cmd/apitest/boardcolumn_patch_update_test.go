@@ -40,6 +40,66 @@ func TestBoardColumn_Update_Success(t *testing.T) {
 	}
 }
 
+func TestBoardColumn_Update_CategoryKeepsName(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	column := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, randomBoardColumnName())
+
+	if column.Category != "todo" {
+		t.Fatalf("expected default category 'todo', got '%s'", column.Category)
+	}
+
+	statusCode, resp := do[domain.BoardColumnModel](t, "PATCH", "/boards/"+uuidToString(board.ID)+"/columns/"+uuidToString(column.ID), domain.BoardColumnUpdateModel{
+		Category: "done",
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data == nil || resp.Data.Category != "done" {
+		t.Fatalf("expected category 'done', got %+v", resp.Data)
+	}
+
+	if resp.Data.Name != column.Name {
+		t.Fatalf("expected name '%s' to be kept, got '%s'", column.Name, resp.Data.Name)
+	}
+}
+
+func TestBoardColumn_Update_InvalidCategory(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	column := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, randomBoardColumnName())
+
+	statusCode, _ = do[domain.BoardColumnModel](t, "PATCH", "/boards/"+uuidToString(board.ID)+"/columns/"+uuidToString(column.ID), domain.BoardColumnUpdateModel{
+		Category: "blocked",
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
+
 func TestBoardColumn_Update_NotFound(t *testing.T) {
 	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
 
@@ -73,8 +73,8 @@ func TestBoardColumn_Create_InvalidBoardUUID(t *testing.T) {
 		Name: "Test Column",
 	}, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
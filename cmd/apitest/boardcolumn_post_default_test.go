@@ -0,0 +1,187 @@
+package apitest_test
+
+import (
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func countDefaultColumns(tb testing.TB, boardID string, token string) (int, domain.BoardColumnModel) {
+	statusCode, list := do[domain.BoardColumnsPagedModel](tb, "GET", "/boards/"+boardID+"/columns", nil, token)
+	if statusCode != http.StatusOK || list.Data == nil {
+		tb.Fatalf("list board columns failed: got status %d, error: %v", statusCode, list.Error)
+	}
+
+	count := 0
+	var found domain.BoardColumnModel
+	for _, item := range list.Data.Items {
+		if item.IsDefault {
+			count++
+			found = item
+		}
+	}
+	return count, found
+}
+
+func TestBoardColumn_Default_FirstColumnAndSwitch(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	first := createBoardColumn(t, boardID, tokens.AccessToken, "Todo")
+	second := createBoardColumn(t, boardID, tokens.AccessToken, "Doing")
+
+	if !first.IsDefault || second.IsDefault {
+		t.Fatalf("expected only the first column to be default, got %v and %v", first.IsDefault, second.IsDefault)
+	}
+
+	statusCode, resp := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns/"+uuidToString(second.ID)+"/make-default", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if !resp.Data.IsDefault {
+		t.Fatal("expected the column to be default")
+	}
+
+	count, current := countDefaultColumns(t, boardID, tokens.AccessToken)
+	if count != 1 || current.ID != second.ID {
+		t.Fatalf("expected exactly the second column as default, got %d defaults", count)
+	}
+}
+
+func TestBoardColumn_Default_ConcurrentSwitches(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	var columns []domain.BoardColumnModel
+	for range 5 {
+		columns = append(columns, createBoardColumn(t, boardID, tokens.AccessToken, randomBoardColumnName()))
+	}
+
+	var wg sync.WaitGroup
+	statuses := make([]int, len(columns))
+	for i, col := range columns {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i], _ = do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns/"+uuidToString(col.ID)+"/make-default", nil, tokens.AccessToken)
+		}()
+	}
+	wg.Wait()
+
+	for i, status := range statuses {
+		if status != http.StatusOK && status != http.StatusConflict {
+			t.Fatalf("switch %d: expected status 200 or 409, got %d", i, status)
+		}
+	}
+
+	if count, _ := countDefaultColumns(t, boardID, tokens.AccessToken); count != 1 {
+		t.Fatalf("expected exactly one default column, got %d", count)
+	}
+}
+
+func TestBoardColumn_Default_DeleteNeedsReplacement(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	first := createBoardColumn(t, boardID, tokens.AccessToken, "Todo")
+	second := createBoardColumn(t, boardID, tokens.AccessToken, "Doing")
+	third := createBoardColumn(t, boardID, tokens.AccessToken, "Done")
+	path := "/boards/" + boardID + "/columns/" + uuidToString(first.ID)
+
+	statusCode, resp := do[interface{}](t, "DELETE", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusConflict || resp.Error == nil || resp.Error.Code != "default_column" {
+		t.Fatalf("expected 409 default_column without a replacement, got %d", statusCode)
+	}
+
+	statusCode, resp = do[interface{}](t, "DELETE", path+"?replacementId="+uuidToString(first.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest || resp.Error == nil || resp.Error.Code != "replace_with_self" {
+		t.Fatalf("expected 400 replace_with_self, got %d", statusCode)
+	}
+
+	if count, current := countDefaultColumns(t, boardID, tokens.AccessToken); count != 1 || current.ID != first.ID {
+		t.Fatalf("expected the refused deletes to keep the default, got %d defaults", count)
+	}
+
+	statusCode, _ = do[interface{}](t, "DELETE", path+"?replacementId="+uuidToString(third.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	count, current := countDefaultColumns(t, boardID, tokens.AccessToken)
+	if count != 1 || current.ID != third.ID {
+		t.Fatalf("expected the replacement to become default, got %d defaults", count)
+	}
+
+	// other columns go without a replacement
+	statusCode, _ = do[interface{}](t, "DELETE", "/boards/"+boardID+"/columns/"+uuidToString(second.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204 for a column that is not the default, got %d", statusCode)
+	}
+}
+
+func TestBoardColumn_Default_ColumnFromDifferentBoard(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board1 := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	board2 := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	other := createBoardColumn(t, uuidToString(board2.ID), tokens.AccessToken, randomBoardColumnName())
+
+	statusCode, _ = do[domain.BoardColumnModel](t, "POST", "/boards/"+uuidToString(board1.ID)+"/columns/"+uuidToString(other.ID)+"/make-default", nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", statusCode)
+	}
+}
+
+func TestBoardColumn_Default_Unauthenticated(t *testing.T) {
+	boardID := "550e8400-e29b-41d4-a716-446655440000"
+	columnID := "550e8400-e29b-41d4-a716-446655440001"
+
+	statusCode, _ := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns/"+columnID+"/make-default", nil, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
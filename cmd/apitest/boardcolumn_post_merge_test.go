@@ -0,0 +1,128 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestBoardColumn_Merge_Success(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	source := createBoardColumn(t, boardID, tokens.AccessToken, "Review")
+	middle := createBoardColumn(t, boardID, tokens.AccessToken, "Testing")
+	target := createBoardColumn(t, boardID, tokens.AccessToken, "Done")
+
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+	statusCode, _ = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(ticket.ID)+"/move-board-column", domain.TicketBoardMoveModel{
+		BoardID:       board.ID,
+		BoardColumnID: source.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("failed to move ticket: %d", statusCode)
+	}
+
+	statusCode, resp := do[domain.BoardColumnMergeModel](t, "POST", "/boards/"+boardID+"/columns/"+uuidToString(source.ID)+"/merge-into/"+uuidToString(target.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data.MovedTickets != 1 {
+		t.Fatalf("expected 1 moved ticket, got %d", resp.Data.MovedTickets)
+	}
+	if resp.Data.Target.ID != target.ID || resp.Data.Target.Position != 1024 {
+		t.Fatalf("expected target at position 1024, got %v at %d", resp.Data.Target.ID, resp.Data.Target.Position)
+	}
+
+	// the source was the board's first, and so default, column
+	if !resp.Data.Target.IsDefault {
+		t.Fatal("expected target to inherit the default flag")
+	}
+
+	moved := getTicket(t, uuidToString(ticket.ID), tokens.AccessToken)
+	if moved.BoardColumnID != target.ID {
+		t.Fatalf("expected ticket in target column, got %v", moved.BoardColumnID)
+	}
+
+	statusCode, list := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, list.Error)
+	}
+	if len(list.Data.Items) != 2 {
+		t.Fatalf("expected 2 columns after merge, got %d", len(list.Data.Items))
+	}
+	if list.Data.Items[0].ID != middle.ID || list.Data.Items[0].Position != 0 {
+		t.Fatalf("expected remaining columns to be compacted, got %v at %d", list.Data.Items[0].ID, list.Data.Items[0].Position)
+	}
+}
+
+func TestBoardColumn_Merge_IntoSelf(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+	column := createBoardColumn(t, boardID, tokens.AccessToken, randomBoardColumnName())
+
+	statusCode, _ = do[domain.BoardColumnMergeModel](t, "POST", "/boards/"+boardID+"/columns/"+uuidToString(column.ID)+"/merge-into/"+uuidToString(column.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
+
+func TestBoardColumn_Merge_TargetFromDifferentBoard(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	board1 := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	board2 := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+
+	source := createBoardColumn(t, uuidToString(board1.ID), tokens.AccessToken, randomBoardColumnName())
+	other := createBoardColumn(t, uuidToString(board2.ID), tokens.AccessToken, randomBoardColumnName())
+
+	statusCode, _ = do[domain.BoardColumnMergeModel](t, "POST", "/boards/"+uuidToString(board1.ID)+"/columns/"+uuidToString(source.ID)+"/merge-into/"+uuidToString(other.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", statusCode)
+	}
+}
+
+func TestBoardColumn_Merge_Unauthenticated(t *testing.T) {
+	boardID := "550e8400-e29b-41d4-a716-446655440000"
+	sourceID := "550e8400-e29b-41d4-a716-446655440001"
+	targetID := "550e8400-e29b-41d4-a716-446655440002"
+
+	statusCode, _ := do[domain.BoardColumnMergeModel](t, "POST", "/boards/"+boardID+"/columns/"+sourceID+"/merge-into/"+targetID, nil, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
@@ -0,0 +1,112 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	ticketservice "github.com/dimasbaguspm/fluxis/internal/ticket/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestBoardColumn_ColorAndWIPLimit(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	boardID := uuidToString(createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName()).ID)
+
+	limit := int32(3)
+	statusCode, created := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Doing",
+		Color:    "#22c55e",
+		WIPLimit: &limit,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, created.Error)
+	}
+	if created.Data.Color != "#22c55e" || created.Data.WIPLimit == nil || *created.Data.WIPLimit != 3 {
+		t.Fatalf("expected color #22c55e and wipLimit 3, got %q and %v", created.Data.Color, created.Data.WIPLimit)
+	}
+	columnURL := "/boards/" + boardID + "/columns/" + uuidToString(created.Data.ID)
+
+	statusCode, _ = do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:  "Review",
+		Color: "green",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a color that is not hex, got %d", statusCode)
+	}
+
+	// a rename keeps the color and limit, a zero limit removes it
+	statusCode, updated := do[domain.BoardColumnModel](t, "PATCH", columnURL, domain.BoardColumnUpdateModel{
+		Name: "In progress",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || updated.Data.Color != "#22c55e" || updated.Data.WIPLimit == nil {
+		t.Fatalf("expected color and limit to be kept, got %d %+v", statusCode, updated.Data)
+	}
+
+	zero := int32(0)
+	statusCode, updated = do[domain.BoardColumnModel](t, "PATCH", columnURL, domain.BoardColumnUpdateModel{
+		WIPLimit: &zero,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || updated.Data.WIPLimit != nil {
+		t.Fatalf("expected the limit to be removed, got %d %+v", statusCode, updated.Data)
+	}
+}
+
+func TestTicket_MoveToBoardColumn_WIPLimit(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	limit := int32(1)
+	statusCode, column := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Doing",
+		WIPLimit: &limit,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d", statusCode)
+	}
+	move := domain.TicketBoardMoveModel{BoardID: board.ID, BoardColumnID: column.Data.ID}
+
+	first := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	second := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	third := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+
+	statusCode, _ = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(first.ID)+"/move-board-column", move, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200 below the limit, got %d", statusCode)
+	}
+
+	// the default mode lets the move through
+	statusCode, _ = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(second.ID)+"/move-board-column", move, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200 in warn mode, got %d", statusCode)
+	}
+
+	testTicketConfig.WIPLimit = ticketservice.WIPLimitReject
+	defer func() { testTicketConfig.WIPLimit = "" }()
+
+	statusCode, resp := do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(third.ID)+"/move-board-column", move, tokens.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity || resp.Error == nil || resp.Error.Code != "wip_limit_reached" {
+		t.Fatalf("expected status 422 wip_limit_reached, got %d: %v", statusCode, resp.Error)
+	}
+}
@@ -0,0 +1,221 @@
+package apitest_test
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+type caldavSetup struct {
+	email    string
+	password string
+	token    string
+	project  domain.ProjectModel
+	board    domain.BoardModel
+	open     domain.BoardColumnModel
+	done     domain.BoardColumnModel
+	ticket   domain.TicketModel
+}
+
+// setupCaldav creates a project whose board has an open and a done column,
+// with one dated ticket sitting in the open column
+func setupCaldav(tb testing.TB) caldavSetup {
+	s := caldavSetup{email: randomEmail(), password: "SecurePassword123!"}
+	s.token = register(tb, s.email, "Test User", s.password).AccessToken
+
+	statusCode, orgResp := do[domain.OrganisationModel](tb, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, s.token)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		tb.Fatalf("failed to create org")
+	}
+	s.project = createProject(tb, uuidToString(orgResp.Data.ID), s.token, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(s.project.ID)
+
+	sprint := createSprint(tb, projectID, s.token, randomSprintName())
+	s.board = createBoard(tb, uuidToString(sprint.ID), s.token, randomBoardName())
+	boardID := uuidToString(s.board.ID)
+	s.open = createBoardColumn(tb, boardID, s.token, randomBoardColumnName())
+
+	statusCode, colResp := do[domain.BoardColumnModel](tb, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Done",
+		Category: "done",
+	}, s.token)
+	if statusCode != http.StatusCreated || colResp.Data == nil {
+		tb.Fatalf("failed to create done column: %d", statusCode)
+	}
+	s.done = *colResp.Data
+
+	statusCode, ticketResp := do[domain.TicketModel](tb, "POST", "/tickets?projectId="+projectID, domain.TicketCreateModel{
+		Title:    "Renew certificates",
+		Type:     "task",
+		Priority: "high",
+		DueDate:  time.Date(2030, 3, 14, 0, 0, 0, 0, time.UTC),
+	}, s.token)
+	if statusCode != http.StatusCreated || ticketResp.Data == nil {
+		tb.Fatalf("failed to create ticket: %d %v", statusCode, ticketResp.Error)
+	}
+	s.ticket = moveTicketToColumn(tb, uuidToString(ticketResp.Data.ID), s.token, s.board.ID, s.open.ID)
+	return s
+}
+
+func (s caldavSetup) todoPath() string {
+	return "/caldav/" + uuidToString(s.project.ID) + "/" + uuidToString(s.ticket.ID) + ".ics"
+}
+
+// dav sends a WebDAV request the way a calendar client does, with Basic auth
+func dav(tb testing.TB, method, path, body, email, password string, header map[string]string) (*http.Response, string) {
+	req, err := http.NewRequest(method, testServer.URL+path, strings.NewReader(body))
+	if err != nil {
+		tb.Fatalf("failed to create request: %v", err)
+	}
+	if email != "" {
+		req.SetBasicAuth(email, password)
+	}
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		tb.Fatalf("failed to perform request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		tb.Fatalf("failed to read response body: %v", err)
+	}
+	return resp, string(respBody)
+}
+
+const calendarQuery = `<?xml version="1.0" encoding="utf-8"?>
+<c:calendar-query xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav">
+  <d:prop><d:getetag/><c:calendar-data/></d:prop>
+  <c:filter><c:comp-filter name="VCALENDAR"><c:comp-filter name="VTODO"/></c:comp-filter></c:filter>
+</c:calendar-query>`
+
+func TestCaldav_Discovery(t *testing.T) {
+	s := setupCaldav(t)
+
+	resp, _ := dav(t, "OPTIONS", "/caldav/", "", "", "", nil)
+	if !strings.Contains(resp.Header.Get("DAV"), "calendar-access") {
+		t.Fatalf("expected calendar-access in DAV header, got %q", resp.Header.Get("DAV"))
+	}
+
+	resp, body := dav(t, "PROPFIND", "/caldav/", "", s.email, s.password, map[string]string{"Depth": "1"})
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("expected status 207, got %d: %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(body, "/caldav/"+uuidToString(s.project.ID)+"/") {
+		t.Fatalf("expected the project calendar in the home listing: %s", body)
+	}
+
+	resp, body = dav(t, "PROPFIND", "/caldav/"+uuidToString(s.project.ID)+"/", "", s.email, s.password, map[string]string{"Depth": "1"})
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("expected status 207, got %d: %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(body, s.todoPath()) || !strings.Contains(body, `<c:comp name="VTODO"/>`) {
+		t.Fatalf("expected a VTODO calendar listing the ticket: %s", body)
+	}
+}
+
+func TestCaldav_QueryAndGet(t *testing.T) {
+	s := setupCaldav(t)
+
+	resp, body := dav(t, "REPORT", "/caldav/"+uuidToString(s.project.ID)+"/", calendarQuery, s.email, s.password, map[string]string{"Depth": "1"})
+	if resp.StatusCode != http.StatusMultiStatus {
+		t.Fatalf("expected status 207, got %d: %s", resp.StatusCode, body)
+	}
+	if !strings.Contains(body, "UID:"+uuidToString(s.ticket.ID)) || !strings.Contains(body, "STATUS:NEEDS-ACTION") {
+		t.Fatalf("expected the open ticket as a VTODO: %s", body)
+	}
+
+	resp, body = dav(t, "GET", s.todoPath(), "", s.email, s.password, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", resp.StatusCode, body)
+	}
+	if !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/calendar") || resp.Header.Get("ETag") == "" {
+		t.Fatalf("unexpected headers %v", resp.Header)
+	}
+	if !strings.Contains(body, "DUE;VALUE=DATE:20300314") || !strings.Contains(body, "Renew certificates") {
+		t.Fatalf("unexpected calendar object: %s", body)
+	}
+}
+
+func TestCaldav_CompleteAndReopen(t *testing.T) {
+	s := setupCaldav(t)
+
+	resp, body := dav(t, "GET", s.todoPath(), "", s.email, s.password, nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", resp.StatusCode, body)
+	}
+	etag := resp.Header.Get("ETag")
+
+	completed := strings.Replace(body, "STATUS:NEEDS-ACTION", "STATUS:COMPLETED", 1)
+	resp, body = dav(t, "PUT", s.todoPath(), completed, s.email, s.password, map[string]string{"If-Match": etag})
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", resp.StatusCode, body)
+	}
+	if resp.Header.Get("ETag") == "" || resp.Header.Get("ETag") == etag {
+		t.Fatal("expected a new ETag after the update")
+	}
+
+	ticket := getTicket(t, uuidToString(s.ticket.ID), s.token)
+	if ticket.BoardColumnID != s.done.ID {
+		t.Fatal("expected the completed ticket in the done column")
+	}
+	if ticket.Title != s.ticket.Title {
+		t.Fatalf("expected the title to survive the round trip, got %q", ticket.Title)
+	}
+
+	// the ETag read before completing is stale now
+	resp, _ = dav(t, "PUT", s.todoPath(), completed, s.email, s.password, map[string]string{"If-Match": etag})
+	if resp.StatusCode != http.StatusPreconditionFailed {
+		t.Fatalf("expected status 412, got %d", resp.StatusCode)
+	}
+
+	resp, body = dav(t, "PUT", s.todoPath(), strings.Replace(completed, "STATUS:COMPLETED", "STATUS:NEEDS-ACTION", 1), s.email, s.password, nil)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", resp.StatusCode, body)
+	}
+	if ticket := getTicket(t, uuidToString(s.ticket.ID), s.token); ticket.BoardColumnID != s.open.ID {
+		t.Fatal("expected the reopened ticket back in the open column")
+	}
+}
+
+func TestCaldav_PutUnknownTodo(t *testing.T) {
+	s := setupCaldav(t)
+
+	path := "/caldav/" + uuidToString(s.project.ID) + "/" + randomString(8) + ".ics"
+	resp, _ := dav(t, "PUT", path, "BEGIN:VCALENDAR\r\nBEGIN:VTODO\r\nSUMMARY:New\r\nEND:VTODO\r\nEND:VCALENDAR\r\n", s.email, s.password, nil)
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", resp.StatusCode)
+	}
+}
+
+func TestCaldav_Auth(t *testing.T) {
+	s := setupCaldav(t)
+
+	resp, _ := dav(t, "PROPFIND", "/caldav/", "", "", "", nil)
+	if resp.StatusCode != http.StatusUnauthorized || resp.Header.Get("WWW-Authenticate") == "" {
+		t.Fatalf("expected a Basic challenge, got %d", resp.StatusCode)
+	}
+
+	resp, _ = dav(t, "PROPFIND", "/caldav/", "", s.email, "wrong password", nil)
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", resp.StatusCode)
+	}
+
+	// another account can not see the project's calendar
+	other := randomEmail()
+	register(t, other, "Other User", s.password)
+	resp, _ = dav(t, "GET", s.todoPath(), "", other, s.password, nil)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", resp.StatusCode)
+	}
+}
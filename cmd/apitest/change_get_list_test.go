@@ -0,0 +1,199 @@
+package apitest_test
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestChange_ProjectChanges_SinceCursor(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	path := "/projects/" + projectID + "/changes"
+
+	statusCode, resp := do[domain.ProjectChangesModel](t, "GET", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	start := resp.Data.Cursor
+	if len(resp.Data.TicketIDs) != 0 {
+		t.Fatalf("expected no ticket ids without since, got %v", resp.Data.TicketIDs)
+	}
+
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	column := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, randomBoardColumnName())
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "high")
+
+	statusCode, resp = do[domain.ProjectChangesModel](t, "GET", path+"?since="+strconv.FormatInt(start, 10), nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Cursor <= start {
+		t.Fatalf("expected cursor to advance past %d, got %d", start, resp.Data.Cursor)
+	}
+	if !slices.Contains(resp.Data.TicketIDs, ticket.ID) {
+		t.Fatalf("expected ticket %s in %v", uuidToString(ticket.ID), resp.Data.TicketIDs)
+	}
+	if !slices.Contains(resp.Data.BoardColumnIDs, column.ID) {
+		t.Fatalf("expected column %s in %v", uuidToString(column.ID), resp.Data.BoardColumnIDs)
+	}
+
+	// Nothing happened since the new cursor
+	cursor := strconv.FormatInt(resp.Data.Cursor, 10)
+	statusCode, resp = do[domain.ProjectChangesModel](t, "GET", path+"?since="+cursor, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.TicketIDs) != 0 || len(resp.Data.BoardColumnIDs) != 0 {
+		t.Fatalf("expected no changes, got %+v", resp.Data)
+	}
+}
+
+func TestChange_ProjectChanges_WaitsForChange(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	path := "/projects/" + projectID + "/changes"
+
+	statusCode, resp := do[domain.ProjectChangesModel](t, "GET", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	cursor := strconv.FormatInt(resp.Data.Cursor, 10)
+
+	type pollResult struct {
+		statusCode int
+		resp       apiResponse[domain.ProjectChangesModel]
+	}
+	polled := make(chan pollResult, 1)
+	go func() {
+		statusCode, resp := do[domain.ProjectChangesModel](t, "GET", path+"?since="+cursor+"&wait=10", nil, tokens.AccessToken)
+		polled <- pollResult{statusCode, resp}
+	}()
+
+	// The poll is already waiting when the ticket lands
+	time.Sleep(500 * time.Millisecond)
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "high")
+
+	var result pollResult
+	select {
+	case result = <-polled:
+	case <-time.After(15 * time.Second):
+		t.Fatalf("poll did not return")
+	}
+	if result.statusCode != http.StatusOK || result.resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", result.statusCode, result.resp.Error)
+	}
+	if !slices.Contains(result.resp.Data.TicketIDs, ticket.ID) {
+		t.Fatalf("expected ticket %s in %v", uuidToString(ticket.ID), result.resp.Data.TicketIDs)
+	}
+}
+
+func TestChange_ProjectChanges_InvalidSince(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	statusCode, _ = do[domain.ProjectChangesModel](t, "GET", "/projects/"+uuidToString(project.ID)+"/changes?since=abc", nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
+
+func TestChange_Feed_OrderedAcrossEntities(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, resp := do[domain.ChangeFeedModel](t, "GET", "/changes", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 0 {
+		t.Fatalf("expected no items without after, got %d", len(resp.Data.Items))
+	}
+	after := strconv.FormatInt(resp.Data.Cursor, 10)
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+	ticket := createTicket(t, uuidToString(project.ID), tokens.AccessToken, randomTicketTitle(), "task", "high")
+
+	// Someone else's writes never show up in this feed
+	other := register(t, randomEmail(), "Other User", "SecurePassword123!")
+	statusCode, otherOrg := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, other.AccessToken)
+	if statusCode != http.StatusCreated || otherOrg.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	otherProject := createProject(t, uuidToString(otherOrg.Data.ID), other.AccessToken, randomProjectKey(), "Other Project", "private")
+
+	var items []domain.ChangeModel
+	for {
+		statusCode, resp = do[domain.ChangeFeedModel](t, "GET", "/changes?limit=2&after="+after, nil, tokens.AccessToken)
+		if statusCode != http.StatusOK || resp.Data == nil {
+			t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+		}
+		items = append(items, resp.Data.Items...)
+		after = strconv.FormatInt(resp.Data.Cursor, 10)
+		if !resp.Data.HasMore {
+			break
+		}
+	}
+
+	indexOf := func(entity string, id pgtype.UUID) int {
+		return slices.IndexFunc(items, func(c domain.ChangeModel) bool {
+			return c.Entity == entity && c.EntityID == id && c.Op == "insert"
+		})
+	}
+	p, s, tk := indexOf("project", project.ID), indexOf("sprint", sprint.ID), indexOf("ticket", ticket.ID)
+	if p < 0 || s < 0 || tk < 0 {
+		t.Fatalf("expected project, sprint and ticket inserts in %+v", items)
+	}
+	if !(p < s && s < tk) {
+		t.Fatalf("expected inserts in commit order, got project %d, sprint %d, ticket %d", p, s, tk)
+	}
+	for i, c := range items {
+		if c.ProjectID == otherProject.ID {
+			t.Fatalf("feed leaked a change of another user's project: %+v", c)
+		}
+		if i > 0 && c.Seq <= items[i-1].Seq {
+			t.Fatalf("expected increasing seq, got %d after %d", c.Seq, items[i-1].Seq)
+		}
+	}
+}
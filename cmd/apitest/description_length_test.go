@@ -0,0 +1,92 @@
+package apitest_test
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProject_DescriptionTooLong(t *testing.T) {
+	testProjectConfig.MaxDescriptionLength = 20
+	defer func() { testProjectConfig.MaxDescriptionLength = 0 }()
+
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	// Limits count characters, so 20 multi-byte runes still fit
+	statusCode, resp := do[domain.ProjectModel](t, "POST", "/projects?orgId="+uuidToString(orgResp.Data.ID), domain.ProjectCreateModel{
+		Key:         randomProjectKey(),
+		Name:        "Test Project",
+		Description: strings.Repeat("é", 20),
+		Visibility:  "private",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || resp.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+
+	statusCode, resp = do[domain.ProjectModel](t, "PATCH", "/projects/"+uuidToString(resp.Data.ID), domain.ProjectUpdateModel{
+		Name:        "Test Project",
+		Description: strings.Repeat("a", 25),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
+	}
+	assertTooLong(t, resp.Error, 20, 25)
+}
+
+func TestTicket_DescriptionTooLong(t *testing.T) {
+	testTicketConfig.MaxDescriptionLength = 20
+	defer func() { testTicketConfig.MaxDescriptionLength = 0 }()
+
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	statusCode, resp := do[domain.TicketModel](t, "POST", "/tickets?projectId="+uuidToString(project.ID), domain.TicketCreateModel{
+		Title:       randomTicketTitle(),
+		Type:        "task",
+		Priority:    "high",
+		Description: strings.Repeat("a", 30),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
+	}
+	assertTooLong(t, resp.Error, 20, 30)
+
+	ticket := createTicket(t, uuidToString(project.ID), tokens.AccessToken, randomTicketTitle(), "task", "high")
+	statusCode, resp = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(ticket.ID), domain.TicketUpdateModel{
+		Description: strings.Repeat("a", 21),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
+	}
+	assertTooLong(t, resp.Error, 20, 21)
+}
+
+func assertTooLong(tb testing.TB, e *apiError, maxLength, length int) {
+	tb.Helper()
+	if e == nil || e.Code != "too_long" {
+		tb.Fatalf("expected too_long, got %v", e)
+	}
+	if e.Details["field"] != "description" {
+		tb.Fatalf("expected field description, got %v", e.Details["field"])
+	}
+	// JSON numbers decode as float64
+	if e.Details["maxLength"] != float64(maxLength) || e.Details["length"] != float64(length) || e.Details["overBy"] != float64(length-maxLength) {
+		tb.Fatalf("unexpected length details: %v", e.Details)
+	}
+}
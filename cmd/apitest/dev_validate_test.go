@@ -0,0 +1,65 @@
+package apitest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/api"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestDevValidate(t *testing.T) {
+	statusCode, resp := do[domain.DevValidateResultModel](t, "POST", "/dev/validate", domain.DevValidateModel{
+		OperationID: "POST /projects",
+		Body:        json.RawMessage(`{"key":"ABC","name":"Valid Project","visibility":"private"}`),
+	}, "")
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if !resp.Data.Valid || resp.Data.Model != "domain.ProjectCreateModel" {
+		t.Fatalf("expected a valid project body, got %+v", resp.Data)
+	}
+
+	statusCode, resp = do[domain.DevValidateResultModel](t, "POST", "/dev/validate", domain.DevValidateModel{
+		OperationID: "post /projects",
+		Body:        json.RawMessage(`{"key":"ABC","visibility":"private","owner":"me"}`),
+	}, "")
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Valid || resp.Data.Status != http.StatusBadRequest || resp.Data.Message == "" {
+		t.Fatalf("expected the unknown field to be reported, got %+v", resp.Data)
+	}
+
+	statusCode, resp = do[domain.DevValidateResultModel](t, "POST", "/dev/validate", domain.DevValidateModel{
+		OperationID: "POST /nowhere",
+	}, "")
+	if statusCode != http.StatusNotFound || resp.Error == nil || resp.Error.Code != "unknown_operation" {
+		t.Fatalf("expected 404 unknown_operation, got %d: %+v", statusCode, resp.Error)
+	}
+}
+
+// every body the spec documents has a model to validate against
+func TestDevValidate_CoversSpec(t *testing.T) {
+	var doc struct {
+		Paths map[string]map[string]json.RawMessage `json:"paths"`
+	}
+	if err := json.Unmarshal(api.Spec, &doc); err != nil {
+		t.Fatalf("failed to read spec: %v", err)
+	}
+
+	for path, methods := range doc.Paths {
+		for method := range methods {
+			op := strings.ToUpper(method) + " " + path
+			statusCode, resp := do[domain.DevValidateResultModel](t, "POST", "/dev/validate", domain.DevValidateModel{
+				OperationID: op,
+				Body:        json.RawMessage(`{}`),
+			}, "")
+			if statusCode != http.StatusOK {
+				t.Errorf("%s: expected status 200, got %d: %v", op, statusCode, resp.Error)
+			}
+		}
+	}
+}
@@ -0,0 +1,87 @@
+package apitest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestDocs_RequiresAdmin(t *testing.T) {
+	email := randomEmail()
+	register(t, email, "Regular User", "SecurePassword123!")
+
+	resp, _ := dav(t, "GET", "/docs/doc.json", "", "", "", nil)
+	if resp.StatusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", resp.StatusCode)
+	}
+	if !strings.HasPrefix(resp.Header.Get("WWW-Authenticate"), "Basic") {
+		t.Fatalf("expected a Basic challenge, got %q", resp.Header.Get("WWW-Authenticate"))
+	}
+
+	resp, _ = dav(t, "GET", "/docs/doc.json", "", email, "SecurePassword123!", nil)
+	if resp.StatusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a regular user, got %d", resp.StatusCode)
+	}
+}
+
+func TestDocs_ServesSpecAndUI(t *testing.T) {
+	adminTokens(t)
+
+	resp, body := dav(t, "GET", "/docs/doc.json", "", testAdminEmail, "SecurePassword123!", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var spec struct {
+		Swagger string         `json:"swagger"`
+		Paths   map[string]any `json:"paths"`
+	}
+	if err := json.Unmarshal([]byte(body), &spec); err != nil {
+		t.Fatalf("expected a JSON spec: %v", err)
+	}
+	if spec.Swagger == "" || spec.Paths["/projects"] == nil {
+		t.Fatalf("expected the generated spec, got version %q with %d paths", spec.Swagger, len(spec.Paths))
+	}
+
+	resp, body = dav(t, "GET", "/docs/index.html", "", testAdminEmail, "SecurePassword123!", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, "/docs/doc.json") {
+		t.Fatalf("expected the docs page pointing at the spec, got %d", resp.StatusCode)
+	}
+}
+
+func TestDocs_ExportsOpenAPI(t *testing.T) {
+	adminTokens(t)
+
+	resp, body := dav(t, "GET", "/openapi.json", "", testAdminEmail, "SecurePassword123!", nil)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	var spec struct {
+		OpenAPI string `json:"openapi"`
+		Paths   map[string]map[string]struct {
+			OperationID string `json:"operationId"`
+		} `json:"paths"`
+		Components struct {
+			Schemas map[string]any `json:"schemas"`
+		} `json:"components"`
+	}
+	if err := json.Unmarshal([]byte(body), &spec); err != nil {
+		t.Fatalf("expected a JSON spec: %v", err)
+	}
+	if !strings.HasPrefix(spec.OpenAPI, "3.0") || len(spec.Components.Schemas) == 0 {
+		t.Fatalf("expected an OpenAPI 3.0 spec, got version %q", spec.OpenAPI)
+	}
+	if id := spec.Paths["/projects"]["get"].OperationID; id != "listProjects" {
+		t.Fatalf("expected a stable operationId for GET /projects, got %q", id)
+	}
+
+	resp, body = dav(t, "GET", "/openapi.json?version=2.0", "", testAdminEmail, "SecurePassword123!", nil)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(body, `"swagger": "2.0"`) {
+		t.Fatalf("expected the swagger 2.0 spec, got %d", resp.StatusCode)
+	}
+
+	resp, body = dav(t, "GET", "/openapi.json?version=3.1", "", testAdminEmail, "SecurePassword123!", nil)
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "unsupported_version") {
+		t.Fatalf("expected 400 unsupported_version, got %d: %s", resp.StatusCode, body)
+	}
+}
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/jackc/pgx/v5/pgtype"
@@ -21,8 +23,9 @@ type apiResponse[T any] struct {
 }
 
 type apiError struct {
-	Code    string `json:"code"`
-	Message string `json:"message"`
+	Code    string         `json:"code"`
+	Message string         `json:"message"`
+	Details map[string]any `json:"details"`
 }
 
 func (r *apiResponse[T]) UnmarshalJSON(b []byte) error {
@@ -117,6 +120,48 @@ func register(tb testing.TB, email, displayName, password string) domain.AuthMod
 	return *resp.Data
 }
 
+// assertTimestamps checks the raw response encodes createdAt/updatedAt as
+// RFC3339 and carries a null deletedAt for a live resource
+func assertTimestamps(tb testing.TB, data map[string]any) {
+	for _, key := range []string{"createdAt", "updatedAt"} {
+		raw, ok := data[key].(string)
+		if !ok {
+			tb.Fatalf("expected %s to be a string, got %T", key, data[key])
+		}
+		if _, err := time.Parse(time.RFC3339Nano, raw); err != nil {
+			tb.Fatalf("expected %s to be RFC3339, got %q", key, raw)
+		}
+	}
+	deletedAt, ok := data["deletedAt"]
+	if !ok {
+		tb.Fatal("expected deletedAt to be present")
+	}
+	if deletedAt != nil {
+		tb.Fatalf("expected deletedAt to be null, got %v", deletedAt)
+	}
+}
+
+var registerAdminOnce sync.Once
+
+// adminTokens logs in as testAdminEmail, registering the account on first use
+func adminTokens(tb testing.TB) domain.AuthModel {
+	const password = "SecurePassword123!"
+	registerAdminOnce.Do(func() {
+		register(tb, testAdminEmail, "Admin User", password)
+	})
+
+	statusCode, resp := do[domain.AuthModel](tb, "POST", "/auth/login", domain.AuthLoginModel{
+		Email:    testAdminEmail,
+		Password: password,
+	}, "")
+
+	if statusCode != http.StatusOK || resp.Data == nil {
+		tb.Fatalf("admin login failed: got status %d, error: %v", statusCode, resp.Error)
+	}
+
+	return *resp.Data
+}
+
 // Random string generation
 func randomString(n int) string {
 	b := make([]byte, n)
@@ -0,0 +1,246 @@
+package apitest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+)
+
+func setupIntegrationProject(tb testing.TB) (string, domain.ProjectModel) {
+	tokens := register(tb, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](tb, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		tb.Fatalf("failed to create org")
+	}
+
+	project := createProject(tb, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	return tokens.AccessToken, project
+}
+
+func createIntegration(tb testing.TB, projectID, token string, mapping domain.InboundMappingModel) domain.InboundIntegrationModel {
+	statusCode, resp := do[domain.InboundIntegrationModel](tb, "POST", "/projects/"+projectID+"/integrations", domain.InboundIntegrationCreateModel{
+		Name:    "Integration " + randomString(4),
+		Mapping: mapping,
+	}, token)
+	if statusCode != http.StatusCreated || resp.Data == nil {
+		tb.Fatalf("failed to create integration: %d %v", statusCode, resp.Error)
+	}
+	return *resp.Data
+}
+
+// deliver posts payload the way a third party would, signed and without a token
+func deliver(tb testing.TB, integrationID, signature string, body []byte) (int, apiResponse[domain.InboundDeliveryModel]) {
+	req, err := http.NewRequest("POST", testServer.URL+"/inbound/webhooks/"+integrationID, bytes.NewReader(body))
+	if err != nil {
+		tb.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if signature != "" {
+		req.Header.Set(webhook.SignatureHeader, signature)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		tb.Fatalf("failed to perform request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		tb.Fatalf("failed to read response body: %v", err)
+	}
+	var result apiResponse[domain.InboundDeliveryModel]
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		tb.Fatalf("failed to unmarshal response %s: %v", respBody, err)
+	}
+	return resp.StatusCode, result
+}
+
+func signedDelivery(tb testing.TB, integration domain.InboundIntegrationModel, payload any) (int, apiResponse[domain.InboundDeliveryModel]) {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		tb.Fatalf("failed to marshal payload: %v", err)
+	}
+	return deliver(tb, uuidToString(integration.ID), webhook.Sign(integration.Secret, time.Now(), body), body)
+}
+
+var createMapping = domain.InboundMappingModel{
+	Action: domain.InboundActionCreate,
+	Fields: map[string]string{
+		"title":       "issue.title",
+		"description": "issue.body",
+		"storyPoints": "issue.estimate",
+		"priority":    "issue.labels.0",
+	},
+	Defaults: map[string]string{"type": "bug", "priority": "medium"},
+}
+
+func TestIntegration_Inbound_CreatesTicket(t *testing.T) {
+	token, project := setupIntegrationProject(t)
+	integration := createIntegration(t, uuidToString(project.ID), token, createMapping)
+	if integration.Secret == "" {
+		t.Fatal("expected the secret in the create response")
+	}
+
+	statusCode, resp := signedDelivery(t, integration, map[string]any{
+		"issue": map[string]any{
+			"title":    "Checkout button does nothing",
+			"body":     "Reported through the support form",
+			"estimate": 3,
+			"labels":   []string{"high"},
+		},
+	})
+	if statusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+
+	ticket := resp.Data.Ticket
+	if ticket.Title != "Checkout button does nothing" || ticket.Description != "Reported through the support form" {
+		t.Fatalf("unexpected ticket text %q / %q", ticket.Title, ticket.Description)
+	}
+	if ticket.Type != "bug" || ticket.Priority != "high" || ticket.StoryPoints != 3 {
+		t.Fatalf("unexpected ticket fields type=%s priority=%s points=%d", ticket.Type, ticket.Priority, ticket.StoryPoints)
+	}
+	if ticket.ReporterID != integration.CreatedBy {
+		t.Fatal("expected the integration owner as reporter")
+	}
+
+	// the default fills in for a payload without labels
+	statusCode, resp = signedDelivery(t, integration, map[string]any{"issue": map[string]any{"title": "No labels"}})
+	if statusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Ticket.Priority != "medium" {
+		t.Fatalf("expected default priority, got %s", resp.Data.Ticket.Priority)
+	}
+
+	statusCode, list := do[[]domain.InboundIntegrationModel](t, "GET", "/projects/"+uuidToString(project.ID)+"/integrations", nil, token)
+	if statusCode != http.StatusOK || len(*list.Data) != 1 {
+		t.Fatalf("expected one integration, got %d: %v", statusCode, list.Error)
+	}
+	if got := (*list.Data)[0]; got.Secret != "" || got.LastDeliveryAt == nil {
+		t.Fatalf("expected no secret and a delivery time, got %+v", got)
+	}
+}
+
+func TestIntegration_Inbound_UpdatesTicketByKey(t *testing.T) {
+	token, project := setupIntegrationProject(t)
+	ticket := createTicket(t, uuidToString(project.ID), token, randomTicketTitle(), "task", "low")
+
+	integration := createIntegration(t, uuidToString(project.ID), token, domain.InboundMappingModel{
+		Action:  domain.InboundActionUpdate,
+		KeyPath: "$.ticket.key",
+		Fields:  map[string]string{"description": "comment.text", "priority": "comment.priority"},
+	})
+
+	statusCode, resp := signedDelivery(t, integration, map[string]any{
+		"ticket":  map[string]any{"key": ticket.Key},
+		"comment": map[string]any{"text": "Customer followed up", "priority": "critical"},
+	})
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Ticket.ID != ticket.ID || resp.Data.Ticket.Description != "Customer followed up" || resp.Data.Ticket.Priority != "critical" {
+		t.Fatalf("unexpected ticket %+v", resp.Data.Ticket)
+	}
+	if resp.Data.Ticket.Title != ticket.Title {
+		t.Fatal("expected unmapped fields to stay as they were")
+	}
+
+	statusCode, resp = signedDelivery(t, integration, map[string]any{
+		"ticket":  map[string]any{"key": "NOPE-999"},
+		"comment": map[string]any{"text": "Lost"},
+	})
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for an unknown key, got %d", statusCode)
+	}
+}
+
+func TestIntegration_Inbound_RejectsBadSignatures(t *testing.T) {
+	token, project := setupIntegrationProject(t)
+	integration := createIntegration(t, uuidToString(project.ID), token, createMapping)
+	id := uuidToString(integration.ID)
+	body := []byte(`{"issue":{"title":"Forged"}}`)
+
+	tests := []struct {
+		name      string
+		signature string
+		code      string
+	}{
+		{"missing", "", "signature_missing"},
+		{"wrong secret", webhook.Sign("whsec_guess", time.Now(), body), "signature_invalid"},
+		{"other body", webhook.Sign(integration.Secret, time.Now(), []byte(`{}`)), "signature_invalid"},
+		{"replayed", webhook.Sign(integration.Secret, time.Now().Add(-time.Hour), body), "signature_expired"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			statusCode, resp := deliver(t, id, tt.signature, body)
+			if statusCode != http.StatusUnauthorized {
+				t.Fatalf("expected status 401, got %d", statusCode)
+			}
+			if resp.Error == nil || resp.Error.Code != tt.code {
+				t.Fatalf("expected %s, got %v", tt.code, resp.Error)
+			}
+		})
+	}
+}
+
+func TestIntegration_Inbound_RejectsUnmappablePayload(t *testing.T) {
+	token, project := setupIntegrationProject(t)
+	integration := createIntegration(t, uuidToString(project.ID), token, createMapping)
+
+	statusCode, resp := signedDelivery(t, integration, map[string]any{"issue": map[string]any{"body": "No title"}})
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "payload_unmappable" {
+		t.Fatalf("expected payload_unmappable, got %v", resp.Error)
+	}
+}
+
+func TestIntegration_Create_RejectsInvalidMapping(t *testing.T) {
+	token, project := setupIntegrationProject(t)
+
+	mappings := []domain.InboundMappingModel{
+		{Action: domain.InboundActionCreate, Fields: map[string]string{"title": "t", "owner": "o"}, Defaults: map[string]string{"type": "bug", "priority": "low"}},
+		{Action: domain.InboundActionCreate, Fields: map[string]string{"title": "t"}},
+		{Action: domain.InboundActionUpdate, Fields: map[string]string{"title": "t"}},
+	}
+	for _, mapping := range mappings {
+		statusCode, resp := do[domain.InboundIntegrationModel](t, "POST", "/projects/"+uuidToString(project.ID)+"/integrations", domain.InboundIntegrationCreateModel{
+			Name:    "Broken",
+			Mapping: mapping,
+		}, token)
+		if statusCode != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422 for %+v, got %d", mapping, statusCode)
+		}
+		if resp.Error == nil || resp.Error.Code != "invalid_mapping" {
+			t.Fatalf("expected invalid_mapping, got %v", resp.Error)
+		}
+	}
+}
+
+func TestIntegration_Delete_StopsDeliveries(t *testing.T) {
+	token, project := setupIntegrationProject(t)
+	integration := createIntegration(t, uuidToString(project.ID), token, createMapping)
+
+	statusCode, _ := do[any](t, "DELETE", "/projects/"+uuidToString(project.ID)+"/integrations/"+uuidToString(integration.ID), nil, token)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	statusCode, _ = signedDelivery(t, integration, map[string]any{"issue": map[string]any{"title": "Too late"}})
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", statusCode)
+	}
+}
@@ -0,0 +1,111 @@
+package apitest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/jsonapi"
+)
+
+// getJSONAPI fetches path negotiating JSON:API and decodes the document
+func getJSONAPI(tb testing.TB, path, token string) (*http.Response, map[string]any) {
+	req, err := http.NewRequest("GET", testServer.URL+path, nil)
+	if err != nil {
+		tb.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Accept", jsonapi.MediaType)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		tb.Fatalf("failed to perform request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var doc map[string]any
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		tb.Fatalf("failed to decode document: %v", err)
+	}
+	return resp, doc
+}
+
+func TestJSONAPI_Project(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project "+randomString(6), "private")
+	projectID := uuidToString(project.ID)
+
+	resp, doc := getJSONAPI(t, "/projects/"+projectID, tokens.AccessToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", resp.StatusCode, doc)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != jsonapi.MediaType {
+		t.Fatalf("expected %s, got %q", jsonapi.MediaType, ct)
+	}
+
+	data := doc["data"].(map[string]any)
+	if data["type"] != "projects" || data["id"] != projectID {
+		t.Fatalf("unexpected resource identity %v", data)
+	}
+	if attrs := data["attributes"].(map[string]any); attrs["name"] != project.Name || attrs["status"] != "active" {
+		t.Fatalf("unexpected attributes %v", attrs)
+	}
+	org := data["relationships"].(map[string]any)["org"].(map[string]any)["data"].(map[string]any)
+	if org["type"] != "orgs" || org["id"] != orgID {
+		t.Fatalf("unexpected org relationship %v", org)
+	}
+}
+
+func TestJSONAPI_TicketCollection(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project "+randomString(6), "private")
+	projectID := uuidToString(project.ID)
+
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	resp, doc := getJSONAPI(t, "/tickets?projectId="+projectID+"&pageSize=1", tokens.AccessToken)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", resp.StatusCode, doc)
+	}
+
+	data := doc["data"].([]any)
+	if len(data) != 1 || data[0].(map[string]any)["type"] != "tickets" {
+		t.Fatalf("expected one ticket resource, got %v", data)
+	}
+	if meta := doc["meta"].(map[string]any); meta["totalCount"] != float64(2) || meta["hasMore"] != true {
+		t.Fatalf("unexpected meta %v", meta)
+	}
+	if links := doc["links"].(map[string]any); links["next"] == nil {
+		t.Fatalf("expected a next link, got %v", links)
+	}
+}
+
+func TestJSONAPI_Errors(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	resp, doc := getJSONAPI(t, "/projects/550e8400-e29b-41d4-a716-446655440000", tokens.AccessToken)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", resp.StatusCode)
+	}
+	errs, ok := doc["errors"].([]any)
+	if !ok || len(errs) != 1 || errs[0].(map[string]any)["status"] != "404" {
+		t.Fatalf("expected a JSON:API error document, got %v", doc)
+	}
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/netip"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/dimasbaguspm/fluxis/api"
 	"github.com/dimasbaguspm/fluxis/internal/auth"
 	authhandler "github.com/dimasbaguspm/fluxis/internal/auth/handler"
 	authservice "github.com/dimasbaguspm/fluxis/internal/auth/service"
@@ -44,18 +46,121 @@ import (
 	ticketrepo "github.com/dimasbaguspm/fluxis/internal/ticket/repository"
 	ticketservice "github.com/dimasbaguspm/fluxis/internal/ticket/service"
 
+	"github.com/dimasbaguspm/fluxis/internal/report"
+	reporthandler "github.com/dimasbaguspm/fluxis/internal/report/handler"
+	reportrepo "github.com/dimasbaguspm/fluxis/internal/report/repository"
+	reportservice "github.com/dimasbaguspm/fluxis/internal/report/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity"
+	activityhandler "github.com/dimasbaguspm/fluxis/internal/activity/handler"
+	activityrepo "github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	activityservice "github.com/dimasbaguspm/fluxis/internal/activity/service"
+	"github.com/dimasbaguspm/fluxis/internal/change"
+	changehandler "github.com/dimasbaguspm/fluxis/internal/change/handler"
+	changerepo "github.com/dimasbaguspm/fluxis/internal/change/repository"
+	changeservice "github.com/dimasbaguspm/fluxis/internal/change/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/attachment"
+	attachmenthandler "github.com/dimasbaguspm/fluxis/internal/attachment/handler"
+	attachmentrepo "github.com/dimasbaguspm/fluxis/internal/attachment/repository"
+	attachmentservice "github.com/dimasbaguspm/fluxis/internal/attachment/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/usage"
+	usagehandler "github.com/dimasbaguspm/fluxis/internal/usage/handler"
+	usagerepo "github.com/dimasbaguspm/fluxis/internal/usage/repository"
+	usageservice "github.com/dimasbaguspm/fluxis/internal/usage/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/notification"
+	notificationhandler "github.com/dimasbaguspm/fluxis/internal/notification/handler"
+	notificationrepo "github.com/dimasbaguspm/fluxis/internal/notification/repository"
+	notificationservice "github.com/dimasbaguspm/fluxis/internal/notification/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/integration"
+	integrationhandler "github.com/dimasbaguspm/fluxis/internal/integration/handler"
+	integrationrepo "github.com/dimasbaguspm/fluxis/internal/integration/repository"
+	integrationservice "github.com/dimasbaguspm/fluxis/internal/integration/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/caldav"
+	caldavhandler "github.com/dimasbaguspm/fluxis/internal/caldav/handler"
+	caldavrepo "github.com/dimasbaguspm/fluxis/internal/caldav/repository"
+	caldavservice "github.com/dimasbaguspm/fluxis/internal/caldav/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/admin"
+	adminhandler "github.com/dimasbaguspm/fluxis/internal/admin/handler"
+
+	"github.com/dimasbaguspm/fluxis/internal/apidocs"
+	apidocshandler "github.com/dimasbaguspm/fluxis/internal/apidocs/handler"
+
+	"github.com/dimasbaguspm/fluxis/internal/integrity"
+	integrityhandler "github.com/dimasbaguspm/fluxis/internal/integrity/handler"
+	integrityrepo "github.com/dimasbaguspm/fluxis/internal/integrity/repository"
+	integrityservice "github.com/dimasbaguspm/fluxis/internal/integrity/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/retention"
+	retentionhandler "github.com/dimasbaguspm/fluxis/internal/retention/handler"
+	retentionrepo "github.com/dimasbaguspm/fluxis/internal/retention/repository"
+	retentionservice "github.com/dimasbaguspm/fluxis/internal/retention/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/trash"
+	trashhandler "github.com/dimasbaguspm/fluxis/internal/trash/handler"
+	trashrepo "github.com/dimasbaguspm/fluxis/internal/trash/repository"
+	trashservice "github.com/dimasbaguspm/fluxis/internal/trash/service"
+
 	"github.com/dimasbaguspm/fluxis/internal/user"
 	usercache "github.com/dimasbaguspm/fluxis/internal/user/cache"
 	userhandler "github.com/dimasbaguspm/fluxis/internal/user/handler"
 	userrepo "github.com/dimasbaguspm/fluxis/internal/user/repository"
 	userservice "github.com/dimasbaguspm/fluxis/internal/user/service"
 
+	"github.com/dimasbaguspm/fluxis/pkg/blob"
 	"github.com/dimasbaguspm/fluxis/pkg/cache"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/ipfilter"
+	"github.com/dimasbaguspm/fluxis/pkg/recorder"
+	"github.com/dimasbaguspm/fluxis/pkg/lease"
+	"github.com/dimasbaguspm/fluxis/pkg/presence"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+	"github.com/dimasbaguspm/fluxis/pkg/webpush"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var testServer *httptest.Server
+// testAdminEmail is granted the admin scope on register
+const testAdminEmail = "admin@fluxis.test"
+
+// testAttachmentMaxSize is kept small so the size limit is cheap to hit
+const testAttachmentMaxSize = 64 << 10
+
+var (
+	testServer        *httptest.Server
+	testAuthConfig    authservice.Config
+	testProjectConfig projectservice.Config
+	testTicketConfig  ticketservice.Config
+
+	// testRetentionConfig starts with purging disabled, tests switch it on
+	testRetentionConfig retentionservice.Config
+
+	// testNotificationSvc is exposed so tests can switch push off
+	testNotificationSvc *notificationservice.Service
+
+	// testReportSvc lets tests take board snapshots without waiting for the
+	// snapshotter
+	testReportSvc *reportservice.Service
+
+	// testTicketSvc lets tests sweep stale tickets without waiting for the
+	// sweeper
+	testTicketSvc *ticketservice.Service
+
+	// testIPFilter guards /admin/ only, so rules set by one test can not
+	// block the rest of the suite
+	testIPFilter *ipfilter.Filter
+
+	testRecorder *recorder.Recorder
+
+	// testPool lets tests write rows the API never would, e.g. to give the
+	// integrity checker something to find
+	testPool *pgxpool.Pool
+)
 
 func TestMain(m *testing.M) {
 	ctx := context.Background()
@@ -69,6 +174,7 @@ func TestMain(m *testing.M) {
 
 	pool := MustPool(ctx, pgContainer.DSN)
 	defer pool.Close()
+	testPool = pool
 
 	var migrationsPath string
 	possiblePaths := []string{
@@ -93,12 +199,15 @@ func TestMain(m *testing.M) {
 		os.Exit(1)
 	}
 
-	authCfg := authservice.Config{
-		AccessTokenSecret:  "test-access-secret-32-chars-long-xxx",
-		RefreshTokenSecret: "test-refresh-secret-32-chars-long-xx",
-		AccessTokenExpiry:  1 * time.Minute,
-		RefreshTokenExpiry: 5 * time.Minute,
-		BcryptCost:         4,
+	testAuthConfig = authservice.Config{
+		AccessTokenSecret:          "test-access-secret-32-chars-long-xxx",
+		RefreshTokenSecret:         "test-refresh-secret-32-chars-long-xx",
+		PreviousAccessTokenSecret:  "test-old-access-secret-32-chars-xxxx",
+		PreviousRefreshTokenSecret: "test-old-refresh-secret-32-chars-xxx",
+		AccessTokenExpiry:          1 * time.Minute,
+		RefreshTokenExpiry:         5 * time.Minute,
+		BcryptCost:                 4,
+		AdminEmails:                []string{testAdminEmail},
 	}
 
 	userRepo := userrepo.New(pool)
@@ -107,6 +216,17 @@ func TestMain(m *testing.M) {
 	sprintRepo := sprintrepo.New(pool)
 	boardRepo := boardrepo.New(pool)
 	ticketRepo := ticketrepo.New(pool)
+	attachmentRepo := attachmentrepo.New(pool)
+	reportRepo := reportrepo.New(pool)
+	changeRepo := changerepo.New(pool)
+	activityRepo := activityrepo.New(pool)
+	usageRepo := usagerepo.New(pool)
+	integrationRepo := integrationrepo.New(pool)
+	caldavRepo := caldavrepo.New(pool)
+	notificationRepo := notificationrepo.New(pool)
+	integrityRepo := integrityrepo.New(pool)
+	retentionRepo := retentionrepo.New(pool)
+	trashRepo := trashrepo.New(pool)
 
 	bus := pubsub.New()
 	defer bus.Close()
@@ -119,6 +239,7 @@ func TestMain(m *testing.M) {
 
 	userSvc := userservice.New(userservice.Deps{
 		Repo: userRepo,
+		Bus:  bus,
 	})
 	orgSvc := orgservice.New(orgservice.Deps{
 		Repo: orgRepo,
@@ -126,9 +247,12 @@ func TestMain(m *testing.M) {
 		Bus:  bus,
 	})
 	projectSvc := projectservice.New(projectservice.Deps{
-		Repo: projectRepo,
-		Org:  orgSvc,
-		Bus:  bus,
+		Repo:     projectRepo,
+		DB:       pool,
+		Org:      orgSvc,
+		Bus:      bus,
+		Config:   &testProjectConfig,
+		Presence: presence.New(presence.Config{TTL: 30 * time.Second}),
 	})
 	sprintSvc := sprintservice.New(sprintservice.Deps{
 		Repo:    sprintRepo,
@@ -136,22 +260,95 @@ func TestMain(m *testing.M) {
 		Bus:     bus,
 	})
 	boardSvc := boardservice.New(boardservice.Deps{
-		Repo:   boardRepo,
-		Sprint: sprintSvc,
-		Bus:    bus,
+		Repo:    boardRepo,
+		Sprint:  sprintSvc,
+		Project: projectSvc,
+		Bus:     bus,
 	})
-	ticketSvc := ticketservice.New(ticketservice.Deps{
+	testTicketSvc = ticketservice.New(ticketservice.Deps{
 		Repo:    ticketRepo,
 		Project: projectSvc,
 		Board:   boardSvc,
 		Sprint:  sprintSvc,
 		Bus:     bus,
+		Config:  &testTicketConfig,
+		Locks:   lease.New(lease.Config{TTL: 2 * time.Minute}),
+	})
+	testReportSvc = reportservice.New(reportservice.Deps{
+		Repo:    reportRepo,
+		Project: projectSvc,
+	})
+	changeSvc := changeservice.New(changeservice.Deps{
+		Repo:    changeRepo,
+		Project: projectSvc,
+	})
+	activitySvc := activityservice.New(activityservice.Deps{
+		Repo:    activityRepo,
+		Project: projectSvc,
+		// the receivers in these tests listen on loopback
+		Hooks: webhook.NewSender(5*time.Second, netip.MustParsePrefix("127.0.0.0/8")),
+	})
+	blobDir, err := os.MkdirTemp("", "fluxis-blobs-")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create blob directory: %v\n", err)
+		os.Exit(1)
+	}
+	defer os.RemoveAll(blobDir)
+	blobStore, err := blob.NewDisk(blobDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to open blob store: %v\n", err)
+		os.Exit(1)
+	}
+	attachmentSvc := attachmentservice.New(attachmentservice.Deps{
+		Repo:    attachmentRepo,
+		Ticket:  testTicketSvc,
+		Project: projectSvc,
+		Store:   blobStore,
+		Config:  &attachmentservice.Config{MaxSize: testAttachmentMaxSize},
+	})
+	usageSvc := usageservice.New(usageservice.Deps{
+		Repo: usageRepo,
+	})
+	integrationSvc := integrationservice.New(integrationservice.Deps{
+		Repo:    integrationRepo,
+		Project: projectSvc,
+		Ticket:  testTicketSvc,
+	})
+	caldavSvc := caldavservice.New(caldavservice.Deps{
+		Repo:   caldavRepo,
+		Ticket: testTicketSvc,
 	})
 	authSvc := authservice.New(authservice.Deps{
 		Users:  userSvc,
-		Config: &authCfg,
+		Config: &testAuthConfig,
+	})
+
+	vapidKey, err := webpush.GenerateKey()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to generate VAPID key: %v\n", err)
+		os.Exit(1)
+	}
+	pushSender, err := webpush.New(webpush.Config{PrivateKey: vapidKey, TTL: time.Minute})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create push sender: %v\n", err)
+		os.Exit(1)
+	}
+	testNotificationSvc = notificationservice.New(notificationservice.Deps{
+		Repo:   notificationRepo,
+		Ticket: testTicketSvc,
+		Push:   pushSender,
 	})
 
+	authn := httpx.NewAuthenticator(authSvc)
+
+	testIPFilter, err = ipfilter.New(ipfilter.Config{Paths: []string{"/admin/"}})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to create IP filter: %v\n", err)
+		os.Exit(1)
+	}
+
+	testRecorder = recorder.New(recorder.Config{Enabled: true, ExemptPaths: []string{"/admin/recording"}})
+
 	userC := usercache.New(memCache)
 	orgC := orgcache.New(memCache)
 	projectC := projectcache.New(memCache)
@@ -181,19 +378,85 @@ func TestMain(m *testing.M) {
 		BoardCache: boardC,
 	})
 	ticketH := tickethandler.New(tickethandler.Deps{
-		Svc:        ticketSvc,
+		Svc:        testTicketSvc,
 		TicketCache: ticketC,
 	})
+	reportH := reporthandler.New(reporthandler.Deps{
+		Svc: testReportSvc,
+	})
+	changeH := changehandler.New(changehandler.Deps{
+		Svc: changeSvc,
+	})
+	activityH := activityhandler.New(activityhandler.Deps{
+		Svc: activitySvc,
+	})
+	attachmentH := attachmenthandler.New(attachmenthandler.Deps{
+		Svc: attachmentSvc,
+	})
+	usageH := usagehandler.New(usagehandler.Deps{
+		Svc: usageSvc,
+	})
+	integrationH := integrationhandler.New(integrationhandler.Deps{
+		Svc: integrationSvc,
+	})
+	caldavH := caldavhandler.New(caldavhandler.Deps{
+		Svc: caldavSvc,
+	})
+	notificationH := notificationhandler.New(notificationhandler.Deps{
+		Svc: testNotificationSvc,
+	})
+	integritySvc := integrityservice.New(integrityservice.Deps{
+		Repo: integrityRepo,
+		DB:   pool,
+	})
+	integrityH := integrityhandler.New(integrityhandler.Deps{
+		Svc: integritySvc,
+	})
+	retentionSvc := retentionservice.New(retentionservice.Deps{
+		Repo:   retentionRepo,
+		DB:     pool,
+		Store:  blobStore,
+		Config: &testRetentionConfig,
+	})
+	retentionH := retentionhandler.New(retentionhandler.Deps{
+		Svc: retentionSvc,
+	})
+	trashSvc := trashservice.New(trashservice.Deps{
+		Repo: trashRepo,
+		Org:  orgSvc,
+	})
+	trashH := trashhandler.New(trashhandler.Deps{
+		Svc: trashSvc,
+	})
+	apidocsH := apidocshandler.New(apidocshandler.Deps{
+		Spec: api.Spec,
+	})
+	adminH := adminhandler.New(adminhandler.Deps{
+		IPFilter: testIPFilter,
+		Recorder: testRecorder,
+		DB:       pool,
+	})
 
 	authModule := auth.NewModule(authSvc, authH, bus)
-	userModule := user.NewModule(userH, userC, bus)
-	orgModule := org.NewModule(orgH, orgC, bus)
-	projectModule := project.NewModule(projectH, projectC, bus)
-	sprintModule := sprint.NewModule(sprintH, sprintC, bus)
-	boardModule := board.NewModule(boardH, boardC, bus)
-	ticketModule := ticket.NewModule(ticketH, ticketC, bus)
-
-	httpx.InitAuth(authModule.Service())
+	userModule := user.NewModule(userH, userC, bus, authn)
+	orgModule := org.NewModule(orgH, orgC, bus, authn)
+	projectModule := project.NewModule(projectH, projectC, bus, authn)
+	sprintModule := sprint.NewModule(sprintH, sprintC, bus, authn)
+	boardModule := board.NewModule(boardH, boardSvc, boardC, bus, authn)
+	ticketModule := ticket.NewModule(ticketH, testTicketSvc, ticketC, bus, authn)
+	attachmentModule := attachment.NewModule(attachmentH, authn)
+	reportModule := report.NewModule(reportH, testReportSvc, authn)
+	changeModule := change.NewModule(changeH, changeSvc, bus, authn)
+	activityModule := activity.NewModule(activityH, activitySvc, bus, authn)
+	usageModule := usage.NewModule(usageH, usageSvc, authn)
+	integrationModule := integration.NewModule(integrationH, authn)
+	caldavModule := caldav.NewModule(caldavH, authn)
+	notificationModule := notification.NewModule(notificationH, testNotificationSvc, bus, authn)
+	adminModule := admin.NewModule(adminH, authn)
+	integrityModule := integrity.NewModule(integrityH, integritySvc, authn)
+	retentionModule := retention.NewModule(retentionH, retentionSvc, authn)
+	trashModule := trash.NewModule(trashH, authn)
+	apidocsModule := apidocs.NewModule(apidocsH, apidocs.Config{Enabled: true, Validate: true}, authn)
 
 	mux := http.NewServeMux()
 	authModule.Routes(mux)
@@ -203,8 +466,24 @@ func TestMain(m *testing.M) {
 	sprintModule.Routes(mux)
 	boardModule.Routes(mux)
 	ticketModule.Routes(mux)
+	attachmentModule.Routes(mux)
+	reportModule.Routes(mux)
+	changeModule.Routes(mux)
+	activityModule.Routes(mux)
+	usageModule.Routes(mux)
+	integrationModule.Routes(mux)
+	caldavModule.Routes(mux)
+	notificationModule.Routes(mux)
+	adminModule.Routes(mux)
+	integrityModule.Routes(mux)
+	retentionModule.Routes(mux)
+	trashModule.Routes(mux)
+	apidocsModule.Routes(mux)
+
+	// the activity log is written from bus events
+	go activityModule.StartSubscriber(context.Background())
 
-	testServer = httptest.NewServer(mux)
+	testServer = httptest.NewServer(testRecorder.Wrap(testIPFilter.Wrap(mux)))
 	defer testServer.Close()
 
 	code := m.Run()
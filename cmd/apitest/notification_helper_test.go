@@ -0,0 +1,35 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func createPushSubscription(tb testing.TB, token string, sub domain.PushSubscriptionCreateModel) domain.PushSubscriptionModel {
+	statusCode, resp := do[domain.PushSubscriptionModel](tb, "POST", "/notifications/push-subscriptions", sub, token)
+
+	if statusCode != http.StatusCreated {
+		tb.Fatalf("create push subscription failed: got status %d, error: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data == nil {
+		tb.Fatalf("create push subscription returned nil data")
+	}
+
+	return *resp.Data
+}
+
+// randomPushSubscription uses the user agent keys from RFC 8291 appendix A
+// with a fresh endpoint
+func randomPushSubscription() domain.PushSubscriptionCreateModel {
+	return domain.PushSubscriptionCreateModel{
+		Endpoint: "https://push.example.com/send/" + randomString(24),
+		Keys: domain.PushSubscriptionKeysModel{
+			P256dh: "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4",
+			Auth:   "BTBZMqHH6r4Tts7J_aSIgg",
+		},
+		UserAgent: "Firefox on Linux",
+	}
+}
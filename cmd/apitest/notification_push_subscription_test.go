@@ -0,0 +1,139 @@
+package apitest_test
+
+import (
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestNotification_PushPublicKey(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, resp := do[domain.PushPublicKeyModel](t, "GET", "/notifications/push/public-key", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	// an uncompressed P-256 point, base64url without padding
+	key, err := base64.RawURLEncoding.DecodeString(resp.Data.PublicKey)
+	if err != nil || len(key) != 65 || key[0] != 0x04 {
+		t.Fatalf("expected a base64url uncompressed P-256 key, got %q", resp.Data.PublicKey)
+	}
+}
+
+func TestNotification_PushSubscription_RegisterAndList(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+	sub := randomPushSubscription()
+
+	statusCode, created := do[domain.PushSubscriptionModel](t, "POST", "/notifications/push-subscriptions", sub, tokens.AccessToken)
+	if statusCode != http.StatusCreated || created.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, created.Error)
+	}
+	if created.Data.Endpoint != sub.Endpoint || created.Data.UserAgent != sub.UserAgent {
+		t.Fatalf("unexpected subscription: %+v", created.Data)
+	}
+
+	// the browser re-sends the same endpoint, e.g. after its keys rotated
+	sub.Keys.Auth = "c2Vjb25kLWF1dGgtc2VjcmV0"
+	statusCode, again := do[domain.PushSubscriptionModel](t, "POST", "/notifications/push-subscriptions", sub, tokens.AccessToken)
+	if statusCode != http.StatusCreated || again.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, again.Error)
+	}
+	if uuidToString(again.Data.ID) != uuidToString(created.Data.ID) {
+		t.Fatal("expected registering the same endpoint to refresh the existing subscription")
+	}
+
+	createPushSubscription(t, tokens.AccessToken, randomPushSubscription())
+
+	statusCode, list := do[[]domain.PushSubscriptionModel](t, "GET", "/notifications/push-subscriptions", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, list.Error)
+	}
+	if len(*list.Data) != 2 {
+		t.Fatalf("expected 2 devices, got %d", len(*list.Data))
+	}
+}
+
+func TestNotification_PushSubscription_MovesToNewUser(t *testing.T) {
+	first := register(t, randomEmail(), "First User", "SecurePassword123!")
+	second := register(t, randomEmail(), "Second User", "SecurePassword123!")
+	sub := randomPushSubscription()
+
+	createPushSubscription(t, first.AccessToken, sub)
+	createPushSubscription(t, second.AccessToken, sub)
+
+	_, list := do[[]domain.PushSubscriptionModel](t, "GET", "/notifications/push-subscriptions", nil, first.AccessToken)
+	if list.Data == nil || len(*list.Data) != 0 {
+		t.Fatal("expected the device to leave the first user once the second user registered it")
+	}
+}
+
+func TestNotification_PushSubscription_InvalidPayload(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	insecure := randomPushSubscription()
+	insecure.Endpoint = "http://push.example.com/send/abc"
+
+	missingKeys := randomPushSubscription()
+	missingKeys.Keys = domain.PushSubscriptionKeysModel{}
+
+	for name, sub := range map[string]domain.PushSubscriptionCreateModel{
+		"insecure endpoint": insecure,
+		"missing keys":      missingKeys,
+	} {
+		statusCode, _ := do[domain.PushSubscriptionModel](t, "POST", "/notifications/push-subscriptions", sub, tokens.AccessToken)
+		if statusCode != http.StatusBadRequest {
+			t.Errorf("%s: expected status 400, got %d", name, statusCode)
+		}
+	}
+}
+
+func TestNotification_PushSubscription_Delete(t *testing.T) {
+	owner := register(t, randomEmail(), "Owner", "SecurePassword123!")
+	other := register(t, randomEmail(), "Other", "SecurePassword123!")
+	sub := createPushSubscription(t, owner.AccessToken, randomPushSubscription())
+	path := "/notifications/push-subscriptions/" + uuidToString(sub.ID)
+
+	// someone else's device reads as missing
+	statusCode, _ := do[interface{}](t, "DELETE", path, nil, other.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for another user's subscription, got %d", statusCode)
+	}
+
+	statusCode, _ = do[interface{}](t, "DELETE", path, nil, owner.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	statusCode, _ = do[interface{}](t, "DELETE", path, nil, owner.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 after delete, got %d", statusCode)
+	}
+}
+
+func TestNotification_Push_Disabled(t *testing.T) {
+	sender := testNotificationSvc.Push
+	testNotificationSvc.Push = nil
+	defer func() { testNotificationSvc.Push = sender }()
+
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.PushPublicKeyModel](t, "GET", "/notifications/push/public-key", nil, tokens.AccessToken)
+	if statusCode != http.StatusNotImplemented {
+		t.Fatalf("expected status 501, got %d", statusCode)
+	}
+
+	statusCode, _ = do[domain.PushSubscriptionModel](t, "POST", "/notifications/push-subscriptions", randomPushSubscription(), tokens.AccessToken)
+	if statusCode != http.StatusNotImplemented {
+		t.Fatalf("expected status 501, got %d", statusCode)
+	}
+}
+
+func TestNotification_PushSubscription_Unauthenticated(t *testing.T) {
+	statusCode, _ := do[[]domain.PushSubscriptionModel](t, "GET", "/notifications/push-subscriptions", nil, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
@@ -50,8 +50,8 @@ func TestOrg_GetByID_InvalidUUID(t *testing.T) {
 
 	statusCode, _ := do[domain.OrganisationModel](t, "GET", "/orgs/not-a-uuid", nil, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
@@ -126,8 +126,8 @@ func TestOrg_ListMembers_InvalidUUID(t *testing.T) {
 
 	statusCode, _ := do[domain.OrganisationMembersPagedModel](t, "GET", "/orgs/not-a-uuid/members", nil, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
@@ -99,3 +99,49 @@ func TestOrg_Paged_ResponseStructure(t *testing.T) {
 
 	}
 }
+
+func TestOrg_Paged_UnknownSort(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, resp := do[domain.OrganisationPagedModel](t, "GET", "/orgs?sortBy=slug", nil, tokens.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "unknown_sort" || resp.Error.Details["sortBy"] != "slug" {
+		t.Fatalf("expected unknown_sort naming the rejected field, got %+v", resp.Error)
+	}
+
+	statusCode, resp = do[domain.OrganisationPagedModel](t, "GET", "/orgs?sortBy=name&sortOrder=sideways", nil, tokens.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "unknown_sort_order" {
+		t.Fatalf("expected unknown_sort_order, got %+v", resp.Error)
+	}
+}
+
+func TestOrg_Paged_SortByName(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	suffix := randomString(6)
+	for _, name := range []string{"Bravo " + suffix, "Alpha " + suffix, "Charlie " + suffix} {
+		do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+			Name: name,
+		}, tokens.AccessToken)
+	}
+
+	statusCode, resp := do[domain.OrganisationPagedModel](t, "GET", "/orgs?name="+suffix+"&sortBy=name&sortOrder=asc", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	want := []string{"Alpha " + suffix, "Bravo " + suffix, "Charlie " + suffix}
+	if len(resp.Data.Items) != len(want) {
+		t.Fatalf("expected %d orgs, got %d", len(want), len(resp.Data.Items))
+	}
+	for i, item := range resp.Data.Items {
+		if item.Name != want[i] {
+			t.Fatalf("unexpected order at %d: got %q, want %q", i, item.Name, want[i])
+		}
+	}
+}
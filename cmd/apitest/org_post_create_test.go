@@ -55,3 +55,44 @@ func TestOrg_Create_MissingName(t *testing.T) {
 		t.Fatalf("expected status 400, got %d", statusCode)
 	}
 }
+
+func TestOrg_Create_UnicodeSlug(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	suffix := randomString(6)
+	statusCode, resp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Команда Разработки " + suffix,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || resp.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+	first := resp.Data.Slug
+	if first == "" {
+		t.Fatal("expected a slug for a cyrillic name")
+	}
+
+	// the same name again gets a suffixed slug rather than a conflict
+	statusCode, resp = do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Команда Разработки " + suffix,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || resp.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Slug != first+"-2" {
+		t.Fatalf("expected slug %s-2, got %s", first, resp.Data.Slug)
+	}
+}
+
+func TestOrg_Create_EmojiOnlyName(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, resp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "🚀🔥",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || resp.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Slug == "" {
+		t.Fatal("expected a fallback slug")
+	}
+}
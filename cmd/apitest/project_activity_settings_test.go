@@ -0,0 +1,116 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestProject_ActivitySettings(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	path := "/projects/" + projectID + "/activity/settings"
+
+	statusCode, settings := do[domain.ActivitySettingsModel](t, "GET", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || settings.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, settings.Error)
+	}
+	if settings.Data.Mode != domain.ActivityModeAll || settings.Data.UpdatedAt != nil {
+		t.Fatalf("expected the defaults, got %+v", settings.Data)
+	}
+
+	statusCode, settings = do[domain.ActivitySettingsModel](t, "PUT", path, domain.ActivitySettingsUpdateModel{
+		Mode: domain.ActivityModeSelected,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest || settings.Error == nil || settings.Error.Code != "activity_actions_required" {
+		t.Fatalf("expected 400 activity_actions_required, got %d: %+v", statusCode, settings.Error)
+	}
+
+	statusCode, settings = do[domain.ActivitySettingsModel](t, "PUT", path, domain.ActivitySettingsUpdateModel{
+		Mode:    domain.ActivityModeSelected,
+		Actions: []string{string(pubsub.TicketsBulkDeleted)},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || settings.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, settings.Error)
+	}
+	if len(settings.Data.Actions) != 1 || settings.Data.UpdatedAt == nil {
+		t.Fatalf("expected the selected action to be stored, got %+v", settings.Data)
+	}
+
+	// the reorder is left out of the log, the bulk delete is kept
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	col1 := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 1")
+	col2 := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 2")
+	do[[]domain.BoardColumnModel](t, "PATCH", "/boards/"+uuidToString(board.ID)+"/columns/reorder", domain.BoardColumnReorderModel{col2.ID, col1.ID}, tokens.AccessToken)
+	bulkDelete(t, project.ID, tokens.AccessToken)
+
+	items := waitForActivity(t, projectID, tokens.AccessToken, 1)
+	if len(items) != 1 || items[0].Action != string(pubsub.TicketsBulkDeleted) {
+		t.Fatalf("expected only the bulk delete to be logged, got %+v", items)
+	}
+
+	statusCode, settings = do[domain.ActivitySettingsModel](t, "PUT", path, domain.ActivitySettingsUpdateModel{
+		Mode: domain.ActivityModeNone,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || settings.Data == nil || len(settings.Data.Actions) != 0 {
+		t.Fatalf("expected logging to be turned off, got %d: %+v", statusCode, settings.Data)
+	}
+	bulkDelete(t, project.ID, tokens.AccessToken)
+
+	if items := waitForActivity(t, projectID, tokens.AccessToken, 2); len(items) != 1 {
+		t.Fatalf("expected nothing logged with mode none, got %d entries", len(items))
+	}
+}
+
+func TestProject_ActivitySettings_NonExistentProject(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.ActivitySettingsModel](t, "GET", "/projects/550e8400-e29b-41d4-a716-446655440000/activity/settings", nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", statusCode)
+	}
+}
+
+func bulkDelete(t *testing.T, projectID pgtype.UUID, token string) {
+	t.Helper()
+	ticket := createTicket(t, uuidToString(projectID), token, randomTicketTitle(), "story", "medium")
+	statusCode, resp := do[domain.TicketBulkDeleteResultModel](t, "POST", "/tickets/bulk-delete", domain.TicketBulkDeleteModel{
+		ID:        []pgtype.UUID{ticket.ID},
+		ProjectID: []pgtype.UUID{projectID},
+	}, token)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+}
+
+// waitForActivity polls the log until it holds want entries or a second
+// passes, as entries are written from bus events after the request returns
+func waitForActivity(t *testing.T, projectID, token string, want int) []domain.ActivityModel {
+	t.Helper()
+	var items []domain.ActivityModel
+	for deadline := time.Now().Add(time.Second); ; {
+		statusCode, resp := do[domain.ActivityPagedModel](t, "GET", "/projects/"+projectID+"/activity", nil, token)
+		if statusCode != http.StatusOK || resp.Data == nil {
+			t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+		}
+		items = resp.Data.Items
+		if len(items) >= want || time.Now().After(deadline) {
+			return items
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
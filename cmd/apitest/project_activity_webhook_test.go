@@ -0,0 +1,128 @@
+package apitest_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type delivery struct {
+	signature string
+	body      []byte
+}
+
+func TestProject_ActivityWebhook(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	path := "/projects/" + projectID + "/activity/webhooks"
+
+	deliveries := make(chan delivery, 4)
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		deliveries <- delivery{r.Header.Get(webhook.SignatureHeader), body}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer collector.Close()
+
+	statusCode, created := do[domain.ActivityWebhookModel](t, "POST", path, domain.ActivityWebhookCreateModel{
+		URL:     collector.URL,
+		Actions: []string{string(pubsub.TicketsBulkDeleted)},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || created.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, created.Error)
+	}
+	if created.Data.Secret == "" {
+		t.Fatal("expected the secret in the create response")
+	}
+	secret := created.Data.Secret
+
+	statusCode, _ = do[domain.ActivityWebhookModel](t, "POST", path, domain.ActivityWebhookCreateModel{
+		URL:     collector.URL,
+		Actions: []string{"ticket.ticket.created"},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an action the log does not record, got %d", statusCode)
+	}
+
+	for _, url := range []string{"http://169.254.169.254/latest/meta-data/", "http://10.0.0.5/hook", "http://localhost:6060/debug/pprof/"} {
+		statusCode, resp := do[domain.ActivityWebhookModel](t, "POST", path, domain.ActivityWebhookCreateModel{
+			URL: url,
+		}, tokens.AccessToken)
+		if statusCode != http.StatusBadRequest || resp.Error == nil || resp.Error.Code != "webhook_url_not_allowed" {
+			t.Fatalf("expected status 400 webhook_url_not_allowed for %s, got %d: %v", url, statusCode, resp.Error)
+		}
+	}
+
+	// a column reorder is logged but filtered out by the webhook
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	col1 := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 1")
+	col2 := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 2")
+	do[[]domain.BoardColumnModel](t, "PATCH", "/boards/"+uuidToString(board.ID)+"/columns/reorder", domain.BoardColumnReorderModel{col2.ID, col1.ID}, tokens.AccessToken)
+
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	statusCode, deleteResp := do[domain.TicketBulkDeleteResultModel](t, "POST", "/tickets/bulk-delete", domain.TicketBulkDeleteModel{
+		ID:        []pgtype.UUID{ticket.ID},
+		ProjectID: []pgtype.UUID{project.ID},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, deleteResp.Error)
+	}
+
+	var got delivery
+	select {
+	case got = <-deliveries:
+	case <-time.After(5 * time.Second):
+		t.Fatal("expected a delivery for the bulk delete")
+	}
+	if err := webhook.Verify(secret, got.signature, got.body, time.Now(), webhook.DefaultTolerance); err != nil {
+		t.Fatalf("expected a delivery signed with the webhook secret: %v", err)
+	}
+	var entry domain.ActivityModel
+	if err := json.Unmarshal(got.body, &entry); err != nil {
+		t.Fatalf("failed to decode delivery: %v", err)
+	}
+	if entry.Action != string(pubsub.TicketsBulkDeleted) || entry.ProjectID != project.ID || entry.ID == 0 {
+		t.Fatalf("expected the bulk delete entry, got %+v", entry)
+	}
+
+	select {
+	case extra := <-deliveries:
+		t.Fatalf("expected only the subscribed action, got %s", extra.body)
+	case <-time.After(200 * time.Millisecond):
+	}
+
+	statusCode, list := do[[]domain.ActivityWebhookModel](t, "GET", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil || len(*list.Data) != 1 {
+		t.Fatalf("expected one webhook, got %d: %v", statusCode, list.Error)
+	}
+	if (*list.Data)[0].Secret != "" {
+		t.Fatal("expected the secret to be left out of the list")
+	}
+
+	statusCode, _ = do[any](t, "DELETE", path+"/"+uuidToString(created.Data.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+	statusCode, _ = do[any](t, "DELETE", path+"/"+uuidToString(created.Data.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 once deleted, got %d", statusCode)
+	}
+}
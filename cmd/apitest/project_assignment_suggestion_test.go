@@ -0,0 +1,91 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProject_SuggestAssignees(t *testing.T) {
+	owner := register(t, randomEmail(), "Busy Owner", "SecurePassword123!")
+	member := register(t, randomEmail(), "Seasoned Member", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, owner.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	statusCode, ownerResp := do[domain.UserModel](t, "GET", "/users/me", nil, owner.AccessToken)
+	if statusCode != http.StatusOK || ownerResp.Data == nil {
+		t.Fatal("failed to get owner data")
+	}
+	statusCode, memberResp := do[domain.UserModel](t, "GET", "/users/me", nil, member.AccessToken)
+	if statusCode != http.StatusOK || memberResp.Data == nil {
+		t.Fatal("failed to get member data")
+	}
+	ownerID, memberID := ownerResp.Data.ID, memberResp.Data.ID
+	do[struct{}](t, "POST", "/orgs/"+orgID+"/members", domain.OrganisationMemberCreateModel{
+		UserId: uuidToString(memberID),
+		Role:   "member",
+	}, owner.AccessToken)
+
+	project := createProject(t, orgID, owner.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	addProjectMember(t, projectID, uuidToString(memberID), owner.AccessToken, domain.ProjectRoleMember)
+	sprint := createSprint(t, projectID, owner.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), owner.AccessToken, randomBoardName())
+	statusCode, doneResp := do[domain.BoardColumnModel](t, "POST", "/boards/"+uuidToString(board.ID)+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Done",
+		Category: "done",
+	}, owner.AccessToken)
+	if statusCode != http.StatusCreated || doneResp.Data == nil {
+		t.Fatalf("failed to create done column: %v", doneResp.Error)
+	}
+
+	ctx := context.Background()
+	for range 2 {
+		open := createTicket(t, projectID, owner.AccessToken, randomTicketTitle(), "task", "medium")
+		if _, err := testPool.Exec(ctx, "UPDATE tickets SET assignee_id = $1 WHERE id = $2", ownerID, open.ID); err != nil {
+			t.Fatalf("failed to assign ticket: %v", err)
+		}
+	}
+	finished := createTicket(t, projectID, owner.AccessToken, randomTicketTitle(), "bug", "medium")
+	if _, err := testPool.Exec(ctx, "UPDATE tickets SET assignee_id = $1, sprint_id = $2, board_id = $3, board_column_id = $4 WHERE id = $5",
+		memberID, sprint.ID, board.ID, doneResp.Data.ID, finished.ID); err != nil {
+		t.Fatalf("failed to finish ticket: %v", err)
+	}
+
+	ticket := createTicket(t, projectID, owner.AccessToken, randomTicketTitle(), "bug", "medium")
+	path := "/projects/" + projectID + "/suggestions/assignment"
+
+	statusCode, resp := do[domain.TicketAssignmentSuggestionsModel](t, "GET", path+"?ticketId="+uuidToString(ticket.ID), nil, owner.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	items := resp.Data.Items
+	if len(items) != 2 {
+		t.Fatalf("expected both members, got %+v", items)
+	}
+	if items[0].UserID != memberID || items[0].DoneSameType != 1 || items[0].OpenTickets != 0 {
+		t.Fatalf("expected the idle member with a finished bug first, got %+v", items[0])
+	}
+	if items[1].UserID != ownerID || items[1].OpenTickets != 2 || items[1].Score >= items[0].Score {
+		t.Fatalf("expected the busy owner last, got %+v", items[1])
+	}
+
+	statusCode, _ = do[domain.TicketAssignmentSuggestionsModel](t, "GET", path, nil, owner.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without ticketId, got %d", statusCode)
+	}
+
+	other := createProject(t, orgID, owner.AccessToken, randomProjectKey(), "Other Project", "private")
+	statusCode, _ = do[domain.TicketAssignmentSuggestionsModel](t, "GET", "/projects/"+uuidToString(other.ID)+"/suggestions/assignment?ticketId="+uuidToString(ticket.ID), nil, owner.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a ticket of another project, got %d", statusCode)
+	}
+}
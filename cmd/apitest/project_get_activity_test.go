@@ -0,0 +1,119 @@
+package apitest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestProject_Activity_BulkActions(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if me.Data == nil {
+		t.Fatal("failed to get user")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	col1 := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 1")
+	col2 := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Column 2")
+
+	statusCode, reorderResp := do[[]domain.BoardColumnModel](t, "PATCH", "/boards/"+uuidToString(board.ID)+"/columns/reorder", domain.BoardColumnReorderModel{col2.ID, col1.ID}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, reorderResp.Error)
+	}
+
+	t1 := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	t2 := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "bug", "high")
+	statusCode, deleteResp := do[domain.TicketBulkDeleteResultModel](t, "POST", "/tickets/bulk-delete", domain.TicketBulkDeleteModel{
+		ID:        []pgtype.UUID{t1.ID, t2.ID},
+		ProjectID: []pgtype.UUID{project.ID},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, deleteResp.Error)
+	}
+
+	// entries are written from bus events, shortly after the requests return
+	var items []domain.ActivityModel
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		statusCode, resp := do[domain.ActivityPagedModel](t, "GET", "/projects/"+projectID+"/activity", nil, tokens.AccessToken)
+		if statusCode != http.StatusOK || resp.Data == nil {
+			t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+		}
+		items = resp.Data.Items
+		if len(items) >= 2 || time.Now().After(deadline) {
+			break
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 activity entries, got %d", len(items))
+	}
+
+	// newest first
+	cases := []struct {
+		action string
+		parent pgtype.UUID
+		ids    []pgtype.UUID
+	}{
+		{string(pubsub.TicketsBulkDeleted), pgtype.UUID{}, []pgtype.UUID{t1.ID, t2.ID}},
+		{string(pubsub.BoardColumnReordered), board.ID, []pgtype.UUID{col2.ID, col1.ID}},
+	}
+	for i, c := range cases {
+		entry := items[i]
+		if entry.Action != c.action || entry.ActorID != me.Data.ID {
+			t.Fatalf("entry %d: expected %s by the caller, got %s by %v", i, c.action, entry.Action, entry.ActorID)
+		}
+		var payload domain.BulkEventModel
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil {
+			t.Fatalf("entry %d: decode payload: %v", i, err)
+		}
+		if payload.ProjectID != project.ID || payload.ParentID != c.parent {
+			t.Fatalf("entry %d: unexpected scope in payload %+v", i, payload)
+		}
+		if !sameUUIDs(payload.IDs, c.ids) {
+			t.Fatalf("entry %d: expected ids %v, got %v", i, c.ids, payload.IDs)
+		}
+	}
+}
+
+func TestProject_Activity_NonExistentProject(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.ActivityPagedModel](t, "GET", "/projects/550e8400-e29b-41d4-a716-446655440000/activity", nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", statusCode)
+	}
+}
+
+// sameUUIDs compares ID lists regardless of order
+func sameUUIDs(a, b []pgtype.UUID) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[pgtype.UUID]int, len(a))
+	for _, id := range a {
+		seen[id]++
+	}
+	for _, id := range b {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+	return true
+}
@@ -58,8 +58,8 @@ func TestProject_GetByID_InvalidUUID(t *testing.T) {
 
 	statusCode, _ := do[domain.ProjectModel](t, "GET", "/projects/not-a-uuid", nil, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
@@ -122,3 +122,24 @@ func TestProject_GetByID_ResponseStructure(t *testing.T) {
 		t.Fatalf("expected visibility 'private', got '%s'", resp.Data.Visibility)
 	}
 }
+
+func TestProject_GetByID_Timestamps(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	statusCode, resp := do[map[string]any](t, "GET", "/projects/"+uuidToString(project.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	assertTimestamps(t, *resp.Data)
+}
@@ -271,3 +271,103 @@ func TestProject_List_FilterByMultipleIDs(t *testing.T) {
 		t.Fatalf("expected 2 projects, got %d", len(resp.Data.Items))
 	}
 }
+
+func TestProject_List_IncludeSummary(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Project 1", "private")
+	projectID := uuidToString(project.ID)
+
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Todo")
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	statusCode, resp := do[domain.ProjectsPagedModel](t, "GET", "/projects?orgId="+orgID+"&includeSummary=true", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if len(resp.Data.Items) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(resp.Data.Items))
+	}
+
+	item := resp.Data.Items[0]
+	if item.OpenTicketCount == nil || *item.OpenTicketCount != 1 {
+		t.Fatalf("expected 1 open ticket, got %v", item.OpenTicketCount)
+	}
+	if item.DoneTicketCount == nil || *item.DoneTicketCount != 0 {
+		t.Fatalf("expected 0 done tickets, got %v", item.DoneTicketCount)
+	}
+	if item.ColumnCount == nil || *item.ColumnCount != 1 {
+		t.Fatalf("expected 1 column, got %v", item.ColumnCount)
+	}
+	if item.LastActivityAt == nil || item.LastActivityAt.IsZero() {
+		t.Fatal("expected lastActivityAt to be set")
+	}
+}
+
+func TestProject_List_IncludeDeletedForbidden(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	statusCode, _ = do[domain.ProjectsPagedModel](t, "GET", "/projects?orgId="+uuidToString(orgResp.Data.ID)+"&includeDeleted=true", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+}
+
+func TestProject_List_IncludeDeletedAsAdmin(t *testing.T) {
+	tokens := adminTokens(t)
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Project 1", "private")
+
+	statusCode, _ = do[struct{}](t, "DELETE", "/projects/"+uuidToString(project.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	statusCode, resp := do[domain.ProjectsPagedModel](t, "GET", "/projects?orgId="+orgID, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 0 {
+		t.Fatalf("expected deleted project to be hidden, got %d items", len(resp.Data.Items))
+	}
+
+	statusCode, resp = do[domain.ProjectsPagedModel](t, "GET", "/projects?orgId="+orgID+"&includeDeleted=true", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 1 {
+		t.Fatalf("expected 1 project, got %d", len(resp.Data.Items))
+	}
+	if resp.Data.Items[0].DeletedAt == nil {
+		t.Fatal("expected deletedAt to be set")
+	}
+}
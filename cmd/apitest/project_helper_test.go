@@ -25,6 +25,25 @@ func createProject(tb testing.TB, orgID string, token string, key, name, visibil
 	return *resp.Data
 }
 
+// addProjectMember gives a member of the project's organisation a role in
+// the project
+func addProjectMember(tb testing.TB, projectID string, userID string, token string, role domain.ProjectRole) domain.ProjectMemberModel {
+	statusCode, resp := do[domain.ProjectMemberModel](tb, "POST", "/projects/"+projectID+"/members", domain.ProjectMemberCreateModel{
+		UserID: userID,
+		Role:   role,
+	}, token)
+
+	if statusCode != http.StatusCreated {
+		tb.Fatalf("add project member failed: got status %d, error: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data == nil {
+		tb.Fatalf("add project member returned nil data")
+	}
+
+	return *resp.Data
+}
+
 func randomProjectKey() string {
 	return "p" + randomString(4)
 }
@@ -0,0 +1,512 @@
+package apitest_test
+
+import (
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+// projectWithOrgMember creates a project owned by a new user and returns it
+// with the owner and a second user who belongs to the organisation but not
+// to the project
+func projectWithOrgMember(t *testing.T) (domain.ProjectModel, domain.AuthModel, domain.AuthModel, string) {
+	owner := register(t, randomEmail(), "Project Owner", "SecurePassword123!")
+	other := register(t, randomEmail(), "Org Member", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, owner.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	_, them := do[domain.UserModel](t, "GET", "/users/me", nil, other.AccessToken)
+	if them.Data == nil {
+		t.Fatal("failed to get org member")
+	}
+	statusCode, _ = do[struct{}](t, "POST", "/orgs/"+uuidToString(orgResp.Data.ID)+"/members", domain.OrganisationMemberCreateModel{
+		UserId: uuidToString(them.Data.ID),
+		Role:   "member",
+	}, owner.AccessToken)
+	if statusCode != http.StatusCreated {
+		t.Fatalf("failed to add org member, got %d", statusCode)
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), owner.AccessToken, randomProjectKey(), "Test Project", "private")
+	return project, owner, other, uuidToString(them.Data.ID)
+}
+
+func TestProjectMembers_CreatorIsAdmin(t *testing.T) {
+	project, owner, _, _ := projectWithOrgMember(t)
+
+	statusCode, resp := do[domain.ProjectMembersPagedModel](t, "GET", "/projects/"+uuidToString(project.ID)+"/members", nil, owner.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 1 || resp.Data.Items[0].Role != domain.ProjectRoleAdmin {
+		t.Fatalf("expected the creator as the only admin, got %+v", resp.Data.Items)
+	}
+}
+
+func TestProjectMembers_NonMemberCannotSeeProject(t *testing.T) {
+	project, _, other, _ := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+
+	statusCode, _ := do[domain.ProjectModel](t, "GET", "/projects/"+projectID, nil, other.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a non-member, got %d", statusCode)
+	}
+
+	statusCode, list := do[domain.ProjectsPagedModel](t, "GET", "/projects?orgId="+uuidToString(project.OrgID), nil, other.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, list.Error)
+	}
+	if len(list.Data.Items) != 0 {
+		t.Fatalf("expected no projects for a non-member, got %+v", list.Data.Items)
+	}
+
+	statusCode, _ = do[domain.TicketModel](t, "POST", "/tickets?projectId="+projectID, domain.TicketCreateModel{
+		Title:    randomTicketTitle(),
+		Type:     "task",
+		Priority: "medium",
+	}, other.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 creating a ticket as a non-member, got %d", statusCode)
+	}
+}
+
+func TestProjectMembers_ViewerCanReadButNotWrite(t *testing.T) {
+	project, owner, other, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+
+	member := addProjectMember(t, projectID, otherID, owner.AccessToken, "viewer")
+	if member.Role != domain.ProjectRoleViewer || member.Email == "" {
+		t.Fatalf("unexpected member: %+v", member)
+	}
+
+	ticket := createTicket(t, projectID, owner.AccessToken, randomTicketTitle(), "task", "medium")
+
+	statusCode, _ := do[domain.TicketModel](t, "GET", "/tickets/"+uuidToString(ticket.ID), nil, other.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected a viewer to read the ticket, got %d", statusCode)
+	}
+
+	statusCode, resp := do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(ticket.ID), domain.TicketUpdateModel{
+		Title: "Renamed by a viewer",
+	}, other.AccessToken)
+	if statusCode != http.StatusForbidden || resp.Error == nil || resp.Error.Code != "insufficient_project_role" {
+		t.Fatalf("expected 403 insufficient_project_role, got %d: %v", statusCode, resp.Error)
+	}
+
+	// promoted to member, the same write goes through
+	statusCode, updated := do[domain.ProjectMemberModel](t, "PATCH", "/projects/"+projectID+"/members/"+otherID, domain.ProjectMemberUpdateModel{
+		Role: domain.ProjectRoleMember,
+	}, owner.AccessToken)
+	if statusCode != http.StatusOK || updated.Data == nil || updated.Data.Role != domain.ProjectRoleMember {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, updated.Error)
+	}
+	statusCode, resp = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(ticket.ID), domain.TicketUpdateModel{
+		Title: "Renamed by a member",
+	}, other.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected a member to update the ticket, got %d: %v", statusCode, resp.Error)
+	}
+
+	// managing members stays with admins
+	statusCode, _ = do[domain.ProjectMemberModel](t, "PATCH", "/projects/"+projectID+"/members/"+otherID, domain.ProjectMemberUpdateModel{
+		Role: domain.ProjectRoleAdmin,
+	}, other.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403 for a member promoting themselves, got %d", statusCode)
+	}
+}
+
+func TestProjectMembers_ViewerCannotChangeAttachments(t *testing.T) {
+	project, owner, other, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+	addProjectMember(t, projectID, otherID, owner.AccessToken, "viewer")
+
+	ticket := createTicket(t, projectID, owner.AccessToken, randomTicketTitle(), "task", "medium")
+	ticketID := uuidToString(ticket.ID)
+	statusCode, uploaded := uploadAttachment(t, ticketID, "file", "notes.txt", []byte("hello"), owner.AccessToken)
+	if statusCode != http.StatusCreated || uploaded.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, uploaded.Error)
+	}
+	attachmentPath := "/tickets/" + ticketID + "/attachments/" + uuidToString(uploaded.Data.ID)
+
+	statusCode, _ = do[[]domain.AttachmentModel](t, "GET", "/tickets/"+ticketID+"/attachments", nil, other.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected a viewer to list attachments, got %d", statusCode)
+	}
+
+	statusCode, resp := uploadAttachment(t, ticketID, "file", "viewer.txt", []byte("nope"), other.AccessToken)
+	if statusCode != http.StatusForbidden || resp.Error == nil || resp.Error.Code != "insufficient_project_role" {
+		t.Fatalf("expected 403 insufficient_project_role for a viewer upload, got %d: %v", statusCode, resp.Error)
+	}
+
+	statusCode, deleted := do[struct{}](t, "DELETE", attachmentPath, nil, other.AccessToken)
+	if statusCode != http.StatusForbidden || deleted.Error == nil || deleted.Error.Code != "insufficient_project_role" {
+		t.Fatalf("expected 403 insufficient_project_role for a viewer delete, got %d: %v", statusCode, deleted.Error)
+	}
+
+	statusCode, _ = do[struct{}](t, "DELETE", attachmentPath, nil, owner.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected the owner to delete the attachment, got %d", statusCode)
+	}
+}
+
+func TestProjectMembers_UpsertNeedsAdmin(t *testing.T) {
+	project, owner, other, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+	path := "/projects/" + projectID + "?orgId=" + uuidToString(project.OrgID)
+	replay := domain.ProjectCreateModel{
+		Key:        project.Key,
+		Name:       "Renamed by an upsert",
+		Visibility: "private",
+	}
+
+	statusCode, resp := do[domain.ProjectModel](t, "PUT", path, replay, other.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for a non-member, got %d: %v", statusCode, resp.Error)
+	}
+
+	addProjectMember(t, projectID, otherID, owner.AccessToken, "member")
+	statusCode, resp = do[domain.ProjectModel](t, "PUT", path, replay, other.AccessToken)
+	if statusCode != http.StatusForbidden || resp.Error == nil || resp.Error.Code != "insufficient_project_role" {
+		t.Fatalf("expected 403 insufficient_project_role for a member, got %d: %v", statusCode, resp.Error)
+	}
+
+	_, got := do[domain.ProjectModel](t, "GET", "/projects/"+projectID, nil, owner.AccessToken)
+	if got.Data == nil || got.Data.Name != project.Name {
+		t.Fatalf("expected the name to stay %q, got %+v", project.Name, got.Data)
+	}
+
+	statusCode, resp = do[domain.ProjectModel](t, "PUT", path, replay, owner.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil || resp.Data.Name != replay.Name {
+		t.Fatalf("expected the admin to update the project, got %d: %v", statusCode, resp.Error)
+	}
+}
+
+func TestProjectMembers_Add_Errors(t *testing.T) {
+	project, owner, _, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+
+	addProjectMember(t, projectID, otherID, owner.AccessToken, "member")
+	statusCode, resp := do[domain.ProjectMemberModel](t, "POST", "/projects/"+projectID+"/members", domain.ProjectMemberCreateModel{
+		UserID: otherID,
+		Role:   domain.ProjectRoleMember,
+	}, owner.AccessToken)
+	if statusCode != http.StatusConflict || resp.Error == nil || resp.Error.Code != "already_a_member" {
+		t.Fatalf("expected 409 already_a_member, got %d: %v", statusCode, resp.Error)
+	}
+
+	stranger := register(t, randomEmail(), "Stranger", "SecurePassword123!")
+	_, them := do[domain.UserModel](t, "GET", "/users/me", nil, stranger.AccessToken)
+	if them.Data == nil {
+		t.Fatal("failed to get stranger")
+	}
+	statusCode, resp = do[domain.ProjectMemberModel](t, "POST", "/projects/"+projectID+"/members", domain.ProjectMemberCreateModel{
+		UserID: uuidToString(them.Data.ID),
+		Role:   domain.ProjectRoleViewer,
+	}, owner.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity || resp.Error == nil || resp.Error.Code != "not_an_org_member" {
+		t.Fatalf("expected 422 not_an_org_member, got %d: %v", statusCode, resp.Error)
+	}
+}
+
+func TestProjectMembers_LastAdmin(t *testing.T) {
+	project, owner, other, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, owner.AccessToken)
+	if me.Data == nil {
+		t.Fatal("failed to get owner")
+	}
+	ownerID := uuidToString(me.Data.ID)
+
+	statusCode, resp := do[domain.ProjectMemberModel](t, "PATCH", "/projects/"+projectID+"/members/"+ownerID, domain.ProjectMemberUpdateModel{
+		Role: domain.ProjectRoleMember,
+	}, owner.AccessToken)
+	if statusCode != http.StatusConflict || resp.Error == nil || resp.Error.Code != "last_project_admin" {
+		t.Fatalf("expected 409 last_project_admin, got %d: %v", statusCode, resp.Error)
+	}
+
+	statusCode, _ = do[any](t, "DELETE", "/projects/"+projectID+"/members/"+ownerID, nil, owner.AccessToken)
+	if statusCode != http.StatusConflict {
+		t.Fatalf("expected status 409 for the last admin leaving, got %d", statusCode)
+	}
+
+	// with a second admin the first can leave
+	addProjectMember(t, projectID, otherID, owner.AccessToken, "admin")
+	statusCode, _ = do[any](t, "DELETE", "/projects/"+projectID+"/members/"+ownerID, nil, owner.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	statusCode, _ = do[domain.ProjectModel](t, "GET", "/projects/"+projectID, nil, owner.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected the former member to lose access, got %d", statusCode)
+	}
+	statusCode, _ = do[domain.ProjectModel](t, "GET", "/projects/"+projectID, nil, other.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected the new admin to keep access, got %d", statusCode)
+	}
+}
+
+func TestProjectMembers_AdminsDemotingEachOtherKeepOne(t *testing.T) {
+	project, owner, other, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+	addProjectMember(t, projectID, otherID, owner.AccessToken, "admin")
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, owner.AccessToken)
+	if me.Data == nil {
+		t.Fatal("failed to get owner")
+	}
+	ownerID := uuidToString(me.Data.ID)
+
+	// each admin demotes the other at the same time
+	demotions := []struct{ target, token string }{
+		{otherID, owner.AccessToken},
+		{ownerID, other.AccessToken},
+	}
+	var wg sync.WaitGroup
+	statuses := make([]int, len(demotions))
+	for i, d := range demotions {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			statuses[i], _ = do[domain.ProjectMemberModel](t, "PATCH", "/projects/"+projectID+"/members/"+d.target, domain.ProjectMemberUpdateModel{
+				Role: domain.ProjectRoleMember,
+			}, d.token)
+		}()
+	}
+	wg.Wait()
+
+	statusCode, resp := do[domain.ProjectMembersPagedModel](t, "GET", "/projects/"+projectID+"/members?role=admin", nil, owner.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 1 {
+		t.Fatalf("expected exactly one admin left, got %+v (demotions answered %v)", resp.Data.Items, statuses)
+	}
+}
+
+func TestProjectMembers_NonMemberReadsNothing(t *testing.T) {
+	project, owner, other, _ := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+
+	createTicket(t, projectID, owner.AccessToken, randomTicketTitle(), "task", "medium")
+	deleted := createTicket(t, projectID, owner.AccessToken, randomTicketTitle(), "task", "medium")
+	if statusCode, resp := do[any](t, "DELETE", "/tickets/"+uuidToString(deleted.ID), nil, owner.AccessToken); statusCode != http.StatusNoContent {
+		t.Fatalf("failed to delete ticket: %d %v", statusCode, resp.Error)
+	}
+
+	statusCode, tickets := do[domain.TicketsPagedModel](t, "GET", "/tickets?projectId="+projectID, nil, other.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 listing tickets as a non-member, got %d: %+v", statusCode, tickets.Data)
+	}
+
+	statusCode, dashboard := do[domain.DashboardModel](t, "GET", "/dashboard", nil, other.AccessToken)
+	if statusCode != http.StatusOK || dashboard.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, dashboard.Error)
+	}
+	if dashboard.Data.ActiveProjects != 0 || dashboard.Data.OpenTickets != 0 || len(dashboard.Data.LatestActivity) != 0 {
+		t.Fatalf("expected an empty dashboard for a non-member, got %+v", dashboard.Data)
+	}
+
+	statusCode, portfolio := do[domain.PortfolioModel](t, "GET", "/portfolio", nil, other.AccessToken)
+	if statusCode != http.StatusOK || portfolio.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, portfolio.Error)
+	}
+	if len(portfolio.Data.Projects) != 0 {
+		t.Fatalf("expected no portfolio projects for a non-member, got %+v", portfolio.Data.Projects)
+	}
+
+	statusCode, changes := do[domain.ChangeFeedModel](t, "GET", "/changes?after=0", nil, other.AccessToken)
+	if statusCode != http.StatusOK || changes.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, changes.Error)
+	}
+	if len(changes.Data.Items) != 0 {
+		t.Fatalf("expected no changes for a non-member, got %+v", changes.Data.Items)
+	}
+
+	statusCode, trash := do[domain.TrashPagedModel](t, "GET", "/trash?orgId="+uuidToString(project.OrgID), nil, other.AccessToken)
+	if statusCode != http.StatusOK || trash.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, trash.Error)
+	}
+	if len(trash.Data.Items) != 0 {
+		t.Fatalf("expected an empty trash for a non-member, got %+v", trash.Data.Items)
+	}
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, other.AccessToken)
+	if me.Data == nil {
+		t.Fatal("failed to get org member")
+	}
+	resp, body := dav(t, "PROPFIND", "/caldav/", "", me.Data.Email, "SecurePassword123!", map[string]string{"Depth": "1"})
+	if resp.StatusCode != http.StatusMultiStatus || strings.Contains(body, projectID) {
+		t.Fatalf("expected no calendar for a non-member, got %d: %s", resp.StatusCode, body)
+	}
+	resp, _ = dav(t, "PROPFIND", "/caldav/"+projectID+"/", "", me.Data.Email, "SecurePassword123!", map[string]string{"Depth": "1"})
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for the calendar of a non-member, got %d", resp.StatusCode)
+	}
+}
+
+func TestProjectMembers_SprintsAndBoards(t *testing.T) {
+	project, owner, other, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+
+	sprint := createSprint(t, projectID, owner.AccessToken, randomSprintName())
+	sprintID := uuidToString(sprint.ID)
+	board := createBoard(t, sprintID, owner.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+	createBoardColumn(t, boardID, owner.AccessToken, randomBoardColumnName())
+
+	// a non-member neither sees nor touches anything of the project
+	for _, path := range []string{"/sprints/" + sprintID, "/boards/" + boardID} {
+		if statusCode, _ := do[any](t, "GET", path, nil, other.AccessToken); statusCode != http.StatusNotFound {
+			t.Fatalf("expected status 404 for GET %s as a non-member, got %d", path, statusCode)
+		}
+	}
+	statusCode, sprints := do[domain.SprintsPagedModel](t, "GET", "/sprints?projectId="+projectID, nil, other.AccessToken)
+	if statusCode != http.StatusOK || sprints.Data == nil || len(sprints.Data.Items) != 0 {
+		t.Fatalf("expected no sprints for a non-member, got %d: %+v", statusCode, sprints.Data)
+	}
+	statusCode, boards := do[domain.BoardsPagedModel](t, "GET", "/boards?sprintId="+sprintID, nil, other.AccessToken)
+	if statusCode != http.StatusOK || boards.Data == nil || len(boards.Data.Items) != 0 {
+		t.Fatalf("expected no boards for a non-member, got %d: %+v", statusCode, boards.Data)
+	}
+	statusCode, columns := do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns", nil, other.AccessToken)
+	if statusCode != http.StatusOK || columns.Data == nil || len(columns.Data.Items) != 0 {
+		t.Fatalf("expected no board columns for a non-member, got %d: %+v", statusCode, columns.Data)
+	}
+	if statusCode, _ := do[any](t, "DELETE", "/boards/"+boardID, nil, other.AccessToken); statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 deleting a board as a non-member, got %d", statusCode)
+	}
+	if statusCode, _ := do[domain.SprintModel](t, "POST", "/sprints/"+sprintID+"/start", nil, other.AccessToken); statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 starting a sprint as a non-member, got %d", statusCode)
+	}
+
+	// a viewer reads but cannot write
+	addProjectMember(t, projectID, otherID, owner.AccessToken, domain.ProjectRoleViewer)
+	for _, path := range []string{"/sprints/" + sprintID, "/boards/" + boardID} {
+		if statusCode, _ := do[any](t, "GET", path, nil, other.AccessToken); statusCode != http.StatusOK {
+			t.Fatalf("expected status 200 for GET %s as a viewer, got %d", path, statusCode)
+		}
+	}
+	statusCode, columns = do[domain.BoardColumnsPagedModel](t, "GET", "/boards/"+boardID+"/columns", nil, other.AccessToken)
+	if statusCode != http.StatusOK || columns.Data == nil || len(columns.Data.Items) != 1 {
+		t.Fatalf("expected the board column for a viewer, got %d: %+v", statusCode, columns.Data)
+	}
+
+	writes := []struct {
+		method, path string
+		body         any
+	}{
+		{"POST", "/sprints", domain.SprintCreateModel{Name: randomSprintName(), ProjectID: project.ID}},
+		{"PATCH", "/sprints/" + sprintID, domain.SprintUpdateModel{Name: "Renamed by a viewer"}},
+		{"POST", "/sprints/" + sprintID + "/start", nil},
+		{"POST", "/sprints/" + sprintID + "/completed", nil},
+		{"POST", "/boards", domain.BoardCreateModel{Name: randomBoardName(), SprintID: sprint.ID}},
+		{"PATCH", "/boards/" + boardID, domain.BoardUpdateModel{Name: "Renamed by a viewer"}},
+		{"PATCH", "/boards/reorder?sprintId=" + sprintID, domain.BoardReorderModel{board.ID}},
+		{"DELETE", "/boards/" + boardID, nil},
+	}
+	for _, w := range writes {
+		statusCode, resp := do[any](t, w.method, w.path, w.body, other.AccessToken)
+		if statusCode != http.StatusForbidden || resp.Error == nil || resp.Error.Code != "insufficient_project_role" {
+			t.Fatalf("expected 403 insufficient_project_role for %s %s as a viewer, got %d: %v", w.method, w.path, statusCode, resp.Error)
+		}
+	}
+
+	// as a member the same writes go through
+	do[domain.ProjectMemberModel](t, "PATCH", "/projects/"+projectID+"/members/"+otherID, domain.ProjectMemberUpdateModel{
+		Role: domain.ProjectRoleMember,
+	}, owner.AccessToken)
+	statusCode, updated := do[domain.BoardModel](t, "PATCH", "/boards/"+boardID, domain.BoardUpdateModel{Name: "Renamed by a member"}, other.AccessToken)
+	if statusCode != http.StatusOK || updated.Data == nil || updated.Data.Name != "Renamed by a member" {
+		t.Fatalf("expected a member to rename the board, got %d: %v", statusCode, updated.Error)
+	}
+	if statusCode, resp := do[domain.SprintModel](t, "POST", "/sprints/"+sprintID+"/start", nil, other.AccessToken); statusCode != http.StatusOK {
+		t.Fatalf("expected a member to start the sprint, got %d: %v", statusCode, resp.Error)
+	}
+	if statusCode, _ := do[any](t, "DELETE", "/boards/"+boardID, nil, other.AccessToken); statusCode != http.StatusNoContent {
+		t.Fatalf("expected a member to delete the board, got %d", statusCode)
+	}
+}
+
+func TestProjectMembers_AdminOnlySettings(t *testing.T) {
+	project, owner, other, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+	addProjectMember(t, projectID, otherID, owner.AccessToken, domain.ProjectRoleMember)
+
+	calls := []struct {
+		method, path string
+		body         any
+	}{
+		{"PUT", "/projects/" + projectID + "/activity/settings", domain.ActivitySettingsUpdateModel{Mode: domain.ActivityModeNone}},
+		{"GET", "/projects/" + projectID + "/activity/webhooks", nil},
+		{"POST", "/projects/" + projectID + "/activity/webhooks", domain.ActivityWebhookCreateModel{URL: "https://audit.example.com/fluxis"}},
+		{"GET", "/projects/" + projectID + "/integrations", nil},
+		{"POST", "/projects/" + projectID + "/integrations", domain.InboundIntegrationCreateModel{Name: "Support inbox"}},
+	}
+	for _, c := range calls {
+		statusCode, resp := do[any](t, c.method, c.path, c.body, other.AccessToken)
+		if statusCode != http.StatusForbidden || resp.Error == nil || resp.Error.Code != "insufficient_project_role" {
+			t.Fatalf("expected 403 insufficient_project_role for %s %s as a member, got %d: %v", c.method, c.path, statusCode, resp.Error)
+		}
+	}
+
+	// reading the settings stays open to every member
+	statusCode, settings := do[domain.ActivitySettingsModel](t, "GET", "/projects/"+projectID+"/activity/settings", nil, other.AccessToken)
+	if statusCode != http.StatusOK || settings.Data == nil || settings.Data.Mode != domain.ActivityModeAll {
+		t.Fatalf("expected a member to read the settings, got %d: %v", statusCode, settings.Error)
+	}
+
+	statusCode, updated := do[domain.ActivitySettingsModel](t, "PUT", "/projects/"+projectID+"/activity/settings", domain.ActivitySettingsUpdateModel{
+		Mode: domain.ActivityModeNone,
+	}, owner.AccessToken)
+	if statusCode != http.StatusOK || updated.Data == nil || updated.Data.Mode != domain.ActivityModeNone {
+		t.Fatalf("expected the admin to change the settings, got %d: %v", statusCode, updated.Error)
+	}
+}
+
+func TestProjectMembers_LastAdminLeavingOrgHandsOverAdmin(t *testing.T) {
+	project, owner, other, otherID := projectWithOrgMember(t)
+	projectID := uuidToString(project.ID)
+	addProjectMember(t, projectID, otherID, owner.AccessToken, domain.ProjectRoleAdmin)
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, owner.AccessToken)
+	if me.Data == nil {
+		t.Fatal("failed to get the owner")
+	}
+	ownerID := uuidToString(me.Data.ID)
+
+	// the owner steps down in the project, leaving the other user its only admin
+	statusCode, _ := do[domain.ProjectMemberModel](t, "PATCH", "/projects/"+projectID+"/members/"+ownerID, domain.ProjectMemberUpdateModel{
+		Role: domain.ProjectRoleMember,
+	}, owner.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected the owner to step down, got %d", statusCode)
+	}
+
+	statusCode, _ = do[struct{}](t, "DELETE", "/orgs/"+uuidToString(project.OrgID)+"/members/"+otherID, nil, owner.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected the org member to be removed, got %d", statusCode)
+	}
+
+	statusCode, resp := do[domain.ProjectMembersPagedModel](t, "GET", "/projects/"+projectID+"/members", nil, owner.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 1 || resp.Data.Items[0].Role != domain.ProjectRoleAdmin {
+		t.Fatalf("expected the remaining member to become admin, got %+v", resp.Data.Items)
+	}
+
+	statusCode, _ = do[domain.ProjectModel](t, "GET", "/projects/"+projectID, nil, other.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected the removed user to lose the project, got %d", statusCode)
+	}
+}
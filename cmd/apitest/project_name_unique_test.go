@@ -0,0 +1,120 @@
+package apitest_test
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProject_UniqueNames_Conflict(t *testing.T) {
+	testProjectConfig.UniqueNames = true
+	defer func() { testProjectConfig.UniqueNames = false }()
+
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	name := "Unique Project " + randomString(8)
+	createProject(t, orgID, tokens.AccessToken, randomProjectKey(), name, "private")
+	createProject(t, orgID, tokens.AccessToken, randomProjectKey(), name+" 2", "private")
+
+	// Same name in another casing still clashes, and the suggestion skips the taken "2"
+	statusCode, resp := do[domain.ProjectModel](t, "POST", "/projects?orgId="+orgID, domain.ProjectCreateModel{
+		Key:        randomProjectKey(),
+		Name:       strings.ToUpper(name),
+		Visibility: "private",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "project_name_taken" {
+		t.Fatalf("expected project_name_taken, got %v", resp.Error)
+	}
+	if got, want := resp.Error.Details["suggestion"], strings.ToUpper(name)+" 3"; got != want {
+		t.Fatalf("expected suggestion %q, got %q", want, got)
+	}
+}
+
+func TestProject_UniqueNames_RenameToOwnName(t *testing.T) {
+	testProjectConfig.UniqueNames = true
+	defer func() { testProjectConfig.UniqueNames = false }()
+
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	name := "Renamed Project " + randomString(8)
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), name, "private")
+
+	statusCode, resp := do[domain.ProjectModel](t, "PATCH", "/projects/"+uuidToString(project.ID), domain.ProjectUpdateModel{
+		Name: strings.ToLower(name),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+}
+
+func TestProject_UniqueNames_DisabledAllowsDuplicates(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	name := "Shared Project " + randomString(8)
+	createProject(t, orgID, tokens.AccessToken, randomProjectKey(), name, "private")
+	createProject(t, orgID, tokens.AccessToken, randomProjectKey(), name, "private")
+}
+
+func TestProject_List_ExactName(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	name := "Lookup " + randomString(8)
+	exact := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), name, "private")
+	createProject(t, orgID, tokens.AccessToken, randomProjectKey(), name+" Backend", "private")
+
+	query := "/projects?orgId=" + orgID + "&name=" + url.QueryEscape(strings.ToLower(name))
+
+	// Without exactName the name still matches as a substring
+	statusCode, resp := do[domain.ProjectsPagedModel](t, "GET", query, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 2 {
+		t.Fatalf("expected 2 substring matches, got %d", len(resp.Data.Items))
+	}
+
+	statusCode, resp = do[domain.ProjectsPagedModel](t, "GET", query+"&exactName=true", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Items) != 1 || uuidToString(resp.Data.Items[0].ID) != uuidToString(exact.ID) {
+		t.Fatalf("expected only the exact match, got %d items", len(resp.Data.Items))
+	}
+}
@@ -110,8 +110,8 @@ func TestProject_UpdateVisibility_InvalidUUID(t *testing.T) {
 		Visibility: "public",
 	}, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
@@ -0,0 +1,112 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProject_Batch_FromTemplate(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	template := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Template", "private")
+	statusCode, prio := do[domain.ProjectPriorityModel](t, "POST", "/projects/"+uuidToString(template.ID)+"/priorities", domain.ProjectPriorityCreateModel{
+		Key:   "p0",
+		Name:  "Blocker",
+		Color: "#7f1d1d",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated {
+		t.Fatalf("failed to create priority: %v", prio.Error)
+	}
+
+	statusCode, resp := do[domain.ProjectBatchResultModel](t, "POST", "/projects/batch?orgId="+orgID, domain.ProjectBatchCreateModel{
+		TemplateID: template.ID,
+		Projects: []domain.ProjectCreateModel{
+			{Key: randomProjectKey(), Name: "Team A", Visibility: "private"},
+			{Key: randomProjectKey(), Name: "Team B", Visibility: "public", Status: domain.ProjectStatusPaused},
+		},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || resp.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Results) != 2 {
+		t.Fatalf("expected 2 results, got %d", len(resp.Data.Results))
+	}
+	for i, r := range resp.Data.Results {
+		if r.Index != i || r.Status != domain.BatchCreated || r.Project == nil {
+			t.Fatalf("unexpected result %d: %+v", i, r)
+		}
+	}
+	if resp.Data.Results[1].Project.Status != domain.ProjectStatusPaused {
+		t.Fatalf("expected the second project paused, got %s", resp.Data.Results[1].Project.Status)
+	}
+
+	projectID := uuidToString(resp.Data.Results[0].Project.ID)
+	statusCode, list := do[[]domain.ProjectPriorityModel](t, "GET", "/projects/"+projectID+"/priorities", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, list.Error)
+	}
+	if len(*list.Data) != 5 {
+		t.Fatalf("expected the template's 5 priorities, got %d", len(*list.Data))
+	}
+	found := false
+	for _, p := range *list.Data {
+		found = found || p.Key == "p0"
+	}
+	if !found {
+		t.Fatal("expected the template's p0 priority on the new project")
+	}
+}
+
+func TestProject_Batch_RejectsAll(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	key := randomProjectKey()
+	statusCode, resp := do[domain.ProjectBatchResultModel](t, "POST", "/projects/batch?orgId="+orgID, domain.ProjectBatchCreateModel{
+		Projects: []domain.ProjectCreateModel{
+			{Key: key, Name: "Team A", Visibility: "private"},
+			{Key: key, Name: "Team B", Visibility: "private"},
+		},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "batch_rejected" {
+		t.Fatalf("expected batch_rejected, got %+v", resp.Error)
+	}
+
+	results, _ := resp.Error.Details["results"].([]any)
+	if len(results) != 2 {
+		t.Fatalf("expected 2 results in details, got %v", resp.Error.Details)
+	}
+	first, _ := results[0].(map[string]any)
+	second, _ := results[1].(map[string]any)
+	if first["status"] != domain.BatchSkipped || second["status"] != domain.BatchRejected || second["code"] != "duplicate_key" {
+		t.Fatalf("unexpected results %v", results)
+	}
+
+	statusCode, list := do[domain.ProjectsPagedModel](t, "GET", "/projects?orgId="+orgID, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+	if list.Data.TotalCount != 0 {
+		t.Fatalf("expected no project to be created, got %d", list.Data.TotalCount)
+	}
+}
@@ -0,0 +1,177 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProject_Lifecycle_PauseAndActivate(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	// Create org and project
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	projResp := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(projResp.ID)
+
+	if projResp.Status != "active" {
+		t.Fatalf("expected new project to be 'active', got '%s'", projResp.Status)
+	}
+
+	statusCode, resp := do[domain.ProjectModel](t, "POST", "/projects/"+projectID+"/pause", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data == nil || resp.Data.Status != "paused" {
+		t.Fatalf("expected status 'paused', got %+v", resp.Data)
+	}
+
+	statusCode, resp = do[domain.ProjectModel](t, "POST", "/projects/"+projectID+"/activate", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data == nil || resp.Data.Status != "active" {
+		t.Fatalf("expected status 'active', got %+v", resp.Data)
+	}
+}
+
+func TestProject_Lifecycle_Archive(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	projResp := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(projResp.ID)
+
+	statusCode, resp := do[domain.ProjectModel](t, "POST", "/projects/"+projectID+"/archive", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data == nil || resp.Data.Status != "archived" {
+		t.Fatalf("expected status 'archived', got %+v", resp.Data)
+	}
+
+	// Archived project can be fetched and keeps its status
+	statusCode, getResp := do[domain.ProjectModel](t, "GET", "/projects/"+projectID, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || getResp.Data == nil || getResp.Data.Status != "archived" {
+		t.Fatalf("expected archived project on get, got %d", statusCode)
+	}
+}
+
+func TestProject_Lifecycle_InvalidTransition(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	projResp := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(projResp.ID)
+
+	// Activating an already active project is not a transition
+	statusCode, _ = do[domain.ProjectModel](t, "POST", "/projects/"+projectID+"/activate", nil, tokens.AccessToken)
+	if statusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", statusCode)
+	}
+
+	statusCode, _ = do[domain.ProjectModel](t, "POST", "/projects/"+projectID+"/archive", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+
+	// Archived projects cannot be paused directly
+	statusCode, resp := do[domain.ProjectModel](t, "POST", "/projects/"+projectID+"/pause", nil, tokens.AccessToken)
+	if statusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "invalid_status_transition" || resp.Error.Details["status"] != "archived" {
+		t.Fatalf("expected invalid_status_transition from archived, got %+v", resp.Error)
+	}
+}
+
+func TestProject_Lifecycle_CreateWithStatus(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	statusCode, resp := do[domain.ProjectModel](t, "POST", "/projects?orgId="+orgID, domain.ProjectCreateModel{
+		Key:        randomProjectKey(),
+		Name:       "Paused Project " + randomString(6),
+		Visibility: "private",
+		Status:     domain.ProjectStatusPaused,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Status != domain.ProjectStatusPaused {
+		t.Fatalf("expected status 'paused', got '%s'", resp.Data.Status)
+	}
+
+	// a project can not be born archived
+	statusCode, _ = do[domain.ProjectModel](t, "POST", "/projects?orgId="+orgID, domain.ProjectCreateModel{
+		Key:        randomProjectKey(),
+		Name:       "Archived Project " + randomString(6),
+		Visibility: "private",
+		Status:     domain.ProjectStatusArchived,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
+
+func TestProject_ListStatuses(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, resp := do[[]domain.ProjectStatusModel](t, "GET", "/projects/statuses", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", statusCode)
+	}
+	if resp.Data == nil || len(*resp.Data) != 3 {
+		t.Fatalf("expected 3 statuses, got %+v", resp.Data)
+	}
+	for _, s := range *resp.Data {
+		if s.Description == "" {
+			t.Errorf("status %s has no description", s.Status)
+		}
+		if s.Status == domain.ProjectStatusArchived && (len(s.Transitions) != 1 || s.Transitions[0] != domain.ProjectStatusActive) {
+			t.Errorf("expected archived to only move to active, got %v", s.Transitions)
+		}
+	}
+}
+
+func TestProject_Lifecycle_NotFound(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.ProjectModel](t, "POST", "/projects/00000000-0000-0000-0000-000000000000/pause", nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", statusCode)
+	}
+}
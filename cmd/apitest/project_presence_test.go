@@ -0,0 +1,62 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProject_Presence(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project "+randomString(8), "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+
+	statusCode, resp := do[domain.ProjectPresenceModel](t, "GET", "/projects/"+projectID+"/presence", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Viewers) != 0 || resp.Data.TTLSeconds == 0 {
+		t.Fatalf("expected no viewers and a ttl, got %+v", resp.Data)
+	}
+
+	statusCode, resp = do[domain.ProjectPresenceModel](t, "PUT", "/projects/"+projectID+"/presence", domain.ProjectPresenceHeartbeatModel{
+		BoardID: board.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Viewers) != 1 || resp.Data.Viewers[0].BoardID != board.ID {
+		t.Fatalf("expected the caller on the board, got %+v", resp.Data.Viewers)
+	}
+	me := resp.Data.Viewers[0].UserID
+
+	// a heartbeat without a body keeps the caller listed on the project
+	statusCode, resp = do[domain.ProjectPresenceModel](t, "PUT", "/projects/"+projectID+"/presence", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Viewers) != 1 || resp.Data.Viewers[0].UserID != me || resp.Data.Viewers[0].BoardID.Valid {
+		t.Fatalf("expected the caller off the board, got %+v", resp.Data.Viewers)
+	}
+
+	statusCode, _ = do[any](t, "DELETE", "/projects/"+projectID+"/presence", nil, tokens.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	statusCode, resp = do[domain.ProjectPresenceModel](t, "GET", "/projects/"+projectID+"/presence", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil || len(resp.Data.Viewers) != 0 {
+		t.Fatalf("expected no viewers after leaving, got %d: %+v", statusCode, resp.Data)
+	}
+}
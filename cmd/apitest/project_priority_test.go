@@ -0,0 +1,185 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProjectPriority_List_DefaultScheme(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	statusCode, resp := do[[]domain.ProjectPriorityModel](t, "GET", "/projects/"+uuidToString(project.ID)+"/priorities", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	want := []string{"critical", "high", "medium", "low"}
+	if len(*resp.Data) != len(want) {
+		t.Fatalf("expected %d default priorities, got %d", len(want), len(*resp.Data))
+	}
+	for i, p := range *resp.Data {
+		if p.Key != want[i] {
+			t.Fatalf("expected %s at %d, got %s", want[i], i, p.Key)
+		}
+	}
+}
+
+func TestProjectPriority_Create_UsableOnTickets(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+
+	statusCode, resp := do[domain.ProjectPriorityModel](t, "POST", "/projects/"+projectID+"/priorities", domain.ProjectPriorityCreateModel{
+		Key:   "p0",
+		Name:  "Blocker",
+		Color: "#7f1d1d",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || resp.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Position <= 4096 {
+		t.Fatalf("expected the new level after the defaults, got position %d", resp.Data.Position)
+	}
+
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "bug", "p0")
+	if ticket.Priority != "p0" {
+		t.Fatalf("expected priority p0, got %s", ticket.Priority)
+	}
+
+	// The key is unique within the project
+	statusCode, resp = do[domain.ProjectPriorityModel](t, "POST", "/projects/"+projectID+"/priorities", domain.ProjectPriorityCreateModel{
+		Key:   "p0",
+		Name:  "Another",
+		Color: "#000000",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", statusCode)
+	}
+}
+
+func TestProjectPriority_Ticket_UnknownPriority(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	statusCode, resp := do[domain.TicketModel](t, "POST", "/tickets?projectId="+uuidToString(project.ID), domain.TicketCreateModel{
+		Title:    randomTicketTitle(),
+		Type:     "task",
+		Priority: "p9",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "unknown_priority" {
+		t.Fatalf("expected unknown_priority, got %v", resp.Error)
+	}
+}
+
+func TestProjectPriority_Update(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+
+	_, list := do[[]domain.ProjectPriorityModel](t, "GET", "/projects/"+projectID+"/priorities", nil, tokens.AccessToken)
+	low := (*list.Data)[3]
+
+	statusCode, resp := do[domain.ProjectPriorityModel](t, "PATCH", "/projects/"+projectID+"/priorities/"+uuidToString(low.ID), domain.ProjectPriorityUpdateModel{
+		Name:     "Someday",
+		Position: 1,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Key != "low" || resp.Data.Name != "Someday" || resp.Data.Color != low.Color {
+		t.Fatalf("unexpected priority after update: %+v", resp.Data)
+	}
+
+	_, list = do[[]domain.ProjectPriorityModel](t, "GET", "/projects/"+projectID+"/priorities", nil, tokens.AccessToken)
+	if (*list.Data)[0].Key != "low" {
+		t.Fatalf("expected low to be listed first, got %s", (*list.Data)[0].Key)
+	}
+}
+
+func TestProjectPriority_Delete_InUse(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	_, list := do[[]domain.ProjectPriorityModel](t, "GET", "/projects/"+projectID+"/priorities", nil, tokens.AccessToken)
+	low := (*list.Data)[3]
+	path := "/projects/" + projectID + "/priorities/" + uuidToString(low.ID)
+
+	statusCode, resp := do[interface{}](t, "DELETE", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "priority_in_use" {
+		t.Fatalf("expected priority_in_use, got %v", resp.Error)
+	}
+
+	statusCode, _ = do[interface{}](t, "DELETE", path+"?replaceWith=low", nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 when replacing with itself, got %d", statusCode)
+	}
+
+	statusCode, _ = do[interface{}](t, "DELETE", path+"?replaceWith=medium", nil, tokens.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	if got := getTicket(t, uuidToString(ticket.ID), tokens.AccessToken); got.Priority != "medium" {
+		t.Fatalf("expected ticket moved to medium, got %s", got.Priority)
+	}
+}
+
+func TestProjectPriority_List_Unauthenticated(t *testing.T) {
+	projectID := "550e8400-e29b-41d4-a716-446655440000"
+
+	statusCode, _ := do[[]domain.ProjectPriorityModel](t, "GET", "/projects/"+projectID+"/priorities", nil, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
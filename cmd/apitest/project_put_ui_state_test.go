@@ -0,0 +1,140 @@
+package apitest_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProject_UIState_DefaultsToEmpty(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	statusCode, resp := do[domain.ProjectUIStateModel](t, "GET", "/projects/"+uuidToString(project.ID)+"/ui-state", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if string(resp.Data.State) != "{}" {
+		t.Fatalf("expected empty state, got %s", resp.Data.State)
+	}
+
+	if resp.Data.UpdatedAt != nil {
+		t.Fatal("expected updatedAt to be null before anything is saved")
+	}
+}
+
+func TestProject_UIState_SaveAndLoad(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	path := "/projects/" + uuidToString(project.ID) + "/ui-state"
+
+	statusCode, resp := do[domain.ProjectUIStateModel](t, "PUT", path, domain.ProjectUIStateUpdateModel{
+		State: json.RawMessage(`{"collapsedColumns":["a","b"],"sort":"priority"}`),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	statusCode, resp = do[domain.ProjectUIStateModel](t, "GET", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	var state struct {
+		CollapsedColumns []string `json:"collapsedColumns"`
+		Sort             string   `json:"sort"`
+	}
+	if err := json.Unmarshal(resp.Data.State, &state); err != nil {
+		t.Fatalf("failed to decode state: %v", err)
+	}
+	if len(state.CollapsedColumns) != 2 || state.Sort != "priority" {
+		t.Fatalf("unexpected state: %s", resp.Data.State)
+	}
+
+	// state is per user
+	other := register(t, randomEmail(), "Other User", "SecurePassword123!")
+	_, them := do[domain.UserModel](t, "GET", "/users/me", nil, other.AccessToken)
+	if them.Data == nil {
+		t.Fatal("failed to get other user")
+	}
+	do[struct{}](t, "POST", "/orgs/"+uuidToString(orgResp.Data.ID)+"/members", domain.OrganisationMemberCreateModel{
+		UserId: uuidToString(them.Data.ID),
+		Role:   "member",
+	}, tokens.AccessToken)
+	addProjectMember(t, uuidToString(project.ID), uuidToString(them.Data.ID), tokens.AccessToken, "viewer")
+	statusCode, otherResp := do[domain.ProjectUIStateModel](t, "GET", path, nil, other.AccessToken)
+	if statusCode != http.StatusOK || otherResp.Data == nil || string(otherResp.Data.State) != "{}" {
+		t.Fatalf("expected another user to see an empty state, got %d", statusCode)
+	}
+}
+
+func TestProject_UIState_Invalid(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	path := "/projects/" + uuidToString(project.ID) + "/ui-state"
+
+	statusCode, _ = do[domain.ProjectUIStateModel](t, "PUT", path, domain.ProjectUIStateUpdateModel{
+		State: json.RawMessage(`["not","an","object"]`),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+
+	large := `{"blob":"` + strings.Repeat("x", domain.MaxProjectUIStateBytes) + `"}`
+	statusCode, _ = do[domain.ProjectUIStateModel](t, "PUT", path, domain.ProjectUIStateUpdateModel{
+		State: json.RawMessage(large),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d", statusCode)
+	}
+
+	// under the limit as sent, over it once jsonb adds a space after every
+	// separator
+	var compact strings.Builder
+	compact.WriteString("{")
+	for i := 0; compact.Len() < domain.MaxProjectUIStateBytes-16; i++ {
+		if i > 0 {
+			compact.WriteString(",")
+		}
+		fmt.Fprintf(&compact, `"k%05d":1`, i)
+	}
+	compact.WriteString("}")
+	statusCode, resp := do[domain.ProjectUIStateModel](t, "PUT", path, domain.ProjectUIStateUpdateModel{
+		State: json.RawMessage(compact.String()),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusRequestEntityTooLarge || resp.Error == nil || resp.Error.Code != "ui_state_too_large" {
+		t.Fatalf("expected 413 ui_state_too_large for %d compact bytes, got %d: %v", compact.Len(), statusCode, resp.Error)
+	}
+}
@@ -0,0 +1,108 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/google/uuid"
+)
+
+func TestProject_Upsert_CreateThenReplace(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	projectID := uuid.NewString()
+	key := randomProjectKey()
+
+	statusCode, resp := do[domain.ProjectModel](t, "PUT", "/projects/"+projectID+"?orgId="+orgID, domain.ProjectCreateModel{
+		Key:        key,
+		Name:       "Imported Project",
+		Visibility: "private",
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || resp.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, resp.Error)
+	}
+	if uuidToString(resp.Data.ID) != projectID {
+		t.Fatalf("expected project ID %s, got %s", projectID, uuidToString(resp.Data.ID))
+	}
+
+	// Replaying with a new name updates in place
+	statusCode, resp = do[domain.ProjectModel](t, "PUT", "/projects/"+projectID+"?orgId="+orgID, domain.ProjectCreateModel{
+		Key:        key,
+		Name:       "Imported Project Renamed",
+		Visibility: "public",
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Name != "Imported Project Renamed" || resp.Data.Visibility != "public" {
+		t.Fatalf("expected updated project, got name %q visibility %q", resp.Data.Name, resp.Data.Visibility)
+	}
+
+	statusCode, getResp := do[domain.ProjectModel](t, "GET", "/projects/"+projectID, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || getResp.Data == nil || getResp.Data.Name != "Imported Project Renamed" {
+		t.Fatalf("expected persisted project, got %d: %v", statusCode, getResp.Error)
+	}
+}
+
+func TestProject_Upsert_KeyMismatch(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	statusCode, resp := do[domain.ProjectModel](t, "PUT", "/projects/"+uuidToString(project.ID)+"?orgId="+orgID, domain.ProjectCreateModel{
+		Key:        randomProjectKey(),
+		Name:       "Test Project",
+		Visibility: "private",
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusConflict {
+		t.Fatalf("expected status 409, got %d: %v", statusCode, resp.Error)
+	}
+}
+
+func TestProject_Upsert_MissingOrgID(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.ProjectModel](t, "PUT", "/projects/"+uuid.NewString(), domain.ProjectCreateModel{
+		Key:        randomProjectKey(),
+		Name:       "Test Project",
+		Visibility: "private",
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
+
+func TestProject_Upsert_Unauthenticated(t *testing.T) {
+	statusCode, _ := do[domain.ProjectModel](t, "PUT", "/projects/"+uuid.NewString()+"?orgId="+uuid.NewString(), domain.ProjectCreateModel{
+		Key:        randomProjectKey(),
+		Name:       "Test Project",
+		Visibility: "private",
+	}, "")
+
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
@@ -0,0 +1,87 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestProject_TicketGraph(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+
+	epic := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "epic", "medium")
+	story := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	subtask := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	loose := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "bug", "medium")
+	deleted := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+
+	ctx := context.Background()
+	if _, err := testPool.Exec(ctx, "UPDATE tickets SET epic_id = $1 WHERE id = $2", epic.ID, story.ID); err != nil {
+		t.Fatalf("failed to link story to epic: %v", err)
+	}
+	if _, err := testPool.Exec(ctx, "UPDATE tickets SET parent_id = $1, epic_id = $2 WHERE id = $3", story.ID, epic.ID, subtask.ID); err != nil {
+		t.Fatalf("failed to link subtask: %v", err)
+	}
+	if _, err := testPool.Exec(ctx, "UPDATE tickets SET parent_id = $1 WHERE id = $2", deleted.ID, loose.ID); err != nil {
+		t.Fatalf("failed to link loose ticket: %v", err)
+	}
+	if statusCode, resp := do[any](t, "DELETE", "/tickets/"+uuidToString(deleted.ID), nil, tokens.AccessToken); statusCode != http.StatusNoContent {
+		t.Fatalf("failed to delete ticket: got %d, error: %v", statusCode, resp.Error)
+	}
+
+	statusCode, resp := do[domain.TicketGraphModel](t, "GET", "/projects/"+projectID+"/graph", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	graph := *resp.Data
+
+	if len(graph.Nodes) != 4 {
+		t.Fatalf("expected 4 nodes without the deleted ticket, got %+v", graph.Nodes)
+	}
+	depths := map[string]int{}
+	for _, n := range graph.Nodes {
+		depths[n.Key] = n.Depth
+	}
+	want := map[string]int{epic.Key: 0, story.Key: 1, subtask.Key: 2, loose.Key: 0}
+	for key, d := range want {
+		if got, ok := depths[key]; !ok || got != d {
+			t.Fatalf("expected %s at depth %d, got %d (present %v)", key, d, got, ok)
+		}
+	}
+
+	type edge struct{ source, target, kind string }
+	edges := map[edge]bool{}
+	for _, e := range graph.Edges {
+		edges[edge{uuidToString(e.Source), uuidToString(e.Target), e.Kind}] = true
+	}
+	wantEdges := []edge{
+		{uuidToString(epic.ID), uuidToString(story.ID), domain.TicketGraphEdgeEpic},
+		{uuidToString(story.ID), uuidToString(subtask.ID), domain.TicketGraphEdgeSubtask},
+		{uuidToString(epic.ID), uuidToString(subtask.ID), domain.TicketGraphEdgeEpic},
+	}
+	if len(edges) != len(wantEdges) {
+		t.Fatalf("expected %d edges, got %+v", len(wantEdges), graph.Edges)
+	}
+	for _, e := range wantEdges {
+		if !edges[e] {
+			t.Fatalf("expected edge %+v, got %+v", e, graph.Edges)
+		}
+	}
+
+	statusCode, resp = do[domain.TicketGraphModel](t, "GET", "/projects/"+uuidToString(deleted.ID)+"/graph", nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 for an unknown project, got %d", statusCode)
+	}
+}
@@ -0,0 +1,91 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestReport_Dashboard_Empty(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, resp := do[domain.DashboardModel](t, "GET", "/dashboard", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data == nil {
+		t.Fatal("expected dashboard data")
+	}
+
+	if resp.Data.ActiveProjects != 0 || resp.Data.OpenTickets != 0 {
+		t.Fatalf("expected empty dashboard for a new user, got %+v", resp.Data)
+	}
+
+	if resp.Data.RecentlyCompleted == nil || resp.Data.LatestActivity == nil {
+		t.Fatal("expected empty lists instead of null")
+	}
+}
+
+func TestReport_Dashboard_Counts(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	statusCode, colResp := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Done",
+		Category: "done",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || colResp.Data == nil {
+		t.Fatalf("failed to create done column: %d", statusCode)
+	}
+
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+	done := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	statusCode, _ = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(done.ID)+"/move-board-column", domain.TicketBoardMoveModel{
+		BoardID:       board.ID,
+		BoardColumnID: colResp.Data.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("failed to move ticket to done column: %d", statusCode)
+	}
+
+	statusCode, resp := do[domain.DashboardModel](t, "GET", "/dashboard", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data.ActiveProjects != 1 {
+		t.Fatalf("expected 1 active project, got %d", resp.Data.ActiveProjects)
+	}
+	if resp.Data.OpenTickets != 1 {
+		t.Fatalf("expected 1 open ticket, got %d", resp.Data.OpenTickets)
+	}
+	if resp.Data.CompletedTickets != 1 || len(resp.Data.RecentlyCompleted) != 1 {
+		t.Fatalf("expected 1 completed ticket, got %d (%d listed)", resp.Data.CompletedTickets, len(resp.Data.RecentlyCompleted))
+	}
+	if len(resp.Data.LatestActivity) != 2 {
+		t.Fatalf("expected 2 activity entries, got %d", len(resp.Data.LatestActivity))
+	}
+}
+
+func TestReport_Dashboard_Unauthenticated(t *testing.T) {
+	statusCode, _ := do[domain.DashboardModel](t, "GET", "/dashboard", nil, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
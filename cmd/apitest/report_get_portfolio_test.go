@@ -0,0 +1,94 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestReport_Portfolio_ActiveProjects(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Active Project", "private")
+	projectID := uuidToString(project.ID)
+	paused := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Paused Project", "private")
+	do[domain.ProjectModel](t, "POST", "/projects/"+uuidToString(paused.ID)+"/pause", nil, tokens.AccessToken)
+
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	sprintID := uuidToString(sprint.ID)
+	do[domain.SprintModel](t, "POST", "/sprints/"+sprintID+"/start", nil, tokens.AccessToken)
+
+	board := createBoard(t, sprintID, tokens.AccessToken, randomBoardName())
+	statusCode, doneResp := do[domain.BoardColumnModel](t, "POST", "/boards/"+uuidToString(board.ID)+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Done",
+		Category: "done",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || doneResp.Data == nil {
+		t.Fatalf("failed to create done column: %d", statusCode)
+	}
+
+	// One sprint ticket is done, a second ticket stays open in the backlog
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	ticketID := uuidToString(ticket.ID)
+	if statusCode, _ = do[domain.TicketModel](t, "PATCH", "/tickets/"+ticketID+"/move-to-sprint?sprintId="+sprintID, nil, tokens.AccessToken); statusCode != http.StatusOK {
+		t.Fatalf("failed to move ticket to sprint: %d", statusCode)
+	}
+	moveTicketToColumn(t, ticketID, tokens.AccessToken, board.ID, doneResp.Data.ID)
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+
+	statusCode, resp := do[domain.PortfolioModel](t, "GET", "/portfolio", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.PeriodDays != 7 {
+		t.Fatalf("expected default period of 7 days, got %d", resp.Data.PeriodDays)
+	}
+	if len(resp.Data.Projects) != 1 {
+		t.Fatalf("expected only the active project, got %d", len(resp.Data.Projects))
+	}
+
+	got := resp.Data.Projects[0]
+	if uuidToString(got.ID) != projectID {
+		t.Fatalf("unexpected project %s", uuidToString(got.ID))
+	}
+	if got.OpenTickets != 1 || got.DoneTickets != 1 {
+		t.Fatalf("expected 1 open and 1 done ticket, got %+v", got)
+	}
+	if got.CompletedThisPeriod != 1 || got.CompletedLastPeriod != 0 || got.Trend != "up" {
+		t.Fatalf("expected an upward trend, got %+v", got)
+	}
+	if got.Milestone == nil || uuidToString(got.Milestone.SprintID) != sprintID {
+		t.Fatalf("expected the active sprint as milestone, got %+v", got.Milestone)
+	}
+	if got.Milestone.TotalTickets != 1 || got.Milestone.Progress != 100 {
+		t.Fatalf("unexpected milestone progress: %+v", got.Milestone)
+	}
+}
+
+func TestReport_Portfolio_InvalidPeriod(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, resp := do[domain.PortfolioModel](t, "GET", "/portfolio?periodDays=365", nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "invalid_period" {
+		t.Fatalf("expected invalid_period, got %v", resp.Error)
+	}
+}
+
+func TestReport_Portfolio_Unauthenticated(t *testing.T) {
+	statusCode, _ := do[domain.PortfolioModel](t, "GET", "/portfolio", nil, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
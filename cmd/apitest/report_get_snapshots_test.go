@@ -0,0 +1,85 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestReport_Snapshots(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	statusCode, colResp := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Doing",
+		Category: "in_progress",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || colResp.Data == nil {
+		t.Fatalf("failed to create column: %d", statusCode)
+	}
+	column := colResp.Data.ID
+
+	today := time.Now().UTC()
+	yesterday := today.AddDate(0, 0, -1)
+
+	first := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "high")
+	moveTicketToColumn(t, uuidToString(first.ID), tokens.AccessToken, board.ID, column)
+	if _, err := testReportSvc.TakeBoardSnapshots(context.Background(), yesterday); err != nil {
+		t.Fatalf("failed to take snapshot: %v", err)
+	}
+
+	second := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "high")
+	moveTicketToColumn(t, uuidToString(second.ID), tokens.AccessToken, board.ID, column)
+	// the second run of a day replaces the first
+	for range 2 {
+		if _, err := testReportSvc.TakeBoardSnapshots(context.Background(), today); err != nil {
+			t.Fatalf("failed to take snapshot: %v", err)
+		}
+	}
+
+	from, to := yesterday.Format("2006-01-02"), today.Format("2006-01-02")
+	path := "/projects/" + projectID + "/reports/snapshots?boardId=" + boardID + "&from=" + from + "&to=" + to
+	statusCode, resp := do[domain.BoardSnapshotsModel](t, "GET", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.From != from || resp.Data.To != to || len(resp.Data.Days) != 2 {
+		t.Fatalf("expected 2 days from %s to %s, got %+v", from, to, resp.Data)
+	}
+
+	for i, want := range []int64{1, 2} {
+		day := resp.Data.Days[i]
+		var count int64 = -1
+		for _, c := range day.Columns {
+			if c.BoardID != board.ID {
+				t.Fatalf("expected only the board's columns, got %+v", c)
+			}
+			if c.BoardColumnID == column {
+				count = c.TicketCount
+			}
+		}
+		if count != want {
+			t.Fatalf("day %s: expected %d tickets in the column, got %d", day.Date, want, count)
+		}
+	}
+
+	statusCode, resp = do[domain.BoardSnapshotsModel](t, "GET", "/projects/"+projectID+"/reports/snapshots?from="+to+"&to="+from, nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest || resp.Error == nil || resp.Error.Code != "invalid_date_range" {
+		t.Fatalf("expected 400 invalid_date_range, got %d: %v", statusCode, resp.Error)
+	}
+}
@@ -0,0 +1,192 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestReport_Stats_PriorityBreakdown(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+
+	statusCode, colResp := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Done",
+		Category: "done",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || colResp.Data == nil {
+		t.Fatalf("failed to create done column: %d", statusCode)
+	}
+
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "high")
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "high")
+	done := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "bug", "high")
+	moveTicketToColumn(t, uuidToString(done.ID), tokens.AccessToken, board.ID, colResp.Data.ID)
+
+	statusCode, resp := do[domain.ProjectStatsModel](t, "GET", "/projects/"+projectID+"/reports/stats", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if len(resp.Data.Priorities) != 4 {
+		t.Fatalf("expected every level of the scheme, got %d", len(resp.Data.Priorities))
+	}
+
+	high := resp.Data.Priorities[1]
+	if high.Key != "high" || high.OpenTickets != 2 || high.DoneTickets != 1 {
+		t.Fatalf("unexpected high priority stats: %+v", high)
+	}
+	if low := resp.Data.Priorities[3]; low.OpenTickets != 0 || low.DoneTickets != 0 {
+		t.Fatalf("expected no low priority tickets, got %+v", low)
+	}
+
+	// Scoped to the board only the moved ticket counts
+	statusCode, resp = do[domain.ProjectStatsModel](t, "GET", "/projects/"+projectID+"/reports/stats?boardId="+boardID, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if high := resp.Data.Priorities[1]; high.OpenTickets != 0 || high.DoneTickets != 1 {
+		t.Fatalf("unexpected board scoped stats: %+v", high)
+	}
+}
+
+func TestReport_Stats_EffortRollup(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, userResp := do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || userResp.Data == nil {
+		t.Fatalf("failed to get current user: %d", statusCode)
+	}
+	me := userResp.Data.ID
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+	todo := createBoardColumn(t, boardID, tokens.AccessToken, "To Do")
+
+	statusCode, doneResp := do[domain.BoardColumnModel](t, "POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{
+		Name:     "Done",
+		Category: "done",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || doneResp.Data == nil {
+		t.Fatalf("failed to create done column: %d", statusCode)
+	}
+	done := *doneResp.Data
+
+	create := func(points int32, assignee bool) domain.TicketModel {
+		body := domain.TicketCreateModel{
+			Title:       randomTicketTitle(),
+			Type:        "task",
+			Priority:    "medium",
+			StoryPoints: points,
+		}
+		if assignee {
+			body.AssigneeID = me
+		}
+		statusCode, resp := do[domain.TicketModel](t, "POST", "/tickets?projectId="+projectID, body, tokens.AccessToken)
+		if statusCode != http.StatusCreated || resp.Data == nil {
+			t.Fatalf("failed to create ticket: %d %v", statusCode, resp.Error)
+		}
+		return *resp.Data
+	}
+
+	a := create(3, true)
+	b := create(5, true)
+	c := create(8, false)
+	create(0, false)
+
+	moveTicketToColumn(t, uuidToString(a.ID), tokens.AccessToken, board.ID, todo.ID)
+	moveTicketToColumn(t, uuidToString(b.ID), tokens.AccessToken, board.ID, done.ID)
+	moveTicketToColumn(t, uuidToString(c.ID), tokens.AccessToken, board.ID, todo.ID)
+
+	statusCode, resp := do[domain.ProjectStatsModel](t, "GET", "/projects/"+projectID+"/reports/stats", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	effort := resp.Data.Effort
+	if effort.TotalEstimate != 16 || effort.CompletedEstimate != 5 || effort.RemainingEstimate != 11 || effort.UnestimatedTickets != 1 {
+		t.Fatalf("unexpected effort totals: %+v", effort)
+	}
+
+	columns := map[string]domain.ColumnEffortModel{}
+	for _, col := range resp.Data.Columns {
+		columns[uuidToString(col.BoardColumnID)] = col
+	}
+	if got := columns[uuidToString(todo.ID)]; got.TicketCount != 2 || got.TotalEstimate != 11 {
+		t.Fatalf("unexpected to do column effort: %+v", got)
+	}
+	if got := columns[uuidToString(done.ID)]; got.TicketCount != 1 || got.TotalEstimate != 5 || got.Category != "done" {
+		t.Fatalf("unexpected done column effort: %+v", got)
+	}
+
+	if len(resp.Data.Assignees) != 2 {
+		t.Fatalf("expected an assignee row and an unassigned row, got %d", len(resp.Data.Assignees))
+	}
+	for _, row := range resp.Data.Assignees {
+		if row.AssigneeID.Valid {
+			if row.AssigneeName != "Test User" || row.TotalEstimate != 8 || row.RemainingEstimate != 3 {
+				t.Fatalf("unexpected assignee effort: %+v", row)
+			}
+		} else if row.TicketCount != 2 || row.TotalEstimate != 8 || row.RemainingEstimate != 8 {
+			t.Fatalf("unexpected unassigned effort: %+v", row)
+		}
+	}
+}
+
+func TestReport_Stats_BoardFromAnotherProject(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	other := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Other Project", "private")
+	sprint := createSprint(t, uuidToString(other.ID), tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+
+	statusCode, resp := do[domain.ProjectStatsModel](t, "GET", "/projects/"+uuidToString(project.ID)+"/reports/stats?boardId="+uuidToString(board.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+	if resp.Error == nil || resp.Error.Code != "board_not_in_project" {
+		t.Fatalf("expected board_not_in_project, got %v", resp.Error)
+	}
+}
+
+func TestReport_Stats_Unauthenticated(t *testing.T) {
+	projectID := "550e8400-e29b-41d4-a716-446655440000"
+
+	statusCode, _ := do[domain.ProjectStatsModel](t, "GET", "/projects/"+projectID+"/reports/stats", nil, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
@@ -0,0 +1,99 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestReport_Weekly_CurrentWeek(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "bug", "high")
+
+	statusCode, resp := do[domain.WeeklyReportModel](t, "GET", "/projects/"+projectID+"/reports/weekly", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data == nil {
+		t.Fatal("expected report data")
+	}
+
+	if len(resp.Data.Created) != 2 {
+		t.Fatalf("expected 2 created tickets, got %d", len(resp.Data.Created))
+	}
+
+	if len(resp.Data.Completed) != 0 || len(resp.Data.CarriedOver) != 0 {
+		t.Fatalf("expected nothing completed or carried over, got %+v", resp.Data)
+	}
+}
+
+func TestReport_Weekly_PastWeek(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	statusCode, resp := do[domain.WeeklyReportModel](t, "GET", "/projects/"+projectID+"/reports/weekly?week=2020-W53", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data.Week != "2020-W53" {
+		t.Fatalf("expected week '2020-W53', got '%s'", resp.Data.Week)
+	}
+
+	if len(resp.Data.Created) != 0 {
+		t.Fatalf("expected no tickets in a past week, got %d", len(resp.Data.Created))
+	}
+}
+
+func TestReport_Weekly_InvalidWeek(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	for _, week := range []string{"2025-32", "2021-W53", "2025-W00"} {
+		statusCode, _ := do[domain.WeeklyReportModel](t, "GET", "/projects/"+uuidToString(project.ID)+"/reports/weekly?week="+week, nil, tokens.AccessToken)
+		if statusCode != http.StatusBadRequest {
+			t.Fatalf("week %s: expected status 400, got %d", week, statusCode)
+		}
+	}
+}
+
+func TestReport_Weekly_ProjectNotFound(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.WeeklyReportModel](t, "GET", "/projects/00000000-0000-0000-0000-000000000000/reports/weekly", nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", statusCode)
+	}
+}
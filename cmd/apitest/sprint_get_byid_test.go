@@ -60,8 +60,8 @@ func TestSprint_GetByID_InvalidUUID(t *testing.T) {
 
 	statusCode, _ := do[domain.SprintModel](t, "GET", "/sprints/not-a-uuid", nil, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
@@ -134,3 +134,25 @@ func TestSprint_GetByID_ResponseStructure(t *testing.T) {
 		t.Fatalf("expected goal '%s', got '%s'", goal, resp.Data.Goal)
 	}
 }
+
+func TestSprint_GetByID_Timestamps(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	sprint := createSprint(t, uuidToString(project.ID), tokens.AccessToken, randomSprintName())
+
+	statusCode, resp := do[map[string]any](t, "GET", "/sprints/"+uuidToString(sprint.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	assertTimestamps(t, *resp.Data)
+}
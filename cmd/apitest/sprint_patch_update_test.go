@@ -141,8 +141,8 @@ func TestSprint_Update_InvalidUUID(t *testing.T) {
 		Name: "Updated Name",
 	}, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
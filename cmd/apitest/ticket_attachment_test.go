@@ -0,0 +1,106 @@
+package apitest_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+// uploadAttachment posts content as the given multipart field
+func uploadAttachment(tb testing.TB, ticketID, field, filename string, content []byte, token string) (int, apiResponse[domain.AttachmentModel]) {
+	tb.Helper()
+
+	var buf bytes.Buffer
+	form := multipart.NewWriter(&buf)
+	part, err := form.CreateFormFile(field, filename)
+	if err != nil {
+		tb.Fatalf("failed to create form file: %v", err)
+	}
+	part.Write(content)
+	form.Close()
+
+	resp, body := dav(tb, "POST", "/tickets/"+ticketID+"/attachments", buf.String(), "", "", map[string]string{
+		"Authorization": "Bearer " + token,
+		"Content-Type":  form.FormDataContentType(),
+	})
+	var result apiResponse[domain.AttachmentModel]
+	if err := json.Unmarshal([]byte(body), &result); err != nil {
+		tb.Fatalf("failed to decode upload response %q: %v", body, err)
+	}
+	return resp.StatusCode, result
+}
+
+func TestTicket_Attachments(t *testing.T) {
+	tokens := register(t, randomEmail(), "Attachment Owner", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project "+randomString(8), "private")
+	ticket := createTicket(t, uuidToString(project.ID), tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	ticketID := uuidToString(ticket.ID)
+	path := "/tickets/" + ticketID + "/attachments"
+
+	content := []byte("line one\nline two\n")
+	statusCode, uploaded := uploadAttachment(t, ticketID, "file", "notes.txt", content, tokens.AccessToken)
+	if statusCode != http.StatusCreated || uploaded.Data == nil {
+		t.Fatalf("expected status 201, got %d: %v", statusCode, uploaded.Error)
+	}
+	attachment := *uploaded.Data
+	if attachment.Filename != "notes.txt" || attachment.Size != int64(len(content)) || len(attachment.Checksum) != 64 {
+		t.Fatalf("unexpected attachment: %+v", attachment)
+	}
+	if uuidToString(attachment.TicketID) != ticketID {
+		t.Fatalf("expected the attachment to belong to the ticket, got %s", uuidToString(attachment.TicketID))
+	}
+
+	statusCode, list := do[[]domain.AttachmentModel](t, "GET", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil || len(*list.Data) != 1 {
+		t.Fatalf("expected one attachment, got %d: %+v", statusCode, list.Data)
+	}
+
+	bearer := map[string]string{"Authorization": "Bearer " + tokens.AccessToken}
+	filePath := path + "/" + uuidToString(attachment.ID)
+	resp, body := dav(t, "GET", filePath, "", "", "", bearer)
+	if resp.StatusCode != http.StatusOK || body != string(content) {
+		t.Fatalf("expected the uploaded content, got %d: %q", resp.StatusCode, body)
+	}
+	if cd := resp.Header.Get("Content-Disposition"); !strings.HasPrefix(cd, "attachment") || !strings.Contains(cd, "notes.txt") {
+		t.Fatalf("expected a download named notes.txt, got %q", cd)
+	}
+	if etag := resp.Header.Get("ETag"); etag != `"`+attachment.Checksum+`"` {
+		t.Fatalf("expected the checksum as ETag, got %q", etag)
+	}
+
+	statusCode, missing := uploadAttachment(t, ticketID, "other", "notes.txt", content, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 without a file field, got %d: %v", statusCode, missing.Error)
+	}
+
+	statusCode, empty := uploadAttachment(t, ticketID, "file", "empty.txt", nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest || empty.Error == nil || empty.Error.Code != "attachment_empty" {
+		t.Fatalf("expected 400 attachment_empty, got %d: %v", statusCode, empty.Error)
+	}
+
+	statusCode, large := uploadAttachment(t, ticketID, "file", "large.bin", make([]byte, testAttachmentMaxSize+1), tokens.AccessToken)
+	if statusCode != http.StatusRequestEntityTooLarge || large.Error == nil || large.Error.Code != "attachment_too_large" {
+		t.Fatalf("expected 413 attachment_too_large, got %d: %v", statusCode, large.Error)
+	}
+
+	resp, body = dav(t, "DELETE", filePath, "", "", "", bearer)
+	if resp.StatusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d: %s", resp.StatusCode, body)
+	}
+	resp, _ = dav(t, "GET", filePath, "", "", "", bearer)
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404 after delete, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,87 @@
+package apitest_test
+
+import (
+	"net/http"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+const benchSeedTickets = 200
+
+// seedBenchProject creates a project with one board and benchSeedTickets tickets
+func seedBenchProject(b *testing.B) (token, projectID, boardID string) {
+	tokens := register(b, randomEmail(), "Bench User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](b, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Bench Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		b.Fatalf("failed to create org")
+	}
+
+	project := createProject(b, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Bench Project", "private")
+	projectID = uuidToString(project.ID)
+
+	sprint := createSprint(b, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(b, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	for _, name := range []string{"Todo", "Doing", "Done"} {
+		createBoardColumn(b, uuidToString(board.ID), tokens.AccessToken, name)
+	}
+
+	for range benchSeedTickets {
+		createTicket(b, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+	}
+
+	return tokens.AccessToken, projectID, uuidToString(board.ID)
+}
+
+// benchLatency runs fn b.N times and reports p50/p95 next to ns/op so slow
+// outliers in the paged CTE queries show up even when the mean looks fine
+func benchLatency(b *testing.B, fn func()) {
+	samples := make([]time.Duration, 0, b.N)
+
+	b.ResetTimer()
+	for range b.N {
+		start := time.Now()
+		fn()
+		samples = append(samples, time.Since(start))
+	}
+	b.StopTimer()
+
+	slices.Sort(samples)
+	b.ReportMetric(float64(percentile(samples, 50).Microseconds())/1000, "p50-ms")
+	b.ReportMetric(float64(percentile(samples, 95).Microseconds())/1000, "p95-ms")
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted)*p+99)/100 - 1
+	return sorted[max(idx, 0)]
+}
+
+func BenchmarkTicket_List(b *testing.B) {
+	token, projectID, _ := seedBenchProject(b)
+
+	benchLatency(b, func() {
+		statusCode, _ := do[domain.TicketsPagedModel](b, "GET", "/tickets?projectId="+projectID+"&pageSize=50&pageNumber=2", nil, token)
+		if statusCode != http.StatusOK {
+			b.Fatalf("expected status 200, got %d", statusCode)
+		}
+	})
+}
+
+func BenchmarkBoardColumn_ListWithCounts(b *testing.B) {
+	token, _, boardID := seedBenchProject(b)
+
+	benchLatency(b, func() {
+		statusCode, _ := do[domain.BoardColumnsPagedModel](b, "GET", "/boards/"+boardID+"/columns?includeCounts=true", nil, token)
+		if statusCode != http.StatusOK {
+			b.Fatalf("expected status 200, got %d", statusCode)
+		}
+	})
+}
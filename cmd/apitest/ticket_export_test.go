@@ -0,0 +1,76 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestTicket_Export(t *testing.T) {
+	tokens := register(t, randomEmail(), "Export Owner", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project "+randomString(8), "private")
+	projectID := uuidToString(project.ID)
+
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "high")
+	path := "/tickets/" + uuidToString(ticket.ID)
+	statusCode, updated := do[domain.TicketModel](t, "PATCH", path, domain.TicketUpdateModel{
+		Description: "Steps to reproduce the issue",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("failed to update ticket: %v", updated.Error)
+	}
+
+	// the API has no way to nest tickets yet
+	subtask := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	if _, err := testPool.Exec(context.Background(), "UPDATE tickets SET parent_id = $2 WHERE id = $1", subtask.ID, ticket.ID); err != nil {
+		t.Fatalf("failed to nest subtask: %v", err)
+	}
+
+	bearer := map[string]string{"Authorization": "Bearer " + tokens.AccessToken}
+	resp, body := dav(t, "GET", path+"/export", "", "", "", bearer)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d: %s", resp.StatusCode, body)
+	}
+	if ct := resp.Header.Get("Content-Type"); !strings.HasPrefix(ct, "text/markdown") {
+		t.Fatalf("expected markdown, got %q", ct)
+	}
+	if cd := resp.Header.Get("Content-Disposition"); !strings.Contains(cd, ticket.Key+".md") {
+		t.Fatalf("expected a filename named after the key, got %q", cd)
+	}
+	for _, want := range []string{
+		"# " + ticket.Key + ": " + ticket.Title,
+		"- **Priority:** high",
+		"- **Reporter:** Export Owner",
+		"Steps to reproduce the issue",
+		"## Checklist (0/1 done)",
+		"- [ ] " + subtask.Key + " " + subtask.Title,
+		"updated once",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected the export to contain %q, got:\n%s", want, body)
+		}
+	}
+
+	resp, body = dav(t, "GET", path+"/export?format=txt", "", "", "", bearer)
+	if resp.StatusCode != http.StatusOK || !strings.HasPrefix(resp.Header.Get("Content-Type"), "text/plain") {
+		t.Fatalf("expected plain text, got %d %q", resp.StatusCode, resp.Header.Get("Content-Type"))
+	}
+	if !strings.Contains(body, "Priority: high") || !strings.Contains(body, "[ ] "+subtask.Key) || strings.Contains(body, "**") {
+		t.Fatalf("expected a plain text export, got:\n%s", body)
+	}
+
+	resp, body = dav(t, "GET", path+"/export?format=pdf", "", "", "", bearer)
+	if resp.StatusCode != http.StatusBadRequest || !strings.Contains(body, "invalid_export_format") {
+		t.Fatalf("expected 400 invalid_export_format, got %d: %s", resp.StatusCode, body)
+	}
+}
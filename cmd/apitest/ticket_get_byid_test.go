@@ -124,4 +124,37 @@ func TestTicket_GetByID_ResponseStructure(t *testing.T) {
 	if resp.Data.Key == "" {
 		t.Fatal("expected Key field in response")
 	}
+
+	links := resp.Data.Links
+	if links == nil {
+		t.Fatal("expected links in response")
+	}
+	if links.Self != "/tickets/"+ticketID || links.Project != "/projects/"+projectID {
+		t.Fatalf("unexpected links %+v", links)
+	}
+	if links.Logs != "/projects/"+projectID+"/changes" {
+		t.Fatalf("expected logs link to the project changes, got %q", links.Logs)
+	}
+}
+
+func TestTicket_GetByID_Timestamps(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	ticket := createTicket(t, uuidToString(project.ID), tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	statusCode, resp := do[map[string]any](t, "GET", "/tickets/"+uuidToString(ticket.ID), nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	assertTimestamps(t, *resp.Data)
 }
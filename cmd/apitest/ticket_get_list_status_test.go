@@ -0,0 +1,101 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestTicket_ListByStatus(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+
+	columns := map[string]domain.BoardColumnModel{}
+	for name, category := range map[string]string{"To Do": "todo", "In Progress": "in_progress", "Done": "done", "Готово": "todo", "Проверка": "todo"} {
+		statusCode, resp := do[domain.BoardColumnModel](t, "POST", "/boards/"+uuidToString(board.ID)+"/columns", domain.BoardColumnCreateModel{
+			Name:     name,
+			Category: category,
+		}, tokens.AccessToken)
+		if statusCode != http.StatusCreated || resp.Data == nil {
+			t.Fatalf("failed to create column %s: %v", name, resp.Error)
+		}
+		columns[name] = *resp.Data
+	}
+
+	place := func(ticket domain.TicketModel, column string) {
+		if _, err := testPool.Exec(context.Background(), "UPDATE tickets SET sprint_id = $1, board_id = $2, board_column_id = $3 WHERE id = $4",
+			sprint.ID, board.ID, columns[column].ID, ticket.ID); err != nil {
+			t.Fatalf("failed to place ticket: %v", err)
+		}
+	}
+	doing := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	place(doing, "In Progress")
+	done := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	place(done, "Done")
+	ready := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	place(ready, "Готово")
+	review := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	place(review, "Проверка")
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+
+	list := func(query url.Values) (int, []domain.TicketModel) {
+		t.Helper()
+		query.Set("projectId", projectID)
+		statusCode, resp := do[domain.TicketsPagedModel](t, "GET", "/tickets?"+query.Encode(), nil, tokens.AccessToken)
+		if resp.Data == nil {
+			return statusCode, nil
+		}
+		return statusCode, resp.Data.Items
+	}
+
+	cases := []struct {
+		name  string
+		query url.Values
+		want  []domain.TicketModel
+	}{
+		{"category", url.Values{"statusCategory": {"done"}}, []domain.TicketModel{done}},
+		{"categories", url.Values{"statusCategory": {"in_progress", "done"}}, []domain.TicketModel{done, doing}},
+		{"slug", url.Values{"status": {"in-progress"}}, []domain.TicketModel{doing}},
+		{"name", url.Values{"status": {"In Progress"}}, []domain.TicketModel{doing}},
+		{"column id", url.Values{"boardColumnId": {uuidToString(columns["Done"].ID)}}, []domain.TicketModel{done}},
+		{"empty column", url.Values{"status": {"to-do"}}, nil},
+		// cyrillic names keep their letters, so one column does not match the other
+		{"cyrillic", url.Values{"status": {"Готово"}}, []domain.TicketModel{ready}},
+		{"cyrillic lowercase", url.Values{"status": {"готово"}}, []domain.TicketModel{ready}},
+	}
+	for _, c := range cases {
+		statusCode, items := list(c.query)
+		if statusCode != http.StatusOK || len(items) != len(c.want) {
+			t.Fatalf("%s: expected %d tickets, got %d: %+v", c.name, len(c.want), statusCode, items)
+		}
+		for i, want := range c.want {
+			if items[i].ID != want.ID {
+				t.Fatalf("%s: expected %s at %d, got %s", c.name, want.Key, i, items[i].Key)
+			}
+		}
+	}
+
+	statusCode, _ = list(url.Values{"statusCategory": {"finished"}})
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an unknown category, got %d", statusCode)
+	}
+
+	statusCode, _ = list(url.Values{"status": {"!!!"}})
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for a status without a slug, got %d", statusCode)
+	}
+}
@@ -2,6 +2,7 @@ package apitest_test
 
 import (
 	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
@@ -172,6 +173,10 @@ func TestTicket_List_WithPagination(t *testing.T) {
 		t.Fatalf("expected 3 total pages, got %d", resp.Data.TotalPages)
 	}
 
+	if !resp.Data.HasMore {
+		t.Fatal("expected hasMore on page 1")
+	}
+
 	// Get second page
 	statusCode, resp2 := do[domain.TicketsPagedModel](t, "GET", "/tickets?projectId="+projectID+"&pageNumber=2&pageSize=2", nil, tokens.AccessToken)
 
@@ -197,6 +202,50 @@ func TestTicket_List_WithPagination(t *testing.T) {
 	if len(resp3.Data.Items) != 1 {
 		t.Fatalf("expected 1 ticket on page 3, got %d", len(resp3.Data.Items))
 	}
+
+	if resp3.Data.HasMore {
+		t.Fatal("expected no more pages after page 3")
+	}
+}
+
+func TestTicket_List_LinkHeader(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+
+	for i := 0; i < 3; i++ {
+		createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	}
+
+	req, _ := http.NewRequest("GET", testServer.URL+"/tickets?projectId="+projectID+"&pageNumber=2&pageSize=1", nil)
+	req.Header.Set("Authorization", "Bearer "+tokens.AccessToken)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to perform request: %v", err)
+	}
+	resp.Body.Close()
+
+	link := resp.Header.Get("Link")
+	for _, want := range []string{
+		`</tickets?pageNumber=1&pageSize=1&projectId=` + projectID + `>; rel="first"`,
+		`</tickets?pageNumber=1&pageSize=1&projectId=` + projectID + `>; rel="prev"`,
+		`</tickets?pageNumber=3&pageSize=1&projectId=` + projectID + `>; rel="next"`,
+		`</tickets?pageNumber=3&pageSize=1&projectId=` + projectID + `>; rel="last"`,
+	} {
+		if !strings.Contains(link, want) {
+			t.Fatalf("expected Link to contain %s, got %s", want, link)
+		}
+	}
 }
 
 func TestTicket_List_InvalidProjectId(t *testing.T) {
@@ -469,3 +518,55 @@ func TestTicket_List_EmptyIdArray(t *testing.T) {
 		t.Fatalf("expected 3 tickets (no id filter), got %d", len(resp.Data.Items))
 	}
 }
+
+func TestTicket_List_IncludeDeletedForbidden(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+
+	statusCode, _ = do[domain.TicketsPagedModel](t, "GET", "/tickets?projectId="+uuidToString(project.ID)+"&includeDeleted=true", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403, got %d", statusCode)
+	}
+}
+
+func TestTicket_List_IncludeDeletedAsAdmin(t *testing.T) {
+	tokens := adminTokens(t)
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	status, _ := do[domain.TicketModel](t, "DELETE", "/tickets/"+uuidToString(ticket.ID), nil, tokens.AccessToken)
+	if status != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", status)
+	}
+
+	statusCode, resp := do[domain.TicketsPagedModel](t, "GET", "/tickets?projectId="+projectID+"&includeDeleted=true", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	if len(resp.Data.Items) != 1 {
+		t.Fatalf("expected 1 ticket, got %d", len(resp.Data.Items))
+	}
+	if resp.Data.Items[0].DeletedAt == nil {
+		t.Fatal("expected deletedAt to be set")
+	}
+}
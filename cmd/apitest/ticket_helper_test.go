@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 func createTicket(tb testing.TB, projectID string, token string, title, ticketType, priority string) domain.TicketModel {
@@ -39,6 +40,23 @@ func getTicket(tb testing.TB, ticketID string, token string) domain.TicketModel
 	return *resp.Data
 }
 
+func moveTicketToColumn(tb testing.TB, ticketID string, token string, boardID, columnID pgtype.UUID) domain.TicketModel {
+	statusCode, resp := do[domain.TicketModel](tb, "PATCH", "/tickets/"+ticketID+"/move-board-column", domain.TicketBoardMoveModel{
+		BoardID:       boardID,
+		BoardColumnID: columnID,
+	}, token)
+
+	if statusCode != http.StatusOK {
+		tb.Fatalf("move ticket to column failed: got status %d, error: %v", statusCode, resp.Error)
+	}
+
+	if resp.Data == nil {
+		tb.Fatalf("move ticket to column returned nil data")
+	}
+
+	return *resp.Data
+}
+
 func randomTicketTitle() string {
 	return "Ticket " + randomString(8)
 }
@@ -0,0 +1,82 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestTicket_Lock(t *testing.T) {
+	owner := register(t, randomEmail(), "User One", "SecurePassword123!")
+	other := register(t, randomEmail(), "User Two", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, owner.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, owner.AccessToken)
+	_, them := do[domain.UserModel](t, "GET", "/users/me", nil, other.AccessToken)
+	if me.Data == nil || them.Data == nil {
+		t.Fatal("failed to get users")
+	}
+	do[struct{}](t, "POST", "/orgs/"+uuidToString(orgResp.Data.ID)+"/members", domain.OrganisationMemberCreateModel{
+		UserId: uuidToString(them.Data.ID),
+		Role:   "member",
+	}, owner.AccessToken)
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), owner.AccessToken, randomProjectKey(), "Test Project "+randomString(8), "private")
+	addProjectMember(t, uuidToString(project.ID), uuidToString(them.Data.ID), owner.AccessToken, "member")
+	ticket := createTicket(t, uuidToString(project.ID), owner.AccessToken, randomTicketTitle(), "story", "medium")
+	path := "/tickets/" + uuidToString(ticket.ID)
+
+	statusCode, lock := do[domain.TicketLockModel](t, "POST", path+"/lock", nil, owner.AccessToken)
+	if statusCode != http.StatusOK || lock.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, lock.Error)
+	}
+	if !lock.Data.Locked || lock.Data.HolderID != me.Data.ID || lock.Data.TTLSeconds == 0 {
+		t.Fatalf("expected the lock held by the owner, got %+v", lock.Data)
+	}
+
+	statusCode, lock = do[domain.TicketLockModel](t, "GET", path+"/lock", nil, other.AccessToken)
+	if statusCode != http.StatusOK || lock.Data == nil || !lock.Data.Locked {
+		t.Fatalf("expected the other user to see the lock, got %d: %+v", statusCode, lock.Data)
+	}
+
+	statusCode, lock = do[domain.TicketLockModel](t, "POST", path+"/lock", nil, other.AccessToken)
+	if statusCode != http.StatusLocked || lock.Error == nil || lock.Error.Code != "ticket_locked" {
+		t.Fatalf("expected 423 ticket_locked, got %d: %+v", statusCode, lock.Error)
+	}
+
+	statusCode, updated := do[domain.TicketModel](t, "PATCH", path, domain.TicketUpdateModel{
+		Description: "overwritten",
+	}, other.AccessToken)
+	if statusCode != http.StatusLocked {
+		t.Fatalf("expected 423 for the other user's update, got %d: %v", statusCode, updated.Error)
+	}
+
+	statusCode, updated = do[domain.TicketModel](t, "PATCH", path, domain.TicketUpdateModel{
+		Description: "edited by the holder",
+	}, owner.AccessToken)
+	if statusCode != http.StatusOK || updated.Data == nil || updated.Data.Description != "edited by the holder" {
+		t.Fatalf("expected the holder's update to apply, got %d: %v", statusCode, updated.Error)
+	}
+
+	statusCode, _ = do[any](t, "DELETE", path+"/lock", nil, other.AccessToken)
+	if statusCode != http.StatusLocked {
+		t.Fatalf("expected the other user not to release the lock, got %d", statusCode)
+	}
+	statusCode, _ = do[any](t, "DELETE", path+"/lock", nil, owner.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	statusCode, updated = do[domain.TicketModel](t, "PATCH", path, domain.TicketUpdateModel{
+		Description: "edited after release",
+	}, other.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("expected the update to apply once released, got %d: %v", statusCode, updated.Error)
+	}
+}
@@ -0,0 +1,160 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestTicket_MovePosition_BetweenNeighbours(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	column := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, randomBoardColumnName())
+
+	var placed []domain.TicketModel
+	for range 3 {
+		ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+		placed = append(placed, moveTicketToColumn(t, uuidToString(ticket.ID), tokens.AccessToken, board.ID, column.ID))
+	}
+
+	// tickets entering a column are appended in arrival order
+	if !(placed[0].Rank < placed[1].Rank && placed[1].Rank < placed[2].Rank) {
+		t.Fatalf("expected ascending ranks, got %q %q %q", placed[0].Rank, placed[1].Rank, placed[2].Rank)
+	}
+
+	// Ticket 3 goes between ticket 1 and ticket 2
+	statusCode, resp := do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(placed[2].ID)+"/position", domain.TicketPositionModel{
+		AfterID: placed[0].ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.Rank <= placed[0].Rank || resp.Data.Rank >= placed[1].Rank {
+		t.Fatalf("expected rank between %q and %q, got %q", placed[0].Rank, placed[1].Rank, resp.Data.Rank)
+	}
+
+	// Neighbours were not rewritten
+	if getTicket(t, uuidToString(placed[0].ID), tokens.AccessToken).Rank != placed[0].Rank ||
+		getTicket(t, uuidToString(placed[1].ID), tokens.AccessToken).Rank != placed[1].Rank {
+		t.Fatal("expected sibling ranks to be untouched")
+	}
+}
+
+func TestTicket_MovePosition_RepeatedSplits(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	column := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, randomBoardColumnName())
+
+	var placed []domain.TicketModel
+	for range 4 {
+		ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+		placed = append(placed, moveTicketToColumn(t, uuidToString(ticket.ID), tokens.AccessToken, board.ID, column.ID))
+	}
+	first, a, b := placed[0], placed[2], placed[3]
+
+	// Alternately dropping A and B right after the first ticket keeps
+	// splitting the same gap until the ranks run long and get rebalanced
+	for i := range 200 {
+		moving := a
+		if i%2 == 1 {
+			moving = b
+		}
+		statusCode, resp := do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(moving.ID)+"/position", domain.TicketPositionModel{
+			AfterID: first.ID,
+		}, tokens.AccessToken)
+		if statusCode != http.StatusOK {
+			t.Fatalf("move %d: expected status 200, got %d: %v", i, statusCode, resp.Error)
+		}
+	}
+
+	ranks := make([]string, len(placed))
+	for i, ticket := range []domain.TicketModel{first, b, a, placed[1]} {
+		ranks[i] = getTicket(t, uuidToString(ticket.ID), tokens.AccessToken).Rank
+	}
+	for i := 1; i < len(ranks); i++ {
+		if ranks[i] <= ranks[i-1] {
+			t.Fatalf("expected first, B, A, second in order, got ranks %q", ranks)
+		}
+	}
+}
+
+func TestTicket_MovePosition_AnchorInOtherColumn(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	todo := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Todo")
+	done := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Done")
+
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+	moveTicketToColumn(t, uuidToString(ticket.ID), tokens.AccessToken, board.ID, todo.ID)
+	other := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+	moveTicketToColumn(t, uuidToString(other.ID), tokens.AccessToken, board.ID, done.ID)
+
+	statusCode, _ = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(ticket.ID)+"/position", domain.TicketPositionModel{
+		AfterID: other.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", statusCode)
+	}
+}
+
+func TestTicket_MovePosition_NotOnBoard(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	ticket := createTicket(t, uuidToString(project.ID), tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	statusCode, _ = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(ticket.ID)+"/position", domain.TicketPositionModel{}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
+
+func TestTicket_MovePosition_Unauthenticated(t *testing.T) {
+	ticketID := "550e8400-e29b-41d4-a716-446655440000"
+
+	statusCode, _ := do[domain.TicketModel](t, "PATCH", "/tickets/"+ticketID+"/position", domain.TicketPositionModel{}, "")
+	if statusCode != http.StatusUnauthorized {
+		t.Fatalf("expected status 401, got %d", statusCode)
+	}
+}
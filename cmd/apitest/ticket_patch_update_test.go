@@ -160,8 +160,8 @@ func TestTicket_Update_InvalidUUID(t *testing.T) {
 		Title: "Updated Title",
 	}, tokens.AccessToken)
 
-	if statusCode != http.StatusBadRequest {
-		t.Fatalf("expected status 400, got %d", statusCode)
+	if statusCode != http.StatusUnprocessableEntity {
+		t.Fatalf("expected status 422, got %d", statusCode)
 	}
 }
 
@@ -0,0 +1,77 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestTicket_BulkDelete_DryRunThenDelete(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	orgID := uuidToString(orgResp.Data.ID)
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project "+randomString(8), "private")
+	projectID := uuidToString(project.ID)
+
+	doomed := []domain.TicketModel{
+		createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "story", "medium"),
+		createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "bug", "high"),
+	}
+	kept := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "low")
+
+	req := domain.TicketBulkDeleteModel{
+		ID:        []pgtype.UUID{doomed[0].ID, doomed[1].ID},
+		ProjectID: []pgtype.UUID{project.ID},
+		DryRun:    true,
+	}
+	statusCode, preview := do[domain.TicketBulkDeleteResultModel](t, "POST", "/tickets/bulk-delete", req, tokens.AccessToken)
+	if statusCode != http.StatusOK || preview.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, preview.Error)
+	}
+	if !preview.Data.DryRun || preview.Data.Count != 2 || len(preview.Data.Sample) != 2 {
+		t.Fatalf("expected a dry run matching 2 tickets, got %+v", preview.Data)
+	}
+	getTicket(t, uuidToString(doomed[0].ID), tokens.AccessToken)
+
+	req.DryRun = false
+	statusCode, result := do[domain.TicketBulkDeleteResultModel](t, "POST", "/tickets/bulk-delete", req, tokens.AccessToken)
+	if statusCode != http.StatusOK || result.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, result.Error)
+	}
+	if result.Data.DryRun || result.Data.Count != 2 {
+		t.Fatalf("expected 2 tickets deleted, got %+v", result.Data)
+	}
+
+	for _, ticket := range doomed {
+		status, _ := do[domain.TicketModel](t, "GET", "/tickets/"+uuidToString(ticket.ID), nil, tokens.AccessToken)
+		if status != http.StatusNotFound {
+			t.Fatalf("expected status 404 after bulk delete, got %d", status)
+		}
+	}
+	getTicket(t, uuidToString(kept.ID), tokens.AccessToken)
+
+	statusCode, result = do[domain.TicketBulkDeleteResultModel](t, "POST", "/tickets/bulk-delete", req, tokens.AccessToken)
+	if statusCode != http.StatusOK || result.Data == nil || result.Data.Count != 0 {
+		t.Fatalf("expected nothing left to delete, got %d: %+v", statusCode, result.Data)
+	}
+}
+
+func TestTicket_BulkDelete_RequiresProject(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, resp := do[domain.TicketBulkDeleteResultModel](t, "POST", "/tickets/bulk-delete", domain.TicketBulkDeleteModel{
+		DryRun: true,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d: %v", statusCode, resp.Error)
+	}
+}
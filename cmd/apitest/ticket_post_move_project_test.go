@@ -0,0 +1,132 @@
+package apitest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+)
+
+// waitForAction returns the first activity entry of the project with the given action
+func waitForAction(t *testing.T, projectID, token, action string) *domain.ActivityModel {
+	t.Helper()
+	for deadline := time.Now().Add(time.Second); ; {
+		for _, item := range waitForActivity(t, projectID, token, 1) {
+			if item.Action == action {
+				return &item
+			}
+		}
+		if time.Now().After(deadline) {
+			return nil
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+}
+
+func TestTicket_MoveToProject(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	source := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Source Project", "private")
+	target := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Target Project", "private")
+	sourceID := uuidToString(source.ID)
+	targetID := uuidToString(target.ID)
+
+	ticket := createTicket(t, sourceID, tokens.AccessToken, randomTicketTitle(), "story", "medium")
+	child := createTicket(t, sourceID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	if _, err := testPool.Exec(context.Background(), "UPDATE tickets SET parent_id = $1 WHERE id = $2", ticket.ID, child.ID); err != nil {
+		t.Fatalf("failed to link child ticket: %v", err)
+	}
+	path := "/tickets/" + uuidToString(ticket.ID) + "/move"
+
+	statusCode, resp := do[domain.TicketModel](t, "POST", path, domain.TicketProjectMoveModel{
+		ProjectID: source.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest || resp.Error == nil || resp.Error.Code != "ticket_same_project" {
+		t.Fatalf("expected 400 ticket_same_project, got %d: %v", statusCode, resp.Error)
+	}
+
+	sourceSprint := createSprint(t, sourceID, tokens.AccessToken, randomSprintName())
+	sourceBoard := createBoard(t, uuidToString(sourceSprint.ID), tokens.AccessToken, randomBoardName())
+	foreign := createBoardColumn(t, uuidToString(sourceBoard.ID), tokens.AccessToken, "Todo")
+	statusCode, resp = do[domain.TicketModel](t, "POST", path, domain.TicketProjectMoveModel{
+		ProjectID:     target.ID,
+		BoardColumnID: foreign.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest || resp.Error == nil || resp.Error.Code != "invalid_board_column" {
+		t.Fatalf("expected 400 invalid_board_column, got %d: %v", statusCode, resp.Error)
+	}
+
+	outsider := register(t, randomEmail(), "Outsider", "SecurePassword123!")
+	statusCode, otherOrg := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Other Org " + randomString(8),
+	}, outsider.AccessToken)
+	if statusCode != http.StatusCreated || otherOrg.Data == nil {
+		t.Fatalf("failed to create other org")
+	}
+	elsewhere := createProject(t, uuidToString(otherOrg.Data.ID), outsider.AccessToken, randomProjectKey(), "Elsewhere", "private")
+	statusCode, resp = do[domain.TicketModel](t, "POST", path, domain.TicketProjectMoveModel{
+		ProjectID: elsewhere.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusForbidden || resp.Error == nil || resp.Error.Code != "not_a_member" {
+		t.Fatalf("expected 403 not_a_member, got %d: %v", statusCode, resp.Error)
+	}
+
+	sprint := createSprint(t, targetID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	todo := createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Todo")
+	createBoardColumn(t, uuidToString(board.ID), tokens.AccessToken, "Done")
+
+	statusCode, resp = do[domain.TicketModel](t, "POST", path, domain.TicketProjectMoveModel{
+		ProjectID: target.ID,
+		BoardID:   board.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	moved := *resp.Data
+	if moved.ProjectID != target.ID || !strings.HasPrefix(moved.Key, target.Key+"-") {
+		t.Fatalf("expected the ticket to carry a %s key, got %+v", target.Key, moved)
+	}
+	if moved.BoardColumnID != todo.ID || moved.SprintID != sprint.ID || moved.Rank == "" {
+		t.Fatalf("expected the ticket in the default column, got %+v", moved)
+	}
+
+	if got := getTicket(t, uuidToString(child.ID), tokens.AccessToken); got.ParentID.Valid {
+		t.Fatalf("expected the child to be detached, got parent %s", uuidToString(got.ParentID))
+	}
+
+	for _, projectID := range []string{sourceID, targetID} {
+		entry := waitForAction(t, projectID, tokens.AccessToken, string(pubsub.TicketMovedToProject))
+		if entry == nil {
+			t.Fatalf("expected a %s entry in project %s", pubsub.TicketMovedToProject, projectID)
+		}
+		var payload domain.TicketProjectMovedEventModel
+		if err := json.Unmarshal(entry.Payload, &payload); err != nil || payload.FromKey != ticket.Key || payload.Key != moved.Key {
+			t.Fatalf("expected the entry to name both keys, got %s", entry.Payload)
+		}
+	}
+
+	// with no placement the ticket goes back to the backlog
+	statusCode, resp = do[domain.TicketModel](t, "POST", path, domain.TicketProjectMoveModel{
+		ProjectID: source.ID,
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	if resp.Data.SprintID.Valid || resp.Data.BoardColumnID.Valid || !strings.HasPrefix(resp.Data.Key, source.Key+"-") {
+		t.Fatalf("expected the ticket in the source backlog, got %+v", resp.Data)
+	}
+}
@@ -0,0 +1,95 @@
+package apitest_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestTicket_Sync_AppliesAndReportsConflicts(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	fresh := createTicket(t, uuidToString(project.ID), tokens.AccessToken, randomTicketTitle(), "task", "high")
+	stale := createTicket(t, uuidToString(project.ID), tokens.AccessToken, randomTicketTitle(), "task", "high")
+
+	// Someone edits stale online after the client went offline
+	statusCode, _ = do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(stale.ID), domain.TicketUpdateModel{
+		Title: "Edited online",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("failed to update ticket: %d", statusCode)
+	}
+
+	statusCode, resp := do[domain.TicketSyncResultModel](t, "POST", "/sync", domain.TicketSyncModel{
+		Mutations: []domain.TicketMutationModel{
+			{
+				ClientID:  "c1",
+				Op:        domain.TicketMutationCreate,
+				ProjectID: project.ID,
+				Create:    &domain.TicketCreateModel{Title: "Made offline", Type: "task", Priority: "low"},
+			},
+			{
+				ClientID:    "c2",
+				Op:          domain.TicketMutationUpdate,
+				ID:          fresh.ID,
+				BaseVersion: fresh.UpdatedAt,
+				Update:      &domain.TicketUpdateModel{Title: "Edited offline"},
+			},
+			{
+				ClientID:    "c3",
+				Op:          domain.TicketMutationUpdate,
+				ID:          stale.ID,
+				BaseVersion: stale.UpdatedAt,
+				Update:      &domain.TicketUpdateModel{Title: "Edited offline"},
+			},
+			{
+				ClientID: "c4",
+				Op:       domain.TicketMutationDelete,
+				ID:       fresh.ID,
+			},
+		},
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK || resp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+
+	results := resp.Data.Results
+	if len(results) != 4 {
+		t.Fatalf("expected 4 results, got %d", len(results))
+	}
+	if r := results[0]; r.ClientID != "c1" || r.Status != domain.SyncApplied || r.Ticket == nil || r.Ticket.Title != "Made offline" {
+		t.Fatalf("unexpected create result: %+v", r)
+	}
+	if r := results[1]; r.Status != domain.SyncApplied || r.Ticket == nil || r.Ticket.Title != "Edited offline" {
+		t.Fatalf("unexpected update result: %+v", r)
+	}
+	if r := results[2]; r.Status != domain.SyncConflict || r.Code != "version_conflict" || r.Ticket == nil || r.Ticket.Title != "Edited online" {
+		t.Fatalf("expected a conflict with the server's ticket, got %+v", r)
+	}
+	if r := results[3]; r.Status != domain.SyncRejected || r.Code != "invalid_mutation" {
+		t.Fatalf("expected a delete without baseVersion to be rejected, got %+v", r)
+	}
+
+	// The conflicting edit was not applied
+	if got := getTicket(t, uuidToString(stale.ID), tokens.AccessToken); got.Title != "Edited online" {
+		t.Fatalf("expected server title to survive, got %q", got.Title)
+	}
+}
+
+func TestTicket_Sync_EmptyBatch(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, _ := do[domain.TicketSyncResultModel](t, "POST", "/sync", domain.TicketSyncModel{}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400, got %d", statusCode)
+	}
+}
@@ -0,0 +1,95 @@
+package apitest_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+)
+
+func TestTicket_StaleNudges(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+
+	project := createProject(t, uuidToString(orgResp.Data.ID), tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	path := "/projects/" + projectID + "/stale-settings"
+
+	statusCode, settings := do[domain.TicketStaleSettingsModel](t, "GET", path, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || settings.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, settings.Error)
+	}
+	if settings.Data.AfterDays != 0 || settings.Data.UpdatedAt != nil {
+		t.Fatalf("expected nudges to be off by default, got %+v", settings.Data)
+	}
+
+	statusCode, settings = do[domain.TicketStaleSettingsModel](t, "PUT", path, domain.TicketStaleSettingsUpdateModel{AfterDays: -1}, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for negative days, got %d", statusCode)
+	}
+
+	statusCode, settings = do[domain.TicketStaleSettingsModel](t, "PUT", path, domain.TicketStaleSettingsUpdateModel{AfterDays: 3}, tokens.AccessToken)
+	if statusCode != http.StatusOK || settings.Data == nil || settings.Data.AfterDays != 3 || settings.Data.UpdatedAt == nil {
+		t.Fatalf("expected the days to be stored, got %d: %+v", statusCode, settings.Data)
+	}
+
+	stale := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+
+	// updated_at is not one of the columns bumping itself, so it can be backdated
+	if _, err := testPool.Exec(context.Background(), "UPDATE tickets SET updated_at = NOW() - INTERVAL '5 days' WHERE id = $1", stale.ID); err != nil {
+		t.Fatalf("failed to backdate ticket: %v", err)
+	}
+	if _, err := testTicketSvc.SweepStaleTickets(context.Background(), time.Now()); err != nil {
+		t.Fatalf("failed to sweep stale tickets: %v", err)
+	}
+
+	listPath := "/tickets?projectId=" + projectID + "&stale=true"
+	statusCode, list := do[domain.TicketsPagedModel](t, "GET", listPath, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, list.Error)
+	}
+	if len(list.Data.Items) != 1 || list.Data.Items[0].ID != stale.ID {
+		t.Fatalf("expected only the backdated ticket to be stale, got %+v", list.Data.Items)
+	}
+
+	items := waitForActivity(t, projectID, tokens.AccessToken, 1)
+	if len(items) != 1 || items[0].Action != string(pubsub.TicketsStale) {
+		t.Fatalf("expected one %s entry, got %+v", pubsub.TicketsStale, items)
+	}
+	var payload domain.BulkEventModel
+	if err := json.Unmarshal(items[0].Payload, &payload); err != nil || len(payload.IDs) != 1 || payload.IDs[0] != stale.ID {
+		t.Fatalf("expected the entry to list the stale ticket, got %s", items[0].Payload)
+	}
+
+	// a second sweep does not nudge again
+	if _, err := testTicketSvc.SweepStaleTickets(context.Background(), time.Now()); err != nil {
+		t.Fatalf("failed to sweep stale tickets: %v", err)
+	}
+	time.Sleep(200 * time.Millisecond)
+	if items := waitForActivity(t, projectID, tokens.AccessToken, 2); len(items) != 1 {
+		t.Fatalf("expected a single stale entry, got %d", len(items))
+	}
+
+	// touching the ticket takes it out of the list right away
+	statusCode, updated := do[domain.TicketModel](t, "PATCH", "/tickets/"+uuidToString(stale.ID), domain.TicketUpdateModel{
+		Title: "Picked up again",
+	}, tokens.AccessToken)
+	if statusCode != http.StatusOK {
+		t.Fatalf("failed to update ticket: %v", updated.Error)
+	}
+	statusCode, list = do[domain.TicketsPagedModel](t, "GET", listPath, nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || list.Data == nil || len(list.Data.Items) != 0 {
+		t.Fatalf("expected no stale tickets after an update, got %d: %+v", statusCode, list.Data)
+	}
+}
@@ -0,0 +1,118 @@
+package apitest_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestTrash_ListAndRestore(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	statusCode, orgResp := do[domain.OrganisationModel](t, "POST", "/orgs", domain.OrganisationCreateModel{
+		Name: "Test Org " + randomString(8),
+	}, tokens.AccessToken)
+	if statusCode != http.StatusCreated || orgResp.Data == nil {
+		t.Fatalf("failed to create org")
+	}
+	orgID := uuidToString(orgResp.Data.ID)
+
+	project := createProject(t, orgID, tokens.AccessToken, randomProjectKey(), "Test Project", "private")
+	projectID := uuidToString(project.ID)
+	sprint := createSprint(t, projectID, tokens.AccessToken, randomSprintName())
+	board := createBoard(t, uuidToString(sprint.ID), tokens.AccessToken, randomBoardName())
+	boardID := uuidToString(board.ID)
+	todo := createBoardColumn(t, boardID, tokens.AccessToken, "Todo")
+	spare := createBoardColumn(t, boardID, tokens.AccessToken, "Spare")
+
+	ticket := createTicket(t, projectID, tokens.AccessToken, randomTicketTitle(), "task", "medium")
+	if _, err := testPool.Exec(context.Background(), "UPDATE tickets SET sprint_id = $1, board_id = $2, board_column_id = $3 WHERE id = $4",
+		sprint.ID, board.ID, todo.ID, ticket.ID); err != nil {
+		t.Fatalf("failed to place ticket: %v", err)
+	}
+	ticketPath := "/tickets/" + uuidToString(ticket.ID)
+	columnPath := "/boards/" + boardID + "/columns/" + uuidToString(spare.ID)
+
+	for _, path := range []string{ticketPath, columnPath} {
+		if statusCode, resp := do[any](t, "DELETE", path, nil, tokens.AccessToken); statusCode != http.StatusNoContent {
+			t.Fatalf("failed to delete %s: got %d, error: %v", path, statusCode, resp.Error)
+		}
+	}
+
+	list := func(query string) []domain.TrashItemModel {
+		t.Helper()
+		statusCode, resp := do[domain.TrashPagedModel](t, "GET", "/trash?orgId="+orgID+query, nil, tokens.AccessToken)
+		if statusCode != http.StatusOK || resp.Data == nil {
+			t.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+		}
+		return resp.Data.Items
+	}
+
+	items := list("")
+	if len(items) != 2 {
+		t.Fatalf("expected the ticket and the column in the trash, got %+v", items)
+	}
+	if items[0].Type != domain.TrashBoardColumn || items[0].ID != spare.ID || items[0].BoardID != board.ID {
+		t.Fatalf("expected the column deleted last to come first, got %+v", items[0])
+	}
+	if items[1].Type != domain.TrashTicket || items[1].Key != ticket.Key || items[1].ParentDeleted {
+		t.Fatalf("expected the ticket second, got %+v", items[1])
+	}
+	if items = list("&type=ticket"); len(items) != 1 || items[0].ID != ticket.ID {
+		t.Fatalf("expected only the ticket, got %+v", items)
+	}
+
+	statusCode, _ = do[domain.TrashPagedModel](t, "GET", "/trash?orgId="+orgID+"&type=sprint", nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an unknown type, got %d", statusCode)
+	}
+
+	outsider := register(t, randomEmail(), "Outsider", "SecurePassword123!")
+	statusCode, outsiderResp := do[domain.TrashPagedModel](t, "GET", "/trash?orgId="+orgID, nil, outsider.AccessToken)
+	if statusCode != http.StatusForbidden || outsiderResp.Error == nil || outsiderResp.Error.Code != "not_a_member" {
+		t.Fatalf("expected 403 not_a_member, got %d: %v", statusCode, outsiderResp.Error)
+	}
+
+	statusCode, columnResp := do[domain.BoardColumnModel](t, "POST", columnPath+"/restore", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || columnResp.Data == nil || columnResp.Data.ID != spare.ID {
+		t.Fatalf("expected the column restored, got %d: %v", statusCode, columnResp.Error)
+	}
+
+	// the ticket's project goes first, which blocks the ticket until it is back
+	if statusCode, resp := do[any](t, "DELETE", "/projects/"+projectID, nil, tokens.AccessToken); statusCode != http.StatusNoContent {
+		t.Fatalf("failed to delete project: got %d, error: %v", statusCode, resp.Error)
+	}
+	items = list("&projectId=" + projectID)
+	if len(items) != 2 || items[0].Type != domain.TrashProject || !items[1].ParentDeleted {
+		t.Fatalf("expected the project first and the ticket under it, got %+v", items)
+	}
+
+	statusCode, ticketResp := do[domain.TicketModel](t, "POST", ticketPath+"/restore", nil, tokens.AccessToken)
+	if statusCode != http.StatusConflict || ticketResp.Error == nil || ticketResp.Error.Code != "parent_deleted" {
+		t.Fatalf("expected 409 parent_deleted, got %d: %v", statusCode, ticketResp.Error)
+	}
+
+	statusCode, projectResp := do[domain.ProjectModel](t, "POST", "/projects/"+projectID+"/restore", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || projectResp.Data == nil || projectResp.Data.ID != project.ID {
+		t.Fatalf("expected the project restored, got %d: %v", statusCode, projectResp.Error)
+	}
+
+	statusCode, ticketResp = do[domain.TicketModel](t, "POST", ticketPath+"/restore", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || ticketResp.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, ticketResp.Error)
+	}
+	if ticketResp.Data.BoardColumnID != todo.ID || ticketResp.Data.Rank == "" {
+		t.Fatalf("expected the ticket back in its column, got %+v", ticketResp.Data)
+	}
+
+	statusCode, ticketResp = do[domain.TicketModel](t, "POST", ticketPath+"/restore", nil, tokens.AccessToken)
+	if statusCode != http.StatusConflict || ticketResp.Error == nil || ticketResp.Error.Code != "not_deleted" {
+		t.Fatalf("expected 409 not_deleted, got %d: %v", statusCode, ticketResp.Error)
+	}
+
+	if items = list(""); len(items) != 0 {
+		t.Fatalf("expected an empty trash, got %+v", items)
+	}
+}
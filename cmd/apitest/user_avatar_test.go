@@ -0,0 +1,186 @@
+package apitest_test
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+// getAvatar fetches an avatar as raw bytes, the JSON helper can not decode it
+func getAvatar(tb testing.TB, userID, token, etag string) (*http.Response, []byte) {
+	req, err := http.NewRequest("GET", testServer.URL+"/users/"+userID+"/avatar", nil)
+	if err != nil {
+		tb.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		tb.Fatalf("failed to perform request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		tb.Fatalf("failed to read response body: %v", err)
+	}
+	return resp, body
+}
+
+func putAvatar(tb testing.TB, token, contentType string, body []byte) int {
+	req, err := http.NewRequest("PUT", testServer.URL+"/users/me/avatar", bytes.NewReader(body))
+	if err != nil {
+		tb.Fatalf("failed to create request: %v", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", contentType)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		tb.Fatalf("failed to perform request: %v", err)
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func samplePNG(tb testing.TB) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 8, 8))
+	img.Set(3, 3, color.NRGBA{R: 0xff, A: 0xff})
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		tb.Fatalf("failed to encode png: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func currentUserID(tb testing.TB, token string) string {
+	statusCode, resp := do[domain.UserModel](tb, "GET", "/users/me", nil, token)
+	if statusCode != http.StatusOK {
+		tb.Fatalf("expected status 200, got %d: %v", statusCode, resp.Error)
+	}
+	return uuidToString(resp.Data.ID)
+}
+
+func TestUser_Avatar_IdenticonFallback(t *testing.T) {
+	tokens := register(t, randomEmail(), "Avatar User", "SecurePassword123!")
+	userID := currentUserID(t, tokens.AccessToken)
+
+	resp, first := getAvatar(t, userID, tokens.AccessToken, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "image/png" {
+		t.Fatalf("expected image/png, got %q", ct)
+	}
+	if _, err := png.Decode(bytes.NewReader(first)); err != nil {
+		t.Fatalf("identicon is not a valid png: %v", err)
+	}
+
+	_, second := getAvatar(t, userID, tokens.AccessToken, "")
+	if !bytes.Equal(first, second) {
+		t.Fatal("expected the identicon to be stable across requests")
+	}
+
+	resp, _ = getAvatar(t, userID, tokens.AccessToken, resp.Header.Get("ETag"))
+	if resp.StatusCode != http.StatusNotModified {
+		t.Fatalf("expected status 304 for a matching etag, got %d", resp.StatusCode)
+	}
+}
+
+func TestUser_Avatar_UploadAndDelete(t *testing.T) {
+	tokens := register(t, randomEmail(), "Avatar User", "SecurePassword123!")
+	userID := currentUserID(t, tokens.AccessToken)
+	_, identicon := getAvatar(t, userID, tokens.AccessToken, "")
+
+	img := samplePNG(t)
+	// the format is sniffed, a wrong declared type is not trusted
+	if code := putAvatar(t, tokens.AccessToken, "application/octet-stream", img); code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+
+	// any authenticated user can see it, that is what assignee chips need
+	other := register(t, randomEmail(), "Other User", "SecurePassword123!")
+	resp, body := getAvatar(t, userID, other.AccessToken, "")
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", resp.StatusCode)
+	}
+	if !bytes.Equal(body, img) {
+		t.Fatal("expected the uploaded image back")
+	}
+
+	statusCode, _ := do[any](t, "DELETE", "/users/me/avatar", nil, tokens.AccessToken)
+	if statusCode != http.StatusNoContent {
+		t.Fatalf("expected status 204, got %d", statusCode)
+	}
+
+	_, body = getAvatar(t, userID, tokens.AccessToken, "")
+	if !bytes.Equal(body, identicon) {
+		t.Fatal("expected the identicon after deleting the upload")
+	}
+
+	statusCode, resp2 := do[any](t, "DELETE", "/users/me/avatar", nil, tokens.AccessToken)
+	if statusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d: %v", statusCode, resp2.Error)
+	}
+}
+
+func TestUser_Avatar_MultipartUpload(t *testing.T) {
+	tokens := register(t, randomEmail(), "Avatar User", "SecurePassword123!")
+	userID := currentUserID(t, tokens.AccessToken)
+
+	img := samplePNG(t)
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fw, err := mw.CreateFormFile("avatar", "me.png")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write(img)
+	mw.Close()
+
+	if code := putAvatar(t, tokens.AccessToken, mw.FormDataContentType(), buf.Bytes()); code != http.StatusOK {
+		t.Fatalf("expected status 200, got %d", code)
+	}
+
+	_, body := getAvatar(t, userID, tokens.AccessToken, "")
+	if !bytes.Equal(body, img) {
+		t.Fatal("expected the uploaded image back")
+	}
+}
+
+func TestUser_Avatar_RejectsInvalidUploads(t *testing.T) {
+	tokens := register(t, randomEmail(), "Avatar User", "SecurePassword123!")
+
+	if code := putAvatar(t, tokens.AccessToken, "image/svg+xml", []byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`)); code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for svg, got %d", code)
+	}
+
+	if code := putAvatar(t, tokens.AccessToken, "image/png", nil); code != http.StatusBadRequest {
+		t.Fatalf("expected status 400 for an empty body, got %d", code)
+	}
+
+	large := append(samplePNG(t), make([]byte, 1<<20)...)
+	if code := putAvatar(t, tokens.AccessToken, "image/png", large); code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected status 413, got %d", code)
+	}
+}
+
+func TestUser_Avatar_UnknownUser(t *testing.T) {
+	tokens := register(t, randomEmail(), "Avatar User", "SecurePassword123!")
+
+	resp, _ := getAvatar(t, "00000000-0000-4000-8000-000000000000", tokens.AccessToken, "")
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("expected status 404, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,64 @@
+package apitest_test
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestUser_Usage(t *testing.T) {
+	tokens := register(t, randomEmail(), "Test User", "SecurePassword123!")
+
+	_, me := do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	if me.Data == nil {
+		t.Fatal("failed to get user")
+	}
+	do[domain.UserModel](t, "GET", "/users/me", nil, tokens.AccessToken)
+	// refused for its scope, still counted
+	statusCode, _ := do[any](t, "GET", "/admin/usage", nil, tokens.AccessToken)
+	if statusCode != http.StatusForbidden {
+		t.Fatalf("expected status 403 without the admin scope, got %d", statusCode)
+	}
+
+	today := time.Now().UTC().Format("2006-01-02")
+
+	// the usage request itself is the fourth
+	statusCode, usage := do[domain.UserUsageModel](t, "GET", "/users/me/usage", nil, tokens.AccessToken)
+	if statusCode != http.StatusOK || usage.Data == nil {
+		t.Fatalf("expected status 200, got %d: %v", statusCode, usage.Error)
+	}
+	if usage.Data.PeriodDays != 30 || len(usage.Data.Days) != 1 {
+		t.Fatalf("expected one day within the default 30, got %+v", usage.Data)
+	}
+	if day := usage.Data.Days[0]; day.Date != today || day.Requests != 4 {
+		t.Fatalf("expected 4 requests today, got %+v", day)
+	}
+
+	statusCode, usage = do[domain.UserUsageModel](t, "GET", "/users/me/usage?periodDays=91", nil, tokens.AccessToken)
+	if statusCode != http.StatusBadRequest || usage.Error == nil || usage.Error.Code != "invalid_period" {
+		t.Fatalf("expected 400 invalid_period, got %d: %v", statusCode, usage.Error)
+	}
+
+	admin := adminTokens(t)
+	var found *domain.UsageModel
+	for page := 1; found == nil; page++ {
+		statusCode, list := do[domain.UsagePagedModel](t, "GET", "/admin/usage?periodDays=1&pageSize=100&pageNumber="+strconv.Itoa(page), nil, admin.AccessToken)
+		if statusCode != http.StatusOK || list.Data == nil {
+			t.Fatalf("expected status 200, got %d: %v", statusCode, list.Error)
+		}
+		for i, item := range list.Data.Items {
+			if item.UserID == me.Data.ID {
+				found = &list.Data.Items[i]
+			}
+		}
+		if !list.Data.HasMore {
+			break
+		}
+	}
+	if found == nil || found.Date != today || found.Requests != 5 || found.Email != me.Data.Email {
+		t.Fatalf("expected the user's 5 requests today in the admin list, got %+v", found)
+	}
+}
@@ -1,27 +1,113 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/dimasbaguspm/fluxis/internal/apidocs"
+	attachmentConfig "github.com/dimasbaguspm/fluxis/internal/attachment/service"
 	authConfig "github.com/dimasbaguspm/fluxis/internal/auth/service"
+	projectConfig "github.com/dimasbaguspm/fluxis/internal/project/service"
+	retentionConfig "github.com/dimasbaguspm/fluxis/internal/retention/service"
+	ticketConfig "github.com/dimasbaguspm/fluxis/internal/ticket/service"
+	"github.com/dimasbaguspm/fluxis/pkg/blob"
 	"github.com/dimasbaguspm/fluxis/pkg/cache"
+	"github.com/dimasbaguspm/fluxis/pkg/chaos"
+	"github.com/dimasbaguspm/fluxis/pkg/contentfilter"
 	"github.com/dimasbaguspm/fluxis/pkg/cors"
+	"github.com/dimasbaguspm/fluxis/pkg/ipfilter"
+	"github.com/dimasbaguspm/fluxis/pkg/lease"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/dimasbaguspm/fluxis/pkg/postgres"
+	"github.com/dimasbaguspm/fluxis/pkg/presence"
 	ratelimit "github.com/dimasbaguspm/fluxis/pkg/rate-limit"
+	"github.com/dimasbaguspm/fluxis/pkg/readonly"
+	"github.com/dimasbaguspm/fluxis/pkg/recorder"
+	"github.com/dimasbaguspm/fluxis/pkg/secrets"
+	"github.com/dimasbaguspm/fluxis/pkg/webpush"
 )
 
 type Config struct {
 	Env       string
+	LogLevel  slog.Level
 	DB        postgres.Config
 	Server    ServerConfig
 	Auth      authConfig.Config
+	Project   projectConfig.Config
+	Ticket    ticketConfig.Config
+	Content   contentfilter.Config
 	DataCache cache.Config
 	RateLimit ratelimit.Config
 	CORS      cors.Config
+	ReadOnly  readonly.Config
+	IPFilter  ipfilter.Config
+	Push      webpush.Config
+	Jobs      JobsConfig
+	Debug     DebugConfig
+	Chaos     chaos.Config
+	Recorder  recorder.Config
+	Docs      apidocs.Config
+	Presence  presence.Config
+	// TicketLocks is how long an edit lock lives without a renewal
+	TicketLocks lease.Config
+	// Pagination applies to every paged list
+	Pagination pagination.Settings
+	// ActivityWebhookTimeout bounds each delivery to an activity webhook
+	ActivityWebhookTimeout time.Duration
+	// ActivityWebhookAllowedNets are private blocks webhooks and log growth
+	// alerts may still be delivered to, e.g. a self-hosted receiver. Other
+	// loopback, private and link-local addresses are refused.
+	ActivityWebhookAllowedNets []string
+	// Attachment limits ticket uploads, Storage is where their bytes go
+	Attachment attachmentConfig.Config
+	Storage    blob.Config
+	// Retention is how long soft-deleted rows are kept before the purge
+	Retention retentionConfig.Config
+}
+
+// RuntimeConfig is the part of Config a SIGHUP reloads without a restart
+type RuntimeConfig struct {
+	LogLevel  slog.Level
+	RateLimit ratelimit.Config
+	CORS      cors.Config
+}
+
+func loadRuntime() RuntimeConfig {
+	return RuntimeConfig{
+		LogLevel: getLogLevel("LOG_LEVEL", slog.LevelInfo),
+		RateLimit: ratelimit.Config{
+			MaxRequests: getInt("RATE_LIMIT_MAX_REQUESTS", 100),
+			Window:      getDuration("RATE_LIMIT_WINDOW", 1*time.Minute),
+		},
+		CORS: cors.Config{
+			AllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"),
+			AllowedMethods: getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
+			AllowedHeaders: getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization"),
+			ExposedHeaders: getEnv("CORS_EXPOSED_HEADERS", "Link,X-Request-Id"),
+			AllowedMaxAge:  getInt("CORS_MAX_AGE", 3600),
+		},
+	}
+}
+
+// JobsConfig holds the intervals of background maintenance loops
+type JobsConfig struct {
+	ColumnCompaction time.Duration
+	IntegrityCheck   time.Duration
+	BoardSnapshot    time.Duration
+	UsageFlush       time.Duration
+	StaleSweep       time.Duration
+	Purge            time.Duration
+	LogGrowth        time.Duration
+	// IntegrityFix lets the periodic integrity check fix what it finds
+	IntegrityFix bool
 }
 
 type ServerConfig struct {
@@ -30,6 +116,8 @@ type ServerConfig struct {
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
 	IdleTimeout  time.Duration
+	ServeWeb     bool // serve the embedded frontend from the API binary
+	Metrics      bool // expose /metrics and /metrics/docs
 }
 
 func (c ServerConfig) addr() string {
@@ -39,49 +127,317 @@ func (c ServerConfig) addr() string {
 func LoadEnv() *Config {
 	slog.Info("[Config]: Attempting to load few environment variables")
 
+	secretStore = loadSecretStore()
+
+	env := getEnv("ENV", "development")
+	runtime := loadRuntime()
 	cfg := &Config{
-		Env: getEnv("ENV", "development"),
+		Env:      env,
+		LogLevel: runtime.LogLevel,
 		Server: ServerConfig{
 			Host:         getEnv("HOST", "0.0.0.0"),
 			Port:         getEnv("PORT", "8080"),
 			ReadTimeout:  getDuration("SERVER_READ_TIMEOUT", 5*time.Second),
 			WriteTimeout: getDuration("SERVER_WRITE_TIMEOUT", 10*time.Second),
 			IdleTimeout:  getDuration("SERVER_IDLE_TIMEOUT", 60*time.Second),
+			ServeWeb:     getBool("SERVE_WEB", false),
+			// /metrics is served on the public port, outside development it
+			// has to be turned on and is best kept private with IP_FILTER_PATHS
+			Metrics: getBool("METRICS_ENABLED", env == "development"),
 		},
 		DB: postgres.Config{
-			Primary:  mustEnv("DATABASE_URL"),
-			MaxConns: getInt("DB_MAX_CONNS", 25),
-			MinConns: getInt("DB_MIN_CONNS", 5),
+			Primary:      databaseURL(),
+			MaxConns:     getInt("DB_MAX_CONNS", 25),
+			MinConns:     getInt("DB_MIN_CONNS", 5),
+			QueryTimeout: getDuration("DB_QUERY_TIMEOUT", 5*time.Second),
+			Health: postgres.HealthConfig{
+				Interval:   getPositiveDuration("DB_HEALTH_INTERVAL", 5*time.Second),
+				Timeout:    getPositiveDuration("DB_HEALTH_TIMEOUT", 2*time.Second),
+				RetryAfter: getDuration("DB_RETRY_AFTER", 10*time.Second),
+			},
+			Connect: postgres.ConnectConfig{
+				MaxWait:        getDuration("DB_CONNECT_MAX_WAIT", 60*time.Second),
+				InitialBackoff: getDuration("DB_CONNECT_BACKOFF", 500*time.Millisecond),
+				MaxBackoff:     getDuration("DB_CONNECT_MAX_BACKOFF", 10*time.Second),
+			},
+			Tracer: postgres.TracerConfig{
+				SlowQuery:         getDuration("DB_SLOW_QUERY_THRESHOLD", 500*time.Millisecond),
+				ExplainSampleRate: getFloat("DB_EXPLAIN_SAMPLE_RATE", 0),
+			},
 		},
 		Auth: authConfig.Config{
-			AccessTokenSecret:  mustEnv("JWT_ACCESS_SECRET"),
-			RefreshTokenSecret: mustEnv("JWT_REFRESH_SECRET"),
-			AccessTokenExpiry:  getDuration("JWT_ACCESS_EXPIRY", 15*time.Minute),
-			RefreshTokenExpiry: getDuration("JWT_REFRESH_EXPIRY", 7*24*time.Hour),
-			BcryptCost:         getInt("BCRYPT_COST", 12),
+			AccessTokenSecret:          mustEnv("JWT_ACCESS_SECRET"),
+			RefreshTokenSecret:         mustEnv("JWT_REFRESH_SECRET"),
+			PreviousAccessTokenSecret:  readEnv("JWT_ACCESS_SECRET_PREVIOUS"),
+			PreviousRefreshTokenSecret: readEnv("JWT_REFRESH_SECRET_PREVIOUS"),
+			AccessTokenExpiry:          getDuration("JWT_ACCESS_EXPIRY", 15*time.Minute),
+			RefreshTokenExpiry:         getDuration("JWT_REFRESH_EXPIRY", 7*24*time.Hour),
+			BcryptCost:                 getInt("BCRYPT_COST", 12),
+			AdminEmails:                getList("ADMIN_EMAILS"),
+		},
+		Project: projectConfig.Config{
+			UniqueNames:          getBool("PROJECT_UNIQUE_NAMES", false),
+			MaxDescriptionLength: getInt("PROJECT_MAX_DESCRIPTION_LENGTH", 10000),
+		},
+		Ticket: ticketConfig.Config{
+			MaxDescriptionLength: getInt("TICKET_MAX_DESCRIPTION_LENGTH", 50000),
+			WIPLimit:             getOneOf("TICKET_WIP_LIMIT_MODE", ticketConfig.WIPLimitWarn, ticketConfig.WIPLimitReject),
+		},
+		Attachment: attachmentConfig.Config{
+			MaxSize: getInt("ATTACHMENT_MAX_SIZE", 10<<20),
+		},
+		Storage: blob.Config{
+			Driver: storageDriver(env),
+			Dir:    getEnv("STORAGE_DIR", "data/blobs"),
+			S3: blob.S3Config{
+				// e.g. http://minio:9000, AWS is reached through its regional endpoint
+				Endpoint:  readEnv("STORAGE_S3_ENDPOINT"),
+				Region:    getEnv("STORAGE_S3_REGION", "us-east-1"),
+				Bucket:    readEnv("STORAGE_S3_BUCKET"),
+				AccessKey: readEnv("STORAGE_S3_ACCESS_KEY"),
+				SecretKey: readEnv("STORAGE_S3_SECRET_KEY"),
+				Timeout:   getDuration("STORAGE_S3_TIMEOUT", 30*time.Second),
+			},
+		},
+		Retention: retentionConfig.Config{
+			// unset keeps soft-deleted rows for good
+			AfterDays: getInt("PURGE_AFTER_DAYS", 0),
+			// clients offline for longer get 410 and reload, 0 keeps the
+			// change feed for good
+			ChangesRetention: getDuration("CHANGES_RETENTION", 30*24*time.Hour),
+			// unset caps only report the activity log's size
+			LogMaxRows:     int64(getInt("ACTIVITY_LOG_MAX_ROWS", 0)),
+			LogMaxBytes:    int64(getInt("ACTIVITY_LOG_MAX_BYTES", 0)),
+			LogAlertURL:    readEnv("ACTIVITY_LOG_ALERT_URL"),
+			LogAlertSecret: readEnv("ACTIVITY_LOG_ALERT_SECRET"),
+		},
+		Content: contentfilter.Config{
+			StripControl: getBool("CONTENT_STRIP_CONTROL", true),
+			DenyList:     getList("CONTENT_DENY_LIST"),
 		},
 		DataCache: cache.Config{
 			DefaultTTL: getDuration("CACHE_DEFAULT_TTL", 15*time.Minute),
 			HMACKey:    mustEnv("CACHE_HMAC_KEY"),
 		},
-		RateLimit: ratelimit.Config{
-			MaxRequests: getInt("RATE_LIMIT_MAX_REQUESTS", 100),
-			Window:      getDuration("RATE_LIMIT_WINDOW", 1*time.Minute),
+		RateLimit: runtime.RateLimit,
+		CORS:      runtime.CORS,
+		ReadOnly: readonly.Config{
+			Enabled:         getBool("READ_ONLY", false),
+			AllowedPrefixes: []string{"/auth/"},
 		},
-		CORS: cors.Config{
-			AllowedOrigins: getEnv("CORS_ALLOWED_ORIGINS", "http://localhost:5173"),
-			AllowedMethods: getEnv("CORS_ALLOWED_METHODS", "GET,POST,PUT,PATCH,DELETE,OPTIONS"),
-			AllowedHeaders: getEnv("CORS_ALLOWED_HEADERS", "Content-Type,Authorization"),
-			AllowedMaxAge:  getInt("CORS_MAX_AGE", 3600),
+		IPFilter: ipfilter.Config{
+			Allow:       getList("IP_ALLOW_LIST"),
+			Deny:        getList("IP_DENY_LIST"),
+			Paths:       getList("IP_FILTER_PATHS"),
+			ExemptPaths: []string{"/health", "/readyz"},
+			TrustProxy:  getBool("IP_FILTER_TRUST_PROXY", false),
+			ProxyHeader: getOneOf("IP_FILTER_PROXY_HEADER", "X-Forwarded-For", "X-Real-IP"),
+		},
+		Push: webpush.Config{
+			PrivateKey: readEnv("VAPID_PRIVATE_KEY"),
+			Subject:    getEnv("VAPID_SUBJECT", "mailto:admin@localhost"),
+			TTL:        getDuration("PUSH_TTL", 24*time.Hour),
+			Timeout:    getDuration("PUSH_TIMEOUT", 10*time.Second),
+		},
+		Docs: apidocs.Config{
+			Enabled: getBool("DOCS_ENABLED", true),
+			// outside development the docs ask for an admin login
+			Public: getBool("DOCS_PUBLIC", env == "development"),
+			// validating payloads without running them is a development aid
+			Validate: env == "development",
+		},
+		Presence: presence.Config{
+			TTL: getDuration("PRESENCE_TTL", 30*time.Second),
+		},
+		TicketLocks: lease.Config{
+			TTL: getDuration("TICKET_LOCK_TTL", 2*time.Minute),
 		},
+		ActivityWebhookTimeout:     getDuration("ACTIVITY_WEBHOOK_TIMEOUT", 10*time.Second),
+		ActivityWebhookAllowedNets: getList("ACTIVITY_WEBHOOK_ALLOWED_NETS"),
+		Jobs: JobsConfig{
+			ColumnCompaction: getDuration("COLUMN_COMPACTION_INTERVAL", 1*time.Hour),
+			IntegrityCheck:   getDuration("INTEGRITY_CHECK_INTERVAL", 24*time.Hour),
+			BoardSnapshot:    getDuration("BOARD_SNAPSHOT_INTERVAL", 1*time.Hour),
+			UsageFlush:       getDuration("USAGE_FLUSH_INTERVAL", 1*time.Minute),
+			StaleSweep:       getDuration("STALE_TICKET_INTERVAL", 1*time.Hour),
+			Purge:            getDuration("PURGE_INTERVAL", 24*time.Hour),
+			LogGrowth:        getDuration("LOG_GROWTH_INTERVAL", 15*time.Minute),
+			IntegrityFix:     getBool("INTEGRITY_AUTO_FIX", false),
+		},
+		Debug: DebugConfig{
+			// e.g. 127.0.0.1:6060, unset keeps profiling off
+			Addr: readEnv("DEBUG_ADDR"),
+		},
+		Chaos: chaos.Config{
+			Enabled: getBool("CHAOS_ENABLED", false),
+			Default: chaos.Fault{
+				Latency:   getDuration("CHAOS_LATENCY", 0),
+				Jitter:    getDuration("CHAOS_JITTER", 0),
+				ErrorRate: getFloat("CHAOS_ERROR_RATE", 0),
+				Status:    getInt("CHAOS_ERROR_STATUS", 0),
+			},
+			// e.g. "GET /tickets=800ms:0.2,POST /boards=0s:1:409"
+			Routes:      getList("CHAOS_ROUTES"),
+			ExemptPaths: []string{"/health", "/readyz", "/metrics"},
+		},
+		Recorder: recorder.Config{
+			Enabled:      getBool("REQUEST_RECORDING_ENABLED", false),
+			Capacity:     getInt("REQUEST_RECORDING_CAPACITY", 200),
+			MaxBodyBytes: getInt("REQUEST_RECORDING_MAX_BODY", 16<<10),
+			MaxWindow:    getDuration("REQUEST_RECORDING_MAX_WINDOW", time.Hour),
+			// reading the buffer must not push the recorded exchanges out
+			ExemptPaths: []string{"/health", "/readyz", "/metrics", "/admin/recording"},
+		},
+		Pagination: pagination.Settings{
+			DefaultPageSize: getInt("PAGE_SIZE_DEFAULT", pagination.DefaultPageSize),
+			MaxPageSize:     getInt("PAGE_SIZE_MAX", pagination.MaxPageSize),
+		},
+	}
+
+	// a password served by the secret manager is re-read on every dial, so
+	// rotating it there only costs the connections that were already open
+	if fromSecretStore("DB_PASSWORD") {
+		cfg.DB.Credentials = storedPassword{store: secretStore, key: "DB_PASSWORD"}
+	}
+
+	// EXPLAIN sampling adds load to the primary and injected failures must
+	// never reach real users, keep both to local development
+	if cfg.Env != "development" {
+		cfg.DB.Tracer.ExplainSampleRate = 0
+		cfg.Chaos.Enabled = false
 	}
 
 	slog.Info(fmt.Sprintf("[Config]: Environment %s is established", cfg.Env))
 	return cfg
 }
 
+// storageDriver picks where uploads are kept when STORAGE_DRIVER is unset:
+// local disk in development or while no bucket is configured, the bucket
+// everywhere else
+func storageDriver(env string) string {
+	if v := readEnv("STORAGE_DRIVER"); v != "" {
+		return v
+	}
+	if env == "development" || readEnv("STORAGE_S3_BUCKET") == "" {
+		return blob.DriverDisk
+	}
+	return blob.DriverS3
+}
+
+// secretStore is the secret manager behind readEnv, nil unless
+// SECRETS_DRIVER is set
+var secretStore *secrets.Store
+
+// loadSecretStore connects the secret manager named by SECRETS_DRIVER. Its
+// own settings come from the environment only, and its first fetch happens
+// here so a wrong token stops the boot instead of the first request.
+func loadSecretStore() *secrets.Store {
+	store, err := secrets.New(secrets.Config{
+		Driver: readEnv("SECRETS_DRIVER"),
+		Vault: secrets.VaultConfig{
+			Addr:  getEnv("SECRETS_VAULT_ADDR", "http://127.0.0.1:8200"),
+			Token: readEnv("SECRETS_VAULT_TOKEN"),
+			Mount: getEnv("SECRETS_VAULT_MOUNT", "secret"),
+			Path:  readEnv("SECRETS_VAULT_PATH"),
+		},
+		AWS: secrets.AWSConfig{
+			Region:       getEnv("SECRETS_AWS_REGION", "us-east-1"),
+			Endpoint:     readEnv("SECRETS_AWS_ENDPOINT"),
+			SecretID:     readEnv("SECRETS_AWS_SECRET_ID"),
+			AccessKey:    readEnv("SECRETS_AWS_ACCESS_KEY"),
+			SecretKey:    readEnv("SECRETS_AWS_SECRET_KEY"),
+			SessionToken: readEnv("SECRETS_AWS_SESSION_TOKEN"),
+		},
+		TTL:     getDuration("SECRETS_TTL", 5*time.Minute),
+		Timeout: getDuration("SECRETS_TIMEOUT", 5*time.Second),
+	})
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: %v", err))
+	}
+	if store == nil {
+		return nil
+	}
+	if _, err := store.Get(context.Background(), "DB_PASSWORD"); err != nil && !errors.Is(err, secrets.ErrNotFound) {
+		panic(fmt.Sprintf("[Config]: Unable to read the secret manager, %v", err))
+	}
+	slog.Info("[Config]: Secret manager connected", "driver", readEnv("SECRETS_DRIVER"))
+	return store
+}
+
+// fromSecretStore reports whether key is left to the secret manager and the
+// manager holds it
+func fromSecretStore(key string) bool {
+	if secretStore == nil || os.Getenv(key) != "" || os.Getenv(key+"_FILE") != "" {
+		return false
+	}
+	_, err := secretStore.Get(context.Background(), key)
+	return err == nil
+}
+
+// storedPassword hands the database one key of the secret manager
+type storedPassword struct {
+	store *secrets.Store
+	key   string
+}
+
+func (p storedPassword) Password(ctx context.Context) (string, error) {
+	return p.store.Get(ctx, p.key)
+}
+
+func (p storedPassword) Invalidate() {
+	p.store.Invalidate()
+}
+
+// readEnv reads key, or when it is unset the file named by key_FILE, the way
+// Docker and Kubernetes mount secrets. Whitespace around the file's content,
+// like the trailing newline most editors add, is trimmed. When neither is set
+// and a secret manager is configured, the key is looked up there.
+func readEnv(key string) string {
+	v, path := os.Getenv(key), os.Getenv(key+"_FILE")
+	switch {
+	case path == "" && v == "" && secretStore != nil:
+		return readSecret(key)
+	case path == "":
+		return v
+	case v != "":
+		panic(fmt.Sprintf("[Config]: Env vars %q and %q are both set, keep one", key, key+"_FILE"))
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Env var %q names an unreadable file: %v", key+"_FILE", err))
+	}
+	return strings.TrimSpace(string(b))
+}
+
+func readSecret(key string) string {
+	v, err := secretStore.Get(context.Background(), key)
+	if errors.Is(err, secrets.ErrNotFound) {
+		return ""
+	}
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Unable to read %q from the secret manager, %v", key, err))
+	}
+	return v
+}
+
+// databaseURL is DATABASE_URL with DB_PASSWORD, when set, as its password,
+// so the URL can live in plain config and only the password in a secret
+func databaseURL() string {
+	raw := mustEnv("DATABASE_URL")
+	password := readEnv("DB_PASSWORD")
+	if password == "" {
+		return raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" {
+		panic(fmt.Sprintf("[Config]: Env var %q must be a URL to take DB_PASSWORD", "DATABASE_URL"))
+	}
+	u.User = url.UserPassword(u.User.Username(), password)
+	return u.String()
+}
+
 func mustEnv(key string) string {
-	v := os.Getenv(key)
+	v := readEnv(key)
 	if v == "" {
 		panic(fmt.Sprintf("[Config]: Required environment variable %q is not set", key))
 	}
@@ -89,7 +445,7 @@ func mustEnv(key string) string {
 }
 
 func getEnv(key, fallback string) string {
-	if v := os.Getenv(key); v != "" {
+	if v := readEnv(key); v != "" {
 		return v
 	}
 	slog.Info(fmt.Sprintf("[Config]: Env %s is missing, using '%s' as a fallback", key, fallback))
@@ -97,7 +453,7 @@ func getEnv(key, fallback string) string {
 }
 
 func getInt(key string, fallback int) int {
-	v := os.Getenv(key)
+	v := readEnv(key)
 	if v == "" {
 		return fallback
 	}
@@ -108,8 +464,64 @@ func getInt(key string, fallback int) int {
 	return n
 }
 
+func getBool(key string, fallback bool) bool {
+	v := readEnv(key)
+	if v == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Env var %q must be a boolean, got %q", key, v))
+	}
+	return b
+}
+
+func getFloat(key string, fallback float64) float64 {
+	v := readEnv(key)
+	if v == "" {
+		return fallback
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Env var %q must be a number, got %q", key, v))
+	}
+	return f
+}
+
+// getOneOf reads key, which must be one of allowed; the first is the default
+func getOneOf(key string, allowed ...string) string {
+	v := getEnv(key, allowed[0])
+	if !slices.Contains(allowed, v) {
+		panic(fmt.Sprintf("[Config]: Env var %q must be one of %s, got %q", key, strings.Join(allowed, ", "), v))
+	}
+	return v
+}
+
+func getLogLevel(key string, fallback slog.Level) slog.Level {
+	v := readEnv(key)
+	if v == "" {
+		return fallback
+	}
+	var level slog.Level
+	if err := level.UnmarshalText([]byte(v)); err != nil {
+		panic(fmt.Sprintf("[Config]: Env var %q must be debug, info, warn or error, got %q", key, v))
+	}
+	return level
+}
+
+// getList splits a comma separated variable, dropping blank entries
+func getList(key string) []string {
+	var out []string
+	for _, v := range strings.Split(readEnv(key), ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
 func getDuration(key string, fallback time.Duration) time.Duration {
-	v := os.Getenv(key)
+	v := readEnv(key)
 	if v == "" {
 		return fallback
 	}
@@ -119,3 +531,13 @@ func getDuration(key string, fallback time.Duration) time.Duration {
 	}
 	return d
 }
+
+// getPositiveDuration reads a duration that has to be above zero, such as a
+// ticker interval
+func getPositiveDuration(key string, fallback time.Duration) time.Duration {
+	d := getDuration(key, fallback)
+	if d <= 0 {
+		panic(fmt.Sprintf("[Config]: Env var %q must be a positive duration, got %q", key, readEnv(key)))
+	}
+	return d
+}
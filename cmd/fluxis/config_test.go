@@ -0,0 +1,131 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// catch runs fn and returns what it panicked with, the way config helpers
+// refuse a bad value on startup
+func catch(fn func() string) (got, panicked string) {
+	defer func() {
+		if r := recover(); r != nil {
+			panicked = fmt.Sprint(r)
+		}
+	}()
+	return fn(), ""
+}
+
+func TestReadEnv_File(t *testing.T) {
+	dir := t.TempDir()
+	secret := writeFile(t, dir, "secret", "  s3cret\n")
+	password := writeFile(t, dir, "db-password", "p@ss:word\n")
+
+	tests := []struct {
+		name      string
+		env       map[string]string
+		read      func() string
+		want      string
+		wantPanic string
+	}{
+		{
+			name: "plain value",
+			env:  map[string]string{"FLUXIS_TEST_SECRET": "plain"},
+			read: func() string { return readEnv("FLUXIS_TEST_SECRET") },
+			want: "plain",
+		},
+		{
+			name: "file trimmed",
+			env:  map[string]string{"FLUXIS_TEST_SECRET_FILE": secret},
+			read: func() string { return readEnv("FLUXIS_TEST_SECRET") },
+			want: "s3cret",
+		},
+		{
+			name: "neither set",
+			read: func() string { return readEnv("FLUXIS_TEST_SECRET") },
+			want: "",
+		},
+		{
+			name:      "both set",
+			env:       map[string]string{"FLUXIS_TEST_SECRET": "plain", "FLUXIS_TEST_SECRET_FILE": secret},
+			read:      func() string { return readEnv("FLUXIS_TEST_SECRET") },
+			wantPanic: "are both set",
+		},
+		{
+			name:      "unreadable file",
+			env:       map[string]string{"FLUXIS_TEST_SECRET_FILE": filepath.Join(dir, "missing")},
+			read:      func() string { return readEnv("FLUXIS_TEST_SECRET") },
+			wantPanic: "names an unreadable file",
+		},
+		{
+			name: "typed helpers read files too",
+			env:  map[string]string{"FLUXIS_TEST_SECRET_FILE": secret, "FLUXIS_TEST_INT_FILE": writeFile(t, dir, "int", "42\n")},
+			read: func() string {
+				return fmt.Sprint(getEnv("FLUXIS_TEST_SECRET", "fallback"), " ", getInt("FLUXIS_TEST_INT", 1))
+			},
+			want: "s3cret 42",
+		},
+		{
+			name: "required value from a file",
+			env:  map[string]string{"FLUXIS_TEST_SECRET_FILE": secret},
+			read: func() string { return mustEnv("FLUXIS_TEST_SECRET") },
+			want: "s3cret",
+		},
+		{
+			name:      "interval must be positive",
+			env:       map[string]string{"FLUXIS_TEST_INT": "0s"},
+			read:      func() string { return getPositiveDuration("FLUXIS_TEST_INT", time.Second).String() },
+			wantPanic: "must be a positive duration",
+		},
+		{
+			name: "database password from a file",
+			env: map[string]string{
+				"DATABASE_URL":     "postgres://fluxis@db:5432/fluxis?sslmode=disable",
+				"DB_PASSWORD_FILE": password,
+			},
+			read: databaseURL,
+			want: "postgres://fluxis:p%40ss%3Aword@db:5432/fluxis?sslmode=disable",
+		},
+		{
+			name:      "database password needs a URL",
+			env:       map[string]string{"DATABASE_URL": "host=db user=fluxis", "DB_PASSWORD_FILE": password},
+			read:      databaseURL,
+			wantPanic: "must be a URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, key := range []string{"FLUXIS_TEST_SECRET", "FLUXIS_TEST_SECRET_FILE", "FLUXIS_TEST_INT", "FLUXIS_TEST_INT_FILE", "DATABASE_URL", "DB_PASSWORD", "DB_PASSWORD_FILE"} {
+				t.Setenv(key, tt.env[key])
+			}
+
+			got, panicked := catch(tt.read)
+			if tt.wantPanic != "" {
+				if !strings.Contains(panicked, tt.wantPanic) {
+					t.Fatalf("panic = %q, want one containing %q", panicked, tt.wantPanic)
+				}
+				return
+			}
+			if panicked != "" {
+				t.Fatalf("unexpected panic: %s", panicked)
+			}
+			if got != tt.want {
+				t.Fatalf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"expvar"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"runtime"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/cache"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+)
+
+// DebugConfig enables the diagnostics listener; it stays off while Addr is
+// empty
+type DebugConfig struct {
+	Addr string
+}
+
+// startDebugServer serves net/http/pprof and expvar on a listener of their
+// own, so goroutine and heap profiles can be taken in production without any
+// of it being reachable through the public port. Besides the runtime's
+// memstats and cmdline, expvar publishes the goroutine count, the size of the
+// shared data cache and the events waiting in bus subscriber queues.
+func startDebugServer(cfg DebugConfig, dataC *cache.MemoryCache, bus pubsub.Bus) {
+	if cfg.Addr == "" {
+		return
+	}
+	if host, _, err := net.SplitHostPort(cfg.Addr); err == nil {
+		if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+			slog.Warn(fmt.Sprintf("[Debug]: %s is not a loopback address, keep it behind a firewall", cfg.Addr))
+		}
+	}
+
+	expvar.Publish("goroutines", expvar.Func(func() any {
+		return runtime.NumGoroutine()
+	}))
+	expvar.Publish("data_cache", expvar.Func(func() any {
+		entries, bytes := dataC.Stats()
+		return map[string]int{"entries": entries, "bytes": bytes}
+	}))
+	if b, ok := bus.(interface{ Pending() map[string]int }); ok {
+		expvar.Publish("bus_pending", expvar.Func(func() any {
+			return b.Pending()
+		}))
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /debug/pprof/", pprof.Index)
+	mux.HandleFunc("GET /debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("GET /debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("GET /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("POST /debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("GET /debug/pprof/trace", pprof.Trace)
+	mux.Handle("GET /debug/vars", expvar.Handler())
+
+	// no write timeout, CPU profiles and traces stream for as long as their
+	// seconds parameter asks
+	svr := http.Server{
+		Addr:              cfg.Addr,
+		Handler:           mux,
+		ReadHeaderTimeout: 5 * time.Second,
+		IdleTimeout:       60 * time.Second,
+	}
+
+	go func() {
+		slog.Info(fmt.Sprintf("[Debug]: pprof and expvar listening on %s", cfg.Addr))
+		if err := svr.ListenAndServe(); err != http.ErrServerClosed {
+			slog.Error("[Debug]: Failed to start the debug server", "error", err)
+		}
+	}()
+}
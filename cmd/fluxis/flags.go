@@ -0,0 +1,31 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/pkg/postgres"
+)
+
+// parseMigrationFlags reads which migration sets the binary applies on
+// start. The default keeps to the expand set so a replica never drops what
+// the release it replaces still reads.
+func parseMigrationFlags() postgres.MigrationConfig {
+	phase := flag.String("migrate", string(postgres.MigrationExpand), "migrations to apply on start: expand, contract, all or none")
+	only := flag.Bool("migrate-only", false, "exit once migrated instead of serving, for a deploy step")
+	flag.Parse()
+
+	cfg := postgres.MigrationConfig{Only: *only}
+	switch *phase {
+	case string(postgres.MigrationExpand):
+		cfg.Phases = []postgres.MigrationPhase{postgres.MigrationExpand}
+	case string(postgres.MigrationContract):
+		cfg.Phases = []postgres.MigrationPhase{postgres.MigrationContract}
+	case "all":
+		cfg.Phases = postgres.MigrationPhases
+	case "none":
+	default:
+		panic(fmt.Sprintf("[Config]: flag -migrate must be expand, contract, all or none, got %q", *phase))
+	}
+	return cfg
+}
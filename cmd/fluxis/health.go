@@ -0,0 +1,59 @@
+package main
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// dbHealth is what the probes need from postgres.Monitor
+type dbHealth interface {
+	Healthy() bool
+	RetryAfter() time.Duration
+}
+
+// readiness is the /readyz body. It is served without auth, so the reason a
+// ping failed stays in the logs.
+type readiness struct {
+	Status string `json:"status"`
+}
+
+// readyHandler answers the readiness probe, 503 while the database is
+// unreachable
+func readyHandler(db dbHealth) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if !db.Healthy() {
+			setRetryAfter(w, db)
+			httpx.JSON(w, http.StatusServiceUnavailable, readiness{Status: "degraded"})
+			return
+		}
+		httpx.OK(w, readiness{Status: "ready"})
+	}
+}
+
+// degraded short-circuits requests with 503 while the database is
+// unreachable, instead of letting every handler fail with an opaque error.
+// Probes listed in skip keep being served.
+func degraded(db dbHealth, next http.Handler, skip ...string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if db.Healthy() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		for _, path := range skip {
+			if r.URL.Path == path {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+
+		setRetryAfter(w, db)
+		httpx.Handle(w, httpx.ServiceUnavailable("database is unavailable, please retry shortly").WithCode("database_unavailable"))
+	})
+}
+
+func setRetryAfter(w http.ResponseWriter, db dbHealth) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(db.RetryAfter().Seconds())))
+}
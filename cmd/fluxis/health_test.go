@@ -0,0 +1,57 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type stubDB bool
+
+func (s stubDB) Healthy() bool             { return bool(s) }
+func (s stubDB) RetryAfter() time.Duration { return 10 * time.Second }
+
+func TestProbes(t *testing.T) {
+	api := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+
+	tests := []struct {
+		name           string
+		healthy        bool
+		path           string
+		wantStatus     int
+		wantBody       string
+		wantRetryAfter string
+	}{
+		{"ready", true, "/readyz", http.StatusOK, `"status":"ready"`, ""},
+		{"not ready", false, "/readyz", http.StatusServiceUnavailable, `"status":"degraded"`, "10"},
+		{"api while healthy", true, "/projects", http.StatusTeapot, "", ""},
+		{"api while degraded", false, "/projects", http.StatusServiceUnavailable, "database_unavailable", "10"},
+		{"skipped probe while degraded", false, "/health", http.StatusTeapot, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := stubDB(tt.healthy)
+			mux := http.NewServeMux()
+			mux.HandleFunc("GET /readyz", readyHandler(db))
+			mux.Handle("/", api)
+
+			rec := httptest.NewRecorder()
+			degraded(db, mux, "/health", "/readyz").ServeHTTP(rec, httptest.NewRequest("GET", tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if !strings.Contains(rec.Body.String(), tt.wantBody) {
+				t.Errorf("body = %s, want it to contain %s", rec.Body, tt.wantBody)
+			}
+			if got := rec.Header().Get("Retry-After"); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q, want %q", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
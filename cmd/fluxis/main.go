@@ -12,10 +12,14 @@ import (
 	"github.com/dimasbaguspm/fluxis/pkg/cache"
 	"github.com/dimasbaguspm/fluxis/pkg/cors"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
 	"github.com/dimasbaguspm/fluxis/pkg/postgres"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 	ratelimit "github.com/dimasbaguspm/fluxis/pkg/rate-limit"
-	httpSwagger "github.com/swaggo/http-swagger/v2"
+	"github.com/dimasbaguspm/fluxis/pkg/readonly"
+	"github.com/dimasbaguspm/fluxis/pkg/recorder"
+	"github.com/dimasbaguspm/fluxis/pkg/spa"
+	"github.com/dimasbaguspm/fluxis/web"
 )
 
 // @title					Fluxis API
@@ -37,12 +41,19 @@ import (
 // @description					Bearer token obtained from /auth/login or /auth/refresh
 func main() {
 	cfg := LoadEnv()
+	slog.SetLogLoggerLevel(cfg.LogLevel)
+	migration := parseMigrationFlags()
+	configurePagination(cfg.Pagination)
 
 	ctx, close := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGINT)
 	defer close()
 
 	db := postgres.MustConnect(ctx, cfg.DB)
-	postgres.RunMigration(cfg.DB)
+	postgres.RunMigration(cfg.DB, migration.Phases)
+	if migration.Only {
+		db.Close()
+		return
+	}
 
 	bus := pubsub.New()
 
@@ -51,26 +62,37 @@ func main() {
 	defer db.Close()
 	defer bus.Close()
 
+	ipFilter := newIPFilter(cfg.IPFilter)
+	reqRecorder := recorder.New(cfg.Recorder)
+	if cfg.Recorder.Enabled {
+		slog.Info("[Config]: request recording can be started from /admin/recording", "capacity", cfg.Recorder.Capacity)
+	}
+
 	app := Wire(Deps{
 		DB:        db,
 		Config:    cfg,
 		Bus:       bus,
 		DataCache: dataC,
+		IPFilter:  ipFilter,
+		Recorder:  reqRecorder,
 	})
 
-	httpx.InitAuth(app.Auth.Service())
+	startDebugServer(cfg.Debug, dataC, bus)
+
+	dbMonitor := postgres.NewMonitor(db, cfg.DB.Health, cfg.DB.Credentials)
+	go dbMonitor.Start(ctx)
 
 	mux := http.NewServeMux()
 
 	mux.HandleFunc("GET /health", func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
-	mux.HandleFunc("GET /swagger/doc.json", func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, "./api/swagger.json")
-	})
-	mux.Handle("GET /swagger/", httpSwagger.Handler(
-		httpSwagger.URL("/swagger/doc.json"),
-	))
+	mux.HandleFunc("GET /readyz", readyHandler(dbMonitor))
+	if cfg.Server.Metrics {
+		// scrape targets are usually kept private with IP_FILTER_PATHS=/metrics
+		mux.Handle("GET /metrics", metrics.Handler(metrics.Default))
+		mux.Handle("GET /metrics/docs", metrics.DocsHandler(metrics.Default))
+	}
 
 	// mount domain routes onto the mux
 	// each domain registers its own paths
@@ -81,6 +103,19 @@ func main() {
 	app.Sprint.Routes(mux)
 	app.Board.Routes(mux)
 	app.Ticket.Routes(mux)
+	app.Attachment.Routes(mux)
+	app.Report.Routes(mux)
+	app.Change.Routes(mux)
+	app.Activity.Routes(mux)
+	app.Usage.Routes(mux)
+	app.Integration.Routes(mux)
+	app.Calendar.Routes(mux)
+	app.Notification.Routes(mux)
+	app.Admin.Routes(mux)
+	app.Integrity.Routes(mux)
+	app.Retention.Routes(mux)
+	app.Trash.Routes(mux)
+	app.Docs.Routes(mux)
 
 	// start event subscribers
 	go app.Auth.StartSubscriber(ctx)
@@ -90,17 +125,49 @@ func main() {
 	go app.Sprint.StartSubscriber(ctx)
 	go app.Board.StartSubscriber(ctx)
 	go app.Ticket.StartSubscriber(ctx)
-
-	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-		httpx.Handle(w, httpx.NotImplemented("endpoint is not implemented"))
-	})
+	go app.Change.StartSubscriber(ctx)
+	go app.Activity.StartSubscriber(ctx)
+	go app.Notification.StartSubscriber(ctx)
+
+	// background maintenance
+	go app.Board.StartCompactor(ctx, cfg.Jobs.ColumnCompaction)
+	go app.Integrity.StartChecker(ctx, cfg.Jobs.IntegrityCheck, cfg.Jobs.IntegrityFix)
+	go app.Report.StartSnapshotter(ctx, cfg.Jobs.BoardSnapshot)
+	go app.Retention.StartPurger(ctx, cfg.Jobs.Purge)
+	go app.Retention.StartLogMonitor(ctx, cfg.Jobs.LogGrowth)
+	go app.Usage.StartFlusher(ctx, cfg.Jobs.UsageFlush)
+	go app.Ticket.StartStaleSweeper(ctx, cfg.Jobs.StaleSweep)
+
+	// in single binary mode every unmatched path belongs to the frontend
+	if dist, ok := web.Dist(); cfg.Server.ServeWeb && ok {
+		slog.Info("[Core]: serving embedded frontend")
+		mux.Handle("/", spa.Handler(dist))
+	} else {
+		if cfg.Server.ServeWeb {
+			slog.Warn("[Core]: SERVE_WEB is set but the binary was built without -tags embedweb")
+		}
+		mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+			httpx.Handle(w, httpx.NotImplemented("endpoint is not implemented"))
+		})
+	}
 
 	rl := ratelimit.New(cfg.RateLimit)
-	cors := cors.New(cfg.CORS)
+	corsPolicy := cors.NewPolicy(cfg.CORS)
+	runtime := &settings{rateLimit: rl, cors: corsPolicy}
+	go runtime.watchReload(ctx)
+	readOnly := readonly.New(cfg.ReadOnly)
+	if cfg.ReadOnly.Enabled {
+		slog.Warn("[Core]: read-only mode is enabled, mutations are rejected")
+	}
+	chaos := newChaos(cfg.Chaos)
 
+	// blocked addresses are turned away before they spend rate limit or
+	// reach authentication; instrumentation and the recorder sit outside so
+	// those refusals are counted and recorded too. chaos sits innermost so
+	// injected failures look like handler failures to everything around it
 	svr := http.Server{
 		Addr:         cfg.Server.addr(),
-		Handler:      cors(rl.Wrap(mux)),
+		Handler:      metrics.Instrument(reqRecorder.Wrap(ipFilter.Wrap(corsPolicy.Wrap(rl.Wrap(readOnly(chaos(degraded(dbMonitor, mux, "/health", "/readyz", "/metrics"))))))), mux),
 		ReadTimeout:  cfg.Server.ReadTimeout,
 		WriteTimeout: cfg.Server.WriteTimeout,
 		IdleTimeout:  cfg.Server.IdleTimeout,
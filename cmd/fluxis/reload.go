@@ -0,0 +1,81 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/dimasbaguspm/fluxis/pkg/cors"
+	ratelimit "github.com/dimasbaguspm/fluxis/pkg/rate-limit"
+)
+
+// settings holds the live middlewares whose configuration a SIGHUP swaps
+type settings struct {
+	rateLimit *ratelimit.Middleware
+	cors      *cors.Policy
+}
+
+// watchReload applies RuntimeConfig again on every SIGHUP. The process
+// environment cannot change once started, so new values arrive through the
+// files named by *_FILE, e.g. a mounted ConfigMap, or the secret manager.
+func (s *settings) watchReload(ctx context.Context) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			s.reload()
+		}
+	}
+}
+
+func (s *settings) reload() {
+	slog.Info("[Config]: SIGHUP received, reloading runtime settings")
+	if secretStore != nil {
+		secretStore.Invalidate()
+	}
+
+	runtime, err := s.apply(loadRuntime)
+	if err != nil {
+		slog.Error("[Config]: reload rejected, keeping the running settings", "error", err)
+		return
+	}
+	slog.Info("[Config]: runtime settings reloaded",
+		"logLevel", runtime.LogLevel.String(),
+		"rateLimit", runtime.RateLimit.MaxRequests,
+		"rateLimitWindow", runtime.RateLimit.Window.String(),
+		"corsOrigins", runtime.CORS.AllowedOrigins,
+	)
+}
+
+// apply loads the runtime settings with load and swaps them into the live
+// middlewares. It keeps the running settings when any value is invalid, a
+// typo in one file must not take the others down with it.
+func (s *settings) apply(load func() RuntimeConfig) (RuntimeConfig, error) {
+	runtime, err := tryLoad(load)
+	if err != nil {
+		return RuntimeConfig{}, err
+	}
+
+	slog.SetLogLoggerLevel(runtime.LogLevel)
+	s.rateLimit.SetConfig(runtime.RateLimit)
+	s.cors.SetConfig(runtime.CORS)
+	return runtime, nil
+}
+
+// tryLoad turns the panics load raises on startup into an error
+func tryLoad(load func() RuntimeConfig) (runtime RuntimeConfig, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return load(), nil
+}
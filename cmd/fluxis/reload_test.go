@@ -0,0 +1,125 @@
+package main
+
+import (
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/cors"
+	ratelimit "github.com/dimasbaguspm/fluxis/pkg/rate-limit"
+)
+
+const (
+	oldOrigin = "https://old.example.com"
+	newOrigin = "https://new.example.com"
+)
+
+func newTestSettings() *settings {
+	return &settings{
+		rateLimit: ratelimit.New(ratelimit.Config{MaxRequests: 100, Window: time.Minute}),
+		cors:      cors.NewPolicy(cors.Config{AllowedOrigins: oldOrigin}),
+	}
+}
+
+// served sends n requests from origin through the live middlewares and
+// reports whether the last one was let through and allowed by CORS
+func (s *settings) served(n int, origin string) (passed, allowed bool) {
+	h := s.cors.Wrap(s.rateLimit.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {})))
+	var rec *httptest.ResponseRecorder
+	for range n {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Origin", origin)
+		rec = httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+	}
+	return rec.Code == http.StatusOK, rec.Header().Get("Access-Control-Allow-Origin") == origin
+}
+
+func TestSettingsApply(t *testing.T) {
+	t.Cleanup(func() { slog.SetLogLoggerLevel(slog.LevelInfo) })
+
+	origins := filepath.Join(t.TempDir(), "cors-origins")
+	if err := os.WriteFile(origins, []byte(newOrigin+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+		// what the live middlewares do afterwards
+		wantNewOrigin  bool
+		wantOldOrigin  bool
+		wantThirdPass  bool
+		wantDebugLevel bool
+	}{
+		{
+			name: "valid values applied",
+			env: map[string]string{
+				"CORS_ALLOWED_ORIGINS_FILE": origins,
+				"RATE_LIMIT_MAX_REQUESTS":   "2",
+				"LOG_LEVEL":                 "debug",
+			},
+			wantNewOrigin:  true,
+			wantDebugLevel: true,
+		},
+		{
+			name: "one invalid value keeps everything",
+			env: map[string]string{
+				"CORS_ALLOWED_ORIGINS_FILE": origins,
+				"RATE_LIMIT_MAX_REQUESTS":   "two",
+				"LOG_LEVEL":                 "debug",
+			},
+			wantErr:       "RATE_LIMIT_MAX_REQUESTS",
+			wantOldOrigin: true,
+			wantThirdPass: true,
+		},
+		{
+			name: "unreadable file keeps everything",
+			env: map[string]string{
+				"CORS_ALLOWED_ORIGINS_FILE": filepath.Join(t.TempDir(), "missing"),
+				"RATE_LIMIT_MAX_REQUESTS":   "2",
+			},
+			wantErr:       "CORS_ALLOWED_ORIGINS_FILE",
+			wantOldOrigin: true,
+			wantThirdPass: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slog.SetLogLoggerLevel(slog.LevelInfo)
+			for key, v := range tt.env {
+				t.Setenv(key, v)
+			}
+			s := newTestSettings()
+
+			_, err := s.apply(loadRuntime)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("apply = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("apply = %v, want an error naming %s", err, tt.wantErr)
+			}
+
+			if _, allowed := s.served(1, newOrigin); allowed != tt.wantNewOrigin {
+				t.Errorf("new origin allowed = %v, want %v", allowed, tt.wantNewOrigin)
+			}
+			if _, allowed := s.served(1, oldOrigin); allowed != tt.wantOldOrigin {
+				t.Errorf("old origin allowed = %v, want %v", allowed, tt.wantOldOrigin)
+			}
+			// the two requests above were counted against the same client
+			if passed, _ := s.served(1, newOrigin); passed != tt.wantThirdPass {
+				t.Errorf("third request passed = %v, want %v", passed, tt.wantThirdPass)
+			}
+			if debug := slog.Default().Enabled(t.Context(), slog.LevelDebug); debug != tt.wantDebugLevel {
+				t.Errorf("debug logging = %v, want %v", debug, tt.wantDebugLevel)
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package main
 
 import (
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/api"
 	"github.com/dimasbaguspm/fluxis/internal/auth"
 	authhandler "github.com/dimasbaguspm/fluxis/internal/auth/handler"
 	authservice "github.com/dimasbaguspm/fluxis/internal/auth/service"
@@ -41,19 +46,104 @@ import (
 	ticketrepo "github.com/dimasbaguspm/fluxis/internal/ticket/repository"
 	ticketservice "github.com/dimasbaguspm/fluxis/internal/ticket/service"
 
+	"github.com/dimasbaguspm/fluxis/internal/attachment"
+	attachmenthandler "github.com/dimasbaguspm/fluxis/internal/attachment/handler"
+	attachmentrepo "github.com/dimasbaguspm/fluxis/internal/attachment/repository"
+	attachmentservice "github.com/dimasbaguspm/fluxis/internal/attachment/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/report"
+	reporthandler "github.com/dimasbaguspm/fluxis/internal/report/handler"
+	reportrepo "github.com/dimasbaguspm/fluxis/internal/report/repository"
+	reportservice "github.com/dimasbaguspm/fluxis/internal/report/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity"
+	activityhandler "github.com/dimasbaguspm/fluxis/internal/activity/handler"
+	activityrepo "github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	activityservice "github.com/dimasbaguspm/fluxis/internal/activity/service"
+	"github.com/dimasbaguspm/fluxis/internal/change"
+	changehandler "github.com/dimasbaguspm/fluxis/internal/change/handler"
+	changerepo "github.com/dimasbaguspm/fluxis/internal/change/repository"
+	changeservice "github.com/dimasbaguspm/fluxis/internal/change/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/usage"
+	usagehandler "github.com/dimasbaguspm/fluxis/internal/usage/handler"
+	usagerepo "github.com/dimasbaguspm/fluxis/internal/usage/repository"
+	usageservice "github.com/dimasbaguspm/fluxis/internal/usage/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/notification"
+	notificationhandler "github.com/dimasbaguspm/fluxis/internal/notification/handler"
+	notificationrepo "github.com/dimasbaguspm/fluxis/internal/notification/repository"
+	notificationservice "github.com/dimasbaguspm/fluxis/internal/notification/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/integration"
+	integrationhandler "github.com/dimasbaguspm/fluxis/internal/integration/handler"
+	integrationrepo "github.com/dimasbaguspm/fluxis/internal/integration/repository"
+	integrationservice "github.com/dimasbaguspm/fluxis/internal/integration/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/caldav"
+	caldavhandler "github.com/dimasbaguspm/fluxis/internal/caldav/handler"
+	caldavrepo "github.com/dimasbaguspm/fluxis/internal/caldav/repository"
+	caldavservice "github.com/dimasbaguspm/fluxis/internal/caldav/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/admin"
+	adminhandler "github.com/dimasbaguspm/fluxis/internal/admin/handler"
+
+	"github.com/dimasbaguspm/fluxis/internal/apidocs"
+	apidocshandler "github.com/dimasbaguspm/fluxis/internal/apidocs/handler"
+
+	"github.com/dimasbaguspm/fluxis/internal/integrity"
+	integrityhandler "github.com/dimasbaguspm/fluxis/internal/integrity/handler"
+	integrityrepo "github.com/dimasbaguspm/fluxis/internal/integrity/repository"
+	integrityservice "github.com/dimasbaguspm/fluxis/internal/integrity/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/retention"
+	retentionhandler "github.com/dimasbaguspm/fluxis/internal/retention/handler"
+	retentionrepo "github.com/dimasbaguspm/fluxis/internal/retention/repository"
+	retentionservice "github.com/dimasbaguspm/fluxis/internal/retention/service"
+
+	"github.com/dimasbaguspm/fluxis/internal/trash"
+	trashhandler "github.com/dimasbaguspm/fluxis/internal/trash/handler"
+	trashrepo "github.com/dimasbaguspm/fluxis/internal/trash/repository"
+	trashservice "github.com/dimasbaguspm/fluxis/internal/trash/service"
+
+	"github.com/dimasbaguspm/fluxis/pkg/blob"
 	"github.com/dimasbaguspm/fluxis/pkg/cache"
+	"github.com/dimasbaguspm/fluxis/pkg/chaos"
+	"github.com/dimasbaguspm/fluxis/pkg/contentfilter"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/ipfilter"
+	"github.com/dimasbaguspm/fluxis/pkg/lease"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+	"github.com/dimasbaguspm/fluxis/pkg/postgres"
+	"github.com/dimasbaguspm/fluxis/pkg/presence"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/recorder"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+	"github.com/dimasbaguspm/fluxis/pkg/webpush"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type App struct {
-	Auth    *auth.Module
-	User    *user.Module
-	Org     *org.Module
-	Project *project.Module
-	Sprint  *sprint.Module
-	Board   *board.Module
-	Ticket  *ticket.Module
+	Auth         *auth.Module
+	User         *user.Module
+	Org          *org.Module
+	Project      *project.Module
+	Sprint       *sprint.Module
+	Board        *board.Module
+	Ticket       *ticket.Module
+	Attachment   *attachment.Module
+	Report       *report.Module
+	Change       *change.Module
+	Activity     *activity.Module
+	Usage        *usage.Module
+	Integration  *integration.Module
+	Calendar     *caldav.Module
+	Notification *notification.Module
+	Admin        *admin.Module
+	Integrity    *integrity.Module
+	Retention    *retention.Module
+	Trash        *trash.Module
+	Docs         *apidocs.Module
 }
 
 type Deps struct {
@@ -61,18 +151,37 @@ type Deps struct {
 	Config    *Config
 	Bus       pubsub.Bus
 	DataCache cache.Cache
+	IPFilter  *ipfilter.Filter
+	Recorder  *recorder.Recorder
 }
 
 func Wire(d Deps) *App {
-	userRepo := userrepo.New(d.DB)
-	orgRepo := orgrepo.New(d.DB)
-	projectRepo := projectrepo.New(d.DB)
-	sprintRepo := sprintrepo.New(d.DB)
-	boardRepo := boardrepo.New(d.DB)
-	ticketRepo := ticketrepo.New(d.DB)
+	db := postgres.WithQueryTimeout(d.DB, d.Config.DB.QueryTimeout)
+
+	// one filter screens user written text across modules
+	contentFilter := contentfilter.New(d.Config.Content)
+
+	userRepo := userrepo.New(db)
+	orgRepo := orgrepo.New(db)
+	projectRepo := projectrepo.New(db)
+	sprintRepo := sprintrepo.New(db)
+	boardRepo := boardrepo.New(db)
+	ticketRepo := ticketrepo.New(db)
+	attachmentRepo := attachmentrepo.New(db)
+	reportRepo := reportrepo.New(db)
+	changeRepo := changerepo.New(db)
+	activityRepo := activityrepo.New(db)
+	usageRepo := usagerepo.New(db)
+	integrationRepo := integrationrepo.New(db)
+	caldavRepo := caldavrepo.New(db)
+	notificationRepo := notificationrepo.New(db)
+	integrityRepo := integrityrepo.New(db)
+	retentionRepo := retentionrepo.New(db)
+	trashRepo := trashrepo.New(db)
 
 	userSvc := userservice.New(userservice.Deps{
 		Repo: userRepo,
+		Bus:  d.Bus,
 	})
 	authSvc := authservice.New(authservice.Deps{
 		Users:  userSvc,
@@ -83,10 +192,16 @@ func Wire(d Deps) *App {
 		User: userSvc,
 		Bus:  d.Bus,
 	})
+	// viewer lists are kept per instance, see pkg/presence
+	viewers := presence.New(d.Config.Presence)
 	projectSvc := projectservice.New(projectservice.Deps{
-		Repo: projectRepo,
-		Org:  orgSvc,
-		Bus:  d.Bus,
+		Repo:     projectRepo,
+		DB:       d.DB,
+		Org:      orgSvc,
+		Bus:      d.Bus,
+		Config:   &d.Config.Project,
+		Filter:   contentFilter,
+		Presence: viewers,
 	})
 	sprintSvc := sprintservice.New(sprintservice.Deps{
 		Repo:    sprintRepo,
@@ -94,9 +209,10 @@ func Wire(d Deps) *App {
 		Bus:     d.Bus,
 	})
 	boardSvc := boardservice.New(boardservice.Deps{
-		Repo:   boardRepo,
-		Sprint: sprintSvc,
-		Bus:    d.Bus,
+		Repo:    boardRepo,
+		Sprint:  sprintSvc,
+		Project: projectSvc,
+		Bus:     d.Bus,
 	})
 	ticketSvc := ticketservice.New(ticketservice.Deps{
 		Repo:    ticketRepo,
@@ -104,8 +220,54 @@ func Wire(d Deps) *App {
 		Board:   boardSvc,
 		Sprint:  sprintSvc,
 		Bus:     d.Bus,
+		Config:  &d.Config.Ticket,
+		Filter:  contentFilter,
+		Locks:   lease.New(d.Config.TicketLocks),
+	})
+	blobStore := newBlobStore(d.Config.Storage)
+	hooks := newWebhookSender(d.Config)
+	attachmentSvc := attachmentservice.New(attachmentservice.Deps{
+		Repo:    attachmentRepo,
+		Ticket:  ticketSvc,
+		Project: projectSvc,
+		Store:   blobStore,
+		Config:  &d.Config.Attachment,
+	})
+	reportSvc := reportservice.New(reportservice.Deps{
+		Repo:    reportRepo,
+		Project: projectSvc,
+	})
+	changeSvc := changeservice.New(changeservice.Deps{
+		Repo:    changeRepo,
+		Project: projectSvc,
+	})
+	activitySvc := activityservice.New(activityservice.Deps{
+		Repo:    activityRepo,
+		Project: projectSvc,
+		Hooks:   hooks,
+	})
+	usageSvc := usageservice.New(usageservice.Deps{
+		Repo: usageRepo,
+	})
+	integrationSvc := integrationservice.New(integrationservice.Deps{
+		Repo:    integrationRepo,
+		Project: projectSvc,
+		Ticket:  ticketSvc,
+	})
+	caldavSvc := caldavservice.New(caldavservice.Deps{
+		Repo:   caldavRepo,
+		Ticket: ticketSvc,
 	})
 
+	notificationSvc := notificationservice.New(notificationservice.Deps{
+		Repo:   notificationRepo,
+		Ticket: ticketSvc,
+		Push:   newPushSender(d.Config.Push),
+	})
+
+	// a single authenticator is shared by every module guarding private routes
+	authn := httpx.NewAuthenticator(authSvc)
+
 	userC := usercache.New(d.DataCache)
 	orgC := orgcache.New(d.DataCache)
 	projectC := projectcache.New(d.DataCache)
@@ -138,15 +300,153 @@ func Wire(d Deps) *App {
 		Svc:         ticketSvc,
 		TicketCache: ticketC,
 	})
+	attachmentH := attachmenthandler.New(attachmenthandler.Deps{
+		Svc: attachmentSvc,
+	})
+	reportH := reporthandler.New(reporthandler.Deps{
+		Svc: reportSvc,
+	})
+	changeH := changehandler.New(changehandler.Deps{
+		Svc: changeSvc,
+	})
+	activityH := activityhandler.New(activityhandler.Deps{
+		Svc: activitySvc,
+	})
+	usageH := usagehandler.New(usagehandler.Deps{
+		Svc: usageSvc,
+	})
+	integrationH := integrationhandler.New(integrationhandler.Deps{
+		Svc: integrationSvc,
+	})
+	caldavH := caldavhandler.New(caldavhandler.Deps{
+		Svc: caldavSvc,
+	})
+	notificationH := notificationhandler.New(notificationhandler.Deps{
+		Svc: notificationSvc,
+	})
+	integritySvc := integrityservice.New(integrityservice.Deps{
+		Repo: integrityRepo,
+		DB:   d.DB,
+	})
+	integrityH := integrityhandler.New(integrityhandler.Deps{
+		Svc: integritySvc,
+	})
+	retentionSvc := retentionservice.New(retentionservice.Deps{
+		Repo:   retentionRepo,
+		DB:     d.DB,
+		Store:  blobStore,
+		Hooks:  hooks,
+		Config: &d.Config.Retention,
+	})
+	retentionH := retentionhandler.New(retentionhandler.Deps{
+		Svc: retentionSvc,
+	})
+	trashSvc := trashservice.New(trashservice.Deps{
+		Repo: trashRepo,
+		Org:  orgSvc,
+	})
+	trashH := trashhandler.New(trashhandler.Deps{
+		Svc: trashSvc,
+	})
+	apidocsH := apidocshandler.New(apidocshandler.Deps{
+		Spec: api.Spec,
+	})
+	adminH := adminhandler.New(adminhandler.Deps{
+		IPFilter: d.IPFilter,
+		Recorder: d.Recorder,
+		DB:       d.DB,
+	})
 
 	return &App{
-		Auth:    auth.NewModule(authSvc, authH, d.Bus),
-		User:    user.NewModule(userH, userC, d.Bus),
-		Org:     org.NewModule(orgH, orgC, d.Bus),
-		Project: project.NewModule(projectH, projectC, d.Bus),
-		Sprint:  sprint.NewModule(sprintH, sprintC, d.Bus),
-		Board:   board.NewModule(boardH, boardC, d.Bus),
-		Ticket:  ticket.NewModule(ticketH, ticketC, d.Bus),
+		Auth:         auth.NewModule(authSvc, authH, d.Bus),
+		User:         user.NewModule(userH, userC, d.Bus, authn),
+		Org:          org.NewModule(orgH, orgC, d.Bus, authn),
+		Project:      project.NewModule(projectH, projectC, d.Bus, authn),
+		Sprint:       sprint.NewModule(sprintH, sprintC, d.Bus, authn),
+		Board:        board.NewModule(boardH, boardSvc, boardC, d.Bus, authn),
+		Ticket:       ticket.NewModule(ticketH, ticketSvc, ticketC, d.Bus, authn),
+		Attachment:   attachment.NewModule(attachmentH, authn),
+		Report:       report.NewModule(reportH, reportSvc, authn),
+		Change:       change.NewModule(changeH, changeSvc, d.Bus, authn),
+		Activity:     activity.NewModule(activityH, activitySvc, d.Bus, authn),
+		Usage:        usage.NewModule(usageH, usageSvc, authn),
+		Integration:  integration.NewModule(integrationH, authn),
+		Calendar:     caldav.NewModule(caldavH, authn),
+		Notification: notification.NewModule(notificationH, notificationSvc, d.Bus, authn),
+		Admin:        admin.NewModule(adminH, authn),
+		Integrity:    integrity.NewModule(integrityH, integritySvc, authn),
+		Retention:    retention.NewModule(retentionH, retentionSvc, authn),
+		Trash:        trash.NewModule(trashH, authn),
+		Docs:         apidocs.NewModule(apidocsH, d.Config.Docs, authn),
 	}
 
 }
+
+// newPushSender returns nil while no VAPID key is configured, which leaves
+// push notifications switched off
+func newPushSender(cfg webpush.Config) *webpush.Sender {
+	if !cfg.Enabled() {
+		slog.Info("[Config]: VAPID_PRIVATE_KEY is not set, push notifications are disabled")
+		return nil
+	}
+	sender, err := webpush.New(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Env var %q is not a valid VAPID key: %v", "VAPID_PRIVATE_KEY", err))
+	}
+	return sender
+}
+
+// newBlobStore refuses to start when uploads have nowhere to go, rather than
+// failing every upload later
+func newBlobStore(cfg blob.Config) blob.Store {
+	store, err := blob.New(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Env var STORAGE_* is invalid: %v", err))
+	}
+	slog.Info("[Config]: attachments are stored", "driver", cfg.Driver)
+	return store
+}
+
+// newWebhookSender refuses to start on a malformed allowed net rather than
+// refusing deliveries the operator meant to allow
+func newWebhookSender(cfg *Config) *webhook.Sender {
+	allowed, err := webhook.ParseNets(cfg.ActivityWebhookAllowedNets)
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Env var ACTIVITY_WEBHOOK_ALLOWED_NETS is invalid: %v", err))
+	}
+	return webhook.NewSender(cfg.ActivityWebhookTimeout, allowed...)
+}
+
+// newIPFilter refuses to start on a malformed list, silently dropping an entry
+// could leave a locked-down instance open
+func newIPFilter(cfg ipfilter.Config) *ipfilter.Filter {
+	f, err := ipfilter.New(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Env var IP_ALLOW_LIST or IP_DENY_LIST is invalid: %v", err))
+	}
+	if len(cfg.Allow) > 0 || len(cfg.Deny) > 0 {
+		slog.Info("[Config]: IP filter is enabled", "allow", len(cfg.Allow), "deny", len(cfg.Deny), "paths", cfg.Paths)
+	}
+	return f
+}
+
+// newChaos refuses to start on a malformed rule so a typo does not quietly
+// leave the frontend testing against a healthy backend
+func newChaos(cfg chaos.Config) func(http.Handler) http.Handler {
+	mw, err := chaos.New(cfg)
+	if err != nil {
+		panic(fmt.Sprintf("[Config]: Env var CHAOS_* is invalid: %v", err))
+	}
+	if cfg.Enabled {
+		slog.Warn("[Core]: chaos mode is enabled, requests may be delayed or failed on purpose", "routes", len(cfg.Routes))
+	}
+	return mw
+}
+
+// configurePagination refuses to start when the default page size could
+// never be served under the maximum
+func configurePagination(s pagination.Settings) {
+	if err := pagination.Configure(s); err != nil {
+		panic(fmt.Sprintf("[Config]: Env var PAGE_SIZE_* is invalid: %v", err))
+	}
+}
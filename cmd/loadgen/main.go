@@ -0,0 +1,247 @@
+// Command loadgen seeds a project through the public API and then hammers the
+// paged listing endpoints, printing latency percentiles. It exits non-zero
+// when the p95 of any target exceeds -max-p95 so it can gate a CI job.
+//
+//	go run ./cmd/loadgen -base http://localhost:8080 -tickets 500 -duration 30s
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type options struct {
+	base        string
+	tickets     int
+	concurrency int
+	duration    time.Duration
+	maxP95      time.Duration
+}
+
+type client struct {
+	base  string
+	token string
+	http  *http.Client
+}
+
+type target struct {
+	name string
+	path string
+}
+
+func main() {
+	var opts options
+	flag.StringVar(&opts.base, "base", "http://localhost:8080", "API base URL")
+	flag.IntVar(&opts.tickets, "tickets", 500, "tickets to seed before the run")
+	flag.IntVar(&opts.concurrency, "concurrency", 8, "concurrent workers per target")
+	flag.DurationVar(&opts.duration, "duration", 30*time.Second, "how long each target is exercised")
+	flag.DurationVar(&opts.maxP95, "max-p95", 0, "fail when a target's p95 exceeds this, zero disables the check")
+	flag.Parse()
+
+	if err := run(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "loadgen: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(opts options) error {
+	c := &client{base: strings.TrimRight(opts.base, "/"), http: &http.Client{Timeout: 30 * time.Second}}
+
+	targets, err := seed(c, opts.tickets)
+	if err != nil {
+		return fmt.Errorf("seed: %w", err)
+	}
+
+	failed := false
+	for _, t := range targets {
+		samples, errs := hammer(c, t, opts)
+		slices.Sort(samples)
+
+		p95 := percentile(samples, 95)
+		fmt.Printf("%-22s reqs=%-6d errors=%-4d p50=%-10s p95=%-10s p99=%s\n",
+			t.name, len(samples), errs, percentile(samples, 50), p95, percentile(samples, 99))
+
+		if opts.maxP95 > 0 && p95 > opts.maxP95 {
+			failed = true
+		}
+	}
+
+	if failed {
+		return fmt.Errorf("p95 above %s", opts.maxP95)
+	}
+	return nil
+}
+
+// seed registers a throwaway user and builds org, project, board and tickets
+func seed(c *client, tickets int) ([]target, error) {
+	suffix := randomHex(4)
+
+	var auth domain.AuthModel
+	if err := c.do("POST", "/auth/register", domain.AuthRegisterModel{
+		UserCreateModel: domain.UserCreateModel{
+			Email:       "loadgen_" + suffix + "@example.com",
+			DisplayName: "Load Generator",
+			Password:    "LoadgenPassword123!",
+		},
+	}, &auth); err != nil {
+		return nil, err
+	}
+	c.token = auth.AccessToken
+
+	var org domain.OrganisationModel
+	if err := c.do("POST", "/orgs", domain.OrganisationCreateModel{Name: "Loadgen " + suffix}, &org); err != nil {
+		return nil, err
+	}
+
+	var project domain.ProjectModel
+	if err := c.do("POST", "/projects?orgId="+uuidString(org.ID), domain.ProjectCreateModel{
+		Key:        "LG" + strings.ToUpper(suffix[:4]),
+		Name:       "Loadgen " + suffix,
+		Visibility: "private",
+	}, &project); err != nil {
+		return nil, err
+	}
+	projectID := uuidString(project.ID)
+
+	var sprint domain.SprintModel
+	if err := c.do("POST", "/sprints", domain.SprintCreateModel{ProjectID: project.ID, Name: "Loadgen sprint"}, &sprint); err != nil {
+		return nil, err
+	}
+
+	var board domain.BoardModel
+	if err := c.do("POST", "/boards", domain.BoardCreateModel{SprintID: sprint.ID, Name: "Loadgen board"}, &board); err != nil {
+		return nil, err
+	}
+	boardID := uuidString(board.ID)
+
+	for _, name := range []string{"Todo", "Doing", "Done"} {
+		if err := c.do("POST", "/boards/"+boardID+"/columns", domain.BoardColumnCreateModel{Name: name}, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	for i := range tickets {
+		if err := c.do("POST", "/tickets?projectId="+projectID, domain.TicketCreateModel{
+			Type:     "task",
+			Priority: "medium",
+			Title:    fmt.Sprintf("Loadgen ticket %d", i+1),
+			SprintID: sprint.ID,
+		}, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return []target{
+		{name: "tickets:first-page", path: "/tickets?projectId=" + projectID + "&pageSize=50"},
+		{name: "tickets:deep-page", path: fmt.Sprintf("/tickets?projectId=%s&pageSize=25&pageNumber=%d", projectID, max(tickets/25, 1))},
+		{name: "columns:with-counts", path: "/boards/" + boardID + "/columns?includeCounts=true"},
+		{name: "projects:summary", path: "/projects?orgId=" + uuidString(org.ID) + "&includeSummary=true"},
+	}, nil
+}
+
+// hammer runs opts.concurrency workers against t for opts.duration
+func hammer(c *client, t target, opts options) ([]time.Duration, int) {
+	var (
+		mu      sync.Mutex
+		samples []time.Duration
+		errs    int
+		wg      sync.WaitGroup
+	)
+
+	deadline := time.Now().Add(opts.duration)
+	for range opts.concurrency {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for time.Now().Before(deadline) {
+				start := time.Now()
+				err := c.do("GET", t.path, nil, nil)
+				elapsed := time.Since(start)
+
+				mu.Lock()
+				if err != nil {
+					errs++
+				} else {
+					samples = append(samples, elapsed)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return samples, errs
+}
+
+func (c *client) do(method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequest(method, c.base+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	raw, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s %s: status %d: %s", method, path, resp.StatusCode, raw)
+	}
+	if out == nil {
+		return nil
+	}
+	return json.Unmarshal(raw, out)
+}
+
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted)*p+99)/100 - 1
+	return sorted[max(idx, 0)]
+}
+
+func uuidString(id pgtype.UUID) string {
+	return uuid.UUID(id.Bytes).String()
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,57 @@
+// Command openapi writes the API spec compiled into the binary to disk, as
+// OpenAPI 3.0 by default, so the frontend can generate a client from it. Keys
+// are sorted so the file only changes when the API does, and it fails when an
+// operation has no operationId as generated clients name methods after them.
+//
+//	go run ./cmd/openapi -out api/openapi.json
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/api"
+	"github.com/dimasbaguspm/fluxis/pkg/openapi"
+)
+
+func main() {
+	version := flag.String("version", "3.0", "spec version to write, 2.0 or 3.0")
+	out := flag.String("out", "", "file to write, stdout when empty")
+	flag.Parse()
+
+	if err := run(*version, *out); err != nil {
+		fmt.Fprintf(os.Stderr, "openapi: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(version, out string) error {
+	missing, err := openapi.MissingOperationIDs(api.Spec)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		return fmt.Errorf("operations without an @ID: %s", strings.Join(missing, ", "))
+	}
+
+	var spec []byte
+	switch version {
+	case "2.0":
+		spec, err = openapi.Canonical(api.Spec)
+	case "3.0":
+		spec, err = openapi.Convert(api.Spec)
+	default:
+		return fmt.Errorf("unsupported version %q, want 2.0 or 3.0", version)
+	}
+	if err != nil {
+		return err
+	}
+
+	if out == "" {
+		_, err = os.Stdout.Write(spec)
+		return err
+	}
+	return os.WriteFile(out, spec, 0o644)
+}
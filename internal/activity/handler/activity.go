@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// ListProjectActivity godoc
+//
+//	@Summary		List project activity
+//	@ID				listProjectActivity
+//	@Description	Returns the project's activity log, newest first. Reorders of boards and board columns and bulk ticket deletes are one entry each whose payload lists the affected IDs
+//	@Tags			activity
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			pageNumber	query		int		false	"Page number"
+//	@Param			pageSize	query		int		false	"Page size"
+//	@Success		200			{object}	domain.ActivityPagedModel
+//	@Header			200			{string}	Link	"RFC 8288 links to the first, prev, next and last page"
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/activity [get]
+func (h *Handler) ListProjectActivity(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	activity, err := h.svc.ListProjectActivity(r.Context(), domain.ActivitySearchModel{
+		ProjectID:  id,
+		PageNumber: httpx.QueryNumber(r, "pageNumber"),
+		PageSize:   httpx.QueryNumber(r, "pageSize"),
+	})
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OKPage(w, r, activity, activity.PageNumber, activity.PageSize, activity.TotalPages)
+}
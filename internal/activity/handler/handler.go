@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/activity/service"
+)
+
+type Deps struct {
+	Svc *service.Service
+}
+
+type Handler struct {
+	svc *service.Service
+}
+
+func New(deps Deps) *Handler {
+	return &Handler{
+		svc: deps.Svc,
+	}
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetActivitySettings godoc
+//
+//	@Summary		Get activity settings
+//	@ID				getActivitySettings
+//	@Description	Returns how much of the project's activity is logged. A project that never changed them logs every action
+//	@Tags			activity
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.ActivitySettingsModel
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/activity/settings [get]
+func (h *Handler) GetActivitySettings(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	settings, err := h.svc.GetActivitySettings(r.Context(), projectID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, settings)
+}
+
+// UpdateActivitySettings godoc
+//
+//	@Summary		Update activity settings
+//	@ID				updateActivitySettings
+//	@Description	Sets how much of the project's activity is logged, for projects whose log grows too fast: mode all logs every action, selected only the listed actions and none nothing. Webhooks only receive logged entries. Applies to events from then on, logged entries are kept
+//	@Tags			activity
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Project ID"
+//	@Param			body	body		domain.ActivitySettingsUpdateModel	true	"Activity settings"
+//	@Success		200		{object}	domain.ActivitySettingsModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		403		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/activity/settings [put]
+func (h *Handler) UpdateActivitySettings(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ActivitySettingsUpdateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	settings, err := h.svc.UpdateActivitySettings(r.Context(), projectID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, settings)
+}
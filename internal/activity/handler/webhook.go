@@ -0,0 +1,111 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// ListActivityWebhooks godoc
+//
+//	@Summary		List activity webhooks
+//	@ID				listActivityWebhooks
+//	@Description	Returns the endpoints receiving the project's activity log, without their secrets
+//	@Tags			activity
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{array}		domain.ActivityWebhookModel
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/activity/webhooks [get]
+func (h *Handler) ListActivityWebhooks(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	items, err := h.svc.ListActivityWebhooks(r.Context(), projectID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, items)
+}
+
+// CreateActivityWebhook godoc
+//
+//	@Summary		Create activity webhook
+//	@ID				createActivityWebhook
+//	@Description	Subscribes an endpoint to the project's activity log. Every entry written afterwards whose action is listed, or every entry when no actions are given, is posted to it as an activity entry, signed with the X-Fluxis-Signature header like inbound deliveries. The URL must be http(s) on a public address, unless the operator allowed its network, and redirects are not followed. The response carries the signing secret, it is not shown again.
+//	@Tags			activity
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Project ID"
+//	@Param			body	body		domain.ActivityWebhookCreateModel	true	"Webhook payload"
+//	@Success		201		{object}	domain.ActivityWebhookModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		403		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/activity/webhooks [post]
+func (h *Handler) CreateActivityWebhook(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ActivityWebhookCreateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	hook, err := h.svc.CreateActivityWebhook(r.Context(), projectID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.Created(w, hook)
+}
+
+// DeleteActivityWebhook godoc
+//
+//	@Summary		Delete activity webhook
+//	@ID				deleteActivityWebhook
+//	@Description	Stops posting the project's activity log to an endpoint
+//	@Tags			activity
+//	@Param			id			path	string	true	"Project ID"
+//	@Param			webhookId	path	string	true	"Webhook ID"
+//	@Success		204
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/activity/webhooks/{webhookId} [delete]
+func (h *Handler) DeleteActivityWebhook(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	id, err := httpx.PathUUID(r, "webhookId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if err := h.svc.DeleteActivityWebhook(r.Context(), projectID, id); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,57 @@
+package activity
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity/handler"
+	"github.com/dimasbaguspm/fluxis/internal/activity/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+)
+
+type Module struct {
+	h    *handler.Handler
+	svc  *service.Service
+	bus  pubsub.Bus
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, svc *service.Service, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		svc:  svc,
+		bus:  bus,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /projects/{id}/activity", m.auth.RequireAuth(m.h.ListProjectActivity, domain.ScopeProjectsRead))
+	mux.HandleFunc("GET /projects/{id}/activity/settings", m.auth.RequireAuth(m.h.GetActivitySettings, domain.ScopeProjectsRead))
+	mux.HandleFunc("PUT /projects/{id}/activity/settings", m.auth.RequireAuth(m.h.UpdateActivitySettings, domain.ScopeProjectsWrite))
+	mux.HandleFunc("GET /projects/{id}/activity/webhooks", m.auth.RequireAuth(m.h.ListActivityWebhooks, domain.ScopeProjectsRead))
+	mux.HandleFunc("POST /projects/{id}/activity/webhooks", m.auth.RequireAuth(m.h.CreateActivityWebhook, domain.ScopeProjectsWrite))
+	mux.HandleFunc("DELETE /projects/{id}/activity/webhooks/{webhookId}", m.auth.RequireAuth(m.h.DeleteActivityWebhook, domain.ScopeProjectsWrite))
+}
+
+func (m *Module) StartSubscriber(ctx context.Context) {
+	slog.Info("[ActivityModule]: starting bus subscriber")
+	// only actions writing many rows at once are logged here, along with the
+	// stale sweep and project moves; their events carry a
+	// domain.BulkEventModel. RecordBulk drops what the project's activity
+	// settings leave out.
+	handler := func(ctx context.Context, e pubsub.Event) error {
+		switch e.Type {
+		case pubsub.BoardReordered, pubsub.BoardColumnReordered, pubsub.TicketsBulkDeleted, pubsub.TicketsStale, pubsub.TicketMovedToProject:
+			return m.svc.RecordBulk(ctx, e)
+		}
+		return nil
+	}
+
+	// Subscribe blocks until ctx is done
+	go m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Board), handler)
+	m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Ticket), handler)
+}
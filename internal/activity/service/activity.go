@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+)
+
+// RecordBulk writes a bulk event to its project's activity log, keeping the
+// event's payload with its ID list as the entry's payload, and hands the
+// entry to the project's webhooks. Events the project's settings leave out
+// of the log are dropped.
+func (s *Service) RecordBulk(ctx context.Context, e pubsub.Event) error {
+	var bulk domain.BulkEventModel
+	if err := httpx.DecodePayload(e.Payload, &bulk); err != nil {
+		return fmt.Errorf("decode %s payload: %w", e.Type, err)
+	}
+	if !bulk.ProjectID.Valid {
+		return fmt.Errorf("%s payload has no project", e.Type)
+	}
+	if !s.logs(ctx, bulk.ProjectID, string(e.Type)) {
+		return nil
+	}
+
+	row, err := s.write(ctx, repository.CreateActivityParams{
+		ProjectID: bulk.ProjectID,
+		ActorID:   bulk.ActorID,
+		Action:    string(e.Type),
+		Payload:   []byte(e.Payload["data"]),
+	})
+	if err != nil || row.ID == 0 {
+		return err
+	}
+
+	s.deliver(ctx, toActivityModel(row))
+	return nil
+}
+
+func (s *Service) ListProjectActivity(ctx context.Context, q domain.ActivitySearchModel) (domain.ActivityPagedModel, error) {
+	q.ApplyDefaults()
+
+	if _, err := s.Project.GetProjectById(ctx, q.ProjectID); err != nil {
+		return domain.ActivityPagedModel{}, err
+	}
+
+	rows, err := s.Repo.ListProjectActivity(ctx, repository.ListProjectActivityParams{
+		ProjectID: q.ProjectID,
+		Limit:     int32(q.PageSize),
+		Offset:    pagination.Offset(q.PageNumber, q.PageSize),
+	})
+	if err != nil {
+		return domain.ActivityPagedModel{}, fmt.Errorf("list project activity: %w", err)
+	}
+
+	page := pagination.FromRows(rows,
+		func(row repository.ListProjectActivityRow) int64 { return row.TotalCount },
+		func(row repository.ListProjectActivityRow) domain.ActivityModel {
+			return toActivityModel(repository.ActivityLog{
+				ID:        row.ID,
+				ProjectID: row.ProjectID,
+				ActorID:   row.ActorID,
+				Action:    row.Action,
+				Payload:   row.Payload,
+				CreatedAt: row.CreatedAt,
+			})
+		},
+		q.PageNumber, q.PageSize)
+
+	return domain.ActivityPagedModel(page), nil
+}
+
+func toActivityModel(row repository.ActivityLog) domain.ActivityModel {
+	return domain.ActivityModel{
+		ID:        row.ID,
+		ProjectID: row.ProjectID,
+		ActorID:   row.ActorID,
+		Action:    row.Action,
+		Payload:   row.Payload,
+		CreatedAt: row.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
+)
+
+var webhookDeliveries = metrics.Default.NewCounter(metrics.Desc{
+	Name:   "fluxis_activity_webhook_deliveries_total",
+	Help:   "Activity log entries posted to project webhooks, by outcome: delivered or failed.",
+	Labels: []string{"outcome"},
+})
+
+// deliver posts a written entry to every webhook of its project subscribed
+// to its action. Each endpoint is tried once in the background so a slow
+// collector does not hold up the log; one that missed entries can catch up
+// through the activity list, whose IDs only grow.
+func (s *Service) deliver(ctx context.Context, entry domain.ActivityModel) {
+	if s.Hooks == nil {
+		return
+	}
+
+	hooks, err := s.Repo.ListActivityWebhooksForAction(ctx, repository.ListActivityWebhooksForActionParams{
+		ProjectID: entry.ProjectID,
+		Column2:   entry.Action,
+	})
+	if err != nil {
+		slog.Warn("[ActivityService]: failed to list activity webhooks", "action", entry.Action, "error", err)
+		return
+	}
+	if len(hooks) == 0 {
+		return
+	}
+
+	body, err := json.Marshal(entry)
+	if err != nil {
+		slog.Warn("[ActivityService]: failed to encode activity entry", "action", entry.Action, "error", err)
+		return
+	}
+
+	ctx = context.WithoutCancel(ctx)
+	for _, hook := range hooks {
+		go func() {
+			if err := s.Hooks.Send(ctx, hook.Url, hook.Secret, body); err != nil {
+				webhookDeliveries.With("failed").Inc()
+				slog.Warn("[ActivityService]: failed to deliver activity webhook", "webhook", hook.ID, "action", entry.Action, "error", err)
+				return
+			}
+			webhookDeliveries.With("delivered").Inc()
+			if err := s.Repo.TouchActivityWebhook(ctx, hook.ID); err != nil {
+				slog.Warn("[ActivityService]: failed to touch activity webhook", "webhook", hook.ID, "error", err)
+			}
+		}()
+	}
+}
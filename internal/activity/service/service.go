@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+)
+
+type Deps struct {
+	Repo    *repository.Queries
+	Project domain.ProjectReader
+	Hooks   *webhook.Sender
+}
+
+type Service struct {
+	Deps
+}
+
+var _ domain.ActivityReader = (*Service)(nil)
+var _ domain.ActivityWriter = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{d}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrActivityActionsRequired = domain.Invalid("actions are required when mode is selected").WithCode("activity_actions_required")
+
+// GetActivitySettings returns the project's settings, the defaults of logging
+// every action when they were never changed
+func (s *Service) GetActivitySettings(ctx context.Context, projectID pgtype.UUID) (domain.ActivitySettingsModel, error) {
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.ActivitySettingsModel{}, err
+	}
+
+	row, err := s.Repo.GetActivitySettings(ctx, projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ActivitySettingsModel{
+			ProjectID: projectID,
+			Mode:      domain.ActivityModeAll,
+			Actions:   []string{},
+		}, nil
+	}
+	if err != nil {
+		return domain.ActivitySettingsModel{}, fmt.Errorf("get activity settings: %w", err)
+	}
+	return toSettingsModel(row), nil
+}
+
+// UpdateActivitySettings replaces the project's settings. They apply to events
+// handled from then on, entries already logged are kept. Only project admins
+// can change them.
+func (s *Service) UpdateActivitySettings(ctx context.Context, projectID pgtype.UUID, p domain.ActivitySettingsUpdateModel) (domain.ActivitySettingsModel, error) {
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return domain.ActivitySettingsModel{}, err
+	}
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.ActivitySettingsModel{}, err
+	}
+
+	actions := []string{}
+	if p.Mode == domain.ActivityModeSelected {
+		if len(p.Actions) == 0 {
+			return domain.ActivitySettingsModel{}, ErrActivityActionsRequired
+		}
+		actions = slices.Compact(slices.Sorted(slices.Values(p.Actions)))
+	}
+
+	row, err := s.Repo.UpsertActivitySettings(ctx, repository.UpsertActivitySettingsParams{
+		ProjectID: projectID,
+		Mode:      p.Mode,
+		Actions:   actions,
+		UpdatedBy: httpx.MustUserID(ctx),
+	})
+	if err != nil {
+		return domain.ActivitySettingsModel{}, fmt.Errorf("update activity settings: %w", err)
+	}
+	return toSettingsModel(row), nil
+}
+
+// logs reports whether the project's settings let an action into its log.
+// When they can not be read the entry is logged, an audit trail is better
+// too long than missing entries.
+func (s *Service) logs(ctx context.Context, projectID pgtype.UUID, action string) bool {
+	row, err := s.Repo.GetActivitySettings(ctx, projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return true
+	}
+	if err != nil {
+		slog.Warn("[ActivityService]: read activity settings, logging the entry", "action", action, "error", err)
+		return true
+	}
+
+	switch row.Mode {
+	case domain.ActivityModeNone:
+		return false
+	case domain.ActivityModeSelected:
+		return slices.Contains(row.Actions, action)
+	}
+	return true
+}
+
+func toSettingsModel(row repository.ActivitySetting) domain.ActivitySettingsModel {
+	m := domain.ActivitySettingsModel{
+		ProjectID: row.ProjectID,
+		Mode:      row.Mode,
+		Actions:   row.Actions,
+		UpdatedBy: row.UpdatedBy,
+	}
+	if row.UpdatedAt.Valid {
+		m.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return m
+}
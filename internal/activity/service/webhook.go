@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrActivityWebhookNotFound = domain.NotFound("activity webhook not found")
+	ErrWebhookURLNotAllowed    = domain.Invalid("url must be an http(s) endpoint on a public address").WithCode("webhook_url_not_allowed")
+)
+
+// ListActivityWebhooks, like the other webhook calls, is for project admins
+func (s *Service) ListActivityWebhooks(ctx context.Context, projectID pgtype.UUID) ([]domain.ActivityWebhookModel, error) {
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return nil, err
+	}
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Repo.ListActivityWebhooks(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list activity webhooks: %w", err)
+	}
+
+	items := make([]domain.ActivityWebhookModel, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toWebhookModel(row))
+	}
+	return items, nil
+}
+
+// CreateActivityWebhook generates the signing secret and returns it; it is
+// not readable through the API afterwards. URLs the sender would refuse are
+// rejected up front.
+func (s *Service) CreateActivityWebhook(ctx context.Context, projectID pgtype.UUID, p domain.ActivityWebhookCreateModel) (domain.ActivityWebhookModel, error) {
+	userID := httpx.MustUserID(ctx)
+
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return domain.ActivityWebhookModel{}, err
+	}
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.ActivityWebhookModel{}, err
+	}
+	if s.Hooks != nil {
+		if err := s.Hooks.CheckEndpoint(p.URL); err != nil {
+			return domain.ActivityWebhookModel{}, ErrWebhookURLNotAllowed
+		}
+	}
+
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return domain.ActivityWebhookModel{}, fmt.Errorf("generate secret: %w", err)
+	}
+
+	actions := p.Actions
+	if actions == nil {
+		actions = []string{}
+	}
+	row, err := s.Repo.CreateActivityWebhook(ctx, repository.CreateActivityWebhookParams{
+		ID:        idgen.New(),
+		ProjectID: projectID,
+		Url:       p.URL,
+		Secret:    secret,
+		Actions:   actions,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		return domain.ActivityWebhookModel{}, fmt.Errorf("create activity webhook: %w", err)
+	}
+
+	result := toWebhookModel(row)
+	result.Secret = row.Secret
+	return result, nil
+}
+
+func (s *Service) DeleteActivityWebhook(ctx context.Context, projectID, id pgtype.UUID) error {
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return err
+	}
+
+	n, err := s.Repo.DeleteActivityWebhook(ctx, repository.DeleteActivityWebhookParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete activity webhook: %w", err)
+	}
+	if n == 0 {
+		return ErrActivityWebhookNotFound
+	}
+	return nil
+}
+
+func toWebhookModel(row repository.ActivityWebhook) domain.ActivityWebhookModel {
+	m := domain.ActivityWebhookModel{
+		ID:        row.ID,
+		ProjectID: row.ProjectID,
+		URL:       row.Url,
+		Actions:   row.Actions,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.LastDeliveryAt.Valid {
+		m.LastDeliveryAt = &row.LastDeliveryAt.Time
+	}
+	return m
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+const (
+	// writeAttempts bounds how often an entry is tried before it goes to the
+	// dead-letter table, waiting writeBackoff and then twice as long each time
+	writeAttempts = 4
+	writeBackoff  = 100 * time.Millisecond
+
+	// deadLetterTimeout bounds moving an entry to the dead-letter table, which
+	// runs on after the caller's context ended
+	deadLetterTimeout = 5 * time.Second
+)
+
+var activityDropped = metrics.Default.NewCounter(metrics.Desc{
+	Name:   "fluxis_activity_dropped_total",
+	Help:   "Activity entries that could not be written to the activity log after retrying, by outcome: dead_lettered entries were kept in activity_dead_letters, lost ones could not be stored at all.",
+	Labels: []string{"outcome"},
+	Rules: []metrics.Rule{
+		{
+			Alert:   "FluxisActivityLost",
+			Expr:    `sum(rate(fluxis_activity_dropped_total{outcome="lost"}[5m])) > 0`,
+			For:     "5m",
+			Summary: "Activity log entries are being lost",
+		},
+	},
+})
+
+// entryStore is the part of the repository write uses
+type entryStore interface {
+	CreateActivity(ctx context.Context, arg repository.CreateActivityParams) (repository.ActivityLog, error)
+	CreateActivityDeadLetter(ctx context.Context, arg repository.CreateActivityDeadLetterParams) error
+}
+
+func (s *Service) write(ctx context.Context, entry repository.CreateActivityParams) (repository.ActivityLog, error) {
+	return writeEntry(ctx, s.Repo, entry)
+}
+
+// writeEntry stores an entry, retrying with backoff while the database
+// hiccups. An entry that still fails is moved to the dead-letter table so the
+// trail can be completed later, even when ctx ended meanwhile; only when that
+// fails too is it lost. The stored row is returned, its ID is zero when the
+// entry was dead-lettered.
+func writeEntry(ctx context.Context, store entryStore, entry repository.CreateActivityParams) (repository.ActivityLog, error) {
+	var row repository.ActivityLog
+	attempts, err := retry(ctx, func() (err error) {
+		row, err = store.CreateActivity(ctx, entry)
+		return err
+	})
+	if err == nil {
+		return row, nil
+	}
+
+	dlCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), deadLetterTimeout)
+	defer cancel()
+
+	_, dlErr := retry(dlCtx, func() error {
+		return store.CreateActivityDeadLetter(dlCtx, repository.CreateActivityDeadLetterParams{
+			ProjectID: entry.ProjectID,
+			ActorID:   entry.ActorID,
+			Action:    entry.Action,
+			Payload:   entry.Payload,
+			Error:     err.Error(),
+			Attempts:  int32(attempts),
+		})
+	})
+	if dlErr != nil {
+		activityDropped.With("lost").Inc()
+		return repository.ActivityLog{}, fmt.Errorf("create activity: %w, dead letter: %w", err, dlErr)
+	}
+
+	activityDropped.With("dead_lettered").Inc()
+	slog.Warn("[ActivityService]: moved activity entry to dead letters", "action", entry.Action, "attempts", attempts, "error", err)
+	return repository.ActivityLog{}, nil
+}
+
+// retry runs fn until it succeeds, fails permanently or runs out of
+// attempts, and reports how many attempts it made
+func retry(ctx context.Context, fn func() error) (int, error) {
+	wait := writeBackoff
+	for attempt := 1; ; attempt++ {
+		err := fn()
+		if err == nil || attempt == writeAttempts || permanent(err) {
+			return attempt, err
+		}
+
+		select {
+		case <-ctx.Done():
+			return attempt, errors.Join(err, ctx.Err())
+		case <-time.After(wait):
+		}
+		wait *= 2
+	}
+}
+
+// permanent reports errors that writing the same row again can not fix, such
+// as a constraint the entry violates
+func permanent(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	// classes 22 and 23 are data exceptions and integrity violations
+	return strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")
+}
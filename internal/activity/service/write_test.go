@@ -0,0 +1,210 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/internal/activity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var (
+	errTransient = &pgconn.PgError{Code: "40001", Message: "could not serialize access"}
+	errBadJSON   = &pgconn.PgError{Code: "22P02", Message: "invalid input syntax for type json"}
+)
+
+// stubStore fails CreateActivity with the queued errors, then succeeds, and
+// records the dead letters it is given
+type stubStore struct {
+	activityErrs  []error
+	deadLetterErr error
+
+	activityCalls int
+	deadLetters   []repository.CreateActivityDeadLetterParams
+	// the state of the context the last dead letter was written under
+	deadLetterCtxErr  error
+	deadLetterTimeout bool
+}
+
+func (s *stubStore) CreateActivity(ctx context.Context, arg repository.CreateActivityParams) (repository.ActivityLog, error) {
+	s.activityCalls++
+	if len(s.activityErrs) > 0 {
+		err := s.activityErrs[0]
+		s.activityErrs = s.activityErrs[1:]
+		return repository.ActivityLog{}, err
+	}
+	return repository.ActivityLog{ID: 42, Action: arg.Action, Payload: arg.Payload}, nil
+}
+
+func (s *stubStore) CreateActivityDeadLetter(ctx context.Context, arg repository.CreateActivityDeadLetterParams) error {
+	s.deadLetterCtxErr = ctx.Err()
+	_, s.deadLetterTimeout = ctx.Deadline()
+	if s.deadLetterErr != nil {
+		return s.deadLetterErr
+	}
+	s.deadLetters = append(s.deadLetters, arg)
+	return nil
+}
+
+// dropped reads fluxis_activity_dropped_total for an outcome off the metrics
+// endpoint
+func dropped(t *testing.T, outcome string) float64 {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	metrics.Handler(metrics.Default).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	prefix := fmt.Sprintf(`fluxis_activity_dropped_total{outcome=%q} `, outcome)
+	sc := bufio.NewScanner(rec.Body)
+	for sc.Scan() {
+		if v, ok := strings.CutPrefix(sc.Text(), prefix); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				t.Fatalf("parse %q: %v", sc.Text(), err)
+			}
+			return f
+		}
+	}
+	return 0
+}
+
+func TestRetry(t *testing.T) {
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		errs         []error
+		wantAttempts int
+		wantErr      error
+	}{
+		{"first try", context.Background(), nil, 1, nil},
+		{"after hiccups", context.Background(), []error{errTransient, errTransient}, 3, nil},
+		{"out of attempts", context.Background(), []error{errTransient, errTransient, errTransient, errTransient, errTransient}, writeAttempts, errTransient},
+		{"permanent", context.Background(), []error{errBadJSON, errTransient}, 1, errBadJSON},
+		{"context ended", canceled, []error{errTransient, errTransient}, 1, context.Canceled},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			errs := tt.errs
+			attempts, err := retry(tt.ctx, func() error {
+				if len(errs) == 0 {
+					return nil
+				}
+				err := errs[0]
+				errs = errs[1:]
+				return err
+			})
+			if attempts != tt.wantAttempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+			if tt.wantErr == nil && err != nil || tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestPermanent(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"invalid json", errBadJSON, true},
+		{"value too long", &pgconn.PgError{Code: "22001"}, true},
+		{"foreign key", &pgconn.PgError{Code: "23503"}, true},
+		{"wrapped", fmt.Errorf("create activity: %w", &pgconn.PgError{Code: "23505"}), true},
+		{"serialization failure", errTransient, false},
+		{"connection failure", &pgconn.PgError{Code: "08006"}, false},
+		{"not from postgres", errors.New("dial tcp: connection refused"), false},
+		{"deadline", context.DeadlineExceeded, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := permanent(tt.err); got != tt.want {
+				t.Fatalf("permanent(%v) = %v, want %v", tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWriteEntry_Stored(t *testing.T) {
+	store := &stubStore{activityErrs: []error{errTransient}}
+
+	row, err := writeEntry(context.Background(), store, repository.CreateActivityParams{Action: "ticket.created"})
+	if err != nil || row.ID != 42 {
+		t.Fatalf("writeEntry = %+v, %v; want the stored row", row, err)
+	}
+	if store.activityCalls != 2 || len(store.deadLetters) != 0 {
+		t.Fatalf("got %d writes and %d dead letters, want 2 and 0", store.activityCalls, len(store.deadLetters))
+	}
+}
+
+func TestWriteEntry_PermanentFailureKeepsRawPayload(t *testing.T) {
+	before := dropped(t, "dead_lettered")
+	store := &stubStore{activityErrs: []error{errBadJSON}}
+	payload := []byte(`{"title": "unterminated`)
+
+	row, err := writeEntry(context.Background(), store, repository.CreateActivityParams{Action: "ticket.created", Payload: payload})
+	if err != nil || row.ID != 0 {
+		t.Fatalf("writeEntry = %+v, %v; want a zero row and no error", row, err)
+	}
+	if store.activityCalls != 1 {
+		t.Fatalf("got %d writes, a permanent failure is not retried", store.activityCalls)
+	}
+	if len(store.deadLetters) != 1 {
+		t.Fatalf("got %d dead letters, want 1", len(store.deadLetters))
+	}
+	dl := store.deadLetters[0]
+	if string(dl.Payload) != string(payload) || dl.Attempts != 1 || !strings.Contains(dl.Error, "22P02") {
+		t.Fatalf("dead letter = %+v, want the raw payload, 1 attempt and the error", dl)
+	}
+	if got := dropped(t, "dead_lettered") - before; got != 1 {
+		t.Fatalf("dead_lettered grew by %v, want 1", got)
+	}
+}
+
+func TestWriteEntry_DeadLettersAfterContextEnded(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	store := &stubStore{activityErrs: []error{errTransient}}
+
+	if _, err := writeEntry(ctx, store, repository.CreateActivityParams{Action: "ticket.updated"}); err != nil {
+		t.Fatalf("writeEntry = %v, want the entry dead-lettered", err)
+	}
+	if len(store.deadLetters) != 1 {
+		t.Fatalf("got %d dead letters, want 1", len(store.deadLetters))
+	}
+	if store.deadLetterCtxErr != nil {
+		t.Fatalf("dead letter written under an ended context: %v", store.deadLetterCtxErr)
+	}
+	if !store.deadLetterTimeout {
+		t.Fatal("dead letter written without a timeout")
+	}
+}
+
+func TestWriteEntry_Lost(t *testing.T) {
+	before := dropped(t, "lost")
+	store := &stubStore{
+		activityErrs:  []error{errBadJSON},
+		deadLetterErr: &pgconn.PgError{Code: "23502"},
+	}
+
+	_, err := writeEntry(context.Background(), store, repository.CreateActivityParams{Action: "ticket.deleted"})
+	if !errors.Is(err, errBadJSON) || !errors.Is(err, store.deadLetterErr) {
+		t.Fatalf("writeEntry = %v, want both errors", err)
+	}
+	if got := dropped(t, "lost") - before; got != 1 {
+		t.Fatalf("lost grew by %v, want 1", got)
+	}
+}
@@ -0,0 +1,90 @@
+-- name: CreateActivity :one
+INSERT INTO activity_log (project_id, actor_id, action, payload)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: CreateActivityDeadLetter :exec
+INSERT INTO activity_dead_letters (project_id, actor_id, action, payload, error, attempts)
+VALUES ($1, $2, $3, $4, $5, $6);
+
+-- name: CreateActivityWebhook :one
+INSERT INTO
+    activity_webhooks (project_id, url, secret, actions, created_by, id)
+VALUES
+    ($1, $2, $3, $4, $5, $6)
+RETURNING
+    *;
+
+-- name: DeleteActivityWebhook :execrows
+DELETE FROM activity_webhooks
+WHERE
+    id = $1
+    AND project_id = $2;
+
+-- name: GetActivitySettings :one
+SELECT
+    *
+FROM
+    activity_settings
+WHERE
+    project_id = $1;
+
+-- name: ListActivityWebhooks :many
+SELECT
+    *
+FROM
+    activity_webhooks
+WHERE
+    project_id = $1
+ORDER BY
+    created_at DESC;
+
+-- name: ListActivityWebhooksForAction :many
+-- An empty actions list subscribes to every action
+SELECT
+    *
+FROM
+    activity_webhooks
+WHERE
+    project_id = $1
+    AND (cardinality(actions) = 0 OR $2::text = ANY(actions));
+
+-- name: ListProjectActivity :many
+-- Newest first; id breaks ties between entries written in the same instant
+WITH filtered_activity AS (
+  SELECT
+    id, project_id, actor_id, action, payload, created_at,
+    COUNT(*) OVER () as total_count
+  FROM
+    activity_log
+  WHERE
+    project_id = $1
+)
+SELECT
+    id, project_id, actor_id, action, payload, created_at, total_count
+FROM
+    filtered_activity
+ORDER BY
+    id DESC
+LIMIT $2
+OFFSET $3;
+
+-- name: TouchActivityWebhook :exec
+UPDATE activity_webhooks
+SET
+    last_delivery_at = NOW()
+WHERE
+    id = $1;
+
+-- name: UpsertActivitySettings :one
+INSERT INTO
+    activity_settings (project_id, mode, actions, updated_by)
+VALUES
+    ($1, $2, $3, $4)
+ON CONFLICT (project_id) DO UPDATE
+SET
+    mode = EXCLUDED.mode,
+    actions = EXCLUDED.actions,
+    updated_by = EXCLUDED.updated_by
+RETURNING
+    *;
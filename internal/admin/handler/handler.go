@@ -0,0 +1,27 @@
+package handler
+
+import (
+	"github.com/dimasbaguspm/fluxis/pkg/ipfilter"
+	"github.com/dimasbaguspm/fluxis/pkg/recorder"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type Deps struct {
+	IPFilter *ipfilter.Filter
+	Recorder *recorder.Recorder
+	DB       *pgxpool.Pool
+}
+
+type Handler struct {
+	ipFilter *ipfilter.Filter
+	recorder *recorder.Recorder
+	db       *pgxpool.Pool
+}
+
+func New(deps Deps) *Handler {
+	return &Handler{
+		ipFilter: deps.IPFilter,
+		recorder: deps.Recorder,
+		db:       deps.DB,
+	}
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/ipfilter"
+)
+
+// GetIPRules godoc
+//
+//	@Summary		Get IP rules
+//	@ID				getIPRules
+//	@Description	Returns the allow and deny lists the IP filter currently enforces
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	domain.IPRulesModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/ip-rules [get]
+func (h *Handler) GetIPRules(w http.ResponseWriter, r *http.Request) {
+	httpx.OK(w, toIPRulesModel(h.ipFilter.Rules()))
+}
+
+// SetIPRules godoc
+//
+//	@Summary		Replace IP rules
+//	@ID				setIPRules
+//	@Description	Replaces the allow and deny lists without a restart. The change is kept in memory only, the IP_ALLOW_LIST and IP_DENY_LIST env vars apply again after a restart. Rules that would block the caller's own address are refused.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.IPRulesModel	true	"Allow and deny lists"
+//	@Success		200		{object}	domain.IPRulesModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		403		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/ip-rules [put]
+func (h *Handler) SetIPRules(w http.ResponseWriter, r *http.Request) {
+	var req domain.IPRulesModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	rs, err := ipfilter.Parse(ipfilter.Rules{Allow: req.Allow, Deny: req.Deny})
+	if err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()).WithCode("invalid_ip_rule"))
+		return
+	}
+
+	// the admin would have to edit the env and restart to get back in
+	if h.ipFilter.Applies(r.URL.Path) {
+		if ip, ok := h.ipFilter.ClientIP(r); ok && !rs.Permits(ip) {
+			httpx.Handle(w, httpx.Unprocessable("these rules would block your own address "+ip.String()).WithCode("ip_rules_lockout"))
+			return
+		}
+	}
+
+	h.ipFilter.SetRules(rs)
+	slog.Warn("[AdminModule]: IP rules replaced at runtime",
+		"user", httpx.MustUserID(r.Context()),
+		"allow", rs.Rules().Allow,
+		"deny", rs.Rules().Deny,
+	)
+	httpx.OK(w, toIPRulesModel(rs))
+}
+
+func toIPRulesModel(rs *ipfilter.RuleSet) domain.IPRulesModel {
+	rules := rs.Rules()
+	m := domain.IPRulesModel{Allow: rules.Allow, Deny: rules.Deny}
+	// render empty lists as [] rather than null
+	if m.Allow == nil {
+		m.Allow = []string{}
+	}
+	if m.Deny == nil {
+		m.Deny = []string{}
+	}
+	return m
+}
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/postgres"
+)
+
+// GetMigrationStatus godoc
+//
+//	@Summary		Get migration status
+//	@ID				getMigrationStatus
+//	@Description	Returns, for the expand and the contract migrations, the schema version recorded in the database, whether the last migration failed halfway (dirty), and the migrations compiled into this binary that the database has not applied yet
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	domain.MigrationStatusModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/migrations [get]
+func (h *Handler) GetMigrationStatus(w http.ResponseWriter, r *http.Request) {
+	m := domain.MigrationStatusModel{Phases: make([]domain.MigrationPhaseModel, 0, len(postgres.MigrationPhases))}
+	for _, phase := range postgres.MigrationPhases {
+		state, err := postgres.ReadMigrationState(r.Context(), h.db, phase)
+		if err != nil {
+			httpx.Handle(w, err)
+			return
+		}
+
+		p := domain.MigrationPhaseModel{
+			Phase:   string(state.Phase),
+			Version: state.Version,
+			Dirty:   state.Dirty,
+			Latest:  state.Latest,
+			Pending: make([]domain.MigrationModel, 0, len(state.Pending)),
+		}
+		for _, pending := range state.Pending {
+			p.Pending = append(p.Pending, domain.MigrationModel{Version: pending.Version, Name: pending.Name})
+		}
+		m.Phases = append(m.Phases, p)
+	}
+	httpx.OK(w, m)
+}
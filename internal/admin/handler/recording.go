@@ -0,0 +1,114 @@
+package handler
+
+import (
+	"errors"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/recorder"
+)
+
+// GetRecording godoc
+//
+//	@Summary		Get recorded requests
+//	@ID				getRecording
+//	@Description	Returns whether request recording is running and the sanitized requests and responses kept so far, oldest first
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	domain.RecordingModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/recording [get]
+func (h *Handler) GetRecording(w http.ResponseWriter, r *http.Request) {
+	httpx.OK(w, h.toRecordingModel(h.recorder.Exchanges()))
+}
+
+// StartRecording godoc
+//
+//	@Summary		Start recording requests
+//	@ID				startRecording
+//	@Description	Records sanitized requests and responses for the given number of seconds, replacing any window already running. Exchanges kept earlier stay in the buffer. Needs REQUEST_RECORDING_ENABLED on the server.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.RecordingStartModel	true	"Window length"
+//	@Success		200		{object}	domain.RecordingModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		403		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/recording [put]
+func (h *Handler) StartRecording(w http.ResponseWriter, r *http.Request) {
+	var req domain.RecordingStartModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	until, err := h.recorder.Start(time.Duration(req.DurationSeconds) * time.Second)
+	switch {
+	case errors.Is(err, recorder.ErrDisabled):
+		httpx.Handle(w, httpx.Unprocessable("request recording is disabled on this server").WithCode("recording_disabled"))
+		return
+	case err != nil:
+		httpx.Handle(w, httpx.BadRequest(err.Error()).WithCode("invalid_recording_window"))
+		return
+	}
+
+	slog.Warn("[AdminModule]: request recording started",
+		"user", httpx.MustUserID(r.Context()),
+		"until", until,
+	)
+	httpx.OK(w, h.toRecordingModel(h.recorder.Exchanges()))
+}
+
+// StopRecording godoc
+//
+//	@Summary		Stop recording requests
+//	@ID				stopRecording
+//	@Description	Closes the running window, if any, and drops every recorded exchange
+//	@Tags			admin
+//	@Success		204
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/recording [delete]
+func (h *Handler) StopRecording(w http.ResponseWriter, r *http.Request) {
+	h.recorder.Stop()
+	h.recorder.Clear()
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) toRecordingModel(exchanges []recorder.Exchange) domain.RecordingModel {
+	m := domain.RecordingModel{
+		Enabled:   h.recorder.Enabled(),
+		Capacity:  h.recorder.Capacity(),
+		Exchanges: make([]domain.RecordedExchangeModel, 0, len(exchanges)),
+	}
+	if until, ok := h.recorder.Until(); ok {
+		m.Active = true
+		m.Until = &until
+	}
+	for _, ex := range exchanges {
+		m.Exchanges = append(m.Exchanges, domain.RecordedExchangeModel{
+			ID:              ex.ID,
+			Time:            ex.Time,
+			DurationMs:      float64(ex.Duration.Microseconds()) / 1000,
+			Method:          ex.Method,
+			Path:            ex.Path,
+			Query:           ex.Query,
+			Status:          ex.Status,
+			RequestHeaders:  ex.RequestHeader,
+			RequestBody:     ex.RequestBody,
+			ResponseHeaders: ex.ResponseHeader,
+			ResponseBody:    ex.ResponseBody,
+			Truncated:       ex.Truncated,
+		})
+	}
+	return m
+}
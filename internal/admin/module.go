@@ -0,0 +1,30 @@
+package admin
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/admin/handler"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Module struct {
+	h    *handler.Handler
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /admin/ip-rules", m.auth.RequireAuth(m.h.GetIPRules, domain.ScopeAdmin))
+	mux.HandleFunc("PUT /admin/ip-rules", m.auth.RequireAuth(m.h.SetIPRules, domain.ScopeAdmin))
+	mux.HandleFunc("GET /admin/recording", m.auth.RequireAuth(m.h.GetRecording, domain.ScopeAdmin))
+	mux.HandleFunc("PUT /admin/recording", m.auth.RequireAuth(m.h.StartRecording, domain.ScopeAdmin))
+	mux.HandleFunc("DELETE /admin/recording", m.auth.RequireAuth(m.h.StopRecording, domain.ScopeAdmin))
+	mux.HandleFunc("GET /admin/migrations", m.auth.RequireAuth(m.h.GetMigrationStatus, domain.ScopeAdmin))
+}
@@ -0,0 +1,85 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/openapi"
+	httpSwagger "github.com/swaggo/http-swagger/v2"
+)
+
+type Deps struct {
+	// Spec is the OpenAPI document the UI renders
+	Spec []byte
+}
+
+type Handler struct {
+	spec []byte
+	// spec3 is the spec converted to OpenAPI 3.0, nil when that failed
+	spec3 []byte
+	ops   map[string]operation
+	ui    http.HandlerFunc
+}
+
+func New(deps Deps) *Handler {
+	spec3, err := openapi.Convert(deps.Spec)
+	if err != nil {
+		slog.Warn("[APIDocsModule]: convert spec to OpenAPI 3.0", "error", err)
+	}
+	return &Handler{
+		spec:  deps.Spec,
+		spec3: spec3,
+		ops:   parseOperations(deps.Spec),
+		ui:    httpSwagger.Handler(httpSwagger.URL("/docs/doc.json")),
+	}
+}
+
+// Spec serves the OpenAPI document
+func (h *Handler) Spec(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(h.spec)
+}
+
+// UI serves the interactive docs page and the assets it loads, all bundled
+// into the binary
+func (h *Handler) UI(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/docs" || r.URL.Path == "/docs/" {
+		http.Redirect(w, r, "/docs/index.html", http.StatusFound)
+		return
+	}
+	h.ui(w, r)
+}
+
+// OpenAPI godoc
+//
+//	@Summary		Export OpenAPI spec
+//	@ID				exportOpenAPI
+//	@Description	Serves the API spec for client generation, as OpenAPI 3.0 by default or the Swagger 2.0 original with version=2.0. Every operation has a stable operationId named after its handler
+//	@Tags			docs
+//	@Produce		json
+//	@Param			version	query		string	false	"Spec version"	Enums(2.0, 3.0)
+//	@Success		200		{object}	object
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		403		{object}	httpx.ErrBlock
+//	@Router			/openapi.json [get]
+func (h *Handler) OpenAPI(w http.ResponseWriter, r *http.Request) {
+	var spec []byte
+	switch v := r.URL.Query().Get("version"); v {
+	case "", "3.0":
+		spec = h.spec3
+	case "2.0":
+		spec = h.spec
+	default:
+		httpx.Handle(w, httpx.BadRequest("unsupported version "+v+", use 2.0 or 3.0").WithCode("unsupported_version"))
+		return
+	}
+	if spec == nil {
+		httpx.Handle(w, httpx.Unprocessable("the spec could not be converted to OpenAPI 3.0, use version=2.0").WithCode("spec_unavailable"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Write(spec)
+}
@@ -0,0 +1,154 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// bodyModels builds the request body each spec definition stands for, so a
+// payload can be decoded and validated exactly as its handler would
+var bodyModels = map[string]func() any{
+	"domain.ActivitySettingsUpdateModel":    func() any { return new(domain.ActivitySettingsUpdateModel) },
+	"domain.ActivityWebhookCreateModel":     func() any { return new(domain.ActivityWebhookCreateModel) },
+	"domain.AuthLoginModel":                 func() any { return new(domain.AuthLoginModel) },
+	"domain.AuthRefreshModel":               func() any { return new(domain.AuthRefreshModel) },
+	"domain.AuthRegisterModel":              func() any { return new(domain.AuthRegisterModel) },
+	"domain.BoardColumnCreateModel":         func() any { return new(domain.BoardColumnCreateModel) },
+	"domain.BoardColumnPositionModel":       func() any { return new(domain.BoardColumnPositionModel) },
+	"domain.BoardColumnUpdateModel":         func() any { return new(domain.BoardColumnUpdateModel) },
+	"domain.BoardCreateModel":               func() any { return new(domain.BoardCreateModel) },
+	"domain.BoardUpdateModel":               func() any { return new(domain.BoardUpdateModel) },
+	"domain.DevValidateModel":               func() any { return new(domain.DevValidateModel) },
+	"domain.IPRulesModel":                   func() any { return new(domain.IPRulesModel) },
+	"domain.InboundIntegrationCreateModel":  func() any { return new(domain.InboundIntegrationCreateModel) },
+	"domain.OrganisationCreateModel":        func() any { return new(domain.OrganisationCreateModel) },
+	"domain.OrganisationMemberCreateModel":  func() any { return new(domain.OrganisationMemberCreateModel) },
+	"domain.OrganisationMemberUpdateModel":  func() any { return new(domain.OrganisationMemberUpdateModel) },
+	"domain.OrganisationUpdateModel":        func() any { return new(domain.OrganisationUpdateModel) },
+	"domain.ProjectBatchCreateModel":        func() any { return new(domain.ProjectBatchCreateModel) },
+	"domain.ProjectCreateModel":             func() any { return new(domain.ProjectCreateModel) },
+	"domain.ProjectPresenceHeartbeatModel":  func() any { return new(domain.ProjectPresenceHeartbeatModel) },
+	"domain.ProjectPriorityCreateModel":     func() any { return new(domain.ProjectPriorityCreateModel) },
+	"domain.ProjectPriorityUpdateModel":     func() any { return new(domain.ProjectPriorityUpdateModel) },
+	"domain.ProjectUIStateUpdateModel":      func() any { return new(domain.ProjectUIStateUpdateModel) },
+	"domain.ProjectUpdateModel":             func() any { return new(domain.ProjectUpdateModel) },
+	"domain.ProjectVisibilityModel":         func() any { return new(domain.ProjectVisibilityModel) },
+	"domain.PushSubscriptionCreateModel":    func() any { return new(domain.PushSubscriptionCreateModel) },
+	"domain.RecordingStartModel":            func() any { return new(domain.RecordingStartModel) },
+	"domain.SprintCreateModel":              func() any { return new(domain.SprintCreateModel) },
+	"domain.SprintUpdateModel":              func() any { return new(domain.SprintUpdateModel) },
+	"domain.TicketBoardMoveModel":           func() any { return new(domain.TicketBoardMoveModel) },
+	"domain.TicketBulkDeleteModel":          func() any { return new(domain.TicketBulkDeleteModel) },
+	"domain.TicketCreateModel":              func() any { return new(domain.TicketCreateModel) },
+	"domain.TicketPositionModel":            func() any { return new(domain.TicketPositionModel) },
+	"domain.TicketProjectMoveModel":         func() any { return new(domain.TicketProjectMoveModel) },
+	"domain.TicketStaleSettingsUpdateModel": func() any { return new(domain.TicketStaleSettingsUpdateModel) },
+	"domain.TicketSyncModel":                func() any { return new(domain.TicketSyncModel) },
+	"domain.TicketUpdateModel":              func() any { return new(domain.TicketUpdateModel) },
+}
+
+// operation is what the spec says an operation takes as its body: a model
+// name, a list of strings, or nothing
+type operation struct {
+	model string
+	list  bool
+}
+
+// parseOperations indexes the spec's body parameters by "METHOD /path"
+func parseOperations(spec []byte) map[string]operation {
+	var doc struct {
+		Paths map[string]map[string]struct {
+			Parameters []struct {
+				In     string `json:"in"`
+				Schema struct {
+					Ref  string `json:"$ref"`
+					Type string `json:"type"`
+				} `json:"schema"`
+			} `json:"parameters"`
+		} `json:"paths"`
+	}
+	if err := json.Unmarshal(spec, &doc); err != nil {
+		return nil
+	}
+
+	ops := make(map[string]operation)
+	for path, methods := range doc.Paths {
+		for method, op := range methods {
+			var o operation
+			for _, p := range op.Parameters {
+				if p.In != "body" {
+					continue
+				}
+				o.model = strings.TrimPrefix(p.Schema.Ref, "#/definitions/")
+				o.list = p.Schema.Ref == "" && p.Schema.Type == "array"
+			}
+			ops[strings.ToUpper(method)+" "+path] = o
+		}
+	}
+	return ops
+}
+
+// Validate godoc
+//
+//	@Summary		Validate a request body
+//	@ID				validate
+//	@Description	Development only. Decodes and validates a body the way the given operation would, without running it, and reports the error response it would get
+//	@Tags			docs
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.DevValidateModel	true	"Operation and body"
+//	@Success		200		{object}	domain.DevValidateResultModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		501		{object}	httpx.ErrBlock
+//	@Router			/dev/validate [post]
+func (h *Handler) Validate(w http.ResponseWriter, r *http.Request) {
+	var req domain.DevValidateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	id := req.OperationID
+	if method, path, ok := strings.Cut(id, " "); ok {
+		id = strings.ToUpper(method) + " " + strings.TrimSpace(path)
+	}
+	op, ok := h.ops[id]
+	if !ok {
+		httpx.Handle(w, httpx.NotFound("operation is not in the spec, use the method and path it lists e.g. POST /projects").WithCode("unknown_operation"))
+		return
+	}
+
+	result := domain.DevValidateResultModel{OperationID: id, Model: op.model, Valid: true}
+	body := bytes.TrimSpace(req.Body)
+	var err error
+	switch {
+	case op.list:
+		err = httpx.DecodeReader(bytes.NewReader(body), new([]string))
+	case op.model != "":
+		model, ok := bodyModels[op.model]
+		if !ok {
+			httpx.Handle(w, httpx.NotImplemented("no validator is registered for "+op.model).WithCode("model_not_registered"))
+			return
+		}
+		dst := model()
+		if err = httpx.DecodeReader(bytes.NewReader(body), dst); err == nil {
+			err = httpx.Validate(dst)
+		}
+	case len(body) > 0 && !bytes.Equal(body, []byte("null")):
+		// the handler never reads it, which is worth knowing but not an error
+		result.Message = "operation takes no request body, it is ignored"
+	}
+
+	if err != nil {
+		result.Valid = false
+		result.Status = http.StatusBadRequest
+		result.Message = err.Error()
+	}
+	httpx.OK(w, result)
+}
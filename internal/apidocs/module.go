@@ -0,0 +1,58 @@
+package apidocs
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/apidocs/handler"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Config struct {
+	Enabled bool // serve the API docs at /docs
+	// Public skips the admin login, meant for local development
+	Public bool
+	// Validate serves POST /dev/validate, development only
+	Validate bool
+}
+
+type Module struct {
+	h    *handler.Handler
+	cfg  Config
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, cfg Config, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		cfg:  cfg,
+		auth: auth,
+	}
+}
+
+// guard asks for an admin's email and password, as a browser opening the
+// page cannot send a bearer token
+func (m *Module) guard(h http.HandlerFunc) http.HandlerFunc {
+	if m.cfg.Public {
+		return h
+	}
+	return m.auth.RequireBasicAuth(h, "fluxis docs", domain.ScopeAdmin)
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	if m.cfg.Validate {
+		mux.HandleFunc("POST /dev/validate", m.h.Validate)
+	}
+	if !m.cfg.Enabled {
+		return
+	}
+	mux.HandleFunc("GET /docs", m.guard(m.h.UI))
+	mux.HandleFunc("GET /docs/", m.guard(m.h.UI))
+	mux.HandleFunc("GET /docs/doc.json", m.guard(m.h.Spec))
+	mux.HandleFunc("GET /openapi.json", m.guard(m.h.OpenAPI))
+
+	// the docs used to live under /swagger
+	mux.HandleFunc("GET /swagger/", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, "/docs/", http.StatusMovedPermanently)
+	})
+}
@@ -0,0 +1,204 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/dimasbaguspm/fluxis/internal/attachment/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// fileFormField is the multipart field carrying the uploaded file
+const fileFormField = "file"
+
+// ListAttachments godoc
+//
+//	@Summary		List ticket attachments
+//	@ID				listAttachments
+//	@Description	Returns the files uploaded to the ticket, oldest first
+//	@Tags			attachment
+//	@Produce		json
+//	@Param			ticketId	path		string	true	"Ticket ID"
+//	@Success		200			{array}		domain.AttachmentModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/attachments [get]
+func (h *Handler) ListAttachments(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	items, err := h.svc.ListAttachments(r.Context(), ticketID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, items)
+}
+
+// UploadAttachment godoc
+//
+//	@Summary		Upload ticket attachment
+//	@ID				uploadAttachment
+//	@Description	Attaches a file to the ticket. Send it as the "file" field of a multipart form; its name and content type are kept, the type is detected from the bytes when missing. Files are limited to ATTACHMENT_MAX_SIZE, 10MB by default
+//	@Tags			attachment
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			ticketId	path		string	true	"Ticket ID"
+//	@Param			file		formData	file	true	"File to attach"
+//	@Success		201			{object}	domain.AttachmentModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		403			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		413			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/attachments [post]
+func (h *Handler) UploadAttachment(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	upload, err := h.readUpload(w, r)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	attachment, err := h.svc.UploadAttachment(r.Context(), ticketID, upload)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.Created(w, attachment)
+}
+
+// DownloadAttachment godoc
+//
+//	@Summary		Download ticket attachment
+//	@ID				downloadAttachment
+//	@Description	Returns the file's content. It is always served as a download, never rendered inline, since the content is whatever was uploaded
+//	@Tags			attachment
+//	@Produce		octet-stream
+//	@Param			ticketId		path	string	true	"Ticket ID"
+//	@Param			attachmentId	path	string	true	"Attachment ID"
+//	@Success		200				{file}		binary
+//	@Failure		400				{object}	httpx.ErrBlock
+//	@Failure		401				{object}	httpx.ErrBlock
+//	@Failure		404				{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/attachments/{attachmentId} [get]
+func (h *Handler) DownloadAttachment(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	id, err := httpx.PathUUID(r, "attachmentId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	attachment, content, err := h.svc.OpenAttachment(r.Context(), ticketID, id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	defer content.Close()
+
+	w.Header().Set("Content-Type", attachment.ContentType)
+	w.Header().Set("Content-Length", strconv.FormatInt(attachment.Size, 10))
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": attachment.Filename}))
+	w.Header().Set("ETag", `"`+attachment.Checksum+`"`)
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	io.Copy(w, content)
+}
+
+// DeleteAttachment godoc
+//
+//	@Summary		Delete ticket attachment
+//	@ID				deleteAttachment
+//	@Description	Removes the file from the ticket and from storage
+//	@Tags			attachment
+//	@Param			ticketId		path	string	true	"Ticket ID"
+//	@Param			attachmentId	path	string	true	"Attachment ID"
+//	@Success		204
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/attachments/{attachmentId} [delete]
+func (h *Handler) DeleteAttachment(w http.ResponseWriter, r *http.Request) {
+	ticketID, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	id, err := httpx.PathUUID(r, "attachmentId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if err := h.svc.DeleteAttachment(r.Context(), ticketID, id); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readUpload reads the file field of a multipart form. The limit leaves room
+// for the multipart envelope, the service checks the file size itself.
+func (h *Handler) readUpload(w http.ResponseWriter, r *http.Request) (domain.AttachmentUploadModel, error) {
+	if h.svc.Config.MaxSize > 0 {
+		r.Body = http.MaxBytesReader(w, r.Body, int64(h.svc.Config.MaxSize)+64<<10)
+	}
+
+	reader, err := r.MultipartReader()
+	if err != nil {
+		return domain.AttachmentUploadModel{}, httpx.BadRequest("expected a multipart/form-data body")
+	}
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return domain.AttachmentUploadModel{}, httpx.BadRequest(`missing "` + fileFormField + `" file field`)
+		}
+		if err != nil {
+			return domain.AttachmentUploadModel{}, readError(err)
+		}
+		if part.FormName() != fileFormField {
+			continue
+		}
+
+		data, err := io.ReadAll(part)
+		if err != nil {
+			return domain.AttachmentUploadModel{}, readError(err)
+		}
+		return domain.AttachmentUploadModel{
+			Filename:    part.FileName(),
+			ContentType: part.Header.Get("Content-Type"),
+			Data:        data,
+		}, nil
+	}
+}
+
+func readError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return service.ErrAttachmentTooLarge
+	}
+	return httpx.BadRequest("could not read attachment upload")
+}
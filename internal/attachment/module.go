@@ -0,0 +1,28 @@
+package attachment
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/attachment/handler"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Module struct {
+	h    *handler.Handler
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /tickets/{ticketId}/attachments", m.auth.RequireAuth(m.h.ListAttachments, domain.ScopeTicketsRead))
+	mux.HandleFunc("POST /tickets/{ticketId}/attachments", m.auth.RequireAuth(m.h.UploadAttachment, domain.ScopeTicketsWrite))
+	mux.HandleFunc("GET /tickets/{ticketId}/attachments/{attachmentId}", m.auth.RequireAuth(m.h.DownloadAttachment, domain.ScopeTicketsRead))
+	mux.HandleFunc("DELETE /tickets/{ticketId}/attachments/{attachmentId}", m.auth.RequireAuth(m.h.DeleteAttachment, domain.ScopeTicketsWrite))
+}
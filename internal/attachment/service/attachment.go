@@ -0,0 +1,199 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"mime"
+	"net/http"
+	"strings"
+	"unicode"
+
+	"github.com/dimasbaguspm/fluxis/internal/attachment/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxFilenameLength matches the filename column
+const maxFilenameLength = 255
+
+var (
+	ErrAttachmentNotFound = domain.NotFound("attachment not found")
+	ErrAttachmentEmpty    = domain.Invalid("attachment is empty").WithCode("attachment_empty")
+	ErrAttachmentTooLarge = domain.TooLarge("attachment is too large").WithCode("attachment_too_large")
+)
+
+func (s *Service) ListAttachments(ctx context.Context, ticketID pgtype.UUID) ([]domain.AttachmentModel, error) {
+	if _, err := s.Ticket.GetTicket(ctx, ticketID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Repo.ListAttachments(ctx, ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("list attachments: %w", err)
+	}
+
+	items := make([]domain.AttachmentModel, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toAttachmentModel(row))
+	}
+	return items, nil
+}
+
+// UploadAttachment writes the content to the store before recording it, so a
+// listed attachment can always be downloaded. When recording fails the
+// content is removed again.
+func (s *Service) UploadAttachment(ctx context.Context, ticketID pgtype.UUID, p domain.AttachmentUploadModel) (domain.AttachmentModel, error) {
+	if len(p.Data) == 0 {
+		return domain.AttachmentModel{}, ErrAttachmentEmpty
+	}
+	if s.Config.MaxSize > 0 && len(p.Data) > s.Config.MaxSize {
+		return domain.AttachmentModel{}, ErrAttachmentTooLarge
+	}
+	if err := s.authorizeWrite(ctx, ticketID); err != nil {
+		return domain.AttachmentModel{}, err
+	}
+
+	key, err := storageKey(ticketID)
+	if err != nil {
+		return domain.AttachmentModel{}, err
+	}
+	contentType := detectContentType(p.ContentType, p.Data)
+	if err := s.Store.Put(ctx, key, bytes.NewReader(p.Data), int64(len(p.Data)), contentType); err != nil {
+		return domain.AttachmentModel{}, fmt.Errorf("store attachment: %w", err)
+	}
+
+	sum := sha256.Sum256(p.Data)
+	row, err := s.Repo.CreateAttachment(ctx, repository.CreateAttachmentParams{
+		ID:          idgen.New(),
+		TicketID:    ticketID,
+		StorageKey:  key,
+		Filename:    cleanFilename(p.Filename),
+		ContentType: contentType,
+		Size:        int64(len(p.Data)),
+		Checksum:    hex.EncodeToString(sum[:]),
+		UploadedBy:  httpx.MustUserID(ctx),
+	})
+	if err != nil {
+		if derr := s.Store.Delete(context.WithoutCancel(ctx), key); derr != nil {
+			slog.Warn("[AttachmentService]: remove content of unrecorded attachment", "key", key, "error", derr)
+		}
+		return domain.AttachmentModel{}, fmt.Errorf("create attachment: %w", err)
+	}
+	return toAttachmentModel(row), nil
+}
+
+func (s *Service) OpenAttachment(ctx context.Context, ticketID, id pgtype.UUID) (domain.AttachmentModel, io.ReadCloser, error) {
+	if _, err := s.Ticket.GetTicket(ctx, ticketID); err != nil {
+		return domain.AttachmentModel{}, nil, err
+	}
+
+	row, err := s.Repo.GetAttachment(ctx, repository.GetAttachmentParams{ID: id, TicketID: ticketID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.AttachmentModel{}, nil, ErrAttachmentNotFound
+	}
+	if err != nil {
+		return domain.AttachmentModel{}, nil, fmt.Errorf("get attachment: %w", err)
+	}
+
+	content, err := s.Store.Get(ctx, row.StorageKey)
+	if err != nil {
+		return domain.AttachmentModel{}, nil, fmt.Errorf("open attachment %s: %w", row.StorageKey, err)
+	}
+	return toAttachmentModel(row), content, nil
+}
+
+// DeleteAttachment forgets the attachment first and then removes its content.
+// Content the store fails to remove is only logged, it is no longer reachable.
+func (s *Service) DeleteAttachment(ctx context.Context, ticketID, id pgtype.UUID) error {
+	if err := s.authorizeWrite(ctx, ticketID); err != nil {
+		return err
+	}
+
+	row, err := s.Repo.DeleteAttachment(ctx, repository.DeleteAttachmentParams{ID: id, TicketID: ticketID})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrAttachmentNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete attachment: %w", err)
+	}
+
+	if err := s.Store.Delete(ctx, row.StorageKey); err != nil {
+		slog.Warn("[AttachmentService]: remove content of deleted attachment", "key", row.StorageKey, "error", err)
+	}
+	return nil
+}
+
+// authorizeWrite lets project members change a ticket's attachments, viewers
+// only read them. GetTicket already reports a ticket outside the caller's
+// projects as not found.
+func (s *Service) authorizeWrite(ctx context.Context, ticketID pgtype.UUID) error {
+	ticket, err := s.Ticket.GetTicket(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+	return s.Project.AuthorizeProject(ctx, ticket.ProjectID, domain.ProjectRoleMember)
+}
+
+// storageKey groups a ticket's files under its ID; the random name keeps a
+// re-upload of the same file from replacing the first one
+func storageKey(ticketID pgtype.UUID) (string, error) {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return "", fmt.Errorf("generate storage key: %w", err)
+	}
+	return "tickets/" + transformer.UUIDString(ticketID) + "/" + hex.EncodeToString(b[:]), nil
+}
+
+// detectContentType keeps the type the client sent when it is well formed
+// and sniffs the content otherwise
+func detectContentType(declared string, data []byte) string {
+	if mediaType, params, err := mime.ParseMediaType(declared); err == nil && mediaType != "application/octet-stream" {
+		return mime.FormatMediaType(mediaType, params)
+	}
+	return http.DetectContentType(data)
+}
+
+// cleanFilename keeps the last path element of an uploaded name without
+// control characters, so it can be echoed in a download header as is
+func cleanFilename(name string) string {
+	if i := strings.LastIndexAny(name, `/\`); i >= 0 {
+		name = name[i+1:]
+	}
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) || r == '"' {
+			return -1
+		}
+		return r
+	}, name))
+	if runes := []rune(name); len(runes) > maxFilenameLength {
+		name = string(runes[:maxFilenameLength])
+	}
+	if name == "" || name == "." || name == ".." {
+		return "attachment"
+	}
+	return name
+}
+
+func toAttachmentModel(row repository.Attachment) domain.AttachmentModel {
+	return domain.AttachmentModel{
+		ID:          row.ID,
+		TicketID:    row.TicketID,
+		Filename:    row.Filename,
+		ContentType: row.ContentType,
+		Size:        row.Size,
+		Checksum:    row.Checksum,
+		UploadedBy:  row.UploadedBy,
+		CreatedAt:   row.CreatedAt.Time,
+	}
+}
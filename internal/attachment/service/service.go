@@ -0,0 +1,31 @@
+package service
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/attachment/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/blob"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+type Deps struct {
+	Repo    *repository.Queries
+	Ticket  domain.TicketReader
+	Project domain.ProjectReader
+	// Store keeps the file contents, on disk or in a bucket
+	Store  blob.Store
+	Config *Config
+}
+
+type Config struct {
+	MaxSize int // bytes per file
+}
+
+type Service struct {
+	Deps
+}
+
+var _ domain.AttachmentReader = (*Service)(nil)
+var _ domain.AttachmentWriter = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{d}
+}
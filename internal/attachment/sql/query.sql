@@ -0,0 +1,35 @@
+-- name: CreateAttachment :one
+INSERT INTO
+    attachments (ticket_id, storage_key, filename, content_type, size, checksum, uploaded_by, id)
+VALUES
+    ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING
+    *;
+
+-- name: DeleteAttachment :one
+-- Returns the deleted row so its blob can be removed from the store
+DELETE FROM attachments
+WHERE
+    id = $1
+    AND ticket_id = $2
+RETURNING
+    *;
+
+-- name: GetAttachment :one
+SELECT
+    *
+FROM
+    attachments
+WHERE
+    id = $1
+    AND ticket_id = $2;
+
+-- name: ListAttachments :many
+SELECT
+    *
+FROM
+    attachments
+WHERE
+    ticket_id = $1
+ORDER BY
+    created_at, id;
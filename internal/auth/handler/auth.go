@@ -10,6 +10,7 @@ import (
 // Register godoc
 //
 //	@Summary		Register a new user
+//	@ID				register
 //	@Description	Creates a new user account and returns access/refresh tokens
 //	@Tags			auth
 //	@Accept			json
@@ -37,6 +38,7 @@ func (h *Handler) Register(w http.ResponseWriter, r *http.Request) {
 // Login godoc
 //
 //	@Summary		Login with email and password
+//	@ID				login
 //	@Description	Authenticates a user and returns access/refresh tokens
 //	@Tags			auth
 //	@Accept			json
@@ -65,6 +67,7 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request) {
 // Refresh godoc
 //
 //	@Summary		Rotate access token
+//	@ID				refresh
 //	@Description	Issues a new access token using a valid refresh token
 //	@Tags			auth
 //	@Accept			json
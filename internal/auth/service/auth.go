@@ -8,15 +8,14 @@ import (
 
 	userservice "github.com/dimasbaguspm/fluxis/internal/user/service"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
-	"github.com/dimasbaguspm/fluxis/pkg/httpx"
 	"golang.org/x/crypto/bcrypt"
 )
 
 var (
-	ErrInvalidCredentials = httpx.Unauthorized("invalid email or password")
-	ErrAccountLocked      = httpx.TooManyRequests("account temporarily locked, try again later")
-	ErrUserAlreadyExists  = httpx.Conflict("email already registered").WithCode("email_taken")
-	ErrTokenInvalid       = httpx.Unauthorized("token is invalid or expired")
+	ErrInvalidCredentials = domain.Unauthorized("invalid email or password")
+	ErrAccountLocked      = domain.RateLimited("account temporarily locked, try again later")
+	ErrUserAlreadyExists  = domain.Conflict("email already registered").WithCode("email_taken")
+	ErrTokenInvalid       = domain.Unauthorized("token is invalid or expired")
 )
 
 func (s *Service) Register(ctx context.Context, p domain.AuthRegisterModel) (domain.AuthModel, error) {
@@ -26,7 +26,14 @@ type Config struct {
 	AccessTokenExpiry  time.Duration // default 15m
 	RefreshTokenExpiry time.Duration // default 7d
 
+	// secrets being rotated out; tokens they signed are still accepted until
+	// they expire, new tokens are signed with the current secrets only
+	PreviousAccessTokenSecret  string
+	PreviousRefreshTokenSecret string
+
 	BcryptCost int
+
+	AdminEmails []string // accounts granted the admin scope
 }
 
 func New(d Deps) *Service {
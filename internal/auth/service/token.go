@@ -3,16 +3,16 @@ package service
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
-	"github.com/dimasbaguspm/fluxis/pkg/httpx"
 	"github.com/golang-jwt/jwt/v5"
 )
 
 var (
-	ErrUnableToSignToken  = httpx.Unauthorized("token unable to be signed")
-	ErrUnableToParseToken = httpx.Unauthorized("token unable to be parsed")
+	ErrUnableToSignToken  = domain.Unauthorized("token unable to be signed")
+	ErrUnableToParseToken = domain.Unauthorized("token unable to be parsed")
 )
 
 func (s *Service) GenerateTokens(_ context.Context, p domain.UserModel) (domain.AuthModel, error) {
@@ -26,7 +26,8 @@ func (s *Service) GenerateTokens(_ context.Context, p domain.UserModel) (domain.
 			IssuedAt:  jwt.NewNumericDate(now),
 			ExpiresAt: jwt.NewNumericDate(accessExpiry),
 		},
-		ID: p.ID,
+		ID:     p.ID,
+		Scopes: s.scopesFor(p),
 	}
 
 	acessToken, err := jwt.NewWithClaims(jwt.SigningMethodHS256, accessClaims).SignedString([]byte(s.Config.AccessTokenSecret))
@@ -54,17 +55,33 @@ func (s *Service) GenerateTokens(_ context.Context, p domain.UserModel) (domain.
 	}, nil
 }
 
+func (s *Service) scopesFor(p domain.UserModel) []string {
+	scopes := append([]string{}, domain.DefaultUserScopes...)
+	for _, email := range s.Config.AdminEmails {
+		if strings.EqualFold(email, p.Email) {
+			return append(scopes, domain.ScopeAdmin)
+		}
+	}
+	return scopes
+}
+
 func (s *Service) ValidateAccessToken(_ context.Context, tokenstr string) (domain.AuthTokenClaimModel, error) {
 	var claims domain.AuthTokenClaimModel
 	_, err := jwt.ParseWithClaims(tokenstr, &claims, func(t *jwt.Token) (any, error) {
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 		}
-		return []byte(s.Config.AccessTokenSecret), nil
+		return verificationKeys(s.Config.AccessTokenSecret, s.Config.PreviousAccessTokenSecret), nil
 	})
 	if err != nil {
 		return domain.AuthTokenClaimModel{}, ErrUnableToParseToken
 	}
+	// tokens signed before scopes were introduced lack the claim; they came
+	// from an interactive login and get what one grants today. A present but
+	// empty list decodes as non-nil and stays empty.
+	if claims.Scopes == nil {
+		claims.Scopes = s.scopesFor(domain.UserModel{Email: claims.Subject})
+	}
 	return claims, nil
 }
 
@@ -74,10 +91,19 @@ func (s *Service) ValidateRefreshToken(_ context.Context, tokenstr string) (doma
 		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
 		}
-		return []byte(s.Config.RefreshTokenSecret), nil
+		return verificationKeys(s.Config.RefreshTokenSecret, s.Config.PreviousRefreshTokenSecret), nil
 	})
 	if err != nil {
 		return domain.AuthTokenClaimModel{}, ErrUnableToParseToken
 	}
 	return claims, nil
 }
+
+// verificationKeys tries the current secret first and the previous one after
+// it, so a rotation does not sign everyone out at once
+func verificationKeys(current, previous string) any {
+	if previous == "" {
+		return []byte(current)
+	}
+	return jwt.VerificationKeySet{Keys: []jwt.VerificationKey{[]byte(current), []byte(previous)}}
+}
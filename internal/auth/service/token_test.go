@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func TestValidateAccessToken_Scopes(t *testing.T) {
+	s := New(Deps{Config: &Config{
+		AccessTokenSecret: "access-secret",
+		AdminEmails:       []string{"admin@example.com"},
+	}})
+
+	sign := func(claims jwt.MapClaims) string {
+		t.Helper()
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte("access-secret"))
+		if err != nil {
+			t.Fatalf("sign: %v", err)
+		}
+		return token
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+	admin := append(slices.Clone(domain.DefaultUserScopes), domain.ScopeAdmin)
+
+	tests := []struct {
+		name   string
+		claims jwt.MapClaims
+		want   []string
+	}{
+		{"no scopes claim", jwt.MapClaims{"sub": "user@example.com", "exp": exp}, domain.DefaultUserScopes},
+		{"no scopes claim for an admin", jwt.MapClaims{"sub": "Admin@Example.com", "exp": exp}, admin},
+		{"scopes claim kept", jwt.MapClaims{"sub": "admin@example.com", "exp": exp, "scopes": []string{domain.ScopeTicketsRead}}, []string{domain.ScopeTicketsRead}},
+		{"empty scopes claim stays empty", jwt.MapClaims{"sub": "admin@example.com", "exp": exp, "scopes": []string{}}, []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims, err := s.ValidateAccessToken(context.Background(), sign(tt.claims))
+			if err != nil {
+				t.Fatalf("ValidateAccessToken = %v", err)
+			}
+			if !slices.Equal(claims.Scopes, tt.want) {
+				t.Fatalf("scopes = %v, want %v", claims.Scopes, tt.want)
+			}
+			for _, scope := range []string{domain.ScopeProjectsRead, domain.ScopeTicketsWrite} {
+				if got, want := domain.HasScopes(claims.Scopes, scope), slices.Contains(tt.want, scope); got != want {
+					t.Errorf("HasScopes(%s) = %v, want %v", scope, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestGenerateTokens_RoundTrip(t *testing.T) {
+	s := New(Deps{Config: &Config{
+		AccessTokenSecret:  "access-secret",
+		RefreshTokenSecret: "refresh-secret",
+		AccessTokenExpiry:  time.Minute,
+		RefreshTokenExpiry: time.Hour,
+	}})
+
+	tokens, err := s.GenerateTokens(context.Background(), domain.UserModel{Email: "user@example.com"})
+	if err != nil {
+		t.Fatalf("GenerateTokens = %v", err)
+	}
+	claims, err := s.ValidateAccessToken(context.Background(), tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken = %v", err)
+	}
+	if !slices.Equal(claims.Scopes, domain.DefaultUserScopes) {
+		t.Fatalf("scopes = %v, want %v", claims.Scopes, domain.DefaultUserScopes)
+	}
+}
+
+func TestAuthTokenClaimModel_EmptyScopesRoundTrip(t *testing.T) {
+	s := New(Deps{Config: &Config{AccessTokenSecret: "access-secret"}})
+
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, domain.AuthTokenClaimModel{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject:   "user@example.com",
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Scopes: []string{},
+	}).SignedString([]byte("access-secret"))
+	if err != nil {
+		t.Fatalf("sign: %v", err)
+	}
+
+	claims, err := s.ValidateAccessToken(context.Background(), token)
+	if err != nil {
+		t.Fatalf("ValidateAccessToken = %v", err)
+	}
+	if claims.Scopes == nil || len(claims.Scopes) != 0 {
+		t.Fatalf("scopes = %#v, want an empty list", claims.Scopes)
+	}
+	if domain.HasScopes(claims.Scopes, domain.ScopeProjectsRead) {
+		t.Fatal("a token minted without scopes was granted projects:read")
+	}
+}
@@ -11,6 +11,7 @@ import (
 // CreateBoard godoc
 //
 //	@Summary		Create a board
+//	@ID				createBoard
 //	@Description	Creates a new board in a sprint
 //	@Tags			board
 //	@Accept			json
@@ -42,11 +43,13 @@ func (h *Handler) CreateBoard(w http.ResponseWriter, r *http.Request) {
 // ListBoards godoc
 //
 //	@Summary		List boards
+//	@ID				listBoards
 //	@Description	Returns all boards in a sprint with pagination
 //	@Tags			board
 //	@Produce		json
 //	@Param			query		query		domain.BoardsSearchModel	false	"Search parameters: name, pageNumber, pageSize"
 //	@Success		200			{object}	domain.BoardsPagedModel
+//	@Header			200			{string}	Link	"RFC 8288 links to the first, prev, next and last page"
 //	@Failure		400			{object}	httpx.ErrBlock
 //	@Failure		401			{object}	httpx.ErrBlock
 //	@Security		BearerAuth
@@ -68,12 +71,13 @@ func (h *Handler) ListBoards(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.OK(w, result)
+	httpx.OKPage(w, r, result, result.PageNumber, result.PageSize, result.TotalPages)
 }
 
 // GetBoard godoc
 //
 //	@Summary		Get a board
+//	@ID				getBoard
 //	@Description	Returns a single board by ID
 //	@Tags			board
 //	@Produce		json
@@ -105,6 +109,7 @@ func (h *Handler) GetBoard(w http.ResponseWriter, r *http.Request) {
 // UpdateBoard godoc
 //
 //	@Summary		Update a board
+//	@ID				updateBoard
 //	@Description	Updates board details
 //	@Tags			board
 //	@Accept			json
@@ -143,6 +148,7 @@ func (h *Handler) UpdateBoard(w http.ResponseWriter, r *http.Request) {
 // ReorderBoards godoc
 //
 //	@Summary		Reorder boards
+//	@ID				reorderBoards
 //	@Description	Reorder boards within a sprint (positions determined by array order)
 //	@Tags			board
 //	@Accept			json
@@ -185,6 +191,7 @@ func (h *Handler) ReorderBoards(w http.ResponseWriter, r *http.Request) {
 // DeleteBoard godoc
 //
 //	@Summary		Delete a board
+//	@ID				deleteBoard
 //	@Description	Deletes a board
 //	@Tags			board
 //	@Produce		json
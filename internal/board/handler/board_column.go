@@ -11,12 +11,14 @@ import (
 // ListBoardColumns godoc
 //
 //	@Summary		List board columns
+//	@ID				listBoardColumns
 //	@Description	Returns all columns in a board with pagination
 //	@Tags			board
 //	@Produce		json
 //	@Param			boardId	path		string							true	"Board ID"
-//	@Param			query	query		domain.BoardColumnsSearchModel	false	"Search parameters: name, pageNumber, pageSize"
+//	@Param			query	query		domain.BoardColumnsSearchModel	false	"Search parameters: id, name, category (repeatable), includeCounts, pageNumber, pageSize"
 //	@Success		200		{object}	domain.BoardColumnsPagedModel
+//	@Header			200		{string}	Link	"RFC 8288 links to the first, prev, next and last page"
 //	@Failure		400		{object}	httpx.ErrBlock
 //	@Failure		401		{object}	httpx.ErrBlock
 //	@Failure		404		{object}	httpx.ErrBlock
@@ -30,11 +32,13 @@ func (h *Handler) ListBoardColumns(w http.ResponseWriter, r *http.Request) {
 	}
 
 	req := domain.BoardColumnsSearchModel{
-		ID:         httpx.QueryUUIDs(r, "id"),
-		BoardID:    []pgtype.UUID{boardID},
-		Name:       httpx.QueryString(r, "name"),
-		PageNumber: httpx.QueryNumber(r, "pageNumber"),
-		PageSize:   httpx.QueryNumber(r, "pageSize"),
+		ID:            httpx.QueryUUIDs(r, "id"),
+		BoardID:       []pgtype.UUID{boardID},
+		Name:          httpx.QueryString(r, "name"),
+		Category:      httpx.QueryStrings(r, "category"),
+		IncludeCounts: httpx.QueryBoolean(r, "includeCounts"),
+		PageNumber:    httpx.QueryNumber(r, "pageNumber"),
+		PageSize:      httpx.QueryNumber(r, "pageSize"),
 	}
 
 	result, err := h.svc.ListBoardColumns(r.Context(), req)
@@ -43,12 +47,13 @@ func (h *Handler) ListBoardColumns(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.OK(w, result)
+	httpx.OKPage(w, r, result, result.PageNumber, result.PageSize, result.TotalPages)
 }
 
 // CreateBoardColumn godoc
 //
 //	@Summary		Create a board column
+//	@ID				createBoardColumn
 //	@Description	Creates a new column in a board (position is auto-calculated)
 //	@Tags			board
 //	@Accept			json
@@ -86,6 +91,7 @@ func (h *Handler) CreateBoardColumn(w http.ResponseWriter, r *http.Request) {
 // UpdateBoardColumn godoc
 //
 //	@Summary		Update a board column
+//	@ID				updateBoardColumn
 //	@Description	Updates column name (use reorder endpoint for position changes)
 //	@Tags			board
 //	@Accept			json
@@ -130,6 +136,7 @@ func (h *Handler) UpdateBoardColumn(w http.ResponseWriter, r *http.Request) {
 // ReorderBoardColumns godoc
 //
 //	@Summary		Reorder board columns
+//	@ID				reorderBoardColumns
 //	@Description	Reorder columns within a board
 //	@Tags			board
 //	@Accept			json
@@ -172,15 +179,18 @@ func (h *Handler) ReorderBoardColumns(w http.ResponseWriter, r *http.Request) {
 // DeleteBoardColumn godoc
 //
 //	@Summary		Delete a board column
-//	@Description	Deletes a column from a board
+//	@ID				deleteBoardColumn
+//	@Description	Deletes a column from a board. The board's default column is refused with 409 (default_column) unless replacementId names another column of the board, which becomes the default
 //	@Tags			board
 //	@Produce		json
 //	@Param			boardId			path		string	true	"Board ID"
 //	@Param			boardColumnId	path		string	true	"Board Column ID"
+//	@Param			replacementId	query		string	false	"Column that becomes the default when the deleted column is the default"
 //	@Success		204
 //	@Failure		400				{object}	httpx.ErrBlock
 //	@Failure		401				{object}	httpx.ErrBlock
 //	@Failure		404				{object}	httpx.ErrBlock
+//	@Failure		409				{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/boards/{boardId}/columns/{boardColumnId} [delete]
 func (h *Handler) DeleteBoardColumn(w http.ResponseWriter, r *http.Request) {
@@ -196,10 +206,183 @@ func (h *Handler) DeleteBoardColumn(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := h.svc.DeleteBoardColumn(r.Context(), boardID, columnID); err != nil {
+	var replacementID pgtype.UUID
+	if httpx.QueryString(r, "replacementId") != "" {
+		if replacementID, err = httpx.QueryUUID(r, "replacementId"); err != nil {
+			httpx.Handle(w, err)
+			return
+		}
+	}
+
+	if err := h.svc.DeleteBoardColumn(r.Context(), boardID, columnID, replacementID); err != nil {
 		httpx.Handle(w, err)
 		return
 	}
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RestoreBoardColumn godoc
+//
+//	@Summary		Restore a deleted board column
+//	@ID				restoreBoardColumn
+//	@Description	Clears the deletion of a soft-deleted column, putting it back at its old position. It becomes the board's default only when the board has none. Refused with 409 when the column is not deleted (not_deleted) or its board, sprint or project is (parent_deleted)
+//	@Tags			board
+//	@Produce		json
+//	@Param			boardId			path		string	true	"Board ID"
+//	@Param			boardColumnId	path		string	true	"Board Column ID"
+//	@Success		200				{object}	domain.BoardColumnModel
+//	@Failure		400				{object}	httpx.ErrBlock
+//	@Failure		401				{object}	httpx.ErrBlock
+//	@Failure		404				{object}	httpx.ErrBlock
+//	@Failure		409				{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/boards/{boardId}/columns/{boardColumnId}/restore [post]
+func (h *Handler) RestoreBoardColumn(w http.ResponseWriter, r *http.Request) {
+	boardID, err := httpx.PathUUID(r, "boardId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	columnID, err := httpx.PathUUID(r, "boardColumnId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	column, err := h.svc.RestoreBoardColumn(r.Context(), boardID, columnID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, column)
+}
+
+// MergeBoardColumn godoc
+//
+//	@Summary		Merge a board column into another
+//	@ID				mergeBoardColumn
+//	@Description	Moves every ticket from the column into the target column of the same board, then deletes the column and closes the gap in positions. The merge is atomic
+//	@Tags			board
+//	@Produce		json
+//	@Param			boardId			path		string	true	"Board ID"
+//	@Param			boardColumnId	path		string	true	"Board Column ID to merge away"
+//	@Param			targetColumnId	path		string	true	"Board Column ID receiving the tickets"
+//	@Success		200				{object}	domain.BoardColumnMergeModel
+//	@Failure		400				{object}	httpx.ErrBlock
+//	@Failure		401				{object}	httpx.ErrBlock
+//	@Failure		404				{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/boards/{boardId}/columns/{boardColumnId}/merge-into/{targetColumnId} [post]
+func (h *Handler) MergeBoardColumn(w http.ResponseWriter, r *http.Request) {
+	boardID, err := httpx.PathUUID(r, "boardId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	columnID, err := httpx.PathUUID(r, "boardColumnId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	targetID, err := httpx.PathUUID(r, "targetColumnId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	result, err := h.svc.MergeBoardColumn(r.Context(), boardID, columnID, targetID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, result)
+}
+
+// MoveBoardColumn godoc
+//
+//	@Summary		Move a single board column
+//	@ID				moveBoardColumn
+//	@Description	Places the column right after afterId, or first when afterId is omitted. Only the moved column is rewritten, so concurrent creates are not lost
+//	@Tags			board
+//	@Accept			json
+//	@Produce		json
+//	@Param			boardId			path		string							true	"Board ID"
+//	@Param			boardColumnId	path		string							true	"Board Column ID"
+//	@Param			body			body		domain.BoardColumnPositionModel	true	"Position payload"
+//	@Success		200				{object}	domain.BoardColumnModel
+//	@Failure		400				{object}	httpx.ErrBlock
+//	@Failure		401				{object}	httpx.ErrBlock
+//	@Failure		404				{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/boards/{boardId}/columns/{boardColumnId}/position [patch]
+func (h *Handler) MoveBoardColumn(w http.ResponseWriter, r *http.Request) {
+	boardID, err := httpx.PathUUID(r, "boardId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	columnID, err := httpx.PathUUID(r, "boardColumnId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.BoardColumnPositionModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.svc.MoveBoardColumn(r.Context(), boardID, columnID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, result)
+}
+
+// SetDefaultBoardColumn godoc
+//
+//	@Summary		Make a column the board default
+//	@ID				setDefaultBoardColumn
+//	@Description	Moves the board's default flag to this column; the previous default is cleared in the same statement
+//	@Tags			board
+//	@Produce		json
+//	@Param			boardId			path		string	true	"Board ID"
+//	@Param			boardColumnId	path		string	true	"Board Column ID"
+//	@Success		200				{object}	domain.BoardColumnModel
+//	@Failure		400				{object}	httpx.ErrBlock
+//	@Failure		401				{object}	httpx.ErrBlock
+//	@Failure		404				{object}	httpx.ErrBlock
+//	@Failure		409				{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/boards/{boardId}/columns/{boardColumnId}/make-default [post]
+func (h *Handler) SetDefaultBoardColumn(w http.ResponseWriter, r *http.Request) {
+	boardID, err := httpx.PathUUID(r, "boardId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	columnID, err := httpx.PathUUID(r, "boardColumnId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	result, err := h.svc.SetDefaultBoardColumn(r.Context(), boardID, columnID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, result)
+}
@@ -4,40 +4,59 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
 	boardcache "github.com/dimasbaguspm/fluxis/internal/board/cache"
 	"github.com/dimasbaguspm/fluxis/internal/board/handler"
+	"github.com/dimasbaguspm/fluxis/internal/board/service"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/jsonapi"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 )
 
 type Module struct {
 	handler    *handler.Handler
+	svc        *service.Service
 	boardCache *boardcache.BoardCache
 	bus        pubsub.Bus
+	auth       *httpx.Authenticator
 }
 
-func NewModule(h *handler.Handler, c *boardcache.BoardCache, bus pubsub.Bus) *Module {
+func NewModule(h *handler.Handler, svc *service.Service, c *boardcache.BoardCache, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
 	return &Module{
 		handler:    h,
+		svc:        svc,
 		boardCache: c,
 		bus:        bus,
+		auth:       auth,
 	}
 }
 
+// boardColumnResource describes board columns, the statuses a ticket moves
+// through, served as JSON:API documents to clients that negotiate
+// application/vnd.api+json
+var boardColumnResource = jsonapi.Resource{
+	Type:          "board-columns",
+	Relationships: map[string]string{"boardId": "boards"},
+}
+
 func (m *Module) Routes(mux *http.ServeMux) {
-	mux.HandleFunc("POST /boards", httpx.RequireAuth(m.handler.CreateBoard))
-	mux.HandleFunc("GET /boards", httpx.RequireAuth(m.handler.ListBoards))
-	mux.HandleFunc("GET /boards/{boardId}", httpx.RequireAuth(m.handler.GetBoard))
-	mux.HandleFunc("PATCH /boards/{boardId}", httpx.RequireAuth(m.handler.UpdateBoard))
-	mux.HandleFunc("PATCH /boards/reorder", httpx.RequireAuth(m.handler.ReorderBoards))
-	mux.HandleFunc("DELETE /boards/{boardId}", httpx.RequireAuth(m.handler.DeleteBoard))
-	mux.HandleFunc("GET /boards/{boardId}/columns", httpx.RequireAuth(m.handler.ListBoardColumns))
-	mux.HandleFunc("POST /boards/{boardId}/columns", httpx.RequireAuth(m.handler.CreateBoardColumn))
-	mux.HandleFunc("PATCH /boards/{boardId}/columns/reorder", httpx.RequireAuth(m.handler.ReorderBoardColumns))
-	mux.HandleFunc("PATCH /boards/{boardId}/columns/{boardColumnId}", httpx.RequireAuth(m.handler.UpdateBoardColumn))
-	mux.HandleFunc("DELETE /boards/{boardId}/columns/{boardColumnId}", httpx.RequireAuth(m.handler.DeleteBoardColumn))
+	mux.HandleFunc("POST /boards", m.auth.RequireAuth(m.handler.CreateBoard, domain.ScopeBoardsWrite))
+	mux.HandleFunc("GET /boards", m.auth.RequireAuth(m.handler.ListBoards, domain.ScopeBoardsRead))
+	mux.HandleFunc("GET /boards/{boardId}", m.auth.RequireAuth(m.handler.GetBoard, domain.ScopeBoardsRead))
+	mux.HandleFunc("PATCH /boards/{boardId}", m.auth.RequireAuth(m.handler.UpdateBoard, domain.ScopeBoardsWrite))
+	mux.HandleFunc("PATCH /boards/reorder", m.auth.RequireAuth(m.handler.ReorderBoards, domain.ScopeBoardsWrite))
+	mux.HandleFunc("DELETE /boards/{boardId}", m.auth.RequireAuth(m.handler.DeleteBoard, domain.ScopeBoardsWrite))
+	mux.HandleFunc("GET /boards/{boardId}/columns", jsonapi.Wrap(boardColumnResource, m.auth.RequireAuth(m.handler.ListBoardColumns, domain.ScopeBoardsRead)))
+	mux.HandleFunc("POST /boards/{boardId}/columns", jsonapi.Wrap(boardColumnResource, m.auth.RequireAuth(m.handler.CreateBoardColumn, domain.ScopeBoardsWrite)))
+	mux.HandleFunc("PATCH /boards/{boardId}/columns/reorder", m.auth.RequireAuth(m.handler.ReorderBoardColumns, domain.ScopeBoardsWrite))
+	mux.HandleFunc("PATCH /boards/{boardId}/columns/{boardColumnId}", jsonapi.Wrap(boardColumnResource, m.auth.RequireAuth(m.handler.UpdateBoardColumn, domain.ScopeBoardsWrite)))
+	mux.HandleFunc("PATCH /boards/{boardId}/columns/{boardColumnId}/position", m.auth.RequireAuth(m.handler.MoveBoardColumn, domain.ScopeBoardsWrite))
+	mux.HandleFunc("DELETE /boards/{boardId}/columns/{boardColumnId}", m.auth.RequireAuth(m.handler.DeleteBoardColumn, domain.ScopeBoardsWrite))
+	mux.HandleFunc("POST /boards/{boardId}/columns/{boardColumnId}/restore", jsonapi.Wrap(boardColumnResource, m.auth.RequireAuth(m.handler.RestoreBoardColumn, domain.ScopeBoardsWrite)))
+	mux.HandleFunc("POST /boards/{boardId}/columns/{boardColumnId}/merge-into/{targetColumnId}", m.auth.RequireAuth(m.handler.MergeBoardColumn, domain.ScopeBoardsWrite))
+	mux.HandleFunc("POST /boards/{boardId}/columns/{boardColumnId}/make-default", m.auth.RequireAuth(m.handler.SetDefaultBoardColumn, domain.ScopeBoardsWrite))
 }
 
 func (m *Module) StartSubscriber(ctx context.Context) {
@@ -58,3 +77,13 @@ func (m *Module) StartSubscriber(ctx context.Context) {
 
 	m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Board), handler)
 }
+
+// StartCompactor periodically re-spaces boards whose column positions have
+// run out of room after many single moves
+func (m *Module) StartCompactor(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	slog.Info("[BoardModule]: starting column compactor", "interval", interval.String())
+	m.svc.StartColumnCompactor(ctx, interval)
+}
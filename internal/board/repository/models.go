@@ -5,9 +5,55 @@
 package repository
 
 import (
+	"database/sql/driver"
+	"fmt"
+
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BoardColumnCategory string
+
+const (
+	BoardColumnCategoryTodo       BoardColumnCategory = "todo"
+	BoardColumnCategoryInProgress BoardColumnCategory = "in_progress"
+	BoardColumnCategoryDone       BoardColumnCategory = "done"
+)
+
+func (e *BoardColumnCategory) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BoardColumnCategory(s)
+	case string:
+		*e = BoardColumnCategory(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BoardColumnCategory: %T", src)
+	}
+	return nil
+}
+
+type NullBoardColumnCategory struct {
+	BoardColumnCategory BoardColumnCategory `json:"board_column_category"`
+	Valid               bool                `json:"valid"` // Valid is true if BoardColumnCategory is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBoardColumnCategory) Scan(value interface{}) error {
+	if value == nil {
+		ns.BoardColumnCategory, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BoardColumnCategory.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBoardColumnCategory) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BoardColumnCategory), nil
+}
+
 type Board struct {
 	ID        pgtype.UUID        `db:"id" json:"id"`
 	SprintID  pgtype.UUID        `db:"sprint_id" json:"sprint_id"`
@@ -19,11 +65,15 @@ type Board struct {
 }
 
 type BoardColumn struct {
-	ID        pgtype.UUID        `db:"id" json:"id"`
-	BoardID   pgtype.UUID        `db:"board_id" json:"board_id"`
-	Name      string             `db:"name" json:"name"`
-	Position  int32              `db:"position" json:"position"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	DeletedAt pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	ID        pgtype.UUID         `db:"id" json:"id"`
+	BoardID   pgtype.UUID         `db:"board_id" json:"board_id"`
+	Name      string              `db:"name" json:"name"`
+	Position  int32               `db:"position" json:"position"`
+	CreatedAt pgtype.Timestamptz  `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz  `db:"updated_at" json:"updated_at"`
+	DeletedAt pgtype.Timestamptz  `db:"deleted_at" json:"deleted_at"`
+	Category  BoardColumnCategory `db:"category" json:"category"`
+	IsDefault bool                `db:"is_default" json:"is_default"`
+	Color     string              `db:"color" json:"color"`
+	WipLimit  pgtype.Int4         `db:"wip_limit" json:"wip_limit"`
 }
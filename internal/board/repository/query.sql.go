@@ -11,19 +11,78 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const compactBoardColumns = `-- name: CompactBoardColumns :exec
+UPDATE board_columns SET position = ranked.pos
+FROM (
+  SELECT id, (ROW_NUMBER() OVER (ORDER BY position, created_at) - 1) * 1024 AS pos
+  FROM board_columns
+  WHERE board_id = $1 AND deleted_at IS NULL
+) ranked
+WHERE board_columns.id = ranked.id AND board_columns.position <> ranked.pos
+`
+
+// Re-spaces a board's columns 1024 apart, keeping their order, once single moves have used up a gap
+func (q *Queries) CompactBoardColumns(ctx context.Context, boardID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, compactBoardColumns, boardID)
+	return err
+}
+
+const countTicketsByBoardColumns = `-- name: CountTicketsByBoardColumns :many
+SELECT
+  bc.id,
+  COUNT(t.id)::bigint AS ticket_count,
+  COUNT(t.id) FILTER (WHERE t.due_date < CURRENT_DATE AND bc.category <> 'done')::bigint AS overdue_count
+FROM
+  board_columns bc
+  LEFT JOIN tickets t ON t.board_column_id = bc.id AND t.deleted_at IS NULL
+WHERE
+  bc.id = ANY($1::uuid[])
+  AND bc.deleted_at IS NULL
+GROUP BY
+  bc.id
+`
+
+type CountTicketsByBoardColumnsRow struct {
+	ID           pgtype.UUID `db:"id" json:"id"`
+	TicketCount  int64       `db:"ticket_count" json:"ticket_count"`
+	OverdueCount int64       `db:"overdue_count" json:"overdue_count"`
+}
+
+// Aggregates ticket and overdue counts per column; columns without tickets report zero
+func (q *Queries) CountTicketsByBoardColumns(ctx context.Context, dollar_1 []pgtype.UUID) ([]CountTicketsByBoardColumnsRow, error) {
+	rows, err := q.db.Query(ctx, countTicketsByBoardColumns, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountTicketsByBoardColumnsRow{}
+	for rows.Next() {
+		var i CountTicketsByBoardColumnsRow
+		if err := rows.Scan(&i.ID, &i.TicketCount, &i.OverdueCount); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const createBoard = `-- name: CreateBoard :one
-INSERT INTO boards (sprint_id, name, position)
-VALUES ($1, $2, (SELECT COALESCE(MAX(position), -1) + 1 FROM boards WHERE sprint_id = $1 AND deleted_at IS NULL))
+INSERT INTO boards (sprint_id, name, position, id)
+VALUES ($1, $2, (SELECT COALESCE(MAX(position), -1) + 1 FROM boards WHERE sprint_id = $1 AND deleted_at IS NULL), $3)
 RETURNING id, sprint_id, name, position, created_at, updated_at, deleted_at
 `
 
 type CreateBoardParams struct {
 	SprintID pgtype.UUID `db:"sprint_id" json:"sprint_id"`
 	Name     string      `db:"name" json:"name"`
+	ID       pgtype.UUID `db:"id" json:"id"`
 }
 
 func (q *Queries) CreateBoard(ctx context.Context, arg CreateBoardParams) (Board, error) {
-	row := q.db.QueryRow(ctx, createBoard, arg.SprintID, arg.Name)
+	row := q.db.QueryRow(ctx, createBoard, arg.SprintID, arg.Name, arg.ID)
 	var i Board
 	err := row.Scan(
 		&i.ID,
@@ -38,18 +97,32 @@ func (q *Queries) CreateBoard(ctx context.Context, arg CreateBoardParams) (Board
 }
 
 const createBoardColumn = `-- name: CreateBoardColumn :one
-INSERT INTO board_columns (board_id, name, position)
-VALUES ($1, $2, (SELECT COALESCE(MAX(position), -1) + 1 FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL))
-RETURNING id, board_id, name, position, created_at, updated_at, deleted_at
+INSERT INTO board_columns (board_id, name, category, position, is_default, color, wip_limit, id)
+VALUES (
+  $1,
+  $2,
+  $3,
+  (SELECT COALESCE(MAX(position) + 1024, 0) FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL),
+  NOT EXISTS (SELECT 1 FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL),
+  $4,
+  $5,
+  $6
+)
+RETURNING id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit
 `
 
 type CreateBoardColumnParams struct {
-	BoardID pgtype.UUID `db:"board_id" json:"board_id"`
-	Name    string      `db:"name" json:"name"`
+	BoardID  pgtype.UUID         `db:"board_id" json:"board_id"`
+	Name     string              `db:"name" json:"name"`
+	Category BoardColumnCategory `db:"category" json:"category"`
+	Color    string              `db:"color" json:"color"`
+	WipLimit pgtype.Int4         `db:"wip_limit" json:"wip_limit"`
+	ID       pgtype.UUID         `db:"id" json:"id"`
 }
 
+// The first column of a board becomes its default
 func (q *Queries) CreateBoardColumn(ctx context.Context, arg CreateBoardColumnParams) (BoardColumn, error) {
-	row := q.db.QueryRow(ctx, createBoardColumn, arg.BoardID, arg.Name)
+	row := q.db.QueryRow(ctx, createBoardColumn, arg.BoardID, arg.Name, arg.Category, arg.Color, arg.WipLimit, arg.ID)
 	var i BoardColumn
 	err := row.Scan(
 		&i.ID,
@@ -59,12 +132,16 @@ func (q *Queries) CreateBoardColumn(ctx context.Context, arg CreateBoardColumnPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Category,
+		&i.IsDefault,
+		&i.Color,
+		&i.WipLimit,
 	)
 	return i, err
 }
 
 const deleteBoard = `-- name: DeleteBoard :one
-UPDATE boards SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING id, sprint_id, name, position, created_at, updated_at, deleted_at
+UPDATE boards SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING id, sprint_id, name, position, created_at, updated_at, deleted_at
 `
 
 func (q *Queries) DeleteBoard(ctx context.Context, id pgtype.UUID) (Board, error) {
@@ -83,12 +160,49 @@ func (q *Queries) DeleteBoard(ctx context.Context, id pgtype.UUID) (Board, error
 }
 
 const deleteBoardColumn = `-- name: DeleteBoardColumn :one
-UPDATE board_columns SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING id, board_id, name, position, created_at, updated_at, deleted_at
+WITH source AS (
+  SELECT id, board_id, is_default FROM board_columns
+  WHERE id = $1 AND deleted_at IS NULL
+  FOR UPDATE
+), deleted AS (
+  UPDATE board_columns SET deleted_at = NOW(), is_default = false
+  FROM source
+  WHERE board_columns.id = source.id
+    AND (NOT source.is_default OR EXISTS (
+      SELECT 1 FROM board_columns r
+      WHERE r.id = $2 AND r.board_id = source.board_id AND r.id <> source.id AND r.deleted_at IS NULL
+    ))
+  RETURNING board_columns.id, board_columns.board_id, board_columns.name, board_columns.position, board_columns.created_at, board_columns.updated_at, board_columns.deleted_at, board_columns.category, board_columns.is_default
+), promoted AS (
+  UPDATE board_columns SET is_default = true
+  FROM source, deleted
+  WHERE source.is_default AND board_columns.id = $2
+  RETURNING board_columns.id
+)
+SELECT id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default FROM deleted
 `
 
-func (q *Queries) DeleteBoardColumn(ctx context.Context, id pgtype.UUID) (BoardColumn, error) {
-	row := q.db.QueryRow(ctx, deleteBoardColumn, id)
-	var i BoardColumn
+type DeleteBoardColumnParams struct {
+	ID   pgtype.UUID `db:"id" json:"id"`
+	ID_2 pgtype.UUID `db:"id_2" json:"id_2"`
+}
+
+type DeleteBoardColumnRow struct {
+	ID        pgtype.UUID         `db:"id" json:"id"`
+	BoardID   pgtype.UUID         `db:"board_id" json:"board_id"`
+	Name      string              `db:"name" json:"name"`
+	Position  int32               `db:"position" json:"position"`
+	CreatedAt pgtype.Timestamptz  `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz  `db:"updated_at" json:"updated_at"`
+	DeletedAt pgtype.Timestamptz  `db:"deleted_at" json:"deleted_at"`
+	Category  BoardColumnCategory `db:"category" json:"category"`
+	IsDefault bool                `db:"is_default" json:"is_default"`
+}
+
+// Soft-deletes a column; the board's default is only deleted when $2 names a live replacement on the board, which takes the flag
+func (q *Queries) DeleteBoardColumn(ctx context.Context, arg DeleteBoardColumnParams) (DeleteBoardColumnRow, error) {
+	row := q.db.QueryRow(ctx, deleteBoardColumn, arg.ID, arg.ID_2)
+	var i DeleteBoardColumnRow
 	err := row.Scan(
 		&i.ID,
 		&i.BoardID,
@@ -97,6 +211,8 @@ func (q *Queries) DeleteBoardColumn(ctx context.Context, id pgtype.UUID) (BoardC
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Category,
+		&i.IsDefault,
 	)
 	return i, err
 }
@@ -121,7 +237,7 @@ func (q *Queries) GetBoard(ctx context.Context, id pgtype.UUID) (Board, error) {
 }
 
 const getBoardColumn = `-- name: GetBoardColumn :one
-SELECT id, board_id, name, position, created_at, updated_at, deleted_at FROM board_columns WHERE id = $1 AND deleted_at IS NULL
+SELECT id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit FROM board_columns WHERE id = $1 AND deleted_at IS NULL
 `
 
 func (q *Queries) GetBoardColumn(ctx context.Context, id pgtype.UUID) (BoardColumn, error) {
@@ -135,12 +251,62 @@ func (q *Queries) GetBoardColumn(ctx context.Context, id pgtype.UUID) (BoardColu
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Category,
+		&i.IsDefault,
+		&i.Color,
+		&i.WipLimit,
 	)
 	return i, err
 }
 
+const getBoardColumnTrashState = `-- name: GetBoardColumnTrashState :one
+-- Whether a column and what holds it are deleted, to explain a refused restore
+SELECT
+    bc.deleted_at IS NOT NULL AS deleted,
+    (b.deleted_at IS NOT NULL OR s.deleted_at IS NOT NULL OR p.deleted_at IS NOT NULL) AS parent_deleted
+FROM board_columns bc
+JOIN boards b ON b.id = bc.board_id
+JOIN sprints s ON s.id = b.sprint_id
+JOIN projects p ON p.id = s.project_id
+WHERE bc.id = $1 AND bc.board_id = $2
+`
+
+type GetBoardColumnTrashStateParams struct {
+	ID      pgtype.UUID `db:"id" json:"id"`
+	BoardID pgtype.UUID `db:"board_id" json:"board_id"`
+}
+
+type GetBoardColumnTrashStateRow struct {
+	Deleted       bool `db:"deleted" json:"deleted"`
+	ParentDeleted bool `db:"parent_deleted" json:"parent_deleted"`
+}
+
+// Whether a column and what holds it are deleted, to explain a refused restore
+func (q *Queries) GetBoardColumnTrashState(ctx context.Context, arg GetBoardColumnTrashStateParams) (GetBoardColumnTrashStateRow, error) {
+	row := q.db.QueryRow(ctx, getBoardColumnTrashState, arg.ID, arg.BoardID)
+	var i GetBoardColumnTrashStateRow
+	err := row.Scan(&i.Deleted, &i.ParentDeleted)
+	return i, err
+}
+
+const getBoardProject = `-- name: GetBoardProject :one
+-- The project a board belongs to through its sprint, deleted or not
+SELECT s.project_id
+FROM boards b
+JOIN sprints s ON s.id = b.sprint_id
+WHERE b.id = $1
+`
+
+// The project a board belongs to through its sprint, deleted or not
+func (q *Queries) GetBoardProject(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getBoardProject, id)
+	var project_id pgtype.UUID
+	err := row.Scan(&project_id)
+	return project_id, err
+}
+
 const listBoardColumns = `-- name: ListBoardColumns :many
-SELECT id, board_id, name, position, created_at, updated_at, deleted_at FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL ORDER BY position ASC
+SELECT id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL ORDER BY position ASC
 `
 
 func (q *Queries) ListBoardColumns(ctx context.Context, boardID pgtype.UUID) ([]BoardColumn, error) {
@@ -160,6 +326,10 @@ func (q *Queries) ListBoardColumns(ctx context.Context, boardID pgtype.UUID) ([]
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Category,
+			&i.IsDefault,
+			&i.Color,
+			&i.WipLimit,
 		); err != nil {
 			return nil, err
 		}
@@ -174,7 +344,7 @@ func (q *Queries) ListBoardColumns(ctx context.Context, boardID pgtype.UUID) ([]
 const listBoardColumnsPaged = `-- name: ListBoardColumnsPaged :many
 WITH filtered_columns AS (
   SELECT
-    id, board_id, name, position, created_at, updated_at, deleted_at,
+    id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit,
     COUNT(*) OVER () as total_count
   FROM
     board_columns
@@ -183,9 +353,17 @@ WITH filtered_columns AS (
     AND (array_length($1::uuid[], 1) IS NULL OR id = ANY($1::uuid[]))
     AND (array_length($2::uuid[], 1) IS NULL OR board_id = ANY($2::uuid[]))
     AND ($3::text = '' OR name ILIKE '%' || $3 || '%')
+    AND (array_length($6::text[], 1) IS NULL OR category::text = ANY($6::text[]))
+    AND ($7::uuid IS NULL OR EXISTS (
+      SELECT 1
+      FROM boards b
+      JOIN sprints s ON s.id = b.sprint_id
+      JOIN project_members pm ON pm.project_id = s.project_id
+      WHERE b.id = board_columns.board_id AND pm.user_id = $7::uuid
+    ))
 )
 SELECT
-  id, board_id, name, position, created_at, updated_at, deleted_at, total_count
+  id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit, total_count
 FROM
   filtered_columns
 ORDER BY
@@ -200,17 +378,23 @@ type ListBoardColumnsPagedParams struct {
 	Column3 string        `db:"column_3" json:"column_3"`
 	Limit   int32         `db:"limit" json:"limit"`
 	Offset  int32         `db:"offset" json:"offset"`
+	Column6 []string      `db:"column_6" json:"column_6"`
+	Column7 pgtype.UUID   `db:"column_7" json:"column_7"`
 }
 
 type ListBoardColumnsPagedRow struct {
-	ID         pgtype.UUID        `db:"id" json:"id"`
-	BoardID    pgtype.UUID        `db:"board_id" json:"board_id"`
-	Name       string             `db:"name" json:"name"`
-	Position   int32              `db:"position" json:"position"`
-	CreatedAt  pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt  pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	DeletedAt  pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
-	TotalCount int64              `db:"total_count" json:"total_count"`
+	ID         pgtype.UUID         `db:"id" json:"id"`
+	BoardID    pgtype.UUID         `db:"board_id" json:"board_id"`
+	Name       string              `db:"name" json:"name"`
+	Position   int32               `db:"position" json:"position"`
+	CreatedAt  pgtype.Timestamptz  `db:"created_at" json:"created_at"`
+	UpdatedAt  pgtype.Timestamptz  `db:"updated_at" json:"updated_at"`
+	DeletedAt  pgtype.Timestamptz  `db:"deleted_at" json:"deleted_at"`
+	Category   BoardColumnCategory `db:"category" json:"category"`
+	IsDefault  bool                `db:"is_default" json:"is_default"`
+	Color      string              `db:"color" json:"color"`
+	WipLimit   pgtype.Int4         `db:"wip_limit" json:"wip_limit"`
+	TotalCount int64               `db:"total_count" json:"total_count"`
 }
 
 func (q *Queries) ListBoardColumnsPaged(ctx context.Context, arg ListBoardColumnsPagedParams) ([]ListBoardColumnsPagedRow, error) {
@@ -220,6 +404,8 @@ func (q *Queries) ListBoardColumnsPaged(ctx context.Context, arg ListBoardColumn
 		arg.Column3,
 		arg.Limit,
 		arg.Offset,
+		arg.Column6,
+		arg.Column7,
 	)
 	if err != nil {
 		return nil, err
@@ -236,6 +422,10 @@ func (q *Queries) ListBoardColumnsPaged(ctx context.Context, arg ListBoardColumn
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Category,
+			&i.IsDefault,
+			&i.Color,
+			&i.WipLimit,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -292,6 +482,12 @@ WITH filtered_boards AS (
     AND (array_length($1::uuid[], 1) IS NULL OR id = ANY($1::uuid[]))
     AND (array_length($2::uuid[], 1) IS NULL OR sprint_id = ANY($2::uuid[]))
     AND ($3::text = '' OR name ILIKE '%' || $3 || '%')
+    AND ($6::uuid IS NULL OR EXISTS (
+      SELECT 1
+      FROM sprints s
+      JOIN project_members pm ON pm.project_id = s.project_id
+      WHERE s.id = boards.sprint_id AND pm.user_id = $6::uuid
+    ))
 )
 SELECT
   id, sprint_id, name, position, created_at, updated_at, deleted_at, total_count
@@ -309,6 +505,7 @@ type ListBoardsBySprintPagedParams struct {
 	Column3 string        `db:"column_3" json:"column_3"`
 	Limit   int32         `db:"limit" json:"limit"`
 	Offset  int32         `db:"offset" json:"offset"`
+	Column6 pgtype.UUID   `db:"column_6" json:"column_6"`
 }
 
 type ListBoardsBySprintPagedRow struct {
@@ -329,6 +526,7 @@ func (q *Queries) ListBoardsBySprintPaged(ctx context.Context, arg ListBoardsByS
 		arg.Column3,
 		arg.Limit,
 		arg.Offset,
+		arg.Column6,
 	)
 	if err != nil {
 		return nil, err
@@ -357,8 +555,101 @@ func (q *Queries) ListBoardsBySprintPaged(ctx context.Context, arg ListBoardsByS
 	return items, nil
 }
 
+const listBoardsWithCrowdedColumns = `-- name: ListBoardsWithCrowdedColumns :many
+SELECT DISTINCT board_id
+FROM (
+  SELECT board_id, position - LAG(position) OVER (PARTITION BY board_id ORDER BY position) AS gap
+  FROM board_columns
+  WHERE deleted_at IS NULL
+) gaps
+WHERE gap < 2
+`
+
+// Boards where two neighbouring columns share a position or sit one apart, so no midpoint is left
+func (q *Queries) ListBoardsWithCrowdedColumns(ctx context.Context) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, listBoardsWithCrowdedColumns)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var board_id pgtype.UUID
+		if err := rows.Scan(&board_id); err != nil {
+			return nil, err
+		}
+		items = append(items, board_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const mergeBoardColumn = `-- name: MergeBoardColumn :one
+WITH source AS (
+  SELECT id, board_id, position, is_default FROM board_columns
+  WHERE id = $1 AND deleted_at IS NULL
+  FOR UPDATE
+), target AS (
+  SELECT bc.id, COALESCE((SELECT MAX(t.rank) FROM tickets t WHERE t.board_column_id = bc.id AND t.deleted_at IS NULL), '') AS last_rank
+  FROM board_columns bc, source
+  WHERE bc.id = $2 AND bc.board_id = source.board_id AND bc.id <> source.id AND bc.deleted_at IS NULL
+  FOR UPDATE OF bc
+), moved AS (
+  UPDATE tickets SET board_column_id = target.id, rank = target.last_rank || lpad(ranked.rn::text, 6, '0') || 'i'
+  FROM target, (
+    SELECT t.id, ROW_NUMBER() OVER (ORDER BY t.rank, t.ticket_number DESC) AS rn
+    FROM tickets t, source
+    WHERE t.board_column_id = source.id AND t.deleted_at IS NULL
+  ) ranked
+  WHERE tickets.id = ranked.id
+  RETURNING tickets.id
+), merged AS (
+  UPDATE board_columns SET deleted_at = NOW(), is_default = false
+  FROM source, target
+  WHERE board_columns.id = source.id
+  RETURNING board_columns.id
+), compacted AS (
+  UPDATE board_columns
+  SET position = ranked.pos,
+      is_default = board_columns.is_default OR (board_columns.id = target.id AND source.is_default)
+  FROM source, target, (
+    SELECT bc.id, (ROW_NUMBER() OVER (ORDER BY bc.position, bc.created_at) - 1) * 1024 AS pos
+    FROM board_columns bc, source, target
+    WHERE bc.board_id = source.board_id AND bc.deleted_at IS NULL AND bc.id <> source.id
+  ) ranked
+  WHERE board_columns.id = ranked.id
+  RETURNING board_columns.id
+)
+SELECT
+  (SELECT COUNT(*) FROM merged) AS merged_count,
+  (SELECT COUNT(*) FROM moved) AS moved_count
+`
+
+type MergeBoardColumnParams struct {
+	ID   pgtype.UUID `db:"id" json:"id"`
+	ID_2 pgtype.UUID `db:"id_2" json:"id_2"`
+}
+
+type MergeBoardColumnRow struct {
+	MergedCount int64 `db:"merged_count" json:"merged_count"`
+	MovedCount  int64 `db:"moved_count" json:"moved_count"`
+}
+
+// Moves every ticket of the source column into the target, ranked after the target's own tickets,
+// soft-deletes the source and re-spaces the remaining positions; the target inherits the default
+// flag from the source. A single statement so the merge is atomic. Nothing changes unless both
+// columns are live and share a board
+func (q *Queries) MergeBoardColumn(ctx context.Context, arg MergeBoardColumnParams) (MergeBoardColumnRow, error) {
+	row := q.db.QueryRow(ctx, mergeBoardColumn, arg.ID, arg.ID_2)
+	var i MergeBoardColumnRow
+	err := row.Scan(&i.MergedCount, &i.MovedCount)
+	return i, err
+}
+
 const reorderBoardColumn = `-- name: ReorderBoardColumn :one
-UPDATE board_columns SET position = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING id, board_id, name, position, created_at, updated_at, deleted_at
+UPDATE board_columns SET position = $2 WHERE id = $1 AND deleted_at IS NULL RETURNING id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit
 `
 
 type ReorderBoardColumnParams struct {
@@ -377,6 +668,10 @@ func (q *Queries) ReorderBoardColumn(ctx context.Context, arg ReorderBoardColumn
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Category,
+		&i.IsDefault,
+		&i.Color,
+		&i.WipLimit,
 	)
 	return i, err
 }
@@ -384,7 +679,7 @@ func (q *Queries) ReorderBoardColumn(ctx context.Context, arg ReorderBoardColumn
 const reorderBoardColumnsInBatch = `-- name: ReorderBoardColumnsInBatch :many
 WITH validation AS (
   -- Validate: all provided IDs exist and belong to this board
-  SELECT id, ROW_NUMBER() OVER () - 1 as pos
+  SELECT id, (ROW_NUMBER() OVER () - 1) * 1024 as pos
   FROM UNNEST($2::uuid[]) AS t(id)
   WHERE EXISTS (
     SELECT 1 FROM board_columns bc
@@ -392,7 +687,7 @@ WITH validation AS (
   )
 ),updated AS (
   UPDATE board_columns
-  SET position = validation.pos, updated_at = NOW()
+  SET position = validation.pos
   FROM validation
   WHERE board_columns.id = validation.id
     AND board_columns.board_id = $1
@@ -402,13 +697,13 @@ WITH validation AS (
       SELECT COUNT(*) FROM board_columns
       WHERE board_id = $1 AND deleted_at IS NULL
     ) = array_length($2::uuid[], 1)
-    -- Validate: all array elements are valid columns for this board
+    -- Validate: all array elements are distinct, valid columns for this board
     AND (
-      SELECT COUNT(*) FROM validation
+      SELECT COUNT(DISTINCT id) FROM validation
     ) = array_length($2::uuid[], 1)
-  RETURNING board_columns.id, board_columns.board_id, board_columns.name, board_columns.position, board_columns.created_at, board_columns.updated_at, board_columns.deleted_at
+  RETURNING board_columns.id, board_columns.board_id, board_columns.name, board_columns.position, board_columns.created_at, board_columns.updated_at, board_columns.deleted_at, board_columns.category, board_columns.is_default, board_columns.color, board_columns.wip_limit
 )
-SELECT id, board_id, name, position, created_at, updated_at, deleted_at FROM updated ORDER BY position
+SELECT id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit FROM updated ORDER BY position
 `
 
 type ReorderBoardColumnsInBatchParams struct {
@@ -417,17 +712,21 @@ type ReorderBoardColumnsInBatchParams struct {
 }
 
 type ReorderBoardColumnsInBatchRow struct {
-	ID        pgtype.UUID        `db:"id" json:"id"`
-	BoardID   pgtype.UUID        `db:"board_id" json:"board_id"`
-	Name      string             `db:"name" json:"name"`
-	Position  int32              `db:"position" json:"position"`
-	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	DeletedAt pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	ID        pgtype.UUID         `db:"id" json:"id"`
+	BoardID   pgtype.UUID         `db:"board_id" json:"board_id"`
+	Name      string              `db:"name" json:"name"`
+	Position  int32               `db:"position" json:"position"`
+	CreatedAt pgtype.Timestamptz  `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz  `db:"updated_at" json:"updated_at"`
+	DeletedAt pgtype.Timestamptz  `db:"deleted_at" json:"deleted_at"`
+	Category  BoardColumnCategory `db:"category" json:"category"`
+	IsDefault bool                `db:"is_default" json:"is_default"`
+	Color     string              `db:"color" json:"color"`
+	WipLimit  pgtype.Int4         `db:"wip_limit" json:"wip_limit"`
 }
 
 // Atomically validates and reorders columns with row-level locking
-// Results ordered by position to maintain input array order
+// Results ordered by position to maintain input array order; positions are spaced 1024 apart
 func (q *Queries) ReorderBoardColumnsInBatch(ctx context.Context, arg ReorderBoardColumnsInBatchParams) ([]ReorderBoardColumnsInBatchRow, error) {
 	rows, err := q.db.Query(ctx, reorderBoardColumnsInBatch, arg.BoardID, arg.Column2)
 	if err != nil {
@@ -445,6 +744,10 @@ func (q *Queries) ReorderBoardColumnsInBatch(ctx context.Context, arg ReorderBoa
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Category,
+			&i.IsDefault,
+			&i.Color,
+			&i.WipLimit,
 		); err != nil {
 			return nil, err
 		}
@@ -467,7 +770,7 @@ WITH validation AS (
   )
 ),updated AS (
   UPDATE boards
-  SET position = validation.pos, updated_at = NOW()
+  SET position = validation.pos
   FROM validation
   WHERE boards.id = validation.id
     AND boards.sprint_id = $1
@@ -477,9 +780,9 @@ WITH validation AS (
       SELECT COUNT(*) FROM boards
       WHERE sprint_id = $1 AND deleted_at IS NULL
     ) = array_length($2::uuid[], 1)
-    -- Validate: all array elements are valid boards for this sprint
+    -- Validate: all array elements are distinct, valid boards for this sprint
     AND (
-      SELECT COUNT(*) FROM validation
+      SELECT COUNT(DISTINCT id) FROM validation
     ) = array_length($2::uuid[], 1)
   RETURNING boards.id, boards.sprint_id, boards.name, boards.position, boards.created_at, boards.updated_at, boards.deleted_at
 )
@@ -531,9 +834,79 @@ func (q *Queries) ReorderBoardsInBatch(ctx context.Context, arg ReorderBoardsInB
 	return items, nil
 }
 
+const restoreBoardColumn = `-- name: RestoreBoardColumn :one
+-- Clears deleted_at of a column whose board, sprint and project are still live; it becomes the default when the board has none
+UPDATE board_columns
+SET deleted_at = NULL,
+    is_default = NOT EXISTS (
+      SELECT 1 FROM board_columns d
+      WHERE d.board_id = board_columns.board_id AND d.is_default AND d.deleted_at IS NULL
+    )
+WHERE id = $1 AND board_id = $2 AND deleted_at IS NOT NULL
+  AND EXISTS (
+    SELECT 1 FROM boards b
+    JOIN sprints s ON s.id = b.sprint_id
+    JOIN projects p ON p.id = s.project_id
+    WHERE b.id = board_columns.board_id AND b.deleted_at IS NULL AND s.deleted_at IS NULL AND p.deleted_at IS NULL
+  )
+RETURNING id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit
+`
+
+type RestoreBoardColumnParams struct {
+	ID      pgtype.UUID `db:"id" json:"id"`
+	BoardID pgtype.UUID `db:"board_id" json:"board_id"`
+}
+
+// Clears deleted_at of a column whose board, sprint and project are still live; it becomes the default when the board has none
+func (q *Queries) RestoreBoardColumn(ctx context.Context, arg RestoreBoardColumnParams) (BoardColumn, error) {
+	row := q.db.QueryRow(ctx, restoreBoardColumn, arg.ID, arg.BoardID)
+	var i BoardColumn
+	err := row.Scan(
+		&i.ID,
+		&i.BoardID,
+		&i.Name,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Category,
+		&i.IsDefault,
+		&i.Color,
+		&i.WipLimit,
+	)
+	return i, err
+}
+
+const setDefaultBoardColumn = `-- name: SetDefaultBoardColumn :execrows
+UPDATE board_columns
+SET is_default = (id = $2)
+WHERE board_id = $1
+  AND deleted_at IS NULL
+  AND (is_default OR id = $2)
+  AND EXISTS (
+    SELECT 1 FROM board_columns bc
+    WHERE bc.id = $2 AND bc.board_id = $1 AND bc.deleted_at IS NULL
+  )
+`
+
+type SetDefaultBoardColumnParams struct {
+	BoardID pgtype.UUID `db:"board_id" json:"board_id"`
+	ID      pgtype.UUID `db:"id" json:"id"`
+}
+
+// Moves the board's default flag to the given column in one statement; the single default
+// constraint is deferred to the end of the statement so both rows can flip together
+func (q *Queries) SetDefaultBoardColumn(ctx context.Context, arg SetDefaultBoardColumnParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setDefaultBoardColumn, arg.BoardID, arg.ID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const updateBoard = `-- name: UpdateBoard :one
 UPDATE boards
-SET name = $2, sprint_id = $3, updated_at = NOW()
+SET name = $2, sprint_id = $3
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING id, sprint_id, name, position, created_at, updated_at, deleted_at
 `
@@ -560,16 +933,19 @@ func (q *Queries) UpdateBoard(ctx context.Context, arg UpdateBoardParams) (Board
 }
 
 const updateBoardColumn = `-- name: UpdateBoardColumn :one
-UPDATE board_columns SET name = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING id, board_id, name, position, created_at, updated_at, deleted_at
+UPDATE board_columns SET name = $2, category = $3, color = $4, wip_limit = $5 WHERE id = $1 AND deleted_at IS NULL RETURNING id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit
 `
 
 type UpdateBoardColumnParams struct {
-	ID   pgtype.UUID `db:"id" json:"id"`
-	Name string      `db:"name" json:"name"`
+	ID       pgtype.UUID         `db:"id" json:"id"`
+	Name     string              `db:"name" json:"name"`
+	Category BoardColumnCategory `db:"category" json:"category"`
+	Color    string              `db:"color" json:"color"`
+	WipLimit pgtype.Int4         `db:"wip_limit" json:"wip_limit"`
 }
 
 func (q *Queries) UpdateBoardColumn(ctx context.Context, arg UpdateBoardColumnParams) (BoardColumn, error) {
-	row := q.db.QueryRow(ctx, updateBoardColumn, arg.ID, arg.Name)
+	row := q.db.QueryRow(ctx, updateBoardColumn, arg.ID, arg.Name, arg.Category, arg.Color, arg.WipLimit)
 	var i BoardColumn
 	err := row.Scan(
 		&i.ID,
@@ -579,6 +955,10 @@ func (q *Queries) UpdateBoardColumn(ctx context.Context, arg UpdateBoardColumnPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Category,
+		&i.IsDefault,
+		&i.Color,
+		&i.WipLimit,
 	)
 	return i, err
 }
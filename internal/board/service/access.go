@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// authorizeBoard checks the caller's role in the project of a board, deleted
+// or not. A board in a project the caller cannot see is reported as not found.
+func (s *Service) authorizeBoard(ctx context.Context, boardID pgtype.UUID, role domain.ProjectRole) error {
+	projectID, err := s.Repo.GetBoardProject(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBoardNotFound
+		}
+		return fmt.Errorf("get board project: %w", err)
+	}
+
+	err = s.Project.AuthorizeProject(ctx, projectID, role)
+	var derr *domain.Error
+	if errors.As(err, &derr) && derr.Kind == domain.KindNotFound {
+		return ErrBoardNotFound
+	}
+	return err
+}
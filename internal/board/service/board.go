@@ -9,15 +9,18 @@ import (
 	"github.com/dimasbaguspm/fluxis/internal/board/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 	"github.com/dimasbaguspm/fluxis/pkg/syncx"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
-	ErrBoardNotFound = httpx.NotFound("board not found")
+	ErrBoardNotFound = domain.NotFound("board not found")
 )
 
 func toBoardModel(board repository.Board) domain.BoardModel {
@@ -28,6 +31,7 @@ func toBoardModel(board repository.Board) domain.BoardModel {
 		Position:  board.Position,
 		CreatedAt: board.CreatedAt.Time,
 		UpdatedAt: board.UpdatedAt.Time,
+		DeletedAt: transformer.TimePtr(board.DeletedAt),
 	}
 }
 
@@ -36,8 +40,12 @@ func (s *Service) CreateBoard(ctx context.Context, b domain.BoardCreateModel) (d
 	if err != nil {
 		return domain.BoardModel{}, fmt.Errorf("get sprint: %w", err)
 	}
+	if err := s.Project.AuthorizeProject(ctx, sprint.ProjectID, domain.ProjectRoleMember); err != nil {
+		return domain.BoardModel{}, err
+	}
 
 	board, err := s.Repo.CreateBoard(ctx, repository.CreateBoardParams{
+		ID:       idgen.New(),
 		SprintID: sprint.ID,
 		Name:     b.Name,
 	})
@@ -54,6 +62,10 @@ func (s *Service) CreateBoard(ctx context.Context, b domain.BoardCreateModel) (d
 }
 
 func (s *Service) GetBoard(ctx context.Context, id pgtype.UUID) (domain.BoardModel, error) {
+	if err := s.authorizeBoard(ctx, id, domain.ProjectRoleViewer); err != nil {
+		return domain.BoardModel{}, err
+	}
+
 	board, err := s.Repo.GetBoard(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -68,51 +80,42 @@ func (s *Service) GetBoard(ctx context.Context, id pgtype.UUID) (domain.BoardMod
 func (s *Service) ListBoards(ctx context.Context, q domain.BoardsSearchModel) (domain.BoardsPagedModel, error) {
 	q.ApplyDefaults()
 
-	offset := int32((q.PageNumber - 1) * q.PageSize)
 	rows, err := s.Repo.ListBoardsBySprintPaged(ctx, repository.ListBoardsBySprintPagedParams{
 		Column1: q.ID,
 		Column2: q.SprintID,
 		Column3: q.Name,
 		Limit:   int32(q.PageSize),
-		Offset:  offset,
+		Offset:  pagination.Offset(q.PageNumber, q.PageSize),
+		Column6: httpx.MemberFilter(ctx),
 	})
 
 	if err != nil {
 		return domain.BoardsPagedModel{}, fmt.Errorf("list boards: %w", err)
 	}
 
-	if len(rows) == 0 {
-		return domain.BoardsPagedModel{}.Empty(q.PageNumber, q.PageSize), nil
-	}
-
-	totalCount := int(rows[0].TotalCount)
-	totalPages := (totalCount + q.PageSize - 1) / q.PageSize
-	if totalPages == 0 {
-		totalPages = 1
-	}
-
-	items := make([]domain.BoardModel, len(rows))
-	for i, row := range rows {
-		items[i] = domain.BoardModel{
-			ID:        row.ID,
-			SprintID:  row.SprintID,
-			Name:      row.Name,
-			Position:  row.Position,
-			CreatedAt: row.CreatedAt.Time,
-			UpdatedAt: row.UpdatedAt.Time,
-		}
-	}
+	page := pagination.FromRows(rows,
+		func(row repository.ListBoardsBySprintPagedRow) int64 { return row.TotalCount },
+		func(row repository.ListBoardsBySprintPagedRow) domain.BoardModel {
+			return domain.BoardModel{
+				ID:        row.ID,
+				SprintID:  row.SprintID,
+				Name:      row.Name,
+				Position:  row.Position,
+				CreatedAt: row.CreatedAt.Time,
+				UpdatedAt: row.UpdatedAt.Time,
+				DeletedAt: transformer.TimePtr(row.DeletedAt),
+			}
+		},
+		q.PageNumber, q.PageSize)
 
-	return domain.BoardsPagedModel{
-		Items:      items,
-		TotalCount: totalCount,
-		TotalPages: totalPages,
-		PageNumber: q.PageNumber,
-		PageSize:   q.PageSize,
-	}, nil
+	return domain.BoardsPagedModel(page), nil
 }
 
 func (s *Service) UpdateBoard(ctx context.Context, id pgtype.UUID, b domain.BoardUpdateModel) (domain.BoardModel, error) {
+	if err := s.authorizeBoard(ctx, id, domain.ProjectRoleMember); err != nil {
+		return domain.BoardModel{}, err
+	}
+
 	var existing domain.BoardModel
 	var sprint domain.SprintModel
 
@@ -127,11 +130,15 @@ func (s *Service) UpdateBoard(ctx context.Context, id pgtype.UUID, b domain.Boar
 		},
 		func(ctx context.Context) error {
 			if b.SprintID.Valid {
-				s, err := s.Sprint.GetSprint(ctx, b.SprintID)
+				sp, err := s.Sprint.GetSprint(ctx, b.SprintID)
 				if err != nil {
 					return fmt.Errorf("validate sprint: %w", err)
 				}
-				sprint = s
+				// moving a board needs the member role on both sides
+				if err := s.Project.AuthorizeProject(ctx, sp.ProjectID, domain.ProjectRoleMember); err != nil {
+					return err
+				}
+				sprint = sp
 			}
 			return nil
 		},
@@ -175,6 +182,9 @@ func (s *Service) ReorderBoards(ctx context.Context, sprintID pgtype.UUID, reord
 	if err != nil {
 		return nil, fmt.Errorf("validate sprint: %w", err)
 	}
+	if err := s.Project.AuthorizeProject(ctx, sprint.ProjectID, domain.ProjectRoleMember); err != nil {
+		return nil, err
+	}
 
 	boards, err := s.Repo.ReorderBoardsInBatch(ctx, repository.ReorderBoardsInBatchParams{
 		SprintID: sprint.ID,
@@ -186,13 +196,15 @@ func (s *Service) ReorderBoards(ctx context.Context, sprintID pgtype.UUID, reord
 
 	if len(boards) == 0 {
 		if len(reorder) == 0 {
-			return nil, httpx.BadRequest("boards array is required and cannot be empty")
+			return nil, domain.Invalid("boards array is required and cannot be empty")
 		}
-		return nil, httpx.BadRequest("some boards not found or don't belong to this sprint, or reorder array must include all boards in the sprint")
+		return nil, domain.Invalid("some boards not found or don't belong to this sprint, or reorder array must include all boards in the sprint")
 	}
 
 	result := make([]domain.BoardModel, 0, len(boards))
+	ids := make([]pgtype.UUID, 0, len(boards))
 	for _, board := range boards {
+		ids = append(ids, board.ID)
 		result = append(result, domain.BoardModel{
 			ID:        board.ID,
 			SprintID:  board.SprintID,
@@ -200,10 +212,11 @@ func (s *Service) ReorderBoards(ctx context.Context, sprintID pgtype.UUID, reord
 			Position:  board.Position,
 			CreatedAt: board.CreatedAt.Time,
 			UpdatedAt: board.UpdatedAt.Time,
+			DeletedAt: transformer.TimePtr(board.DeletedAt),
 		})
 	}
 
-	if err := s.Bus.Publish(ctx, pubsub.BoardReordered, map[string]string{"sprintId": uuid.UUID(sprintID.Bytes).String()}); err != nil {
+	if err := s.Bus.Publish(ctx, pubsub.BoardReordered, reorderPayload(ctx, sprint.ProjectID, sprint.ID, ids)); err != nil {
 		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.BoardReordered), "error", err)
 	}
 
@@ -211,6 +224,10 @@ func (s *Service) ReorderBoards(ctx context.Context, sprintID pgtype.UUID, reord
 }
 
 func (s *Service) DeleteBoard(ctx context.Context, id pgtype.UUID) error {
+	if err := s.authorizeBoard(ctx, id, domain.ProjectRoleMember); err != nil {
+		return err
+	}
+
 	_, err := s.Repo.DeleteBoard(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
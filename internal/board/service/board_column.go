@@ -1,6 +1,7 @@
 package service
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -9,87 +10,127 @@ import (
 	"github.com/dimasbaguspm/fluxis/internal/board/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+var (
+	ErrInvalidColumnCategory = domain.Invalid("category must be one of todo, in_progress, done").WithCode("invalid_category")
+	ErrMergeIntoSelf         = domain.Invalid("a column cannot be merged into itself").WithCode("merge_into_self")
+	ErrBoardColumnNotFound   = domain.NotFound("board column not found")
+	ErrDefaultNeedsReplace   = domain.Conflict("the default column can only be deleted with a replacementId").WithCode("default_column")
+	ErrReplaceWithSelf       = domain.Invalid("a column cannot replace itself").WithCode("replace_with_self")
+)
+
 func (s *Service) GetBoardColumn(ctx context.Context, id pgtype.UUID) (domain.BoardColumnModel, error) {
 	col, err := s.Repo.GetBoardColumn(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.BoardColumnModel{}, httpx.NotFound("board column not found")
+			return domain.BoardColumnModel{}, ErrBoardColumnNotFound
 		}
 		return domain.BoardColumnModel{}, fmt.Errorf("get board column: %w", err)
 	}
+	if err := s.authorizeBoard(ctx, col.BoardID, domain.ProjectRoleViewer); err != nil {
+		if errors.Is(err, ErrBoardNotFound) {
+			return domain.BoardColumnModel{}, ErrBoardColumnNotFound
+		}
+		return domain.BoardColumnModel{}, err
+	}
 
 	return domain.BoardColumnModel{
 		ID:        col.ID,
 		BoardID:   col.BoardID,
 		Name:      col.Name,
 		Position:  col.Position,
+		Category:  string(col.Category),
+		IsDefault: col.IsDefault,
+		Color:     col.Color,
+		WIPLimit:  transformer.Int32Ptr(col.WipLimit),
 		CreatedAt: col.CreatedAt.Time,
 		UpdatedAt: col.UpdatedAt.Time,
+		DeletedAt: transformer.TimePtr(col.DeletedAt),
 	}, nil
 }
 
 func (s *Service) ListBoardColumns(ctx context.Context, q domain.BoardColumnsSearchModel) (domain.BoardColumnsPagedModel, error) {
 	q.ApplyDefaults()
 
-	offset := int32((q.PageNumber - 1) * q.PageSize)
+	for _, category := range q.Category {
+		switch repository.BoardColumnCategory(category) {
+		case repository.BoardColumnCategoryTodo, repository.BoardColumnCategoryInProgress, repository.BoardColumnCategoryDone:
+		default:
+			return domain.BoardColumnsPagedModel{}, ErrInvalidColumnCategory
+		}
+	}
+
 	rows, err := s.Repo.ListBoardColumnsPaged(ctx, repository.ListBoardColumnsPagedParams{
 		Column1: q.ID,
 		Column2: q.BoardID,
 		Column3: q.Name,
 		Limit:   int32(q.PageSize),
-		Offset:  offset,
+		Offset:  pagination.Offset(q.PageNumber, q.PageSize),
+		Column6: q.Category,
+		Column7: httpx.MemberFilter(ctx),
 	})
 
 	if err != nil {
 		return domain.BoardColumnsPagedModel{}, fmt.Errorf("list board columns: %w", err)
 	}
 
-	if len(rows) == 0 {
-		return domain.BoardColumnsPagedModel{}.Empty(q.PageNumber, q.PageSize), nil
-	}
-
-	totalCount := int(rows[0].TotalCount)
-	totalPages := (totalCount + q.PageSize - 1) / q.PageSize
-	if totalPages == 0 {
-		totalPages = 1
-	}
-
-	items := make([]domain.BoardColumnModel, len(rows))
-	for i, row := range rows {
-		items[i] = domain.BoardColumnModel{
-			ID:        row.ID,
-			BoardID:   row.BoardID,
-			Name:      row.Name,
-			Position:  row.Position,
-			CreatedAt: row.CreatedAt.Time,
-			UpdatedAt: row.UpdatedAt.Time,
+	page := pagination.FromRows(rows,
+		func(row repository.ListBoardColumnsPagedRow) int64 { return row.TotalCount },
+		func(row repository.ListBoardColumnsPagedRow) domain.BoardColumnModel {
+			return domain.BoardColumnModel{
+				ID:        row.ID,
+				BoardID:   row.BoardID,
+				Name:      row.Name,
+				Position:  row.Position,
+				Category:  string(row.Category),
+				IsDefault: row.IsDefault,
+				Color:     row.Color,
+				WIPLimit:  transformer.Int32Ptr(row.WipLimit),
+				CreatedAt: row.CreatedAt.Time,
+				UpdatedAt: row.UpdatedAt.Time,
+				DeletedAt: transformer.TimePtr(row.DeletedAt),
+			}
+		},
+		q.PageNumber, q.PageSize)
+
+	if q.IncludeCounts && len(page.Items) > 0 {
+		if err := s.attachColumnCounts(ctx, page.Items); err != nil {
+			return domain.BoardColumnsPagedModel{}, err
 		}
 	}
 
-	return domain.BoardColumnsPagedModel{
-		Items:      items,
-		TotalCount: totalCount,
-		TotalPages: totalPages,
-		PageNumber: q.PageNumber,
-		PageSize:   q.PageSize,
-	}, nil
+	return domain.BoardColumnsPagedModel(page), nil
 }
 
 func (s *Service) CreateBoardColumn(ctx context.Context, boardID pgtype.UUID, b domain.BoardColumnCreateModel) (domain.BoardColumnModel, error) {
 	if _, err := s.GetBoard(ctx, boardID); err != nil {
 		return domain.BoardColumnModel{}, fmt.Errorf("validate board: %w", err)
 	}
+	if err := s.authorizeBoard(ctx, boardID, domain.ProjectRoleMember); err != nil {
+		return domain.BoardColumnModel{}, err
+	}
 
-	col, err := s.Repo.CreateBoardColumn(ctx, repository.CreateBoardColumnParams{
-		BoardID: boardID,
-		Name:    b.Name,
-	})
+	params := repository.CreateBoardColumnParams{
+		ID:       idgen.New(),
+		BoardID:  boardID,
+		Name:     b.Name,
+		Category: columnCategoryOrDefault(b.Category, repository.BoardColumnCategoryTodo),
+		Color:    b.Color,
+		WipLimit: wipLimit(b.WIPLimit),
+	}
+	col, err := s.Repo.CreateBoardColumn(ctx, params)
+	// a concurrent create took the default first, the retry sees it and adds a regular column
+	if isDefaultConflict(err) {
+		col, err = s.Repo.CreateBoardColumn(ctx, params)
+	}
 	if err != nil {
 		return domain.BoardColumnModel{}, fmt.Errorf("create board column: %w", err)
 	}
@@ -99,8 +140,13 @@ func (s *Service) CreateBoardColumn(ctx context.Context, boardID pgtype.UUID, b
 		BoardID:   col.BoardID,
 		Name:      col.Name,
 		Position:  col.Position,
+		Category:  string(col.Category),
+		IsDefault: col.IsDefault,
+		Color:     col.Color,
+		WIPLimit:  transformer.Int32Ptr(col.WipLimit),
 		CreatedAt: col.CreatedAt.Time,
 		UpdatedAt: col.UpdatedAt.Time,
+		DeletedAt: transformer.TimePtr(col.DeletedAt),
 	}
 
 	if err := s.Bus.Publish(ctx, pubsub.BoardColumnCreated, httpx.EncodePayload(result)); err != nil {
@@ -111,18 +157,35 @@ func (s *Service) CreateBoardColumn(ctx context.Context, boardID pgtype.UUID, b
 }
 
 func (s *Service) UpdateBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID, b domain.BoardColumnUpdateModel) (domain.BoardColumnModel, error) {
+	if err := s.authorizeBoard(ctx, boardID, domain.ProjectRoleMember); err != nil {
+		return domain.BoardColumnModel{}, err
+	}
+
 	col, err := s.GetBoardColumn(ctx, columnID)
 	if err != nil {
 		return domain.BoardColumnModel{}, err
 	}
 
 	if col.BoardID != boardID {
-		return domain.BoardColumnModel{}, httpx.NotFound("board column not found in this board")
+		return domain.BoardColumnModel{}, domain.NotFound("board column not found in this board")
+	}
+
+	name := b.Name
+	if name == "" {
+		name = col.Name
+	}
+
+	limit := col.WIPLimit
+	if b.WIPLimit != nil {
+		limit = b.WIPLimit
 	}
 
 	colUpdated, err := s.Repo.UpdateBoardColumn(ctx, repository.UpdateBoardColumnParams{
-		ID:   columnID,
-		Name: b.Name,
+		ID:       columnID,
+		Name:     name,
+		Category: columnCategoryOrDefault(b.Category, repository.BoardColumnCategory(col.Category)),
+		Color:    cmp.Or(b.Color, col.Color),
+		WipLimit: wipLimit(limit),
 	})
 	if err != nil {
 		return domain.BoardColumnModel{}, fmt.Errorf("update board column: %w", err)
@@ -133,8 +196,13 @@ func (s *Service) UpdateBoardColumn(ctx context.Context, boardID, columnID pgtyp
 		BoardID:   colUpdated.BoardID,
 		Name:      colUpdated.Name,
 		Position:  colUpdated.Position,
+		Category:  string(colUpdated.Category),
+		IsDefault: colUpdated.IsDefault,
+		Color:     colUpdated.Color,
+		WIPLimit:  transformer.Int32Ptr(colUpdated.WipLimit),
 		CreatedAt: colUpdated.CreatedAt.Time,
 		UpdatedAt: colUpdated.UpdatedAt.Time,
+		DeletedAt: transformer.TimePtr(colUpdated.DeletedAt),
 	}
 
 	if err := s.Bus.Publish(ctx, pubsub.BoardColumnUpdated, httpx.EncodePayload(result)); err != nil {
@@ -145,8 +213,12 @@ func (s *Service) UpdateBoardColumn(ctx context.Context, boardID, columnID pgtyp
 }
 
 func (s *Service) ReorderBoardColumns(ctx context.Context, boardID pgtype.UUID, reorder domain.BoardColumnReorderModel) ([]domain.BoardColumnModel, error) {
-	if _, err := s.GetBoard(ctx, boardID); err != nil {
-		return nil, fmt.Errorf("validate board: %w", err)
+	projectID, err := s.boardProject(ctx, boardID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authorizeBoard(ctx, boardID, domain.ProjectRoleMember); err != nil {
+		return nil, err
 	}
 
 	cols, err := s.Repo.ReorderBoardColumnsInBatch(ctx, repository.ReorderBoardColumnsInBatchParams{
@@ -159,45 +231,82 @@ func (s *Service) ReorderBoardColumns(ctx context.Context, boardID pgtype.UUID,
 
 	if len(cols) == 0 {
 		if len(reorder) == 0 {
-			return nil, httpx.BadRequest("columns array is required and cannot be empty")
+			return nil, domain.Invalid("columns array is required and cannot be empty")
 		}
-		return nil, httpx.BadRequest("some board columns not found or don't belong to this board, or reorder array must include all board columns")
+		return nil, domain.Invalid("some board columns not found or don't belong to this board, or reorder array must include all board columns")
 	}
 
 	result := make([]domain.BoardColumnModel, 0, len(cols))
+	ids := make([]pgtype.UUID, 0, len(cols))
 	for _, col := range cols {
+		ids = append(ids, col.ID)
 		result = append(result, domain.BoardColumnModel{
 			ID:        col.ID,
 			BoardID:   col.BoardID,
 			Name:      col.Name,
 			Position:  col.Position,
+			Category:  string(col.Category),
+			IsDefault: col.IsDefault,
+			Color:     col.Color,
+			WIPLimit:  transformer.Int32Ptr(col.WipLimit),
 			CreatedAt: col.CreatedAt.Time,
 			UpdatedAt: col.UpdatedAt.Time,
+			DeletedAt: transformer.TimePtr(col.DeletedAt),
 		})
 	}
 
-	// For reorder operations, publish the boardId to identify scope
-	// since we're returning a list and not a single entity
-	reorderPayload := map[string]string{"boardId": uuid.UUID(boardID.Bytes).String()}
-	if err := s.Bus.Publish(ctx, pubsub.BoardColumnReordered, reorderPayload); err != nil {
+	// a reorder returns a list rather than a single entity, so the event
+	// carries the board and every column in it
+	if err := s.Bus.Publish(ctx, pubsub.BoardColumnReordered, reorderPayload(ctx, projectID, boardID, ids)); err != nil {
 		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.BoardColumnReordered), "error", err)
 	}
 
 	return result, nil
 }
 
-func (s *Service) DeleteBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID) error {
+// DeleteBoardColumn soft-deletes a column. The board's default column is
+// refused unless replacementID names another column of the board, which
+// becomes the default; for any other column the replacement is ignored.
+func (s *Service) DeleteBoardColumn(ctx context.Context, boardID, columnID, replacementID pgtype.UUID) error {
+	if err := s.authorizeBoard(ctx, boardID, domain.ProjectRoleMember); err != nil {
+		return err
+	}
+
 	col, err := s.GetBoardColumn(ctx, columnID)
 	if err != nil {
 		return err
 	}
 
 	if col.BoardID != boardID {
-		return httpx.NotFound("board column not found in this board")
+		return domain.NotFound("board column not found in this board")
+	}
+
+	if col.IsDefault {
+		if !replacementID.Valid {
+			return ErrDefaultNeedsReplace
+		}
+		if replacementID == columnID {
+			return ErrReplaceWithSelf
+		}
+		replacement, err := s.GetBoardColumn(ctx, replacementID)
+		if err != nil {
+			return err
+		}
+		if replacement.BoardID != boardID {
+			return domain.NotFound("replacement column not found in this board")
+		}
 	}
 
-	_, err = s.Repo.DeleteBoardColumn(ctx, columnID)
+	_, err = s.Repo.DeleteBoardColumn(ctx, repository.DeleteBoardColumnParams{
+		ID:   columnID,
+		ID_2: replacementID,
+	})
 	if err != nil {
+		// the column went away, or became the default, or its replacement
+		// went away since the checks above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBoardColumnNotFound
+		}
 		return fmt.Errorf("delete board column: %w", err)
 	}
 
@@ -207,3 +316,95 @@ func (s *Service) DeleteBoardColumn(ctx context.Context, boardID, columnID pgtyp
 
 	return nil
 }
+
+// MergeBoardColumn moves every ticket from the source column into the target,
+// then soft-deletes the source and compacts the remaining positions
+func (s *Service) MergeBoardColumn(ctx context.Context, boardID, columnID, targetID pgtype.UUID) (domain.BoardColumnMergeModel, error) {
+	if columnID == targetID {
+		return domain.BoardColumnMergeModel{}, ErrMergeIntoSelf
+	}
+	if err := s.authorizeBoard(ctx, boardID, domain.ProjectRoleMember); err != nil {
+		return domain.BoardColumnMergeModel{}, err
+	}
+
+	for _, id := range []pgtype.UUID{columnID, targetID} {
+		col, err := s.GetBoardColumn(ctx, id)
+		if err != nil {
+			return domain.BoardColumnMergeModel{}, err
+		}
+		if col.BoardID != boardID {
+			return domain.BoardColumnMergeModel{}, domain.NotFound("board column not found in this board")
+		}
+	}
+
+	row, err := s.Repo.MergeBoardColumn(ctx, repository.MergeBoardColumnParams{
+		ID:   columnID,
+		ID_2: targetID,
+	})
+	if err != nil {
+		return domain.BoardColumnMergeModel{}, fmt.Errorf("merge board column: %w", err)
+	}
+
+	// either column was deleted between the checks above and the merge
+	if row.MergedCount == 0 {
+		return domain.BoardColumnMergeModel{}, ErrBoardColumnNotFound
+	}
+
+	target, err := s.GetBoardColumn(ctx, targetID)
+	if err != nil {
+		return domain.BoardColumnMergeModel{}, err
+	}
+
+	if err := s.Bus.Publish(ctx, pubsub.BoardColumnMerged, map[string]string{
+		"id":       uuid.UUID(columnID.Bytes).String(),
+		"targetId": uuid.UUID(targetID.Bytes).String(),
+		"boardId":  uuid.UUID(boardID.Bytes).String(),
+	}); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.BoardColumnMerged), "error", err)
+	}
+
+	return domain.BoardColumnMergeModel{
+		Target:       target,
+		MovedTickets: row.MovedCount,
+	}, nil
+}
+
+func columnCategoryOrDefault(category string, fallback repository.BoardColumnCategory) repository.BoardColumnCategory {
+	if category == "" {
+		return fallback
+	}
+	return repository.BoardColumnCategory(category)
+}
+
+// wipLimit stores a missing or zero limit as NULL, an unlimited column
+func wipLimit(limit *int32) pgtype.Int4 {
+	if limit == nil || *limit == 0 {
+		return pgtype.Int4{}
+	}
+	return pgtype.Int4{Int32: *limit, Valid: true}
+}
+
+func (s *Service) attachColumnCounts(ctx context.Context, items []domain.BoardColumnModel) error {
+	ids := make([]pgtype.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+
+	counts, err := s.Repo.CountTicketsByBoardColumns(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("count tickets by board columns: %w", err)
+	}
+
+	byColumn := make(map[pgtype.UUID]repository.CountTicketsByBoardColumnsRow, len(counts))
+	for _, c := range counts {
+		byColumn[c.ID] = c
+	}
+
+	for i := range items {
+		c := byColumn[items[i].ID]
+		items[i].TicketCount = &c.TicketCount
+		items[i].OverdueCount = &c.OverdueCount
+	}
+
+	return nil
+}
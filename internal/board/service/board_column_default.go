@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/board/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// singleDefaultConstraint allows one live default column per board, see the
+// board column default migration
+const singleDefaultConstraint = "board_columns_single_default"
+
+var ErrDefaultConflict = domain.Conflict("the default column was changed by another request, try again").WithCode("default_column_conflict")
+
+// SetDefaultBoardColumn makes columnID the default of its board. The previous
+// default is cleared by the same statement, so a board never ends up with zero
+// or two defaults even when two switches race; the loser gets a 409.
+func (s *Service) SetDefaultBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID) (domain.BoardColumnModel, error) {
+	if err := s.authorizeBoard(ctx, boardID, domain.ProjectRoleMember); err != nil {
+		return domain.BoardColumnModel{}, err
+	}
+
+	n, err := s.Repo.SetDefaultBoardColumn(ctx, repository.SetDefaultBoardColumnParams{
+		BoardID: boardID,
+		ID:      columnID,
+	})
+	if err != nil {
+		if isDefaultConflict(err) {
+			return domain.BoardColumnModel{}, ErrDefaultConflict
+		}
+		return domain.BoardColumnModel{}, fmt.Errorf("set default board column: %w", err)
+	}
+	if n == 0 {
+		return domain.BoardColumnModel{}, domain.NotFound("board column not found in this board")
+	}
+
+	result, err := s.GetBoardColumn(ctx, columnID)
+	if err != nil {
+		return domain.BoardColumnModel{}, err
+	}
+
+	if err := s.Bus.Publish(ctx, pubsub.BoardColumnUpdated, httpx.EncodePayload(result)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.BoardColumnUpdated), "error", err)
+	}
+
+	return result, nil
+}
+
+func isDefaultConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23P01" && pgErr.ConstraintName == singleDefaultConstraint
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/board/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// columnPositionStep is the gap left between neighbouring columns, it has to
+// match the spacing used by the column queries
+const columnPositionStep = 1024
+
+var ErrMoveAfterSelf = domain.Invalid("a column cannot be placed after itself").WithCode("move_after_self")
+
+// MoveBoardColumn places a single column right after p.AfterID, or first when
+// no anchor is given. Only the moved row is written unless the gap between the
+// new neighbours is used up, in which case the board is re-spaced first.
+func (s *Service) MoveBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID, p domain.BoardColumnPositionModel) (domain.BoardColumnModel, error) {
+	if p.AfterID.Valid && p.AfterID == columnID {
+		return domain.BoardColumnModel{}, ErrMoveAfterSelf
+	}
+
+	projectID, err := s.boardProject(ctx, boardID)
+	if err != nil {
+		return domain.BoardColumnModel{}, err
+	}
+	if err := s.authorizeBoard(ctx, boardID, domain.ProjectRoleMember); err != nil {
+		return domain.BoardColumnModel{}, err
+	}
+
+	position, err := s.columnSlot(ctx, boardID, columnID, p.AfterID)
+	if err != nil {
+		return domain.BoardColumnModel{}, err
+	}
+
+	col, err := s.Repo.ReorderBoardColumn(ctx, repository.ReorderBoardColumnParams{
+		ID:       columnID,
+		Position: position,
+	})
+	if err != nil {
+		return domain.BoardColumnModel{}, fmt.Errorf("move board column: %w", err)
+	}
+
+	result := domain.BoardColumnModel{
+		ID:        col.ID,
+		BoardID:   col.BoardID,
+		Name:      col.Name,
+		Position:  col.Position,
+		Category:  string(col.Category),
+		IsDefault: col.IsDefault,
+		Color:     col.Color,
+		WIPLimit:  transformer.Int32Ptr(col.WipLimit),
+		CreatedAt: col.CreatedAt.Time,
+		UpdatedAt: col.UpdatedAt.Time,
+		DeletedAt: transformer.TimePtr(col.DeletedAt),
+	}
+
+	payload := reorderPayload(ctx, projectID, boardID, []pgtype.UUID{col.ID})
+	if err := s.Bus.Publish(ctx, pubsub.BoardColumnReordered, payload); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.BoardColumnReordered), "error", err)
+	}
+
+	return result, nil
+}
+
+// columnSlot returns the midpoint between the anchor and the column after it,
+// compacting the board once when the two are too close to split
+func (s *Service) columnSlot(ctx context.Context, boardID, columnID, afterID pgtype.UUID) (int32, error) {
+	for attempt := 0; ; attempt++ {
+		cols, err := s.Repo.ListBoardColumns(ctx, boardID)
+		if err != nil {
+			return 0, fmt.Errorf("list board columns: %w", err)
+		}
+
+		found := false
+		siblings := make([]repository.BoardColumn, 0, len(cols))
+		for _, col := range cols {
+			if col.ID == columnID {
+				found = true
+				continue
+			}
+			siblings = append(siblings, col)
+		}
+		if !found {
+			return 0, domain.NotFound("board column not found in this board")
+		}
+
+		// index of the anchor in siblings, -1 places the column first
+		anchor := -1
+		if afterID.Valid {
+			for i, col := range siblings {
+				if col.ID == afterID {
+					anchor = i
+					break
+				}
+			}
+			if anchor == -1 {
+				return 0, domain.NotFound("anchor column not found in this board")
+			}
+		}
+
+		var lower, upper int32
+		switch {
+		case len(siblings) == 0:
+			return 0, nil
+		case anchor == -1:
+			upper = siblings[0].Position
+			lower = upper - 2*columnPositionStep
+		case anchor == len(siblings)-1:
+			lower = siblings[anchor].Position
+			upper = lower + 2*columnPositionStep
+		default:
+			lower = siblings[anchor].Position
+			upper = siblings[anchor+1].Position
+		}
+
+		if upper-lower >= 2 {
+			return lower + (upper-lower)/2, nil
+		}
+		if attempt > 0 {
+			return 0, fmt.Errorf("no room to place column %s after compaction", uuid.UUID(columnID.Bytes))
+		}
+		if err := s.Repo.CompactBoardColumns(ctx, boardID); err != nil {
+			return 0, fmt.Errorf("compact board columns: %w", err)
+		}
+	}
+}
+
+// CompactCrowdedBoards re-spaces every board whose columns have run out of
+// room between neighbours
+func (s *Service) CompactCrowdedBoards(ctx context.Context) error {
+	boardIDs, err := s.Repo.ListBoardsWithCrowdedColumns(ctx)
+	if err != nil {
+		return fmt.Errorf("list crowded boards: %w", err)
+	}
+
+	for _, boardID := range boardIDs {
+		// stop between boards on shutdown, each compaction is its own statement
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := s.Repo.CompactBoardColumns(ctx, boardID); err != nil {
+			return fmt.Errorf("compact board columns: %w", err)
+		}
+	}
+
+	if len(boardIDs) > 0 {
+		slog.Info("[BoardModule]: compacted column positions", "boards", len(boardIDs))
+	}
+	return nil
+}
+
+// StartColumnCompactor runs CompactCrowdedBoards on every tick until ctx ends
+func (s *Service) StartColumnCompactor(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.CompactCrowdedBoards(ctx); err != nil {
+				slog.Warn("[BoardModule]: column compaction failed", "error", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/board/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrColumnNotDeleted = domain.Conflict("board column is not deleted").WithCode("not_deleted")
+	ErrColumnParentGone = domain.Conflict("the column's board, sprint or project is deleted").WithCode("parent_deleted")
+)
+
+// RestoreBoardColumn brings a soft-deleted column back at its old position.
+// It takes the default flag only when the board has no default left.
+func (s *Service) RestoreBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID) (domain.BoardColumnModel, error) {
+	if err := s.authorizeBoard(ctx, boardID, domain.ProjectRoleMember); err != nil {
+		return domain.BoardColumnModel{}, err
+	}
+
+	key := repository.RestoreBoardColumnParams{ID: columnID, BoardID: boardID}
+	if _, err := s.Repo.RestoreBoardColumn(ctx, key); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.BoardColumnModel{}, s.refusedColumnRestore(ctx, key)
+		}
+		if isDefaultConflict(err) {
+			return domain.BoardColumnModel{}, ErrDefaultConflict
+		}
+		return domain.BoardColumnModel{}, fmt.Errorf("restore board column: %w", err)
+	}
+
+	result, err := s.GetBoardColumn(ctx, columnID)
+	if err != nil {
+		return domain.BoardColumnModel{}, err
+	}
+
+	if err := s.Bus.Publish(ctx, pubsub.BoardColumnRestored, httpx.EncodePayload(result)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.BoardColumnRestored), "error", err)
+	}
+
+	return result, nil
+}
+
+// refusedColumnRestore tells why nothing was restored
+func (s *Service) refusedColumnRestore(ctx context.Context, key repository.RestoreBoardColumnParams) error {
+	state, err := s.Repo.GetBoardColumnTrashState(ctx, repository.GetBoardColumnTrashStateParams(key))
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return domain.NotFound("board column not found in this board")
+	case err != nil:
+		return fmt.Errorf("get board column trash state: %w", err)
+	case !state.Deleted:
+		return ErrColumnNotDeleted
+	default:
+		return ErrColumnParentGone
+	}
+}
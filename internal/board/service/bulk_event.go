@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// reorderPayload describes a reorder for the activity log: ids were put in
+// a new order within parentID, the sprint of boards or the board of columns
+func reorderPayload(ctx context.Context, projectID, parentID pgtype.UUID, ids []pgtype.UUID) map[string]string {
+	actorID, _ := httpx.UserIDFrom(ctx)
+	return httpx.EncodePayload(domain.BulkEventModel{
+		ProjectID: projectID,
+		ActorID:   actorID,
+		ParentID:  parentID,
+		IDs:       ids,
+	})
+}
+
+// boardProject finds the project of a board through its sprint
+func (s *Service) boardProject(ctx context.Context, boardID pgtype.UUID) (pgtype.UUID, error) {
+	board, err := s.GetBoard(ctx, boardID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("validate board: %w", err)
+	}
+	sprint, err := s.Sprint.GetSprint(ctx, board.SprintID)
+	if err != nil {
+		return pgtype.UUID{}, fmt.Errorf("validate sprint: %w", err)
+	}
+	return sprint.ProjectID, nil
+}
@@ -7,9 +7,10 @@ import (
 )
 
 type Deps struct {
-	Repo   *repository.Queries
-	Sprint domain.SprintReader
-	Bus    pubsub.Publisher
+	Repo    *repository.Queries
+	Sprint  domain.SprintReader
+	Project domain.ProjectReader
+	Bus     pubsub.Publisher
 }
 
 type Service struct {
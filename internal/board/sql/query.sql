@@ -1,11 +1,18 @@
 -- name: CreateBoard :one
-INSERT INTO boards (sprint_id, name, position)
-VALUES ($1, $2, (SELECT COALESCE(MAX(position), -1) + 1 FROM boards WHERE sprint_id = $1 AND deleted_at IS NULL))
+INSERT INTO boards (sprint_id, name, position, id)
+VALUES ($1, $2, (SELECT COALESCE(MAX(position), -1) + 1 FROM boards WHERE sprint_id = $1 AND deleted_at IS NULL), $3)
 RETURNING *;
 
 -- name: GetBoard :one
 SELECT * FROM boards WHERE id = $1 AND deleted_at IS NULL;
 
+-- name: GetBoardProject :one
+-- The project a board belongs to through its sprint, deleted or not
+SELECT s.project_id
+FROM boards b
+JOIN sprints s ON s.id = b.sprint_id
+WHERE b.id = $1;
+
 -- name: ListBoardsBySprint :many
 SELECT * FROM boards WHERE sprint_id = $1 AND deleted_at IS NULL ORDER BY position ASC;
 
@@ -21,6 +28,12 @@ WITH filtered_boards AS (
     AND (array_length($1::uuid[], 1) IS NULL OR id = ANY($1::uuid[]))
     AND (array_length($2::uuid[], 1) IS NULL OR sprint_id = ANY($2::uuid[]))
     AND ($3::text = '' OR name ILIKE '%' || $3 || '%')
+    AND ($6::uuid IS NULL OR EXISTS (
+      SELECT 1
+      FROM sprints s
+      JOIN project_members pm ON pm.project_id = s.project_id
+      WHERE s.id = boards.sprint_id AND pm.user_id = $6::uuid
+    ))
 )
 SELECT
   id, sprint_id, name, position, created_at, updated_at, deleted_at, total_count
@@ -33,12 +46,12 @@ OFFSET $5;
 
 -- name: UpdateBoard :one
 UPDATE boards
-SET name = $2, sprint_id = $3, updated_at = NOW()
+SET name = $2, sprint_id = $3
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: DeleteBoard :one
-UPDATE boards SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING *;
+UPDATE boards SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING *;
 
 -- name: ReorderBoardsInBatch :many
 -- Atomically validates and reorders boards within a sprint with row-level locking
@@ -53,7 +66,7 @@ WITH validation AS (
   )
 ),updated AS (
   UPDATE boards
-  SET position = validation.pos, updated_at = NOW()
+  SET position = validation.pos
   FROM validation
   WHERE boards.id = validation.id
     AND boards.sprint_id = $1
@@ -63,17 +76,27 @@ WITH validation AS (
       SELECT COUNT(*) FROM boards
       WHERE sprint_id = $1 AND deleted_at IS NULL
     ) = array_length($2::uuid[], 1)
-    -- Validate: all array elements are valid boards for this sprint
+    -- Validate: all array elements are distinct, valid boards for this sprint
     AND (
-      SELECT COUNT(*) FROM validation
+      SELECT COUNT(DISTINCT id) FROM validation
     ) = array_length($2::uuid[], 1)
   RETURNING boards.id, boards.sprint_id, boards.name, boards.position, boards.created_at, boards.updated_at, boards.deleted_at
 )
 SELECT * FROM updated ORDER BY position;
 
 -- name: CreateBoardColumn :one
-INSERT INTO board_columns (board_id, name, position)
-VALUES ($1, $2, (SELECT COALESCE(MAX(position), -1) + 1 FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL))
+-- The first column of a board becomes its default
+INSERT INTO board_columns (board_id, name, category, position, is_default, color, wip_limit, id)
+VALUES (
+  $1,
+  $2,
+  $3,
+  (SELECT COALESCE(MAX(position) + 1024, 0) FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL),
+  NOT EXISTS (SELECT 1 FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL),
+  $4,
+  $5,
+  $6
+)
 RETURNING *;
 
 -- name: GetBoardColumn :one
@@ -85,7 +108,7 @@ SELECT * FROM board_columns WHERE board_id = $1 AND deleted_at IS NULL ORDER BY
 -- name: ListBoardColumnsPaged :many
 WITH filtered_columns AS (
   SELECT
-    id, board_id, name, position, created_at, updated_at, deleted_at,
+    id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit,
     COUNT(*) OVER () as total_count
   FROM
     board_columns
@@ -94,9 +117,17 @@ WITH filtered_columns AS (
     AND (array_length($1::uuid[], 1) IS NULL OR id = ANY($1::uuid[]))
     AND (array_length($2::uuid[], 1) IS NULL OR board_id = ANY($2::uuid[]))
     AND ($3::text = '' OR name ILIKE '%' || $3 || '%')
+    AND (array_length($6::text[], 1) IS NULL OR category::text = ANY($6::text[]))
+    AND ($7::uuid IS NULL OR EXISTS (
+      SELECT 1
+      FROM boards b
+      JOIN sprints s ON s.id = b.sprint_id
+      JOIN project_members pm ON pm.project_id = s.project_id
+      WHERE b.id = board_columns.board_id AND pm.user_id = $7::uuid
+    ))
 )
 SELECT
-  id, board_id, name, position, created_at, updated_at, deleted_at, total_count
+  id, board_id, name, position, created_at, updated_at, deleted_at, category, is_default, color, wip_limit, total_count
 FROM
   filtered_columns
 ORDER BY
@@ -105,20 +136,68 @@ LIMIT $4
 OFFSET $5;
 
 -- name: UpdateBoardColumn :one
-UPDATE board_columns SET name = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING *;
+UPDATE board_columns SET name = $2, category = $3, color = $4, wip_limit = $5 WHERE id = $1 AND deleted_at IS NULL RETURNING *;
 
 -- name: ReorderBoardColumn :one
-UPDATE board_columns SET position = $2, updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING *;
+UPDATE board_columns SET position = $2 WHERE id = $1 AND deleted_at IS NULL RETURNING *;
 
 -- name: DeleteBoardColumn :one
-UPDATE board_columns SET deleted_at = NOW(), updated_at = NOW() WHERE id = $1 AND deleted_at IS NULL RETURNING *;
+-- Soft-deletes a column; the board's default is only deleted when $2 names a live replacement on the board, which takes the flag
+WITH source AS (
+  SELECT id, board_id, is_default FROM board_columns
+  WHERE id = $1 AND deleted_at IS NULL
+  FOR UPDATE
+), deleted AS (
+  UPDATE board_columns SET deleted_at = NOW(), is_default = false
+  FROM source
+  WHERE board_columns.id = source.id
+    AND (NOT source.is_default OR EXISTS (
+      SELECT 1 FROM board_columns r
+      WHERE r.id = $2 AND r.board_id = source.board_id AND r.id <> source.id AND r.deleted_at IS NULL
+    ))
+  RETURNING board_columns.id, board_columns.board_id, board_columns.name, board_columns.position, board_columns.created_at, board_columns.updated_at, board_columns.deleted_at, board_columns.category, board_columns.is_default
+), promoted AS (
+  UPDATE board_columns SET is_default = true
+  FROM source, deleted
+  WHERE source.is_default AND board_columns.id = $2
+  RETURNING board_columns.id
+)
+SELECT * FROM deleted;
+
+-- name: RestoreBoardColumn :one
+-- Clears deleted_at of a column whose board, sprint and project are still live; it becomes the default when the board has none
+UPDATE board_columns
+SET deleted_at = NULL,
+    is_default = NOT EXISTS (
+      SELECT 1 FROM board_columns d
+      WHERE d.board_id = board_columns.board_id AND d.is_default AND d.deleted_at IS NULL
+    )
+WHERE id = $1 AND board_id = $2 AND deleted_at IS NOT NULL
+  AND EXISTS (
+    SELECT 1 FROM boards b
+    JOIN sprints s ON s.id = b.sprint_id
+    JOIN projects p ON p.id = s.project_id
+    WHERE b.id = board_columns.board_id AND b.deleted_at IS NULL AND s.deleted_at IS NULL AND p.deleted_at IS NULL
+  )
+RETURNING *;
+
+-- name: GetBoardColumnTrashState :one
+-- Whether a column and what holds it are deleted, to explain a refused restore
+SELECT
+    bc.deleted_at IS NOT NULL AS deleted,
+    (b.deleted_at IS NOT NULL OR s.deleted_at IS NOT NULL OR p.deleted_at IS NOT NULL) AS parent_deleted
+FROM board_columns bc
+JOIN boards b ON b.id = bc.board_id
+JOIN sprints s ON s.id = b.sprint_id
+JOIN projects p ON p.id = s.project_id
+WHERE bc.id = $1 AND bc.board_id = $2;
 
 -- name: ReorderBoardColumnsInBatch :many
 -- Atomically validates and reorders columns with row-level locking
--- Results ordered by position to maintain input array order
+-- Results ordered by position to maintain input array order; positions are spaced 1024 apart
 WITH validation AS (
   -- Validate: all provided IDs exist and belong to this board
-  SELECT id, ROW_NUMBER() OVER () - 1 as pos
+  SELECT id, (ROW_NUMBER() OVER () - 1) * 1024 as pos
   FROM UNNEST($2::uuid[]) AS t(id)
   WHERE EXISTS (
     SELECT 1 FROM board_columns bc
@@ -126,7 +205,7 @@ WITH validation AS (
   )
 ),updated AS (
   UPDATE board_columns
-  SET position = validation.pos, updated_at = NOW()
+  SET position = validation.pos
   FROM validation
   WHERE board_columns.id = validation.id
     AND board_columns.board_id = $1
@@ -136,10 +215,102 @@ WITH validation AS (
       SELECT COUNT(*) FROM board_columns
       WHERE board_id = $1 AND deleted_at IS NULL
     ) = array_length($2::uuid[], 1)
-    -- Validate: all array elements are valid columns for this board
+    -- Validate: all array elements are distinct, valid columns for this board
     AND (
-      SELECT COUNT(*) FROM validation
+      SELECT COUNT(DISTINCT id) FROM validation
     ) = array_length($2::uuid[], 1)
-  RETURNING board_columns.id, board_columns.board_id, board_columns.name, board_columns.position, board_columns.created_at, board_columns.updated_at, board_columns.deleted_at
+  RETURNING board_columns.id, board_columns.board_id, board_columns.name, board_columns.position, board_columns.created_at, board_columns.updated_at, board_columns.deleted_at, board_columns.category, board_columns.is_default, board_columns.color, board_columns.wip_limit
 )
 SELECT * FROM updated ORDER BY position;
+
+-- name: CountTicketsByBoardColumns :many
+-- Aggregates ticket and overdue counts per column; columns without tickets report zero
+SELECT
+  bc.id,
+  COUNT(t.id)::bigint AS ticket_count,
+  COUNT(t.id) FILTER (WHERE t.due_date < CURRENT_DATE AND bc.category <> 'done')::bigint AS overdue_count
+FROM
+  board_columns bc
+  LEFT JOIN tickets t ON t.board_column_id = bc.id AND t.deleted_at IS NULL
+WHERE
+  bc.id = ANY($1::uuid[])
+  AND bc.deleted_at IS NULL
+GROUP BY
+  bc.id;
+
+-- name: MergeBoardColumn :one
+-- Moves every ticket of the source column into the target, ranked after the target's own tickets,
+-- soft-deletes the source and re-spaces the remaining positions; the target inherits the default
+-- flag from the source. A single statement so the merge is atomic. Nothing changes unless both
+-- columns are live and share a board
+WITH source AS (
+  SELECT id, board_id, position, is_default FROM board_columns
+  WHERE id = $1 AND deleted_at IS NULL
+  FOR UPDATE
+), target AS (
+  SELECT bc.id, COALESCE((SELECT MAX(t.rank) FROM tickets t WHERE t.board_column_id = bc.id AND t.deleted_at IS NULL), '') AS last_rank
+  FROM board_columns bc, source
+  WHERE bc.id = $2 AND bc.board_id = source.board_id AND bc.id <> source.id AND bc.deleted_at IS NULL
+  FOR UPDATE OF bc
+), moved AS (
+  UPDATE tickets SET board_column_id = target.id, rank = target.last_rank || lpad(ranked.rn::text, 6, '0') || 'i'
+  FROM target, (
+    SELECT t.id, ROW_NUMBER() OVER (ORDER BY t.rank, t.ticket_number DESC) AS rn
+    FROM tickets t, source
+    WHERE t.board_column_id = source.id AND t.deleted_at IS NULL
+  ) ranked
+  WHERE tickets.id = ranked.id
+  RETURNING tickets.id
+), merged AS (
+  UPDATE board_columns SET deleted_at = NOW(), is_default = false
+  FROM source, target
+  WHERE board_columns.id = source.id
+  RETURNING board_columns.id
+), compacted AS (
+  UPDATE board_columns
+  SET position = ranked.pos,
+      is_default = board_columns.is_default OR (board_columns.id = target.id AND source.is_default)
+  FROM source, target, (
+    SELECT bc.id, (ROW_NUMBER() OVER (ORDER BY bc.position, bc.created_at) - 1) * 1024 AS pos
+    FROM board_columns bc, source, target
+    WHERE bc.board_id = source.board_id AND bc.deleted_at IS NULL AND bc.id <> source.id
+  ) ranked
+  WHERE board_columns.id = ranked.id
+  RETURNING board_columns.id
+)
+SELECT
+  (SELECT COUNT(*) FROM merged) AS merged_count,
+  (SELECT COUNT(*) FROM moved) AS moved_count;
+
+-- name: CompactBoardColumns :exec
+-- Re-spaces a board's columns 1024 apart, keeping their order, once single moves have used up a gap
+UPDATE board_columns SET position = ranked.pos
+FROM (
+  SELECT id, (ROW_NUMBER() OVER (ORDER BY position, created_at) - 1) * 1024 AS pos
+  FROM board_columns
+  WHERE board_id = $1 AND deleted_at IS NULL
+) ranked
+WHERE board_columns.id = ranked.id AND board_columns.position <> ranked.pos;
+
+-- name: ListBoardsWithCrowdedColumns :many
+-- Boards where two neighbouring columns share a position or sit one apart, so no midpoint is left
+SELECT DISTINCT board_id
+FROM (
+  SELECT board_id, position - LAG(position) OVER (PARTITION BY board_id ORDER BY position) AS gap
+  FROM board_columns
+  WHERE deleted_at IS NULL
+) gaps
+WHERE gap < 2;
+
+-- name: SetDefaultBoardColumn :execrows
+-- Moves the board's default flag to the given column in one statement; the single default
+-- constraint is deferred to the end of the statement so both rows can flip together
+UPDATE board_columns
+SET is_default = (id = $2)
+WHERE board_id = $1
+  AND deleted_at IS NULL
+  AND (is_default OR id = $2)
+  AND EXISTS (
+    SELECT 1 FROM board_columns bc
+    WHERE bc.id = $2 AND bc.board_id = $1 AND bc.deleted_at IS NULL
+  );
@@ -0,0 +1,213 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/internal/caldav/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+)
+
+// BasePath is both the principal and the calendar home; every project the
+// user can reach is a calendar directly below it
+const BasePath = "/caldav/"
+
+// Options advertises calendar-access so clients treat the server as CalDAV
+func (h *Handler) Options(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("DAV", "1, 3, calendar-access")
+	w.Header().Set("Allow", "OPTIONS, GET, HEAD, PUT, PROPFIND, REPORT")
+	w.WriteHeader(http.StatusOK)
+}
+
+// PropfindHome describes the principal and, at depth 1, lists the user's
+// calendars
+func (h *Handler) PropfindHome(w http.ResponseWriter, r *http.Request) {
+	req, err := parseDAVRequest(w, r)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	resources := []davResource{homeResource()}
+	if depth(r) > 0 {
+		calendars, err := h.svc.ListCalendars(r.Context(), httpx.MustUserID(r.Context()))
+		if err != nil {
+			httpx.Handle(w, err)
+			return
+		}
+		for _, cal := range calendars {
+			resources = append(resources, calendarResource(cal))
+		}
+	}
+
+	writeMultistatus(w, req, resources)
+}
+
+// PropfindCalendar describes a project's calendar and, at depth 1, lists its
+// dated tickets
+func (h *Handler) PropfindCalendar(w http.ResponseWriter, r *http.Request) {
+	cal, ok := h.calendar(w, r)
+	if !ok {
+		return
+	}
+	req, err := parseDAVRequest(w, r)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	resources := []davResource{calendarResource(cal)}
+	if depth(r) > 0 {
+		todos, err := h.svc.ListCalendarTodos(r.Context(), cal.ProjectID)
+		if err != nil {
+			httpx.Handle(w, err)
+			return
+		}
+		for _, todo := range todos {
+			resources = append(resources, todoResource(todo))
+		}
+	}
+
+	writeMultistatus(w, req, resources)
+}
+
+// ReportCalendar answers calendar-query, which returns every task since only
+// VTODO components exist, and calendar-multiget for the hrefs asked for
+func (h *Handler) ReportCalendar(w http.ResponseWriter, r *http.Request) {
+	cal, ok := h.calendar(w, r)
+	if !ok {
+		return
+	}
+	req, err := parseDAVRequest(w, r)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var resources []davResource
+	switch req.root {
+	case caldav("calendar-query"):
+		if !wantsTodos(req.comps) {
+			break
+		}
+		todos, err := h.svc.ListCalendarTodos(r.Context(), cal.ProjectID)
+		if err != nil {
+			httpx.Handle(w, err)
+			return
+		}
+		for _, todo := range todos {
+			resources = append(resources, todoResource(todo))
+		}
+	case caldav("calendar-multiget"):
+		prefix := calendarPath(cal)
+		for _, ref := range req.hrefs {
+			name, ok := strings.CutPrefix(ref, prefix)
+			if !ok {
+				resources = append(resources, davResource{href: ref, status: http.StatusNotFound})
+				continue
+			}
+			todo, err := h.svc.GetCalendarTodo(r.Context(), cal.ProjectID, parseObjectName(name))
+			if err != nil {
+				if !errors.Is(err, service.ErrTodoNotFound) {
+					httpx.Handle(w, err)
+					return
+				}
+				resources = append(resources, davResource{href: ref, status: http.StatusNotFound})
+				continue
+			}
+			resources = append(resources, todoResource(todo))
+		}
+	default:
+		httpx.Handle(w, httpx.Forbidden("only calendar-query and calendar-multiget reports are supported").WithCode("report_unsupported"))
+		return
+	}
+
+	writeMultistatus(w, req, resources)
+}
+
+// calendar resolves the projectId path param to a calendar of the user,
+// answering 404 for projects they cannot reach
+func (h *Handler) calendar(w http.ResponseWriter, r *http.Request) (domain.CalendarModel, bool) {
+	projectID, err := httpx.PathUUID(r, "projectId")
+	if err != nil {
+		httpx.Handle(w, httpx.NotFound("calendar not found"))
+		return domain.CalendarModel{}, false
+	}
+	cal, err := h.svc.GetCalendar(r.Context(), httpx.MustUserID(r.Context()), projectID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return domain.CalendarModel{}, false
+	}
+	return cal, true
+}
+
+// wantsTodos reports whether a calendar-query's component filters can match
+// a VTODO; a query for events only matches nothing here
+func wantsTodos(comps []string) bool {
+	for _, c := range comps {
+		if c != "VCALENDAR" && c != "VTODO" {
+			return false
+		}
+	}
+	return true
+}
+
+func homeResource() davResource {
+	return davResource{
+		href: BasePath,
+		all: []davProp{
+			{dav("resourcetype"), "<d:collection/><d:principal/>"},
+			{dav("displayname"), "Fluxis"},
+			{dav("current-user-principal"), href(BasePath)},
+			{dav("principal-URL"), href(BasePath)},
+			{caldav("calendar-home-set"), href(BasePath)},
+		},
+	}
+}
+
+func calendarResource(cal domain.CalendarModel) davResource {
+	tag := strconv.FormatInt(cal.ChangedAt.UnixMicro(), 10)
+	return davResource{
+		href: calendarPath(cal),
+		all: []davProp{
+			{dav("resourcetype"), "<d:collection/><c:calendar/>"},
+			{dav("displayname"), escape(cal.Key + " " + cal.Name)},
+			{dav("getetag"), escape(`"` + tag + `"`)},
+			{cs("getctag"), tag},
+			{caldav("supported-calendar-component-set"), `<c:comp name="VTODO"/>`},
+			// tasks can be edited but not created or removed from a client
+			{dav("current-user-privilege-set"), "<d:privilege><d:read/></d:privilege><d:privilege><d:write-content/></d:privilege>"},
+		},
+		more: []davProp{
+			{caldav("calendar-description"), escape(cal.Description)},
+			{dav("current-user-principal"), href(BasePath)},
+		},
+	}
+}
+
+func todoResource(todo domain.CalendarTodoModel) davResource {
+	return davResource{
+		href: calendarPath(domain.CalendarModel{ProjectID: todo.ProjectID}) + transformer.UUIDString(todo.ID) + ".ics",
+		all: []davProp{
+			{dav("resourcetype"), ""},
+			{dav("getetag"), escape(etag(todo))},
+			{dav("getcontenttype"), escape(contentType)},
+			{dav("getlastmodified"), todo.UpdatedAt.UTC().Format(http.TimeFormat)},
+		},
+		more: []davProp{
+			{caldav("calendar-data"), escape(string(marshalTodo(todo)))},
+		},
+	}
+}
+
+func calendarPath(cal domain.CalendarModel) string {
+	return BasePath + transformer.UUIDString(cal.ProjectID) + "/"
+}
+
+func etag(todo domain.CalendarTodoModel) string {
+	return `"` + strconv.FormatInt(todo.UpdatedAt.UnixMicro(), 10) + `"`
+}
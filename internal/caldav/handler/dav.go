@@ -0,0 +1,208 @@
+package handler
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+const (
+	nsDAV    = "DAV:"
+	nsCalDAV = "urn:ietf:params:xml:ns:caldav"
+	nsCS     = "http://calendarserver.org/ns/"
+
+	maxDAVBody = 1 << 20
+)
+
+var prefixes = map[string]string{
+	nsDAV:    "d",
+	nsCalDAV: "c",
+	nsCS:     "cs",
+}
+
+var errMalformedXML = httpx.BadRequest("malformed WebDAV request body").WithCode("invalid_xml")
+
+// davRequest is what the handlers need from a PROPFIND or REPORT body. A nil
+// props means all properties were asked for, which is also what an empty
+// PROPFIND body asks for.
+type davRequest struct {
+	root  xml.Name
+	props []xml.Name
+	hrefs []string
+	comps []string
+}
+
+func parseDAVRequest(w http.ResponseWriter, r *http.Request) (davRequest, error) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxDAVBody))
+	if err != nil {
+		return davRequest{}, httpx.PayloadTooLarge("request body too large")
+	}
+
+	var (
+		req   davRequest
+		stack []xml.Name
+	)
+	dec := xml.NewDecoder(bytes.NewReader(body))
+	for {
+		tok, err := dec.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return davRequest{}, errMalformedXML
+		}
+
+		switch t := tok.(type) {
+		case xml.StartElement:
+			parent := xml.Name{}
+			if len(stack) > 0 {
+				parent = stack[len(stack)-1]
+			} else {
+				req.root = t.Name
+			}
+			switch {
+			case parent == xml.Name{Space: nsDAV, Local: "prop"}:
+				req.props = append(req.props, t.Name)
+			case t.Name == xml.Name{Space: nsDAV, Local: "href"} && len(stack) == 1:
+				var href string
+				if err := dec.DecodeElement(&href, &t); err != nil {
+					return davRequest{}, errMalformedXML
+				}
+				req.hrefs = append(req.hrefs, strings.TrimSpace(href))
+				continue
+			case t.Name == xml.Name{Space: nsCalDAV, Local: "comp-filter"}:
+				for _, a := range t.Attr {
+					if a.Name.Local == "name" {
+						req.comps = append(req.comps, strings.ToUpper(a.Value))
+					}
+				}
+			}
+			stack = append(stack, t.Name)
+		case xml.EndElement:
+			if len(stack) > 0 {
+				stack = stack[:len(stack)-1]
+			}
+		}
+	}
+	return req, nil
+}
+
+// davProp is a property name with its value as inner XML
+type davProp struct {
+	name  xml.Name
+	inner string
+}
+
+// davResource is one response of a multistatus; all is what an allprop
+// request returns, more holds properties only returned when asked for. A
+// resource with a status, such as a missing multiget href, has no properties.
+type davResource struct {
+	href   string
+	status int
+	all    []davProp
+	more   []davProp
+}
+
+// writeMultistatus answers with every requested property each resource has
+// and lists the rest as not found
+func writeMultistatus(w http.ResponseWriter, req davRequest, resources []davResource) {
+	var b bytes.Buffer
+	b.WriteString(xml.Header)
+	b.WriteString(`<d:multistatus xmlns:d="DAV:" xmlns:c="urn:ietf:params:xml:ns:caldav" xmlns:cs="http://calendarserver.org/ns/">`)
+	for _, res := range resources {
+		if res.status != 0 {
+			b.WriteString("<d:response>" + href(res.href) + "<d:status>HTTP/1.1 ")
+			b.WriteString(strconv.Itoa(res.status) + " " + http.StatusText(res.status))
+			b.WriteString("</d:status></d:response>")
+			continue
+		}
+
+		found, missing := res.all, []xml.Name(nil)
+		if req.props != nil {
+			found = nil
+			for _, name := range req.props {
+				if p, ok := res.lookup(name); ok {
+					found = append(found, p)
+				} else {
+					missing = append(missing, name)
+				}
+			}
+		}
+
+		b.WriteString("<d:response>" + href(res.href))
+		if len(found) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for _, p := range found {
+				writeProp(&b, p.name, p.inner)
+			}
+			b.WriteString("</d:prop><d:status>HTTP/1.1 200 OK</d:status></d:propstat>")
+		}
+		if len(missing) > 0 {
+			b.WriteString("<d:propstat><d:prop>")
+			for _, name := range missing {
+				writeProp(&b, name, "")
+			}
+			b.WriteString("</d:prop><d:status>HTTP/1.1 404 Not Found</d:status></d:propstat>")
+		}
+		b.WriteString("</d:response>")
+	}
+	b.WriteString("</d:multistatus>")
+
+	w.Header().Set("Content-Type", `application/xml; charset="utf-8"`)
+	w.WriteHeader(http.StatusMultiStatus)
+	w.Write(b.Bytes())
+}
+
+func (res davResource) lookup(name xml.Name) (davProp, bool) {
+	for _, set := range [][]davProp{res.all, res.more} {
+		for _, p := range set {
+			if p.name == name {
+				return p, true
+			}
+		}
+	}
+	return davProp{}, false
+}
+
+func writeProp(b *bytes.Buffer, name xml.Name, inner string) {
+	tag, decl := name.Local, ""
+	if prefix, ok := prefixes[name.Space]; ok {
+		tag = prefix + ":" + name.Local
+	} else if name.Space != "" {
+		decl = ` xmlns="` + escape(name.Space) + `"`
+	}
+	if inner == "" {
+		b.WriteString("<" + tag + decl + "/>")
+		return
+	}
+	b.WriteString("<" + tag + decl + ">" + inner + "</" + tag + ">")
+}
+
+func escape(s string) string {
+	var b bytes.Buffer
+	xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
+
+func href(path string) string {
+	return "<d:href>" + escape(path) + "</d:href>"
+}
+
+func dav(local string) xml.Name    { return xml.Name{Space: nsDAV, Local: local} }
+func caldav(local string) xml.Name { return xml.Name{Space: nsCalDAV, Local: local} }
+func cs(local string) xml.Name     { return xml.Name{Space: nsCS, Local: local} }
+
+// depth reads the Depth header; anything but 0 is served as 1, collections
+// here are never nested deeper
+func depth(r *http.Request) int {
+	if r.Header.Get("Depth") == "0" {
+		return 0
+	}
+	return 1
+}
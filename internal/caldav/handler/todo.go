@@ -0,0 +1,152 @@
+package handler
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/internal/caldav/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/ical"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	prodID      = "-//Fluxis//CalDAV//EN"
+	contentType = ical.ContentType + "; component=VTODO"
+	maxTodoBody = 1 << 20
+)
+
+// GetTodo serves a dated ticket as a VTODO
+func (h *Handler) GetTodo(w http.ResponseWriter, r *http.Request) {
+	todo, ok := h.todo(w, r)
+	if !ok {
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", etag(todo))
+	http.ServeContent(w, r, "", todo.UpdatedAt, bytes.NewReader(marshalTodo(todo)))
+}
+
+func (h *Handler) PropfindTodo(w http.ResponseWriter, r *http.Request) {
+	todo, ok := h.todo(w, r)
+	if !ok {
+		return
+	}
+	req, err := parseDAVRequest(w, r)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	writeMultistatus(w, req, []davResource{todoResource(todo)})
+}
+
+// PutTodo applies a VTODO edited in a calendar client to its ticket. Summary,
+// description, due date and completion are taken over; tickets are only
+// created in Fluxis, so a PUT to an unknown resource is refused.
+func (h *Handler) PutTodo(w http.ResponseWriter, r *http.Request) {
+	cal, ok := h.calendar(w, r)
+	if !ok {
+		return
+	}
+	id := parseObjectName(r.PathValue("object"))
+	current, err := h.svc.GetCalendarTodo(r.Context(), cal.ProjectID, id)
+	if err != nil {
+		if errors.Is(err, service.ErrTodoNotFound) {
+			httpx.Handle(w, httpx.Forbidden("tasks can not be created from a calendar, create the ticket in Fluxis and give it a due date").WithCode("create_unsupported"))
+			return
+		}
+		httpx.Handle(w, err)
+		return
+	}
+
+	// clients send the ETag they last saw so concurrent edits are not lost
+	if match := r.Header.Get("If-Match"); match != "" && match != "*" && match != etag(current) {
+		httpx.ErrorCode(w, http.StatusPreconditionFailed, "task was changed since it was last read", "precondition_failed")
+		return
+	}
+	if r.Header.Get("If-None-Match") == "*" {
+		httpx.ErrorCode(w, http.StatusPreconditionFailed, "task already exists", "precondition_failed")
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTodoBody))
+	if err != nil {
+		httpx.Handle(w, httpx.PayloadTooLarge("request body too large"))
+		return
+	}
+	parsed, err := ical.ParseTodo(body)
+	if err != nil {
+		httpx.Handle(w, httpx.BadRequest("request body is not a calendar object with a VTODO").WithCode("invalid_ical"))
+		return
+	}
+	if parsed.UID != "" && parsed.UID != transformer.UUIDString(id) {
+		httpx.Handle(w, httpx.BadRequest("UID does not match the resource").WithCode("uid_mismatch"))
+		return
+	}
+
+	updated, err := h.svc.UpdateCalendarTodo(r.Context(), cal.ProjectID, id, domain.CalendarTodoUpdateModel{
+		// the summary is served with the ticket key in front
+		Title:       strings.TrimPrefix(parsed.Summary, current.Key+" "),
+		Description: parsed.Description,
+		DueDate:     parsed.Due,
+		Done:        parsed.Completed(),
+	})
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(updated))
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// todo resolves the calendar and object path params to a dated ticket
+func (h *Handler) todo(w http.ResponseWriter, r *http.Request) (domain.CalendarTodoModel, bool) {
+	cal, ok := h.calendar(w, r)
+	if !ok {
+		return domain.CalendarTodoModel{}, false
+	}
+	todo, err := h.svc.GetCalendarTodo(r.Context(), cal.ProjectID, parseObjectName(r.PathValue("object")))
+	if err != nil {
+		httpx.Handle(w, err)
+		return domain.CalendarTodoModel{}, false
+	}
+	return todo, true
+}
+
+// parseObjectName reads the ticket id out of "<id>.ics". Names that are not
+// an id come back invalid, which no ticket matches.
+func parseObjectName(name string) pgtype.UUID {
+	var id pgtype.UUID
+	if raw, ok := strings.CutSuffix(name, ".ics"); ok {
+		_ = id.Scan(raw)
+	}
+	return id
+}
+
+func marshalTodo(todo domain.CalendarTodoModel) []byte {
+	status := ical.StatusNeedsAction
+	switch todo.Category {
+	case "done":
+		status = ical.StatusCompleted
+	case "in_progress":
+		status = ical.StatusInProcess
+	}
+
+	return ical.Marshal(prodID, ical.Todo{
+		UID:          transformer.UUIDString(todo.ID),
+		Summary:      todo.Key + " " + todo.Title,
+		Description:  todo.Description,
+		Due:          todo.DueDate,
+		Status:       status,
+		Created:      todo.CreatedAt,
+		LastModified: todo.UpdatedAt,
+	})
+}
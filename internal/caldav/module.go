@@ -0,0 +1,48 @@
+package caldav
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/caldav/handler"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// realm is shown by clients when they prompt for the account's credentials
+const realm = "Fluxis"
+
+type Module struct {
+	h    *handler.Handler
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		auth: auth,
+	}
+}
+
+// Routes serves a minimal CalDAV tree. Calendar clients cannot obtain bearer
+// tokens, so these routes take the account's email and password over Basic
+// auth instead.
+func (m *Module) Routes(mux *http.ServeMux) {
+	read := func(h http.HandlerFunc) http.HandlerFunc {
+		return m.auth.RequireBasicAuth(h, realm, domain.ScopeProjectsRead, domain.ScopeTicketsRead)
+	}
+	write := func(h http.HandlerFunc) http.HandlerFunc {
+		return m.auth.RequireBasicAuth(h, realm, domain.ScopeProjectsRead, domain.ScopeTicketsWrite)
+	}
+
+	mux.HandleFunc("/.well-known/caldav", func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, handler.BasePath, http.StatusMovedPermanently)
+	})
+	mux.HandleFunc("OPTIONS /caldav/", m.h.Options)
+
+	mux.HandleFunc("PROPFIND /caldav/{$}", read(m.h.PropfindHome))
+	mux.HandleFunc("PROPFIND /caldav/{projectId}/{$}", read(m.h.PropfindCalendar))
+	mux.HandleFunc("REPORT /caldav/{projectId}/{$}", read(m.h.ReportCalendar))
+	mux.HandleFunc("PROPFIND /caldav/{projectId}/{object}", read(m.h.PropfindTodo))
+	mux.HandleFunc("GET /caldav/{projectId}/{object}", read(m.h.GetTodo))
+	mux.HandleFunc("PUT /caldav/{projectId}/{object}", write(m.h.PutTodo))
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/caldav/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrCalendarNotFound = domain.NotFound("calendar not found")
+	ErrTodoNotFound     = domain.NotFound("task not found")
+)
+
+func (s *Service) ListCalendars(ctx context.Context, userID pgtype.UUID) ([]domain.CalendarModel, error) {
+	rows, err := s.Repo.ListCalendars(ctx, repository.ListCalendarsParams{
+		UserID:  userID,
+		Column2: httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("list calendars: %w", err)
+	}
+
+	items := make([]domain.CalendarModel, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toCalendarModel(repository.GetCalendarRow(row)))
+	}
+	return items, nil
+}
+
+// GetCalendar only finds projects the user is a member of, so it doubles as
+// the access check for everything below a calendar
+func (s *Service) GetCalendar(ctx context.Context, userID, projectID pgtype.UUID) (domain.CalendarModel, error) {
+	row, err := s.Repo.GetCalendar(ctx, repository.GetCalendarParams{
+		UserID:  userID,
+		ID:      projectID,
+		Column3: httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CalendarModel{}, ErrCalendarNotFound
+		}
+		return domain.CalendarModel{}, fmt.Errorf("get calendar: %w", err)
+	}
+	return toCalendarModel(row), nil
+}
+
+func (s *Service) ListCalendarTodos(ctx context.Context, projectID pgtype.UUID) ([]domain.CalendarTodoModel, error) {
+	rows, err := s.Repo.ListCalendarTodos(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list calendar todos: %w", err)
+	}
+
+	items := make([]domain.CalendarTodoModel, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toTodoModel(repository.GetCalendarTodoRow(row)))
+	}
+	return items, nil
+}
+
+// GetCalendarTodo reports tickets without a due date as missing, matching the
+// listing
+func (s *Service) GetCalendarTodo(ctx context.Context, projectID, id pgtype.UUID) (domain.CalendarTodoModel, error) {
+	row, err := s.Repo.GetCalendarTodo(ctx, repository.GetCalendarTodoParams{
+		ProjectID: projectID,
+		ID:        id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CalendarTodoModel{}, ErrTodoNotFound
+		}
+		return domain.CalendarTodoModel{}, fmt.Errorf("get calendar todo: %w", err)
+	}
+	return toTodoModel(row), nil
+}
+
+func toCalendarModel(row repository.GetCalendarRow) domain.CalendarModel {
+	return domain.CalendarModel{
+		ProjectID:   row.ID,
+		Key:         row.Key,
+		Name:        row.Name,
+		Description: row.Description.String,
+		ChangedAt:   row.ChangedAt.Time,
+	}
+}
+
+func toTodoModel(row repository.GetCalendarTodoRow) domain.CalendarTodoModel {
+	return domain.CalendarTodoModel{
+		ID:          row.ID,
+		ProjectID:   row.ProjectID,
+		Key:         row.Key,
+		Title:       row.Title,
+		Description: row.Description.String,
+		DueDate:     row.DueDate.Time,
+		Category:    row.Category,
+		CreatedAt:   row.CreatedAt.Time,
+		UpdatedAt:   row.UpdatedAt.Time,
+	}
+}
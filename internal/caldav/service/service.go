@@ -0,0 +1,22 @@
+package service
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/caldav/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+type Deps struct {
+	Repo   *repository.Queries
+	Ticket domain.TicketWriter
+}
+
+type Service struct {
+	Deps
+}
+
+var _ domain.CalendarReader = (*Service)(nil)
+var _ domain.CalendarWriter = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{d}
+}
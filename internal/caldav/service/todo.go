@@ -0,0 +1,87 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/caldav/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrTodoNotOnBoard = domain.Conflict("ticket is not on a board, move it onto one before completing it").WithCode("not_on_board")
+	ErrNoDoneColumn   = domain.Conflict("board has no done column").WithCode("no_done_column")
+	ErrNoOpenColumn   = domain.Conflict("board has no open column to reopen the ticket in").WithCode("no_open_column")
+)
+
+// UpdateCalendarTodo applies a task edited in a calendar client. Text and due
+// date go through the regular ticket update; flipping completion moves the
+// ticket between the board's first done column and its default column, so
+// board rules and change events apply as for a move on the board.
+func (s *Service) UpdateCalendarTodo(ctx context.Context, projectID, id pgtype.UUID, p domain.CalendarTodoUpdateModel) (domain.CalendarTodoModel, error) {
+	current, err := s.Repo.GetCalendarTodo(ctx, repository.GetCalendarTodoParams{
+		ProjectID: projectID,
+		ID:        id,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.CalendarTodoModel{}, ErrTodoNotFound
+		}
+		return domain.CalendarTodoModel{}, fmt.Errorf("get calendar todo: %w", err)
+	}
+
+	update := domain.TicketUpdateModel{}
+	if p.Title != "" && p.Title != current.Title {
+		update.Title = p.Title
+	}
+	if p.Description != "" && p.Description != current.Description.String {
+		update.Description = p.Description
+	}
+	if !p.DueDate.IsZero() && !p.DueDate.Equal(current.DueDate.Time) {
+		update.DueDate = p.DueDate
+	}
+	if update != (domain.TicketUpdateModel{}) {
+		if err := httpx.Validate(update); err != nil {
+			return domain.CalendarTodoModel{}, err
+		}
+		if _, err := s.Ticket.UpdateTicket(ctx, id, update); err != nil {
+			return domain.CalendarTodoModel{}, err
+		}
+	}
+
+	if done := current.Category == "done"; p.Done != done {
+		if err := s.moveTodo(ctx, current, p.Done); err != nil {
+			return domain.CalendarTodoModel{}, err
+		}
+	}
+
+	return s.GetCalendarTodo(ctx, projectID, id)
+}
+
+func (s *Service) moveTodo(ctx context.Context, todo repository.GetCalendarTodoRow, done bool) error {
+	if !todo.BoardID.Valid {
+		return ErrTodoNotOnBoard
+	}
+
+	pick, missing := s.Repo.GetReopenColumn, ErrNoOpenColumn
+	if done {
+		pick, missing = s.Repo.GetDoneColumn, ErrNoDoneColumn
+	}
+	columnID, err := pick(ctx, todo.BoardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return missing
+		}
+		return fmt.Errorf("get target column: %w", err)
+	}
+
+	_, err = s.Ticket.MoveTicketToBoardColumn(ctx, todo.ID, domain.TicketBoardMoveModel{
+		BoardID:       todo.BoardID,
+		BoardColumnID: columnID,
+	})
+	return err
+}
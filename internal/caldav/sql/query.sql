@@ -0,0 +1,90 @@
+-- name: ListCalendars :many
+-- Every project of the user's orgs they are a member of is one calendar, $2 is NULL for callers reaching them all
+-- changed_at moves whenever the project or any of its tickets is touched, deleted tickets included, and serves as the collection tag
+SELECT
+  p.id, p.key, p.name, p.description,
+  GREATEST(p.updated_at, COALESCE(MAX(t.updated_at), p.updated_at))::timestamptz AS changed_at
+FROM
+  projects p
+  JOIN org_members om ON om.org_id = p.org_id
+  LEFT JOIN tickets t ON t.project_id = p.id
+WHERE
+  om.user_id = $1
+  AND ($2::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $2::uuid
+  ))
+  AND p.deleted_at IS NULL
+GROUP BY
+  p.id
+ORDER BY
+  p.key;
+
+-- name: GetCalendar :one
+SELECT
+  p.id, p.key, p.name, p.description,
+  GREATEST(p.updated_at, COALESCE(MAX(t.updated_at), p.updated_at))::timestamptz AS changed_at
+FROM
+  projects p
+  JOIN org_members om ON om.org_id = p.org_id
+  LEFT JOIN tickets t ON t.project_id = p.id
+WHERE
+  om.user_id = $1
+  AND p.id = $2
+  AND ($3::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $3::uuid
+  ))
+  AND p.deleted_at IS NULL
+GROUP BY
+  p.id;
+
+-- name: ListCalendarTodos :many
+-- Only tickets with a due date are exposed, a calendar client has nowhere to place the rest
+SELECT
+  t.id, t.project_id, t.key, t.title, t.description, t.board_id, t.due_date,
+  COALESCE(bc.category::text, '')::text AS category, t.created_at, t.updated_at
+FROM
+  active_tickets t
+  LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE
+  t.project_id = $1
+  AND t.due_date IS NOT NULL
+ORDER BY
+  t.due_date, t.ticket_number;
+
+-- name: GetCalendarTodo :one
+SELECT
+  t.id, t.project_id, t.key, t.title, t.description, t.board_id, t.due_date,
+  COALESCE(bc.category::text, '')::text AS category, t.created_at, t.updated_at
+FROM
+  active_tickets t
+  LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE
+  t.project_id = $1
+  AND t.id = $2
+  AND t.due_date IS NOT NULL;
+
+-- name: GetDoneColumn :one
+-- The leftmost done column of a board receives tickets completed from a calendar
+SELECT
+  id
+FROM
+  active_board_columns
+WHERE
+  board_id = $1
+  AND category = 'done'
+ORDER BY
+  position
+LIMIT 1;
+
+-- name: GetReopenColumn :one
+-- Reopened tickets go back to the board's default column, or its first open one when no default is set
+SELECT
+  id
+FROM
+  active_board_columns
+WHERE
+  board_id = $1
+  AND category <> 'done'
+ORDER BY
+  is_default DESC, position
+LIMIT 1;
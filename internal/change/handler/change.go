@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/change/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// writeGrace leaves room to write the response once a poll times out
+const writeGrace = 5 * time.Second
+
+// ListProjectChanges godoc
+//
+//	@Summary		Poll project changes
+//	@ID				listProjectChanges
+//	@Description	Long-poll alternative to a stream. Without since it answers right away with the current cursor. With since it returns the IDs of tickets and board columns written after that cursor, waiting up to wait seconds (max 30) for one when there are none. Send the returned cursor as since on the next request; when hasMore is true ask again without waiting. Changes older than CHANGES_RETENTION are trimmed, a since before the oldest kept one answers 410 cursor_expired and the client has to reload
+//	@Tags			change
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			since	query		int		false	"Cursor from a previous response"
+//	@Param			wait	query		int		false	"Seconds to wait for a change"
+//	@Success		200		{object}	domain.ProjectChangesModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		410		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/changes [get]
+func (h *Handler) ListProjectChanges(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	q := domain.ProjectChangesSearchModel{
+		ProjectID: id,
+		Wait:      time.Duration(httpx.QueryNumber(r, "wait")) * time.Second,
+	}
+	if v := httpx.QueryString(r, "since"); v != "" {
+		since, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			httpx.Handle(w, httpx.BadRequest("invalid since"))
+			return
+		}
+		q.Since = pgtype.Int8{Int64: since, Valid: true}
+	}
+
+	// the server's write timeout is sized for ordinary requests
+	if q.Since.Valid && q.Wait > 0 {
+		deadline := time.Now().Add(min(q.Wait, service.MaxWait) + writeGrace)
+		_ = http.NewResponseController(w).SetWriteDeadline(deadline)
+	}
+
+	changes, err := h.svc.ListProjectChanges(r.Context(), q)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, changes)
+}
@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ListChanges godoc
+//
+//	@Summary		Read the change feed
+//	@ID				listChanges
+//	@Description	Ordered log of every insert, update and delete of projects, sprints, boards, board columns and tickets across the projects the caller is a member of. Entries are numbered by seq in commit order, so a client that applies them in order after its cursor replicates the server state. Without after it returns only the current cursor to start from after an initial load. When hasMore is true fetch again right away. Entries older than CHANGES_RETENTION are trimmed, an after before the oldest kept one answers 410 cursor_expired and the client has to reload
+//	@Tags			change
+//	@Produce		json
+//	@Param			after	query		int	false	"Cursor from a previous response"
+//	@Param			limit	query		int	false	"Max entries (default 100, max 1000)"
+//	@Success		200		{object}	domain.ChangeFeedModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		410		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/changes [get]
+func (h *Handler) ListChanges(w http.ResponseWriter, r *http.Request) {
+	q := domain.ChangesSearchModel{
+		UserID: httpx.MustUserID(r.Context()),
+		Limit:  httpx.QueryNumber(r, "limit"),
+	}
+	if v := httpx.QueryString(r, "after"); v != "" {
+		after, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			httpx.Handle(w, httpx.BadRequest("invalid after"))
+			return
+		}
+		q.After = pgtype.Int8{Int64: after, Valid: true}
+	}
+
+	feed, err := h.svc.ListChanges(r.Context(), q)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, feed)
+}
@@ -0,0 +1,48 @@
+package change
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/change/handler"
+	"github.com/dimasbaguspm/fluxis/internal/change/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+)
+
+type Module struct {
+	h    *handler.Handler
+	svc  *service.Service
+	bus  pubsub.Bus
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, svc *service.Service, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		svc:  svc,
+		bus:  bus,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /changes", m.auth.RequireAuth(m.h.ListChanges, domain.ScopeProjectsRead, domain.ScopeSprintsRead, domain.ScopeBoardsRead, domain.ScopeTicketsRead))
+	mux.HandleFunc("GET /projects/{id}/changes", m.auth.RequireAuth(m.h.ListProjectChanges, domain.ScopeTicketsRead, domain.ScopeBoardsRead))
+}
+
+func (m *Module) StartSubscriber(ctx context.Context) {
+	slog.Info("[ChangeModule]: starting bus subscriber")
+	// events are published after their transaction commits, so waiting polls
+	// can read again right away instead of on their next recheck
+	handler := func(ctx context.Context, e pubsub.Event) error {
+		m.svc.Notify()
+		return nil
+	}
+
+	// Subscribe blocks until ctx is done
+	go m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Board), handler)
+	m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Ticket), handler)
+}
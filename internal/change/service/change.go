@@ -0,0 +1,151 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/change/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// MaxWait caps how long a poll is held open
+	MaxWait = 30 * time.Second
+
+	// changeBatchSize bounds one response; a client that falls behind
+	// catches up over several requests
+	changeBatchSize = 500
+
+	// recheckInterval catches changes the bus did not announce, e.g. ones
+	// written by another instance or straight to the database
+	recheckInterval = 2 * time.Second
+)
+
+var (
+	ErrInvalidCursor = domain.Invalid("since must not be negative").WithCode("invalid_cursor")
+	ErrCursorExpired = domain.Gone("changes after this cursor are no longer kept, reload and start from a new cursor").WithCode("cursor_expired")
+)
+
+// Notify wakes every poll waiting for changes so it reads again
+func (s *Service) Notify() {
+	s.changed.notify()
+}
+
+// ListProjectChanges returns the project's changes after q.Since. With nothing
+// to report it waits up to q.Wait for one before answering with an empty
+// list and a cursor moved past whatever happened elsewhere meanwhile.
+func (s *Service) ListProjectChanges(ctx context.Context, q domain.ProjectChangesSearchModel) (domain.ProjectChangesModel, error) {
+	if _, err := s.Project.GetProjectById(ctx, q.ProjectID); err != nil {
+		return domain.ProjectChangesModel{}, err
+	}
+
+	if !q.Since.Valid {
+		head, err := s.Repo.GetLatestChangeSeq(ctx)
+		if err != nil {
+			return domain.ProjectChangesModel{}, fmt.Errorf("get latest change seq: %w", err)
+		}
+		return newProjectChanges(q.ProjectID, head), nil
+	}
+	if q.Since.Int64 < 0 {
+		return domain.ProjectChangesModel{}, ErrInvalidCursor
+	}
+
+	wait := min(max(q.Wait, 0), MaxWait)
+	timeout := time.NewTimer(wait)
+	defer timeout.Stop()
+	recheck := time.NewTicker(recheckInterval)
+	defer recheck.Stop()
+
+	since := q.Since.Int64
+	for {
+		woken := s.changed.wait()
+
+		result, err := s.readProjectChanges(ctx, q.ProjectID, since)
+		if err != nil {
+			return domain.ProjectChangesModel{}, err
+		}
+		if result.HasMore || len(result.TicketIDs) > 0 || len(result.BoardColumnIDs) > 0 || wait == 0 {
+			return result, nil
+		}
+		since = result.Cursor
+
+		select {
+		case <-ctx.Done():
+			return domain.ProjectChangesModel{}, ctx.Err()
+		case <-timeout.C:
+			return result, nil
+		case <-woken:
+		case <-recheck.C:
+		}
+	}
+}
+
+// readProjectChanges takes the head before the project's rows so a change
+// committing in between is left for the next read instead of being skipped
+func (s *Service) readProjectChanges(ctx context.Context, projectID pgtype.UUID, since int64) (domain.ProjectChangesModel, error) {
+	head, err := s.Repo.GetLatestChangeSeq(ctx)
+	if err != nil {
+		return domain.ProjectChangesModel{}, fmt.Errorf("get latest change seq: %w", err)
+	}
+	result := newProjectChanges(projectID, max(head, since))
+	if head <= since {
+		return result, nil
+	}
+
+	rows, err := s.Repo.ListProjectChanges(ctx, repository.ListProjectChangesParams{
+		ProjectID: projectID,
+		Column2:   since,
+		Column3:   head,
+		Limit:     changeBatchSize,
+	})
+	if err != nil {
+		return domain.ProjectChangesModel{}, fmt.Errorf("list project changes: %w", err)
+	}
+	if err := s.checkCursor(ctx, since); err != nil {
+		return domain.ProjectChangesModel{}, err
+	}
+	if len(rows) == changeBatchSize {
+		result.Cursor = rows[len(rows)-1].Seq
+		result.HasMore = true
+	}
+
+	seen := make(map[pgtype.UUID]bool, len(rows))
+	for _, row := range rows {
+		if seen[row.EntityID] {
+			continue
+		}
+		seen[row.EntityID] = true
+		switch row.Entity {
+		case "ticket":
+			result.TicketIDs = append(result.TicketIDs, row.EntityID)
+		case "board_column":
+			result.BoardColumnIDs = append(result.BoardColumnIDs, row.EntityID)
+		}
+	}
+	return result, nil
+}
+
+// checkCursor fails when the retention may have trimmed changes after cursor,
+// which is when it lies before the oldest kept one. It runs after the read,
+// so a trim racing the read answers 410 rather than a page with a gap.
+func (s *Service) checkCursor(ctx context.Context, cursor int64) error {
+	oldest, err := s.Repo.GetOldestChangeSeq(ctx)
+	if err != nil {
+		return fmt.Errorf("get oldest change seq: %w", err)
+	}
+	if cursor < oldest-1 {
+		return ErrCursorExpired
+	}
+	return nil
+}
+
+func newProjectChanges(projectID pgtype.UUID, cursor int64) domain.ProjectChangesModel {
+	return domain.ProjectChangesModel{
+		ProjectID:      projectID,
+		Cursor:         cursor,
+		TicketIDs:      []pgtype.UUID{},
+		BoardColumnIDs: []pgtype.UUID{},
+	}
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/change/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+const (
+	DefaultFeedLimit = 100
+	MaxFeedLimit     = 1000
+)
+
+var ErrInvalidAfter = domain.Invalid("after must not be negative").WithCode("invalid_cursor")
+
+// ListChanges reads the user's feed after q.After. Like a project poll it
+// takes the head first, so an entry committing mid-read waits for the next
+// page rather than being passed by the cursor.
+func (s *Service) ListChanges(ctx context.Context, q domain.ChangesSearchModel) (domain.ChangeFeedModel, error) {
+	head, err := s.Repo.GetLatestChangeSeq(ctx)
+	if err != nil {
+		return domain.ChangeFeedModel{}, fmt.Errorf("get latest change seq: %w", err)
+	}
+	if !q.After.Valid {
+		return domain.ChangeFeedModel{Items: []domain.ChangeModel{}, Cursor: head}, nil
+	}
+	if q.After.Int64 < 0 {
+		return domain.ChangeFeedModel{}, ErrInvalidAfter
+	}
+
+	limit := q.Limit
+	if limit < 1 {
+		limit = DefaultFeedLimit
+	}
+	limit = min(limit, MaxFeedLimit)
+
+	result := domain.ChangeFeedModel{
+		Items:  []domain.ChangeModel{},
+		Cursor: max(head, q.After.Int64),
+	}
+	if head <= q.After.Int64 {
+		return result, nil
+	}
+
+	rows, err := s.Repo.ListUserChanges(ctx, repository.ListUserChangesParams{
+		UserID:  q.UserID,
+		Column2: q.After.Int64,
+		Column3: head,
+		Limit:   int32(limit),
+		Column5: httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		return domain.ChangeFeedModel{}, fmt.Errorf("list user changes: %w", err)
+	}
+	if err := s.checkCursor(ctx, q.After.Int64); err != nil {
+		return domain.ChangeFeedModel{}, err
+	}
+	if len(rows) == limit {
+		result.Cursor = rows[len(rows)-1].Seq
+		result.HasMore = true
+	}
+
+	result.Items = make([]domain.ChangeModel, len(rows))
+	for i, row := range rows {
+		result.Items[i] = domain.ChangeModel{
+			Seq:       row.Seq,
+			ProjectID: row.ProjectID,
+			Entity:    row.Entity,
+			EntityID:  row.EntityID,
+			Op:        row.Op,
+			CreatedAt: row.CreatedAt.Time,
+		}
+	}
+	return result, nil
+}
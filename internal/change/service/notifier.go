@@ -0,0 +1,28 @@
+package service
+
+import "sync"
+
+// notifier wakes every waiting poll at once. A waiter takes the channel
+// before it reads, so a change landing between the read and the wait still
+// closes the channel it is about to block on.
+type notifier struct {
+	mu sync.Mutex
+	ch chan struct{}
+}
+
+func newNotifier() *notifier {
+	return &notifier{ch: make(chan struct{})}
+}
+
+func (n *notifier) wait() <-chan struct{} {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return n.ch
+}
+
+func (n *notifier) notify() {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	close(n.ch)
+	n.ch = make(chan struct{})
+}
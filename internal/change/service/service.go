@@ -0,0 +1,22 @@
+package service
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/change/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+type Deps struct {
+	Repo    *repository.Queries
+	Project domain.ProjectReader
+}
+
+type Service struct {
+	Deps
+	changed *notifier
+}
+
+var _ domain.ChangeReader = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{Deps: d, changed: newNotifier()}
+}
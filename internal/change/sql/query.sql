@@ -0,0 +1,48 @@
+-- name: GetLatestChangeSeq :one
+-- seq is assigned in commit order, so every change up to the latest one is already visible
+SELECT
+  COALESCE(MAX(seq), 0)::bigint AS seq
+FROM
+  changes;
+
+-- name: GetOldestChangeSeq :one
+-- The retention trims the oldest changes, a cursor before this one may have missed some
+SELECT
+  COALESCE(MIN(seq), 0)::bigint AS seq
+FROM
+  changes;
+
+-- name: ListProjectChanges :many
+-- Reads the project's changes in (after, upto], oldest first
+SELECT
+  seq::bigint AS seq, entity, entity_id
+FROM
+  changes
+WHERE
+  project_id = $1
+  AND seq > $2::bigint
+  AND seq <= $3::bigint
+ORDER BY
+  seq
+LIMIT $4;
+
+-- name: ListUserChanges :many
+-- Reads changes in (after, upto] across every project the user is a member
+-- of, or every project of their orgs when $5 is NULL, deleted projects
+-- included so their removal reaches the client
+SELECT
+  c.seq::bigint AS seq, c.project_id, c.entity, c.entity_id, c.op, c.created_at
+FROM
+  changes c
+  JOIN projects p ON p.id = c.project_id
+  JOIN org_members om ON om.org_id = p.org_id
+WHERE
+  om.user_id = $1
+  AND ($5::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $5::uuid
+  ))
+  AND c.seq > $2::bigint
+  AND c.seq <= $3::bigint
+ORDER BY
+  c.seq
+LIMIT $4;
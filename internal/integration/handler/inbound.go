@@ -0,0 +1,64 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+)
+
+// maxInboundBody matches the limit of regular JSON request bodies
+const maxInboundBody = 1 << 20
+
+// ReceiveInboundWebhook godoc
+//
+//	@Summary		Receive webhook
+//	@ID				receiveInboundWebhook
+//	@Description	Applies a third party's JSON payload through the integration's mapping. Takes no bearer token; the X-Fluxis-Signature header must be "t=<unix seconds>,v1=<hex HMAC-SHA256 of "<t>.<raw body>" keyed with the integration secret>" and t must be within 5 minutes of the server clock.
+//	@Tags			integration
+//	@Accept			json
+//	@Produce		json
+//	@Param			integrationId		path		string	true	"Integration ID"
+//	@Param			X-Fluxis-Signature	header		string	true	"Delivery signature"
+//	@Success		200					{object}	domain.InboundDeliveryModel	"ticket updated"
+//	@Success		201					{object}	domain.InboundDeliveryModel	"ticket created"
+//	@Failure		400					{object}	httpx.ErrBlock
+//	@Failure		401					{object}	httpx.ErrBlock
+//	@Failure		404					{object}	httpx.ErrBlock
+//	@Failure		413					{object}	httpx.ErrBlock
+//	@Failure		422					{object}	httpx.ErrBlock
+//	@Router			/inbound/webhooks/{integrationId} [post]
+func (h *Handler) ReceiveInboundWebhook(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "integrationId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	// the signature covers the raw bytes, so the body is read as is
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxInboundBody))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			httpx.Handle(w, httpx.PayloadTooLarge("request body too large"))
+			return
+		}
+		httpx.Handle(w, httpx.BadRequest("could not read request body"))
+		return
+	}
+
+	delivery, err := h.svc.ReceiveInbound(r.Context(), id, r.Header.Get(webhook.SignatureHeader), body)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if delivery.Action == domain.InboundActionCreate {
+		httpx.Created(w, delivery)
+		return
+	}
+	httpx.OK(w, delivery)
+}
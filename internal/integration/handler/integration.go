@@ -0,0 +1,112 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// ListInboundIntegrations godoc
+//
+//	@Summary		List inbound integrations
+//	@ID				listInboundIntegrations
+//	@Description	Returns the signed webhook endpoints of a project, without their secrets
+//	@Tags			integration
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{array}		domain.InboundIntegrationModel
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/integrations [get]
+func (h *Handler) ListInboundIntegrations(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	items, err := h.svc.ListInboundIntegrations(r.Context(), projectID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, items)
+}
+
+// CreateInboundIntegration godoc
+//
+//	@Summary		Create inbound integration
+//	@ID				createInboundIntegration
+//	@Description	Creates a signed webhook endpoint that turns JSON payloads into ticket creates or updates, acting as the caller. The response carries the signing secret, it is not shown again. Deliveries go to POST /inbound/webhooks/{integrationId}.
+//	@Tags			integration
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string									true	"Project ID"
+//	@Param			body	body		domain.InboundIntegrationCreateModel	true	"Integration payload"
+//	@Success		201		{object}	domain.InboundIntegrationModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		403		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/integrations [post]
+func (h *Handler) CreateInboundIntegration(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.InboundIntegrationCreateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	integration, err := h.svc.CreateInboundIntegration(r.Context(), projectID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.Created(w, integration)
+}
+
+// DeleteInboundIntegration godoc
+//
+//	@Summary		Delete inbound integration
+//	@ID				deleteInboundIntegration
+//	@Description	Removes a webhook endpoint, later deliveries to it are answered with 404
+//	@Tags			integration
+//	@Param			id				path	string	true	"Project ID"
+//	@Param			integrationId	path	string	true	"Integration ID"
+//	@Success		204
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/integrations/{integrationId} [delete]
+func (h *Handler) DeleteInboundIntegration(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	id, err := httpx.PathUUID(r, "integrationId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if err := h.svc.DeleteInboundIntegration(r.Context(), projectID, id); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
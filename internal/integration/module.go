@@ -0,0 +1,30 @@
+package integration
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/integration/handler"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Module struct {
+	h    *handler.Handler
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /projects/{id}/integrations", m.auth.RequireAuth(m.h.ListInboundIntegrations, domain.ScopeProjectsRead))
+	mux.HandleFunc("POST /projects/{id}/integrations", m.auth.RequireAuth(m.h.CreateInboundIntegration, domain.ScopeProjectsWrite, domain.ScopeTicketsWrite))
+	mux.HandleFunc("DELETE /projects/{id}/integrations/{integrationId}", m.auth.RequireAuth(m.h.DeleteInboundIntegration, domain.ScopeProjectsWrite))
+
+	// deliveries authenticate with the integration's signature instead of a token
+	mux.HandleFunc("POST /inbound/webhooks/{integrationId}", m.h.ReceiveInboundWebhook)
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrSignatureMissing = domain.Unauthorized("missing " + webhook.SignatureHeader + " header").WithCode("signature_missing")
+	ErrSignatureExpired = domain.Unauthorized("signature timestamp is too old or too far in the future").WithCode("signature_expired")
+	ErrSignatureInvalid = domain.Unauthorized("signature does not match the payload").WithCode("signature_invalid")
+	ErrInvalidPayload   = domain.Invalid("payload must be a JSON object or array").WithCode("invalid_payload")
+)
+
+// ReceiveInbound verifies a delivery and applies it as the user who set the
+// integration up, so the ticket shows them as reporter and the usual checks
+// for priorities, content and lengths apply.
+func (s *Service) ReceiveInbound(ctx context.Context, id pgtype.UUID, signature string, body []byte) (domain.InboundDeliveryModel, error) {
+	integration, mapping, err := s.getIntegration(ctx, id)
+	if err != nil {
+		return domain.InboundDeliveryModel{}, err
+	}
+
+	if err := webhook.Verify(integration.Secret, signature, body, time.Now(), webhook.DefaultTolerance); err != nil {
+		switch {
+		case errors.Is(err, webhook.ErrMissingSignature):
+			return domain.InboundDeliveryModel{}, ErrSignatureMissing
+		case errors.Is(err, webhook.ErrExpiredSignature):
+			return domain.InboundDeliveryModel{}, ErrSignatureExpired
+		default:
+			return domain.InboundDeliveryModel{}, ErrSignatureInvalid
+		}
+	}
+
+	var payload any
+	dec := json.NewDecoder(bytes.NewReader(body))
+	// keep large ids and story points exact
+	dec.UseNumber()
+	if err := dec.Decode(&payload); err != nil {
+		return domain.InboundDeliveryModel{}, ErrInvalidPayload
+	}
+	switch payload.(type) {
+	case map[string]any, []any:
+	default:
+		return domain.InboundDeliveryModel{}, ErrInvalidPayload
+	}
+
+	ctx = httpx.WithUserID(ctx, integration.CreatedBy)
+	values := resolve(mapping, payload)
+
+	var result domain.InboundDeliveryModel
+	switch mapping.Action {
+	case domain.InboundActionCreate:
+		result.Ticket, err = s.createFromPayload(ctx, integration.ProjectID, values)
+	case domain.InboundActionUpdate:
+		result.Ticket, err = s.updateFromPayload(ctx, integration.ProjectID, mapping, payload, values)
+	}
+	if err != nil {
+		return domain.InboundDeliveryModel{}, err
+	}
+	result.Action = mapping.Action
+
+	if err := s.Repo.TouchInboundIntegration(ctx, id); err != nil {
+		slog.Warn("[Integration]: failed to record delivery", "id", id, "error", err)
+	}
+	return result, nil
+}
+
+func (s *Service) createFromPayload(ctx context.Context, projectID pgtype.UUID, values map[string]string) (domain.TicketModel, error) {
+	storyPoints, err := parseStoryPoints(values["storyPoints"])
+	if err != nil {
+		return domain.TicketModel{}, err
+	}
+	dueDate, err := parseDueDate(values["dueDate"])
+	if err != nil {
+		return domain.TicketModel{}, err
+	}
+
+	p := domain.TicketCreateModel{
+		Type:        values["type"],
+		Priority:    values["priority"],
+		Title:       values["title"],
+		Description: values["description"],
+		StoryPoints: storyPoints,
+		DueDate:     dueDate,
+	}
+	if err := httpx.Validate(p); err != nil {
+		return domain.TicketModel{}, unmappablePayload(err.Error())
+	}
+	return s.Ticket.CreateTicket(ctx, projectID, p)
+}
+
+func (s *Service) updateFromPayload(ctx context.Context, projectID pgtype.UUID, mapping domain.InboundMappingModel, payload any, values map[string]string) (domain.TicketModel, error) {
+	key, ok := lookup(payload, mapping.KeyPath)
+	if !ok || key == "" {
+		return domain.TicketModel{}, unmappablePayload("no ticket key at " + mapping.KeyPath)
+	}
+	ticket, err := s.Ticket.GetTicketByKey(ctx, projectID, key)
+	if err != nil {
+		return domain.TicketModel{}, err
+	}
+
+	storyPoints, err := parseStoryPoints(values["storyPoints"])
+	if err != nil {
+		return domain.TicketModel{}, err
+	}
+	dueDate, err := parseDueDate(values["dueDate"])
+	if err != nil {
+		return domain.TicketModel{}, err
+	}
+
+	p := domain.TicketUpdateModel{
+		Title:       values["title"],
+		Description: values["description"],
+		Type:        values["type"],
+		Priority:    values["priority"],
+		StoryPoints: storyPoints,
+		DueDate:     dueDate,
+	}
+	if err := httpx.Validate(p); err != nil {
+		return domain.TicketModel{}, unmappablePayload(err.Error())
+	}
+	return s.Ticket.UpdateTicket(ctx, ticket.ID, p)
+}
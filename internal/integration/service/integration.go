@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/integration/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrIntegrationNotFound = domain.NotFound("integration not found")
+
+// ListInboundIntegrations, like the other integration calls, is for project
+// admins
+func (s *Service) ListInboundIntegrations(ctx context.Context, projectID pgtype.UUID) ([]domain.InboundIntegrationModel, error) {
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return nil, err
+	}
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Repo.ListInboundIntegrations(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list inbound integrations: %w", err)
+	}
+
+	items := make([]domain.InboundIntegrationModel, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, toIntegrationModel(row))
+	}
+	return items, nil
+}
+
+// CreateInboundIntegration generates the signing secret and returns it; it is
+// not readable through the API afterwards.
+func (s *Service) CreateInboundIntegration(ctx context.Context, projectID pgtype.UUID, p domain.InboundIntegrationCreateModel) (domain.InboundIntegrationModel, error) {
+	userID := httpx.MustUserID(ctx)
+
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return domain.InboundIntegrationModel{}, err
+	}
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.InboundIntegrationModel{}, err
+	}
+	if err := checkMapping(p.Mapping); err != nil {
+		return domain.InboundIntegrationModel{}, err
+	}
+
+	mapping, err := json.Marshal(p.Mapping)
+	if err != nil {
+		return domain.InboundIntegrationModel{}, fmt.Errorf("encode mapping: %w", err)
+	}
+	secret, err := webhook.NewSecret()
+	if err != nil {
+		return domain.InboundIntegrationModel{}, fmt.Errorf("generate secret: %w", err)
+	}
+
+	row, err := s.Repo.CreateInboundIntegration(ctx, repository.CreateInboundIntegrationParams{
+		ID:        idgen.New(),
+		ProjectID: projectID,
+		Name:      p.Name,
+		Secret:    secret,
+		Mapping:   mapping,
+		CreatedBy: userID,
+	})
+	if err != nil {
+		return domain.InboundIntegrationModel{}, fmt.Errorf("create inbound integration: %w", err)
+	}
+
+	result := toIntegrationModel(row)
+	result.Secret = row.Secret
+	return result, nil
+}
+
+func (s *Service) DeleteInboundIntegration(ctx context.Context, projectID, id pgtype.UUID) error {
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return err
+	}
+
+	n, err := s.Repo.DeleteInboundIntegration(ctx, repository.DeleteInboundIntegrationParams{
+		ID:        id,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete inbound integration: %w", err)
+	}
+	if n == 0 {
+		return ErrIntegrationNotFound
+	}
+	return nil
+}
+
+func (s *Service) getIntegration(ctx context.Context, id pgtype.UUID) (repository.InboundIntegration, domain.InboundMappingModel, error) {
+	row, err := s.Repo.GetInboundIntegration(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.InboundIntegration{}, domain.InboundMappingModel{}, ErrIntegrationNotFound
+		}
+		return repository.InboundIntegration{}, domain.InboundMappingModel{}, fmt.Errorf("get inbound integration: %w", err)
+	}
+
+	var mapping domain.InboundMappingModel
+	if err := json.Unmarshal(row.Mapping, &mapping); err != nil {
+		return repository.InboundIntegration{}, domain.InboundMappingModel{}, fmt.Errorf("decode mapping: %w", err)
+	}
+	return row, mapping, nil
+}
+
+func toIntegrationModel(row repository.InboundIntegration) domain.InboundIntegrationModel {
+	// the mapping was validated before it was stored
+	var mapping domain.InboundMappingModel
+	_ = json.Unmarshal(row.Mapping, &mapping)
+
+	m := domain.InboundIntegrationModel{
+		ID:        row.ID,
+		ProjectID: row.ProjectID,
+		Name:      row.Name,
+		Mapping:   mapping,
+		CreatedBy: row.CreatedBy,
+		CreatedAt: row.CreatedAt.Time,
+		UpdatedAt: row.UpdatedAt.Time,
+	}
+	if row.LastDeliveryAt.Valid {
+		m.LastDeliveryAt = &row.LastDeliveryAt.Time
+	}
+	return m
+}
@@ -0,0 +1,146 @@
+package service
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+// mappableFields are the ticket fields a payload can fill
+var mappableFields = []string{"title", "description", "type", "priority", "storyPoints", "dueDate"}
+
+// requiredOnCreate have no server side default, a create mapping has to map
+// or default each of them
+var requiredOnCreate = []string{"title", "type", "priority"}
+
+func invalidMapping(msg string) error {
+	return domain.Unprocessable("invalid mapping: " + msg).WithCode("invalid_mapping")
+}
+
+func unmappablePayload(msg string) error {
+	return domain.Unprocessable("payload does not fit the mapping: " + msg).WithCode("payload_unmappable")
+}
+
+func checkMapping(m domain.InboundMappingModel) error {
+	for field, path := range m.Fields {
+		if !slices.Contains(mappableFields, field) {
+			return invalidMapping(fmt.Sprintf("unknown field %q, expected one of %s", field, strings.Join(mappableFields, ", ")))
+		}
+		if strings.TrimSpace(path) == "" {
+			return invalidMapping(fmt.Sprintf("field %q has an empty path", field))
+		}
+	}
+	for field := range m.Defaults {
+		if !slices.Contains(mappableFields, field) {
+			return invalidMapping(fmt.Sprintf("unknown default %q, expected one of %s", field, strings.Join(mappableFields, ", ")))
+		}
+	}
+
+	switch m.Action {
+	case domain.InboundActionCreate:
+		for _, field := range requiredOnCreate {
+			if m.Fields[field] == "" && m.Defaults[field] == "" {
+				return invalidMapping(fmt.Sprintf("a create mapping needs a path or default for %q", field))
+			}
+		}
+	case domain.InboundActionUpdate:
+		if strings.TrimSpace(m.KeyPath) == "" {
+			return invalidMapping("an update mapping needs a keyPath")
+		}
+		if len(m.Fields) == 0 {
+			return invalidMapping("an update mapping needs at least one field")
+		}
+	}
+	return nil
+}
+
+// resolve reads every mapped field from payload, falling back to its default.
+// Fields that end up empty are left out.
+func resolve(m domain.InboundMappingModel, payload any) map[string]string {
+	values := make(map[string]string, len(mappableFields))
+	for _, field := range mappableFields {
+		if path, ok := m.Fields[field]; ok {
+			if v, ok := lookup(payload, path); ok && v != "" {
+				values[field] = v
+				continue
+			}
+		}
+		if v := m.Defaults[field]; v != "" {
+			values[field] = v
+		}
+	}
+	return values
+}
+
+// lookup walks a dot separated path through decoded JSON. A leading "$." is
+// accepted for senders used to JSONPath.
+func lookup(payload any, path string) (string, bool) {
+	node := payload
+	for _, seg := range strings.Split(strings.TrimPrefix(path, "$."), ".") {
+		switch n := node.(type) {
+		case map[string]any:
+			v, ok := n[seg]
+			if !ok {
+				return "", false
+			}
+			node = v
+		case []any:
+			i, err := strconv.Atoi(seg)
+			if err != nil || i < 0 || i >= len(n) {
+				return "", false
+			}
+			node = n[i]
+		default:
+			return "", false
+		}
+	}
+	return stringify(node)
+}
+
+func stringify(v any) (string, bool) {
+	switch v := v.(type) {
+	case nil:
+		return "", false
+	case string:
+		return v, true
+	case json.Number:
+		return v.String(), true
+	case bool:
+		return strconv.FormatBool(v), true
+	default:
+		// objects and arrays land in the ticket as their JSON text
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", false
+		}
+		return string(b), true
+	}
+}
+
+func parseStoryPoints(v string) (int32, error) {
+	if v == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil || n < 0 || n > 1000 || n != float64(int32(n)) {
+		return 0, unmappablePayload(fmt.Sprintf("storyPoints %q is not a whole number", v))
+	}
+	return int32(n), nil
+}
+
+func parseDueDate(v string) (time.Time, error) {
+	if v == "" {
+		return time.Time{}, nil
+	}
+	for _, layout := range []string{time.RFC3339, time.DateOnly} {
+		if t, err := time.Parse(layout, v); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, unmappablePayload(fmt.Sprintf("dueDate %q is not a date", v))
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/integration/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+type Deps struct {
+	Repo    *repository.Queries
+	Project domain.ProjectReader
+	Ticket  interface {
+		domain.TicketReader
+		domain.TicketWriter
+	}
+}
+
+type Service struct {
+	Deps
+}
+
+var _ domain.InboundIntegrationReader = (*Service)(nil)
+var _ domain.InboundIntegrationWriter = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{d}
+}
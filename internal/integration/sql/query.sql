@@ -0,0 +1,38 @@
+-- name: CreateInboundIntegration :one
+INSERT INTO
+    inbound_integrations (project_id, name, secret, mapping, created_by, id)
+VALUES
+    ($1, $2, $3, $4, $5, $6)
+RETURNING
+    *;
+
+-- name: GetInboundIntegration :one
+SELECT
+    *
+FROM
+    inbound_integrations
+WHERE
+    id = $1;
+
+-- name: ListInboundIntegrations :many
+SELECT
+    *
+FROM
+    inbound_integrations
+WHERE
+    project_id = $1
+ORDER BY
+    created_at DESC;
+
+-- name: TouchInboundIntegration :exec
+UPDATE inbound_integrations
+SET
+    last_delivery_at = NOW()
+WHERE
+    id = $1;
+
+-- name: DeleteInboundIntegration :execrows
+DELETE FROM inbound_integrations
+WHERE
+    id = $1
+    AND project_id = $2;
@@ -0,0 +1,51 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// CheckIntegrity godoc
+//
+//	@Summary		Check data integrity
+//	@ID				checkIntegrity
+//	@Description	Looks for live columns under a deleted board, sprint or project, live tickets placed in a deleted column or a column of another board, and columns or boards sharing a position. Nothing is changed.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	domain.IntegrityReportModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/integrity [get]
+func (h *Handler) CheckIntegrity(w http.ResponseWriter, r *http.Request) {
+	report, err := h.svc.CheckIntegrity(r.Context())
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, report)
+}
+
+// FixIntegrity godoc
+//
+//	@Summary		Fix data integrity
+//	@ID				fixIntegrity
+//	@Description	Runs the integrity checks and fixes what they find in one transaction: orphaned columns are deleted, orphaned tickets move to their board's default column, and shared positions are re-spaced keeping the current order.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	domain.IntegrityReportModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/integrity/fix [post]
+func (h *Handler) FixIntegrity(w http.ResponseWriter, r *http.Request) {
+	report, err := h.svc.FixIntegrity(r.Context())
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, report)
+}
@@ -0,0 +1,42 @@
+package integrity
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/integrity/handler"
+	"github.com/dimasbaguspm/fluxis/internal/integrity/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Module struct {
+	h    *handler.Handler
+	svc  *service.Service
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, svc *service.Service, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		svc:  svc,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /admin/integrity", m.auth.RequireAuth(m.h.CheckIntegrity, domain.ScopeAdmin))
+	mux.HandleFunc("POST /admin/integrity/fix", m.auth.RequireAuth(m.h.FixIntegrity, domain.ScopeAdmin))
+}
+
+// StartChecker periodically runs the integrity checks, fixing what they find
+// when fix is set
+func (m *Module) StartChecker(ctx context.Context, interval time.Duration, fix bool) {
+	if interval <= 0 {
+		return
+	}
+	slog.Info("[IntegrityModule]: starting integrity checker", "interval", interval.String(), "fix", fix)
+	m.svc.StartChecker(ctx, interval, fix)
+}
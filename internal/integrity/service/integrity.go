@@ -0,0 +1,153 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/integrity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// sampleSize caps the ids a report lists per check
+const sampleSize = 100
+
+type check struct {
+	name string
+	find func(ctx context.Context, q *repository.Queries) ([]pgtype.UUID, int64, error)
+	fix  func(q *repository.Queries, ctx context.Context) (int64, error)
+}
+
+// checks run in this order. Orphaned columns go first since deleting them
+// orphans the tickets they hold, which the next check then places again.
+var checks = []check{
+	{
+		name: domain.IntegrityOrphanedColumns,
+		find: func(ctx context.Context, q *repository.Queries) ([]pgtype.UUID, int64, error) {
+			rows, err := q.ListOrphanedBoardColumns(ctx, sampleSize)
+			return sample(rows, err, func(r repository.ListOrphanedBoardColumnsRow) (pgtype.UUID, int64) { return r.ID, r.Total })
+		},
+		fix: (*repository.Queries).FixOrphanedBoardColumns,
+	},
+	{
+		name: domain.IntegrityOrphanedTickets,
+		find: func(ctx context.Context, q *repository.Queries) ([]pgtype.UUID, int64, error) {
+			rows, err := q.ListOrphanedTickets(ctx, sampleSize)
+			return sample(rows, err, func(r repository.ListOrphanedTicketsRow) (pgtype.UUID, int64) { return r.ID, r.Total })
+		},
+		fix: (*repository.Queries).FixOrphanedTickets,
+	},
+	{
+		name: domain.IntegrityDuplicateColumnPositions,
+		find: func(ctx context.Context, q *repository.Queries) ([]pgtype.UUID, int64, error) {
+			rows, err := q.ListBoardsWithDuplicateColumnPositions(ctx, sampleSize)
+			return sample(rows, err, func(r repository.ListBoardsWithDuplicateColumnPositionsRow) (pgtype.UUID, int64) {
+				return r.BoardID, r.Total
+			})
+		},
+		fix: (*repository.Queries).FixDuplicateColumnPositions,
+	},
+	{
+		name: domain.IntegrityDuplicateBoardPositions,
+		find: func(ctx context.Context, q *repository.Queries) ([]pgtype.UUID, int64, error) {
+			rows, err := q.ListSprintsWithDuplicateBoardPositions(ctx, sampleSize)
+			return sample(rows, err, func(r repository.ListSprintsWithDuplicateBoardPositionsRow) (pgtype.UUID, int64) {
+				return r.SprintID, r.Total
+			})
+		},
+		fix: (*repository.Queries).FixDuplicateBoardPositions,
+	},
+}
+
+// sample splits rows carrying a window count into their ids and the total
+func sample[R any](rows []R, err error, split func(R) (pgtype.UUID, int64)) ([]pgtype.UUID, int64, error) {
+	if err != nil {
+		return nil, 0, err
+	}
+	ids := make([]pgtype.UUID, len(rows))
+	var total int64
+	for i, row := range rows {
+		ids[i], total = split(row)
+	}
+	return ids, total, nil
+}
+
+// CheckIntegrity runs every check and changes nothing
+func (s *Service) CheckIntegrity(ctx context.Context) (domain.IntegrityReportModel, error) {
+	report := domain.IntegrityReportModel{CheckedAt: time.Now(), Mode: domain.IntegrityModeCheck}
+	for _, c := range checks {
+		result, err := find(ctx, s.Repo, c)
+		if err != nil {
+			return domain.IntegrityReportModel{}, err
+		}
+		report.Checks = append(report.Checks, result)
+	}
+	return report, nil
+}
+
+// FixIntegrity runs every check and fixes what it finds in one transaction,
+// so a failing fix leaves the data as it was
+func (s *Service) FixIntegrity(ctx context.Context) (domain.IntegrityReportModel, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return domain.IntegrityReportModel{}, fmt.Errorf("begin integrity fix: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	repo := s.Repo.WithTx(tx)
+	report := domain.IntegrityReportModel{CheckedAt: time.Now(), Mode: domain.IntegrityModeFix}
+	for _, c := range checks {
+		result, err := find(ctx, repo, c)
+		if err != nil {
+			return domain.IntegrityReportModel{}, err
+		}
+		if result.Fixed, err = c.fix(repo, ctx); err != nil {
+			return domain.IntegrityReportModel{}, fmt.Errorf("fix %s: %w", c.name, err)
+		}
+		report.Checks = append(report.Checks, result)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.IntegrityReportModel{}, fmt.Errorf("commit integrity fix: %w", err)
+	}
+	return report, nil
+}
+
+func find(ctx context.Context, q *repository.Queries, c check) (domain.IntegrityCheckModel, error) {
+	ids, total, err := c.find(ctx, q)
+	if err != nil {
+		return domain.IntegrityCheckModel{}, fmt.Errorf("check %s: %w", c.name, err)
+	}
+	return domain.IntegrityCheckModel{Check: c.name, Count: total, IDs: ids}, nil
+}
+
+// StartChecker runs the checks on every tick until ctx ends, fixing what they
+// find when fix is set, and logs every check that found something
+func (s *Service) StartChecker(ctx context.Context, interval time.Duration, fix bool) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			run := s.CheckIntegrity
+			if fix {
+				run = s.FixIntegrity
+			}
+			report, err := run(ctx)
+			if err != nil {
+				slog.Warn("[IntegrityModule]: integrity check failed", "error", err)
+				continue
+			}
+			for _, c := range report.Checks {
+				if c.Count > 0 || c.Fixed > 0 {
+					slog.Warn("[IntegrityModule]: integrity check found inconsistent rows", "check", c.Check, "count", c.Count, "fixed", c.Fixed)
+				}
+			}
+		}
+	}
+}
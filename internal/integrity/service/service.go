@@ -0,0 +1,30 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dimasbaguspm/fluxis/internal/integrity/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5"
+)
+
+// TxBeginner opens the transaction a fix runs in, the pool in practice
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type Deps struct {
+	Repo *repository.Queries
+	DB   TxBeginner
+}
+
+type Service struct {
+	Deps
+}
+
+var _ domain.IntegrityReader = (*Service)(nil)
+var _ domain.IntegrityWriter = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{d}
+}
@@ -0,0 +1,127 @@
+-- name: ListOrphanedTickets :many
+-- Live tickets placed in a column that has been deleted or belongs to another board than the ticket
+SELECT
+  t.id,
+  COUNT(*) OVER () AS total
+FROM
+  tickets t
+  JOIN board_columns bc ON bc.id = t.board_column_id
+WHERE
+  t.deleted_at IS NULL
+  AND (bc.deleted_at IS NOT NULL OR bc.board_id IS DISTINCT FROM t.board_id)
+ORDER BY
+  t.id
+LIMIT $1;
+
+-- name: ListOrphanedBoardColumns :many
+-- Live columns whose board, sprint or project has been deleted
+SELECT
+  bc.id,
+  COUNT(*) OVER () AS total
+FROM
+  board_columns bc
+  JOIN boards b ON b.id = bc.board_id
+  JOIN sprints s ON s.id = b.sprint_id
+  JOIN projects p ON p.id = s.project_id
+WHERE
+  bc.deleted_at IS NULL
+  AND (b.deleted_at IS NOT NULL OR s.deleted_at IS NOT NULL OR p.deleted_at IS NOT NULL)
+ORDER BY
+  bc.id
+LIMIT $1;
+
+-- name: ListBoardsWithDuplicateColumnPositions :many
+-- Boards where two live columns share a position
+SELECT
+  dup.board_id,
+  COUNT(*) OVER () AS total
+FROM (
+  SELECT DISTINCT board_id
+  FROM board_columns
+  WHERE deleted_at IS NULL
+  GROUP BY board_id, position
+  HAVING COUNT(*) > 1
+) dup
+ORDER BY
+  dup.board_id
+LIMIT $1;
+
+-- name: ListSprintsWithDuplicateBoardPositions :many
+-- Sprints where two live boards share a position
+SELECT
+  dup.sprint_id,
+  COUNT(*) OVER () AS total
+FROM (
+  SELECT DISTINCT sprint_id
+  FROM boards
+  WHERE deleted_at IS NULL
+  GROUP BY sprint_id, position
+  HAVING COUNT(*) > 1
+) dup
+ORDER BY
+  dup.sprint_id
+LIMIT $1;
+
+-- name: FixOrphanedBoardColumns :execrows
+-- Deletes live columns left under a deleted board, sprint or project, the same way deleting the column would
+UPDATE board_columns bc
+SET deleted_at = NOW(), is_default = false
+FROM boards b
+  JOIN sprints s ON s.id = b.sprint_id
+  JOIN projects p ON p.id = s.project_id
+WHERE
+  b.id = bc.board_id
+  AND bc.deleted_at IS NULL
+  AND (b.deleted_at IS NOT NULL OR s.deleted_at IS NOT NULL OR p.deleted_at IS NOT NULL);
+
+-- name: FixOrphanedTickets :execrows
+-- Moves orphaned tickets into the default column of their board, ranked after its own tickets like a
+-- column merge; tickets whose board has no live default column are taken off the board's columns
+WITH orphaned AS (
+  SELECT t.id, t.board_id, t.rank, t.ticket_number
+  FROM tickets t
+  JOIN board_columns bc ON bc.id = t.board_column_id
+  WHERE t.deleted_at IS NULL
+    AND (bc.deleted_at IS NOT NULL OR bc.board_id IS DISTINCT FROM t.board_id)
+), target AS (
+  SELECT
+    bc.board_id, bc.id,
+    COALESCE((SELECT MAX(x.rank) FROM tickets x WHERE x.board_column_id = bc.id AND x.deleted_at IS NULL), '') AS last_rank
+  FROM board_columns bc
+  WHERE bc.is_default AND bc.deleted_at IS NULL AND bc.board_id IN (SELECT board_id FROM orphaned)
+), ranked AS (
+  SELECT o.id, o.board_id, ROW_NUMBER() OVER (PARTITION BY o.board_id ORDER BY o.rank, o.ticket_number DESC) AS rn
+  FROM orphaned o
+)
+UPDATE tickets
+SET
+  board_column_id = target.id,
+  rank = CASE WHEN target.id IS NULL THEN tickets.rank ELSE target.last_rank || lpad(ranked.rn::text, 6, '0') || 'i' END
+FROM ranked
+  LEFT JOIN target ON target.board_id = ranked.board_id
+WHERE
+  tickets.id = ranked.id;
+
+-- name: FixDuplicateColumnPositions :execrows
+-- Re-spaces the live columns of every board with a shared position 1024 apart, ties broken by age
+UPDATE board_columns SET position = ranked.pos
+FROM (
+  SELECT id, (ROW_NUMBER() OVER (PARTITION BY board_id ORDER BY position, created_at, id) - 1) * 1024 AS pos
+  FROM board_columns
+  WHERE deleted_at IS NULL AND board_id IN (
+    SELECT board_id FROM board_columns WHERE deleted_at IS NULL GROUP BY board_id, position HAVING COUNT(*) > 1
+  )
+) ranked
+WHERE board_columns.id = ranked.id AND board_columns.position <> ranked.pos;
+
+-- name: FixDuplicateBoardPositions :execrows
+-- Renumbers the live boards of every sprint with a shared position from zero, ties broken by age
+UPDATE boards SET position = ranked.pos
+FROM (
+  SELECT id, ROW_NUMBER() OVER (PARTITION BY sprint_id ORDER BY position, created_at, id) - 1 AS pos
+  FROM boards
+  WHERE deleted_at IS NULL AND sprint_id IN (
+    SELECT sprint_id FROM boards WHERE deleted_at IS NULL GROUP BY sprint_id, position HAVING COUNT(*) > 1
+  )
+) ranked
+WHERE boards.id = ranked.id AND boards.position <> ranked.pos;
@@ -0,0 +1,110 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetPushPublicKey godoc
+//
+//	@Summary		Get the push public key
+//	@ID				getPushPublicKey
+//	@Description	Returns the VAPID application server key to pass to PushManager.subscribe
+//	@Tags			notification
+//	@Produce		json
+//	@Success		200	{object}	domain.PushPublicKeyModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		501	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/notifications/push/public-key [get]
+func (h *Handler) GetPushPublicKey(w http.ResponseWriter, r *http.Request) {
+	key, err := h.svc.GetPushPublicKey(r.Context())
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, key)
+}
+
+// ListPushSubscriptions godoc
+//
+//	@Summary		List push subscriptions
+//	@ID				listPushSubscriptions
+//	@Description	Returns the devices the caller receives push notifications on
+//	@Tags			notification
+//	@Produce		json
+//	@Success		200	{array}		domain.PushSubscriptionModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/notifications/push-subscriptions [get]
+func (h *Handler) ListPushSubscriptions(w http.ResponseWriter, r *http.Request) {
+	subs, err := h.svc.ListPushSubscriptions(r.Context(), httpx.MustUserID(r.Context()))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, subs)
+}
+
+// CreatePushSubscription godoc
+//
+//	@Summary		Register a push subscription
+//	@ID				createPushSubscription
+//	@Description	Registers the browser PushSubscription of the caller's device. Posting an endpoint that is already registered refreshes its keys and moves it to the caller
+//	@Tags			notification
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.PushSubscriptionCreateModel	true	"Push subscription payload"
+//	@Success		201		{object}	domain.PushSubscriptionModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		501		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/notifications/push-subscriptions [post]
+func (h *Handler) CreatePushSubscription(w http.ResponseWriter, r *http.Request) {
+	var req domain.PushSubscriptionCreateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	sub, err := h.svc.CreatePushSubscription(r.Context(), httpx.MustUserID(r.Context()), req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.Created(w, sub)
+}
+
+// DeletePushSubscription godoc
+//
+//	@Summary		Unregister a push subscription
+//	@ID				deletePushSubscription
+//	@Description	Stops push notifications to one of the caller's devices
+//	@Tags			notification
+//	@Param			subscriptionId	path	string	true	"Push subscription ID"
+//	@Success		204
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/notifications/push-subscriptions/{subscriptionId} [delete]
+func (h *Handler) DeletePushSubscription(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "subscriptionId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if err := h.svc.DeletePushSubscription(r.Context(), httpx.MustUserID(r.Context()), id); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
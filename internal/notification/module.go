@@ -0,0 +1,72 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/notification/handler"
+	"github.com/dimasbaguspm/fluxis/internal/notification/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+)
+
+type Module struct {
+	h    *handler.Handler
+	svc  *service.Service
+	bus  pubsub.Bus
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, svc *service.Service, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		svc:  svc,
+		bus:  bus,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /notifications/push/public-key", m.auth.RequireAuth(m.h.GetPushPublicKey, domain.ScopeNotificationsRead))
+	mux.HandleFunc("GET /notifications/push-subscriptions", m.auth.RequireAuth(m.h.ListPushSubscriptions, domain.ScopeNotificationsRead))
+	mux.HandleFunc("POST /notifications/push-subscriptions", m.auth.RequireAuth(m.h.CreatePushSubscription, domain.ScopeNotificationsWrite))
+	mux.HandleFunc("DELETE /notifications/push-subscriptions/{subscriptionId}", m.auth.RequireAuth(m.h.DeletePushSubscription, domain.ScopeNotificationsWrite))
+}
+
+func (m *Module) StartSubscriber(ctx context.Context) {
+	slog.Info("[NotificationModule]: starting bus subscriber")
+	// tell the assignee about tickets someone else created for them, and
+	// about their tickets the stale sweep flagged
+	ticketHandler := func(ctx context.Context, e pubsub.Event) error {
+		if e.Type == pubsub.TicketsStale {
+			var bulk domain.BulkEventModel
+			if err := httpx.DecodePayload(e.Payload, &bulk); err != nil {
+				return nil
+			}
+			return m.svc.NotifyStaleTickets(ctx, bulk.IDs)
+		}
+
+		var ticket domain.TicketModel
+		if err := httpx.DecodePayload(e.Payload, &ticket); err != nil {
+			return nil
+		}
+
+		switch e.Type {
+		case pubsub.TicketCreated:
+			if !ticket.AssigneeID.Valid || ticket.AssigneeID == ticket.ReporterID {
+				return nil
+			}
+			return m.svc.SendPush(ctx, ticket.AssigneeID, domain.PushMessageModel{
+				Title: fmt.Sprintf("%s was assigned to you", ticket.Key),
+				Body:  ticket.Title,
+				Tag:   ticket.Key,
+			})
+		}
+		return nil
+	}
+
+	m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Ticket), ticketHandler)
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/webpush"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// SendPush delivers m to every device the user registered. Delivery is best
+// effort: a failing device is logged and skipped, and devices the push
+// service reports as gone are forgotten so they are not tried again.
+func (s *Service) SendPush(ctx context.Context, userID pgtype.UUID, m domain.PushMessageModel) error {
+	if s.Push == nil {
+		return nil
+	}
+
+	payload, err := json.Marshal(m)
+	if err != nil {
+		return fmt.Errorf("encode push message: %w", err)
+	}
+
+	subs, err := s.Repo.ListPushSubscriptions(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("list push subscriptions: %w", err)
+	}
+
+	for _, sub := range subs {
+		// every remaining send would fail the same way
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		err := s.Push.Send(ctx, webpush.Subscription{
+			Endpoint: sub.Endpoint,
+			P256dh:   sub.P256dh,
+			Auth:     sub.Auth,
+		}, payload)
+
+		switch {
+		case errors.Is(err, webpush.ErrGone):
+			if err := s.Repo.DeletePushSubscriptionByEndpoint(ctx, sub.Endpoint); err != nil {
+				slog.Warn("[Notification]: failed to forget gone push subscription", "id", sub.ID, "error", err)
+			}
+		case err != nil:
+			slog.Warn("[Notification]: failed to deliver push", "id", sub.ID, "error", err)
+		}
+	}
+	return nil
+}
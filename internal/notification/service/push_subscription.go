@@ -0,0 +1,84 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/notification/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrPushDisabled             = domain.Unsupported("push notifications are not configured on this server").WithCode("push_disabled")
+	ErrPushSubscriptionNotFound = domain.NotFound("push subscription not found")
+)
+
+func toPushSubscriptionModel(p repository.PushSubscription) domain.PushSubscriptionModel {
+	return domain.PushSubscriptionModel{
+		ID:        p.ID,
+		Endpoint:  p.Endpoint,
+		UserAgent: p.UserAgent,
+		CreatedAt: p.CreatedAt.Time,
+		UpdatedAt: p.UpdatedAt.Time,
+	}
+}
+
+func (s *Service) GetPushPublicKey(ctx context.Context) (domain.PushPublicKeyModel, error) {
+	if s.Push == nil {
+		return domain.PushPublicKeyModel{}, ErrPushDisabled
+	}
+	return domain.PushPublicKeyModel{PublicKey: s.Push.PublicKey()}, nil
+}
+
+func (s *Service) ListPushSubscriptions(ctx context.Context, userID pgtype.UUID) ([]domain.PushSubscriptionModel, error) {
+	rows, err := s.Repo.ListPushSubscriptions(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("list push subscriptions: %w", err)
+	}
+
+	items := make([]domain.PushSubscriptionModel, len(rows))
+	for i, row := range rows {
+		items[i] = toPushSubscriptionModel(row)
+	}
+	return items, nil
+}
+
+// CreatePushSubscription registers the caller's device. Registering an
+// endpoint that is already known refreshes it instead of failing, browsers
+// hand out the same endpoint again after a key rotation or a re-login.
+func (s *Service) CreatePushSubscription(ctx context.Context, userID pgtype.UUID, p domain.PushSubscriptionCreateModel) (domain.PushSubscriptionModel, error) {
+	if s.Push == nil {
+		return domain.PushSubscriptionModel{}, ErrPushDisabled
+	}
+
+	sub, err := s.Repo.UpsertPushSubscription(ctx, repository.UpsertPushSubscriptionParams{
+		ID:        idgen.New(),
+		UserID:    userID,
+		Endpoint:  p.Endpoint,
+		P256dh:    p.Keys.P256dh,
+		Auth:      p.Keys.Auth,
+		UserAgent: p.UserAgent,
+	})
+	if err != nil {
+		return domain.PushSubscriptionModel{}, fmt.Errorf("upsert push subscription: %w", err)
+	}
+	return toPushSubscriptionModel(sub), nil
+}
+
+// DeletePushSubscription unregisters one of the caller's devices; another
+// user's subscription reads as not found
+func (s *Service) DeletePushSubscription(ctx context.Context, userID, id pgtype.UUID) error {
+	n, err := s.Repo.DeletePushSubscription(ctx, repository.DeletePushSubscriptionParams{
+		ID:     id,
+		UserID: userID,
+	})
+	if err != nil {
+		return fmt.Errorf("delete push subscription: %w", err)
+	}
+	if n == 0 {
+		return ErrPushSubscriptionNotFound
+	}
+	return nil
+}
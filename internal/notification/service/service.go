@@ -0,0 +1,24 @@
+package service
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/notification/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/webpush"
+)
+
+type Deps struct {
+	Repo   *repository.Queries
+	Ticket domain.TicketReader
+	Push   *webpush.Sender // nil while no VAPID key is configured
+}
+
+type Service struct {
+	Deps
+}
+
+var _ domain.NotificationReader = (*Service)(nil)
+var _ domain.NotificationWriter = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{d}
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// NotifyStaleTickets nudges the assignee of each ticket the stale sweep
+// flagged. Unassigned tickets and tickets gone since are skipped.
+func (s *Service) NotifyStaleTickets(ctx context.Context, ids []pgtype.UUID) error {
+	if s.Push == nil {
+		return nil
+	}
+
+	for _, id := range ids {
+		ticket, err := s.Ticket.GetTicket(ctx, id)
+		if err != nil {
+			slog.Warn("[Notification]: failed to read stale ticket", "id", id, "error", err)
+			continue
+		}
+		if !ticket.AssigneeID.Valid {
+			continue
+		}
+
+		if err := s.SendPush(ctx, ticket.AssigneeID, domain.PushMessageModel{
+			Title: fmt.Sprintf("%s has had no activity for a while", ticket.Key),
+			Body:  ticket.Title,
+			Tag:   ticket.Key,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+-- name: ListPushSubscriptions :many
+SELECT id, user_id, endpoint, p256dh, auth, user_agent, created_at, updated_at
+FROM push_subscriptions
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpsertPushSubscription :one
+-- An endpoint belongs to one device, so registering it again refreshes the
+-- keys and hands it to whoever is signed in on that device now
+INSERT INTO push_subscriptions (user_id, endpoint, p256dh, auth, user_agent, id)
+VALUES ($1, $2, $3, $4, $5, $6)
+ON CONFLICT (endpoint) DO UPDATE
+SET user_id = EXCLUDED.user_id,
+    p256dh = EXCLUDED.p256dh,
+    auth = EXCLUDED.auth,
+    user_agent = EXCLUDED.user_agent
+RETURNING id, user_id, endpoint, p256dh, auth, user_agent, created_at, updated_at;
+
+-- name: DeletePushSubscription :execrows
+DELETE FROM push_subscriptions
+WHERE id = $1 AND user_id = $2;
+
+-- name: DeletePushSubscriptionByEndpoint :exec
+DELETE FROM push_subscriptions
+WHERE endpoint = $1;
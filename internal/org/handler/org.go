@@ -6,17 +6,18 @@ import (
 
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
-	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // ListOrgs godoc
 //
 //	@Summary		List organisations with pagination
+//	@ID				listOrgs
 //	@Description	Returns paginated organisations with optional filtering and sorting
 //	@Tags			org
 //	@Produce		json
 //	@Param			query	query	domain.Organisations	false	"Search parameters: id (array), name (array), pageNumber, pageSize, sortBy, sortOrder"
 //	@Success		200	{object}	domain.OrganisationPagedModel
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last page"
 //	@Failure		401	{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/orgs [get]
@@ -38,12 +39,13 @@ func (h *Handler) ListOrgs(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.OK(w, result)
+	httpx.OKPage(w, r, result, result.Page, result.PageSize, result.TotalPages)
 }
 
 // CreateOrg godoc
 //
 //	@Summary		Create an organisation
+//	@ID				createOrg
 //	@Description	Creates a new organisation
 //	@Tags			org
 //	@Accept			json
@@ -74,6 +76,7 @@ func (h *Handler) CreateOrg(w http.ResponseWriter, r *http.Request) {
 // GetOrg godoc
 //
 //	@Summary		Get an organisation
+//	@ID				getOrg
 //	@Description	Returns a single organisation by ID
 //	@Tags			org
 //	@Produce		json
@@ -85,9 +88,9 @@ func (h *Handler) CreateOrg(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Router			/orgs/{id} [get]
 func (h *Handler) GetOrg(w http.ResponseWriter, r *http.Request) {
-	var id pgtype.UUID
-	if err := id.Scan(r.PathValue("id")); err != nil {
-		httpx.Handle(w, httpx.BadRequest("invalid org id"))
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
 		return
 	}
 
@@ -105,6 +108,7 @@ func (h *Handler) GetOrg(w http.ResponseWriter, r *http.Request) {
 // UpdateOrg godoc
 //
 //	@Summary		Update an organisation
+//	@ID				updateOrg
 //	@Description	Updates an organisation's name
 //	@Tags			org
 //	@Accept			json
@@ -118,9 +122,9 @@ func (h *Handler) GetOrg(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Router			/orgs/{id} [patch]
 func (h *Handler) UpdateOrg(w http.ResponseWriter, r *http.Request) {
-	var id pgtype.UUID
-	if err := id.Scan(r.PathValue("id")); err != nil {
-		httpx.Handle(w, httpx.BadRequest("invalid org id"))
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
 		return
 	}
 
@@ -142,6 +146,7 @@ func (h *Handler) UpdateOrg(w http.ResponseWriter, r *http.Request) {
 // DeleteOrg godoc
 //
 //	@Summary		Delete an organisation
+//	@ID				deleteOrg
 //	@Description	Soft-deletes an organisation by ID
 //	@Tags			org
 //	@Param			id	path	string	true	"Organisation ID"
@@ -152,9 +157,9 @@ func (h *Handler) UpdateOrg(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Router			/orgs/{id} [delete]
 func (h *Handler) DeleteOrg(w http.ResponseWriter, r *http.Request) {
-	var id pgtype.UUID
-	if err := id.Scan(r.PathValue("id")); err != nil {
-		httpx.Handle(w, httpx.BadRequest("invalid org id"))
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
 		return
 	}
 
@@ -5,18 +5,19 @@ import (
 
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
-	"github.com/jackc/pgx/v5/pgtype"
 )
 
 // ListOrgMembers godoc
 //
 //	@Summary		List organisation members with pagination
+//	@ID				listOrgMembers
 //	@Description	Returns paginated members of an organisation with optional filtering
 //	@Tags			org
 //	@Produce		json
 //	@Param			id		path	string								true	"Organisation ID"
 //	@Param			query	query	domain.OrganisationMembersSearchModel	false	"Search parameters: email, displayName, pageNumber, pageSize"
 //	@Success		200	{object}	domain.OrganisationMembersPagedModel
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last page"
 //	@Failure		400	{object}	httpx.ErrBlock
 //	@Failure		401	{object}	httpx.ErrBlock
 //	@Failure		404	{object}	httpx.ErrBlock
@@ -43,12 +44,13 @@ func (h *Handler) ListOrgMembers(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.OK(w, result)
+	httpx.OKPage(w, r, result, result.PageNumber, result.PageSize, result.TotalPages)
 }
 
 // AddOrgMember godoc
 //
 //	@Summary		Add a member to an organisation
+//	@ID				addOrgMember
 //	@Description	Adds a user to an organisation with a given role
 //	@Tags			org
 //	@Accept			json
@@ -62,9 +64,9 @@ func (h *Handler) ListOrgMembers(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Router			/orgs/{id}/members [post]
 func (h *Handler) AddOrgMember(w http.ResponseWriter, r *http.Request) {
-	var orgID pgtype.UUID
-	if err := orgID.Scan(r.PathValue("id")); err != nil {
-		httpx.Handle(w, httpx.BadRequest("invalid org id"))
+	orgID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
 		return
 	}
 
@@ -85,6 +87,7 @@ func (h *Handler) AddOrgMember(w http.ResponseWriter, r *http.Request) {
 // UpdateOrgMember godoc
 //
 //	@Summary		Update a member's role
+//	@ID				updateOrgMember
 //	@Description	Updates the role of a member within an organisation
 //	@Tags			org
 //	@Accept			json
@@ -99,15 +102,15 @@ func (h *Handler) AddOrgMember(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Router			/orgs/{id}/members/{userId} [patch]
 func (h *Handler) UpdateOrgMember(w http.ResponseWriter, r *http.Request) {
-	var orgID pgtype.UUID
-	if err := orgID.Scan(r.PathValue("id")); err != nil {
-		httpx.Handle(w, httpx.BadRequest("invalid org id"))
+	orgID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
 		return
 	}
 
-	var userID pgtype.UUID
-	if err := userID.Scan(r.PathValue("userId")); err != nil {
-		httpx.Handle(w, httpx.BadRequest("invalid member id"))
+	userID, err := httpx.PathUUID(r, "userId")
+	if err != nil {
+		httpx.Handle(w, err)
 		return
 	}
 
@@ -128,7 +131,8 @@ func (h *Handler) UpdateOrgMember(w http.ResponseWriter, r *http.Request) {
 // DeleteOrgMember godoc
 //
 //	@Summary		Delete a member from an organsiation
-//	@Description	Delete a user from an organisation
+//	@ID				deleteOrgMember
+//	@Description	Delete a user from an organisation and its projects. A project the user was the last admin of hands the admin role to the member who stays with the most standing, organisation admins first
 //	@Tags			org
 //	@Accept			json
 //	@Produce		json
@@ -141,15 +145,15 @@ func (h *Handler) UpdateOrgMember(w http.ResponseWriter, r *http.Request) {
 //	@Security		BearerAuth
 //	@Router			/orgs/{id}/members/{userId} [delete]
 func (h *Handler) DeleteOrgMember(w http.ResponseWriter, r *http.Request) {
-	var orgID pgtype.UUID
-	if err := orgID.Scan(r.PathValue("id")); err != nil {
-		httpx.Handle(w, httpx.BadRequest("invalid org id"))
+	orgID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
 		return
 	}
 
-	var userID pgtype.UUID
-	if err := userID.Scan(r.PathValue("userId")); err != nil {
-		httpx.Handle(w, httpx.BadRequest("invalid member id"))
+	userID, err := httpx.PathUUID(r, "userId")
+	if err != nil {
+		httpx.Handle(w, err)
 		return
 	}
 
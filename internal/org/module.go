@@ -16,22 +16,23 @@ type Module struct {
 	h        *handler.Handler
 	orgCache *orgcache.OrgCache
 	bus      pubsub.Bus
+	auth     *httpx.Authenticator
 }
 
-func NewModule(h *handler.Handler, c *orgcache.OrgCache, bus pubsub.Bus) *Module {
-	return &Module{h: h, orgCache: c, bus: bus}
+func NewModule(h *handler.Handler, c *orgcache.OrgCache, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
+	return &Module{h: h, orgCache: c, bus: bus, auth: auth}
 }
 
 func (m *Module) Routes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /orgs", httpx.RequireAuth(m.h.ListOrgs))
-	mux.HandleFunc("POST /orgs", httpx.RequireAuth(m.h.CreateOrg))
-	mux.HandleFunc("GET /orgs/{id}", httpx.RequireAuth(m.h.GetOrg))
-	mux.HandleFunc("PATCH /orgs/{id}", httpx.RequireAuth(m.h.UpdateOrg))
-	mux.HandleFunc("DELETE /orgs/{id}", httpx.RequireAuth(m.h.DeleteOrg))
-	mux.HandleFunc("GET /orgs/{id}/members", httpx.RequireAuth(m.h.ListOrgMembers))
-	mux.HandleFunc("POST /orgs/{id}/members", httpx.RequireAuth(m.h.AddOrgMember))
-	mux.HandleFunc("PATCH /orgs/{id}/members/{userId}", httpx.RequireAuth(m.h.UpdateOrgMember))
-	mux.HandleFunc("DELETE /orgs/{id}/members/{userId}", httpx.RequireAuth(m.h.DeleteOrgMember))
+	mux.HandleFunc("GET /orgs", m.auth.RequireAuth(m.h.ListOrgs, domain.ScopeOrgsRead))
+	mux.HandleFunc("POST /orgs", m.auth.RequireAuth(m.h.CreateOrg, domain.ScopeOrgsWrite))
+	mux.HandleFunc("GET /orgs/{id}", m.auth.RequireAuth(m.h.GetOrg, domain.ScopeOrgsRead))
+	mux.HandleFunc("PATCH /orgs/{id}", m.auth.RequireAuth(m.h.UpdateOrg, domain.ScopeOrgsWrite))
+	mux.HandleFunc("DELETE /orgs/{id}", m.auth.RequireAuth(m.h.DeleteOrg, domain.ScopeOrgsWrite))
+	mux.HandleFunc("GET /orgs/{id}/members", m.auth.RequireAuth(m.h.ListOrgMembers, domain.ScopeOrgsRead))
+	mux.HandleFunc("POST /orgs/{id}/members", m.auth.RequireAuth(m.h.AddOrgMember, domain.ScopeOrgsWrite))
+	mux.HandleFunc("PATCH /orgs/{id}/members/{userId}", m.auth.RequireAuth(m.h.UpdateOrgMember, domain.ScopeOrgsWrite))
+	mux.HandleFunc("DELETE /orgs/{id}/members/{userId}", m.auth.RequireAuth(m.h.DeleteOrgMember, domain.ScopeOrgsWrite))
 }
 
 func (m *Module) StartSubscriber(ctx context.Context) {
@@ -38,16 +38,17 @@ func (q *Queries) CountOrgMembers(ctx context.Context, arg CountOrgMembersParams
 
 const createOrg = `-- name: CreateOrg :one
 INSERT INTO
-    orgs (name, slug)
+    orgs (name, slug, id)
 VALUES
-    ($1, $2)
+    ($1, $2, $3)
 RETURNING
     id, name, slug, created_at, updated_at
 `
 
 type CreateOrgParams struct {
-	Name string `db:"name" json:"name"`
-	Slug string `db:"slug" json:"slug"`
+	Name string      `db:"name" json:"name"`
+	Slug string      `db:"slug" json:"slug"`
+	ID   pgtype.UUID `db:"id" json:"id"`
 }
 
 type CreateOrgRow struct {
@@ -59,7 +60,7 @@ type CreateOrgRow struct {
 }
 
 func (q *Queries) CreateOrg(ctx context.Context, arg CreateOrgParams) (CreateOrgRow, error) {
-	row := q.db.QueryRow(ctx, createOrg, arg.Name, arg.Slug)
+	row := q.db.QueryRow(ctx, createOrg, arg.Name, arg.Slug, arg.ID)
 	var i CreateOrgRow
 	err := row.Scan(
 		&i.ID,
@@ -237,6 +238,23 @@ func (q *Queries) GetOrgMember(ctx context.Context, arg GetOrgMemberParams) (Get
 	return i, err
 }
 
+const getSlugOwner = `-- name: GetSlugOwner :one
+SELECT
+    id
+FROM
+    orgs
+WHERE
+    slug = $1
+`
+
+// Deleted orgs are included, their slug stays reserved by the unique index
+func (q *Queries) GetSlugOwner(ctx context.Context, slug string) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getSlugOwner, slug)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
 const listOrg = `-- name: ListOrg :many
 SELECT
     id, name, slug, created_at, updated_at
@@ -391,7 +409,8 @@ ORDER BY
     CASE WHEN $3 = 'createdAt' AND $4 = 'asc' THEN created_at END ASC,
     CASE WHEN $3 = 'createdAt' AND $4 = 'desc' THEN created_at END DESC,
     CASE WHEN $3 = 'updatedAt' AND $4 = 'asc' THEN updated_at END ASC,
-    CASE WHEN $3 = 'updatedAt' AND $4 = 'desc' THEN updated_at END DESC
+    CASE WHEN $3 = 'updatedAt' AND $4 = 'desc' THEN updated_at END DESC,
+    id ASC
 LIMIT $5
 OFFSET (($6 - 1) * $5)
 `
@@ -417,6 +436,7 @@ type SearchOrganisationsRow struct {
 // Searches organisations with pagination support
 // Parameters: $1=idArray, $2=nameArray, $3=sortBy (name/createdAt/updatedAt), $4=sortOrder (asc/desc), $5=pageSize, $6=pageNumber
 // Defaults should be applied in service layer: sortBy=updatedAt, sortOrder=desc, pageSize=25, pageNumber=1
+// The service rejects any other sortBy/sortOrder; id keeps pages stable when sort values tie
 func (q *Queries) SearchOrganisations(ctx context.Context, arg SearchOrganisationsParams) ([]SearchOrganisationsRow, error) {
 	rows, err := q.db.Query(ctx, searchOrganisations,
 		arg.Column1,
@@ -468,8 +488,7 @@ const updateOrg = `-- name: UpdateOrg :one
 UPDATE orgs
 SET
     name = COALESCE(NULLIF($1, ''), name),
-    slug = COALESCE(NULLIF($2, ''), slug),
-    updated_at = NOW()
+    slug = COALESCE(NULLIF($2, ''), slug)
 WHERE
     id = $3
     AND deleted_at IS NULL
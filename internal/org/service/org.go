@@ -5,10 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
 	"github.com/dimasbaguspm/fluxis/internal/org/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 	"github.com/dimasbaguspm/fluxis/pkg/transformer"
 	"github.com/google/uuid"
@@ -18,9 +21,18 @@ import (
 )
 
 var (
-	ErrOrgNotFound       = httpx.NotFound("organisation not found")
-	ErrSlugIsTaken       = httpx.Conflict("slug has been taken")
-	ErrOrgMemberNotFound = httpx.NotFound("organisation member not found")
+	ErrOrgNotFound       = domain.NotFound("organisation not found")
+	ErrSlugIsTaken       = domain.Conflict("slug has been taken")
+	ErrOrgMemberNotFound = domain.NotFound("organisation member not found")
+)
+
+const (
+	// fallbackSlug stands in for names that give no slug, e.g. only emoji
+	fallbackSlug = "org"
+	// maxSlugLength leaves room for a suffix within the slug column
+	maxSlugLength = 240
+	// maxSlugAttempts bounds the suffixes tried before giving up
+	maxSlugAttempts = 50
 )
 
 func (s *Service) ListOrgs(ctx context.Context, q domain.OrganisationSearchModel) ([]domain.OrganisationModel, error) {
@@ -53,6 +65,9 @@ func (s *Service) ListOrgs(ctx context.Context, q domain.OrganisationSearchModel
 
 func (s *Service) SearchOrganisations(ctx context.Context, q domain.Organisations) (domain.OrganisationPagedModel, error) {
 	q.ApplyDefaults()
+	if err := q.ValidateSort(); err != nil {
+		return domain.OrganisationPagedModel{}, err
+	}
 
 	rows, err := s.Repo.SearchOrganisations(ctx, repository.SearchOrganisationsParams{
 		Column1: q.ID,
@@ -64,40 +79,29 @@ func (s *Service) SearchOrganisations(ctx context.Context, q domain.Organisation
 	})
 
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			emptyPage := domain.OrganisationPagedModel{}
-			return emptyPage.Empty(q.PageNumber, q.PageSize), nil
-		}
 		return domain.OrganisationPagedModel{}, fmt.Errorf("search organisations: %w", err)
 	}
 
-	var totalCount int64
-	items := make([]domain.OrganisationModel, 0, len(rows))
-
-	for _, row := range rows {
-		if totalCount == 0 {
-			totalCount = row.TotalCount
-		}
-		items = append(items, domain.OrganisationModel{
-			ID:        row.ID,
-			Name:      row.Name,
-			Slug:      row.Slug,
-			CreatedAt: row.CreatedAt.Time,
-			UpdatedAt: row.UpdatedAt.Time,
-		})
-	}
-
-	totalPages := int(totalCount) / q.PageSize
-	if int(totalCount)%q.PageSize != 0 {
-		totalPages++
-	}
+	page := pagination.FromRows(rows,
+		func(row repository.SearchOrganisationsRow) int64 { return row.TotalCount },
+		func(row repository.SearchOrganisationsRow) domain.OrganisationModel {
+			return domain.OrganisationModel{
+				ID:        row.ID,
+				Name:      row.Name,
+				Slug:      row.Slug,
+				CreatedAt: row.CreatedAt.Time,
+				UpdatedAt: row.UpdatedAt.Time,
+			}
+		},
+		q.PageNumber, q.PageSize)
 
 	return domain.OrganisationPagedModel{
-		Items:      items,
-		TotalCount: int(totalCount),
-		TotalPages: totalPages,
-		Page:       q.PageNumber,
-		PageSize:   q.PageSize,
+		Items:      page.Items,
+		TotalCount: page.TotalCount,
+		TotalPages: page.TotalPages,
+		Page:       page.PageNumber,
+		PageSize:   page.PageSize,
+		HasMore:    page.HasMore,
 	}, nil
 }
 
@@ -151,9 +155,14 @@ func (s *Service) GetOrgBySlug(ctx context.Context, slug string) (domain.Organis
 
 func (s *Service) CreateOrg(ctx context.Context, p domain.OrganisationCreateModel) (domain.OrganisationModel, error) {
 	userID := httpx.MustUserID(ctx)
+	slug, err := s.availableSlug(ctx, p.Name, pgtype.UUID{})
+	if err != nil {
+		return domain.OrganisationModel{}, err
+	}
 	org, err := s.Repo.CreateOrg(ctx, repository.CreateOrgParams{
+		ID:   idgen.New(),
 		Name: p.Name,
-		Slug: transformer.CreateSlug(p.Name),
+		Slug: slug,
 	})
 	if err != nil {
 		var pgErr *pgconn.PgError
@@ -192,15 +201,26 @@ func (s *Service) CreateOrg(ctx context.Context, p domain.OrganisationCreateMode
 }
 
 func (s *Service) UpdateOrg(ctx context.Context, id pgtype.UUID, p domain.OrganisationUpdateModel) (domain.OrganisationModel, error) {
+	var slug string
+	if p.Name != "" {
+		var err error
+		if slug, err = s.availableSlug(ctx, p.Name, id); err != nil {
+			return domain.OrganisationModel{}, err
+		}
+	}
 	org, err := s.Repo.UpdateOrg(ctx, repository.UpdateOrgParams{
 		ID:      id,
 		Column1: p.Name,
-		Column2: transformer.CreateSlug(p.Name),
+		Column2: slug,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return domain.OrganisationModel{}, ErrOrgNotFound
 		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.OrganisationModel{}, ErrSlugIsTaken
+		}
 		return domain.OrganisationModel{}, fmt.Errorf("update org: %w", err)
 	}
 
@@ -236,3 +256,32 @@ func (s *Service) DeleteOrg(ctx context.Context, id pgtype.UUID) error {
 
 	return nil
 }
+
+// availableSlug derives a slug from name and, when another org already has
+// it, appends the first free suffix: "acme", "acme-2", "acme-3". The org being
+// renamed may keep its own slug. Two requests racing for the same slug can
+// still collide on insert, which reports ErrSlugIsTaken.
+func (s *Service) availableSlug(ctx context.Context, name string, self pgtype.UUID) (string, error) {
+	base := transformer.CreateSlug(name)
+	if base == "" {
+		base = fallbackSlug
+	}
+	if r := []rune(base); len(r) > maxSlugLength {
+		base = strings.TrimRight(string(r[:maxSlugLength]), "-")
+	}
+
+	for n := 1; n <= maxSlugAttempts; n++ {
+		slug := base
+		if n > 1 {
+			slug = transformer.SuffixSlug(base, n)
+		}
+		owner, err := s.Repo.GetSlugOwner(ctx, slug)
+		if errors.Is(err, pgx.ErrNoRows) || (err == nil && self.Valid && owner == self) {
+			return slug, nil
+		}
+		if err != nil {
+			return "", fmt.Errorf("get slug owner: %w", err)
+		}
+	}
+	return "", ErrSlugIsTaken
+}
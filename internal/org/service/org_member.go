@@ -8,6 +8,7 @@ import (
 
 	"github.com/dimasbaguspm/fluxis/internal/org/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 	"github.com/dimasbaguspm/fluxis/pkg/syncx"
 	"github.com/google/uuid"
@@ -24,44 +25,27 @@ func (s *Service) ListMembers(ctx context.Context, orgId pgtype.UUID, q domain.O
 		Column3: q.Email,
 		Column4: q.DisplayName,
 		Limit:   int32(q.PageSize),
-		Offset:  int32((q.PageNumber - 1) * q.PageSize),
+		Offset:  pagination.Offset(q.PageNumber, q.PageSize),
 	})
 
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			emptyResult := domain.OrganisationMembersPagedModel{}
-			return emptyResult.Empty(q.PageNumber, q.PageSize), nil
-		}
-		emptyResult := domain.OrganisationMembersPagedModel{}
-		return emptyResult.Empty(q.PageNumber, q.PageSize), fmt.Errorf("get org members: %w", err)
-	}
-
-	totalCount := int64(0)
-	data := make([]domain.OrganisationMemberModel, 0, len(members))
-
-	for _, member := range members {
-		totalCount = member.TotalCount
-		data = append(data, domain.OrganisationMemberModel{
-			UserID:   member.UserID,
-			Name:     member.DisplayName,
-			Email:    member.Email,
-			Role:     string(member.Role),
-			JoinedAt: member.JoinedAt.Time,
-		})
-	}
-
-	totalPages := 0
-	if totalCount > 0 {
-		totalPages = int((totalCount + int64(q.PageSize) - 1) / int64(q.PageSize))
+		return domain.OrganisationMembersPagedModel{}, fmt.Errorf("get org members: %w", err)
 	}
 
-	return domain.OrganisationMembersPagedModel{
-		Items:      data,
-		TotalCount: int(totalCount),
-		TotalPages: totalPages,
-		PageNumber: q.PageNumber,
-		PageSize:   q.PageSize,
-	}, nil
+	page := pagination.FromRows(members,
+		func(member repository.ListOrgMembersRow) int64 { return member.TotalCount },
+		func(member repository.ListOrgMembersRow) domain.OrganisationMemberModel {
+			return domain.OrganisationMemberModel{
+				UserID:   member.UserID,
+				Name:     member.DisplayName,
+				Email:    member.Email,
+				Role:     string(member.Role),
+				JoinedAt: member.JoinedAt.Time,
+			}
+		},
+		q.PageNumber, q.PageSize)
+
+	return domain.OrganisationMembersPagedModel(page), nil
 }
 
 func (s *Service) AddMember(ctx context.Context, orgId pgtype.UUID, p domain.OrganisationMemberCreateModel) error {
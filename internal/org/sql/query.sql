@@ -1,8 +1,8 @@
 -- name: CreateOrg :one
 INSERT INTO
-    orgs (name, slug)
+    orgs (name, slug, id)
 VALUES
-    ($1, $2)
+    ($1, $2, $3)
 RETURNING
     id, name, slug, created_at, updated_at;
 
@@ -28,6 +28,15 @@ WHERE
 LIMIT
     1;
 
+-- name: GetSlugOwner :one
+-- Deleted orgs are included, their slug stays reserved by the unique index
+SELECT
+    id
+FROM
+    orgs
+WHERE
+    slug = $1;
+
 -- name: SlugExists :one
 SELECT EXISTS (
     SELECT 1 FROM orgs WHERE slug = $1 AND deleted_at IS NULL
@@ -37,8 +46,7 @@ SELECT EXISTS (
 UPDATE orgs
 SET
     name = COALESCE(NULLIF($1, ''), name),
-    slug = COALESCE(NULLIF($2, ''), slug),
-    updated_at = NOW()
+    slug = COALESCE(NULLIF($2, ''), slug)
 WHERE
     id = $3
     AND deleted_at IS NULL
@@ -57,6 +65,7 @@ WHERE
 -- Searches organisations with pagination support
 -- Parameters: $1=idArray, $2=nameArray, $3=sortBy (name/createdAt/updatedAt), $4=sortOrder (asc/desc), $5=pageSize, $6=pageNumber
 -- Defaults should be applied in service layer: sortBy=updatedAt, sortOrder=desc, pageSize=25, pageNumber=1
+-- The service rejects any other sortBy/sortOrder; id keeps pages stable when sort values tie
 WITH filtered_orgs AS (
   SELECT
     id, name, slug, created_at, updated_at,
@@ -77,7 +86,8 @@ ORDER BY
     CASE WHEN $3 = 'createdAt' AND $4 = 'asc' THEN created_at END ASC,
     CASE WHEN $3 = 'createdAt' AND $4 = 'desc' THEN created_at END DESC,
     CASE WHEN $3 = 'updatedAt' AND $4 = 'asc' THEN updated_at END ASC,
-    CASE WHEN $3 = 'updatedAt' AND $4 = 'desc' THEN updated_at END DESC
+    CASE WHEN $3 = 'updatedAt' AND $4 = 'desc' THEN updated_at END DESC,
+    id ASC
 LIMIT $5
 OFFSET (($6 - 1) * $5);
 
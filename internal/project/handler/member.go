@@ -0,0 +1,171 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// ListProjectMembers godoc
+//
+//	@Summary		List project members
+//	@ID				listProjectMembers
+//	@Description	Returns the users who belong to the project and their roles; any member can list them
+//	@Tags			project
+//	@Produce		json
+//	@Param			id		path	string								true	"Project ID"
+//	@Param			query	query	domain.ProjectMembersSearchModel	false	"Search parameters: userId, role, pageNumber, pageSize"
+//	@Success		200	{object}	domain.ProjectMembersPagedModel
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last page"
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/members [get]
+func (h *Handler) ListProjectMembers(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	req := domain.ProjectMembersSearchModel{
+		UserID:     httpx.QueryUUIDs(r, "userId"),
+		Role:       httpx.QueryString(r, "role"),
+		PageNumber: httpx.QueryNumber(r, "pageNumber"),
+		PageSize:   httpx.QueryNumber(r, "pageSize"),
+	}
+
+	result, err := h.svc.ListProjectMembers(r.Context(), id, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OKPage(w, r, result, result.PageNumber, result.PageSize, result.TotalPages)
+}
+
+// AddProjectMember godoc
+//
+//	@Summary		Add a project member
+//	@ID				addProjectMember
+//	@Description	Gives a member of the project's organisation a role in the project. Requires the admin role in the project
+//	@Tags			project
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Project ID"
+//	@Param			body	body		domain.ProjectMemberCreateModel	true	"Member payload"
+//	@Success		201		{object}	domain.ProjectMemberModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		403		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		409		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/members [post]
+func (h *Handler) AddProjectMember(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ProjectMemberCreateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	member, err := h.svc.AddProjectMember(r.Context(), id, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.Created(w, member)
+}
+
+// UpdateProjectMember godoc
+//
+//	@Summary		Change a project member's role
+//	@ID				updateProjectMember
+//	@Description	Changes the role of a project member. Requires the admin role in the project; the last admin cannot be demoted
+//	@Tags			project
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Project ID"
+//	@Param			userId	path		string							true	"User ID"
+//	@Param			body	body		domain.ProjectMemberUpdateModel	true	"Role payload"
+//	@Success		200		{object}	domain.ProjectMemberModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		403		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		409		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/members/{userId} [patch]
+func (h *Handler) UpdateProjectMember(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	userID, err := httpx.PathUUID(r, "userId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ProjectMemberUpdateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	member, err := h.svc.UpdateProjectMemberRole(r.Context(), id, userID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, member)
+}
+
+// RemoveProjectMember godoc
+//
+//	@Summary		Remove a project member
+//	@ID				removeProjectMember
+//	@Description	Takes a user out of the project. Admins can remove anyone and every member can remove themselves; the last admin cannot leave
+//	@Tags			project
+//	@Param			id		path	string	true	"Project ID"
+//	@Param			userId	path	string	true	"User ID"
+//	@Success		204
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		409	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/members/{userId} [delete]
+func (h *Handler) RemoveProjectMember(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	userID, err := httpx.PathUUID(r, "userId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if err := h.svc.RemoveProjectMember(r.Context(), id, userID); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
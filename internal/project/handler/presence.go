@@ -0,0 +1,106 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetProjectPresence godoc
+//
+//	@Summary		List project viewers
+//	@ID				getProjectPresence
+//	@Description	Returns the users who have the project open, as reported by their heartbeats within the last ttlSeconds
+//	@Tags			project
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.ProjectPresenceModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/presence [get]
+func (h *Handler) GetProjectPresence(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	presence, err := h.svc.GetProjectPresence(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, presence)
+}
+
+// TouchProjectPresence godoc
+//
+//	@Summary		Send a presence heartbeat
+//	@ID				touchProjectPresence
+//	@Description	Marks the caller as viewing the project, optionally on a board, and returns the current viewers. Clients repeat it well within ttlSeconds to stay listed
+//	@Tags			project
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string									true	"Project ID"
+//	@Param			body	body		domain.ProjectPresenceHeartbeatModel	false	"Board being viewed"
+//	@Success		200		{object}	domain.ProjectPresenceModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/presence [put]
+func (h *Handler) TouchProjectPresence(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ProjectPresenceHeartbeatModel
+	if r.ContentLength != 0 {
+		if err := httpx.DecodeAndValidate(r, &req); err != nil {
+			httpx.Handle(w, httpx.BadRequest(err.Error()))
+			return
+		}
+	}
+
+	presence, err := h.svc.TouchProjectPresence(r.Context(), id, httpx.MustUserID(r.Context()), req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, presence)
+}
+
+// LeaveProjectPresence godoc
+//
+//	@Summary		Leave a project
+//	@ID				leaveProjectPresence
+//	@Description	Removes the caller from the project's viewers right away instead of waiting for the heartbeat to expire
+//	@Tags			project
+//	@Param			id	path	string	true	"Project ID"
+//	@Success		204
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/presence [delete]
+func (h *Handler) LeaveProjectPresence(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if err := h.svc.LeaveProjectPresence(r.Context(), id, httpx.MustUserID(r.Context())); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,159 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// ListProjectPriorities godoc
+//
+//	@Summary		List project priorities
+//	@ID				listProjectPriorities
+//	@Description	Returns the project's priority levels ordered by position; ticket priorities must use one of these keys
+//	@Tags			project
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{array}		domain.ProjectPriorityModel
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/priorities [get]
+func (h *Handler) ListProjectPriorities(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	priorities, err := h.svc.ListProjectPriorities(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, priorities)
+}
+
+// CreateProjectPriority godoc
+//
+//	@Summary		Create a project priority
+//	@ID				createProjectPriority
+//	@Description	Adds a priority level to the project; without a position it is placed after the last level
+//	@Tags			project
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Project ID"
+//	@Param			body	body		domain.ProjectPriorityCreateModel	true	"Priority payload"
+//	@Success		201		{object}	domain.ProjectPriorityModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		409		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/priorities [post]
+func (h *Handler) CreateProjectPriority(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ProjectPriorityCreateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	priority, err := h.svc.CreateProjectPriority(r.Context(), id, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.Created(w, priority)
+}
+
+// UpdateProjectPriority godoc
+//
+//	@Summary		Update a project priority
+//	@ID				updateProjectPriority
+//	@Description	Renames, recolors or repositions a priority level; the key cannot change
+//	@Tags			project
+//	@Accept			json
+//	@Produce		json
+//	@Param			id			path		string								true	"Project ID"
+//	@Param			priorityId	path		string								true	"Priority ID"
+//	@Param			body		body		domain.ProjectPriorityUpdateModel	true	"Priority payload"
+//	@Success		200			{object}	domain.ProjectPriorityModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/priorities/{priorityId} [patch]
+func (h *Handler) UpdateProjectPriority(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	priorityID, err := httpx.PathUUID(r, "priorityId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ProjectPriorityUpdateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	priority, err := h.svc.UpdateProjectPriority(r.Context(), id, priorityID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, priority)
+}
+
+// DeleteProjectPriority godoc
+//
+//	@Summary		Delete a project priority
+//	@ID				deleteProjectPriority
+//	@Description	Removes a priority level. A level still used by tickets is refused unless replaceWith names the level those tickets move to
+//	@Tags			project
+//	@Param			id			path	string	true	"Project ID"
+//	@Param			priorityId	path	string	true	"Priority ID"
+//	@Param			replaceWith	query	string	false	"Key of the priority that takes over the deleted level's tickets"
+//	@Success		204
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		409	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/priorities/{priorityId} [delete]
+func (h *Handler) DeleteProjectPriority(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	priorityID, err := httpx.PathUUID(r, "priorityId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if err := h.svc.DeleteProjectPriority(r.Context(), id, priorityID, httpx.QueryString(r, "replaceWith")); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
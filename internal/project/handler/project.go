@@ -10,22 +10,28 @@ import (
 // ListProjects godoc
 //
 //	@Summary		List projects with pagination
+//	@ID				listProjects
 //	@Description	Returns paginated projects in an organisation with optional filtering
 //	@Tags			project
 //	@Produce		json
-//	@Param			query	query	domain.ProjectsSearchModel	false	"Search parameters: name, pageNumber, pageSize"
+//	@Param			query	query	domain.ProjectsSearchModel	false	"Search parameters: name (substring, or a case-insensitive exact match with exactName=true), includeSummary, includeDeleted (admin only), pageNumber, pageSize"
 //	@Success		200	{object}	domain.ProjectsPagedModel
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last page"
 //	@Failure		400	{object}	httpx.ErrBlock
 //	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/projects [get]
 func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
 	req := domain.ProjectsSearchModel{
-		ID:         httpx.QueryUUIDs(r, "id"),
-		OrgID:      httpx.QueryUUIDs(r, "orgId"),
-		Name:       httpx.QueryString(r, "name"),
-		PageNumber: httpx.QueryNumber(r, "pageNumber"),
-		PageSize:   httpx.QueryNumber(r, "pageSize"),
+		ID:             httpx.QueryUUIDs(r, "id"),
+		OrgID:          httpx.QueryUUIDs(r, "orgId"),
+		Name:           httpx.QueryString(r, "name"),
+		ExactName:      httpx.QueryBoolean(r, "exactName"),
+		IncludeSummary: httpx.QueryBoolean(r, "includeSummary"),
+		IncludeDeleted: httpx.QueryBoolean(r, "includeDeleted"),
+		PageNumber:     httpx.QueryNumber(r, "pageNumber"),
+		PageSize:       httpx.QueryNumber(r, "pageSize"),
 	}
 
 	result, err := h.svc.ListProjectsByOrgPaged(r.Context(), req)
@@ -34,12 +40,13 @@ func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.OK(w, result)
+	httpx.OKPage(w, r, result, result.PageNumber, result.PageSize, result.TotalPages)
 }
 
 // CreateProject godoc
 //
 //	@Summary		Create a project
+//	@ID				createProject
 //	@Description	Creates a new project in an organisation
 //	@Tags			project
 //	@Accept			json
@@ -50,6 +57,7 @@ func (h *Handler) ListProjects(w http.ResponseWriter, r *http.Request) {
 //	@Failure		400		{object}	httpx.ErrBlock
 //	@Failure		401		{object}	httpx.ErrBlock
 //	@Failure		409		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/projects [post]
 func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
@@ -74,9 +82,49 @@ func (h *Handler) CreateProject(w http.ResponseWriter, r *http.Request) {
 	httpx.Created(w, project)
 }
 
+// CreateProjects godoc
+//
+//	@Summary		Create projects in a batch
+//	@ID				createProjects
+//	@Description	Creates up to 50 projects in an organisation in one transaction, optionally copying the priority levels of a template project. When any item is rejected nothing is created and the error details carry the result of every item.
+//	@Tags			project
+//	@Accept			json
+//	@Produce		json
+//	@Param			orgId	query		string							true	"Organisation ID"
+//	@Param			body	body		domain.ProjectBatchCreateModel	true	"Batch payload"
+//	@Success		201		{object}	domain.ProjectBatchResultModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/batch [post]
+func (h *Handler) CreateProjects(w http.ResponseWriter, r *http.Request) {
+	orgID, err := httpx.QueryUUID(r, "orgId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ProjectBatchCreateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.svc.CreateProjects(r.Context(), orgID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.Created(w, result)
+}
+
 // GetProject godoc
 //
 //	@Summary		Get a project
+//	@ID				getProject
 //	@Description	Returns a single project by ID
 //	@Tags			project
 //	@Produce		json
@@ -106,6 +154,7 @@ func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
 // UpdateProject godoc
 //
 //	@Summary		Update a project
+//	@ID				updateProject
 //	@Description	Updates a project's name and description
 //	@Tags			project
 //	@Accept			json
@@ -116,6 +165,7 @@ func (h *Handler) GetProject(w http.ResponseWriter, r *http.Request) {
 //	@Failure		400	{object}	httpx.ErrBlock
 //	@Failure		401	{object}	httpx.ErrBlock
 //	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/projects/{id} [patch]
 func (h *Handler) UpdateProject(w http.ResponseWriter, r *http.Request) {
@@ -140,9 +190,61 @@ func (h *Handler) UpdateProject(w http.ResponseWriter, r *http.Request) {
 	httpx.OK(w, project)
 }
 
+// UpsertProject godoc
+//
+//	@Summary		Create or replace a project
+//	@ID				upsertProject
+//	@Description	Idempotently creates a project under the given ID, or updates its name, description and visibility when it already exists. Intended for import flows that replay the same payload
+//	@Tags			project
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Project ID"
+//	@Param			orgId	query		string						true	"Organisation ID"
+//	@Param			body	body		domain.ProjectCreateModel	true	"Project payload"
+//	@Success		200		{object}	domain.ProjectModel
+//	@Success		201		{object}	domain.ProjectModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		409		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id} [put]
+func (h *Handler) UpsertProject(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	orgID, err := httpx.QueryUUID(r, "orgId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ProjectCreateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	project, created, err := h.svc.UpsertProject(r.Context(), id, orgID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if created {
+		httpx.Created(w, project)
+		return
+	}
+	httpx.OK(w, project)
+}
+
 // UpdateProjectVisibility godoc
 //
 //	@Summary		Update project visibility
+//	@ID				updateProjectVisibility
 //	@Description	Changes a project's visibility (public/private)
 //	@Tags			project
 //	@Accept			json
@@ -177,9 +279,118 @@ func (h *Handler) UpdateProjectVisibility(w http.ResponseWriter, r *http.Request
 	httpx.OK(w, project)
 }
 
+// ListProjectStatuses godoc
+//
+//	@Summary		List project statuses
+//	@ID				listProjectStatuses
+//	@Description	Returns every project status with its meaning and the statuses a project may move to from it, in lifecycle order
+//	@Tags			project
+//	@Produce		json
+//	@Success		200	{array}		domain.ProjectStatusModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/statuses [get]
+func (h *Handler) ListProjectStatuses(w http.ResponseWriter, r *http.Request) {
+	httpx.OK(w, domain.ProjectStatusModels())
+}
+
+// ActivateProject godoc
+//
+//	@Summary		Activate a project
+//	@ID				activateProject
+//	@Description	Moves a paused or archived project back to active
+//	@Tags			project
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.ProjectModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		409	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/activate [post]
+func (h *Handler) ActivateProject(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	project, err := h.svc.ActivateProject(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, project)
+}
+
+// PauseProject godoc
+//
+//	@Summary		Pause a project
+//	@ID				pauseProject
+//	@Description	Moves an active project to paused
+//	@Tags			project
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.ProjectModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		409	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/pause [post]
+func (h *Handler) PauseProject(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	project, err := h.svc.PauseProject(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, project)
+}
+
+// ArchiveProject godoc
+//
+//	@Summary		Archive a project
+//	@ID				archiveProject
+//	@Description	Moves an active or paused project to archived
+//	@Tags			project
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.ProjectModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		409	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/archive [post]
+func (h *Handler) ArchiveProject(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	project, err := h.svc.ArchiveProject(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, project)
+}
+
 // DeleteProject godoc
 //
 //	@Summary		Delete a project
+//	@ID				deleteProject
 //	@Description	Soft deletes a project
 //	@Tags			project
 //	@Param			id	path	string	true	"Project ID"
@@ -203,3 +414,104 @@ func (h *Handler) DeleteProject(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RestoreProject godoc
+//
+//	@Summary		Restore a deleted project
+//	@ID				restoreProject
+//	@Description	Clears the deletion of a soft-deleted project, bringing back its sprints, boards and tickets with it. Refused with 409 when the project is not deleted (not_deleted) or its organisation is (parent_deleted)
+//	@Tags			project
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.ProjectModel
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		409	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/restore [post]
+func (h *Handler) RestoreProject(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	project, err := h.svc.RestoreProject(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, project)
+}
+
+// GetProjectUIState godoc
+//
+//	@Summary		Get project UI state
+//	@ID				getProjectUIState
+//	@Description	Returns the caller's saved UI preferences for a project (collapsed columns, sort choice, ...). An empty object is returned when nothing was saved
+//	@Tags			project
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.ProjectUIStateModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/ui-state [get]
+func (h *Handler) GetProjectUIState(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	state, err := h.svc.GetProjectUIState(r.Context(), id, httpx.MustUserID(r.Context()))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, state)
+}
+
+// UpdateProjectUIState godoc
+//
+//	@Summary		Replace project UI state
+//	@ID				updateProjectUIState
+//	@Description	Stores the caller's UI preferences for a project as an opaque JSON object (max 16KB)
+//	@Tags			project
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string								true	"Project ID"
+//	@Param			body	body		domain.ProjectUIStateUpdateModel	true	"UI state payload"
+//	@Success		200		{object}	domain.ProjectUIStateModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		413		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/ui-state [put]
+func (h *Handler) UpdateProjectUIState(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.ProjectUIStateUpdateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	state, err := h.svc.UpdateProjectUIState(r.Context(), id, httpx.MustUserID(r.Context()), req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, state)
+}
@@ -9,30 +9,64 @@ import (
 	"github.com/dimasbaguspm/fluxis/internal/project/handler"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/jsonapi"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 )
 
 type Module struct {
-	h              *handler.Handler
-	projectCache   *projectcache.ProjectCache
-	bus            pubsub.Bus
+	h            *handler.Handler
+	projectCache *projectcache.ProjectCache
+	bus          pubsub.Bus
+	auth         *httpx.Authenticator
 }
 
-func NewModule(h *handler.Handler, c *projectcache.ProjectCache, bus pubsub.Bus) *Module {
+func NewModule(h *handler.Handler, c *projectcache.ProjectCache, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
 	return &Module{
 		h:            h,
 		projectCache: c,
 		bus:          bus,
+		auth:         auth,
 	}
 }
 
+// projectResource and projectStatusResource describe the responses served as
+// JSON:API documents to clients that negotiate application/vnd.api+json
+var (
+	projectResource = jsonapi.Resource{
+		Type:          "projects",
+		Relationships: map[string]string{"orgId": "orgs"},
+		SelfPrefix:    "/projects/",
+	}
+	projectStatusResource = jsonapi.Resource{Type: "project-statuses", IDField: "status"}
+)
+
 func (m *Module) Routes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /projects", httpx.RequireAuth(m.h.ListProjects))
-	mux.HandleFunc("POST /projects", httpx.RequireAuth(m.h.CreateProject))
-	mux.HandleFunc("GET /projects/{id}", httpx.RequireAuth(m.h.GetProject))
-	mux.HandleFunc("PATCH /projects/{id}", httpx.RequireAuth(m.h.UpdateProject))
-	mux.HandleFunc("PATCH /projects/{id}/visibility", httpx.RequireAuth(m.h.UpdateProjectVisibility))
-	mux.HandleFunc("DELETE /projects/{id}", httpx.RequireAuth(m.h.DeleteProject))
+	mux.HandleFunc("GET /projects", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.ListProjects, domain.ScopeProjectsRead)))
+	mux.HandleFunc("POST /projects", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.CreateProject, domain.ScopeProjectsWrite)))
+	mux.HandleFunc("POST /projects/batch", m.auth.RequireAuth(m.h.CreateProjects, domain.ScopeProjectsWrite))
+	mux.HandleFunc("GET /projects/statuses", jsonapi.Wrap(projectStatusResource, m.auth.RequireAuth(m.h.ListProjectStatuses, domain.ScopeProjectsRead)))
+	mux.HandleFunc("GET /projects/{id}", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.GetProject, domain.ScopeProjectsRead)))
+	mux.HandleFunc("PUT /projects/{id}", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.UpsertProject, domain.ScopeProjectsWrite)))
+	mux.HandleFunc("PATCH /projects/{id}", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.UpdateProject, domain.ScopeProjectsWrite)))
+	mux.HandleFunc("PATCH /projects/{id}/visibility", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.UpdateProjectVisibility, domain.ScopeProjectsWrite)))
+	mux.HandleFunc("POST /projects/{id}/activate", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.ActivateProject, domain.ScopeProjectsWrite)))
+	mux.HandleFunc("POST /projects/{id}/pause", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.PauseProject, domain.ScopeProjectsWrite)))
+	mux.HandleFunc("POST /projects/{id}/archive", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.ArchiveProject, domain.ScopeProjectsWrite)))
+	mux.HandleFunc("GET /projects/{id}/ui-state", m.auth.RequireAuth(m.h.GetProjectUIState, domain.ScopeProjectsRead))
+	mux.HandleFunc("PUT /projects/{id}/ui-state", m.auth.RequireAuth(m.h.UpdateProjectUIState, domain.ScopeProjectsWrite))
+	mux.HandleFunc("GET /projects/{id}/presence", m.auth.RequireAuth(m.h.GetProjectPresence, domain.ScopeProjectsRead))
+	mux.HandleFunc("PUT /projects/{id}/presence", m.auth.RequireAuth(m.h.TouchProjectPresence, domain.ScopeProjectsRead))
+	mux.HandleFunc("DELETE /projects/{id}/presence", m.auth.RequireAuth(m.h.LeaveProjectPresence, domain.ScopeProjectsRead))
+	mux.HandleFunc("DELETE /projects/{id}", m.auth.RequireAuth(m.h.DeleteProject, domain.ScopeProjectsWrite))
+	mux.HandleFunc("POST /projects/{id}/restore", jsonapi.Wrap(projectResource, m.auth.RequireAuth(m.h.RestoreProject, domain.ScopeProjectsWrite)))
+	mux.HandleFunc("GET /projects/{id}/priorities", m.auth.RequireAuth(m.h.ListProjectPriorities, domain.ScopeProjectsRead))
+	mux.HandleFunc("POST /projects/{id}/priorities", m.auth.RequireAuth(m.h.CreateProjectPriority, domain.ScopeProjectsWrite))
+	mux.HandleFunc("PATCH /projects/{id}/priorities/{priorityId}", m.auth.RequireAuth(m.h.UpdateProjectPriority, domain.ScopeProjectsWrite))
+	mux.HandleFunc("DELETE /projects/{id}/priorities/{priorityId}", m.auth.RequireAuth(m.h.DeleteProjectPriority, domain.ScopeProjectsWrite))
+	mux.HandleFunc("GET /projects/{id}/members", m.auth.RequireAuth(m.h.ListProjectMembers, domain.ScopeProjectsRead))
+	mux.HandleFunc("POST /projects/{id}/members", m.auth.RequireAuth(m.h.AddProjectMember, domain.ScopeProjectsWrite))
+	mux.HandleFunc("PATCH /projects/{id}/members/{userId}", m.auth.RequireAuth(m.h.UpdateProjectMember, domain.ScopeProjectsWrite))
+	mux.HandleFunc("DELETE /projects/{id}/members/{userId}", m.auth.RequireAuth(m.h.RemoveProjectMember, domain.ScopeProjectsWrite))
 }
 
 func (m *Module) StartSubscriber(ctx context.Context) {
@@ -44,7 +78,8 @@ func (m *Module) StartSubscriber(ctx context.Context) {
 		}
 
 		switch e.Type {
-		case pubsub.ProjectCreated, pubsub.ProjectUpdated, pubsub.ProjectDeleted, pubsub.ProjectVisibilityUpdated:
+		case pubsub.ProjectCreated, pubsub.ProjectUpdated, pubsub.ProjectDeleted, pubsub.ProjectRestored, pubsub.ProjectVisibilityUpdated,
+			pubsub.ProjectActivated, pubsub.ProjectPaused, pubsub.ProjectArchived:
 			m.projectCache.InvalidateSingleProject(ctx, project.ID)
 			m.projectCache.InvalidateSingleProjectByKey(ctx, project.OrgID, project.Key)
 			m.projectCache.InvalidatePagedProjects(ctx)
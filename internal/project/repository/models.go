@@ -11,6 +11,92 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type ProjectRole string
+
+const (
+	ProjectRoleAdmin  ProjectRole = "admin"
+	ProjectRoleMember ProjectRole = "member"
+	ProjectRoleViewer ProjectRole = "viewer"
+)
+
+func (e *ProjectRole) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProjectRole(s)
+	case string:
+		*e = ProjectRole(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProjectRole: %T", src)
+	}
+	return nil
+}
+
+type NullProjectRole struct {
+	ProjectRole ProjectRole `json:"project_role"`
+	Valid       bool        `json:"valid"` // Valid is true if ProjectRole is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProjectRole) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProjectRole, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProjectRole.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProjectRole) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProjectRole), nil
+}
+
+type ProjectStatus string
+
+const (
+	ProjectStatusActive   ProjectStatus = "active"
+	ProjectStatusPaused   ProjectStatus = "paused"
+	ProjectStatusArchived ProjectStatus = "archived"
+)
+
+func (e *ProjectStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ProjectStatus(s)
+	case string:
+		*e = ProjectStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ProjectStatus: %T", src)
+	}
+	return nil
+}
+
+type NullProjectStatus struct {
+	ProjectStatus ProjectStatus `json:"project_status"`
+	Valid         bool          `json:"valid"` // Valid is true if ProjectStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullProjectStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.ProjectStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ProjectStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullProjectStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ProjectStatus), nil
+}
+
 type ProjectVisibility string
 
 const (
@@ -63,4 +149,30 @@ type Project struct {
 	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 	DeletedAt   pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	Status      ProjectStatus      `db:"status" json:"status"`
+}
+
+type ProjectMember struct {
+	ProjectID pgtype.UUID        `db:"project_id" json:"project_id"`
+	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
+	Role      ProjectRole        `db:"role" json:"role"`
+	JoinedAt  pgtype.Timestamptz `db:"joined_at" json:"joined_at"`
+}
+
+type ProjectPriority struct {
+	ID        pgtype.UUID        `db:"id" json:"id"`
+	ProjectID pgtype.UUID        `db:"project_id" json:"project_id"`
+	Key       string             `db:"key" json:"key"`
+	Name      string             `db:"name" json:"name"`
+	Color     string             `db:"color" json:"color"`
+	Position  int32              `db:"position" json:"position"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
+
+type ProjectUiState struct {
+	UserID    pgtype.UUID        `db:"user_id" json:"user_id"`
+	ProjectID pgtype.UUID        `db:"project_id" json:"project_id"`
+	State     []byte             `db:"state" json:"state"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
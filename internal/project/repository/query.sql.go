@@ -11,10 +11,46 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const clearProjectPriorities = `-- name: ClearProjectPriorities :exec
+DELETE FROM project_priorities
+WHERE project_id = $1
+`
+
+// Drops every level of a project that no ticket uses yet, e.g. the defaults of a project about to copy a template
+func (q *Queries) ClearProjectPriorities(ctx context.Context, projectID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, clearProjectPriorities, projectID)
+	return err
+}
+
+const copyProjectPriorities = `-- name: CopyProjectPriorities :exec
+INSERT INTO project_priorities (project_id, key, name, color, position)
+SELECT $1::uuid, key, name, color, position
+FROM project_priorities
+WHERE project_id = $2
+`
+
+type CopyProjectPrioritiesParams struct {
+	Column1   pgtype.UUID `db:"column_1" json:"column_1"`
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+}
+
+// Gives project $1 the priority levels of project $2, keys, names, colors and order included
+func (q *Queries) CopyProjectPriorities(ctx context.Context, arg CopyProjectPrioritiesParams) error {
+	_, err := q.db.Exec(ctx, copyProjectPriorities, arg.Column1, arg.ProjectID)
+	return err
+}
+
 const createProject = `-- name: CreateProject :one
-INSERT INTO projects (org_id, key, name, description, visibility)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+WITH created AS (
+  INSERT INTO projects (org_id, key, name, description, visibility, status, id)
+  VALUES ($1, $2, $3, $4, $5, $6, $7)
+  RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
+), creator AS (
+  INSERT INTO project_members (project_id, user_id, role)
+  SELECT id, $8::uuid, 'admin' FROM created WHERE $8::uuid IS NOT NULL
+)
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
+FROM created
 `
 
 type CreateProjectParams struct {
@@ -23,8 +59,12 @@ type CreateProjectParams struct {
 	Name        string            `db:"name" json:"name"`
 	Description pgtype.Text       `db:"description" json:"description"`
 	Visibility  ProjectVisibility `db:"visibility" json:"visibility"`
+	Status      ProjectStatus     `db:"status" json:"status"`
+	ID          pgtype.UUID       `db:"id" json:"id"`
+	Column8     pgtype.UUID       `db:"column_8" json:"column_8"`
 }
 
+// The creator, $8 when given, joins the project as its first admin
 func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (Project, error) {
 	row := q.db.QueryRow(ctx, createProject,
 		arg.OrgID,
@@ -32,6 +72,9 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 		arg.Name,
 		arg.Description,
 		arg.Visibility,
+		arg.Status,
+		arg.ID,
+		arg.Column8,
 	)
 	var i Project
 	err := row.Scan(
@@ -44,6 +87,79 @@ func (q *Queries) CreateProject(ctx context.Context, arg CreateProjectParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+	)
+	return i, err
+}
+
+const createProjectMember = `-- name: CreateProjectMember :one
+INSERT INTO project_members (project_id, user_id, role)
+SELECT p.id, om.user_id, $3
+FROM projects p
+JOIN org_members om ON om.org_id = p.org_id AND om.user_id = $2
+WHERE p.id = $1 AND p.deleted_at IS NULL
+RETURNING project_id, user_id, role, joined_at
+`
+
+type CreateProjectMemberParams struct {
+	ID     pgtype.UUID `db:"id" json:"id"`
+	UserID pgtype.UUID `db:"user_id" json:"user_id"`
+	Role   ProjectRole `db:"role" json:"role"`
+}
+
+// Only members of the project's organisation can join; no row means the user is not one
+func (q *Queries) CreateProjectMember(ctx context.Context, arg CreateProjectMemberParams) (ProjectMember, error) {
+	row := q.db.QueryRow(ctx, createProjectMember, arg.ID, arg.UserID, arg.Role)
+	var i ProjectMember
+	err := row.Scan(
+		&i.ProjectID,
+		&i.UserID,
+		&i.Role,
+		&i.JoinedAt,
+	)
+	return i, err
+}
+
+const createProjectPriority = `-- name: CreateProjectPriority :one
+INSERT INTO project_priorities (project_id, key, name, color, position, id)
+VALUES (
+  $1, $2, $3, $4,
+  CASE WHEN $5::int > 0 THEN $5::int
+  ELSE (SELECT COALESCE(MAX(position), 0) + 1024 FROM project_priorities WHERE project_id = $1) END,
+  $6
+)
+RETURNING id, project_id, key, name, color, position, created_at, updated_at
+`
+
+type CreateProjectPriorityParams struct {
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+	Key       string      `db:"key" json:"key"`
+	Name      string      `db:"name" json:"name"`
+	Color     string      `db:"color" json:"color"`
+	Column5   int32       `db:"column_5" json:"column_5"`
+	ID        pgtype.UUID `db:"id" json:"id"`
+}
+
+// A zero position appends the level after the current last one
+func (q *Queries) CreateProjectPriority(ctx context.Context, arg CreateProjectPriorityParams) (ProjectPriority, error) {
+	row := q.db.QueryRow(ctx, createProjectPriority,
+		arg.ProjectID,
+		arg.Key,
+		arg.Name,
+		arg.Color,
+		arg.Column5,
+		arg.ID,
+	)
+	var i ProjectPriority
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Key,
+		&i.Name,
+		&i.Color,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -52,7 +168,7 @@ const deleteProject = `-- name: DeleteProject :one
 UPDATE projects
 SET deleted_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 `
 
 func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) (Project, error) {
@@ -68,12 +184,59 @@ func (q *Queries) DeleteProject(ctx context.Context, id pgtype.UUID) (Project, e
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
+const deleteProjectMember = `-- name: DeleteProjectMember :execrows
+DELETE FROM project_members
+WHERE project_id = $1 AND user_id = $2
+`
+
+type DeleteProjectMemberParams struct {
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+	UserID    pgtype.UUID `db:"user_id" json:"user_id"`
+}
+
+func (q *Queries) DeleteProjectMember(ctx context.Context, arg DeleteProjectMemberParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProjectMember, arg.ProjectID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const deleteProjectPriority = `-- name: DeleteProjectPriority :execrows
+WITH reassigned AS (
+  UPDATE tickets t
+  SET priority = $3::text
+  FROM project_priorities pp
+  WHERE pp.id = $1 AND pp.project_id = $2 AND $3::text <> ''
+    AND t.project_id = pp.project_id AND t.priority = pp.key
+)
+DELETE FROM project_priorities
+WHERE id = $1 AND project_id = $2
+`
+
+type DeleteProjectPriorityParams struct {
+	ID        pgtype.UUID `db:"id" json:"id"`
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+	Column3   string      `db:"column_3" json:"column_3"`
+}
+
+// Moves the level's tickets, deleted ones included, to $3 first when given;
+// otherwise the ticket foreign key refuses to drop a level that is still in use
+func (q *Queries) DeleteProjectPriority(ctx context.Context, arg DeleteProjectPriorityParams) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteProjectPriority, arg.ID, arg.ProjectID, arg.Column3)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getProject = `-- name: GetProject :one
-SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 FROM projects
 WHERE id = $1 AND deleted_at IS NULL
 `
@@ -91,12 +254,13 @@ func (q *Queries) GetProject(ctx context.Context, id pgtype.UUID) (Project, erro
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
 const getProjectByKey = `-- name: GetProjectByKey :one
-SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 FROM projects
 WHERE org_id = $1 AND key = $2 AND deleted_at IS NULL
 `
@@ -119,6 +283,74 @@ func (q *Queries) GetProjectByKey(ctx context.Context, arg GetProjectByKeyParams
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+	)
+	return i, err
+}
+
+const getProjectMemberRole = `-- name: GetProjectMemberRole :one
+SELECT role
+FROM project_members
+WHERE project_id = $1 AND user_id = $2
+`
+
+type GetProjectMemberRoleParams struct {
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+	UserID    pgtype.UUID `db:"user_id" json:"user_id"`
+}
+
+// Deleted projects keep their members so a restore can be authorised
+func (q *Queries) GetProjectMemberRole(ctx context.Context, arg GetProjectMemberRoleParams) (ProjectRole, error) {
+	row := q.db.QueryRow(ctx, getProjectMemberRole, arg.ProjectID, arg.UserID)
+	var role ProjectRole
+	err := row.Scan(&role)
+	return role, err
+}
+
+const getProjectTrashState = `-- name: GetProjectTrashState :one
+-- Whether a project and the organisation it belongs to are deleted, to explain a refused restore
+SELECT p.deleted_at IS NOT NULL AS deleted, o.deleted_at IS NOT NULL AS org_deleted
+FROM projects p
+JOIN orgs o ON o.id = p.org_id
+WHERE p.id = $1
+`
+
+type GetProjectTrashStateRow struct {
+	Deleted    bool `db:"deleted" json:"deleted"`
+	OrgDeleted bool `db:"org_deleted" json:"org_deleted"`
+}
+
+// Whether a project and the organisation it belongs to are deleted, to explain a refused restore
+func (q *Queries) GetProjectTrashState(ctx context.Context, id pgtype.UUID) (GetProjectTrashStateRow, error) {
+	row := q.db.QueryRow(ctx, getProjectTrashState, id)
+	var i GetProjectTrashStateRow
+	err := row.Scan(&i.Deleted, &i.OrgDeleted)
+	return i, err
+}
+
+const getProjectUIState = `-- name: GetProjectUIState :one
+SELECT
+  user_id, project_id, state, updated_at
+FROM
+  project_ui_states
+WHERE
+  user_id = $1
+  AND project_id = $2
+`
+
+type GetProjectUIStateParams struct {
+	UserID    pgtype.UUID `db:"user_id" json:"user_id"`
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+}
+
+func (q *Queries) GetProjectUIState(ctx context.Context, arg GetProjectUIStateParams) (ProjectUiState, error) {
+	row := q.db.QueryRow(ctx, getProjectUIState, arg.UserID, arg.ProjectID)
+	var i ProjectUiState
+	err := row.Scan(
+		&i.UserID,
+		&i.ProjectID,
+		&i.State,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -133,8 +365,223 @@ func (q *Queries) HardDeleteProject(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const listProjectMembers = `-- name: ListProjectMembers :many
+WITH filtered_members AS (
+  SELECT
+    pm.project_id, pm.user_id, pm.role, pm.joined_at,
+    u.email, u.display_name,
+    COUNT(*) OVER () as total_count
+  FROM
+    project_members pm
+    JOIN users u ON u.id = pm.user_id
+  WHERE
+    pm.project_id = $1
+    AND (array_length($2::uuid[], 1) IS NULL OR pm.user_id = ANY($2::uuid[]))
+    AND ($3::text = '' OR pm.role::text = $3::text)
+)
+SELECT
+  project_id, user_id, role, joined_at, email, display_name, total_count
+FROM
+  filtered_members
+ORDER BY
+  joined_at DESC
+LIMIT $4
+OFFSET $5
+`
+
+type ListProjectMembersParams struct {
+	ProjectID pgtype.UUID   `db:"project_id" json:"project_id"`
+	Column2   []pgtype.UUID `db:"column_2" json:"column_2"`
+	Column3   string        `db:"column_3" json:"column_3"`
+	Limit     int32         `db:"limit" json:"limit"`
+	Offset    int32         `db:"offset" json:"offset"`
+}
+
+type ListProjectMembersRow struct {
+	ProjectID   pgtype.UUID        `db:"project_id" json:"project_id"`
+	UserID      pgtype.UUID        `db:"user_id" json:"user_id"`
+	Role        ProjectRole        `db:"role" json:"role"`
+	JoinedAt    pgtype.Timestamptz `db:"joined_at" json:"joined_at"`
+	Email       string             `db:"email" json:"email"`
+	DisplayName string             `db:"display_name" json:"display_name"`
+	TotalCount  int64              `db:"total_count" json:"total_count"`
+}
+
+func (q *Queries) ListProjectMembers(ctx context.Context, arg ListProjectMembersParams) ([]ListProjectMembersRow, error) {
+	rows, err := q.db.Query(ctx, listProjectMembers,
+		arg.ProjectID,
+		arg.Column2,
+		arg.Column3,
+		arg.Limit,
+		arg.Offset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectMembersRow{}
+	for rows.Next() {
+		var i ListProjectMembersRow
+		if err := rows.Scan(
+			&i.ProjectID,
+			&i.UserID,
+			&i.Role,
+			&i.JoinedAt,
+			&i.Email,
+			&i.DisplayName,
+			&i.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectNamesWithPrefix = `-- name: ListProjectNamesWithPrefix :many
+SELECT id, name
+FROM projects
+WHERE left(lower(name), char_length($1::text)) = lower($1::text) AND deleted_at IS NULL
+`
+
+type ListProjectNamesWithPrefixRow struct {
+	ID   pgtype.UUID `db:"id" json:"id"`
+	Name string      `db:"name" json:"name"`
+}
+
+// Live project names starting with $1, ignoring case; used to detect a name clash and suggest a free variant
+func (q *Queries) ListProjectNamesWithPrefix(ctx context.Context, dollar_1 string) ([]ListProjectNamesWithPrefixRow, error) {
+	rows, err := q.db.Query(ctx, listProjectNamesWithPrefix, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectNamesWithPrefixRow{}
+	for rows.Next() {
+		var i ListProjectNamesWithPrefixRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectPriorities = `-- name: ListProjectPriorities :many
+SELECT id, project_id, key, name, color, position, created_at, updated_at
+FROM project_priorities
+WHERE project_id = $1
+ORDER BY position, key
+`
+
+func (q *Queries) ListProjectPriorities(ctx context.Context, projectID pgtype.UUID) ([]ProjectPriority, error) {
+	rows, err := q.db.Query(ctx, listProjectPriorities, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ProjectPriority{}
+	for rows.Next() {
+		var i ProjectPriority
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.Key,
+			&i.Name,
+			&i.Color,
+			&i.Position,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectSummaries = `-- name: ListProjectSummaries :many
+SELECT
+  p.id,
+  COALESCE(ts.open_ticket_count, 0)::bigint AS open_ticket_count,
+  COALESCE(ts.done_ticket_count, 0)::bigint AS done_ticket_count,
+  COALESCE(cs.column_count, 0)::bigint AS column_count,
+  GREATEST(p.updated_at, ts.last_ticket_at)::timestamptz AS last_activity_at
+FROM
+  projects p
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) FILTER (WHERE bc.category IS DISTINCT FROM 'done') AS open_ticket_count,
+      COUNT(*) FILTER (WHERE bc.category = 'done') AS done_ticket_count,
+      MAX(t.updated_at) AS last_ticket_at
+    FROM
+      tickets t
+      LEFT JOIN board_columns bc ON bc.id = t.board_column_id AND bc.deleted_at IS NULL
+    WHERE
+      t.project_id = p.id
+      AND t.deleted_at IS NULL
+  ) ts ON true
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) AS column_count
+    FROM
+      board_columns bc
+      JOIN boards b ON b.id = bc.board_id AND b.deleted_at IS NULL
+      JOIN sprints s ON s.id = b.sprint_id AND s.deleted_at IS NULL
+    WHERE
+      s.project_id = p.id
+      AND bc.deleted_at IS NULL
+  ) cs ON true
+WHERE
+  p.id = ANY($1::uuid[])
+`
+
+type ListProjectSummariesRow struct {
+	ID              pgtype.UUID        `db:"id" json:"id"`
+	OpenTicketCount int64              `db:"open_ticket_count" json:"open_ticket_count"`
+	DoneTicketCount int64              `db:"done_ticket_count" json:"done_ticket_count"`
+	ColumnCount     int64              `db:"column_count" json:"column_count"`
+	LastActivityAt  pgtype.Timestamptz `db:"last_activity_at" json:"last_activity_at"`
+}
+
+// Computes per-project progress for the given projects; a ticket is done while it sits in a done column
+func (q *Queries) ListProjectSummaries(ctx context.Context, dollar_1 []pgtype.UUID) ([]ListProjectSummariesRow, error) {
+	rows, err := q.db.Query(ctx, listProjectSummaries, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectSummariesRow{}
+	for rows.Next() {
+		var i ListProjectSummariesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.OpenTicketCount,
+			&i.DoneTicketCount,
+			&i.ColumnCount,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listProjectsByOrg = `-- name: ListProjectsByOrg :many
-SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 FROM projects
 WHERE org_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
@@ -159,6 +606,7 @@ func (q *Queries) ListProjectsByOrg(ctx context.Context, orgID pgtype.UUID) ([]P
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
 		); err != nil {
 			return nil, err
 		}
@@ -173,18 +621,21 @@ func (q *Queries) ListProjectsByOrg(ctx context.Context, orgID pgtype.UUID) ([]P
 const listProjectsByOrgPaged = `-- name: ListProjectsByOrgPaged :many
 WITH filtered_projects AS (
   SELECT
-    id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at,
+    id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status,
     COUNT(*) OVER () as total_count
   FROM
     projects
   WHERE
-    deleted_at IS NULL
+    ($6::boolean OR deleted_at IS NULL)
     AND (array_length($1::uuid[], 1) IS NULL OR org_id = ANY($1::uuid[]))
     AND (array_length($2::uuid[], 1) IS NULL OR id = ANY($2::uuid[]))
-    AND ($3::text = '' OR name ILIKE '%' || $3 || '%')
+    AND ($3::text = '' OR CASE WHEN $7::boolean THEN lower(name) = lower($3) ELSE name ILIKE '%' || $3 || '%' END)
+    AND ($8::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM project_members pm WHERE pm.project_id = projects.id AND pm.user_id = $8::uuid
+    ))
 )
 SELECT
-  id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, total_count
+  id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status, total_count
 FROM
   filtered_projects
 ORDER BY
@@ -199,6 +650,9 @@ type ListProjectsByOrgPagedParams struct {
 	Column3 string        `db:"column_3" json:"column_3"`
 	Limit   int32         `db:"limit" json:"limit"`
 	Offset  int32         `db:"offset" json:"offset"`
+	Column6 bool          `db:"column_6" json:"column_6"`
+	Column7 bool          `db:"column_7" json:"column_7"`
+	Column8 pgtype.UUID   `db:"column_8" json:"column_8"`
 }
 
 type ListProjectsByOrgPagedRow struct {
@@ -211,6 +665,7 @@ type ListProjectsByOrgPagedRow struct {
 	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 	DeletedAt   pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	Status      ProjectStatus      `db:"status" json:"status"`
 	TotalCount  int64              `db:"total_count" json:"total_count"`
 }
 
@@ -221,6 +676,9 @@ func (q *Queries) ListProjectsByOrgPaged(ctx context.Context, arg ListProjectsBy
 		arg.Column3,
 		arg.Limit,
 		arg.Offset,
+		arg.Column6,
+		arg.Column7,
+		arg.Column8,
 	)
 	if err != nil {
 		return nil, err
@@ -239,6 +697,7 @@ func (q *Queries) ListProjectsByOrgPaged(ctx context.Context, arg ListProjectsBy
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Status,
 			&i.TotalCount,
 		); err != nil {
 			return nil, err
@@ -251,11 +710,67 @@ func (q *Queries) ListProjectsByOrgPaged(ctx context.Context, arg ListProjectsBy
 	return items, nil
 }
 
+const lockProjectAdmins = `-- name: LockProjectAdmins :many
+SELECT user_id
+FROM project_members
+WHERE project_id = $1 AND role = 'admin'
+ORDER BY user_id
+FOR UPDATE
+`
+
+func (q *Queries) LockProjectAdmins(ctx context.Context, projectID pgtype.UUID) ([]pgtype.UUID, error) {
+	rows, err := q.db.Query(ctx, lockProjectAdmins, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []pgtype.UUID{}
+	for rows.Next() {
+		var user_id pgtype.UUID
+		if err := rows.Scan(&user_id); err != nil {
+			return nil, err
+		}
+		items = append(items, user_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const restoreProject = `-- name: RestoreProject :one
+-- Clears deleted_at as long as the organisation is still there
+UPDATE projects
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at IS NOT NULL
+  AND EXISTS (SELECT 1 FROM orgs o WHERE o.id = projects.org_id AND o.deleted_at IS NULL)
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
+`
+
+// Clears deleted_at as long as the organisation is still there
+func (q *Queries) RestoreProject(ctx context.Context, id pgtype.UUID) (Project, error) {
+	row := q.db.QueryRow(ctx, restoreProject, id)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.OrgID,
+		&i.Key,
+		&i.Name,
+		&i.Description,
+		&i.Visibility,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+	)
+	return i, err
+}
+
 const updateProject = `-- name: UpdateProject :one
 UPDATE projects
-SET name = $2, description = $3, updated_at = NOW()
+SET name = $2, description = $3
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 `
 
 type UpdateProjectParams struct {
@@ -277,15 +792,114 @@ func (q *Queries) UpdateProject(ctx context.Context, arg UpdateProjectParams) (P
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+	)
+	return i, err
+}
+
+const updateProjectMemberRole = `-- name: UpdateProjectMemberRole :one
+UPDATE project_members
+SET role = $3
+WHERE project_id = $1 AND user_id = $2
+RETURNING project_id, user_id, role, joined_at
+`
+
+type UpdateProjectMemberRoleParams struct {
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+	UserID    pgtype.UUID `db:"user_id" json:"user_id"`
+	Role      ProjectRole `db:"role" json:"role"`
+}
+
+func (q *Queries) UpdateProjectMemberRole(ctx context.Context, arg UpdateProjectMemberRoleParams) (ProjectMember, error) {
+	row := q.db.QueryRow(ctx, updateProjectMemberRole, arg.ProjectID, arg.UserID, arg.Role)
+	var i ProjectMember
+	err := row.Scan(
+		&i.ProjectID,
+		&i.UserID,
+		&i.Role,
+		&i.JoinedAt,
+	)
+	return i, err
+}
+
+const updateProjectPriority = `-- name: UpdateProjectPriority :one
+UPDATE project_priorities
+SET
+  name = COALESCE(NULLIF($3::text, ''), name),
+  color = COALESCE(NULLIF($4::text, ''), color),
+  position = CASE WHEN $5::int > 0 THEN $5::int ELSE position END
+WHERE id = $1 AND project_id = $2
+RETURNING id, project_id, key, name, color, position, created_at, updated_at
+`
+
+type UpdateProjectPriorityParams struct {
+	ID        pgtype.UUID `db:"id" json:"id"`
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+	Column3   string      `db:"column_3" json:"column_3"`
+	Column4   string      `db:"column_4" json:"column_4"`
+	Column5   int32       `db:"column_5" json:"column_5"`
+}
+
+// The key is immutable since tickets reference it; empty fields keep their value
+func (q *Queries) UpdateProjectPriority(ctx context.Context, arg UpdateProjectPriorityParams) (ProjectPriority, error) {
+	row := q.db.QueryRow(ctx, updateProjectPriority,
+		arg.ID,
+		arg.ProjectID,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+	)
+	var i ProjectPriority
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.Key,
+		&i.Name,
+		&i.Color,
+		&i.Position,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const updateProjectStatus = `-- name: UpdateProjectStatus :one
+UPDATE projects
+SET status = $2
+WHERE id = $1 AND status = $3 AND deleted_at IS NULL
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
+`
+
+type UpdateProjectStatusParams struct {
+	ID       pgtype.UUID   `db:"id" json:"id"`
+	Status   ProjectStatus `db:"status" json:"status"`
+	Status_2 ProjectStatus `db:"status_2" json:"status_2"`
+}
+
+// Transitions only apply when the project is still in the expected status
+func (q *Queries) UpdateProjectStatus(ctx context.Context, arg UpdateProjectStatusParams) (Project, error) {
+	row := q.db.QueryRow(ctx, updateProjectStatus, arg.ID, arg.Status, arg.Status_2)
+	var i Project
+	err := row.Scan(
+		&i.ID,
+		&i.OrgID,
+		&i.Key,
+		&i.Name,
+		&i.Description,
+		&i.Visibility,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
 	)
 	return i, err
 }
 
 const updateProjectVisibility = `-- name: UpdateProjectVisibility :one
 UPDATE projects
-SET visibility = $2, updated_at = NOW()
+SET visibility = $2
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 `
 
 type UpdateProjectVisibilityParams struct {
@@ -306,6 +920,117 @@ func (q *Queries) UpdateProjectVisibility(ctx context.Context, arg UpdateProject
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Status,
+	)
+	return i, err
+}
+
+const upsertProject = `-- name: UpsertProject :one
+WITH upserted AS (
+  INSERT INTO projects (id, org_id, key, name, description, visibility, status)
+  VALUES ($1, $2, $3, $4, $5, $6, $7)
+  ON CONFLICT (id) DO UPDATE
+  SET name = EXCLUDED.name, description = EXCLUDED.description, visibility = EXCLUDED.visibility
+  WHERE projects.org_id = EXCLUDED.org_id AND projects.key = EXCLUDED.key AND projects.deleted_at IS NULL
+    AND ($9::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM project_members pm
+      WHERE pm.project_id = projects.id AND pm.user_id = $9::uuid AND pm.role = 'admin'
+    ))
+  RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status, (xmax = 0)::boolean AS inserted
+), creator AS (
+  INSERT INTO project_members (project_id, user_id, role)
+  SELECT id, $8::uuid, 'admin' FROM upserted WHERE inserted AND $8::uuid IS NOT NULL
+)
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status, inserted
+FROM upserted
+`
+
+type UpsertProjectParams struct {
+	ID          pgtype.UUID       `db:"id" json:"id"`
+	OrgID       pgtype.UUID       `db:"org_id" json:"org_id"`
+	Key         string            `db:"key" json:"key"`
+	Name        string            `db:"name" json:"name"`
+	Description pgtype.Text       `db:"description" json:"description"`
+	Visibility  ProjectVisibility `db:"visibility" json:"visibility"`
+	Status      ProjectStatus     `db:"status" json:"status"`
+	Column8     pgtype.UUID       `db:"column_8" json:"column_8"`
+	Column9     pgtype.UUID       `db:"column_9" json:"column_9"`
+}
+
+type UpsertProjectRow struct {
+	ID          pgtype.UUID        `db:"id" json:"id"`
+	OrgID       pgtype.UUID        `db:"org_id" json:"org_id"`
+	Key         string             `db:"key" json:"key"`
+	Name        string             `db:"name" json:"name"`
+	Description pgtype.Text        `db:"description" json:"description"`
+	Visibility  ProjectVisibility  `db:"visibility" json:"visibility"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	DeletedAt   pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	Status      ProjectStatus      `db:"status" json:"status"`
+	Inserted    bool               `db:"inserted" json:"inserted"`
+}
+
+// Creates the project under a caller supplied id, or updates it in place when it already exists
+// in the same org with the same key; inserted tells the two apart. Status only applies on insert,
+// and so does making the creator, $8 when given, the project's first admin. Updating takes $9 to be
+// an admin of the project, NULL skips the check; checking in the same statement leaves no gap for
+// another caller to create the project in between
+func (q *Queries) UpsertProject(ctx context.Context, arg UpsertProjectParams) (UpsertProjectRow, error) {
+	row := q.db.QueryRow(ctx, upsertProject,
+		arg.ID,
+		arg.OrgID,
+		arg.Key,
+		arg.Name,
+		arg.Description,
+		arg.Visibility,
+		arg.Status,
+		arg.Column8,
+		arg.Column9,
+	)
+	var i UpsertProjectRow
+	err := row.Scan(
+		&i.ID,
+		&i.OrgID,
+		&i.Key,
+		&i.Name,
+		&i.Description,
+		&i.Visibility,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Status,
+		&i.Inserted,
+	)
+	return i, err
+}
+
+const upsertProjectUIState = `-- name: UpsertProjectUIState :one
+INSERT INTO
+  project_ui_states (user_id, project_id, state)
+VALUES
+  ($1, $2, $3)
+ON CONFLICT (user_id, project_id) DO UPDATE
+SET
+  state = EXCLUDED.state
+RETURNING
+  user_id, project_id, state, updated_at
+`
+
+type UpsertProjectUIStateParams struct {
+	UserID    pgtype.UUID `db:"user_id" json:"user_id"`
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+	State     []byte      `db:"state" json:"state"`
+}
+
+func (q *Queries) UpsertProjectUIState(ctx context.Context, arg UpsertProjectUIStateParams) (ProjectUiState, error) {
+	row := q.db.QueryRow(ctx, upsertProjectUIState, arg.UserID, arg.ProjectID, arg.State)
+	var i ProjectUiState
+	err := row.Scan(
+		&i.UserID,
+		&i.ProjectID,
+		&i.State,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/internal/project/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrBatchRejected      = domain.Unprocessable("no project was created, see the rejected items").WithCode("batch_rejected")
+	ErrTemplateOtherOrg   = domain.Invalid("template project belongs to another organisation").WithCode("invalid_template")
+	ErrBatchDuplicateKey  = domain.Conflict("project key is used by another project in the batch").WithCode("duplicate_key")
+	ErrBatchDuplicateName = domain.Conflict("project name is used by another project in the batch").WithCode("project_name_taken")
+)
+
+// CreateProjects creates a batch of projects in a single transaction. Every
+// item is checked before anything is written; when one is rejected nothing is
+// created and the error details list each item's result, so a script can fix
+// the bad items and resend the whole batch.
+func (s *Service) CreateProjects(ctx context.Context, orgId pgtype.UUID, p domain.ProjectBatchCreateModel) (domain.ProjectBatchResultModel, error) {
+	org, err := s.Org.GetOrgById(ctx, orgId)
+	if err != nil {
+		return domain.ProjectBatchResultModel{}, err
+	}
+
+	var template pgtype.UUID
+	if p.TemplateID.Valid {
+		tpl, err := s.GetProjectById(ctx, p.TemplateID)
+		if err != nil {
+			return domain.ProjectBatchResultModel{}, err
+		}
+		if tpl.OrgID != org.ID {
+			return domain.ProjectBatchResultModel{}, ErrTemplateOtherOrg
+		}
+		template = tpl.ID
+	}
+
+	results := make([]domain.ProjectBatchItemResultModel, len(p.Projects))
+	params := make([]repository.CreateProjectParams, len(p.Projects))
+	keys := make(map[string]bool, len(p.Projects))
+	names := make(map[string]bool, len(p.Projects))
+	rejected := false
+	for i, item := range p.Projects {
+		results[i] = domain.ProjectBatchItemResultModel{Index: i, Status: domain.BatchSkipped}
+
+		params[i], err = s.prepareProject(ctx, idgen.New(), org.ID, item)
+		switch {
+		case err != nil:
+		case keys[params[i].Key]:
+			err = ErrBatchDuplicateKey
+		case s.Config != nil && s.Config.UniqueNames && names[strings.ToLower(params[i].Name)]:
+			err = ErrBatchDuplicateName
+		}
+		if err != nil {
+			if !rejectItem(&results[i], err) {
+				return domain.ProjectBatchResultModel{}, err
+			}
+			rejected = true
+			continue
+		}
+		keys[params[i].Key] = true
+		names[strings.ToLower(params[i].Name)] = true
+	}
+	if rejected {
+		return domain.ProjectBatchResultModel{}, batchRejected(results)
+	}
+
+	projects, failed, err := s.insertProjects(ctx, params, template)
+	if err != nil {
+		if failed >= 0 && rejectItem(&results[failed], err) {
+			return domain.ProjectBatchResultModel{}, batchRejected(results)
+		}
+		return domain.ProjectBatchResultModel{}, err
+	}
+
+	for i, project := range projects {
+		result := toProjectModel(project)
+		results[i].Status = domain.BatchCreated
+		results[i].Project = &result
+
+		if err := s.Bus.Publish(ctx, pubsub.ProjectCreated, httpx.EncodePayload(result)); err != nil {
+			slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.ProjectCreated), "error", err)
+		}
+	}
+
+	return domain.ProjectBatchResultModel{Results: results}, nil
+}
+
+// insertProjects writes the batch and, given a template, swaps each project's
+// default priority levels for the template's. On failure it reports the index
+// of the item that failed, or -1 when the transaction itself did.
+func (s *Service) insertProjects(ctx context.Context, params []repository.CreateProjectParams, template pgtype.UUID) ([]repository.Project, int, error) {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return nil, -1, fmt.Errorf("begin project batch: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	repo := s.Repo.WithTx(tx)
+	projects := make([]repository.Project, len(params))
+	for i, p := range params {
+		if projects[i], err = repo.CreateProject(ctx, p); err != nil {
+			return nil, i, createProjectError(err)
+		}
+		if !template.Valid {
+			continue
+		}
+		if err := repo.ClearProjectPriorities(ctx, projects[i].ID); err != nil {
+			return nil, i, fmt.Errorf("clear project priorities: %w", err)
+		}
+		if err := repo.CopyProjectPriorities(ctx, repository.CopyProjectPrioritiesParams{Column1: projects[i].ID, ProjectID: template}); err != nil {
+			return nil, i, fmt.Errorf("copy project priorities: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, -1, fmt.Errorf("commit project batch: %w", err)
+	}
+	return projects, -1, nil
+}
+
+// rejectItem records a domain error on the item's result. Anything else is a
+// server error that fails the whole request instead.
+func rejectItem(result *domain.ProjectBatchItemResultModel, err error) bool {
+	var derr *domain.Error
+	if !errors.As(err, &derr) {
+		return false
+	}
+	result.Status = domain.BatchRejected
+	result.Code = derr.Code
+	result.Message = derr.Message
+	return true
+}
+
+func batchRejected(results []domain.ProjectBatchItemResultModel) error {
+	return domain.Unprocessable(ErrBatchRejected.Message).
+		WithCode(ErrBatchRejected.Code).
+		WithDetails(domain.ProjectBatchResultModel{Results: results})
+}
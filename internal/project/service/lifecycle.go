@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/project/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidStatusTransition = domain.Conflict("project status transition is not allowed").WithCode("invalid_status_transition")
+	ErrInvalidInitialStatus    = domain.Invalid("a project can only start active or paused").WithCode("invalid_project_status")
+)
+
+var transitionEvents = map[repository.ProjectStatus]pubsub.EventType{
+	repository.ProjectStatusActive:   pubsub.ProjectActivated,
+	repository.ProjectStatusPaused:   pubsub.ProjectPaused,
+	repository.ProjectStatusArchived: pubsub.ProjectArchived,
+}
+
+func (s *Service) ActivateProject(ctx context.Context, id pgtype.UUID) (domain.ProjectModel, error) {
+	return s.transitionProject(ctx, id, repository.ProjectStatusActive)
+}
+
+func (s *Service) PauseProject(ctx context.Context, id pgtype.UUID) (domain.ProjectModel, error) {
+	return s.transitionProject(ctx, id, repository.ProjectStatusPaused)
+}
+
+func (s *Service) ArchiveProject(ctx context.Context, id pgtype.UUID) (domain.ProjectModel, error) {
+	return s.transitionProject(ctx, id, repository.ProjectStatusArchived)
+}
+
+func (s *Service) transitionProject(ctx context.Context, id pgtype.UUID, to repository.ProjectStatus) (domain.ProjectModel, error) {
+	if err := s.AuthorizeProject(ctx, id, domain.ProjectRoleAdmin); err != nil {
+		return domain.ProjectModel{}, err
+	}
+
+	current, err := s.Repo.GetProject(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ProjectModel{}, ErrProjectNotFound
+		}
+		return domain.ProjectModel{}, fmt.Errorf("get project by id: %w", err)
+	}
+
+	if from := domain.ProjectStatus(current.Status); !from.CanTransitionTo(domain.ProjectStatus(to)) {
+		return domain.ProjectModel{}, invalidStatusTransition(from)
+	}
+
+	project, err := s.Repo.UpdateProjectStatus(ctx, repository.UpdateProjectStatusParams{
+		ID:       id,
+		Status:   to,
+		Status_2: current.Status,
+	})
+	if err != nil {
+		// the status moved underneath us between the read and the write
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ProjectModel{}, ErrInvalidStatusTransition
+		}
+		return domain.ProjectModel{}, fmt.Errorf("update project status: %w", err)
+	}
+
+	result := toProjectModel(project)
+
+	eventType := transitionEvents[to]
+	payload := httpx.EncodePayload(result)
+	payload["previousStatus"] = string(current.Status)
+	if err := s.Bus.Publish(ctx, eventType, payload); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(eventType), "error", err)
+	}
+
+	return result, nil
+}
+
+// invalidStatusTransition tells the client where the project can go instead
+func invalidStatusTransition(from domain.ProjectStatus) error {
+	return domain.Conflict(fmt.Sprintf("project status transition is not allowed from %s", from)).
+		WithCode(ErrInvalidStatusTransition.Code).
+		WithDetails(map[string]any{"status": from, "transitions": domain.ProjectStatusTransitions[from]})
+}
+
+// initialStatus resolves the status a new project starts in. Archived is
+// refused here as well as by the request validation, so callers outside HTTP
+// get the same rule.
+func initialStatus(s domain.ProjectStatus) (repository.ProjectStatus, error) {
+	switch s {
+	case "":
+		return repository.ProjectStatusActive, nil
+	case domain.ProjectStatusActive, domain.ProjectStatusPaused:
+		return repository.ProjectStatus(s), nil
+	}
+	return "", ErrInvalidInitialStatus
+}
@@ -0,0 +1,260 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/dimasbaguspm/fluxis/internal/project/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrProjectMemberNotFound = domain.NotFound("project member not found")
+	ErrProjectMemberExists   = domain.Conflict("the user is already a member of the project").WithCode("already_a_member")
+	ErrNotOrgMember          = domain.Unprocessable("only members of the project's organisation can join it").WithCode("not_an_org_member")
+	ErrLastProjectAdmin      = domain.Conflict("a project must keep at least one admin").WithCode("last_project_admin")
+	ErrInvalidProjectRole    = domain.Invalid("role must be admin, member or viewer").WithCode("invalid_role")
+	ErrInvalidMemberUserID   = domain.Invalid("userId must be a valid UUID")
+)
+
+// AuthorizeProject checks the caller's role in the project. Calls made
+// without a user, such as bus subscribers and sweepers, and tokens with the
+// admin scope are not restricted.
+func (s *Service) AuthorizeProject(ctx context.Context, projectID pgtype.UUID, role domain.ProjectRole) error {
+	userID := httpx.MemberFilter(ctx)
+	if !userID.Valid {
+		return nil
+	}
+
+	held, err := s.Repo.GetProjectMemberRole(ctx, repository.GetProjectMemberRoleParams{
+		ProjectID: projectID,
+		UserID:    userID,
+	})
+	if err != nil {
+		// an outsider learns nothing about the project, not even that it exists
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrProjectNotFound
+		}
+		return fmt.Errorf("get project member role: %w", err)
+	}
+
+	if !domain.ProjectRole(held).Grants(role) {
+		return domain.Forbidden(fmt.Sprintf("this requires the %s role in the project", role)).WithCode("insufficient_project_role")
+	}
+	return nil
+}
+
+func (s *Service) ListProjectMembers(ctx context.Context, projectID pgtype.UUID, q domain.ProjectMembersSearchModel) (domain.ProjectMembersPagedModel, error) {
+	q.ApplyDefaults()
+
+	if q.Role != "" && !domain.ProjectRole(q.Role).Valid() {
+		return domain.ProjectMembersPagedModel{}, ErrInvalidProjectRole
+	}
+	if _, err := s.GetProjectById(ctx, projectID); err != nil {
+		return domain.ProjectMembersPagedModel{}, err
+	}
+
+	rows, err := s.Repo.ListProjectMembers(ctx, repository.ListProjectMembersParams{
+		ProjectID: projectID,
+		Column2:   q.UserID,
+		Column3:   q.Role,
+		Limit:     int32(q.PageSize),
+		Offset:    pagination.Offset(q.PageNumber, q.PageSize),
+	})
+	if err != nil {
+		return domain.ProjectMembersPagedModel{}, fmt.Errorf("list project members: %w", err)
+	}
+
+	page := pagination.FromRows(rows,
+		func(row repository.ListProjectMembersRow) int64 { return row.TotalCount },
+		toProjectMemberModel,
+		q.PageNumber, q.PageSize)
+
+	return domain.ProjectMembersPagedModel(page), nil
+}
+
+// AddProjectMember gives a member of the project's organisation a role in the
+// project; only project admins can
+func (s *Service) AddProjectMember(ctx context.Context, projectID pgtype.UUID, p domain.ProjectMemberCreateModel) (domain.ProjectMemberModel, error) {
+	var userID pgtype.UUID
+	if err := userID.Scan(p.UserID); err != nil {
+		return domain.ProjectMemberModel{}, ErrInvalidMemberUserID
+	}
+
+	if err := s.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return domain.ProjectMemberModel{}, err
+	}
+	if _, err := s.GetProjectById(ctx, projectID); err != nil {
+		return domain.ProjectMemberModel{}, err
+	}
+
+	_, err := s.Repo.CreateProjectMember(ctx, repository.CreateProjectMemberParams{
+		ID:     projectID,
+		UserID: userID,
+		Role:   repository.ProjectRole(p.Role),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ProjectMemberModel{}, ErrNotOrgMember
+		}
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ProjectMemberModel{}, ErrProjectMemberExists
+		}
+		return domain.ProjectMemberModel{}, fmt.Errorf("add project member: %w", err)
+	}
+
+	result, err := s.projectMember(ctx, projectID, userID)
+	if err != nil {
+		return domain.ProjectMemberModel{}, err
+	}
+	s.publishMemberEvent(ctx, pubsub.ProjectMemberAdded, projectID, result)
+
+	return result, nil
+}
+
+func (s *Service) UpdateProjectMemberRole(ctx context.Context, projectID, userID pgtype.UUID, p domain.ProjectMemberUpdateModel) (domain.ProjectMemberModel, error) {
+	if err := s.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return domain.ProjectMemberModel{}, err
+	}
+	update := func(repo *repository.Queries) error {
+		_, err := repo.UpdateProjectMemberRole(ctx, repository.UpdateProjectMemberRoleParams{
+			ProjectID: projectID,
+			UserID:    userID,
+			Role:      repository.ProjectRole(p.Role),
+		})
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrProjectMemberNotFound
+		}
+		if err != nil {
+			return fmt.Errorf("update project member role: %w", err)
+		}
+		return nil
+	}
+	var err error
+	if p.Role == domain.ProjectRoleAdmin {
+		err = update(s.Repo)
+	} else {
+		err = s.keepAnAdmin(ctx, projectID, userID, update)
+	}
+	if err != nil {
+		return domain.ProjectMemberModel{}, err
+	}
+
+	result, err := s.projectMember(ctx, projectID, userID)
+	if err != nil {
+		return domain.ProjectMemberModel{}, err
+	}
+	s.publishMemberEvent(ctx, pubsub.ProjectMemberUpdated, projectID, result)
+
+	return result, nil
+}
+
+// RemoveProjectMember takes a user out of the project. Admins can remove
+// anyone, every member can leave on their own.
+func (s *Service) RemoveProjectMember(ctx context.Context, projectID, userID pgtype.UUID) error {
+	required := domain.ProjectRoleAdmin
+	if callerID, ok := httpx.UserIDFrom(ctx); ok && callerID == userID {
+		required = domain.ProjectRoleViewer
+	}
+	if err := s.AuthorizeProject(ctx, projectID, required); err != nil {
+		return err
+	}
+	err := s.keepAnAdmin(ctx, projectID, userID, func(repo *repository.Queries) error {
+		n, err := repo.DeleteProjectMember(ctx, repository.DeleteProjectMemberParams{
+			ProjectID: projectID,
+			UserID:    userID,
+		})
+		if err != nil {
+			return fmt.Errorf("remove project member: %w", err)
+		}
+		if n == 0 {
+			return ErrProjectMemberNotFound
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	s.publishMemberEvent(ctx, pubsub.ProjectMemberRemoved, projectID, domain.ProjectMemberModel{UserID: userID})
+
+	return nil
+}
+
+// keepAnAdmin runs change, which demotes or removes userID, in a transaction
+// holding the project's admin rows and refuses it when userID is the last
+// admin. Two admins demoting each other wait on the same rows, so the second
+// sees the first one's change and is refused.
+func (s *Service) keepAnAdmin(ctx context.Context, projectID, userID pgtype.UUID, change func(repo *repository.Queries) error) error {
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("begin project member change: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	repo := s.Repo.WithTx(tx)
+	admins, err := repo.LockProjectAdmins(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("lock project admins: %w", err)
+	}
+	if len(admins) <= 1 && slices.Contains(admins, userID) {
+		return ErrLastProjectAdmin
+	}
+
+	if err := change(repo); err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit project member change: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) projectMember(ctx context.Context, projectID, userID pgtype.UUID) (domain.ProjectMemberModel, error) {
+	rows, err := s.Repo.ListProjectMembers(ctx, repository.ListProjectMembersParams{
+		ProjectID: projectID,
+		Column2:   []pgtype.UUID{userID},
+		Limit:     1,
+	})
+	if err != nil {
+		return domain.ProjectMemberModel{}, fmt.Errorf("get project member: %w", err)
+	}
+	if len(rows) == 0 {
+		return domain.ProjectMemberModel{}, ErrProjectMemberNotFound
+	}
+	return toProjectMemberModel(rows[0]), nil
+}
+
+func (s *Service) publishMemberEvent(ctx context.Context, event pubsub.EventType, projectID pgtype.UUID, m domain.ProjectMemberModel) {
+	payload := map[string]string{
+		"projectId": transformer.UUIDString(projectID),
+		"userId":    transformer.UUIDString(m.UserID),
+	}
+	if m.Role != "" {
+		payload["role"] = string(m.Role)
+	}
+	if err := s.Bus.Publish(ctx, event, payload); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(event), "error", err)
+	}
+}
+
+func toProjectMemberModel(row repository.ListProjectMembersRow) domain.ProjectMemberModel {
+	return domain.ProjectMemberModel{
+		UserID:   row.UserID,
+		Name:     row.DisplayName,
+		Email:    row.Email,
+		Role:     domain.ProjectRole(row.Role),
+		JoinedAt: row.JoinedAt.Time,
+	}
+}
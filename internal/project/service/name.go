@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxProjectNameLength mirrors the max tag on the project create and update models
+const maxProjectNameLength = 100
+
+// checkProjectName rejects a name another live project already uses when
+// unique names are enabled. self is the project being written, so renaming a
+// project to a different casing of its own name is allowed.
+func (s *Service) checkProjectName(ctx context.Context, name string, self pgtype.UUID) error {
+	if s.Config == nil || !s.Config.UniqueNames || name == "" {
+		return nil
+	}
+
+	rows, err := s.Repo.ListProjectNamesWithPrefix(ctx, name)
+	if err != nil {
+		return fmt.Errorf("list project names: %w", err)
+	}
+
+	taken := make(map[string]bool, len(rows))
+	clash := false
+	for _, row := range rows {
+		if self.Valid && row.ID == self {
+			continue
+		}
+		taken[strings.ToLower(row.Name)] = true
+		if strings.EqualFold(row.Name, name) {
+			clash = true
+		}
+	}
+	if !clash {
+		return nil
+	}
+
+	return domain.Conflict("project name has been taken").
+		WithCode("project_name_taken").
+		WithDetails(map[string]string{"suggestion": suggestProjectName(name, taken)})
+}
+
+// suggestProjectName appends the lowest free counter to name, trimming name
+// when the result would outgrow the column
+func suggestProjectName(name string, taken map[string]bool) string {
+	for n := 2; ; n++ {
+		suffix := " " + strconv.Itoa(n)
+		base := []rune(name)
+		if limit := maxProjectNameLength - len([]rune(suffix)); len(base) > limit {
+			base = base[:limit]
+		}
+		candidate := strings.TrimRight(string(base), " ") + suffix
+		if !taken[strings.ToLower(candidate)] {
+			return candidate
+		}
+	}
+}
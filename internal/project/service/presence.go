@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/presence"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetProjectPresence lists the users who sent a heartbeat for the project
+// within the presence TTL
+func (s *Service) GetProjectPresence(ctx context.Context, projectID pgtype.UUID) (domain.ProjectPresenceModel, error) {
+	if _, err := s.GetProjectById(ctx, projectID); err != nil {
+		return domain.ProjectPresenceModel{}, err
+	}
+	return s.projectPresence(projectID), nil
+}
+
+// TouchProjectPresence records that the user has the project open, on the
+// given board when one is set, and returns the updated viewer list
+func (s *Service) TouchProjectPresence(ctx context.Context, projectID, userID pgtype.UUID, p domain.ProjectPresenceHeartbeatModel) (domain.ProjectPresenceModel, error) {
+	if _, err := s.GetProjectById(ctx, projectID); err != nil {
+		return domain.ProjectPresenceModel{}, err
+	}
+
+	var board string
+	if p.BoardID.Valid {
+		board = transformer.UUIDString(p.BoardID)
+	}
+	s.Presence.Touch(transformer.UUIDString(projectID), transformer.UUIDString(userID), board)
+	return s.projectPresence(projectID), nil
+}
+
+// LeaveProjectPresence drops the user from the list without waiting for the
+// TTL, e.g. when the tab is closed
+func (s *Service) LeaveProjectPresence(ctx context.Context, projectID, userID pgtype.UUID) error {
+	s.Presence.Leave(transformer.UUIDString(projectID), transformer.UUIDString(userID))
+	return nil
+}
+
+func (s *Service) projectPresence(projectID pgtype.UUID) domain.ProjectPresenceModel {
+	viewers := s.Presence.List(transformer.UUIDString(projectID))
+	items := make([]domain.ProjectViewerModel, 0, len(viewers))
+	for _, v := range viewers {
+		items = append(items, toProjectViewerModel(v))
+	}
+	return domain.ProjectPresenceModel{
+		ProjectID:  projectID,
+		Viewers:    items,
+		TTLSeconds: int(s.Presence.GetConfig().TTL.Seconds()),
+	}
+}
+
+func toProjectViewerModel(v presence.Viewer) domain.ProjectViewerModel {
+	m := domain.ProjectViewerModel{
+		Since:      v.Since,
+		LastSeenAt: v.LastSeenAt,
+	}
+	// both were written from valid UUIDs by TouchProjectPresence
+	_ = m.UserID.Scan(v.Member)
+	if v.Detail != "" {
+		_ = m.BoardID.Scan(v.Detail)
+	}
+	return m
+}
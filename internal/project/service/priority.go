@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/project/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ticketPriorityConstraint is the tickets (project_id, priority) foreign key
+// from the project priority migration
+const ticketPriorityConstraint = "tickets_priority_fkey"
+
+var (
+	ErrPriorityNotFound       = domain.NotFound("priority not found")
+	ErrPriorityKeyTaken       = domain.Conflict("priority key has been taken in this project").WithCode("priority_key_taken")
+	ErrPriorityInUse          = domain.Conflict("priority is still used by tickets, pass replaceWith to move them").WithCode("priority_in_use")
+	ErrPriorityReplacedBySelf = domain.Invalid("replaceWith must be another priority").WithCode("invalid_replacement")
+	ErrUnknownPriority        = domain.Invalid("priority is not defined for this project").WithCode("unknown_priority")
+)
+
+func toProjectPriorityModel(p repository.ProjectPriority) domain.ProjectPriorityModel {
+	return domain.ProjectPriorityModel{
+		ID:        p.ID,
+		ProjectID: p.ProjectID,
+		Key:       p.Key,
+		Name:      p.Name,
+		Color:     p.Color,
+		Position:  p.Position,
+		CreatedAt: p.CreatedAt.Time,
+		UpdatedAt: p.UpdatedAt.Time,
+	}
+}
+
+func (s *Service) ListProjectPriorities(ctx context.Context, projectID pgtype.UUID) ([]domain.ProjectPriorityModel, error) {
+	if _, err := s.GetProjectById(ctx, projectID); err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Repo.ListProjectPriorities(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("list project priorities: %w", err)
+	}
+
+	items := make([]domain.ProjectPriorityModel, len(rows))
+	for i, row := range rows {
+		items[i] = toProjectPriorityModel(row)
+	}
+	return items, nil
+}
+
+func (s *Service) CreateProjectPriority(ctx context.Context, projectID pgtype.UUID, p domain.ProjectPriorityCreateModel) (domain.ProjectPriorityModel, error) {
+	if err := s.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return domain.ProjectPriorityModel{}, err
+	}
+	if _, err := s.GetProjectById(ctx, projectID); err != nil {
+		return domain.ProjectPriorityModel{}, err
+	}
+
+	priority, err := s.Repo.CreateProjectPriority(ctx, repository.CreateProjectPriorityParams{
+		ID:        idgen.New(),
+		ProjectID: projectID,
+		Key:       p.Key,
+		Name:      p.Name,
+		Color:     p.Color,
+		Column5:   p.Position,
+	})
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+			return domain.ProjectPriorityModel{}, ErrPriorityKeyTaken
+		}
+		return domain.ProjectPriorityModel{}, fmt.Errorf("create project priority: %w", err)
+	}
+
+	result := toProjectPriorityModel(priority)
+	if err := s.Bus.Publish(ctx, pubsub.ProjectPriorityCreated, httpx.EncodePayload(result)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.ProjectPriorityCreated), "error", err)
+	}
+
+	return result, nil
+}
+
+func (s *Service) UpdateProjectPriority(ctx context.Context, projectID, id pgtype.UUID, p domain.ProjectPriorityUpdateModel) (domain.ProjectPriorityModel, error) {
+	if err := s.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return domain.ProjectPriorityModel{}, err
+	}
+
+	priority, err := s.Repo.UpdateProjectPriority(ctx, repository.UpdateProjectPriorityParams{
+		ID:        id,
+		ProjectID: projectID,
+		Column3:   p.Name,
+		Column4:   p.Color,
+		Column5:   p.Position,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ProjectPriorityModel{}, ErrPriorityNotFound
+		}
+		return domain.ProjectPriorityModel{}, fmt.Errorf("update project priority: %w", err)
+	}
+
+	result := toProjectPriorityModel(priority)
+	if err := s.Bus.Publish(ctx, pubsub.ProjectPriorityUpdated, httpx.EncodePayload(result)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.ProjectPriorityUpdated), "error", err)
+	}
+
+	return result, nil
+}
+
+// DeleteProjectPriority removes a level from the scheme. Tickets still on it
+// are moved to replaceWith in the same statement; without a replacement a
+// level in use is refused.
+func (s *Service) DeleteProjectPriority(ctx context.Context, projectID, id pgtype.UUID, replaceWith string) error {
+	if err := s.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return err
+	}
+
+	priorities, err := s.ListProjectPriorities(ctx, projectID)
+	if err != nil {
+		return err
+	}
+
+	var deleted *domain.ProjectPriorityModel
+	replacementFound := replaceWith == ""
+	for i := range priorities {
+		if priorities[i].ID == id {
+			deleted = &priorities[i]
+		}
+		if priorities[i].Key == replaceWith {
+			replacementFound = true
+		}
+	}
+	if deleted == nil {
+		return ErrPriorityNotFound
+	}
+	if replaceWith == deleted.Key {
+		return ErrPriorityReplacedBySelf
+	}
+	if !replacementFound {
+		return ErrUnknownPriority
+	}
+
+	n, err := s.Repo.DeleteProjectPriority(ctx, repository.DeleteProjectPriorityParams{
+		ID:        id,
+		ProjectID: projectID,
+		Column3:   replaceWith,
+	})
+	if err != nil {
+		if isPriorityInUse(err) {
+			return ErrPriorityInUse
+		}
+		return fmt.Errorf("delete project priority: %w", err)
+	}
+	if n == 0 {
+		return ErrPriorityNotFound
+	}
+
+	if err := s.Bus.Publish(ctx, pubsub.ProjectPriorityDeleted, httpx.EncodePayload(*deleted)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.ProjectPriorityDeleted), "error", err)
+	}
+
+	return nil
+}
+
+func isPriorityInUse(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == ticketPriorityConstraint
+}
@@ -9,7 +9,10 @@ import (
 	"github.com/dimasbaguspm/fluxis/internal/project/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -17,19 +20,12 @@ import (
 )
 
 var (
-	ErrProjectNotFound = httpx.NotFound("project not found")
-	ErrKeyIsTaken      = httpx.Conflict("project key has been taken")
+	ErrProjectNotFound = domain.NotFound("project not found")
+	ErrKeyIsTaken      = domain.Conflict("project key has been taken")
+	ErrAdminOnly       = domain.Forbidden("includeDeleted requires admin access").WithCode("insufficient_scope")
 )
 
-func (s *Service) GetProjectById(ctx context.Context, id pgtype.UUID) (domain.ProjectModel, error) {
-	project, err := s.Repo.GetProject(ctx, id)
-	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.ProjectModel{}, ErrProjectNotFound
-		}
-		return domain.ProjectModel{}, fmt.Errorf("get project by id: %w", err)
-	}
-
+func toProjectModel(project repository.Project) domain.ProjectModel {
 	return domain.ProjectModel{
 		ID:          project.ID,
 		OrgID:       project.OrgID,
@@ -37,9 +33,27 @@ func (s *Service) GetProjectById(ctx context.Context, id pgtype.UUID) (domain.Pr
 		Name:        project.Name,
 		Description: project.Description.String,
 		Visibility:  string(project.Visibility),
+		Status:      domain.ProjectStatus(project.Status),
 		CreatedAt:   project.CreatedAt.Time,
 		UpdatedAt:   project.UpdatedAt.Time,
-	}, nil
+		DeletedAt:   transformer.TimePtr(project.DeletedAt),
+	}
+}
+
+func (s *Service) GetProjectById(ctx context.Context, id pgtype.UUID) (domain.ProjectModel, error) {
+	if err := s.AuthorizeProject(ctx, id, domain.ProjectRoleViewer); err != nil {
+		return domain.ProjectModel{}, err
+	}
+
+	project, err := s.Repo.GetProject(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ProjectModel{}, ErrProjectNotFound
+		}
+		return domain.ProjectModel{}, fmt.Errorf("get project by id: %w", err)
+	}
+
+	return toProjectModel(project), nil
 }
 
 func (s *Service) GetProjectByKey(ctx context.Context, orgId pgtype.UUID, key string) (domain.ProjectModel, error) {
@@ -53,17 +67,11 @@ func (s *Service) GetProjectByKey(ctx context.Context, orgId pgtype.UUID, key st
 		}
 		return domain.ProjectModel{}, fmt.Errorf("get project by key: %w", err)
 	}
+	if err := s.AuthorizeProject(ctx, project.ID, domain.ProjectRoleViewer); err != nil {
+		return domain.ProjectModel{}, err
+	}
 
-	return domain.ProjectModel{
-		ID:          project.ID,
-		OrgID:       project.OrgID,
-		Key:         project.Key,
-		Name:        project.Name,
-		Description: project.Description.String,
-		Visibility:  string(project.Visibility),
-		CreatedAt:   project.CreatedAt.Time,
-		UpdatedAt:   project.UpdatedAt.Time,
-	}, nil
+	return toProjectModel(project), nil
 }
 
 func (s *Service) ListProjectsByOrg(ctx context.Context, orgId pgtype.UUID) ([]domain.ProjectModel, error) {
@@ -77,16 +85,7 @@ func (s *Service) ListProjectsByOrg(ctx context.Context, orgId pgtype.UUID) ([]d
 
 	data := make([]domain.ProjectModel, len(projects))
 	for i, project := range projects {
-		data[i] = domain.ProjectModel{
-			ID:          project.ID,
-			OrgID:       project.OrgID,
-			Key:         project.Key,
-			Name:        project.Name,
-			Description: project.Description.String,
-			Visibility:  string(project.Visibility),
-			CreatedAt:   project.CreatedAt.Time,
-			UpdatedAt:   project.UpdatedAt.Time,
-		}
+		data[i] = toProjectModel(project)
 	}
 
 	return data, nil
@@ -95,51 +94,77 @@ func (s *Service) ListProjectsByOrg(ctx context.Context, orgId pgtype.UUID) ([]d
 func (s *Service) ListProjectsByOrgPaged(ctx context.Context, q domain.ProjectsSearchModel) (domain.ProjectsPagedModel, error) {
 	q.ApplyDefaults()
 
+	if q.IncludeDeleted && !domain.HasScopes(httpx.ScopesFrom(ctx), domain.ScopeAdmin) {
+		return domain.ProjectsPagedModel{}, ErrAdminOnly
+	}
+
 	projects, err := s.Repo.ListProjectsByOrgPaged(ctx, repository.ListProjectsByOrgPagedParams{
 		Column1: q.OrgID,
 		Column2: q.ID,
 		Column3: q.Name,
 		Limit:   int32(q.PageSize),
-		Offset:  int32((q.PageNumber - 1) * q.PageSize),
+		Offset:  pagination.Offset(q.PageNumber, q.PageSize),
+		Column6: q.IncludeDeleted,
+		Column7: q.ExactName,
+		Column8: httpx.MemberFilter(ctx),
 	})
 
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			emptyResult := domain.ProjectsPagedModel{}
-			return emptyResult.Empty(q.PageNumber, q.PageSize), nil
-		}
 		return domain.ProjectsPagedModel{}, fmt.Errorf("list projects by org paged: %w", err)
 	}
 
-	var totalCount int64
-	data := make([]domain.ProjectModel, 0, len(projects))
+	page := pagination.FromRows(projects,
+		func(project repository.ListProjectsByOrgPagedRow) int64 { return project.TotalCount },
+		func(project repository.ListProjectsByOrgPagedRow) domain.ProjectModel {
+			return domain.ProjectModel{
+				ID:          project.ID,
+				OrgID:       project.OrgID,
+				Key:         project.Key,
+				Name:        project.Name,
+				Description: project.Description.String,
+				Visibility:  string(project.Visibility),
+				Status:      domain.ProjectStatus(project.Status),
+				CreatedAt:   project.CreatedAt.Time,
+				UpdatedAt:   project.UpdatedAt.Time,
+			}
+		},
+		q.PageNumber, q.PageSize)
 
-	for _, project := range projects {
-		totalCount = project.TotalCount
-		data = append(data, domain.ProjectModel{
-			ID:          project.ID,
-			OrgID:       project.OrgID,
-			Key:         project.Key,
-			Name:        project.Name,
-			Description: project.Description.String,
-			Visibility:  string(project.Visibility),
-			CreatedAt:   project.CreatedAt.Time,
-			UpdatedAt:   project.UpdatedAt.Time,
-		})
+	if q.IncludeSummary && len(page.Items) > 0 {
+		if err := s.attachProjectSummaries(ctx, page.Items); err != nil {
+			return domain.ProjectsPagedModel{}, err
+		}
 	}
 
-	totalPages := 0
-	if totalCount > 0 {
-		totalPages = int((totalCount + int64(q.PageSize) - 1) / int64(q.PageSize))
+	return domain.ProjectsPagedModel(page), nil
+}
+
+func (s *Service) attachProjectSummaries(ctx context.Context, projects []domain.ProjectModel) error {
+	ids := make([]pgtype.UUID, len(projects))
+	for i, p := range projects {
+		ids[i] = p.ID
 	}
 
-	return domain.ProjectsPagedModel{
-		Items:      data,
-		TotalCount: int(totalCount),
-		TotalPages: totalPages,
-		PageNumber: q.PageNumber,
-		PageSize:   q.PageSize,
-	}, nil
+	summaries, err := s.Repo.ListProjectSummaries(ctx, ids)
+	if err != nil {
+		return fmt.Errorf("list project summaries: %w", err)
+	}
+
+	byProject := make(map[pgtype.UUID]repository.ListProjectSummariesRow, len(summaries))
+	for _, summary := range summaries {
+		byProject[summary.ID] = summary
+	}
+
+	for i := range projects {
+		summary := byProject[projects[i].ID]
+		lastActivityAt := summary.LastActivityAt.Time
+		projects[i].OpenTicketCount = &summary.OpenTicketCount
+		projects[i].DoneTicketCount = &summary.DoneTicketCount
+		projects[i].ColumnCount = &summary.ColumnCount
+		projects[i].LastActivityAt = &lastActivityAt
+	}
+
+	return nil
 }
 
 func (s *Service) CreateProject(ctx context.Context, orgId pgtype.UUID, p domain.ProjectCreateModel) (domain.ProjectModel, error) {
@@ -148,33 +173,18 @@ func (s *Service) CreateProject(ctx context.Context, orgId pgtype.UUID, p domain
 		return domain.ProjectModel{}, err
 	}
 
-	project, err := s.Repo.CreateProject(ctx, repository.CreateProjectParams{
-		OrgID:       org.ID,
-		Key:         p.Key,
-		Name:        p.Name,
-		Description: pgtype.Text{String: p.Description, Valid: p.Description != ""},
-		Visibility:  repository.ProjectVisibility(p.Visibility),
-	})
+	params, err := s.prepareProject(ctx, idgen.New(), org.ID, p)
 	if err != nil {
-		if pgErr, ok := err.(*pgconn.PgError); ok {
-			if pgErr.Code == "23505" { // unique constraint violation
-				return domain.ProjectModel{}, ErrKeyIsTaken
-			}
-		}
-		return domain.ProjectModel{}, fmt.Errorf("create project: %w", err)
+		return domain.ProjectModel{}, err
 	}
 
-	result := domain.ProjectModel{
-		ID:          project.ID,
-		OrgID:       project.OrgID,
-		Key:         project.Key,
-		Name:        project.Name,
-		Description: project.Description.String,
-		Visibility:  string(project.Visibility),
-		CreatedAt:   project.CreatedAt.Time,
-		UpdatedAt:   project.UpdatedAt.Time,
+	project, err := s.Repo.CreateProject(ctx, params)
+	if err != nil {
+		return domain.ProjectModel{}, createProjectError(err)
 	}
 
+	result := toProjectModel(project)
+
 	if err := s.Bus.Publish(ctx, pubsub.ProjectCreated, httpx.EncodePayload(result)); err != nil {
 		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.ProjectCreated), "error", err)
 	}
@@ -182,7 +192,68 @@ func (s *Service) CreateProject(ctx context.Context, orgId pgtype.UUID, p domain
 	return result, nil
 }
 
+// prepareProject runs every check a new project has to pass before it is
+// written and returns the row to insert under id. A project already stored
+// under id does not clash with its own name.
+func (s *Service) prepareProject(ctx context.Context, id, orgID pgtype.UUID, p domain.ProjectCreateModel) (repository.CreateProjectParams, error) {
+	var err error
+	if p.Name, p.Description, err = s.screenText(p.Name, p.Description); err != nil {
+		return repository.CreateProjectParams{}, err
+	}
+
+	status, err := initialStatus(p.Status)
+	if err != nil {
+		return repository.CreateProjectParams{}, err
+	}
+
+	if err := s.checkProjectName(ctx, p.Name, id); err != nil {
+		return repository.CreateProjectParams{}, err
+	}
+	if err := s.checkDescription(p.Description); err != nil {
+		return repository.CreateProjectParams{}, err
+	}
+
+	// the caller becomes the project's first admin
+	creatorID, _ := httpx.UserIDFrom(ctx)
+
+	return repository.CreateProjectParams{
+		ID:          id,
+		OrgID:       orgID,
+		Key:         p.Key,
+		Name:        p.Name,
+		Description: pgtype.Text{String: p.Description, Valid: p.Description != ""},
+		Visibility:  repository.ProjectVisibility(p.Visibility),
+		Status:      status,
+		Column8:     creatorID,
+	}, nil
+}
+
+func createProjectError(err error) error {
+	if pgErr, ok := err.(*pgconn.PgError); ok {
+		if pgErr.Code == "23505" { // unique constraint violation
+			return ErrKeyIsTaken
+		}
+	}
+	return fmt.Errorf("create project: %w", err)
+}
+
 func (s *Service) UpdateProject(ctx context.Context, id pgtype.UUID, p domain.ProjectUpdateModel) (domain.ProjectModel, error) {
+	if err := s.AuthorizeProject(ctx, id, domain.ProjectRoleAdmin); err != nil {
+		return domain.ProjectModel{}, err
+	}
+
+	var err error
+	if p.Name, p.Description, err = s.screenText(p.Name, p.Description); err != nil {
+		return domain.ProjectModel{}, err
+	}
+
+	if err := s.checkProjectName(ctx, p.Name, id); err != nil {
+		return domain.ProjectModel{}, err
+	}
+	if err := s.checkDescription(p.Description); err != nil {
+		return domain.ProjectModel{}, err
+	}
+
 	project, err := s.Repo.UpdateProject(ctx, repository.UpdateProjectParams{
 		ID:          id,
 		Name:        p.Name,
@@ -195,16 +266,7 @@ func (s *Service) UpdateProject(ctx context.Context, id pgtype.UUID, p domain.Pr
 		return domain.ProjectModel{}, fmt.Errorf("update project: %w", err)
 	}
 
-	result := domain.ProjectModel{
-		ID:          project.ID,
-		OrgID:       project.OrgID,
-		Key:         project.Key,
-		Name:        project.Name,
-		Description: project.Description.String,
-		Visibility:  string(project.Visibility),
-		CreatedAt:   project.CreatedAt.Time,
-		UpdatedAt:   project.UpdatedAt.Time,
-	}
+	result := toProjectModel(project)
 
 	if err := s.Bus.Publish(ctx, pubsub.ProjectUpdated, httpx.EncodePayload(result)); err != nil {
 		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.ProjectUpdated), "error", err)
@@ -214,6 +276,10 @@ func (s *Service) UpdateProject(ctx context.Context, id pgtype.UUID, p domain.Pr
 }
 
 func (s *Service) UpdateProjectVisibility(ctx context.Context, id pgtype.UUID, p domain.ProjectVisibilityModel) (domain.ProjectModel, error) {
+	if err := s.AuthorizeProject(ctx, id, domain.ProjectRoleAdmin); err != nil {
+		return domain.ProjectModel{}, err
+	}
+
 	project, err := s.Repo.UpdateProjectVisibility(ctx, repository.UpdateProjectVisibilityParams{
 		ID:         id,
 		Visibility: repository.ProjectVisibility(p.Visibility),
@@ -225,16 +291,7 @@ func (s *Service) UpdateProjectVisibility(ctx context.Context, id pgtype.UUID, p
 		return domain.ProjectModel{}, fmt.Errorf("update project visibility: %w", err)
 	}
 
-	result := domain.ProjectModel{
-		ID:          project.ID,
-		OrgID:       project.OrgID,
-		Key:         project.Key,
-		Name:        project.Name,
-		Description: project.Description.String,
-		Visibility:  string(project.Visibility),
-		CreatedAt:   project.CreatedAt.Time,
-		UpdatedAt:   project.UpdatedAt.Time,
-	}
+	result := toProjectModel(project)
 
 	if err := s.Bus.Publish(ctx, pubsub.ProjectVisibilityUpdated, httpx.EncodePayload(result)); err != nil {
 		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.ProjectVisibilityUpdated), "error", err)
@@ -244,6 +301,10 @@ func (s *Service) UpdateProjectVisibility(ctx context.Context, id pgtype.UUID, p
 }
 
 func (s *Service) DeleteProject(ctx context.Context, id pgtype.UUID) error {
+	if err := s.AuthorizeProject(ctx, id, domain.ProjectRoleAdmin); err != nil {
+		return err
+	}
+
 	_, err := s.Repo.DeleteProject(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -258,3 +319,32 @@ func (s *Service) DeleteProject(ctx context.Context, id pgtype.UUID) error {
 
 	return nil
 }
+
+// checkDescription keeps pasted documents out of the project row, which every
+// project list carries in full
+func (s *Service) checkDescription(description string) error {
+	if s.Config == nil {
+		return nil
+	}
+	return domain.ValidateLength("description", description, s.Config.MaxDescriptionLength)
+}
+
+// screenText runs the name and description through the instance's content
+// filter before any other check sees them
+func (s *Service) screenText(name, description string) (string, string, error) {
+	if s.Filter == nil {
+		return name, description, nil
+	}
+	var err error
+	if name != "" {
+		if name, err = s.Filter.Filter("name", name); err != nil {
+			return "", "", err
+		}
+	}
+	if description != "" {
+		if description, err = s.Filter.Filter("description", description); err != nil {
+			return "", "", err
+		}
+	}
+	return name, description, nil
+}
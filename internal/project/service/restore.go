@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrProjectNotDeleted = domain.Conflict("project is not deleted").WithCode("not_deleted")
+	ErrOrgDeleted        = domain.Conflict("the project's organisation is deleted").WithCode("parent_deleted")
+)
+
+// RestoreProject brings a soft-deleted project back along with everything
+// under it, which a project delete leaves untouched
+func (s *Service) RestoreProject(ctx context.Context, id pgtype.UUID) (domain.ProjectModel, error) {
+	if err := s.AuthorizeProject(ctx, id, domain.ProjectRoleAdmin); err != nil {
+		return domain.ProjectModel{}, err
+	}
+
+	project, err := s.Repo.RestoreProject(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.ProjectModel{}, s.refusedRestore(ctx, id)
+	}
+	if err != nil {
+		return domain.ProjectModel{}, fmt.Errorf("restore project: %w", err)
+	}
+
+	result := toProjectModel(project)
+	if err := s.Bus.Publish(ctx, pubsub.ProjectRestored, httpx.EncodePayload(result)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.ProjectRestored), "error", err)
+	}
+	return result, nil
+}
+
+// refusedRestore tells why nothing was restored
+func (s *Service) refusedRestore(ctx context.Context, id pgtype.UUID) error {
+	state, err := s.Repo.GetProjectTrashState(ctx, id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return ErrProjectNotFound
+	case err != nil:
+		return fmt.Errorf("get project trash state: %w", err)
+	case !state.Deleted:
+		return ErrProjectNotDeleted
+	default:
+		return ErrOrgDeleted
+	}
+}
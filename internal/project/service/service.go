@@ -1,15 +1,36 @@
 package service
 
 import (
+	"context"
+
 	"github.com/dimasbaguspm/fluxis/internal/project/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/contentfilter"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/presence"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
 )
 
+// TxBeginner opens the transaction a write spanning several projects runs
+// in, the pool in practice
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
 type Deps struct {
-	Repo *repository.Queries
-	Org  domain.OrgReader
-	Bus  pubsub.Publisher
+	Repo   *repository.Queries
+	DB     TxBeginner
+	Org    domain.OrgReader
+	Bus    pubsub.Publisher
+	Config *Config
+	Filter contentfilter.Filter
+	// Presence holds who is viewing each project, in memory only
+	Presence *presence.Tracker
+}
+
+type Config struct {
+	UniqueNames          bool // reject names another live project already uses, ignoring case
+	MaxDescriptionLength int  // characters, zero or less disables the limit
 }
 
 type Service struct {
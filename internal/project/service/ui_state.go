@@ -0,0 +1,88 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/project/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// uiStateSizeConstraint measures the stored jsonb, re-serialised with spaces
+// after every separator, so a body just under the limit can still exceed it
+const uiStateSizeConstraint = "project_ui_states_state_size"
+
+var (
+	ErrUIStateTooLarge  = domain.TooLarge(fmt.Sprintf("ui state must not exceed %d bytes", domain.MaxProjectUIStateBytes)).WithCode("ui_state_too_large")
+	ErrUIStateNotObject = domain.Invalid("ui state must be a JSON object").WithCode("invalid_ui_state")
+)
+
+func (s *Service) GetProjectUIState(ctx context.Context, projectID, userID pgtype.UUID) (domain.ProjectUIStateModel, error) {
+	if _, err := s.GetProjectById(ctx, projectID); err != nil {
+		return domain.ProjectUIStateModel{}, err
+	}
+
+	state, err := s.Repo.GetProjectUIState(ctx, repository.GetProjectUIStateParams{
+		UserID:    userID,
+		ProjectID: projectID,
+	})
+	if err != nil {
+		// nothing saved yet, the client falls back to its defaults
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.ProjectUIStateModel{
+				ProjectID: projectID,
+				State:     json.RawMessage("{}"),
+			}, nil
+		}
+		return domain.ProjectUIStateModel{}, fmt.Errorf("get project ui state: %w", err)
+	}
+
+	return toProjectUIStateModel(state), nil
+}
+
+func (s *Service) UpdateProjectUIState(ctx context.Context, projectID, userID pgtype.UUID, p domain.ProjectUIStateUpdateModel) (domain.ProjectUIStateModel, error) {
+	if len(p.State) > domain.MaxProjectUIStateBytes {
+		return domain.ProjectUIStateModel{}, ErrUIStateTooLarge
+	}
+	if trimmed := bytes.TrimSpace(p.State); len(trimmed) == 0 || trimmed[0] != '{' {
+		return domain.ProjectUIStateModel{}, ErrUIStateNotObject
+	}
+
+	if _, err := s.GetProjectById(ctx, projectID); err != nil {
+		return domain.ProjectUIStateModel{}, err
+	}
+
+	state, err := s.Repo.UpsertProjectUIState(ctx, repository.UpsertProjectUIStateParams{
+		UserID:    userID,
+		ProjectID: projectID,
+		State:     p.State,
+	})
+	if err != nil {
+		if isUIStateTooLarge(err) {
+			return domain.ProjectUIStateModel{}, ErrUIStateTooLarge
+		}
+		return domain.ProjectUIStateModel{}, fmt.Errorf("upsert project ui state: %w", err)
+	}
+
+	return toProjectUIStateModel(state), nil
+}
+
+func isUIStateTooLarge(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23514" && pgErr.ConstraintName == uiStateSizeConstraint
+}
+
+func toProjectUIStateModel(state repository.ProjectUiState) domain.ProjectUIStateModel {
+	updatedAt := state.UpdatedAt.Time
+	return domain.ProjectUIStateModel{
+		ProjectID: state.ProjectID,
+		State:     json.RawMessage(state.State),
+		UpdatedAt: &updatedAt,
+	}
+}
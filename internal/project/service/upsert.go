@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/project/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrProjectIDTaken = domain.Conflict("project id belongs to another organisation, key or a deleted project").WithCode("project_id_taken")
+
+// UpsertProject creates the project under the given id or updates it in
+// place, so import flows can replay the same payload safely. The key and
+// organisation of an existing project never change; a mismatch is a conflict.
+// Updating takes the admin role, checked in the same statement as the write.
+// The boolean reports whether the project was created.
+func (s *Service) UpsertProject(ctx context.Context, id pgtype.UUID, orgId pgtype.UUID, p domain.ProjectCreateModel) (domain.ProjectModel, bool, error) {
+	org, err := s.Org.GetOrgById(ctx, orgId)
+	if err != nil {
+		return domain.ProjectModel{}, false, err
+	}
+
+	params, err := s.prepareProject(ctx, id, org.ID, p)
+	if err != nil {
+		return domain.ProjectModel{}, false, err
+	}
+
+	row, err := s.Repo.UpsertProject(ctx, repository.UpsertProjectParams{
+		ID:          params.ID,
+		OrgID:       params.OrgID,
+		Key:         params.Key,
+		Name:        params.Name,
+		Description: params.Description,
+		Visibility:  params.Visibility,
+		Status:      params.Status,
+		Column8:     params.Column8,
+		Column9:     httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			// the project exists but was not updated, tell a caller without
+			// the admin role apart from a mismatched key or organisation
+			if err := s.AuthorizeProject(ctx, id, domain.ProjectRoleAdmin); err != nil {
+				return domain.ProjectModel{}, false, err
+			}
+			return domain.ProjectModel{}, false, ErrProjectIDTaken
+		}
+		if pgErr, ok := err.(*pgconn.PgError); ok {
+			if pgErr.Code == "23505" { // unique constraint violation
+				return domain.ProjectModel{}, false, ErrKeyIsTaken
+			}
+		}
+		return domain.ProjectModel{}, false, fmt.Errorf("upsert project: %w", err)
+	}
+
+	result := toProjectModel(repository.Project{
+		ID:          row.ID,
+		OrgID:       row.OrgID,
+		Key:         row.Key,
+		Name:        row.Name,
+		Description: row.Description,
+		Visibility:  row.Visibility,
+		CreatedAt:   row.CreatedAt,
+		UpdatedAt:   row.UpdatedAt,
+		DeletedAt:   row.DeletedAt,
+		Status:      row.Status,
+	})
+
+	event := pubsub.ProjectUpdated
+	if row.Inserted {
+		event = pubsub.ProjectCreated
+	}
+	if err := s.Bus.Publish(ctx, event, httpx.EncodePayload(result)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(event), "error", err)
+	}
+
+	return result, row.Inserted, nil
+}
@@ -1,20 +1,28 @@
 -- name: CreateProject :one
-INSERT INTO projects (org_id, key, name, description, visibility)
-VALUES ($1, $2, $3, $4, $5)
-RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at;
+-- The creator, $8 when given, joins the project as its first admin
+WITH created AS (
+  INSERT INTO projects (org_id, key, name, description, visibility, status, id)
+  VALUES ($1, $2, $3, $4, $5, $6, $7)
+  RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
+), creator AS (
+  INSERT INTO project_members (project_id, user_id, role)
+  SELECT id, $8::uuid, 'admin' FROM created WHERE $8::uuid IS NOT NULL
+)
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
+FROM created;
 
 -- name: GetProject :one
-SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 FROM projects
 WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetProjectByKey :one
-SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 FROM projects
 WHERE org_id = $1 AND key = $2 AND deleted_at IS NULL;
 
 -- name: ListProjectsByOrg :many
-SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status
 FROM projects
 WHERE org_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC;
@@ -22,18 +30,21 @@ ORDER BY created_at DESC;
 -- name: ListProjectsByOrgPaged :many
 WITH filtered_projects AS (
   SELECT
-    id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at,
+    id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status,
     COUNT(*) OVER () as total_count
   FROM
     projects
   WHERE
-    deleted_at IS NULL
+    ($6::boolean OR deleted_at IS NULL)
     AND (array_length($1::uuid[], 1) IS NULL OR org_id = ANY($1::uuid[]))
     AND (array_length($2::uuid[], 1) IS NULL OR id = ANY($2::uuid[]))
-    AND ($3::text = '' OR name ILIKE '%' || $3 || '%')
+    AND ($3::text = '' OR CASE WHEN $7::boolean THEN lower(name) = lower($3) ELSE name ILIKE '%' || $3 || '%' END)
+    AND ($8::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM project_members pm WHERE pm.project_id = projects.id AND pm.user_id = $8::uuid
+    ))
 )
 SELECT
-  id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, total_count
+  id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status, total_count
 FROM
   filtered_projects
 ORDER BY
@@ -41,24 +52,231 @@ ORDER BY
 LIMIT $4
 OFFSET $5;
 
+-- name: ListProjectNamesWithPrefix :many
+-- Live project names starting with $1, ignoring case; used to detect a name clash and suggest a free variant
+SELECT id, name
+FROM projects
+WHERE left(lower(name), char_length($1::text)) = lower($1::text) AND deleted_at IS NULL;
+
 -- name: UpdateProject :one
 UPDATE projects
-SET name = $2, description = $3, updated_at = NOW()
+SET name = $2, description = $3
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at;
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status;
 
 -- name: UpdateProjectVisibility :one
 UPDATE projects
-SET visibility = $2, updated_at = NOW()
+SET visibility = $2
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at;
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status;
+
+-- name: UpdateProjectStatus :one
+-- Transitions only apply when the project is still in the expected status
+UPDATE projects
+SET status = $2
+WHERE id = $1 AND status = $3 AND deleted_at IS NULL
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status;
 
 -- name: DeleteProject :one
 UPDATE projects
 SET deleted_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at;
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status;
+
+-- name: RestoreProject :one
+-- Clears deleted_at as long as the organisation is still there
+UPDATE projects
+SET deleted_at = NULL
+WHERE id = $1 AND deleted_at IS NOT NULL
+  AND EXISTS (SELECT 1 FROM orgs o WHERE o.id = projects.org_id AND o.deleted_at IS NULL)
+RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status;
+
+-- name: GetProjectTrashState :one
+-- Whether a project and the organisation it belongs to are deleted, to explain a refused restore
+SELECT p.deleted_at IS NOT NULL AS deleted, o.deleted_at IS NOT NULL AS org_deleted
+FROM projects p
+JOIN orgs o ON o.id = p.org_id
+WHERE p.id = $1;
 
 -- name: HardDeleteProject :exec
 DELETE FROM projects
 WHERE id = $1;
+
+-- name: ListProjectSummaries :many
+-- Computes per-project progress for the given projects; a ticket is done while it sits in a done column
+SELECT
+  p.id,
+  COALESCE(ts.open_ticket_count, 0)::bigint AS open_ticket_count,
+  COALESCE(ts.done_ticket_count, 0)::bigint AS done_ticket_count,
+  COALESCE(cs.column_count, 0)::bigint AS column_count,
+  GREATEST(p.updated_at, ts.last_ticket_at)::timestamptz AS last_activity_at
+FROM
+  projects p
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) FILTER (WHERE bc.category IS DISTINCT FROM 'done') AS open_ticket_count,
+      COUNT(*) FILTER (WHERE bc.category = 'done') AS done_ticket_count,
+      MAX(t.updated_at) AS last_ticket_at
+    FROM
+      tickets t
+      LEFT JOIN board_columns bc ON bc.id = t.board_column_id AND bc.deleted_at IS NULL
+    WHERE
+      t.project_id = p.id
+      AND t.deleted_at IS NULL
+  ) ts ON true
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) AS column_count
+    FROM
+      board_columns bc
+      JOIN boards b ON b.id = bc.board_id AND b.deleted_at IS NULL
+      JOIN sprints s ON s.id = b.sprint_id AND s.deleted_at IS NULL
+    WHERE
+      s.project_id = p.id
+      AND bc.deleted_at IS NULL
+  ) cs ON true
+WHERE
+  p.id = ANY($1::uuid[]);
+
+-- name: GetProjectUIState :one
+SELECT
+  user_id, project_id, state, updated_at
+FROM
+  project_ui_states
+WHERE
+  user_id = $1
+  AND project_id = $2;
+
+-- name: UpsertProjectUIState :one
+INSERT INTO
+  project_ui_states (user_id, project_id, state)
+VALUES
+  ($1, $2, $3)
+ON CONFLICT (user_id, project_id) DO UPDATE
+SET
+  state = EXCLUDED.state
+RETURNING
+  user_id, project_id, state, updated_at;
+
+-- name: UpsertProject :one
+-- Creates the project under a caller supplied id, or updates it in place when it already exists
+-- in the same org with the same key; inserted tells the two apart. Status only applies on insert,
+-- and so does making the creator, $8 when given, the project's first admin
+WITH upserted AS (
+  INSERT INTO projects (id, org_id, key, name, description, visibility, status)
+  VALUES ($1, $2, $3, $4, $5, $6, $7)
+  ON CONFLICT (id) DO UPDATE
+  SET name = EXCLUDED.name, description = EXCLUDED.description, visibility = EXCLUDED.visibility
+  WHERE projects.org_id = EXCLUDED.org_id AND projects.key = EXCLUDED.key AND projects.deleted_at IS NULL
+  RETURNING id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status, (xmax = 0)::boolean AS inserted
+), creator AS (
+  INSERT INTO project_members (project_id, user_id, role)
+  SELECT id, $8::uuid, 'admin' FROM upserted WHERE inserted AND $8::uuid IS NOT NULL
+)
+SELECT id, org_id, key, name, description, visibility, created_at, updated_at, deleted_at, status, inserted
+FROM upserted;
+
+-- name: ListProjectPriorities :many
+SELECT id, project_id, key, name, color, position, created_at, updated_at
+FROM project_priorities
+WHERE project_id = $1
+ORDER BY position, key;
+
+-- name: CreateProjectPriority :one
+-- A zero position appends the level after the current last one
+INSERT INTO project_priorities (project_id, key, name, color, position, id)
+VALUES (
+  $1, $2, $3, $4,
+  CASE WHEN $5::int > 0 THEN $5::int
+  ELSE (SELECT COALESCE(MAX(position), 0) + 1024 FROM project_priorities WHERE project_id = $1) END,
+  $6
+)
+RETURNING id, project_id, key, name, color, position, created_at, updated_at;
+
+-- name: UpdateProjectPriority :one
+-- The key is immutable since tickets reference it; empty fields keep their value
+UPDATE project_priorities
+SET
+  name = COALESCE(NULLIF($3::text, ''), name),
+  color = COALESCE(NULLIF($4::text, ''), color),
+  position = CASE WHEN $5::int > 0 THEN $5::int ELSE position END
+WHERE id = $1 AND project_id = $2
+RETURNING id, project_id, key, name, color, position, created_at, updated_at;
+
+-- name: DeleteProjectPriority :execrows
+-- Moves the level's tickets, deleted ones included, to $3 first when given;
+-- otherwise the ticket foreign key refuses to drop a level that is still in use
+WITH reassigned AS (
+  UPDATE tickets t
+  SET priority = $3::text
+  FROM project_priorities pp
+  WHERE pp.id = $1 AND pp.project_id = $2 AND $3::text <> ''
+    AND t.project_id = pp.project_id AND t.priority = pp.key
+)
+DELETE FROM project_priorities
+WHERE id = $1 AND project_id = $2;
+
+-- name: ClearProjectPriorities :exec
+-- Drops every level of a project that no ticket uses yet, e.g. the defaults of a project about to copy a template
+DELETE FROM project_priorities
+WHERE project_id = $1;
+
+-- name: CopyProjectPriorities :exec
+-- Gives project $1 the priority levels of project $2, keys, names, colors and order included
+INSERT INTO project_priorities (project_id, key, name, color, position)
+SELECT $1::uuid, key, name, color, position
+FROM project_priorities
+WHERE project_id = $2;
+
+-- name: GetProjectMemberRole :one
+-- Deleted projects keep their members so a restore can be authorised
+SELECT role
+FROM project_members
+WHERE project_id = $1 AND user_id = $2;
+
+-- name: ListProjectMembers :many
+WITH filtered_members AS (
+  SELECT
+    pm.project_id, pm.user_id, pm.role, pm.joined_at,
+    u.email, u.display_name,
+    COUNT(*) OVER () as total_count
+  FROM
+    project_members pm
+    JOIN users u ON u.id = pm.user_id
+  WHERE
+    pm.project_id = $1
+    AND (array_length($2::uuid[], 1) IS NULL OR pm.user_id = ANY($2::uuid[]))
+    AND ($3::text = '' OR pm.role::text = $3::text)
+)
+SELECT
+  project_id, user_id, role, joined_at, email, display_name, total_count
+FROM
+  filtered_members
+ORDER BY
+  joined_at DESC
+LIMIT $4
+OFFSET $5;
+
+-- name: CreateProjectMember :one
+-- Only members of the project's organisation can join; no row means the user is not one
+INSERT INTO project_members (project_id, user_id, role)
+SELECT p.id, om.user_id, $3
+FROM projects p
+JOIN org_members om ON om.org_id = p.org_id AND om.user_id = $2
+WHERE p.id = $1 AND p.deleted_at IS NULL
+RETURNING project_id, user_id, role, joined_at;
+
+-- name: UpdateProjectMemberRole :one
+UPDATE project_members
+SET role = $3
+WHERE project_id = $1 AND user_id = $2
+RETURNING project_id, user_id, role, joined_at;
+
+-- name: DeleteProjectMember :execrows
+DELETE FROM project_members
+WHERE project_id = $1 AND user_id = $2;
+
+-- name: CountProjectAdmins :one
+SELECT COUNT(*)
+FROM project_members
+WHERE project_id = $1 AND role = 'admin';
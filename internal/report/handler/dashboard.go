@@ -0,0 +1,28 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetDashboard godoc
+//
+//	@Summary		Get dashboard summary
+//	@ID				getDashboard
+//	@Description	Returns home screen counters (active projects, open/overdue/recently completed tickets) and the latest ticket activity across the caller's organisations
+//	@Tags			report
+//	@Produce		json
+//	@Success		200	{object}	domain.DashboardModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/dashboard [get]
+func (h *Handler) GetDashboard(w http.ResponseWriter, r *http.Request) {
+	dashboard, err := h.svc.GetDashboard(r.Context(), httpx.MustUserID(r.Context()))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, dashboard)
+}
@@ -0,0 +1,30 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetPortfolio godoc
+//
+//	@Summary		Get portfolio overview
+//	@ID				getPortfolio
+//	@Description	Rolls up every active project across the caller's organisations: ticket and overdue counts, active sprint progress as the milestone, and a trend comparing completions in the last periodDays (default 7) with the period before
+//	@Tags			report
+//	@Produce		json
+//	@Param			periodDays	query		int	false	"Length of the trend period in days (1-90)"
+//	@Success		200			{object}	domain.PortfolioModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/portfolio [get]
+func (h *Handler) GetPortfolio(w http.ResponseWriter, r *http.Request) {
+	portfolio, err := h.svc.GetPortfolio(r.Context(), httpx.MustUserID(r.Context()), httpx.QueryNumber(r, "periodDays"))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, portfolio)
+}
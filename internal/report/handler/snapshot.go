@@ -0,0 +1,49 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetBoardSnapshots godoc
+//
+//	@Summary		Get board snapshots
+//	@ID				getBoardSnapshots
+//	@Description	Returns the ticket count and story points of every board column as they stood at the end of each day in the range, for historical WIP and cumulative flow charts. Snapshots are taken in the background, days before the first one are empty. Pass boardId to only return one board
+//	@Tags			report
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			boardId	query		string	false	"Board ID"
+//	@Param			from	query		string	false	"First day, e.g. 2025-08-01. Defaults to 30 days before to"
+//	@Param			to		query		string	false	"Last day, e.g. 2025-08-30. Defaults to today"
+//	@Success		200		{object}	domain.BoardSnapshotsModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/reports/snapshots [get]
+func (h *Handler) GetBoardSnapshots(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var boardID pgtype.UUID
+	if httpx.QueryString(r, "boardId") != "" {
+		if boardID, err = httpx.QueryUUID(r, "boardId"); err != nil {
+			httpx.Handle(w, err)
+			return
+		}
+	}
+
+	snapshots, err := h.svc.GetBoardSnapshots(r.Context(), id, boardID, httpx.QueryString(r, "from"), httpx.QueryString(r, "to"))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, snapshots)
+}
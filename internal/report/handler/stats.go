@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetProjectStats godoc
+//
+//	@Summary		Get project stats
+//	@ID				getProjectStats
+//	@Description	Breaks the project's live tickets down per priority level in scheme order, and sums story point estimates per board column and per assignee with the remaining effort for burndown. Pass boardId to only count one board
+//	@Tags			report
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			boardId	query		string	false	"Board ID"
+//	@Success		200		{object}	domain.ProjectStatsModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/reports/stats [get]
+func (h *Handler) GetProjectStats(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var boardID pgtype.UUID
+	if httpx.QueryString(r, "boardId") != "" {
+		if boardID, err = httpx.QueryUUID(r, "boardId"); err != nil {
+			httpx.Handle(w, err)
+			return
+		}
+	}
+
+	stats, err := h.svc.GetProjectStats(r.Context(), id, boardID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, stats)
+}
@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetWeeklyReport godoc
+//
+//	@Summary		Get weekly project report
+//	@ID				getWeeklyReport
+//	@Description	Summarises a project's ISO week: completed, created and carried over tickets plus sprint status changes. Defaults to the current week
+//	@Tags			report
+//	@Produce		json
+//	@Param			id		path		string	true	"Project ID"
+//	@Param			week	query		string	false	"ISO week, e.g. 2025-W32"
+//	@Success		200		{object}	domain.WeeklyReportModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Failure		422		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/reports/weekly [get]
+func (h *Handler) GetWeeklyReport(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	report, err := h.svc.GetWeeklyReport(r.Context(), id, httpx.QueryString(r, "week"))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, report)
+}
@@ -0,0 +1,45 @@
+package report
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/report/handler"
+	"github.com/dimasbaguspm/fluxis/internal/report/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Module struct {
+	h    *handler.Handler
+	svc  *service.Service
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, svc *service.Service, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		svc:  svc,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /dashboard", m.auth.RequireAuth(m.h.GetDashboard, domain.ScopeReportsRead))
+	mux.HandleFunc("GET /portfolio", m.auth.RequireAuth(m.h.GetPortfolio, domain.ScopeReportsRead))
+	mux.HandleFunc("GET /projects/{id}/reports/weekly", m.auth.RequireAuth(m.h.GetWeeklyReport, domain.ScopeReportsRead))
+	mux.HandleFunc("GET /projects/{id}/reports/stats", m.auth.RequireAuth(m.h.GetProjectStats, domain.ScopeReportsRead))
+	mux.HandleFunc("GET /projects/{id}/reports/snapshots", m.auth.RequireAuth(m.h.GetBoardSnapshots, domain.ScopeReportsRead))
+}
+
+// StartSnapshotter periodically records the ticket counts of every board
+// column for the historical charts
+func (m *Module) StartSnapshotter(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	slog.Info("[ReportModule]: starting board snapshotter", "interval", interval.String())
+	m.svc.StartSnapshotter(ctx, interval)
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/report/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// completedWindow bounds what the dashboard treats as "recently" completed
+	completedWindow        = 7 * 24 * time.Hour
+	recentlyCompletedLimit = 5
+	latestActivityLimit    = 10
+)
+
+func (s *Service) GetDashboard(ctx context.Context, userID pgtype.UUID) (domain.DashboardModel, error) {
+	since := time.Now().Add(-completedWindow)
+
+	counts, err := s.Repo.GetDashboardCounts(ctx, repository.GetDashboardCountsParams{
+		UserID:    userID,
+		UpdatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+		Column3:   httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		return domain.DashboardModel{}, fmt.Errorf("get dashboard counts: %w", err)
+	}
+
+	completed, err := s.Repo.ListRecentlyCompletedTickets(ctx, repository.ListRecentlyCompletedTicketsParams{
+		UserID:    userID,
+		UpdatedAt: pgtype.Timestamptz{Time: since, Valid: true},
+		Limit:     recentlyCompletedLimit,
+		Column4:   httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		return domain.DashboardModel{}, fmt.Errorf("list recently completed tickets: %w", err)
+	}
+
+	activity, err := s.Repo.ListLatestTicketActivity(ctx, repository.ListLatestTicketActivityParams{
+		UserID:  userID,
+		Limit:   latestActivityLimit,
+		Column3: httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		return domain.DashboardModel{}, fmt.Errorf("list latest ticket activity: %w", err)
+	}
+
+	recentlyCompleted := make([]domain.DashboardTicketModel, 0, len(completed))
+	for _, t := range completed {
+		recentlyCompleted = append(recentlyCompleted, domain.DashboardTicketModel{
+			ID:         t.ID,
+			ProjectID:  t.ProjectID,
+			Key:        t.Key,
+			Title:      t.Title,
+			ColumnName: t.ColumnName,
+			UpdatedAt:  t.UpdatedAt.Time,
+		})
+	}
+
+	latestActivity := make([]domain.DashboardTicketModel, 0, len(activity))
+	for _, t := range activity {
+		latestActivity = append(latestActivity, domain.DashboardTicketModel{
+			ID:         t.ID,
+			ProjectID:  t.ProjectID,
+			Key:        t.Key,
+			Title:      t.Title,
+			ColumnName: t.ColumnName,
+			UpdatedAt:  t.UpdatedAt.Time,
+		})
+	}
+
+	return domain.DashboardModel{
+		ActiveProjects:    counts.ActiveProjects,
+		OpenTickets:       counts.OpenTickets,
+		OverdueTickets:    counts.OverdueTickets,
+		CompletedTickets:  counts.CompletedTickets,
+		CompletedSince:    since,
+		RecentlyCompleted: recentlyCompleted,
+		LatestActivity:    latestActivity,
+	}, nil
+}
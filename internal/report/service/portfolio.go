@@ -0,0 +1,97 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/report/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultPortfolioPeriodDays = 7
+	maxPortfolioPeriodDays     = 90
+)
+
+var ErrInvalidPeriod = domain.Invalid(fmt.Sprintf("periodDays must be between 1 and %d", maxPortfolioPeriodDays)).WithCode("invalid_period")
+
+// GetPortfolio lists the caller's active projects with their active sprint
+// progress and whether completions rose or fell against the previous period
+func (s *Service) GetPortfolio(ctx context.Context, userID pgtype.UUID, periodDays int) (domain.PortfolioModel, error) {
+	if periodDays == 0 {
+		periodDays = defaultPortfolioPeriodDays
+	}
+	if periodDays < 1 || periodDays > maxPortfolioPeriodDays {
+		return domain.PortfolioModel{}, ErrInvalidPeriod
+	}
+
+	period := time.Duration(periodDays) * 24 * time.Hour
+	start := time.Now().Add(-period)
+	previous := start.Add(-period)
+
+	rows, err := s.Repo.ListPortfolioProjects(ctx, repository.ListPortfolioProjectsParams{
+		UserID:  userID,
+		Column2: pgtype.Timestamptz{Time: start, Valid: true},
+		Column3: pgtype.Timestamptz{Time: previous, Valid: true},
+		Column4: httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		return domain.PortfolioModel{}, fmt.Errorf("list portfolio projects: %w", err)
+	}
+
+	projects := make([]domain.PortfolioProjectModel, 0, len(rows))
+	for _, row := range rows {
+		project := domain.PortfolioProjectModel{
+			ID:                  row.ID,
+			OrgID:               row.OrgID,
+			Key:                 row.Key,
+			Name:                row.Name,
+			OpenTickets:         row.OpenTickets,
+			DoneTickets:         row.DoneTickets,
+			OverdueTickets:      row.OverdueTickets,
+			CompletedThisPeriod: row.CompletedThisPeriod,
+			CompletedLastPeriod: row.CompletedLastPeriod,
+			Trend:               trend(row.CompletedThisPeriod, row.CompletedLastPeriod),
+		}
+		if row.MilestoneID.Valid {
+			project.Milestone = &domain.PortfolioMilestoneModel{
+				SprintID:     row.MilestoneID,
+				Name:         row.MilestoneName.String,
+				DueAt:        transformer.TimePtr(row.MilestoneDueAt),
+				TotalTickets: row.MilestoneTotalTickets,
+				DoneTickets:  row.MilestoneDoneTickets,
+				Progress:     percent(row.MilestoneDoneTickets, row.MilestoneTotalTickets),
+			}
+		}
+		projects = append(projects, project)
+	}
+
+	return domain.PortfolioModel{
+		PeriodDays:             periodDays,
+		PeriodStartsAt:         start,
+		PreviousPeriodStartsAt: previous,
+		Projects:               projects,
+	}, nil
+}
+
+func trend(current, previous int64) string {
+	switch {
+	case current > previous:
+		return "up"
+	case current < previous:
+		return "down"
+	default:
+		return "flat"
+	}
+}
+
+func percent(part, total int64) int {
+	if total == 0 {
+		return 0
+	}
+	return int(part * 100 / total)
+}
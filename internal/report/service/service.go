@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/report/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+type Deps struct {
+	Repo    *repository.Queries
+	Project domain.ProjectReader
+}
+
+type Service struct {
+	Deps
+}
+
+var _ domain.ReportReader = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{d}
+}
@@ -0,0 +1,134 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/report/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	snapshotDateLayout = "2006-01-02"
+	// defaultSnapshotDays is the range returned when from is left out, and
+	// maxSnapshotDays the longest range a request may ask for
+	defaultSnapshotDays = 30
+	maxSnapshotDays     = 366
+)
+
+var (
+	ErrInvalidDateRange = domain.Invalid("from and to must be dates such as 2025-08-01, from no later than to and at most 366 days apart").WithCode("invalid_date_range")
+)
+
+// GetBoardSnapshots returns the daily column counts of the project between
+// from and to, both inclusive, limited to one board when boardID is valid.
+// to defaults to today and from to 30 days before to.
+func (s *Service) GetBoardSnapshots(ctx context.Context, projectID, boardID pgtype.UUID, from, to string) (domain.BoardSnapshotsModel, error) {
+	start, end, err := parseDateRange(from, to)
+	if err != nil {
+		return domain.BoardSnapshotsModel{}, err
+	}
+
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.BoardSnapshotsModel{}, err
+	}
+	if err := s.checkBoard(ctx, projectID, boardID); err != nil {
+		return domain.BoardSnapshotsModel{}, err
+	}
+
+	rows, err := s.Repo.ListBoardSnapshots(ctx, repository.ListBoardSnapshotsParams{
+		ProjectID: projectID,
+		Column2:   boardID,
+		Column3:   pgtype.Date{Time: start, Valid: true},
+		Column4:   pgtype.Date{Time: end, Valid: true},
+	})
+	if err != nil {
+		return domain.BoardSnapshotsModel{}, fmt.Errorf("list board snapshots: %w", err)
+	}
+
+	result := domain.BoardSnapshotsModel{
+		ProjectID: projectID,
+		BoardID:   boardID,
+		From:      start.Format(snapshotDateLayout),
+		To:        end.Format(snapshotDateLayout),
+		Days:      []domain.BoardSnapshotDayModel{},
+	}
+	for _, row := range rows {
+		date := row.SnapshotDate.Time.Format(snapshotDateLayout)
+		// rows come ordered by day
+		if n := len(result.Days); n == 0 || result.Days[n-1].Date != date {
+			result.Days = append(result.Days, domain.BoardSnapshotDayModel{Date: date})
+		}
+		day := &result.Days[len(result.Days)-1]
+		day.Columns = append(day.Columns, domain.ColumnEffortModel{
+			BoardColumnID: row.BoardColumnID,
+			BoardID:       row.BoardID,
+			Name:          row.Name,
+			Category:      row.Category,
+			TicketCount:   int64(row.TicketCount),
+			TotalEstimate: row.TotalEstimate,
+		})
+	}
+
+	return result, nil
+}
+
+// TakeBoardSnapshots records the current column counts of every live board
+// as the snapshot of the UTC day at, returning how many columns it wrote
+func (s *Service) TakeBoardSnapshots(ctx context.Context, at time.Time) (int64, error) {
+	n, err := s.Repo.TakeBoardSnapshots(ctx, pgtype.Date{Time: at.UTC(), Valid: true})
+	if err != nil {
+		return 0, fmt.Errorf("take board snapshots: %w", err)
+	}
+	return n, nil
+}
+
+// StartSnapshotter takes a snapshot right away and then on every tick until
+// ctx ends. A day's snapshot is overwritten by later runs that day, so an
+// interval shorter than a day keeps it current and a restart does not leave
+// the day out.
+func (s *Service) StartSnapshotter(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.TakeBoardSnapshots(ctx, time.Now()); err != nil {
+			slog.Warn("[ReportModule]: board snapshot failed", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// parseDateRange reads the from and to query values as UTC days
+func parseDateRange(from, to string) (time.Time, time.Time, error) {
+	end := time.Now().UTC().Truncate(24 * time.Hour)
+	if to != "" {
+		t, err := time.Parse(snapshotDateLayout, to)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+		end = t
+	}
+
+	start := end.AddDate(0, 0, -(defaultSnapshotDays - 1))
+	if from != "" {
+		t, err := time.Parse(snapshotDateLayout, from)
+		if err != nil {
+			return time.Time{}, time.Time{}, ErrInvalidDateRange
+		}
+		start = t
+	}
+
+	if start.After(end) || end.Sub(start) >= maxSnapshotDays*24*time.Hour {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return start, end, nil
+}
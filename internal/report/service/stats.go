@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/report/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/syncx"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrBoardNotFound     = domain.NotFound("board not found")
+	ErrBoardNotInProject = domain.Invalid("board does not belong to this project").WithCode("board_not_in_project")
+)
+
+// GetProjectStats aggregates the project's live tickets, limited to one board
+// when boardID is valid. Every figure is summed in SQL; the queries run
+// concurrently.
+func (s *Service) GetProjectStats(ctx context.Context, projectID, boardID pgtype.UUID) (domain.ProjectStatsModel, error) {
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.ProjectStatsModel{}, err
+	}
+
+	if err := s.checkBoard(ctx, projectID, boardID); err != nil {
+		return domain.ProjectStatsModel{}, err
+	}
+
+	var (
+		priorities []repository.ListPriorityStatsRow
+		totals     repository.GetEffortTotalsRow
+		columns    []repository.ListColumnEffortRow
+		assignees  []repository.ListAssigneeEffortRow
+	)
+	err := syncx.Run(ctx,
+		func(ctx context.Context) (err error) {
+			priorities, err = s.Repo.ListPriorityStats(ctx, repository.ListPriorityStatsParams{
+				ProjectID: projectID,
+				Column2:   boardID,
+			})
+			if err != nil {
+				return fmt.Errorf("list priority stats: %w", err)
+			}
+			return nil
+		},
+		func(ctx context.Context) (err error) {
+			totals, err = s.Repo.GetEffortTotals(ctx, repository.GetEffortTotalsParams{
+				ProjectID: projectID,
+				Column2:   boardID,
+			})
+			if err != nil {
+				return fmt.Errorf("get effort totals: %w", err)
+			}
+			return nil
+		},
+		func(ctx context.Context) (err error) {
+			columns, err = s.Repo.ListColumnEffort(ctx, repository.ListColumnEffortParams{
+				ProjectID: projectID,
+				Column2:   boardID,
+			})
+			if err != nil {
+				return fmt.Errorf("list column effort: %w", err)
+			}
+			return nil
+		},
+		func(ctx context.Context) (err error) {
+			assignees, err = s.Repo.ListAssigneeEffort(ctx, repository.ListAssigneeEffortParams{
+				ProjectID: projectID,
+				Column2:   boardID,
+			})
+			if err != nil {
+				return fmt.Errorf("list assignee effort: %w", err)
+			}
+			return nil
+		},
+	)
+	if err != nil {
+		return domain.ProjectStatsModel{}, err
+	}
+
+	result := domain.ProjectStatsModel{
+		ProjectID:  projectID,
+		BoardID:    boardID,
+		Priorities: make([]domain.PriorityStatModel, len(priorities)),
+		Effort: domain.EffortStatModel{
+			TotalEstimate:      totals.TotalEstimate,
+			CompletedEstimate:  totals.CompletedEstimate,
+			RemainingEstimate:  totals.RemainingEstimate,
+			UnestimatedTickets: totals.UnestimatedTickets,
+		},
+		Columns:   make([]domain.ColumnEffortModel, len(columns)),
+		Assignees: make([]domain.AssigneeEffortModel, len(assignees)),
+	}
+	for i, p := range priorities {
+		result.Priorities[i] = domain.PriorityStatModel{
+			Key:         p.Key,
+			Name:        p.Name,
+			Color:       p.Color,
+			Position:    p.Position,
+			OpenTickets: p.OpenTickets,
+			DoneTickets: p.DoneTickets,
+		}
+	}
+	for i, c := range columns {
+		result.Columns[i] = domain.ColumnEffortModel{
+			BoardColumnID: c.ID,
+			BoardID:       c.BoardID,
+			Name:          c.Name,
+			Category:      c.Category,
+			TicketCount:   c.TicketCount,
+			TotalEstimate: c.TotalEstimate,
+		}
+	}
+	for i, a := range assignees {
+		result.Assignees[i] = domain.AssigneeEffortModel{
+			AssigneeID:        a.AssigneeID,
+			AssigneeName:      a.AssigneeName,
+			TicketCount:       a.TicketCount,
+			TotalEstimate:     a.TotalEstimate,
+			RemainingEstimate: a.RemainingEstimate,
+		}
+	}
+
+	return result, nil
+}
+
+// checkBoard makes sure a board filter, when given, names a board of the
+// project
+func (s *Service) checkBoard(ctx context.Context, projectID, boardID pgtype.UUID) error {
+	if !boardID.Valid {
+		return nil
+	}
+	owner, err := s.Repo.GetBoardProjectID(ctx, boardID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrBoardNotFound
+		}
+		return fmt.Errorf("get board project: %w", err)
+	}
+	if owner != projectID {
+		return ErrBoardNotInProject
+	}
+	return nil
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/report/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrInvalidWeek = domain.Invalid("week must be an ISO week such as 2025-W32").WithCode("invalid_week")
+)
+
+func (s *Service) GetWeeklyReport(ctx context.Context, projectID pgtype.UUID, week string) (domain.WeeklyReportModel, error) {
+	if week == "" {
+		year, w := time.Now().UTC().ISOWeek()
+		week = fmt.Sprintf("%d-W%02d", year, w)
+	}
+
+	start, err := parseISOWeek(week)
+	if err != nil {
+		return domain.WeeklyReportModel{}, ErrInvalidWeek
+	}
+	end := start.AddDate(0, 0, 7)
+
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.WeeklyReportModel{}, err
+	}
+
+	tickets, err := s.Repo.ListWeeklyProjectTickets(ctx, repository.ListWeeklyProjectTicketsParams{
+		ProjectID: projectID,
+		Column2:   pgtype.Timestamptz{Time: start, Valid: true},
+		Column3:   pgtype.Timestamptz{Time: end, Valid: true},
+	})
+	if err != nil {
+		return domain.WeeklyReportModel{}, fmt.Errorf("list weekly project tickets: %w", err)
+	}
+
+	sprints, err := s.Repo.ListWeeklySprintChanges(ctx, repository.ListWeeklySprintChangesParams{
+		ProjectID: projectID,
+		Column2:   pgtype.Timestamptz{Time: start, Valid: true},
+		Column3:   pgtype.Timestamptz{Time: end, Valid: true},
+	})
+	if err != nil {
+		return domain.WeeklyReportModel{}, fmt.Errorf("list weekly sprint changes: %w", err)
+	}
+
+	result := domain.WeeklyReportModel{
+		ProjectID:     projectID,
+		Week:          week,
+		StartsAt:      start,
+		EndsAt:        end,
+		Completed:     []domain.WeeklyReportTicketModel{},
+		Created:       []domain.WeeklyReportTicketModel{},
+		CarriedOver:   []domain.WeeklyReportTicketModel{},
+		StatusChanges: []domain.WeeklyReportStatusModel{},
+	}
+
+	for _, t := range tickets {
+		ticket := domain.WeeklyReportTicketModel{
+			ID:        t.ID,
+			Key:       t.Key,
+			Title:     t.Title,
+			CreatedAt: t.CreatedAt.Time,
+			UpdatedAt: t.UpdatedAt.Time,
+		}
+		if t.CompletedInWeek {
+			result.Completed = append(result.Completed, ticket)
+		}
+		if t.CreatedInWeek {
+			result.Created = append(result.Created, ticket)
+		}
+		if t.CarriedOver {
+			result.CarriedOver = append(result.CarriedOver, ticket)
+		}
+	}
+
+	for _, sp := range sprints {
+		if inRange(sp.StartedAt, start, end) {
+			result.StatusChanges = append(result.StatusChanges, domain.WeeklyReportStatusModel{
+				Type:       "sprint_started",
+				SprintID:   sp.ID,
+				SprintName: sp.Name,
+				At:         sp.StartedAt.Time,
+			})
+		}
+		if inRange(sp.CompletedAt, start, end) {
+			result.StatusChanges = append(result.StatusChanges, domain.WeeklyReportStatusModel{
+				Type:       "sprint_completed",
+				SprintID:   sp.ID,
+				SprintName: sp.Name,
+				At:         sp.CompletedAt.Time,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// parseISOWeek returns the Monday 00:00 UTC that starts the given "YYYY-Www" week
+func parseISOWeek(week string) (time.Time, error) {
+	yearPart, weekPart, ok := strings.Cut(week, "-W")
+	if !ok {
+		return time.Time{}, fmt.Errorf("missing week separator")
+	}
+
+	year, err := strconv.Atoi(yearPart)
+	if err != nil || len(yearPart) != 4 {
+		return time.Time{}, fmt.Errorf("invalid year %q", yearPart)
+	}
+	w, err := strconv.Atoi(weekPart)
+	if err != nil || len(weekPart) != 2 || w < 1 || w > 53 {
+		return time.Time{}, fmt.Errorf("invalid week %q", weekPart)
+	}
+
+	// January 4th always falls in the first ISO week of its year
+	jan4 := time.Date(year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7
+	start := jan4.AddDate(0, 0, -offset+(w-1)*7)
+
+	if y, got := start.ISOWeek(); y != year || got != w {
+		return time.Time{}, fmt.Errorf("week %d does not exist in %d", w, year)
+	}
+
+	return start, nil
+}
+
+func inRange(ts pgtype.Timestamptz, start, end time.Time) bool {
+	return ts.Valid && !ts.Time.Before(start) && ts.Time.Before(end)
+}
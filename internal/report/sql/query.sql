@@ -0,0 +1,291 @@
+-- name: GetDashboardCounts :one
+-- Aggregates the home screen counters over every project the user is a member of, or every project of their orgs when $3 is NULL
+-- A ticket counts as completed while it sits in a board column categorised as done
+WITH member_projects AS (
+  SELECT
+    p.id, p.status
+  FROM
+    projects p
+    JOIN org_members om ON om.org_id = p.org_id
+  WHERE
+    om.user_id = $1
+    AND ($3::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $3::uuid
+    ))
+    AND p.deleted_at IS NULL
+), member_tickets AS (
+  SELECT
+    t.id, t.due_date, t.updated_at,
+    COALESCE(bc.category = 'done', false) AS is_done
+  FROM
+    active_tickets t
+    JOIN member_projects mp ON mp.id = t.project_id
+    LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+)
+SELECT
+  (SELECT COUNT(*) FROM member_projects WHERE status = 'active')::bigint AS active_projects,
+  (SELECT COUNT(*) FROM member_tickets WHERE NOT is_done)::bigint AS open_tickets,
+  (SELECT COUNT(*) FROM member_tickets WHERE NOT is_done AND due_date < CURRENT_DATE)::bigint AS overdue_tickets,
+  (SELECT COUNT(*) FROM member_tickets WHERE is_done AND updated_at >= $2)::bigint AS completed_tickets;
+
+-- name: ListRecentlyCompletedTickets :many
+SELECT
+  t.id, t.project_id, t.key, t.title, bc.name AS column_name, t.updated_at
+FROM
+  active_tickets t
+  JOIN projects p ON p.id = t.project_id
+  JOIN org_members om ON om.org_id = p.org_id
+  JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE
+  om.user_id = $1
+  AND ($4::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $4::uuid
+  ))
+  AND bc.category = 'done'
+  AND t.updated_at >= $2
+ORDER BY
+  t.updated_at DESC
+LIMIT $3;
+
+-- name: ListLatestTicketActivity :many
+SELECT
+  t.id, t.project_id, t.key, t.title, COALESCE(bc.name, '')::text AS column_name, t.updated_at
+FROM
+  active_tickets t
+  JOIN projects p ON p.id = t.project_id
+  JOIN org_members om ON om.org_id = p.org_id
+  LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE
+  om.user_id = $1
+  AND ($3::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $3::uuid
+  ))
+ORDER BY
+  t.updated_at DESC
+LIMIT $2;
+
+-- name: ListWeeklyProjectTickets :many
+-- Returns every ticket of a project that was created, completed or still open during [$2, $3)
+-- Completion is approximated by the ticket's last update while it sits in a done column
+WITH project_tickets AS (
+  SELECT
+    t.id, t.key, t.title, t.created_at, t.updated_at,
+    COALESCE(bc.category = 'done', false) AS is_done
+  FROM
+    active_tickets t
+    LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+  WHERE
+    t.project_id = $1
+    AND t.created_at < $3::timestamptz
+)
+SELECT
+  id, key, title, created_at, updated_at,
+  (created_at >= $2::timestamptz)::boolean AS created_in_week,
+  (is_done AND updated_at >= $2::timestamptz AND updated_at < $3::timestamptz)::boolean AS completed_in_week,
+  (created_at < $2::timestamptz AND (NOT is_done OR updated_at >= $2::timestamptz))::boolean AS carried_over
+FROM
+  project_tickets
+WHERE
+  created_at >= $2::timestamptz
+  OR NOT is_done
+  OR updated_at >= $2::timestamptz
+ORDER BY
+  updated_at DESC;
+
+-- name: ListWeeklySprintChanges :many
+SELECT
+  id, name, started_at, completed_at
+FROM
+  sprints
+WHERE
+  project_id = $1
+  AND deleted_at IS NULL
+  AND (
+    (started_at >= $2::timestamptz AND started_at < $3::timestamptz)
+    OR (completed_at >= $2::timestamptz AND completed_at < $3::timestamptz)
+  )
+ORDER BY
+  COALESCE(completed_at, started_at) ASC;
+
+-- name: GetBoardProjectID :one
+SELECT
+  s.project_id
+FROM
+  boards b
+  JOIN sprints s ON s.id = b.sprint_id
+WHERE
+  b.id = $1
+  AND b.deleted_at IS NULL;
+
+-- name: ListPriorityStats :many
+-- Counts live tickets per level of the project's priority scheme, only those on board $2 when given
+-- Unused levels are still listed so charts keep the full scheme
+SELECT
+  pp.key, pp.name, pp.color, pp.position,
+  COUNT(t.id) FILTER (WHERE bc.category IS DISTINCT FROM 'done')::bigint AS open_tickets,
+  COUNT(t.id) FILTER (WHERE bc.category = 'done')::bigint AS done_tickets
+FROM
+  project_priorities pp
+  LEFT JOIN active_tickets t ON t.project_id = pp.project_id
+    AND t.priority = pp.key
+    AND ($2::uuid IS NULL OR t.board_id = $2::uuid)
+  LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE
+  pp.project_id = $1
+GROUP BY
+  pp.id
+ORDER BY
+  pp.position, pp.key;
+
+-- name: GetEffortTotals :one
+-- Burndown figures from story points: remaining is every estimate outside a done column
+SELECT
+  COALESCE(SUM(t.story_points), 0)::bigint AS total_estimate,
+  COALESCE(SUM(t.story_points) FILTER (WHERE bc.category = 'done'), 0)::bigint AS completed_estimate,
+  COALESCE(SUM(t.story_points) FILTER (WHERE bc.category IS DISTINCT FROM 'done'), 0)::bigint AS remaining_estimate,
+  COUNT(*) FILTER (WHERE t.story_points IS NULL)::bigint AS unestimated_tickets
+FROM
+  active_tickets t
+  LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE
+  t.project_id = $1
+  AND ($2::uuid IS NULL OR t.board_id = $2::uuid);
+
+-- name: ListColumnEffort :many
+-- Sums story points per live board column of the project, only board $2 when given
+SELECT
+  bc.id, bc.board_id, bc.name, bc.category::text AS category,
+  COUNT(t.id)::bigint AS ticket_count,
+  COALESCE(SUM(t.story_points), 0)::bigint AS total_estimate
+FROM
+  active_board_columns bc
+  JOIN boards b ON b.id = bc.board_id
+  LEFT JOIN active_tickets t ON t.board_column_id = bc.id
+WHERE
+  bc.project_id = $1
+  AND ($2::uuid IS NULL OR bc.board_id = $2::uuid)
+GROUP BY
+  bc.id, bc.board_id, bc.name, bc.category, bc.position, b.id
+ORDER BY
+  b.position, bc.position;
+
+-- name: ListAssigneeEffort :many
+-- Sums story points per assignee; unassigned tickets are grouped under a NULL assignee
+SELECT
+  t.assignee_id,
+  COALESCE(u.display_name, '')::text AS assignee_name,
+  COUNT(*)::bigint AS ticket_count,
+  COALESCE(SUM(t.story_points), 0)::bigint AS total_estimate,
+  COALESCE(SUM(t.story_points) FILTER (WHERE bc.category IS DISTINCT FROM 'done'), 0)::bigint AS remaining_estimate
+FROM
+  active_tickets t
+  LEFT JOIN users u ON u.id = t.assignee_id
+  LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE
+  t.project_id = $1
+  AND ($2::uuid IS NULL OR t.board_id = $2::uuid)
+GROUP BY
+  t.assignee_id, u.display_name
+ORDER BY
+  total_estimate DESC, t.assignee_id NULLS LAST;
+
+-- name: ListPortfolioProjects :many
+-- One row per active project the user is a member of, or of their orgs when $4 is NULL; the active sprint stands in as the milestone
+-- Completions are approximated by the last update of tickets in a done column, for [$2, now) and the period [$3, $2) before it
+SELECT
+  p.id, p.org_id, p.key, p.name,
+  COALESCE(ts.open_tickets, 0)::bigint AS open_tickets,
+  COALESCE(ts.done_tickets, 0)::bigint AS done_tickets,
+  COALESCE(ts.overdue_tickets, 0)::bigint AS overdue_tickets,
+  COALESCE(ts.completed_this_period, 0)::bigint AS completed_this_period,
+  COALESCE(ts.completed_last_period, 0)::bigint AS completed_last_period,
+  ms.id AS milestone_id,
+  ms.name AS milestone_name,
+  ms.planned_completed_at AS milestone_due_at,
+  COALESCE(ms.total_tickets, 0)::bigint AS milestone_total_tickets,
+  COALESCE(ms.done_tickets, 0)::bigint AS milestone_done_tickets
+FROM
+  projects p
+  JOIN org_members om ON om.org_id = p.org_id
+  LEFT JOIN LATERAL (
+    SELECT
+      COUNT(*) FILTER (WHERE NOT pt.is_done) AS open_tickets,
+      COUNT(*) FILTER (WHERE pt.is_done) AS done_tickets,
+      COUNT(*) FILTER (WHERE NOT pt.is_done AND pt.due_date < CURRENT_DATE) AS overdue_tickets,
+      COUNT(*) FILTER (WHERE pt.is_done AND pt.updated_at >= $2::timestamptz) AS completed_this_period,
+      COUNT(*) FILTER (WHERE pt.is_done AND pt.updated_at >= $3::timestamptz AND pt.updated_at < $2::timestamptz) AS completed_last_period
+    FROM (
+      SELECT
+        t.due_date, t.updated_at,
+        COALESCE(bc.category = 'done', false) AS is_done
+      FROM
+        active_tickets t
+        LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+      WHERE
+        t.project_id = p.id
+    ) pt
+  ) ts ON true
+  LEFT JOIN LATERAL (
+    SELECT
+      s.id, s.name, s.planned_completed_at,
+      COUNT(t.id) AS total_tickets,
+      COUNT(t.id) FILTER (WHERE bc.category = 'done') AS done_tickets
+    FROM
+      sprints s
+      LEFT JOIN active_tickets t ON t.sprint_id = s.id
+      LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+    WHERE
+      s.project_id = p.id
+      AND s.status = 'active'
+      AND s.deleted_at IS NULL
+    GROUP BY
+      s.id
+    ORDER BY
+      s.started_at DESC NULLS LAST
+    LIMIT 1
+  ) ms ON true
+WHERE
+  om.user_id = $1
+  AND ($4::uuid IS NULL OR EXISTS (
+    SELECT 1 FROM project_members pm WHERE pm.project_id = p.id AND pm.user_id = $4::uuid
+  ))
+  AND p.deleted_at IS NULL
+  AND p.status = 'active'
+ORDER BY
+  overdue_tickets DESC, p.name;
+
+-- name: TakeBoardSnapshots :execrows
+-- Counts the tickets of every live board column as of day $1, replacing what an earlier run that day wrote
+INSERT INTO board_snapshots (
+  snapshot_date, board_column_id, board_id, project_id, name, category, position, ticket_count, total_estimate
+)
+SELECT
+  $1::date, bc.id, bc.board_id, bc.project_id, bc.name, bc.category, bc.position,
+  COUNT(t.id)::int,
+  COALESCE(SUM(t.story_points), 0)::bigint
+FROM
+  active_board_columns bc
+  LEFT JOIN active_tickets t ON t.board_column_id = bc.id
+GROUP BY
+  bc.id, bc.board_id, bc.project_id, bc.name, bc.category, bc.position
+ON CONFLICT (snapshot_date, board_column_id) DO UPDATE
+SET
+  name = EXCLUDED.name,
+  category = EXCLUDED.category,
+  position = EXCLUDED.position,
+  ticket_count = EXCLUDED.ticket_count,
+  total_estimate = EXCLUDED.total_estimate,
+  created_at = NOW();
+
+-- name: ListBoardSnapshots :many
+-- Returns the project's snapshots from day $3 through day $4, only board $2 when given
+SELECT
+  snapshot_date, board_column_id, board_id, name, category::text AS category, ticket_count, total_estimate
+FROM
+  board_snapshots
+WHERE
+  project_id = $1
+  AND ($2::uuid IS NULL OR board_id = $2::uuid)
+  AND snapshot_date BETWEEN $3::date AND $4::date
+ORDER BY
+  snapshot_date, board_id, position;
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// Purge godoc
+//
+//	@Summary		Purge soft-deleted rows
+//	@ID				purge
+//	@Description	Permanently deletes the organisations, projects, sprints, boards, board columns, tickets and users soft-deleted more than PURGE_AFTER_DAYS ago, with the content of their tickets' attachments, without waiting for the periodic purge. Users still named as a ticket's reporter are kept. Also trims the change feed to CHANGES_RETENTION, keeping its newest entry. Either part is skipped while its setting is 0, refused with 422 purge_disabled when both are.
+//	@Tags			admin
+//	@Produce		json
+//	@Success		200	{object}	domain.PurgeReportModel
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/purge [post]
+func (h *Handler) Purge(w http.ResponseWriter, r *http.Request) {
+	report, err := h.svc.Purge(r.Context())
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	slog.Warn("[RetentionModule]: purge triggered",
+		"user", httpx.MustUserID(r.Context()),
+		"total", report.Total(),
+	)
+	httpx.OK(w, report)
+}
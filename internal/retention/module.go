@@ -0,0 +1,52 @@
+package retention
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/retention/handler"
+	"github.com/dimasbaguspm/fluxis/internal/retention/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Module struct {
+	h    *handler.Handler
+	svc  *service.Service
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, svc *service.Service, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		svc:  svc,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("POST /admin/purge", m.auth.RequireAuth(m.h.Purge, domain.ScopeAdmin))
+}
+
+// StartPurger periodically purges the rows soft-deleted longer ago than the
+// retention allows and trims the change feed; it does not run while purging
+// is disabled
+func (m *Module) StartPurger(ctx context.Context, interval time.Duration) {
+	if interval <= 0 || !m.svc.Enabled() {
+		return
+	}
+	slog.Info("[RetentionModule]: starting purger", "interval", interval.String(), "afterDays", m.svc.Config.AfterDays, "changesRetention", m.svc.Config.ChangesRetention.String())
+	m.svc.StartPurger(ctx, interval)
+}
+
+// StartLogMonitor periodically reports the activity log's size and warns
+// while it is past a soft cap
+func (m *Module) StartLogMonitor(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	slog.Info("[RetentionModule]: starting log monitor", "interval", interval.String(), "maxRows", m.svc.Config.LogMaxRows, "maxBytes", m.svc.Config.LogMaxBytes)
+	m.svc.StartLogMonitor(ctx, interval)
+}
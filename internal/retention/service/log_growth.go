@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
+)
+
+var (
+	activityLogRows = metrics.Default.NewGauge(metrics.Desc{
+		Name: "fluxis_activity_log_rows",
+		Help: "Rows in the activity log as of the last log growth check, estimated from the planner statistics.",
+	})
+	activityLogBytes = metrics.Default.NewGauge(metrics.Desc{
+		Name: "fluxis_activity_log_bytes",
+		Help: "Bytes the activity log takes with its indexes as of the last log growth check.",
+		Rules: []metrics.Rule{
+			{
+				Alert:   "FluxisActivityLogGrowingFast",
+				Expr:    `delta(fluxis_activity_log_bytes[1d]) > 1e9`,
+				Summary: "The activity log grew by more than 1GB in a day",
+			},
+		},
+	})
+	activityLogOverCap = metrics.Default.NewGauge(metrics.Desc{
+		Name:   "fluxis_activity_log_over_cap",
+		Help:   "1 while the activity log is past its soft cap, by cap: rows or bytes. A cap that is not set stays 0.",
+		Labels: []string{"cap"},
+		Rules: []metrics.Rule{
+			{
+				Alert:   "FluxisActivityLogOverCap",
+				Expr:    `max(fluxis_activity_log_over_cap) > 0`,
+				For:     "1h",
+				Summary: "The activity log is past its soft cap, consider narrowing the activity settings of busy projects",
+			},
+		},
+	})
+)
+
+// CheckLogGrowth reads the activity log's size into the metrics and warns
+// while it is past a soft cap. Crossing a cap also posts an alert when an
+// alert URL is configured; the next one goes out after the log got back
+// under its caps and crossed again.
+func (s *Service) CheckLogGrowth(ctx context.Context) (domain.LogGrowthModel, error) {
+	size, err := s.Repo.GetActivityLogSize(ctx)
+	if err != nil {
+		return domain.LogGrowthModel{}, fmt.Errorf("get activity log size: %w", err)
+	}
+
+	report := domain.LogGrowthModel{
+		CheckedAt: time.Now(),
+		Rows:      size.RowEstimate,
+		Bytes:     size.TotalBytes,
+	}
+	if s.Config != nil {
+		report.MaxRows = s.Config.LogMaxRows
+		report.MaxBytes = s.Config.LogMaxBytes
+	}
+
+	activityLogRows.With().Set(float64(report.Rows))
+	activityLogBytes.With().Set(float64(report.Bytes))
+	activityLogOverCap.With("rows").Set(flag(report.OverRows()))
+	activityLogOverCap.With("bytes").Set(flag(report.OverBytes()))
+
+	over := report.OverRows() || report.OverBytes()
+	if !over {
+		s.logOverCap.Store(false)
+		return report, nil
+	}
+
+	slog.Warn("[RetentionModule]: activity log is past its soft cap",
+		"rows", report.Rows,
+		"maxRows", report.MaxRows,
+		"bytes", report.Bytes,
+		"maxBytes", report.MaxBytes,
+	)
+	if !s.logOverCap.Swap(true) {
+		s.alertLogGrowth(ctx, report)
+	}
+	return report, nil
+}
+
+// alertLogGrowth posts the report to the configured alert URL; a failed post
+// is logged, the warning in the log and the metrics remain
+func (s *Service) alertLogGrowth(ctx context.Context, report domain.LogGrowthModel) {
+	if s.Hooks == nil || s.Config == nil || s.Config.LogAlertURL == "" {
+		return
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		slog.Warn("[RetentionModule]: failed to encode log growth alert", "error", err)
+		return
+	}
+	if err := s.Hooks.Send(ctx, s.Config.LogAlertURL, s.Config.LogAlertSecret, body); err != nil {
+		slog.Warn("[RetentionModule]: failed to send log growth alert", "error", err)
+	}
+}
+
+// StartLogMonitor checks the activity log's growth on every tick until ctx
+// ends
+func (s *Service) StartLogMonitor(ctx context.Context, interval time.Duration) {
+	check := func() {
+		if _, err := s.CheckLogGrowth(ctx); err != nil {
+			slog.Warn("[RetentionModule]: log growth check failed", "error", err)
+		}
+	}
+
+	// the metrics have a reading from the start rather than after a tick
+	check()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			check()
+		}
+	}
+}
+
+func flag(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,133 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/retention/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrPurgeDisabled = domain.Unprocessable("purging is disabled on this server, set PURGE_AFTER_DAYS or CHANGES_RETENTION to enable it").WithCode("purge_disabled")
+
+// Enabled reports whether soft-deleted rows or change feed entries expire at
+// all
+func (s *Service) Enabled() bool {
+	return s.Config != nil && (s.Config.AfterDays > 0 || s.Config.ChangesRetention > 0)
+}
+
+// Purge permanently deletes the rows soft-deleted more than AfterDays ago and
+// trims the change feed to ChangesRetention in one transaction. Rows go
+// parents first so their children go through the foreign keys. Attachment
+// contents are removed once the rows are gone; a content that fails to go is
+// logged and left behind rather than undoing the purge.
+func (s *Service) Purge(ctx context.Context) (domain.PurgeReportModel, error) {
+	if !s.Enabled() {
+		return domain.PurgeReportModel{}, ErrPurgeDisabled
+	}
+
+	now := time.Now()
+	report := domain.PurgeReportModel{PurgedAt: now}
+
+	tx, err := s.DB.Begin(ctx)
+	if err != nil {
+		return domain.PurgeReportModel{}, fmt.Errorf("begin purge: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	repo := s.Repo.WithTx(tx)
+	var keys []string
+	if s.Config.AfterDays > 0 {
+		report.DeletedUntil = now.AddDate(0, 0, -s.Config.AfterDays)
+		if keys, err = purgeDeleted(ctx, repo, &report); err != nil {
+			return domain.PurgeReportModel{}, err
+		}
+	}
+	if s.Config.ChangesRetention > 0 {
+		report.ChangesUntil = now.Add(-s.Config.ChangesRetention)
+		until := pgtype.Timestamptz{Time: report.ChangesUntil, Valid: true}
+		if report.Changes, err = repo.PurgeChanges(ctx, until); err != nil {
+			return domain.PurgeReportModel{}, fmt.Errorf("purge changes: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return domain.PurgeReportModel{}, fmt.Errorf("commit purge: %w", err)
+	}
+
+	for _, key := range keys {
+		if err := s.Store.Delete(context.WithoutCancel(ctx), key); err != nil {
+			slog.Warn("[RetentionService]: remove content of purged attachment", "key", key, "error", err)
+		}
+	}
+	return report, nil
+}
+
+// purgeDeleted deletes the rows soft-deleted before report.DeletedUntil,
+// counting them into report, and returns the storage keys of the attachments
+// that went with them
+func purgeDeleted(ctx context.Context, repo *repository.Queries, report *domain.PurgeReportModel) ([]string, error) {
+	until := pgtype.Timestamptz{Time: report.DeletedUntil, Valid: true}
+	keys, err := repo.PurgeAttachments(ctx, until)
+	if err != nil {
+		return nil, fmt.Errorf("purge attachments: %w", err)
+	}
+	report.Attachments = int64(len(keys))
+
+	steps := []struct {
+		name  string
+		purge func(*repository.Queries, context.Context, pgtype.Timestamptz) (int64, error)
+		count *int64
+	}{
+		{"orgs", (*repository.Queries).PurgeOrgs, &report.Orgs},
+		{"projects", (*repository.Queries).PurgeProjects, &report.Projects},
+		{"sprints", (*repository.Queries).PurgeSprints, &report.Sprints},
+		{"boards", (*repository.Queries).PurgeBoards, &report.Boards},
+		{"board columns", (*repository.Queries).PurgeBoardColumns, &report.BoardColumns},
+		{"tickets", (*repository.Queries).PurgeTickets, &report.Tickets},
+		{"users", (*repository.Queries).PurgeUsers, &report.Users},
+	}
+	for _, step := range steps {
+		if *step.count, err = step.purge(repo, ctx, until); err != nil {
+			return nil, fmt.Errorf("purge %s: %w", step.name, err)
+		}
+	}
+	return keys, nil
+}
+
+// StartPurger purges on every tick until ctx ends and logs what went
+func (s *Service) StartPurger(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := s.Purge(ctx)
+			if err != nil {
+				slog.Warn("[RetentionModule]: purge failed", "error", err)
+				continue
+			}
+			if report.Total() > 0 {
+				slog.Info("[RetentionModule]: purged expired rows",
+					"deletedUntil", report.DeletedUntil,
+					"changesUntil", report.ChangesUntil,
+					"orgs", report.Orgs,
+					"projects", report.Projects,
+					"sprints", report.Sprints,
+					"boards", report.Boards,
+					"boardColumns", report.BoardColumns,
+					"tickets", report.Tickets,
+					"users", report.Users,
+					"attachments", report.Attachments,
+					"changes", report.Changes,
+				)
+			}
+		}
+	}
+}
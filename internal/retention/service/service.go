@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/retention/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/blob"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+	"github.com/jackc/pgx/v5"
+)
+
+// TxBeginner opens the transaction a purge runs in, the pool in practice
+type TxBeginner interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+}
+
+type Deps struct {
+	Repo *repository.Queries
+	DB   TxBeginner
+	// Store holds the attachment contents removed along with their tickets
+	Store blob.Store
+	// Hooks posts the log growth alerts, nil sends none
+	Hooks  *webhook.Sender
+	Config *Config
+}
+
+type Config struct {
+	AfterDays int // days a soft-deleted row is kept, 0 keeps it for good
+	// ChangesRetention is how long the change feed keeps an entry, 0 keeps
+	// it for good
+	ChangesRetention time.Duration
+	// Soft caps on the activity log: crossing one warns, nothing is removed.
+	// 0 leaves a cap unset.
+	LogMaxRows  int64
+	LogMaxBytes int64
+	// LogAlertURL is posted a signed alert each time the log crosses a cap
+	LogAlertURL    string
+	LogAlertSecret string
+}
+
+type Service struct {
+	Deps
+	// logOverCap remembers the last check found the log past a cap, so an
+	// alert goes out once per crossing rather than on every check
+	logOverCap atomic.Bool
+}
+
+var _ domain.RetentionWriter = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{Deps: d}
+}
@@ -0,0 +1,60 @@
+-- name: PurgeAttachments :many
+-- Forgets the attachments of tickets about to be purged, directly or with their project or organisation,
+-- returning the storage keys whose content has to go too
+DELETE FROM attachments a
+USING tickets t
+  JOIN projects p ON p.id = t.project_id
+  JOIN orgs o ON o.id = p.org_id
+WHERE
+  a.ticket_id = t.id
+  AND (t.deleted_at < $1 OR p.deleted_at < $1 OR o.deleted_at < $1)
+RETURNING a.storage_key;
+
+-- name: PurgeOrgs :execrows
+-- Projects and everything under them go along through their foreign keys
+DELETE FROM orgs
+WHERE deleted_at < $1;
+
+-- name: PurgeProjects :execrows
+DELETE FROM projects
+WHERE deleted_at < $1;
+
+-- name: PurgeSprints :execrows
+-- Tickets of a purged sprint, board or column stay and lose their placement
+DELETE FROM sprints
+WHERE deleted_at < $1;
+
+-- name: PurgeBoards :execrows
+DELETE FROM boards
+WHERE deleted_at < $1;
+
+-- name: PurgeBoardColumns :execrows
+DELETE FROM board_columns
+WHERE deleted_at < $1;
+
+-- name: PurgeTickets :execrows
+DELETE FROM tickets
+WHERE deleted_at < $1;
+
+-- name: PurgeUsers :execrows
+-- Users still named as the reporter of a ticket are kept, the reference is restrictive
+DELETE FROM users u
+WHERE
+  u.deleted_at < $1
+  AND NOT EXISTS (SELECT 1 FROM tickets t WHERE t.reporter_id = u.id);
+
+-- name: PurgeChanges :execrows
+-- Trims the change feed. The newest entry is kept whatever its age, the feed's
+-- cursor is the highest seq and must not go back.
+DELETE FROM changes
+WHERE
+  created_at < $1
+  AND seq < (SELECT MAX(seq) FROM changes);
+
+-- name: GetActivityLogSize :one
+-- The row count is the planner's estimate, counting the rows would read the whole table
+SELECT
+  GREATEST(c.reltuples, 0)::bigint AS row_estimate,
+  pg_total_relation_size(c.oid) AS total_bytes
+FROM pg_class c
+WHERE c.oid = 'activity_log'::regclass;
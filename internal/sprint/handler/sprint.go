@@ -11,6 +11,7 @@ import (
 // CreateSprint godoc
 //
 //	@Summary		Create a sprint
+//	@ID				createSprint
 //	@Description	Creates a new sprint in a project
 //	@Tags			sprint
 //	@Accept			json
@@ -42,11 +43,13 @@ func (h *Handler) CreateSprint(w http.ResponseWriter, r *http.Request) {
 // ListSprints godoc
 //
 //	@Summary		List sprints with pagination
+//	@ID				listSprints
 //	@Description	Returns paginated sprints in a project with optional filtering
 //	@Tags			sprint
 //	@Produce		json
 //	@Param			query		query		domain.SprintsSearchModel	false	"Search parameters: name, pageNumber, pageSize"
 //	@Success		200			{object}	domain.SprintsPagedModel
+//	@Header			200			{string}	Link	"RFC 8288 links to the first, prev, next and last page"
 //	@Failure		400			{object}	httpx.ErrBlock
 //	@Failure		401			{object}	httpx.ErrBlock
 //	@Security		BearerAuth
@@ -68,12 +71,13 @@ func (h *Handler) ListSprints(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.OK(w, result)
+	httpx.OKPage(w, r, result, result.PageNumber, result.PageSize, result.TotalPages)
 }
 
 // GetSprint godoc
 //
 //	@Summary		Get a sprint
+//	@ID				getSprint
 //	@Description	Returns a single sprint by ID
 //	@Tags			sprint
 //	@Produce		json
@@ -105,6 +109,7 @@ func (h *Handler) GetSprint(w http.ResponseWriter, r *http.Request) {
 // UpdateSprint godoc
 //
 //	@Summary		Update a sprint
+//	@ID				updateSprint
 //	@Description	Updates sprint details
 //	@Tags			sprint
 //	@Accept			json
@@ -143,6 +148,7 @@ func (h *Handler) UpdateSprint(w http.ResponseWriter, r *http.Request) {
 // StartSprint godoc
 //
 //	@Summary		Start a sprint
+//	@ID				startSprint
 //	@Description	Transitions a sprint to active status
 //	@Tags			sprint
 //	@Produce		json
@@ -172,6 +178,7 @@ func (h *Handler) StartSprint(w http.ResponseWriter, r *http.Request) {
 // CompleteSprint godoc
 //
 //	@Summary		Complete a sprint
+//	@ID				completeSprint
 //	@Description	Transitions a sprint to completed status
 //	@Tags			sprint
 //	@Produce		json
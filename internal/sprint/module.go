@@ -13,26 +13,28 @@ import (
 )
 
 type Module struct {
-	h            *handler.Handler
-	sprintCache  *sprintcache.SprintCache
-	bus          pubsub.Bus
+	h           *handler.Handler
+	sprintCache *sprintcache.SprintCache
+	bus         pubsub.Bus
+	auth        *httpx.Authenticator
 }
 
-func NewModule(h *handler.Handler, c *sprintcache.SprintCache, bus pubsub.Bus) *Module {
+func NewModule(h *handler.Handler, c *sprintcache.SprintCache, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
 	return &Module{
 		h:           h,
 		sprintCache: c,
 		bus:         bus,
+		auth:        auth,
 	}
 }
 
 func (m *Module) Routes(mux *http.ServeMux) {
-	mux.HandleFunc("POST /sprints", httpx.RequireAuth(m.h.CreateSprint))
-	mux.HandleFunc("GET /sprints", httpx.RequireAuth(m.h.ListSprints))
-	mux.HandleFunc("GET /sprints/{sprintId}", httpx.RequireAuth(m.h.GetSprint))
-	mux.HandleFunc("PATCH /sprints/{sprintId}", httpx.RequireAuth(m.h.UpdateSprint))
-	mux.HandleFunc("POST /sprints/{sprintId}/start", httpx.RequireAuth(m.h.StartSprint))
-	mux.HandleFunc("POST /sprints/{sprintId}/completed", httpx.RequireAuth(m.h.CompleteSprint))
+	mux.HandleFunc("POST /sprints", m.auth.RequireAuth(m.h.CreateSprint, domain.ScopeSprintsWrite))
+	mux.HandleFunc("GET /sprints", m.auth.RequireAuth(m.h.ListSprints, domain.ScopeSprintsRead))
+	mux.HandleFunc("GET /sprints/{sprintId}", m.auth.RequireAuth(m.h.GetSprint, domain.ScopeSprintsRead))
+	mux.HandleFunc("PATCH /sprints/{sprintId}", m.auth.RequireAuth(m.h.UpdateSprint, domain.ScopeSprintsWrite))
+	mux.HandleFunc("POST /sprints/{sprintId}/start", m.auth.RequireAuth(m.h.StartSprint, domain.ScopeSprintsWrite))
+	mux.HandleFunc("POST /sprints/{sprintId}/completed", m.auth.RequireAuth(m.h.CompleteSprint, domain.ScopeSprintsWrite))
 }
 
 func (m *Module) StartSubscriber(ctx context.Context) {
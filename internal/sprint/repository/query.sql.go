@@ -13,7 +13,7 @@ import (
 
 const completeSprint = `-- name: CompleteSprint :one
 UPDATE sprints
-SET status = 'completed', completed_at = NOW(), updated_at = NOW()
+SET status = 'completed', completed_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at
 `
@@ -39,8 +39,8 @@ func (q *Queries) CompleteSprint(ctx context.Context, id pgtype.UUID) (Sprint, e
 }
 
 const createSprint = `-- name: CreateSprint :one
-INSERT INTO sprints (project_id, name, goal, status, planned_started_at, planned_completed_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO sprints (project_id, name, goal, status, planned_started_at, planned_completed_at, id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at
 `
 
@@ -51,6 +51,7 @@ type CreateSprintParams struct {
 	Status             SprintStatus       `db:"status" json:"status"`
 	PlannedStartedAt   pgtype.Timestamptz `db:"planned_started_at" json:"planned_started_at"`
 	PlannedCompletedAt pgtype.Timestamptz `db:"planned_completed_at" json:"planned_completed_at"`
+	ID                 pgtype.UUID        `db:"id" json:"id"`
 }
 
 func (q *Queries) CreateSprint(ctx context.Context, arg CreateSprintParams) (Sprint, error) {
@@ -61,6 +62,7 @@ func (q *Queries) CreateSprint(ctx context.Context, arg CreateSprintParams) (Spr
 		arg.Status,
 		arg.PlannedStartedAt,
 		arg.PlannedCompletedAt,
+		arg.ID,
 	)
 	var i Sprint
 	err := row.Scan(
@@ -195,6 +197,9 @@ WITH filtered_sprints AS (
     AND (array_length($1::uuid[], 1) IS NULL OR id = ANY($1::uuid[]))
     AND (array_length($2::uuid[], 1) IS NULL OR project_id = ANY($2::uuid[]))
     AND ($3::text = '' OR name ILIKE '%' || $3 || '%')
+    AND ($6::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM project_members pm WHERE pm.project_id = sprints.project_id AND pm.user_id = $6::uuid
+    ))
 )
 SELECT
   id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at, total_count
@@ -212,6 +217,7 @@ type ListSprintsPagedParams struct {
 	Column3 string        `db:"column_3" json:"column_3"`
 	Limit   int32         `db:"limit" json:"limit"`
 	Offset  int32         `db:"offset" json:"offset"`
+	Column6 pgtype.UUID   `db:"column_6" json:"column_6"`
 }
 
 type ListSprintsPagedRow struct {
@@ -237,6 +243,7 @@ func (q *Queries) ListSprintsPaged(ctx context.Context, arg ListSprintsPagedPara
 		arg.Column3,
 		arg.Limit,
 		arg.Offset,
+		arg.Column6,
 	)
 	if err != nil {
 		return nil, err
@@ -272,7 +279,7 @@ func (q *Queries) ListSprintsPaged(ctx context.Context, arg ListSprintsPagedPara
 
 const startSprint = `-- name: StartSprint :one
 UPDATE sprints
-SET status = 'active', started_at = NOW(), updated_at = NOW()
+SET status = 'active', started_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at
 `
@@ -299,7 +306,7 @@ func (q *Queries) StartSprint(ctx context.Context, id pgtype.UUID) (Sprint, erro
 
 const updateSprint = `-- name: UpdateSprint :one
 UPDATE sprints
-SET name = $2, goal = $3, status = $4, planned_started_at = $5, planned_completed_at = $6, updated_at = NOW()
+SET name = $2, goal = $3, status = $4, planned_started_at = $5, planned_completed_at = $6
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at
 `
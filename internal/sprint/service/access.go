@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/sprint/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// authorizeSprint checks the caller's role in the project of a live sprint
+// and returns the sprint. A sprint in a project the caller cannot see is
+// reported as not found.
+func (s *Service) authorizeSprint(ctx context.Context, id pgtype.UUID, role domain.ProjectRole) (repository.Sprint, error) {
+	sprint, err := s.Repo.GetSprint(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return repository.Sprint{}, ErrSprintNotFound
+		}
+		return repository.Sprint{}, fmt.Errorf("get sprint: %w", err)
+	}
+
+	err = s.Project.AuthorizeProject(ctx, sprint.ProjectID, role)
+	var derr *domain.Error
+	if errors.As(err, &derr) && derr.Kind == domain.KindNotFound {
+		return repository.Sprint{}, ErrSprintNotFound
+	}
+	if err != nil {
+		return repository.Sprint{}, err
+	}
+	return sprint, nil
+}
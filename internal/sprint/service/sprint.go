@@ -10,13 +10,16 @@ import (
 	"github.com/dimasbaguspm/fluxis/internal/sprint/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
-	ErrSprintNotFound = httpx.NotFound("sprint not found")
+	ErrSprintNotFound = domain.NotFound("sprint not found")
 )
 
 func toSprintModel(sprint repository.Sprint) domain.SprintModel {
@@ -57,11 +60,15 @@ func toSprintModel(sprint repository.Sprint) domain.SprintModel {
 		CompletedAt:        completedAt,
 		CreatedAt:          sprint.CreatedAt.Time,
 		UpdatedAt:          sprint.UpdatedAt.Time,
+		DeletedAt:          transformer.TimePtr(sprint.DeletedAt),
 	}
 }
 
 // CreateSprint creates a new sprint
 func (s *Service) CreateSprint(ctx context.Context, req domain.SprintCreateModel) (domain.SprintModel, error) {
+	if err := s.Project.AuthorizeProject(ctx, req.ProjectID, domain.ProjectRoleMember); err != nil {
+		return domain.SprintModel{}, err
+	}
 	project, err := s.Project.GetProjectById(ctx, req.ProjectID)
 	if err != nil {
 		return domain.SprintModel{}, fmt.Errorf("get project: %w", err)
@@ -90,6 +97,7 @@ func (s *Service) CreateSprint(ctx context.Context, req domain.SprintCreateModel
 	}
 
 	sprint, err := s.Repo.CreateSprint(ctx, repository.CreateSprintParams{
+		ID:                 idgen.New(),
 		ProjectID:          project.ID,
 		Name:               req.Name,
 		Goal:               goalText,
@@ -111,12 +119,9 @@ func (s *Service) CreateSprint(ctx context.Context, req domain.SprintCreateModel
 
 // GetSprint retrieves a single sprint by ID
 func (s *Service) GetSprint(ctx context.Context, id pgtype.UUID) (domain.SprintModel, error) {
-	sprint, err := s.Repo.GetSprint(ctx, id)
+	sprint, err := s.authorizeSprint(ctx, id, domain.ProjectRoleViewer)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.SprintModel{}, ErrSprintNotFound
-		}
-		return domain.SprintModel{}, fmt.Errorf("get sprint: %w", err)
+		return domain.SprintModel{}, err
 	}
 
 	return toSprintModel(sprint), nil
@@ -131,61 +136,43 @@ func (s *Service) ListSprintsPaged(ctx context.Context, q domain.SprintsSearchMo
 		Column2: q.ProjectID,
 		Column3: q.Name,
 		Limit:   int32(q.PageSize),
-		Offset:  int32((q.PageNumber - 1) * q.PageSize),
+		Offset:  pagination.Offset(q.PageNumber, q.PageSize),
+		Column6: httpx.MemberFilter(ctx),
 	})
 
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			emptyResult := domain.SprintsPagedModel{}
-			return emptyResult.Empty(q.PageNumber, q.PageSize), nil
-		}
 		return domain.SprintsPagedModel{}, fmt.Errorf("list sprints by project paged: %w", err)
 	}
 
-	var totalCount int64
-	data := make([]domain.SprintModel, 0, len(sprints))
-
-	for _, row := range sprints {
-		totalCount = row.TotalCount
-		data = append(data, toSprintModel(repository.Sprint{
-			ID:                 row.ID,
-			ProjectID:          row.ProjectID,
-			Name:               row.Name,
-			Goal:               row.Goal,
-			Status:             row.Status,
-			PlannedStartedAt:   row.PlannedStartedAt,
-			PlannedCompletedAt: row.PlannedCompletedAt,
-			StartedAt:          row.StartedAt,
-			CompletedAt:        row.CompletedAt,
-			CreatedAt:          row.CreatedAt,
-			UpdatedAt:          row.UpdatedAt,
-			DeletedAt:          row.DeletedAt,
-		}))
-	}
-
-	totalPages := 0
-	if totalCount > 0 {
-		totalPages = int((totalCount + int64(q.PageSize) - 1) / int64(q.PageSize))
-	}
-
-	return domain.SprintsPagedModel{
-		Items:      data,
-		TotalCount: int(totalCount),
-		TotalPages: totalPages,
-		PageNumber: q.PageNumber,
-		PageSize:   q.PageSize,
-	}, nil
+	page := pagination.FromRows(sprints,
+		func(row repository.ListSprintsPagedRow) int64 { return row.TotalCount },
+		func(row repository.ListSprintsPagedRow) domain.SprintModel {
+			return toSprintModel(repository.Sprint{
+				ID:                 row.ID,
+				ProjectID:          row.ProjectID,
+				Name:               row.Name,
+				Goal:               row.Goal,
+				Status:             row.Status,
+				PlannedStartedAt:   row.PlannedStartedAt,
+				PlannedCompletedAt: row.PlannedCompletedAt,
+				StartedAt:          row.StartedAt,
+				CompletedAt:        row.CompletedAt,
+				CreatedAt:          row.CreatedAt,
+				UpdatedAt:          row.UpdatedAt,
+				DeletedAt:          row.DeletedAt,
+			})
+		},
+		q.PageNumber, q.PageSize)
+
+	return domain.SprintsPagedModel(page), nil
 }
 
 // UpdateSprint updates sprint details
 func (s *Service) UpdateSprint(ctx context.Context, id pgtype.UUID, req domain.SprintUpdateModel) (domain.SprintModel, error) {
 	// Get current sprint to preserve existing values
-	current, err := s.Repo.GetSprint(ctx, id)
+	current, err := s.authorizeSprint(ctx, id, domain.ProjectRoleMember)
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.SprintModel{}, ErrSprintNotFound
-		}
-		return domain.SprintModel{}, fmt.Errorf("get sprint: %w", err)
+		return domain.SprintModel{}, err
 	}
 
 	// Use provided values or keep existing ones
@@ -243,6 +230,10 @@ func (s *Service) UpdateSprint(ctx context.Context, id pgtype.UUID, req domain.S
 
 // StartSprint transitions a sprint to active status
 func (s *Service) StartSprint(ctx context.Context, id pgtype.UUID) (domain.SprintModel, error) {
+	if _, err := s.authorizeSprint(ctx, id, domain.ProjectRoleMember); err != nil {
+		return domain.SprintModel{}, err
+	}
+
 	sprint, err := s.Repo.StartSprint(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -261,6 +252,10 @@ func (s *Service) StartSprint(ctx context.Context, id pgtype.UUID) (domain.Sprin
 
 // CompleteSprint transitions a sprint to completed status
 func (s *Service) CompleteSprint(ctx context.Context, id pgtype.UUID) (domain.SprintModel, error) {
+	if _, err := s.authorizeSprint(ctx, id, domain.ProjectRoleMember); err != nil {
+		return domain.SprintModel{}, err
+	}
+
 	sprint, err := s.Repo.CompleteSprint(ctx, id)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -1,6 +1,6 @@
 -- name: CreateSprint :one
-INSERT INTO sprints (project_id, name, goal, status, planned_started_at, planned_completed_at)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO sprints (project_id, name, goal, status, planned_started_at, planned_completed_at, id)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at;
 
 -- name: GetSprint :one
@@ -26,6 +26,9 @@ WITH filtered_sprints AS (
     AND (array_length($1::uuid[], 1) IS NULL OR id = ANY($1::uuid[]))
     AND (array_length($2::uuid[], 1) IS NULL OR project_id = ANY($2::uuid[]))
     AND ($3::text = '' OR name ILIKE '%' || $3 || '%')
+    AND ($6::uuid IS NULL OR EXISTS (
+      SELECT 1 FROM project_members pm WHERE pm.project_id = sprints.project_id AND pm.user_id = $6::uuid
+    ))
 )
 SELECT
   id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at, total_count
@@ -38,19 +41,19 @@ OFFSET $5;
 
 -- name: UpdateSprint :one
 UPDATE sprints
-SET name = $2, goal = $3, status = $4, planned_started_at = $5, planned_completed_at = $6, updated_at = NOW()
+SET name = $2, goal = $3, status = $4, planned_started_at = $5, planned_completed_at = $6
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at;
 
 -- name: StartSprint :one
 UPDATE sprints
-SET status = 'active', started_at = NOW(), updated_at = NOW()
+SET status = 'active', started_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at;
 
 -- name: CompleteSprint :one
 UPDATE sprints
-SET status = 'completed', completed_at = NOW(), updated_at = NOW()
+SET status = 'completed', completed_at = NOW()
 WHERE id = $1 AND deleted_at IS NULL
 RETURNING id, project_id, name, goal, status, planned_started_at, planned_completed_at, started_at, completed_at, created_at, updated_at, deleted_at;
 
@@ -0,0 +1,45 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// ExportTicket godoc
+//
+//	@Summary		Export a ticket as a document
+//	@ID				exportTicket
+//	@Description	Renders the ticket with its details, subtasks as a checklist and a summary of its history as a self-contained document for pasting into docs or emails. format is md (default) or txt
+//	@Tags			ticket
+//	@Produce		text/markdown,plain
+//	@Param			ticketId	path		string	true	"Ticket ID"
+//	@Param			format		query		string	false	"Document format"	Enums(md, txt)
+//	@Success		200			{string}	string
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/export [get]
+func (h *Handler) ExportTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	doc, err := h.svc.ExportTicket(r.Context(), id, r.URL.Query().Get("format"))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	contentType := "text/markdown; charset=utf-8"
+	if doc.Format == domain.TicketExportText {
+		contentType = "text/plain; charset=utf-8"
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Disposition", `inline; filename="`+doc.Key+"."+doc.Format+`"`)
+	w.Write([]byte(doc.Body))
+}
@@ -0,0 +1,36 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetProjectTicketGraph godoc
+//
+//	@Summary		Get a project's ticket graph
+//	@ID				getProjectTicketGraph
+//	@Description	Returns the project's tickets as nodes and their subtask and epic links as edges in one call, ready for rendering a dependency graph. Edges run from the parent or epic to the ticket under it; depth is the layer a node sits on, 0 for tickets under nothing
+//	@Tags			ticket
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.TicketGraphModel
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/graph [get]
+func (h *Handler) GetProjectTicketGraph(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	graph, err := h.svc.GetProjectTicketGraph(r.Context(), projectID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, graph)
+}
@@ -0,0 +1,96 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetTicketLock godoc
+//
+//	@Summary		Get ticket edit lock
+//	@ID				getTicketLock
+//	@Description	Reports whether someone is editing the ticket, so clients can show an indicator before opening the editor
+//	@Tags			ticket
+//	@Produce		json
+//	@Param			ticketId	path		string	true	"Ticket ID"
+//	@Success		200			{object}	domain.TicketLockModel
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		422			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/lock [get]
+func (h *Handler) GetTicketLock(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	lock, err := h.svc.GetTicketLock(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, lock)
+}
+
+// LockTicket godoc
+//
+//	@Summary		Claim ticket edit lock
+//	@ID				lockTicket
+//	@Description	Claims the advisory edit lock for the caller, or renews it when the caller already holds it. Repeat it well within ttlSeconds while editing. When someone else holds it the 423 error details carry their lock
+//	@Tags			ticket
+//	@Produce		json
+//	@Param			ticketId	path		string	true	"Ticket ID"
+//	@Success		200			{object}	domain.TicketLockModel
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		422			{object}	httpx.ErrBlock
+//	@Failure		423			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/lock [post]
+func (h *Handler) LockTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	lock, err := h.svc.LockTicket(r.Context(), id, httpx.MustUserID(r.Context()))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, lock)
+}
+
+// UnlockTicket godoc
+//
+//	@Summary		Release ticket edit lock
+//	@ID				unlockTicket
+//	@Description	Releases the caller's edit lock once they are done editing
+//	@Tags			ticket
+//	@Param			ticketId	path	string	true	"Ticket ID"
+//	@Success		204
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		422	{object}	httpx.ErrBlock
+//	@Failure		423	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/lock [delete]
+func (h *Handler) UnlockTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	if err := h.svc.UnlockTicket(r.Context(), id, httpx.MustUserID(r.Context())); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetTicketStaleSettings godoc
+//
+//	@Summary		Get stale ticket settings
+//	@ID				getTicketStaleSettings
+//	@Description	Returns after how many days without activity the project's tickets are flagged as stale. 0, the default, means they never are
+//	@Tags			ticket
+//	@Produce		json
+//	@Param			id	path		string	true	"Project ID"
+//	@Success		200	{object}	domain.TicketStaleSettingsModel
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/stale-settings [get]
+func (h *Handler) GetTicketStaleSettings(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	settings, err := h.svc.GetTicketStaleSettings(r.Context(), projectID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, settings)
+}
+
+// UpdateTicketStaleSettings godoc
+//
+//	@Summary		Update stale ticket settings
+//	@ID				updateTicketStaleSettings
+//	@Description	Sets after how many days without activity the project's tickets are flagged as stale, 0 turns it off. A periodic sweep flags them, logs ticket.ticket.stale to the activity log and nudges their assignees; list them with GET /tickets?stale=true. Done tickets are never flagged
+//	@Tags			ticket
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Project ID"
+//	@Param			body	body		domain.TicketStaleSettingsUpdateModel	true	"Stale settings"
+//	@Success		200		{object}	domain.TicketStaleSettingsModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		404		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/stale-settings [put]
+func (h *Handler) UpdateTicketStaleSettings(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.TicketStaleSettingsUpdateModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	settings, err := h.svc.UpdateTicketStaleSettings(r.Context(), projectID, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, settings)
+}
@@ -0,0 +1,42 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// SuggestAssignees godoc
+//
+//	@Summary		Suggest assignees for a ticket
+//	@ID				suggestAssignees
+//	@Description	Ranks the members of the project as assignees of one of its tickets, usually an unassigned one. Members with fewer unfinished tickets across the organisation and more finished tickets of the same type or epic rank higher; each item carries the counts behind its score so the UI can explain it
+//	@Tags			ticket
+//	@Produce		json
+//	@Param			id			path		string	true	"Project ID"
+//	@Param			ticketId	query		string	true	"Ticket ID"
+//	@Success		200			{object}	domain.TicketAssignmentSuggestionsModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/projects/{id}/suggestions/assignment [get]
+func (h *Handler) SuggestAssignees(w http.ResponseWriter, r *http.Request) {
+	projectID, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	ticketID, err := httpx.QueryUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	suggestions, err := h.svc.SuggestAssignees(r.Context(), projectID, ticketID)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, suggestions)
+}
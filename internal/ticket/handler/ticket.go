@@ -10,23 +10,31 @@ import (
 // ListTickets godoc
 //
 //	@Summary		List tickets with pagination
-//	@Description	Returns paginated tickets for a project, optionally filtered by sprint or board
+//	@ID				listTickets
+//	@Description	Returns paginated tickets for a project, optionally filtered by sprint or board. stale=true keeps only tickets flagged as stale and untouched since
 //	@Tags			ticket
 //	@Produce		json
-//	@Param			query	query	domain.TicketSearchModel	false	"Search parameters: projectId (required), sprintId (optional), boardId (optional), pageNumber, pageSize"
+//	@Param			query	query	domain.TicketSearchModel	false	"Search parameters: projectId (required), sprintId (optional), boardId (optional), boardColumnId, status (column name slug) and statusCategory (todo, in_progress, done) filter by board column (optional), stale (optional), includeDeleted (admin only), pageNumber, pageSize"
 //	@Success		200	{object}	domain.TicketsPagedModel
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last page"
 //	@Failure		400	{object}	httpx.ErrBlock
 //	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/tickets [get]
 func (h *Handler) ListTickets(w http.ResponseWriter, r *http.Request) {
 	req := domain.TicketSearchModel{
-		ID:         httpx.QueryUUIDs(r, "id"),
-		ProjectID:  httpx.QueryUUIDs(r, "projectId"),
-		SprintID:   httpx.QueryUUIDs(r, "sprintId"),
-		BoardID:    httpx.QueryUUIDs(r, "boardId"),
-		PageNumber: httpx.QueryNumber(r, "pageNumber"),
-		PageSize:   httpx.QueryNumber(r, "pageSize"),
+		ID:             httpx.QueryUUIDs(r, "id"),
+		ProjectID:      httpx.QueryUUIDs(r, "projectId"),
+		SprintID:       httpx.QueryUUIDs(r, "sprintId"),
+		BoardID:        httpx.QueryUUIDs(r, "boardId"),
+		BoardColumnID:  httpx.QueryUUIDs(r, "boardColumnId"),
+		Status:         httpx.QueryStrings(r, "status"),
+		StatusCategory: httpx.QueryStrings(r, "statusCategory"),
+		IncludeDeleted: httpx.QueryBoolean(r, "includeDeleted"),
+		Stale:          httpx.QueryBoolean(r, "stale"),
+		PageNumber:     httpx.QueryNumber(r, "pageNumber"),
+		PageSize:       httpx.QueryNumber(r, "pageSize"),
 	}
 
 	tickets, err := h.svc.ListTickets(r.Context(), req)
@@ -35,12 +43,13 @@ func (h *Handler) ListTickets(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	httpx.OK(w, tickets)
+	httpx.OKPage(w, r, tickets, tickets.PageNumber, tickets.PageSize, tickets.TotalPages)
 }
 
 // GetTicket godoc
 //
 //	@Summary		Get a ticket
+//	@ID				getTicket
 //	@Description	Returns a single ticket by ID
 //	@Tags			ticket
 //	@Produce		json
@@ -70,6 +79,7 @@ func (h *Handler) GetTicket(w http.ResponseWriter, r *http.Request) {
 // CreateTicket godoc
 //
 //	@Summary		Create a ticket
+//	@ID				createTicket
 //	@Description	Creates a new ticket in a project
 //	@Tags			ticket
 //	@Accept			json
@@ -80,6 +90,7 @@ func (h *Handler) GetTicket(w http.ResponseWriter, r *http.Request) {
 //	@Failure		400			{object}	httpx.ErrBlock
 //	@Failure		401			{object}	httpx.ErrBlock
 //	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		422			{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/tickets [post]
 func (h *Handler) CreateTicket(w http.ResponseWriter, r *http.Request) {
@@ -107,6 +118,7 @@ func (h *Handler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 // UpdateTicket godoc
 //
 //	@Summary		Update a ticket
+//	@ID				updateTicket
 //	@Description	Updates ticket details (title, description, priority, type, etc.)
 //	@Tags			ticket
 //	@Accept			json
@@ -117,6 +129,8 @@ func (h *Handler) CreateTicket(w http.ResponseWriter, r *http.Request) {
 //	@Failure		400			{object}	httpx.ErrBlock
 //	@Failure		401			{object}	httpx.ErrBlock
 //	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		422			{object}	httpx.ErrBlock
+//	@Failure		423			{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/tickets/{ticketId} [patch]
 func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
@@ -144,7 +158,8 @@ func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 // MoveTicketToBoard godoc
 //
 //	@Summary		Move ticket to board column
-//	@Description	Moves a ticket to a specific board and column
+//	@ID				moveTicketToBoard
+//	@Description	Moves a ticket to a specific board and column. A column at its wipLimit refuses the move with 422 (wip_limit_reached) when the server runs with TICKET_WIP_LIMIT_MODE=reject, otherwise the move goes through
 //	@Tags			ticket
 //	@Accept			json
 //	@Produce		json
@@ -154,6 +169,7 @@ func (h *Handler) UpdateTicket(w http.ResponseWriter, r *http.Request) {
 //	@Failure		400			{object}	httpx.ErrBlock
 //	@Failure		401			{object}	httpx.ErrBlock
 //	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		422			{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/tickets/{ticketId}/move-to-board [patch]
 func (h *Handler) MoveTicketToBoard(w http.ResponseWriter, r *http.Request) {
@@ -181,6 +197,7 @@ func (h *Handler) MoveTicketToBoard(w http.ResponseWriter, r *http.Request) {
 // MoveTicketToSprint godoc
 //
 //	@Summary		Move ticket to sprint
+//	@ID				moveTicketToSprint
 //	@Description	Moves a ticket to a specific sprint
 //	@Tags			ticket
 //	@Accept			json
@@ -215,10 +232,51 @@ func (h *Handler) MoveTicketToSprint(w http.ResponseWriter, r *http.Request) {
 	httpx.OK(w, ticket)
 }
 
+// MoveTicketToProject godoc
+//
+//	@Summary		Move ticket to another project
+//	@ID				moveTicketToProject
+//	@Description	Moves a ticket to another project, where it gets a new key. It lands in boardColumnId when given, else in the default column of boardId, else in the backlog; both must belong to the destination project. The caller must be a member of both projects. Its sprint, epic and parent links are dropped, so are the links of tickets pointing at it and an assignee outside the destination project. Pass priority when the destination lacks the ticket's level. Both projects log ticket.ticket.moved_to_project. A column at its wipLimit refuses the move with 422 (wip_limit_reached) when the server runs with TICKET_WIP_LIMIT_MODE=reject, otherwise the move goes through
+//	@Tags			ticket
+//	@Accept			json
+//	@Produce		json
+//	@Param			ticketId	path		string							true	"Ticket ID"
+//	@Param			body		body		domain.TicketProjectMoveModel	true	"Destination"
+//	@Success		200			{object}	domain.TicketModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		403			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		422			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/move [post]
+func (h *Handler) MoveTicketToProject(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.TicketProjectMoveModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	ticket, err := h.svc.MoveTicketToProject(r.Context(), id, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, ticket)
+}
+
 // MoveTicketToBoardColumn godoc
 //
 //	@Summary		Move ticket to board column
-//	@Description	Moves a ticket to a specific board column
+//	@ID				moveTicketToBoardColumn
+//	@Description	Moves a ticket to a specific board column. A column at its wipLimit refuses the move with 422 (wip_limit_reached) when the server runs with TICKET_WIP_LIMIT_MODE=reject, otherwise the move goes through
 //	@Tags			ticket
 //	@Accept			json
 //	@Produce		json
@@ -228,6 +286,7 @@ func (h *Handler) MoveTicketToSprint(w http.ResponseWriter, r *http.Request) {
 //	@Failure		400			{object}	httpx.ErrBlock
 //	@Failure		401			{object}	httpx.ErrBlock
 //	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		422			{object}	httpx.ErrBlock
 //	@Security		BearerAuth
 //	@Router			/tickets/{ticketId}/move-board-column [patch]
 func (h *Handler) MoveTicketToBoardColumn(w http.ResponseWriter, r *http.Request) {
@@ -252,9 +311,49 @@ func (h *Handler) MoveTicketToBoardColumn(w http.ResponseWriter, r *http.Request
 	httpx.OK(w, ticket)
 }
 
+// MoveTicketPosition godoc
+//
+//	@Summary		Move ticket within its column
+//	@ID				moveTicketPosition
+//	@Description	Places the ticket right after afterId in its current board column, or at the top when afterId is omitted. Only the moved ticket is rewritten
+//	@Tags			ticket
+//	@Accept			json
+//	@Produce		json
+//	@Param			ticketId	path		string						true	"Ticket ID"
+//	@Param			body		body		domain.TicketPositionModel	true	"Position payload"
+//	@Success		200			{object}	domain.TicketModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		409			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/position [patch]
+func (h *Handler) MoveTicketPosition(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	var req domain.TicketPositionModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	ticket, err := h.svc.MoveTicketPosition(r.Context(), id, req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, ticket)
+}
+
 // DeleteTicket godoc
 //
 //	@Summary		Delete a ticket
+//	@ID				deleteTicket
 //	@Description	Soft-deletes a ticket by ID
 //	@Tags			ticket
 //	@Param			ticketId	path	string	true	"Ticket ID"
@@ -278,3 +377,94 @@ func (h *Handler) DeleteTicket(w http.ResponseWriter, r *http.Request) {
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// RestoreTicket godoc
+//
+//	@Summary		Restore a deleted ticket
+//	@ID				restoreTicket
+//	@Description	Clears the deletion of a soft-deleted ticket. It returns to the end of its old column when that column and its board and sprint are still live, otherwise to the project's backlog. Refused with 409 when the ticket is not deleted (not_deleted) or its project is (parent_deleted)
+//	@Tags			ticket
+//	@Produce		json
+//	@Param			ticketId	path		string	true	"Ticket ID"
+//	@Success		200			{object}	domain.TicketModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		404			{object}	httpx.ErrBlock
+//	@Failure		409			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/{ticketId}/restore [post]
+func (h *Handler) RestoreTicket(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "ticketId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	ticket, err := h.svc.RestoreTicket(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, ticket)
+}
+
+// BulkDeleteTickets godoc
+//
+//	@Summary		Bulk delete tickets
+//	@ID				bulkDeleteTickets
+//	@Description	Soft-deletes every ticket matching the same filters as the ticket list in one statement; projectId is required. With dryRun nothing is deleted and the response counts the matching tickets and samples the newest of them, so the client can confirm before sending it again without dryRun
+//	@Tags			ticket
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.TicketBulkDeleteModel	true	"Filters and dryRun"
+//	@Success		200		{object}	domain.TicketBulkDeleteResultModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/tickets/bulk-delete [post]
+func (h *Handler) BulkDeleteTickets(w http.ResponseWriter, r *http.Request) {
+	var req domain.TicketBulkDeleteModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.svc.BulkDeleteTickets(r.Context(), req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, result)
+}
+
+// SyncTickets godoc
+//
+//	@Summary		Sync offline ticket changes
+//	@ID				syncTickets
+//	@Description	Applies a batch of ticket mutations queued while offline, in order, and reports each by clientId. Updates and deletes carry the updatedAt the client last saw as baseVersion and only apply while the ticket is unchanged; otherwise the result is a conflict holding the server's ticket. The batch always answers 200, per item statuses tell what happened
+//	@Tags			ticket
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		domain.TicketSyncModel	true	"Queued mutations"
+//	@Success		200		{object}	domain.TicketSyncResultModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/sync [post]
+func (h *Handler) SyncTickets(w http.ResponseWriter, r *http.Request) {
+	var req domain.TicketSyncModel
+	if err := httpx.DecodeAndValidate(r, &req); err != nil {
+		httpx.Handle(w, httpx.BadRequest(err.Error()))
+		return
+	}
+
+	result, err := h.svc.SyncTickets(r.Context(), req)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, result)
+}
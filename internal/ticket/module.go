@@ -4,56 +4,101 @@ import (
 	"context"
 	"log/slog"
 	"net/http"
+	"time"
 
 	ticketcache "github.com/dimasbaguspm/fluxis/internal/ticket/cache"
 	"github.com/dimasbaguspm/fluxis/internal/ticket/handler"
+	"github.com/dimasbaguspm/fluxis/internal/ticket/service"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/jsonapi"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 )
 
 type Module struct {
-	h            *handler.Handler
-	ticketCache  *ticketcache.TicketCache
-	bus          pubsub.Bus
+	h           *handler.Handler
+	svc         *service.Service
+	ticketCache *ticketcache.TicketCache
+	bus         pubsub.Bus
+	auth        *httpx.Authenticator
 }
 
-func NewModule(h *handler.Handler, c *ticketcache.TicketCache, bus pubsub.Bus) *Module {
+func NewModule(h *handler.Handler, svc *service.Service, c *ticketcache.TicketCache, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
 	return &Module{
 		h:           h,
+		svc:         svc,
 		ticketCache: c,
 		bus:         bus,
+		auth:        auth,
 	}
 }
 
+// ticketResource describes tickets served as JSON:API documents to clients
+// that negotiate application/vnd.api+json
+var ticketResource = jsonapi.Resource{
+	Type: "tickets",
+	Relationships: map[string]string{
+		"projectId":     "projects",
+		"sprintId":      "sprints",
+		"boardId":       "boards",
+		"boardColumnId": "board-columns",
+		"assigneeId":    "users",
+		"reporterId":    "users",
+		"epicId":        "tickets",
+		"parentId":      "tickets",
+	},
+	SelfPrefix: "/tickets/",
+}
+
 func (m *Module) Routes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /tickets", httpx.RequireAuth(m.h.ListTickets))
-	mux.HandleFunc("GET /tickets/{ticketId}", httpx.RequireAuth(m.h.GetTicket))
-	mux.HandleFunc("POST /tickets", httpx.RequireAuth(m.h.CreateTicket))
-	mux.HandleFunc("PATCH /tickets/{ticketId}", httpx.RequireAuth(m.h.UpdateTicket))
-	mux.HandleFunc("PATCH /tickets/{ticketId}/move-to-board", httpx.RequireAuth(m.h.MoveTicketToBoard))
-	mux.HandleFunc("PATCH /tickets/{ticketId}/move-to-sprint", httpx.RequireAuth(m.h.MoveTicketToSprint))
-	mux.HandleFunc("PATCH /tickets/{ticketId}/move-board-column", httpx.RequireAuth(m.h.MoveTicketToBoardColumn))
-	mux.HandleFunc("DELETE /tickets/{ticketId}", httpx.RequireAuth(m.h.DeleteTicket))
+	mux.HandleFunc("GET /tickets", jsonapi.Wrap(ticketResource, m.auth.RequireAuth(m.h.ListTickets, domain.ScopeTicketsRead)))
+	mux.HandleFunc("GET /tickets/{ticketId}", jsonapi.Wrap(ticketResource, m.auth.RequireAuth(m.h.GetTicket, domain.ScopeTicketsRead)))
+	mux.HandleFunc("POST /tickets", jsonapi.Wrap(ticketResource, m.auth.RequireAuth(m.h.CreateTicket, domain.ScopeTicketsWrite)))
+	mux.HandleFunc("PATCH /tickets/{ticketId}", jsonapi.Wrap(ticketResource, m.auth.RequireAuth(m.h.UpdateTicket, domain.ScopeTicketsWrite)))
+	mux.HandleFunc("PATCH /tickets/{ticketId}/move-to-board", m.auth.RequireAuth(m.h.MoveTicketToBoard, domain.ScopeTicketsWrite))
+	mux.HandleFunc("PATCH /tickets/{ticketId}/move-to-sprint", m.auth.RequireAuth(m.h.MoveTicketToSprint, domain.ScopeTicketsWrite))
+	mux.HandleFunc("PATCH /tickets/{ticketId}/move-board-column", m.auth.RequireAuth(m.h.MoveTicketToBoardColumn, domain.ScopeTicketsWrite))
+	mux.HandleFunc("POST /tickets/{ticketId}/move", m.auth.RequireAuth(m.h.MoveTicketToProject, domain.ScopeTicketsWrite))
+	mux.HandleFunc("PATCH /tickets/{ticketId}/position", m.auth.RequireAuth(m.h.MoveTicketPosition, domain.ScopeTicketsWrite))
+	mux.HandleFunc("DELETE /tickets/{ticketId}", m.auth.RequireAuth(m.h.DeleteTicket, domain.ScopeTicketsWrite))
+	mux.HandleFunc("POST /tickets/{ticketId}/restore", jsonapi.Wrap(ticketResource, m.auth.RequireAuth(m.h.RestoreTicket, domain.ScopeTicketsWrite)))
+	mux.HandleFunc("POST /tickets/bulk-delete", m.auth.RequireAuth(m.h.BulkDeleteTickets, domain.ScopeTicketsWrite))
+	mux.HandleFunc("GET /tickets/{ticketId}/export", m.auth.RequireAuth(m.h.ExportTicket, domain.ScopeTicketsRead))
+	mux.HandleFunc("GET /tickets/{ticketId}/lock", m.auth.RequireAuth(m.h.GetTicketLock, domain.ScopeTicketsRead))
+	mux.HandleFunc("POST /tickets/{ticketId}/lock", m.auth.RequireAuth(m.h.LockTicket, domain.ScopeTicketsWrite))
+	mux.HandleFunc("DELETE /tickets/{ticketId}/lock", m.auth.RequireAuth(m.h.UnlockTicket, domain.ScopeTicketsWrite))
+	mux.HandleFunc("GET /projects/{id}/graph", m.auth.RequireAuth(m.h.GetProjectTicketGraph, domain.ScopeTicketsRead))
+	mux.HandleFunc("GET /projects/{id}/suggestions/assignment", m.auth.RequireAuth(m.h.SuggestAssignees, domain.ScopeTicketsRead))
+	mux.HandleFunc("GET /projects/{id}/stale-settings", m.auth.RequireAuth(m.h.GetTicketStaleSettings, domain.ScopeProjectsRead))
+	mux.HandleFunc("PUT /projects/{id}/stale-settings", m.auth.RequireAuth(m.h.UpdateTicketStaleSettings, domain.ScopeProjectsWrite))
+	mux.HandleFunc("POST /sync", m.auth.RequireAuth(m.h.SyncTickets, domain.ScopeTicketsWrite))
 }
 
 func (m *Module) StartSubscriber(ctx context.Context) {
 	slog.Info("[TicketModule]: starting bus subscriber")
 	ticketHandler := func(ctx context.Context, e pubsub.Event) error {
+		// a bulk delete and a project move carry IDs rather than a ticket
+		if e.Type == pubsub.TicketsBulkDeleted || e.Type == pubsub.TicketMovedToProject {
+			m.ticketCache.InvalidatePagedBoardTickets(ctx)
+			m.ticketCache.InvalidatePagedSprintTickets(ctx)
+			m.ticketCache.InvalidatePagedProjectBacklog(ctx)
+			return nil
+		}
+
 		var ticket domain.TicketModel
 		if err := httpx.DecodePayload(e.Payload, &ticket); err != nil {
 			return nil
 		}
 
 		switch e.Type {
-		case pubsub.TicketCreated, pubsub.TicketUpdated, pubsub.TicketDeleted:
+		case pubsub.TicketCreated, pubsub.TicketUpdated, pubsub.TicketDeleted, pubsub.TicketRestored:
 			m.ticketCache.InvalidatePagedBoardTickets(ctx)
 			m.ticketCache.InvalidatePagedSprintTickets(ctx)
 			m.ticketCache.InvalidatePagedProjectBacklog(ctx)
 		case pubsub.TicketMovedToBoard:
 			m.ticketCache.InvalidatePagedBoardTickets(ctx)
 			m.ticketCache.InvalidatePagedProjectBacklog(ctx)
-		case pubsub.TicketMovedToBoardColumn:
+		case pubsub.TicketMovedToBoardColumn, pubsub.TicketReordered:
 			m.ticketCache.InvalidatePagedBoardTickets(ctx)
 		case pubsub.TicketMovedToSprint:
 			m.ticketCache.InvalidatePagedSprintTickets(ctx)
@@ -70,6 +115,38 @@ func (m *Module) StartSubscriber(ctx context.Context) {
 		return nil
 	}
 
+	// merging columns moves tickets without emitting per-ticket events
+	boardHandler := func(ctx context.Context, e pubsub.Event) error {
+		switch e.Type {
+		case pubsub.BoardColumnMerged:
+			m.ticketCache.InvalidatePagedBoardTickets(ctx)
+		}
+		return nil
+	}
+
+	// deleting a priority with a replacement rewrites tickets without per-ticket events
+	projectHandler := func(ctx context.Context, e pubsub.Event) error {
+		switch e.Type {
+		case pubsub.ProjectPriorityDeleted:
+			m.ticketCache.InvalidatePagedBoardTickets(ctx)
+			m.ticketCache.InvalidatePagedSprintTickets(ctx)
+			m.ticketCache.InvalidatePagedProjectBacklog(ctx)
+		}
+		return nil
+	}
+
 	m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Ticket), ticketHandler)
 	m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Sprint), sprintHandler)
+	m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Board), boardHandler)
+	m.bus.Subscribe(ctx, pubsub.Channel(pubsub.Project), projectHandler)
+}
+
+// StartStaleSweeper periodically flags tickets that went without activity for
+// longer than their project allows
+func (m *Module) StartStaleSweeper(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	slog.Info("[TicketModule]: starting stale ticket sweeper", "interval", interval.String())
+	m.svc.StartStaleSweeper(ctx, interval)
 }
@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/pkg/sqlfilter"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Bulk deletes take the ticket list's filters, so like the list its WHERE
+// clause is composed at runtime
+const bulkDeleteTickets = `UPDATE tickets
+SET deleted_at = NOW(), rank = NULL
+%s
+RETURNING id, project_id
+`
+
+type BulkDeleteTicketsParams struct {
+	ProjectIDs []pgtype.UUID
+	IDs        []pgtype.UUID
+	SprintIDs  []pgtype.UUID
+	BoardIDs   []pgtype.UUID
+}
+
+type BulkDeleteTicketsRow struct {
+	ID        pgtype.UUID `db:"id" json:"id"`
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+}
+
+// BulkDeleteTickets soft deletes every live ticket matching the filters in
+// one statement and returns the deleted tickets
+func (q *Queries) BulkDeleteTickets(ctx context.Context, arg BulkDeleteTicketsParams) ([]BulkDeleteTicketsRow, error) {
+	f := sqlfilter.New().And(sqlfilter.Raw("deleted_at IS NULL")).
+		And(ticketFilters(arg.ProjectIDs, arg.IDs, arg.SprintIDs, arg.BoardIDs)...)
+	query := fmt.Sprintf(bulkDeleteTickets, f.Where())
+
+	rows, err := q.db.Query(ctx, query, f.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []BulkDeleteTicketsRow{}
+	for rows.Next() {
+		var i BulkDeleteTicketsRow
+		if err := rows.Scan(&i.ID, &i.ProjectID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
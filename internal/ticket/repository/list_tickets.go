@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/pkg/sqlfilter"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The ticket list has the most optional filters of any list, so its WHERE
+// clause is composed at runtime instead of living in query.sql
+const listTicketsPaged = `WITH filtered_tickets AS (
+    SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank,
+           COUNT(*) OVER () as total_count
+    FROM tickets
+    %s
+)
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank, total_count FROM filtered_tickets
+ORDER BY ticket_number DESC
+LIMIT %s OFFSET %s
+`
+
+type ListTicketsPagedParams struct {
+	ProjectIDs     []pgtype.UUID
+	IDs            []pgtype.UUID
+	SprintIDs      []pgtype.UUID
+	BoardIDs       []pgtype.UUID
+	BoardColumnIDs []pgtype.UUID
+	// StatusColumnIDs are the columns a status filter matched; ByStatus
+	// applies it even when nothing matched, which lists no tickets
+	StatusColumnIDs []pgtype.UUID
+	ByStatus        bool
+	Categories      []string
+	IncludeDeleted  bool
+	Stale           bool
+	Limit           int32
+	Offset          int32
+}
+
+type ListTicketsPagedRow struct {
+	ID            pgtype.UUID        `db:"id" json:"id"`
+	ProjectID     pgtype.UUID        `db:"project_id" json:"project_id"`
+	TicketNumber  int32              `db:"ticket_number" json:"ticket_number"`
+	Key           string             `db:"key" json:"key"`
+	SprintID      pgtype.UUID        `db:"sprint_id" json:"sprint_id"`
+	BoardID       pgtype.UUID        `db:"board_id" json:"board_id"`
+	BoardColumnID pgtype.UUID        `db:"board_column_id" json:"board_column_id"`
+	Type          TicketType         `db:"type" json:"type"`
+	Priority      string             `db:"priority" json:"priority"`
+	Title         string             `db:"title" json:"title"`
+	Description   pgtype.Text        `db:"description" json:"description"`
+	AssigneeID    pgtype.UUID        `db:"assignee_id" json:"assignee_id"`
+	ReporterID    pgtype.UUID        `db:"reporter_id" json:"reporter_id"`
+	EpicID        pgtype.UUID        `db:"epic_id" json:"epic_id"`
+	ParentID      pgtype.UUID        `db:"parent_id" json:"parent_id"`
+	StoryPoints   pgtype.Int4        `db:"story_points" json:"story_points"`
+	DueDate       pgtype.Date        `db:"due_date" json:"due_date"`
+	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+	DeletedAt     pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	Rank          pgtype.Text        `db:"rank" json:"rank"`
+	TotalCount    int64              `db:"total_count" json:"total_count"`
+}
+
+func (q *Queries) ListTicketsPaged(ctx context.Context, arg ListTicketsPagedParams) ([]ListTicketsPagedRow, error) {
+	f := sqlfilter.New().And(
+		sqlfilter.If(!arg.IncludeDeleted, sqlfilter.Raw("deleted_at IS NULL")),
+		sqlfilter.If(arg.Stale, sqlfilter.Raw("EXISTS (SELECT 1 FROM stale_tickets st WHERE st.ticket_id = tickets.id AND st.flagged_at >= tickets.updated_at)")),
+		sqlfilter.In("board_column_id", arg.BoardColumnIDs),
+		sqlfilter.If(arg.ByStatus, sqlfilter.Raw("board_column_id = ANY(?)", arg.StatusColumnIDs)),
+		sqlfilter.If(len(arg.Categories) > 0, sqlfilter.Raw("board_column_id IN (SELECT id FROM active_board_columns WHERE category::text = ANY(?))", arg.Categories)),
+	).And(ticketFilters(arg.ProjectIDs, arg.IDs, arg.SprintIDs, arg.BoardIDs)...)
+	query := fmt.Sprintf(listTicketsPaged, f.Where(), f.Arg(arg.Limit), f.Arg(arg.Offset))
+
+	rows, err := q.db.Query(ctx, query, f.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTicketsPagedRow{}
+	for rows.Next() {
+		var i ListTicketsPagedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ProjectID,
+			&i.TicketNumber,
+			&i.Key,
+			&i.SprintID,
+			&i.BoardID,
+			&i.BoardColumnID,
+			&i.Type,
+			&i.Priority,
+			&i.Title,
+			&i.Description,
+			&i.AssigneeID,
+			&i.ReporterID,
+			&i.EpicID,
+			&i.ParentID,
+			&i.StoryPoints,
+			&i.DueDate,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Rank,
+			&i.TotalCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// ticketFilters are the search filters shared by the ticket list and bulk
+// delete, so a bulk delete removes exactly what the same search lists
+func ticketFilters(projectIDs, ids, sprintIDs, boardIDs []pgtype.UUID) []sqlfilter.Cond {
+	return []sqlfilter.Cond{
+		sqlfilter.In("project_id", projectIDs),
+		sqlfilter.In("id", ids),
+		sqlfilter.In("sprint_id", sprintIDs),
+		sqlfilter.In("board_id", boardIDs),
+	}
+}
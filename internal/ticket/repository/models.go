@@ -11,50 +11,6 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
-type TicketPriority string
-
-const (
-	TicketPriorityLow      TicketPriority = "low"
-	TicketPriorityMedium   TicketPriority = "medium"
-	TicketPriorityHigh     TicketPriority = "high"
-	TicketPriorityCritical TicketPriority = "critical"
-)
-
-func (e *TicketPriority) Scan(src interface{}) error {
-	switch s := src.(type) {
-	case []byte:
-		*e = TicketPriority(s)
-	case string:
-		*e = TicketPriority(s)
-	default:
-		return fmt.Errorf("unsupported scan type for TicketPriority: %T", src)
-	}
-	return nil
-}
-
-type NullTicketPriority struct {
-	TicketPriority TicketPriority `json:"ticket_priority"`
-	Valid          bool           `json:"valid"` // Valid is true if TicketPriority is not NULL
-}
-
-// Scan implements the Scanner interface.
-func (ns *NullTicketPriority) Scan(value interface{}) error {
-	if value == nil {
-		ns.TicketPriority, ns.Valid = "", false
-		return nil
-	}
-	ns.Valid = true
-	return ns.TicketPriority.Scan(value)
-}
-
-// Value implements the driver Valuer interface.
-func (ns NullTicketPriority) Value() (driver.Value, error) {
-	if !ns.Valid {
-		return nil, nil
-	}
-	return string(ns.TicketPriority), nil
-}
-
 type TicketType string
 
 const (
@@ -108,7 +64,7 @@ type Ticket struct {
 	BoardID       pgtype.UUID        `db:"board_id" json:"board_id"`
 	BoardColumnID pgtype.UUID        `db:"board_column_id" json:"board_column_id"`
 	Type          TicketType         `db:"type" json:"type"`
-	Priority      TicketPriority     `db:"priority" json:"priority"`
+	Priority      string             `db:"priority" json:"priority"`
 	Title         string             `db:"title" json:"title"`
 	Description   pgtype.Text        `db:"description" json:"description"`
 	AssigneeID    pgtype.UUID        `db:"assignee_id" json:"assignee_id"`
@@ -120,4 +76,13 @@ type Ticket struct {
 	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
 	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 	DeletedAt     pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
+	Rank          pgtype.Text        `db:"rank" json:"rank"`
+}
+
+type TicketStaleSetting struct {
+	ProjectID pgtype.UUID        `db:"project_id" json:"project_id"`
+	AfterDays int32              `db:"after_days" json:"after_days"`
+	UpdatedBy pgtype.UUID        `db:"updated_by" json:"updated_by"`
+	CreatedAt pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
 }
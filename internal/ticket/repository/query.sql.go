@@ -11,6 +11,26 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countColumnTickets = `-- name: CountColumnTickets :one
+-- Live tickets in a column, leaving out $2 so a ticket already there is not counted twice
+SELECT COUNT(*)
+FROM tickets
+WHERE board_column_id = $1 AND deleted_at IS NULL AND id IS DISTINCT FROM $2
+`
+
+type CountColumnTicketsParams struct {
+	BoardColumnID pgtype.UUID `db:"board_column_id" json:"board_column_id"`
+	ID            pgtype.UUID `db:"id" json:"id"`
+}
+
+// Live tickets in a column, leaving out $2 so a ticket already there is not counted twice
+func (q *Queries) CountColumnTickets(ctx context.Context, arg CountColumnTicketsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countColumnTickets, arg.BoardColumnID, arg.ID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const createTicket = `-- name: CreateTicket :one
 INSERT INTO tickets (
     project_id,
@@ -23,7 +43,8 @@ INSERT INTO tickets (
     reporter_id,
     assignee_id,
     story_points,
-    due_date
+    due_date,
+    id
 )
 VALUES (
     $1,
@@ -36,22 +57,24 @@ VALUES (
     $7,
     $8,
     $9,
-    $10
+    $10,
+    $11
 )
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 `
 
 type CreateTicketParams struct {
-	ProjectID   pgtype.UUID    `db:"project_id" json:"project_id"`
-	Key         string         `db:"key" json:"key"`
-	Type        TicketType     `db:"type" json:"type"`
-	Priority    TicketPriority `db:"priority" json:"priority"`
-	Title       string         `db:"title" json:"title"`
-	Description pgtype.Text    `db:"description" json:"description"`
-	ReporterID  pgtype.UUID    `db:"reporter_id" json:"reporter_id"`
-	AssigneeID  pgtype.UUID    `db:"assignee_id" json:"assignee_id"`
-	StoryPoints pgtype.Int4    `db:"story_points" json:"story_points"`
-	DueDate     pgtype.Date    `db:"due_date" json:"due_date"`
+	ProjectID   pgtype.UUID `db:"project_id" json:"project_id"`
+	Key         string      `db:"key" json:"key"`
+	Type        TicketType  `db:"type" json:"type"`
+	Priority    string      `db:"priority" json:"priority"`
+	Title       string      `db:"title" json:"title"`
+	Description pgtype.Text `db:"description" json:"description"`
+	ReporterID  pgtype.UUID `db:"reporter_id" json:"reporter_id"`
+	AssigneeID  pgtype.UUID `db:"assignee_id" json:"assignee_id"`
+	StoryPoints pgtype.Int4 `db:"story_points" json:"story_points"`
+	DueDate     pgtype.Date `db:"due_date" json:"due_date"`
+	ID          pgtype.UUID `db:"id" json:"id"`
 }
 
 func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Ticket, error) {
@@ -66,6 +89,7 @@ func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Tic
 		arg.AssigneeID,
 		arg.StoryPoints,
 		arg.DueDate,
+		arg.ID,
 	)
 	var i Ticket
 	err := row.Scan(
@@ -89,19 +113,27 @@ func (q *Queries) CreateTicket(ctx context.Context, arg CreateTicketParams) (Tic
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Rank,
 	)
 	return i, err
 }
 
 const deleteTicket = `-- name: DeleteTicket :one
 UPDATE tickets
-SET deleted_at = NOW()
+SET deleted_at = NOW(), rank = NULL
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+  AND ($2::timestamptz IS NULL OR updated_at = $2::timestamptz)
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 `
 
-func (q *Queries) DeleteTicket(ctx context.Context, id pgtype.UUID) (Ticket, error) {
-	row := q.db.QueryRow(ctx, deleteTicket, id)
+type DeleteTicketParams struct {
+	ID      pgtype.UUID        `db:"id" json:"id"`
+	Column2 pgtype.Timestamptz `db:"column_2" json:"column_2"`
+}
+
+// A valid $2 only deletes a ticket still at that updated_at
+func (q *Queries) DeleteTicket(ctx context.Context, arg DeleteTicketParams) (Ticket, error) {
+	row := q.db.QueryRow(ctx, deleteTicket, arg.ID, arg.Column2)
 	var i Ticket
 	err := row.Scan(
 		&i.ID,
@@ -124,10 +156,52 @@ func (q *Queries) DeleteTicket(ctx context.Context, id pgtype.UUID) (Ticket, err
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Rank,
 	)
 	return i, err
 }
 
+const flagStaleTickets = `-- name: FlagStaleTickets :many
+-- Flags the tickets untouched for their project's after_days at $1 that are
+-- not done, returning only those that were not flagged yet
+INSERT INTO stale_tickets (ticket_id, project_id, flagged_at)
+SELECT t.id, t.project_id, $1::timestamptz
+FROM active_tickets t
+JOIN ticket_stale_settings ss ON ss.project_id = t.project_id AND ss.after_days > 0
+LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE t.updated_at < $1::timestamptz - make_interval(days => ss.after_days)
+  AND COALESCE(bc.category <> 'done', true)
+ON CONFLICT (ticket_id) DO NOTHING
+RETURNING ticket_id, project_id
+`
+
+type FlagStaleTicketsRow struct {
+	TicketID  pgtype.UUID `db:"ticket_id" json:"ticket_id"`
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+}
+
+// Flags the tickets untouched for their project's after_days at $1 that are
+// not done, returning only those that were not flagged yet
+func (q *Queries) FlagStaleTickets(ctx context.Context, dollar_1 pgtype.Timestamptz) ([]FlagStaleTicketsRow, error) {
+	rows, err := q.db.Query(ctx, flagStaleTickets, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FlagStaleTicketsRow{}
+	for rows.Next() {
+		var i FlagStaleTicketsRow
+		if err := rows.Scan(&i.TicketID, &i.ProjectID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const generateTicketKey = `-- name: GenerateTicketKey :one
 SELECT generate_ticket_key($1)
 `
@@ -139,8 +213,56 @@ func (q *Queries) GenerateTicketKey(ctx context.Context, pProjectID pgtype.UUID)
 	return generate_ticket_key, err
 }
 
+const getDefaultBoardColumn = `-- name: GetDefaultBoardColumn :one
+SELECT id
+FROM active_board_columns
+WHERE board_id = $1 AND is_default
+`
+
+func (q *Queries) GetDefaultBoardColumn(ctx context.Context, boardID pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getDefaultBoardColumn, boardID)
+	var id pgtype.UUID
+	err := row.Scan(&id)
+	return id, err
+}
+
+const getLastTicketRank = `-- name: GetLastTicketRank :one
+SELECT COALESCE(MAX(rank), '')::text AS last_rank
+FROM tickets
+WHERE board_column_id = $1 AND deleted_at IS NULL
+`
+
+func (q *Queries) GetLastTicketRank(ctx context.Context, boardColumnID pgtype.UUID) (string, error) {
+	row := q.db.QueryRow(ctx, getLastTicketRank, boardColumnID)
+	var last_rank string
+	err := row.Scan(&last_rank)
+	return last_rank, err
+}
+
+const getNextTicketRank = `-- name: GetNextTicketRank :one
+SELECT rank
+FROM tickets
+WHERE board_column_id = $1 AND rank > $2 AND id <> $3 AND deleted_at IS NULL
+ORDER BY rank
+LIMIT 1
+`
+
+type GetNextTicketRankParams struct {
+	BoardColumnID pgtype.UUID `db:"board_column_id" json:"board_column_id"`
+	Rank          pgtype.Text `db:"rank" json:"rank"`
+	ID            pgtype.UUID `db:"id" json:"id"`
+}
+
+// Returns the first rank after $2 in a column, ignoring the ticket being placed
+func (q *Queries) GetNextTicketRank(ctx context.Context, arg GetNextTicketRankParams) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, getNextTicketRank, arg.BoardColumnID, arg.Rank, arg.ID)
+	var rank pgtype.Text
+	err := row.Scan(&rank)
+	return rank, err
+}
+
 const getTicket = `-- name: GetTicket :one
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE id = $1 AND deleted_at IS NULL
 `
@@ -169,12 +291,13 @@ func (q *Queries) GetTicket(ctx context.Context, id pgtype.UUID) (Ticket, error)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Rank,
 	)
 	return i, err
 }
 
 const getTicketByKey = `-- name: GetTicketByKey :one
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE project_id = $1 AND key = $2 AND deleted_at IS NULL
 `
@@ -208,10 +331,154 @@ func (q *Queries) GetTicketByKey(ctx context.Context, arg GetTicketByKeyParams)
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Rank,
 	)
 	return i, err
 }
 
+const getTicketChangeSummary = `-- name: GetTicketChangeSummary :one
+-- Counts the writes the change log holds for a ticket and when the last one happened
+SELECT
+    COUNT(*) FILTER (WHERE op = 'update')::bigint AS updates,
+    MAX(created_at)::timestamptz AS last_changed_at
+FROM changes
+WHERE entity = 'ticket' AND entity_id = $1
+`
+
+type GetTicketChangeSummaryRow struct {
+	Updates       int64              `db:"updates" json:"updates"`
+	LastChangedAt pgtype.Timestamptz `db:"last_changed_at" json:"last_changed_at"`
+}
+
+// Counts the writes the change log holds for a ticket and when the last one happened
+func (q *Queries) GetTicketChangeSummary(ctx context.Context, entityID pgtype.UUID) (GetTicketChangeSummaryRow, error) {
+	row := q.db.QueryRow(ctx, getTicketChangeSummary, entityID)
+	var i GetTicketChangeSummaryRow
+	err := row.Scan(&i.Updates, &i.LastChangedAt)
+	return i, err
+}
+
+const getTicketExportNames = `-- name: GetTicketExportNames :one
+-- Resolves the names a ticket export prints in place of IDs, empty when unset
+SELECT
+    p.name AS project_name,
+    COALESCE(s.name, '')::text AS sprint_name,
+    COALESCE(b.name, '')::text AS board_name,
+    COALESCE(bc.name, '')::text AS column_name,
+    COALESCE(bc.category::text, '')::text AS column_category,
+    COALESCE(a.display_name, '')::text AS assignee_name,
+    COALESCE(r.display_name, '')::text AS reporter_name,
+    COALESCE(e.key, '')::text AS epic_key,
+    COALESCE(pt.key, '')::text AS parent_key
+FROM tickets t
+JOIN projects p ON p.id = t.project_id
+LEFT JOIN sprints s ON s.id = t.sprint_id
+LEFT JOIN boards b ON b.id = t.board_id
+LEFT JOIN board_columns bc ON bc.id = t.board_column_id
+LEFT JOIN users a ON a.id = t.assignee_id
+LEFT JOIN users r ON r.id = t.reporter_id
+LEFT JOIN tickets e ON e.id = t.epic_id
+LEFT JOIN tickets pt ON pt.id = t.parent_id
+WHERE t.id = $1 AND t.deleted_at IS NULL
+`
+
+type GetTicketExportNamesRow struct {
+	ProjectName    string `db:"project_name" json:"project_name"`
+	SprintName     string `db:"sprint_name" json:"sprint_name"`
+	BoardName      string `db:"board_name" json:"board_name"`
+	ColumnName     string `db:"column_name" json:"column_name"`
+	ColumnCategory string `db:"column_category" json:"column_category"`
+	AssigneeName   string `db:"assignee_name" json:"assignee_name"`
+	ReporterName   string `db:"reporter_name" json:"reporter_name"`
+	EpicKey        string `db:"epic_key" json:"epic_key"`
+	ParentKey      string `db:"parent_key" json:"parent_key"`
+}
+
+// Resolves the names a ticket export prints in place of IDs, empty when unset
+func (q *Queries) GetTicketExportNames(ctx context.Context, id pgtype.UUID) (GetTicketExportNamesRow, error) {
+	row := q.db.QueryRow(ctx, getTicketExportNames, id)
+	var i GetTicketExportNamesRow
+	err := row.Scan(
+		&i.ProjectName,
+		&i.SprintName,
+		&i.BoardName,
+		&i.ColumnName,
+		&i.ColumnCategory,
+		&i.AssigneeName,
+		&i.ReporterName,
+		&i.EpicKey,
+		&i.ParentKey,
+	)
+	return i, err
+}
+
+const getTicketProject = `-- name: GetTicketProject :one
+-- The project a ticket belongs to, deleted or not
+SELECT project_id
+FROM tickets
+WHERE id = $1
+`
+
+// The project a ticket belongs to, deleted or not
+func (q *Queries) GetTicketProject(ctx context.Context, id pgtype.UUID) (pgtype.UUID, error) {
+	row := q.db.QueryRow(ctx, getTicketProject, id)
+	var project_id pgtype.UUID
+	err := row.Scan(&project_id)
+	return project_id, err
+}
+
+const getTicketStaleSettings = `-- name: GetTicketStaleSettings :one
+SELECT project_id, after_days, updated_by, created_at, updated_at
+FROM ticket_stale_settings
+WHERE project_id = $1
+`
+
+func (q *Queries) GetTicketStaleSettings(ctx context.Context, projectID pgtype.UUID) (TicketStaleSetting, error) {
+	row := q.db.QueryRow(ctx, getTicketStaleSettings, projectID)
+	var i TicketStaleSetting
+	err := row.Scan(
+		&i.ProjectID,
+		&i.AfterDays,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getTicketTrashState = `-- name: GetTicketTrashState :one
+-- Whether a ticket and its project are deleted, and the column it goes back
+-- to when that column, its board and its sprint are all still live
+SELECT
+    t.deleted_at IS NOT NULL AS deleted,
+    p.deleted_at IS NOT NULL AS project_deleted,
+    bc.id AS live_column_id
+FROM tickets t
+JOIN projects p ON p.id = t.project_id
+LEFT JOIN board_columns bc ON bc.id = t.board_column_id AND bc.deleted_at IS NULL
+    AND EXISTS (
+        SELECT 1 FROM boards b
+        JOIN sprints s ON s.id = b.sprint_id
+        WHERE b.id = bc.board_id AND b.deleted_at IS NULL AND s.deleted_at IS NULL
+    )
+WHERE t.id = $1
+`
+
+type GetTicketTrashStateRow struct {
+	Deleted        bool        `db:"deleted" json:"deleted"`
+	ProjectDeleted bool        `db:"project_deleted" json:"project_deleted"`
+	LiveColumnID   pgtype.UUID `db:"live_column_id" json:"live_column_id"`
+}
+
+// Whether a ticket and its project are deleted, and the column it goes back
+// to when that column, its board and its sprint are all still live
+func (q *Queries) GetTicketTrashState(ctx context.Context, id pgtype.UUID) (GetTicketTrashStateRow, error) {
+	row := q.db.QueryRow(ctx, getTicketTrashState, id)
+	var i GetTicketTrashStateRow
+	err := row.Scan(&i.Deleted, &i.ProjectDeleted, &i.LiveColumnID)
+	return i, err
+}
+
 const hardDeleteTicket = `-- name: HardDeleteTicket :exec
 DELETE FROM tickets
 WHERE id = $1
@@ -222,8 +489,201 @@ func (q *Queries) HardDeleteTicket(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const listAssignmentCandidates = `-- name: ListAssignmentCandidates :many
+-- The members of a project with their open tickets and the done ones sharing a type or epic, counted across the organisation
+SELECT
+    u.id AS user_id,
+    u.display_name,
+    COUNT(t.id) FILTER (WHERE bc.category IS DISTINCT FROM 'done')::bigint AS open_tickets,
+    COALESCE(SUM(t.story_points) FILTER (WHERE bc.category IS DISTINCT FROM 'done'), 0)::bigint AS open_points,
+    COUNT(t.id) FILTER (WHERE bc.category = 'done' AND t.type = $2)::bigint AS done_same_type,
+    COUNT(t.id) FILTER (WHERE bc.category = 'done' AND t.epic_id = $3)::bigint AS done_same_epic
+FROM projects p
+JOIN project_members pm ON pm.project_id = p.id
+JOIN users u ON u.id = pm.user_id AND u.deleted_at IS NULL
+LEFT JOIN active_tickets t ON t.assignee_id = u.id
+    AND t.project_id IN (SELECT id FROM projects WHERE org_id = p.org_id AND deleted_at IS NULL)
+LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE p.id = $1
+GROUP BY u.id, u.display_name
+ORDER BY u.display_name
+`
+
+type ListAssignmentCandidatesParams struct {
+	ID     pgtype.UUID `db:"id" json:"id"`
+	Type   TicketType  `db:"type" json:"type"`
+	EpicID pgtype.UUID `db:"epic_id" json:"epic_id"`
+}
+
+type ListAssignmentCandidatesRow struct {
+	UserID       pgtype.UUID `db:"user_id" json:"user_id"`
+	DisplayName  string      `db:"display_name" json:"display_name"`
+	OpenTickets  int64       `db:"open_tickets" json:"open_tickets"`
+	OpenPoints   int64       `db:"open_points" json:"open_points"`
+	DoneSameType int64       `db:"done_same_type" json:"done_same_type"`
+	DoneSameEpic int64       `db:"done_same_epic" json:"done_same_epic"`
+}
+
+// The members of a project with their open tickets and the done ones sharing a type or epic, counted across the organisation
+func (q *Queries) ListAssignmentCandidates(ctx context.Context, arg ListAssignmentCandidatesParams) ([]ListAssignmentCandidatesRow, error) {
+	rows, err := q.db.Query(ctx, listAssignmentCandidates, arg.ID, arg.Type, arg.EpicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListAssignmentCandidatesRow{}
+	for rows.Next() {
+		var i ListAssignmentCandidatesRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.DisplayName,
+			&i.OpenTickets,
+			&i.OpenPoints,
+			&i.DoneSameType,
+			&i.DoneSameEpic,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectColumnNames = `-- name: ListProjectColumnNames :many
+-- The live board columns of the projects, which the status filter matches by name slug
+SELECT id, name
+FROM active_board_columns
+WHERE project_id = ANY($1::uuid[])
+`
+
+type ListProjectColumnNamesRow struct {
+	ID   pgtype.UUID `db:"id" json:"id"`
+	Name string      `db:"name" json:"name"`
+}
+
+// The live board columns of the projects, which the status filter matches by name slug
+func (q *Queries) ListProjectColumnNames(ctx context.Context, dollar_1 []pgtype.UUID) ([]ListProjectColumnNamesRow, error) {
+	rows, err := q.db.Query(ctx, listProjectColumnNames, dollar_1)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectColumnNamesRow{}
+	for rows.Next() {
+		var i ListProjectColumnNamesRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listProjectTicketGraph = `-- name: ListProjectTicketGraph :many
+-- A project's tickets with their status category and the links that make up its graph
+SELECT
+    t.id,
+    t.key,
+    t.title,
+    t.type,
+    t.priority,
+    COALESCE(bc.category::text, '')::text AS status,
+    t.assignee_id,
+    t.epic_id,
+    t.parent_id
+FROM active_tickets t
+LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE t.project_id = $1
+ORDER BY t.ticket_number
+`
+
+type ListProjectTicketGraphRow struct {
+	ID         pgtype.UUID `db:"id" json:"id"`
+	Key        string      `db:"key" json:"key"`
+	Title      string      `db:"title" json:"title"`
+	Type       TicketType  `db:"type" json:"type"`
+	Priority   string      `db:"priority" json:"priority"`
+	Status     string      `db:"status" json:"status"`
+	AssigneeID pgtype.UUID `db:"assignee_id" json:"assignee_id"`
+	EpicID     pgtype.UUID `db:"epic_id" json:"epic_id"`
+	ParentID   pgtype.UUID `db:"parent_id" json:"parent_id"`
+}
+
+// A project's tickets with their status category and the links that make up its graph
+func (q *Queries) ListProjectTicketGraph(ctx context.Context, projectID pgtype.UUID) ([]ListProjectTicketGraphRow, error) {
+	rows, err := q.db.Query(ctx, listProjectTicketGraph, projectID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListProjectTicketGraphRow{}
+	for rows.Next() {
+		var i ListProjectTicketGraphRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Key,
+			&i.Title,
+			&i.Type,
+			&i.Priority,
+			&i.Status,
+			&i.AssigneeID,
+			&i.EpicID,
+			&i.ParentID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTicketSubtasks = `-- name: ListTicketSubtasks :many
+-- A ticket's children with whether their column counts as done, the checklist of an export
+SELECT t.key, t.title, COALESCE(bc.category = 'done', false) AS is_done
+FROM active_tickets t
+LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE t.parent_id = $1
+ORDER BY t.ticket_number
+`
+
+type ListTicketSubtasksRow struct {
+	Key    string `db:"key" json:"key"`
+	Title  string `db:"title" json:"title"`
+	IsDone bool   `db:"is_done" json:"is_done"`
+}
+
+// A ticket's children with whether their column counts as done, the checklist of an export
+func (q *Queries) ListTicketSubtasks(ctx context.Context, parentID pgtype.UUID) ([]ListTicketSubtasksRow, error) {
+	rows, err := q.db.Query(ctx, listTicketSubtasks, parentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListTicketSubtasksRow{}
+	for rows.Next() {
+		var i ListTicketSubtasksRow
+		if err := rows.Scan(&i.Key, &i.Title, &i.IsDone); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listTicketsByBoard = `-- name: ListTicketsByBoard :many
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE board_id = $1 AND deleted_at IS NULL
 ORDER BY ticket_number DESC
@@ -259,6 +719,7 @@ func (q *Queries) ListTicketsByBoard(ctx context.Context, boardID pgtype.UUID) (
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -271,10 +732,10 @@ func (q *Queries) ListTicketsByBoard(ctx context.Context, boardID pgtype.UUID) (
 }
 
 const listTicketsByBoardColumn = `-- name: ListTicketsByBoardColumn :many
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE board_column_id = $1 AND deleted_at IS NULL
-ORDER BY ticket_number DESC
+ORDER BY rank, ticket_number DESC
 `
 
 func (q *Queries) ListTicketsByBoardColumn(ctx context.Context, boardColumnID pgtype.UUID) ([]Ticket, error) {
@@ -307,6 +768,7 @@ func (q *Queries) ListTicketsByBoardColumn(ctx context.Context, boardColumnID pg
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -319,7 +781,7 @@ func (q *Queries) ListTicketsByBoardColumn(ctx context.Context, boardColumnID pg
 }
 
 const listTicketsByProject = `-- name: ListTicketsByProject :many
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE project_id = $1 AND deleted_at IS NULL
 ORDER BY ticket_number DESC
@@ -355,6 +817,7 @@ func (q *Queries) ListTicketsByProject(ctx context.Context, projectID pgtype.UUI
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -367,7 +830,7 @@ func (q *Queries) ListTicketsByProject(ctx context.Context, projectID pgtype.UUI
 }
 
 const listTicketsBySprint = `-- name: ListTicketsBySprint :many
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE project_id = $1 AND sprint_id = $2 AND deleted_at IS NULL
 ORDER BY ticket_number DESC
@@ -408,6 +871,7 @@ func (q *Queries) ListTicketsBySprint(ctx context.Context, arg ListTicketsBySpri
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.DeletedAt,
+			&i.Rank,
 		); err != nil {
 			return nil, err
 		}
@@ -419,119 +883,209 @@ func (q *Queries) ListTicketsBySprint(ctx context.Context, arg ListTicketsBySpri
 	return items, nil
 }
 
-const listTicketsPaged = `-- name: ListTicketsPaged :many
-WITH filtered_tickets AS (
-    SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at,
-           COUNT(*) OVER () as total_count
-    FROM tickets
-    WHERE deleted_at IS NULL
-        AND (array_length($1::uuid[], 1) IS NULL OR project_id = ANY($1::uuid[]))
-        AND (array_length($2::uuid[], 1) IS NULL OR id = ANY($2::uuid[]))
-        AND (array_length($3::uuid[], 1) IS NULL OR sprint_id = ANY($3::uuid[]))
-        AND (array_length($4::uuid[], 1) IS NULL OR board_id = ANY($4::uuid[]))
+const moveTicketToProject = `-- name: MoveTicketToProject :one
+-- Numbers the ticket in project $2 under key $3, which GenerateTicketKey just
+-- handed out, and places it on the given board or in the backlog. Links into
+-- the old project go: its epic and parent, the assignee when they are not a
+-- member of the new project's organisation, and the epic and parent links of
+-- tickets pointing at it.
+WITH detached AS (
+    UPDATE tickets
+    SET parent_id = NULLIF(parent_id, $1), epic_id = NULLIF(epic_id, $1)
+    WHERE parent_id = $1 OR epic_id = $1
 )
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, total_count FROM filtered_tickets
-ORDER BY ticket_number DESC
-LIMIT $5 OFFSET $6
-`
-
-type ListTicketsPagedParams struct {
-	Column1 []pgtype.UUID `db:"column_1" json:"column_1"`
-	Column2 []pgtype.UUID `db:"column_2" json:"column_2"`
-	Column3 []pgtype.UUID `db:"column_3" json:"column_3"`
-	Column4 []pgtype.UUID `db:"column_4" json:"column_4"`
-	Limit   int32         `db:"limit" json:"limit"`
-	Offset  int32         `db:"offset" json:"offset"`
-}
-
-type ListTicketsPagedRow struct {
-	ID            pgtype.UUID        `db:"id" json:"id"`
-	ProjectID     pgtype.UUID        `db:"project_id" json:"project_id"`
-	TicketNumber  int32              `db:"ticket_number" json:"ticket_number"`
-	Key           string             `db:"key" json:"key"`
-	SprintID      pgtype.UUID        `db:"sprint_id" json:"sprint_id"`
-	BoardID       pgtype.UUID        `db:"board_id" json:"board_id"`
-	BoardColumnID pgtype.UUID        `db:"board_column_id" json:"board_column_id"`
-	Type          TicketType         `db:"type" json:"type"`
-	Priority      TicketPriority     `db:"priority" json:"priority"`
-	Title         string             `db:"title" json:"title"`
-	Description   pgtype.Text        `db:"description" json:"description"`
-	AssigneeID    pgtype.UUID        `db:"assignee_id" json:"assignee_id"`
-	ReporterID    pgtype.UUID        `db:"reporter_id" json:"reporter_id"`
-	EpicID        pgtype.UUID        `db:"epic_id" json:"epic_id"`
-	ParentID      pgtype.UUID        `db:"parent_id" json:"parent_id"`
-	StoryPoints   pgtype.Int4        `db:"story_points" json:"story_points"`
-	DueDate       pgtype.Date        `db:"due_date" json:"due_date"`
-	CreatedAt     pgtype.Timestamptz `db:"created_at" json:"created_at"`
-	UpdatedAt     pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
-	DeletedAt     pgtype.Timestamptz `db:"deleted_at" json:"deleted_at"`
-	TotalCount    int64              `db:"total_count" json:"total_count"`
-}
-
-func (q *Queries) ListTicketsPaged(ctx context.Context, arg ListTicketsPagedParams) ([]ListTicketsPagedRow, error) {
-	rows, err := q.db.Query(ctx, listTicketsPaged,
-		arg.Column1,
-		arg.Column2,
-		arg.Column3,
-		arg.Column4,
-		arg.Limit,
-		arg.Offset,
+UPDATE tickets t
+SET project_id = $2,
+    ticket_number = (SELECT next_number - 1 FROM ticket_counters WHERE project_id = $2),
+    key = $3,
+    priority = $4,
+    sprint_id = $5,
+    board_id = $6,
+    board_column_id = $7,
+    rank = NULLIF($8::text, ''),
+    epic_id = NULL,
+    parent_id = NULL,
+    assignee_id = CASE
+        WHEN EXISTS (
+            SELECT 1 FROM project_members pm WHERE pm.project_id = $2 AND pm.user_id = t.assignee_id
+        ) THEN t.assignee_id
+    END
+WHERE t.id = $1 AND t.deleted_at IS NULL
+RETURNING t.id, t.project_id, t.ticket_number, t.key, t.sprint_id, t.board_id, t.board_column_id, t.type, t.priority, t.title, t.description, t.assignee_id, t.reporter_id, t.epic_id, t.parent_id, t.story_points, t.due_date, t.created_at, t.updated_at, t.deleted_at, t.rank
+`
+
+type MoveTicketToProjectParams struct {
+	ID            pgtype.UUID `db:"id" json:"id"`
+	ProjectID     pgtype.UUID `db:"project_id" json:"project_id"`
+	Key           string      `db:"key" json:"key"`
+	Priority      string      `db:"priority" json:"priority"`
+	SprintID      pgtype.UUID `db:"sprint_id" json:"sprint_id"`
+	BoardID       pgtype.UUID `db:"board_id" json:"board_id"`
+	BoardColumnID pgtype.UUID `db:"board_column_id" json:"board_column_id"`
+	Column8       string      `db:"column_8" json:"column_8"`
+}
+
+// Numbers the ticket in project $2 under key $3, which GenerateTicketKey just
+// handed out, and places it on the given board or in the backlog. Links into
+// the old project go: its epic and parent, the assignee when they are not a
+// member of the new project, and the epic and parent links of tickets
+// pointing at it.
+func (q *Queries) MoveTicketToProject(ctx context.Context, arg MoveTicketToProjectParams) (Ticket, error) {
+	row := q.db.QueryRow(ctx, moveTicketToProject,
+		arg.ID,
+		arg.ProjectID,
+		arg.Key,
+		arg.Priority,
+		arg.SprintID,
+		arg.BoardID,
+		arg.BoardColumnID,
+		arg.Column8,
+	)
+	var i Ticket
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.TicketNumber,
+		&i.Key,
+		&i.SprintID,
+		&i.BoardID,
+		&i.BoardColumnID,
+		&i.Type,
+		&i.Priority,
+		&i.Title,
+		&i.Description,
+		&i.AssigneeID,
+		&i.ReporterID,
+		&i.EpicID,
+		&i.ParentID,
+		&i.StoryPoints,
+		&i.DueDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Rank,
 	)
+	return i, err
+}
+
+const rebalanceTicketRanks = `-- name: RebalanceTicketRanks :exec
+UPDATE tickets
+SET rank = ranked.rank
+FROM (
+    SELECT id, lpad((ROW_NUMBER() OVER (ORDER BY rank, ticket_number DESC))::text, 6, '0') || 'i' AS rank
+    FROM tickets
+    WHERE board_column_id = $1 AND deleted_at IS NULL
+) ranked
+WHERE tickets.id = ranked.id
+`
+
+// Re-spaces the ranks of a column with short fixed width keys, keeping their order
+func (q *Queries) RebalanceTicketRanks(ctx context.Context, boardColumnID pgtype.UUID) error {
+	_, err := q.db.Exec(ctx, rebalanceTicketRanks, boardColumnID)
+	return err
+}
+
+const restoreTicket = `-- name: RestoreTicket :one
+-- Clears deleted_at of a ticket whose project is live, keeping it in column
+-- $2 at rank $3, or moving it to the backlog when $2 is null
+UPDATE tickets t
+SET deleted_at = NULL,
+    sprint_id = CASE WHEN $2::uuid IS NOT NULL THEN t.sprint_id END,
+    board_id = CASE WHEN $2::uuid IS NOT NULL THEN t.board_id END,
+    board_column_id = $2,
+    rank = NULLIF($3::text, '')
+WHERE t.id = $1 AND t.deleted_at IS NOT NULL
+  AND EXISTS (SELECT 1 FROM projects p WHERE p.id = t.project_id AND p.deleted_at IS NULL)
+RETURNING t.id, t.project_id, t.ticket_number, t.key, t.sprint_id, t.board_id, t.board_column_id, t.type, t.priority, t.title, t.description, t.assignee_id, t.reporter_id, t.epic_id, t.parent_id, t.story_points, t.due_date, t.created_at, t.updated_at, t.deleted_at, t.rank
+`
+
+type RestoreTicketParams struct {
+	ID            pgtype.UUID `db:"id" json:"id"`
+	BoardColumnID pgtype.UUID `db:"board_column_id" json:"board_column_id"`
+	Column3       string      `db:"column_3" json:"column_3"`
+}
+
+// Clears deleted_at of a ticket whose project is live, keeping it in column
+// $2 at rank $3, or moving it to the backlog when $2 is null
+func (q *Queries) RestoreTicket(ctx context.Context, arg RestoreTicketParams) (Ticket, error) {
+	row := q.db.QueryRow(ctx, restoreTicket, arg.ID, arg.BoardColumnID, arg.Column3)
+	var i Ticket
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.TicketNumber,
+		&i.Key,
+		&i.SprintID,
+		&i.BoardID,
+		&i.BoardColumnID,
+		&i.Type,
+		&i.Priority,
+		&i.Title,
+		&i.Description,
+		&i.AssigneeID,
+		&i.ReporterID,
+		&i.EpicID,
+		&i.ParentID,
+		&i.StoryPoints,
+		&i.DueDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Rank,
+	)
+	return i, err
+}
+
+const unflagStaleTickets = `-- name: UnflagStaleTickets :execrows
+-- Drops the flags of tickets that are no longer stale at $1: touched since
+-- they were flagged, done, deleted, or within a raised or disabled after_days
+DELETE FROM stale_tickets st
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM active_tickets t
+    JOIN ticket_stale_settings ss ON ss.project_id = t.project_id AND ss.after_days > 0
+    LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+    WHERE t.id = st.ticket_id
+      AND t.updated_at <= st.flagged_at
+      AND t.updated_at < $1::timestamptz - make_interval(days => ss.after_days)
+      AND COALESCE(bc.category <> 'done', true)
+)
+`
+
+// Drops the flags of tickets that are no longer stale at $1: touched since
+// they were flagged, done, deleted, or within a raised or disabled after_days
+func (q *Queries) UnflagStaleTickets(ctx context.Context, dollar_1 pgtype.Timestamptz) (int64, error) {
+	result, err := q.db.Exec(ctx, unflagStaleTickets, dollar_1)
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
-	defer rows.Close()
-	items := []ListTicketsPagedRow{}
-	for rows.Next() {
-		var i ListTicketsPagedRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.ProjectID,
-			&i.TicketNumber,
-			&i.Key,
-			&i.SprintID,
-			&i.BoardID,
-			&i.BoardColumnID,
-			&i.Type,
-			&i.Priority,
-			&i.Title,
-			&i.Description,
-			&i.AssigneeID,
-			&i.ReporterID,
-			&i.EpicID,
-			&i.ParentID,
-			&i.StoryPoints,
-			&i.DueDate,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.DeletedAt,
-			&i.TotalCount,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
-	}
-	return items, nil
+	return result.RowsAffected(), nil
 }
 
 const updateTicketBoard = `-- name: UpdateTicketBoard :one
 UPDATE tickets
-SET board_id = $2, board_column_id = $3, updated_at = NOW()
+SET board_id = $2,
+    rank = CASE WHEN board_column_id = $3 THEN rank ELSE $4::text END,
+    board_column_id = $3
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 `
 
 type UpdateTicketBoardParams struct {
 	ID            pgtype.UUID `db:"id" json:"id"`
 	BoardID       pgtype.UUID `db:"board_id" json:"board_id"`
 	BoardColumnID pgtype.UUID `db:"board_column_id" json:"board_column_id"`
+	Column4       string      `db:"column_4" json:"column_4"`
 }
 
+// Keeps the current rank when the ticket stays in the same column
 func (q *Queries) UpdateTicketBoard(ctx context.Context, arg UpdateTicketBoardParams) (Ticket, error) {
-	row := q.db.QueryRow(ctx, updateTicketBoard, arg.ID, arg.BoardID, arg.BoardColumnID)
+	row := q.db.QueryRow(ctx, updateTicketBoard,
+		arg.ID,
+		arg.BoardID,
+		arg.BoardColumnID,
+		arg.Column4,
+	)
 	var i Ticket
 	err := row.Scan(
 		&i.ID,
@@ -554,6 +1108,7 @@ func (q *Queries) UpdateTicketBoard(ctx context.Context, arg UpdateTicketBoardPa
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Rank,
 	)
 	return i, err
 }
@@ -566,23 +1121,26 @@ SET title = COALESCE($2, title),
     priority = COALESCE($5, priority),
     assignee_id = COALESCE($6, assignee_id),
     story_points = COALESCE($7, story_points),
-    due_date = COALESCE($8, due_date),
-    updated_at = NOW()
+    due_date = COALESCE($8, due_date)
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+  AND ($9::timestamptz IS NULL OR updated_at = $9::timestamptz)
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 `
 
 type UpdateTicketDetailsParams struct {
-	ID          pgtype.UUID    `db:"id" json:"id"`
-	Title       string         `db:"title" json:"title"`
-	Description pgtype.Text    `db:"description" json:"description"`
-	Type        TicketType     `db:"type" json:"type"`
-	Priority    TicketPriority `db:"priority" json:"priority"`
-	AssigneeID  pgtype.UUID    `db:"assignee_id" json:"assignee_id"`
-	StoryPoints pgtype.Int4    `db:"story_points" json:"story_points"`
-	DueDate     pgtype.Date    `db:"due_date" json:"due_date"`
+	ID          pgtype.UUID        `db:"id" json:"id"`
+	Title       string             `db:"title" json:"title"`
+	Description pgtype.Text        `db:"description" json:"description"`
+	Type        TicketType         `db:"type" json:"type"`
+	Priority    string             `db:"priority" json:"priority"`
+	AssigneeID  pgtype.UUID        `db:"assignee_id" json:"assignee_id"`
+	StoryPoints pgtype.Int4        `db:"story_points" json:"story_points"`
+	DueDate     pgtype.Date        `db:"due_date" json:"due_date"`
+	Column9     pgtype.Timestamptz `db:"column_9" json:"column_9"`
 }
 
+// A valid $9 only updates a ticket still at that updated_at, the base
+// version an offline client edited
 func (q *Queries) UpdateTicketDetails(ctx context.Context, arg UpdateTicketDetailsParams) (Ticket, error) {
 	row := q.db.QueryRow(ctx, updateTicketDetails,
 		arg.ID,
@@ -593,7 +1151,49 @@ func (q *Queries) UpdateTicketDetails(ctx context.Context, arg UpdateTicketDetai
 		arg.AssigneeID,
 		arg.StoryPoints,
 		arg.DueDate,
+		arg.Column9,
+	)
+	var i Ticket
+	err := row.Scan(
+		&i.ID,
+		&i.ProjectID,
+		&i.TicketNumber,
+		&i.Key,
+		&i.SprintID,
+		&i.BoardID,
+		&i.BoardColumnID,
+		&i.Type,
+		&i.Priority,
+		&i.Title,
+		&i.Description,
+		&i.AssigneeID,
+		&i.ReporterID,
+		&i.EpicID,
+		&i.ParentID,
+		&i.StoryPoints,
+		&i.DueDate,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.DeletedAt,
+		&i.Rank,
 	)
+	return i, err
+}
+
+const updateTicketRank = `-- name: UpdateTicketRank :one
+UPDATE tickets
+SET rank = $2
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
+`
+
+type UpdateTicketRankParams struct {
+	ID   pgtype.UUID `db:"id" json:"id"`
+	Rank pgtype.Text `db:"rank" json:"rank"`
+}
+
+func (q *Queries) UpdateTicketRank(ctx context.Context, arg UpdateTicketRankParams) (Ticket, error) {
+	row := q.db.QueryRow(ctx, updateTicketRank, arg.ID, arg.Rank)
 	var i Ticket
 	err := row.Scan(
 		&i.ID,
@@ -616,15 +1216,16 @@ func (q *Queries) UpdateTicketDetails(ctx context.Context, arg UpdateTicketDetai
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Rank,
 	)
 	return i, err
 }
 
 const updateTicketSprint = `-- name: UpdateTicketSprint :one
 UPDATE tickets
-SET sprint_id = $2, updated_at = NOW()
+SET sprint_id = $2
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 `
 
 type UpdateTicketSprintParams struct {
@@ -656,6 +1257,34 @@ func (q *Queries) UpdateTicketSprint(ctx context.Context, arg UpdateTicketSprint
 		&i.CreatedAt,
 		&i.UpdatedAt,
 		&i.DeletedAt,
+		&i.Rank,
+	)
+	return i, err
+}
+
+const upsertTicketStaleSettings = `-- name: UpsertTicketStaleSettings :one
+INSERT INTO ticket_stale_settings (project_id, after_days, updated_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (project_id) DO UPDATE
+SET after_days = EXCLUDED.after_days, updated_by = EXCLUDED.updated_by
+RETURNING project_id, after_days, updated_by, created_at, updated_at
+`
+
+type UpsertTicketStaleSettingsParams struct {
+	ProjectID pgtype.UUID `db:"project_id" json:"project_id"`
+	AfterDays int32       `db:"after_days" json:"after_days"`
+	UpdatedBy pgtype.UUID `db:"updated_by" json:"updated_by"`
+}
+
+func (q *Queries) UpsertTicketStaleSettings(ctx context.Context, arg UpsertTicketStaleSettingsParams) (TicketStaleSetting, error) {
+	row := q.db.QueryRow(ctx, upsertTicketStaleSettings, arg.ProjectID, arg.AfterDays, arg.UpdatedBy)
+	var i TicketStaleSetting
+	err := row.Scan(
+		&i.ProjectID,
+		&i.AfterDays,
+		&i.UpdatedBy,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
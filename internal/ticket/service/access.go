@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// authorizeTicket checks the caller's role in the project of a ticket,
+// deleted or not
+func (s *Service) authorizeTicket(ctx context.Context, id pgtype.UUID, role domain.ProjectRole) error {
+	projectID, err := s.Repo.GetTicketProject(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTicketNotFound
+		}
+		return fmt.Errorf("get ticket project: %w", err)
+	}
+	return s.authorizeTicketProject(ctx, projectID, role)
+}
+
+// authorizeTicketProject checks the caller's role in a ticket's project. A
+// ticket in a project the caller cannot see is reported as not found.
+func (s *Service) authorizeTicketProject(ctx context.Context, projectID pgtype.UUID, role domain.ProjectRole) error {
+	err := s.Project.AuthorizeProject(ctx, projectID, role)
+	var derr *domain.Error
+	if errors.As(err, &derr) && derr.Kind == domain.KindNotFound {
+		return ErrTicketNotFound
+	}
+	return err
+}
+
+// authorizeProjects checks the caller's role in each of the projects
+func (s *Service) authorizeProjects(ctx context.Context, projectIDs []pgtype.UUID, role domain.ProjectRole) error {
+	for _, projectID := range projectIDs {
+		if err := s.Project.AuthorizeProject(ctx, projectID, role); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// bulkDeleteSample is how many tickets a dry run lists next to its count
+const bulkDeleteSample = 10
+
+// BulkDeleteTickets soft deletes every ticket the same search would list.
+// A dry run runs that search instead and returns the count and a sample, so
+// clients can ask for confirmation before sending it again without DryRun.
+func (s *Service) BulkDeleteTickets(ctx context.Context, p domain.TicketBulkDeleteModel) (domain.TicketBulkDeleteResultModel, error) {
+	// a project is required like for the list, and keeps a bulk delete from
+	// spanning the whole instance
+	if len(p.ProjectID) == 0 {
+		return domain.TicketBulkDeleteResultModel{}, domain.Invalid("projectId is required")
+	}
+	if err := s.authorizeProjects(ctx, p.ProjectID, domain.ProjectRoleMember); err != nil {
+		return domain.TicketBulkDeleteResultModel{}, err
+	}
+
+	if p.DryRun {
+		page, err := s.ListTickets(ctx, domain.TicketSearchModel{
+			ID:         p.ID,
+			ProjectID:  p.ProjectID,
+			SprintID:   p.SprintID,
+			BoardID:    p.BoardID,
+			PageNumber: 1,
+			PageSize:   bulkDeleteSample,
+		})
+		if err != nil {
+			return domain.TicketBulkDeleteResultModel{}, err
+		}
+		return domain.TicketBulkDeleteResultModel{
+			DryRun: true,
+			Count:  int64(page.TotalCount),
+			Sample: page.Items,
+		}, nil
+	}
+
+	deleted, err := s.Repo.BulkDeleteTickets(ctx, repository.BulkDeleteTicketsParams{
+		ProjectIDs: p.ProjectID,
+		IDs:        p.ID,
+		SprintIDs:  p.SprintID,
+		BoardIDs:   p.BoardID,
+	})
+	if err != nil {
+		return domain.TicketBulkDeleteResultModel{}, fmt.Errorf("bulk delete tickets: %w", err)
+	}
+
+	// one event per project, so each project's activity log lists its own
+	// tickets
+	actorID, _ := httpx.UserIDFrom(ctx)
+	byProject := make(map[pgtype.UUID][]pgtype.UUID)
+	for _, row := range deleted {
+		byProject[row.ProjectID] = append(byProject[row.ProjectID], row.ID)
+	}
+	for projectID, ids := range byProject {
+		payload := httpx.EncodePayload(domain.BulkEventModel{
+			ProjectID: projectID,
+			ActorID:   actorID,
+			IDs:       ids,
+		})
+		if err := s.Bus.Publish(ctx, pubsub.TicketsBulkDeleted, payload); err != nil {
+			slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.TicketsBulkDeleted), "error", err)
+		}
+	}
+
+	return domain.TicketBulkDeleteResultModel{Count: int64(len(deleted))}, nil
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var ErrInvalidExportFormat = domain.Invalid("format must be md or txt").WithCode("invalid_export_format")
+
+// exportTimeLayout prints times in UTC so a pasted export reads the same for
+// everyone
+const exportTimeLayout = "2006-01-02 15:04 UTC"
+
+// ExportTicket renders a ticket with its details, subtasks as a checklist and
+// a summary of its change log, as Markdown by default or plain text
+func (s *Service) ExportTicket(ctx context.Context, id pgtype.UUID, format string) (domain.TicketExportModel, error) {
+	switch format {
+	case "":
+		format = domain.TicketExportMarkdown
+	case domain.TicketExportMarkdown, domain.TicketExportText:
+	default:
+		return domain.TicketExportModel{}, ErrInvalidExportFormat
+	}
+
+	t, err := s.GetTicket(ctx, id)
+	if err != nil {
+		return domain.TicketExportModel{}, err
+	}
+	names, err := s.Repo.GetTicketExportNames(ctx, id)
+	if err != nil {
+		return domain.TicketExportModel{}, fmt.Errorf("get ticket export names: %w", err)
+	}
+	subtasks, err := s.Repo.ListTicketSubtasks(ctx, id)
+	if err != nil {
+		return domain.TicketExportModel{}, fmt.Errorf("list ticket subtasks: %w", err)
+	}
+	changes, err := s.Repo.GetTicketChangeSummary(ctx, id)
+	if err != nil {
+		return domain.TicketExportModel{}, fmt.Errorf("get ticket change summary: %w", err)
+	}
+
+	d := exportDoc{markdown: format == domain.TicketExportMarkdown}
+	d.title(t.Key + ": " + t.Title)
+
+	status := names.ColumnName
+	if names.ColumnCategory != "" {
+		status += " (" + strings.ReplaceAll(names.ColumnCategory, "_", " ") + ")"
+	}
+	d.field("Project", names.ProjectName)
+	d.field("Type", t.Type)
+	d.field("Priority", t.Priority)
+	d.field("Status", status)
+	d.field("Sprint", names.SprintName)
+	d.field("Board", names.BoardName)
+	d.field("Assignee", names.AssigneeName)
+	d.field("Reporter", names.ReporterName)
+	d.field("Epic", names.EpicKey)
+	d.field("Parent", names.ParentKey)
+	if t.StoryPoints > 0 {
+		d.field("Story points", strconv.Itoa(int(t.StoryPoints)))
+	}
+	if !t.DueDate.IsZero() {
+		d.field("Due", t.DueDate.Format(time.DateOnly))
+	}
+
+	if desc := strings.TrimSpace(t.Description); desc != "" {
+		d.section("Description")
+		d.line(desc)
+	}
+
+	if len(subtasks) > 0 {
+		done := 0
+		for _, st := range subtasks {
+			if st.IsDone {
+				done++
+			}
+		}
+		d.section(fmt.Sprintf("Checklist (%d/%d done)", done, len(subtasks)))
+		for _, st := range subtasks {
+			d.check(st.IsDone, st.Key+" "+st.Title)
+		}
+	}
+
+	d.section("History")
+	d.line(historySummary(t, changes))
+
+	return domain.TicketExportModel{Key: t.Key, Format: format, Body: d.String()}, nil
+}
+
+// historySummary condenses the change log into one sentence, the log itself
+// only records that a write happened and not what it changed
+func historySummary(t domain.TicketModel, changes repository.GetTicketChangeSummaryRow) string {
+	last := t.UpdatedAt
+	if changes.LastChangedAt.Valid && changes.LastChangedAt.Time.After(last) {
+		last = changes.LastChangedAt.Time
+	}
+
+	summary := "Created " + t.CreatedAt.UTC().Format(exportTimeLayout)
+	switch changes.Updates {
+	case 0:
+		return summary + ", not changed since."
+	case 1:
+		summary += ", updated once"
+	default:
+		summary += ", updated " + strconv.FormatInt(changes.Updates, 10) + " times"
+	}
+	return summary + ", last on " + last.UTC().Format(exportTimeLayout) + "."
+}
+
+// exportDoc writes the same document as Markdown or as plain text
+type exportDoc struct {
+	strings.Builder
+	markdown bool
+}
+
+func (d *exportDoc) title(s string) {
+	if d.markdown {
+		d.WriteString("# " + s + "\n\n")
+		return
+	}
+	d.WriteString(s + "\n" + strings.Repeat("=", len([]rune(s))) + "\n\n")
+}
+
+// field writes a "label: value" detail, skipping empty values
+func (d *exportDoc) field(label, value string) {
+	if value == "" {
+		return
+	}
+	if d.markdown {
+		d.WriteString("- **" + label + ":** " + value + "\n")
+		return
+	}
+	d.WriteString(label + ": " + value + "\n")
+}
+
+func (d *exportDoc) section(s string) {
+	if d.Len() > 0 {
+		d.WriteString("\n")
+	}
+	if d.markdown {
+		d.WriteString("## " + s + "\n\n")
+		return
+	}
+	d.WriteString(s + "\n" + strings.Repeat("-", len([]rune(s))) + "\n")
+}
+
+func (d *exportDoc) check(done bool, s string) {
+	mark := " "
+	if done {
+		mark = "x"
+	}
+	if d.markdown {
+		d.WriteString("- [" + mark + "] " + s + "\n")
+		return
+	}
+	d.WriteString("[" + mark + "] " + s + "\n")
+}
+
+func (d *exportDoc) line(s string) {
+	d.WriteString(s + "\n")
+}
@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetProjectTicketGraph returns the project's tickets as nodes with their
+// subtask and epic links as edges. Links to tickets that are deleted or live
+// elsewhere are left out, so every edge joins two nodes of the graph.
+func (s *Service) GetProjectTicketGraph(ctx context.Context, projectID pgtype.UUID) (domain.TicketGraphModel, error) {
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.TicketGraphModel{}, err
+	}
+
+	rows, err := s.Repo.ListProjectTicketGraph(ctx, projectID)
+	if err != nil {
+		return domain.TicketGraphModel{}, fmt.Errorf("list project ticket graph: %w", err)
+	}
+
+	index := make(map[pgtype.UUID]int, len(rows))
+	for i, r := range rows {
+		index[r.ID] = i
+	}
+
+	g := domain.TicketGraphModel{
+		ProjectID: projectID,
+		Nodes:     make([]domain.TicketGraphNodeModel, len(rows)),
+		Edges:     []domain.TicketGraphEdgeModel{},
+	}
+	// sources holds the node indexes each node hangs under, for the depths
+	sources := make([][]int, len(rows))
+	for i, r := range rows {
+		g.Nodes[i] = domain.TicketGraphNodeModel{
+			ID:         r.ID,
+			Key:        r.Key,
+			Title:      r.Title,
+			Type:       string(r.Type),
+			Priority:   r.Priority,
+			Status:     r.Status,
+			AssigneeID: r.AssigneeID,
+		}
+		for _, link := range []struct {
+			id   pgtype.UUID
+			kind string
+		}{{r.ParentID, domain.TicketGraphEdgeSubtask}, {r.EpicID, domain.TicketGraphEdgeEpic}} {
+			j, ok := index[link.id]
+			if !link.id.Valid || !ok {
+				continue
+			}
+			g.Edges = append(g.Edges, domain.TicketGraphEdgeModel{Source: link.id, Target: r.ID, Kind: link.kind})
+			sources[i] = append(sources[i], j)
+		}
+	}
+
+	// depths[i] is -1 until known; walking marks the nodes on the current path
+	depths := make([]int, len(rows))
+	walking := make([]bool, len(rows))
+	for i := range depths {
+		depths[i] = -1
+	}
+	var depth func(i int) int
+	depth = func(i int) int {
+		if depths[i] >= 0 {
+			return depths[i]
+		}
+		if walking[i] {
+			// a cycle, which the links should never form; cut it here
+			return 0
+		}
+		walking[i] = true
+		d := 0
+		for _, j := range sources[i] {
+			d = max(d, depth(j)+1)
+		}
+		walking[i] = false
+		depths[i] = d
+		return d
+	}
+	for i := range g.Nodes {
+		g.Nodes[i].Depth = depth(i)
+	}
+
+	return g, nil
+}
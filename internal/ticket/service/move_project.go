@@ -0,0 +1,182 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrTicketSameProject  = domain.Invalid("ticket is already in this project").WithCode("ticket_same_project")
+	ErrNotProjectMember   = domain.Forbidden("moving a ticket requires membership of both projects").WithCode("not_a_member")
+	ErrForeignBoardColumn = domain.Invalid("board column does not belong to the destination project").WithCode("invalid_board_column")
+	ErrNoDefaultColumn    = domain.Invalid("board has no default column, pass boardColumnId").WithCode("no_default_column")
+)
+
+// placement is where a moved ticket lands, all invalid for the backlog
+type placement struct {
+	sprintID pgtype.UUID
+	boardID  pgtype.UUID
+	columnID pgtype.UUID
+}
+
+// MoveTicketToProject moves a ticket to another project of an organisation
+// the caller belongs to. The ticket gets a key of the new project and keeps
+// its content; links into the old project, such as its sprint, epic and
+// parent, are dropped, and so is an assignee outside the new project.
+func (s *Service) MoveTicketToProject(ctx context.Context, id pgtype.UUID, p domain.TicketProjectMoveModel) (domain.TicketModel, error) {
+	ticket, err := s.GetTicket(ctx, id)
+	if err != nil {
+		return domain.TicketModel{}, err
+	}
+	if ticket.ProjectID == p.ProjectID {
+		return domain.TicketModel{}, ErrTicketSameProject
+	}
+
+	// the caller names the destination, so a project they are not a member
+	// of is refused as such rather than hidden
+	for _, projectID := range []pgtype.UUID{ticket.ProjectID, p.ProjectID} {
+		err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleMember)
+		var derr *domain.Error
+		if errors.As(err, &derr) && derr.Kind == domain.KindNotFound {
+			return domain.TicketModel{}, ErrNotProjectMember
+		}
+		if err != nil {
+			return domain.TicketModel{}, err
+		}
+	}
+	if _, err := s.Project.GetProjectById(ctx, p.ProjectID); err != nil {
+		return domain.TicketModel{}, err
+	}
+
+	priority := ticket.Priority
+	if p.Priority != "" {
+		priority = p.Priority
+	}
+	if err := s.checkPriority(ctx, p.ProjectID, priority); err != nil {
+		return domain.TicketModel{}, err
+	}
+
+	place, err := s.resolvePlacement(ctx, p)
+	if err != nil {
+		return domain.TicketModel{}, err
+	}
+	if place.columnID.Valid {
+		column, err := s.Board.GetBoardColumn(ctx, place.columnID)
+		if err != nil {
+			return domain.TicketModel{}, fmt.Errorf("validate board column: %w", err)
+		}
+		if err := s.checkWIPLimit(ctx, column, id); err != nil {
+			return domain.TicketModel{}, err
+		}
+	}
+
+	key, err := s.Repo.GenerateTicketKey(ctx, p.ProjectID)
+	if err != nil {
+		return domain.TicketModel{}, fmt.Errorf("generate ticket key: %w", err)
+	}
+
+	write := func(r string) (repository.Ticket, error) {
+		return s.Repo.MoveTicketToProject(ctx, repository.MoveTicketToProjectParams{
+			ID:            id,
+			ProjectID:     p.ProjectID,
+			Key:           key,
+			Priority:      priority,
+			SprintID:      place.sprintID,
+			BoardID:       place.boardID,
+			BoardColumnID: place.columnID,
+			Column8:       r,
+		})
+	}
+	var moved repository.Ticket
+	if place.columnID.Valid {
+		moved, err = s.rankedWrite(ctx, place.columnID, s.lastSlot(ctx, place.columnID), write)
+	} else {
+		moved, err = write("")
+	}
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TicketModel{}, ErrTicketNotFound
+		}
+		if isUnknownPriority(err) {
+			return domain.TicketModel{}, ErrUnknownPriority
+		}
+		return domain.TicketModel{}, fmt.Errorf("move ticket to project: %w", err)
+	}
+
+	result := s.ticketToModel(moved)
+
+	// one event per project, so each project's activity log records the move
+	for _, projectID := range []pgtype.UUID{ticket.ProjectID, p.ProjectID} {
+		payload := httpx.EncodePayload(domain.TicketProjectMovedEventModel{
+			BulkEventModel: domain.BulkEventModel{
+				ProjectID: projectID,
+				ActorID:   httpx.MustUserID(ctx),
+				IDs:       []pgtype.UUID{id},
+			},
+			FromProjectID: ticket.ProjectID,
+			ToProjectID:   p.ProjectID,
+			FromKey:       ticket.Key,
+			Key:           result.Key,
+		})
+		if err := s.Bus.Publish(ctx, pubsub.TicketMovedToProject, payload); err != nil {
+			slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.TicketMovedToProject), "error", err)
+		}
+	}
+
+	return result, nil
+}
+
+// resolvePlacement checks that the requested column or board belongs to the
+// destination project, picking the board's default column when only the
+// board is given
+func (s *Service) resolvePlacement(ctx context.Context, p domain.TicketProjectMoveModel) (placement, error) {
+	if !p.BoardColumnID.Valid && !p.BoardID.Valid {
+		return placement{}, nil
+	}
+
+	columnID := p.BoardColumnID
+	boardID := p.BoardID
+	if columnID.Valid {
+		column, err := s.Board.GetBoardColumn(ctx, columnID)
+		if err != nil {
+			return placement{}, fmt.Errorf("validate board column: %w", err)
+		}
+		if boardID.Valid && column.BoardID != boardID {
+			return placement{}, domain.Invalid("board column does not belong to the board")
+		}
+		boardID = column.BoardID
+	}
+
+	board, err := s.Board.GetBoard(ctx, boardID)
+	if err != nil {
+		return placement{}, fmt.Errorf("validate board: %w", err)
+	}
+	sprint, err := s.Sprint.GetSprint(ctx, board.SprintID)
+	if err != nil {
+		return placement{}, fmt.Errorf("validate sprint: %w", err)
+	}
+	if sprint.ProjectID != p.ProjectID {
+		return placement{}, ErrForeignBoardColumn
+	}
+
+	if !columnID.Valid {
+		columnID, err = s.Repo.GetDefaultBoardColumn(ctx, board.ID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return placement{}, ErrNoDefaultColumn
+		}
+		if err != nil {
+			return placement{}, fmt.Errorf("get default board column: %w", err)
+		}
+	}
+
+	return placement{sprintID: sprint.ID, boardID: board.ID, columnID: columnID}, nil
+}
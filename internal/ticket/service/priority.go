@@ -0,0 +1,36 @@
+package service
+
+import (
+	"context"
+	"errors"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ticketPriorityConstraint is the tickets (project_id, priority) foreign key
+// from the project priority migration
+const ticketPriorityConstraint = "tickets_priority_fkey"
+
+var ErrUnknownPriority = domain.Invalid("priority is not defined for this project").WithCode("unknown_priority")
+
+// checkPriority verifies key is a level of the project's priority scheme
+func (s *Service) checkPriority(ctx context.Context, projectID pgtype.UUID, key string) error {
+	priorities, err := s.Project.ListProjectPriorities(ctx, projectID)
+	if err != nil {
+		return err
+	}
+	for _, p := range priorities {
+		if p.Key == key {
+			return nil
+		}
+	}
+	return ErrUnknownPriority
+}
+
+// isUnknownPriority catches a level removed between checkPriority and the write
+func isUnknownPriority(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23503" && pgErr.ConstraintName == ticketPriorityConstraint
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrTicketNotDeleted = domain.Conflict("ticket is not deleted").WithCode("not_deleted")
+	ErrProjectDeleted   = domain.Conflict("the ticket's project is deleted").WithCode("parent_deleted")
+)
+
+// RestoreTicket brings a soft-deleted ticket back. It returns to the end of
+// its old column when that column is still on a live board, otherwise to the
+// project's backlog.
+func (s *Service) RestoreTicket(ctx context.Context, id pgtype.UUID) (domain.TicketModel, error) {
+	if err := s.authorizeTicket(ctx, id, domain.ProjectRoleMember); err != nil {
+		return domain.TicketModel{}, err
+	}
+
+	state, err := s.ticketTrashState(ctx, id)
+	if err != nil {
+		return domain.TicketModel{}, err
+	}
+
+	write := func(r string) (repository.Ticket, error) {
+		return s.Repo.RestoreTicket(ctx, repository.RestoreTicketParams{
+			ID:            id,
+			BoardColumnID: state.LiveColumnID,
+			Column3:       r,
+		})
+	}
+	var restored repository.Ticket
+	if state.LiveColumnID.Valid {
+		restored, err = s.rankedWrite(ctx, state.LiveColumnID, s.lastSlot(ctx, state.LiveColumnID), write)
+	} else {
+		restored, err = write("")
+	}
+	if err != nil {
+		// restored or its project deleted since the check
+		if errors.Is(err, pgx.ErrNoRows) {
+			if _, err := s.ticketTrashState(ctx, id); err != nil {
+				return domain.TicketModel{}, err
+			}
+			return domain.TicketModel{}, ErrTicketNotDeleted
+		}
+		return domain.TicketModel{}, fmt.Errorf("restore ticket: %w", err)
+	}
+
+	result := s.ticketToModel(restored)
+	if err := s.Bus.Publish(ctx, pubsub.TicketRestored, httpx.EncodePayload(result)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.TicketRestored), "error", err)
+	}
+	return result, nil
+}
+
+// ticketTrashState fails with the reason a ticket cannot be restored
+func (s *Service) ticketTrashState(ctx context.Context, id pgtype.UUID) (repository.GetTicketTrashStateRow, error) {
+	state, err := s.Repo.GetTicketTrashState(ctx, id)
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+		return state, ErrTicketNotFound
+	case err != nil:
+		return state, fmt.Errorf("get ticket trash state: %w", err)
+	case !state.Deleted:
+		return state, ErrTicketNotDeleted
+	case state.ProjectDeleted:
+		return state, ErrProjectDeleted
+	}
+	return state, nil
+}
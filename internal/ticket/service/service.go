@@ -2,7 +2,9 @@ package service
 
 import (
 	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/contentfilter"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/lease"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 )
 
@@ -12,6 +14,22 @@ type Deps struct {
 	Board   domain.BoardReader
 	Sprint  domain.SprintReader
 	Bus     pubsub.Publisher
+	Config  *Config
+	Filter  contentfilter.Filter
+	// Locks holds the advisory edit locks, in memory only
+	Locks *lease.Table
+}
+
+// What a move into a board column already at its WIP limit does
+const (
+	WIPLimitWarn   = "warn"   // log it and let the move through
+	WIPLimitReject = "reject" // refuse the move
+)
+
+type Config struct {
+	MaxDescriptionLength int // characters, zero or less disables the limit
+	// WIPLimit is WIPLimitWarn or WIPLimitReject, empty warns
+	WIPLimit string
 }
 
 type Service struct {
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// GetTicketStaleSettings returns the project's stale settings, nudges off
+// when they were never changed
+func (s *Service) GetTicketStaleSettings(ctx context.Context, projectID pgtype.UUID) (domain.TicketStaleSettingsModel, error) {
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.TicketStaleSettingsModel{}, err
+	}
+
+	row, err := s.Repo.GetTicketStaleSettings(ctx, projectID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return domain.TicketStaleSettingsModel{ProjectID: projectID}, nil
+	}
+	if err != nil {
+		return domain.TicketStaleSettingsModel{}, fmt.Errorf("get ticket stale settings: %w", err)
+	}
+	return toStaleSettingsModel(row), nil
+}
+
+// UpdateTicketStaleSettings replaces the project's stale settings. The next
+// sweep flags or unflags tickets by the new value.
+func (s *Service) UpdateTicketStaleSettings(ctx context.Context, projectID pgtype.UUID, p domain.TicketStaleSettingsUpdateModel) (domain.TicketStaleSettingsModel, error) {
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.TicketStaleSettingsModel{}, err
+	}
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleAdmin); err != nil {
+		return domain.TicketStaleSettingsModel{}, err
+	}
+
+	row, err := s.Repo.UpsertTicketStaleSettings(ctx, repository.UpsertTicketStaleSettingsParams{
+		ProjectID: projectID,
+		AfterDays: p.AfterDays,
+		UpdatedBy: httpx.MustUserID(ctx),
+	})
+	if err != nil {
+		return domain.TicketStaleSettingsModel{}, fmt.Errorf("update ticket stale settings: %w", err)
+	}
+	return toStaleSettingsModel(row), nil
+}
+
+// SweepStaleTickets flags the tickets that went without activity for their
+// project's stale days as of at, and publishes one event per project listing
+// those flagged for the first time. Flags of tickets that were touched,
+// finished or whose project raised its days are dropped first, so such a
+// ticket is nudged again once it goes stale again. Returns how many tickets
+// were newly flagged.
+func (s *Service) SweepStaleTickets(ctx context.Context, at time.Time) (int, error) {
+	now := pgtype.Timestamptz{Time: at, Valid: true}
+	if _, err := s.Repo.UnflagStaleTickets(ctx, now); err != nil {
+		return 0, fmt.Errorf("unflag stale tickets: %w", err)
+	}
+
+	flagged, err := s.Repo.FlagStaleTickets(ctx, now)
+	if err != nil {
+		return 0, fmt.Errorf("flag stale tickets: %w", err)
+	}
+
+	byProject := make(map[pgtype.UUID][]pgtype.UUID)
+	for _, row := range flagged {
+		byProject[row.ProjectID] = append(byProject[row.ProjectID], row.TicketID)
+	}
+	for projectID, ids := range byProject {
+		payload := httpx.EncodePayload(domain.BulkEventModel{
+			ProjectID: projectID,
+			IDs:       ids,
+		})
+		if err := s.Bus.Publish(ctx, pubsub.TicketsStale, payload); err != nil {
+			slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.TicketsStale), "error", err)
+		}
+	}
+
+	return len(flagged), nil
+}
+
+// StartStaleSweeper sweeps right away and then on every tick until ctx ends
+func (s *Service) StartStaleSweeper(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.SweepStaleTickets(ctx, time.Now()); err != nil {
+			slog.Warn("[TicketModule]: stale ticket sweep failed", "error", err)
+		} else if n > 0 {
+			slog.Info("[TicketModule]: flagged stale tickets", "count", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func toStaleSettingsModel(row repository.TicketStaleSetting) domain.TicketStaleSettingsModel {
+	m := domain.TicketStaleSettingsModel{
+		ProjectID: row.ProjectID,
+		AfterDays: row.AfterDays,
+		UpdatedBy: row.UpdatedBy,
+	}
+	if row.UpdatedAt.Valid {
+		m.UpdatedAt = &row.UpdatedAt.Time
+	}
+	return m
+}
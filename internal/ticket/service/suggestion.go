@@ -0,0 +1,70 @@
+package service
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Weights of the assignment score. Done counts are capped so a long history
+// does not outweigh a full plate.
+const (
+	suggestSameTypeCap    = 10
+	suggestSameEpicCap    = 5
+	suggestSameEpicWeight = 2
+	suggestOpenWeight     = 2
+)
+
+// SuggestAssignees ranks the members of the project as assignees of one of
+// its tickets. Fewer open tickets and more done tickets of the same type or
+// epic rank a member higher; ties go to fewer open story points, then to the
+// name.
+func (s *Service) SuggestAssignees(ctx context.Context, projectID, ticketID pgtype.UUID) (domain.TicketAssignmentSuggestionsModel, error) {
+	if _, err := s.Project.GetProjectById(ctx, projectID); err != nil {
+		return domain.TicketAssignmentSuggestionsModel{}, err
+	}
+	t, err := s.GetTicket(ctx, ticketID)
+	if err != nil {
+		return domain.TicketAssignmentSuggestionsModel{}, err
+	}
+	if t.ProjectID != projectID {
+		return domain.TicketAssignmentSuggestionsModel{}, ErrTicketNotFound
+	}
+
+	rows, err := s.Repo.ListAssignmentCandidates(ctx, repository.ListAssignmentCandidatesParams{
+		ID:     projectID,
+		Type:   repository.TicketType(t.Type),
+		EpicID: t.EpicID,
+	})
+	if err != nil {
+		return domain.TicketAssignmentSuggestionsModel{}, fmt.Errorf("list assignment candidates: %w", err)
+	}
+
+	items := make([]domain.TicketAssignmentSuggestionModel, len(rows))
+	for i, r := range rows {
+		items[i] = domain.TicketAssignmentSuggestionModel{
+			UserID:       r.UserID,
+			DisplayName:  r.DisplayName,
+			OpenTickets:  r.OpenTickets,
+			OpenPoints:   r.OpenPoints,
+			DoneSameType: r.DoneSameType,
+			DoneSameEpic: r.DoneSameEpic,
+			Score: min(r.DoneSameType, suggestSameTypeCap) +
+				suggestSameEpicWeight*min(r.DoneSameEpic, suggestSameEpicCap) -
+				suggestOpenWeight*r.OpenTickets,
+		}
+	}
+	slices.SortStableFunc(items, func(a, b domain.TicketAssignmentSuggestionModel) int {
+		return cmp.Or(
+			cmp.Compare(b.Score, a.Score),
+			cmp.Compare(a.OpenPoints, b.OpenPoints),
+		)
+	})
+
+	return domain.TicketAssignmentSuggestionsModel{TicketID: ticketID, Items: items}, nil
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+var (
+	ErrMutationMissingCreate = domain.Invalid("create mutations need projectId and create").WithCode("invalid_mutation")
+	ErrMutationMissingUpdate = domain.Invalid("update mutations need id, baseVersion and update").WithCode("invalid_mutation")
+	ErrMutationMissingDelete = domain.Invalid("delete mutations need id and baseVersion").WithCode("invalid_mutation")
+)
+
+// SyncTickets applies queued offline mutations one by one in the order sent.
+// Each is independent: a conflict or rejection is reported and the rest still
+// run, so a batch never has to be resent because of one stale item.
+func (s *Service) SyncTickets(ctx context.Context, p domain.TicketSyncModel) (domain.TicketSyncResultModel, error) {
+	results := make([]domain.TicketMutationResultModel, len(p.Mutations))
+	for i, m := range p.Mutations {
+		if err := ctx.Err(); err != nil {
+			return domain.TicketSyncResultModel{}, err
+		}
+		results[i] = s.applyMutation(ctx, m)
+	}
+	return domain.TicketSyncResultModel{Results: results}, nil
+}
+
+func (s *Service) applyMutation(ctx context.Context, m domain.TicketMutationModel) domain.TicketMutationResultModel {
+	result := domain.TicketMutationResultModel{ClientID: m.ClientID}
+	base := pgtype.Timestamptz{Time: m.BaseVersion, Valid: !m.BaseVersion.IsZero()}
+
+	var (
+		ticket domain.TicketModel
+		err    error
+	)
+	switch m.Op {
+	case domain.TicketMutationCreate:
+		if !m.ProjectID.Valid || m.Create == nil {
+			err = ErrMutationMissingCreate
+			break
+		}
+		ticket, err = s.CreateTicket(ctx, m.ProjectID, *m.Create)
+	case domain.TicketMutationUpdate:
+		if !m.ID.Valid || !base.Valid || m.Update == nil {
+			err = ErrMutationMissingUpdate
+			break
+		}
+		ticket, err = s.updateTicket(ctx, m.ID, *m.Update, base)
+	case domain.TicketMutationDelete:
+		if !m.ID.Valid || !base.Valid {
+			err = ErrMutationMissingDelete
+			break
+		}
+		err = s.deleteTicket(ctx, m.ID, base)
+	}
+
+	if err == nil {
+		result.Status = domain.SyncApplied
+		if m.Op != domain.TicketMutationDelete {
+			result.Ticket = &ticket
+		}
+		return result
+	}
+
+	var derr *domain.Error
+	if !errors.As(err, &derr) {
+		slog.Error("[TicketSync]: failed to apply mutation", "clientId", m.ClientID, "op", m.Op, "error", err)
+		result.Status = domain.SyncFailed
+		result.Message = "internal server error"
+		return result
+	}
+
+	result.Code = derr.Code
+	result.Message = derr.Message
+	result.Status = domain.SyncRejected
+	if derr.Kind == domain.KindLocked {
+		// the lock runs out, the same mutation can be sent again later
+		result.Status = domain.SyncFailed
+	}
+	if errors.Is(err, ErrTicketVersionConflict) {
+		result.Status = domain.SyncConflict
+		if current, err := s.GetTicket(ctx, m.ID); err == nil {
+			result.Ticket = &current
+		}
+	}
+	return result
+}
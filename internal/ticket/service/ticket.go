@@ -9,14 +9,22 @@ import (
 	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 	"github.com/dimasbaguspm/fluxis/pkg/syncx"
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
-	ErrTicketNotFound = httpx.NotFound("ticket not found")
+	ErrTicketNotFound        = domain.NotFound("ticket not found")
+	ErrTicketVersionConflict = domain.Conflict("ticket was changed since the base version").WithCode("version_conflict")
+	ErrAdminOnly             = domain.Forbidden("includeDeleted requires admin access").WithCode("insufficient_scope")
+	ErrInvalidStatusCategory = domain.Invalid("statusCategory must be todo, in_progress or done").WithCode("invalid_status_category")
+	ErrInvalidStatus         = domain.Invalid("status must contain a letter or digit").WithCode("invalid_status")
+	ErrWIPLimitReached       = domain.Unprocessable("the board column is at its WIP limit").WithCode("wip_limit_reached")
 )
 
 func (s *Service) ListTickets(ctx context.Context, q domain.TicketSearchModel) (domain.TicketsPagedModel, error) {
@@ -24,68 +32,75 @@ func (s *Service) ListTickets(ctx context.Context, q domain.TicketSearchModel) (
 
 	// Require at least projectId for listing
 	if len(q.ProjectID) == 0 {
-		return domain.TicketsPagedModel{}, httpx.BadRequest("projectId is required")
+		return domain.TicketsPagedModel{}, domain.Invalid("projectId is required")
+	}
+
+	if q.IncludeDeleted && !domain.HasScopes(httpx.ScopesFrom(ctx), domain.ScopeAdmin) {
+		return domain.TicketsPagedModel{}, ErrAdminOnly
+	}
+
+	for _, c := range q.StatusCategory {
+		if c != "todo" && c != "in_progress" && c != "done" {
+			return domain.TicketsPagedModel{}, ErrInvalidStatusCategory
+		}
+	}
+	if err := s.authorizeProjects(ctx, q.ProjectID, domain.ProjectRoleViewer); err != nil {
+		return domain.TicketsPagedModel{}, err
+	}
+	statusColumns, err := s.statusColumns(ctx, q.ProjectID, q.Status)
+	if err != nil {
+		return domain.TicketsPagedModel{}, err
 	}
 
-	offset := int32((q.PageNumber - 1) * q.PageSize)
 	rows, err := s.Repo.ListTicketsPaged(ctx, repository.ListTicketsPagedParams{
-		Column1: q.ProjectID,
-		Column2: q.ID,
-		Column3: q.SprintID,
-		Column4: q.BoardID,
-		Limit:   int32(q.PageSize),
-		Offset:  offset,
+		ProjectIDs:      q.ProjectID,
+		IDs:             q.ID,
+		SprintIDs:       q.SprintID,
+		BoardIDs:        q.BoardID,
+		BoardColumnIDs:  q.BoardColumnID,
+		StatusColumnIDs: statusColumns,
+		ByStatus:        len(q.Status) > 0,
+		Categories:      q.StatusCategory,
+		IncludeDeleted:  q.IncludeDeleted,
+		Stale:           q.Stale,
+		Limit:           int32(q.PageSize),
+		Offset:          pagination.Offset(q.PageNumber, q.PageSize),
 	})
 
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.TicketsPagedModel{}.Empty(q.PageNumber, q.PageSize), nil
-		}
 		return domain.TicketsPagedModel{}, fmt.Errorf("list tickets: %w", err)
 	}
 
-	if len(rows) == 0 {
-		return domain.TicketsPagedModel{}.Empty(q.PageNumber, q.PageSize), nil
-	}
-
-	totalCount := int(rows[0].TotalCount)
-	items := make([]domain.TicketModel, len(rows))
-	for i, row := range rows {
-		items[i] = domain.TicketModel{
-			ID:            row.ID,
-			ProjectID:     row.ProjectID,
-			TicketNumber:  row.TicketNumber,
-			Key:           row.Key,
-			Type:          string(row.Type),
-			Priority:      string(row.Priority),
-			Title:         row.Title,
-			Description:   row.Description.String,
-			SprintID:      row.SprintID,
-			BoardID:       row.BoardID,
-			BoardColumnID: row.BoardColumnID,
-			AssigneeID:    row.AssigneeID,
-			ReporterID:    row.ReporterID,
-			EpicID:        row.EpicID,
-			ParentID:      row.ParentID,
-			StoryPoints:   row.StoryPoints.Int32,
-			DueDate:       row.DueDate.Time,
-			CreatedAt:     row.CreatedAt.Time,
-			UpdatedAt:     row.UpdatedAt.Time,
-		}
-	}
-
-	totalPages := (totalCount + q.PageSize - 1) / q.PageSize
-	if totalPages == 0 {
-		totalPages = 1
-	}
+	page := pagination.FromRows(rows,
+		func(row repository.ListTicketsPagedRow) int64 { return row.TotalCount },
+		func(row repository.ListTicketsPagedRow) domain.TicketModel {
+			return domain.TicketModel{
+				ID:            row.ID,
+				ProjectID:     row.ProjectID,
+				TicketNumber:  row.TicketNumber,
+				Key:           row.Key,
+				Type:          string(row.Type),
+				Priority:      row.Priority,
+				Title:         row.Title,
+				Description:   row.Description.String,
+				SprintID:      row.SprintID,
+				BoardID:       row.BoardID,
+				BoardColumnID: row.BoardColumnID,
+				AssigneeID:    row.AssigneeID,
+				ReporterID:    row.ReporterID,
+				EpicID:        row.EpicID,
+				ParentID:      row.ParentID,
+				StoryPoints:   row.StoryPoints.Int32,
+				DueDate:       row.DueDate.Time,
+				Rank:          row.Rank.String,
+				CreatedAt:     row.CreatedAt.Time,
+				UpdatedAt:     row.UpdatedAt.Time,
+				DeletedAt:     transformer.TimePtr(row.DeletedAt),
+			}
+		},
+		q.PageNumber, q.PageSize)
 
-	return domain.TicketsPagedModel{
-		Items:      items,
-		TotalCount: totalCount,
-		TotalPages: totalPages,
-		PageNumber: q.PageNumber,
-		PageSize:   q.PageSize,
-	}, nil
+	return domain.TicketsPagedModel(page), nil
 }
 
 func (s *Service) GetTicket(ctx context.Context, id pgtype.UUID) (domain.TicketModel, error) {
@@ -96,11 +111,18 @@ func (s *Service) GetTicket(ctx context.Context, id pgtype.UUID) (domain.TicketM
 		}
 		return domain.TicketModel{}, fmt.Errorf("get ticket: %w", err)
 	}
+	if err := s.authorizeTicketProject(ctx, ticket.ProjectID, domain.ProjectRoleViewer); err != nil {
+		return domain.TicketModel{}, err
+	}
 
 	return s.ticketToModel(ticket), nil
 }
 
 func (s *Service) GetTicketByKey(ctx context.Context, projectID pgtype.UUID, key string) (domain.TicketModel, error) {
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleViewer); err != nil {
+		return domain.TicketModel{}, err
+	}
+
 	ticket, err := s.Repo.GetTicketByKey(ctx, repository.GetTicketByKeyParams{
 		ProjectID: projectID,
 		Key:       key,
@@ -123,6 +145,19 @@ func (s *Service) CreateTicket(ctx context.Context, projectID pgtype.UUID, p dom
 	if err != nil {
 		return domain.TicketModel{}, err
 	}
+	if err := s.Project.AuthorizeProject(ctx, projectID, domain.ProjectRoleMember); err != nil {
+		return domain.TicketModel{}, err
+	}
+
+	if err := s.checkPriority(ctx, projectID, p.Priority); err != nil {
+		return domain.TicketModel{}, err
+	}
+	if p.Title, p.Description, err = s.screenText(p.Title, p.Description); err != nil {
+		return domain.TicketModel{}, err
+	}
+	if err := s.checkDescription(p.Description); err != nil {
+		return domain.TicketModel{}, err
+	}
 
 	// Generate ticket key
 	key, err := s.Repo.GenerateTicketKey(ctx, projectID)
@@ -146,10 +181,11 @@ func (s *Service) CreateTicket(ctx context.Context, projectID pgtype.UUID, p dom
 	}
 
 	ticket, err := s.Repo.CreateTicket(ctx, repository.CreateTicketParams{
+		ID:          idgen.New(),
 		ProjectID:   projectID,
 		Key:         key,
 		Type:        repository.TicketType(p.Type),
-		Priority:    repository.TicketPriority(p.Priority),
+		Priority:    p.Priority,
 		Title:       p.Title,
 		Description: pgtype.Text{String: p.Description, Valid: p.Description != ""},
 		ReporterID:  userID,
@@ -158,6 +194,9 @@ func (s *Service) CreateTicket(ctx context.Context, projectID pgtype.UUID, p dom
 		DueDate:     dueDate,
 	})
 	if err != nil {
+		if isUnknownPriority(err) {
+			return domain.TicketModel{}, ErrUnknownPriority
+		}
 		return domain.TicketModel{}, fmt.Errorf("create ticket: %w", err)
 	}
 
@@ -170,6 +209,13 @@ func (s *Service) CreateTicket(ctx context.Context, projectID pgtype.UUID, p dom
 }
 
 func (s *Service) UpdateTicket(ctx context.Context, id pgtype.UUID, p domain.TicketUpdateModel) (domain.TicketModel, error) {
+	return s.updateTicket(ctx, id, p, pgtype.Timestamptz{})
+}
+
+// updateTicket only writes while the ticket is still at base when base is
+// valid; the check is repeated in the UPDATE so a concurrent write between
+// the read and the write is caught too
+func (s *Service) updateTicket(ctx context.Context, id pgtype.UUID, p domain.TicketUpdateModel, base pgtype.Timestamptz) (domain.TicketModel, error) {
 	// Fetch current ticket to preserve values for optional fields
 	currentTicket, err := s.Repo.GetTicket(ctx, id)
 	if err != nil {
@@ -178,6 +224,21 @@ func (s *Service) UpdateTicket(ctx context.Context, id pgtype.UUID, p domain.Tic
 		}
 		return domain.TicketModel{}, fmt.Errorf("get ticket: %w", err)
 	}
+	if err := s.authorizeTicketProject(ctx, currentTicket.ProjectID, domain.ProjectRoleMember); err != nil {
+		return domain.TicketModel{}, err
+	}
+	if base.Valid && !currentTicket.UpdatedAt.Time.Equal(base.Time) {
+		return domain.TicketModel{}, ErrTicketVersionConflict
+	}
+	if err := s.checkLock(ctx, id); err != nil {
+		return domain.TicketModel{}, err
+	}
+	if p.Title, p.Description, err = s.screenText(p.Title, p.Description); err != nil {
+		return domain.TicketModel{}, err
+	}
+	if err := s.checkDescription(p.Description); err != nil {
+		return domain.TicketModel{}, err
+	}
 
 	// Convert DueDate to pgtype.Date if provided
 	var dueDate pgtype.Date
@@ -202,7 +263,9 @@ func (s *Service) UpdateTicket(ctx context.Context, id pgtype.UUID, p domain.Tic
 
 	priority := p.Priority
 	if priority == "" {
-		priority = string(currentTicket.Priority)
+		priority = currentTicket.Priority
+	} else if err := s.checkPriority(ctx, currentTicket.ProjectID, priority); err != nil {
+		return domain.TicketModel{}, err
 	}
 
 	ticket, err := s.Repo.UpdateTicketDetails(ctx, repository.UpdateTicketDetailsParams{
@@ -210,14 +273,18 @@ func (s *Service) UpdateTicket(ctx context.Context, id pgtype.UUID, p domain.Tic
 		Title:       p.Title,
 		Description: pgtype.Text{String: p.Description, Valid: p.Description != ""},
 		Type:        repository.TicketType(ticketType),
-		Priority:    repository.TicketPriority(priority),
+		Priority:    priority,
 		AssigneeID:  assigneeID,
 		StoryPoints: pgtype.Int4{Int32: p.StoryPoints, Valid: p.StoryPoints > 0},
 		DueDate:     dueDate,
+		Column9:     base,
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return domain.TicketModel{}, ErrTicketNotFound
+			return domain.TicketModel{}, s.missedWrite(ctx, id, base)
+		}
+		if isUnknownPriority(err) {
+			return domain.TicketModel{}, ErrUnknownPriority
 		}
 		return domain.TicketModel{}, fmt.Errorf("update ticket: %w", err)
 	}
@@ -231,6 +298,10 @@ func (s *Service) UpdateTicket(ctx context.Context, id pgtype.UUID, p domain.Tic
 }
 
 func (s *Service) MoveTicketToBoard(ctx context.Context, id pgtype.UUID, p domain.TicketBoardMoveModel) (domain.TicketModel, error) {
+	if err := s.authorizeTicket(ctx, id, domain.ProjectRoleMember); err != nil {
+		return domain.TicketModel{}, err
+	}
+
 	var board domain.BoardModel
 	var boardColumn domain.BoardColumnModel
 
@@ -257,13 +328,19 @@ func (s *Service) MoveTicketToBoard(ctx context.Context, id pgtype.UUID, p domai
 	}
 
 	if boardColumn.BoardID != board.ID {
-		return domain.TicketModel{}, httpx.BadRequest("board column does not belong to the board")
+		return domain.TicketModel{}, domain.Invalid("board column does not belong to the board")
+	}
+	if err := s.checkWIPLimit(ctx, boardColumn, id); err != nil {
+		return domain.TicketModel{}, err
 	}
 
-	ticket, err := s.Repo.UpdateTicketBoard(ctx, repository.UpdateTicketBoardParams{
-		ID:            id,
-		BoardID:       board.ID,
-		BoardColumnID: boardColumn.ID,
+	ticket, err := s.rankedWrite(ctx, boardColumn.ID, s.lastSlot(ctx, boardColumn.ID), func(r string) (repository.Ticket, error) {
+		return s.Repo.UpdateTicketBoard(ctx, repository.UpdateTicketBoardParams{
+			ID:            id,
+			BoardID:       board.ID,
+			BoardColumnID: boardColumn.ID,
+			Column4:       r,
+		})
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -281,6 +358,10 @@ func (s *Service) MoveTicketToBoard(ctx context.Context, id pgtype.UUID, p domai
 }
 
 func (s *Service) MoveTicketToSprint(ctx context.Context, id pgtype.UUID, sprintID pgtype.UUID) (domain.TicketModel, error) {
+	if err := s.authorizeTicket(ctx, id, domain.ProjectRoleMember); err != nil {
+		return domain.TicketModel{}, err
+	}
+
 	// Validate sprint exists
 	if _, err := s.Sprint.GetSprint(ctx, sprintID); err != nil {
 		return domain.TicketModel{}, fmt.Errorf("validate sprint: %w", err)
@@ -306,6 +387,10 @@ func (s *Service) MoveTicketToSprint(ctx context.Context, id pgtype.UUID, sprint
 }
 
 func (s *Service) MoveTicketToBoardColumn(ctx context.Context, id pgtype.UUID, p domain.TicketBoardMoveModel) (domain.TicketModel, error) {
+	if err := s.authorizeTicket(ctx, id, domain.ProjectRoleMember); err != nil {
+		return domain.TicketModel{}, err
+	}
+
 	var board domain.BoardModel
 	var boardColumn domain.BoardColumnModel
 
@@ -332,13 +417,19 @@ func (s *Service) MoveTicketToBoardColumn(ctx context.Context, id pgtype.UUID, p
 	}
 
 	if boardColumn.BoardID != board.ID {
-		return domain.TicketModel{}, httpx.BadRequest("board column does not belong to the board")
+		return domain.TicketModel{}, domain.Invalid("board column does not belong to the board")
+	}
+	if err := s.checkWIPLimit(ctx, boardColumn, id); err != nil {
+		return domain.TicketModel{}, err
 	}
 
-	ticket, err := s.Repo.UpdateTicketBoard(ctx, repository.UpdateTicketBoardParams{
-		ID:            id,
-		BoardID:       board.ID,
-		BoardColumnID: boardColumn.ID,
+	ticket, err := s.rankedWrite(ctx, boardColumn.ID, s.lastSlot(ctx, boardColumn.ID), func(r string) (repository.Ticket, error) {
+		return s.Repo.UpdateTicketBoard(ctx, repository.UpdateTicketBoardParams{
+			ID:            id,
+			BoardID:       board.ID,
+			BoardColumnID: boardColumn.ID,
+			Column4:       r,
+		})
 	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
@@ -356,10 +447,21 @@ func (s *Service) MoveTicketToBoardColumn(ctx context.Context, id pgtype.UUID, p
 }
 
 func (s *Service) DeleteTicket(ctx context.Context, id pgtype.UUID) error {
-	_, err := s.Repo.DeleteTicket(ctx, id)
+	return s.deleteTicket(ctx, id, pgtype.Timestamptz{})
+}
+
+func (s *Service) deleteTicket(ctx context.Context, id pgtype.UUID, base pgtype.Timestamptz) error {
+	if err := s.authorizeTicket(ctx, id, domain.ProjectRoleMember); err != nil {
+		return err
+	}
+
+	_, err := s.Repo.DeleteTicket(ctx, repository.DeleteTicketParams{
+		ID:      id,
+		Column2: base,
+	})
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			return ErrTicketNotFound
+			return s.missedWrite(ctx, id, base)
 		}
 		return fmt.Errorf("delete ticket: %w", err)
 	}
@@ -371,6 +473,75 @@ func (s *Service) DeleteTicket(ctx context.Context, id pgtype.UUID) error {
 	return nil
 }
 
+// screenText runs the title and description through the instance's content
+// filter; an empty value is left alone since updates use it for "unchanged"
+func (s *Service) screenText(title, description string) (string, string, error) {
+	if s.Filter == nil {
+		return title, description, nil
+	}
+	var err error
+	if title != "" {
+		if title, err = s.Filter.Filter("title", title); err != nil {
+			return "", "", err
+		}
+	}
+	if description != "" {
+		if description, err = s.Filter.Filter("description", description); err != nil {
+			return "", "", err
+		}
+	}
+	return title, description, nil
+}
+
+// checkDescription keeps megabyte pastes out of the ticket row, which every
+// ticket list and board read carries in full
+func (s *Service) checkDescription(description string) error {
+	if s.Config == nil {
+		return nil
+	}
+	return domain.ValidateLength("description", description, s.Config.MaxDescriptionLength)
+}
+
+// checkWIPLimit holds a ticket about to enter column to the column's WIP
+// limit. Counting and moving are separate statements, so two racing moves
+// may both land; the limit is a team agreement rather than an invariant.
+func (s *Service) checkWIPLimit(ctx context.Context, column domain.BoardColumnModel, ticketID pgtype.UUID) error {
+	if column.WIPLimit == nil {
+		return nil
+	}
+	count, err := s.Repo.CountColumnTickets(ctx, repository.CountColumnTicketsParams{
+		BoardColumnID: column.ID,
+		ID:            ticketID,
+	})
+	if err != nil {
+		return fmt.Errorf("count column tickets: %w", err)
+	}
+	if count < int64(*column.WIPLimit) {
+		return nil
+	}
+	if s.Config != nil && s.Config.WIPLimit == WIPLimitReject {
+		return ErrWIPLimitReached
+	}
+	slog.Warn("[TicketModule]: ticket moved into a column at its WIP limit",
+		"column", transformer.UUIDString(column.ID), "limit", *column.WIPLimit, "tickets", count+1)
+	return nil
+}
+
+// missedWrite explains a conditional write that matched no row: without a
+// base the ticket is gone, with one it may just have moved on
+func (s *Service) missedWrite(ctx context.Context, id pgtype.UUID, base pgtype.Timestamptz) error {
+	if !base.Valid {
+		return ErrTicketNotFound
+	}
+	if _, err := s.Repo.GetTicket(ctx, id); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrTicketNotFound
+		}
+		return fmt.Errorf("get ticket: %w", err)
+	}
+	return ErrTicketVersionConflict
+}
+
 // Helper function to convert repository model to domain model
 func (s *Service) ticketToModel(t repository.Ticket) domain.TicketModel {
 	return domain.TicketModel{
@@ -379,7 +550,7 @@ func (s *Service) ticketToModel(t repository.Ticket) domain.TicketModel {
 		TicketNumber:  t.TicketNumber,
 		Key:           t.Key,
 		Type:          string(t.Type),
-		Priority:      string(t.Priority),
+		Priority:      t.Priority,
 		Title:         t.Title,
 		Description:   t.Description.String,
 		SprintID:      t.SprintID,
@@ -391,7 +562,40 @@ func (s *Service) ticketToModel(t repository.Ticket) domain.TicketModel {
 		ParentID:      t.ParentID,
 		StoryPoints:   t.StoryPoints.Int32,
 		DueDate:       t.DueDate.Time,
+		Rank:          t.Rank.String,
 		CreatedAt:     t.CreatedAt.Time,
 		UpdatedAt:     t.UpdatedAt.Time,
+		DeletedAt:     transformer.TimePtr(t.DeletedAt),
+	}
+}
+
+// statusColumns returns the live columns of the projects whose name has the
+// slug of one of statuses. Names are slugged like org slugs, so "In Progress",
+// "in progress" and "in-progress" match the same column and so do "Готово"
+// and "готово". A status that gives no slug, e.g. only punctuation, would
+// match every column whose name gives none either and is rejected instead.
+func (s *Service) statusColumns(ctx context.Context, projectIDs []pgtype.UUID, statuses []string) ([]pgtype.UUID, error) {
+	if len(statuses) == 0 {
+		return nil, nil
+	}
+	slugs := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		slug := transformer.CreateSlug(status)
+		if slug == "" {
+			return nil, ErrInvalidStatus
+		}
+		slugs[slug] = true
+	}
+
+	columns, err := s.Repo.ListProjectColumnNames(ctx, projectIDs)
+	if err != nil {
+		return nil, fmt.Errorf("list project columns: %w", err)
+	}
+	ids := []pgtype.UUID{}
+	for _, c := range columns {
+		if slug := transformer.CreateSlug(c.Name); slug != "" && slugs[slug] {
+			ids = append(ids, c.ID)
+		}
 	}
+	return ids, nil
 }
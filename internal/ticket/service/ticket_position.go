@@ -0,0 +1,143 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/dimasbaguspm/fluxis/internal/ticket/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/dimasbaguspm/fluxis/pkg/rank"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxRankAttempts bounds how often a placement is retried when concurrent
+// moves keep claiming the same rank
+const maxRankAttempts = 3
+
+// ticketRankConstraint is the unique (board_column_id, rank) constraint from
+// the ticket rank migration
+const ticketRankConstraint = "tickets_board_column_rank_key"
+
+var (
+	ErrMoveAfterSelf    = domain.Invalid("a ticket cannot be placed after itself").WithCode("move_after_self")
+	ErrTicketNotOnBoard = domain.Invalid("ticket is not on a board column").WithCode("ticket_not_on_board")
+	ErrAnchorNotFound   = domain.NotFound("anchor ticket not found in this column")
+	ErrRankConflict     = domain.Conflict("the column changed while placing the ticket, try again").WithCode("rank_conflict")
+)
+
+// MoveTicketPosition places a ticket right after p.AfterID within its current
+// column, or at the top when no anchor is given. Only the moved ticket is
+// written; its rank is picked between the anchor and the ticket that follows it.
+func (s *Service) MoveTicketPosition(ctx context.Context, id pgtype.UUID, p domain.TicketPositionModel) (domain.TicketModel, error) {
+	if p.AfterID.Valid && p.AfterID == id {
+		return domain.TicketModel{}, ErrMoveAfterSelf
+	}
+
+	current, err := s.Repo.GetTicket(ctx, id)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TicketModel{}, ErrTicketNotFound
+		}
+		return domain.TicketModel{}, fmt.Errorf("get ticket: %w", err)
+	}
+	if err := s.authorizeTicketProject(ctx, current.ProjectID, domain.ProjectRoleMember); err != nil {
+		return domain.TicketModel{}, err
+	}
+	if !current.BoardColumnID.Valid {
+		return domain.TicketModel{}, ErrTicketNotOnBoard
+	}
+	columnID := current.BoardColumnID
+
+	slot := func() (string, error) {
+		var lower pgtype.Text
+		if p.AfterID.Valid {
+			anchor, err := s.Repo.GetTicket(ctx, p.AfterID)
+			if errors.Is(err, pgx.ErrNoRows) || (err == nil && anchor.BoardColumnID != columnID) {
+				return "", ErrAnchorNotFound
+			}
+			if err != nil {
+				return "", fmt.Errorf("get anchor ticket: %w", err)
+			}
+			lower = anchor.Rank
+		}
+
+		upper, err := s.Repo.GetNextTicketRank(ctx, repository.GetNextTicketRankParams{
+			BoardColumnID: columnID,
+			Rank:          pgtype.Text{String: lower.String, Valid: true},
+			ID:            id,
+		})
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			return "", fmt.Errorf("get next ticket rank: %w", err)
+		}
+		return rank.Between(lower.String, upper.String)
+	}
+
+	ticket, err := s.rankedWrite(ctx, columnID, slot, func(r string) (repository.Ticket, error) {
+		return s.Repo.UpdateTicketRank(ctx, repository.UpdateTicketRankParams{
+			ID:   id,
+			Rank: pgtype.Text{String: r, Valid: true},
+		})
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return domain.TicketModel{}, ErrTicketNotFound
+		}
+		return domain.TicketModel{}, fmt.Errorf("move ticket position: %w", err)
+	}
+
+	result := s.ticketToModel(ticket)
+	if err := s.Bus.Publish(ctx, pubsub.TicketReordered, httpx.EncodePayload(result)); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(pubsub.TicketReordered), "error", err)
+	}
+
+	return result, nil
+}
+
+// lastSlot ranks a ticket after everything already in the column
+func (s *Service) lastSlot(ctx context.Context, columnID pgtype.UUID) func() (string, error) {
+	return func() (string, error) {
+		last, err := s.Repo.GetLastTicketRank(ctx, columnID)
+		if err != nil {
+			return "", fmt.Errorf("get last ticket rank: %w", err)
+		}
+		return rank.Between(last, "")
+	}
+}
+
+// rankedWrite picks a rank with slot and hands it to write. When a concurrent
+// move claims the same rank first the slot is recomputed against the new
+// neighbours, and a column whose ranks have grown too long is rebalanced once.
+func (s *Service) rankedWrite(ctx context.Context, columnID pgtype.UUID, slot func() (string, error), write func(string) (repository.Ticket, error)) (repository.Ticket, error) {
+	rebalanced := false
+	for attempt := 0; attempt < maxRankAttempts; attempt++ {
+		r, err := slot()
+		if errors.Is(err, rank.ErrExhausted) && !rebalanced {
+			if err := s.Repo.RebalanceTicketRanks(ctx, columnID); err != nil {
+				return repository.Ticket{}, fmt.Errorf("rebalance ticket ranks: %w", err)
+			}
+			rebalanced = true
+			continue
+		}
+		if err != nil {
+			return repository.Ticket{}, err
+		}
+
+		ticket, err := write(r)
+		if isRankConflict(err) {
+			continue
+		}
+		return ticket, err
+	}
+	return repository.Ticket{}, ErrRankConflict
+}
+
+func isRankConflict(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == "23505" && pgErr.ConstraintName == ticketRankConstraint
+}
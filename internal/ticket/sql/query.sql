@@ -13,7 +13,8 @@ INSERT INTO tickets (
     reporter_id,
     assignee_id,
     story_points,
-    due_date
+    due_date,
+    id
 )
 VALUES (
     $1,
@@ -26,57 +27,69 @@ VALUES (
     $7,
     $8,
     $9,
-    $10
+    $10,
+    $11
 )
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at;
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank;
 
 -- name: GetTicket :one
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE id = $1 AND deleted_at IS NULL;
 
 -- name: GetTicketByKey :one
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE project_id = $1 AND key = $2 AND deleted_at IS NULL;
 
+-- name: GetTicketProject :one
+-- The project a ticket belongs to, deleted or not
+SELECT project_id
+FROM tickets
+WHERE id = $1;
+
 -- name: ListTicketsByProject :many
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE project_id = $1 AND deleted_at IS NULL
 ORDER BY ticket_number DESC;
 
 -- name: ListTicketsBySprint :many
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE project_id = $1 AND sprint_id = $2 AND deleted_at IS NULL
 ORDER BY ticket_number DESC;
 
 -- name: ListTicketsByBoard :many
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE board_id = $1 AND deleted_at IS NULL
 ORDER BY ticket_number DESC;
 
 -- name: ListTicketsByBoardColumn :many
-SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at
+SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank
 FROM tickets
 WHERE board_column_id = $1 AND deleted_at IS NULL
-ORDER BY ticket_number DESC;
+ORDER BY rank, ticket_number DESC;
 
 -- name: UpdateTicketBoard :one
+-- Keeps the current rank when the ticket stays in the same column
 UPDATE tickets
-SET board_id = $2, board_column_id = $3, updated_at = NOW()
+SET board_id = $2,
+    rank = CASE WHEN board_column_id = $3 THEN rank ELSE $4::text END,
+    board_column_id = $3
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at;
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank;
 
 -- name: UpdateTicketSprint :one
 UPDATE tickets
-SET sprint_id = $2, updated_at = NOW()
+SET sprint_id = $2
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at;
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank;
 
 -- name: UpdateTicketDetails :one
+-- A valid $9 only updates a ticket still at that updated_at, the base
+-- version an offline client edited
 UPDATE tickets
 SET title = COALESCE($2, title),
     description = COALESCE($3, description),
@@ -84,32 +97,241 @@ SET title = COALESCE($2, title),
     priority = COALESCE($5, priority),
     assignee_id = COALESCE($6, assignee_id),
     story_points = COALESCE($7, story_points),
-    due_date = COALESCE($8, due_date),
-    updated_at = NOW()
+    due_date = COALESCE($8, due_date)
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at;
+  AND ($9::timestamptz IS NULL OR updated_at = $9::timestamptz)
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank;
 
 -- name: DeleteTicket :one
+-- A valid $2 only deletes a ticket still at that updated_at
 UPDATE tickets
-SET deleted_at = NOW()
+SET deleted_at = NOW(), rank = NULL
 WHERE id = $1 AND deleted_at IS NULL
-RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at;
+  AND ($2::timestamptz IS NULL OR updated_at = $2::timestamptz)
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank;
+
+-- name: RestoreTicket :one
+-- Clears deleted_at of a ticket whose project is live, keeping it in column
+-- $2 at rank $3, or moving it to the backlog when $2 is null
+UPDATE tickets t
+SET deleted_at = NULL,
+    sprint_id = CASE WHEN $2::uuid IS NOT NULL THEN t.sprint_id END,
+    board_id = CASE WHEN $2::uuid IS NOT NULL THEN t.board_id END,
+    board_column_id = $2,
+    rank = NULLIF($3::text, '')
+WHERE t.id = $1 AND t.deleted_at IS NOT NULL
+  AND EXISTS (SELECT 1 FROM projects p WHERE p.id = t.project_id AND p.deleted_at IS NULL)
+RETURNING t.id, t.project_id, t.ticket_number, t.key, t.sprint_id, t.board_id, t.board_column_id, t.type, t.priority, t.title, t.description, t.assignee_id, t.reporter_id, t.epic_id, t.parent_id, t.story_points, t.due_date, t.created_at, t.updated_at, t.deleted_at, t.rank;
+
+-- name: GetTicketTrashState :one
+-- Whether a ticket and its project are deleted, and the column it goes back
+-- to when that column, its board and its sprint are all still live
+SELECT
+    t.deleted_at IS NOT NULL AS deleted,
+    p.deleted_at IS NOT NULL AS project_deleted,
+    bc.id AS live_column_id
+FROM tickets t
+JOIN projects p ON p.id = t.project_id
+LEFT JOIN board_columns bc ON bc.id = t.board_column_id AND bc.deleted_at IS NULL
+    AND EXISTS (
+        SELECT 1 FROM boards b
+        JOIN sprints s ON s.id = b.sprint_id
+        WHERE b.id = bc.board_id AND b.deleted_at IS NULL AND s.deleted_at IS NULL
+    )
+WHERE t.id = $1;
 
 -- name: HardDeleteTicket :exec
 DELETE FROM tickets
 WHERE id = $1;
 
--- name: ListTicketsPaged :many
-WITH filtered_tickets AS (
-    SELECT id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at,
-           COUNT(*) OVER () as total_count
+-- name: GetLastTicketRank :one
+SELECT COALESCE(MAX(rank), '')::text AS last_rank
+FROM tickets
+WHERE board_column_id = $1 AND deleted_at IS NULL;
+
+-- name: CountColumnTickets :one
+-- Live tickets in a column, leaving out $2 so a ticket already there is not counted twice
+SELECT COUNT(*)
+FROM tickets
+WHERE board_column_id = $1 AND deleted_at IS NULL AND id IS DISTINCT FROM $2;
+
+-- name: GetNextTicketRank :one
+-- Returns the first rank after $2 in a column, ignoring the ticket being placed
+SELECT rank
+FROM tickets
+WHERE board_column_id = $1 AND rank > $2 AND id <> $3 AND deleted_at IS NULL
+ORDER BY rank
+LIMIT 1;
+
+-- name: UpdateTicketRank :one
+UPDATE tickets
+SET rank = $2
+WHERE id = $1 AND deleted_at IS NULL
+RETURNING id, project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority, title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date, created_at, updated_at, deleted_at, rank;
+
+-- name: RebalanceTicketRanks :exec
+-- Re-spaces the ranks of a column with short fixed width keys, keeping their order
+UPDATE tickets
+SET rank = ranked.rank
+FROM (
+    SELECT id, lpad((ROW_NUMBER() OVER (ORDER BY rank, ticket_number DESC))::text, 6, '0') || 'i' AS rank
     FROM tickets
-    WHERE deleted_at IS NULL
-        AND (array_length($1::uuid[], 1) IS NULL OR project_id = ANY($1::uuid[]))
-        AND (array_length($2::uuid[], 1) IS NULL OR id = ANY($2::uuid[]))
-        AND (array_length($3::uuid[], 1) IS NULL OR sprint_id = ANY($3::uuid[]))
-        AND (array_length($4::uuid[], 1) IS NULL OR board_id = ANY($4::uuid[]))
+    WHERE board_column_id = $1 AND deleted_at IS NULL
+) ranked
+WHERE tickets.id = ranked.id;
+
+-- name: GetTicketExportNames :one
+-- Resolves the names a ticket export prints in place of IDs, empty when unset
+SELECT
+    p.name AS project_name,
+    COALESCE(s.name, '')::text AS sprint_name,
+    COALESCE(b.name, '')::text AS board_name,
+    COALESCE(bc.name, '')::text AS column_name,
+    COALESCE(bc.category::text, '')::text AS column_category,
+    COALESCE(a.display_name, '')::text AS assignee_name,
+    COALESCE(r.display_name, '')::text AS reporter_name,
+    COALESCE(e.key, '')::text AS epic_key,
+    COALESCE(pt.key, '')::text AS parent_key
+FROM tickets t
+JOIN projects p ON p.id = t.project_id
+LEFT JOIN sprints s ON s.id = t.sprint_id
+LEFT JOIN boards b ON b.id = t.board_id
+LEFT JOIN board_columns bc ON bc.id = t.board_column_id
+LEFT JOIN users a ON a.id = t.assignee_id
+LEFT JOIN users r ON r.id = t.reporter_id
+LEFT JOIN tickets e ON e.id = t.epic_id
+LEFT JOIN tickets pt ON pt.id = t.parent_id
+WHERE t.id = $1 AND t.deleted_at IS NULL;
+
+-- name: ListTicketSubtasks :many
+-- A ticket's children with whether their column counts as done, the checklist of an export
+SELECT t.key, t.title, COALESCE(bc.category = 'done', false) AS is_done
+FROM active_tickets t
+LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE t.parent_id = $1
+ORDER BY t.ticket_number;
+
+-- name: ListProjectColumnNames :many
+-- The live board columns of the projects, which the status filter matches by name slug
+SELECT id, name
+FROM active_board_columns
+WHERE project_id = ANY($1::uuid[]);
+
+-- name: ListProjectTicketGraph :many
+-- A project's tickets with their status category and the links that make up its graph
+SELECT
+    t.id,
+    t.key,
+    t.title,
+    t.type,
+    t.priority,
+    COALESCE(bc.category::text, '')::text AS status,
+    t.assignee_id,
+    t.epic_id,
+    t.parent_id
+FROM active_tickets t
+LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE t.project_id = $1
+ORDER BY t.ticket_number;
+
+-- name: GetTicketChangeSummary :one
+-- Counts the writes the change log holds for a ticket and when the last one happened
+SELECT
+    COUNT(*) FILTER (WHERE op = 'update')::bigint AS updates,
+    MAX(created_at)::timestamptz AS last_changed_at
+FROM changes
+WHERE entity = 'ticket' AND entity_id = $1;
+
+-- name: GetTicketStaleSettings :one
+SELECT project_id, after_days, updated_by, created_at, updated_at
+FROM ticket_stale_settings
+WHERE project_id = $1;
+
+-- name: UpsertTicketStaleSettings :one
+INSERT INTO ticket_stale_settings (project_id, after_days, updated_by)
+VALUES ($1, $2, $3)
+ON CONFLICT (project_id) DO UPDATE
+SET after_days = EXCLUDED.after_days, updated_by = EXCLUDED.updated_by
+RETURNING project_id, after_days, updated_by, created_at, updated_at;
+
+-- name: UnflagStaleTickets :execrows
+-- Drops the flags of tickets that are no longer stale at $1: touched since
+-- they were flagged, done, deleted, or within a raised or disabled after_days
+DELETE FROM stale_tickets st
+WHERE NOT EXISTS (
+    SELECT 1
+    FROM active_tickets t
+    JOIN ticket_stale_settings ss ON ss.project_id = t.project_id AND ss.after_days > 0
+    LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+    WHERE t.id = st.ticket_id
+      AND t.updated_at <= st.flagged_at
+      AND t.updated_at < $1::timestamptz - make_interval(days => ss.after_days)
+      AND COALESCE(bc.category <> 'done', true)
+);
+
+-- name: FlagStaleTickets :many
+-- Flags the tickets untouched for their project's after_days at $1 that are
+-- not done, returning only those that were not flagged yet
+INSERT INTO stale_tickets (ticket_id, project_id, flagged_at)
+SELECT t.id, t.project_id, $1::timestamptz
+FROM active_tickets t
+JOIN ticket_stale_settings ss ON ss.project_id = t.project_id AND ss.after_days > 0
+LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE t.updated_at < $1::timestamptz - make_interval(days => ss.after_days)
+  AND COALESCE(bc.category <> 'done', true)
+ON CONFLICT (ticket_id) DO NOTHING
+RETURNING ticket_id, project_id;
+
+-- name: GetDefaultBoardColumn :one
+SELECT id
+FROM active_board_columns
+WHERE board_id = $1 AND is_default;
+
+-- name: MoveTicketToProject :one
+-- Numbers the ticket in project $2 under key $3, which GenerateTicketKey just
+-- handed out, and places it on the given board or in the backlog. Links into
+-- the old project go: its epic and parent, the assignee when they are not a
+-- member of the new project, and the epic and parent links of tickets
+-- pointing at it.
+WITH detached AS (
+    UPDATE tickets
+    SET parent_id = NULLIF(parent_id, $1), epic_id = NULLIF(epic_id, $1)
+    WHERE parent_id = $1 OR epic_id = $1
 )
-SELECT * FROM filtered_tickets
-ORDER BY ticket_number DESC
-LIMIT $5 OFFSET $6;
+UPDATE tickets t
+SET project_id = $2,
+    ticket_number = (SELECT next_number - 1 FROM ticket_counters WHERE project_id = $2),
+    key = $3,
+    priority = $4,
+    sprint_id = $5,
+    board_id = $6,
+    board_column_id = $7,
+    rank = NULLIF($8::text, ''),
+    epic_id = NULL,
+    parent_id = NULL,
+    assignee_id = CASE
+        WHEN EXISTS (
+            SELECT 1 FROM project_members pm WHERE pm.project_id = $2 AND pm.user_id = t.assignee_id
+        ) THEN t.assignee_id
+    END
+WHERE t.id = $1 AND t.deleted_at IS NULL
+RETURNING t.id, t.project_id, t.ticket_number, t.key, t.sprint_id, t.board_id, t.board_column_id, t.type, t.priority, t.title, t.description, t.assignee_id, t.reporter_id, t.epic_id, t.parent_id, t.story_points, t.due_date, t.created_at, t.updated_at, t.deleted_at, t.rank;
+
+-- name: ListAssignmentCandidates :many
+-- The members of a project with their open tickets and the done ones sharing a type or epic, counted across the organisation
+SELECT
+    u.id AS user_id,
+    u.display_name,
+    COUNT(t.id) FILTER (WHERE bc.category IS DISTINCT FROM 'done')::bigint AS open_tickets,
+    COALESCE(SUM(t.story_points) FILTER (WHERE bc.category IS DISTINCT FROM 'done'), 0)::bigint AS open_points,
+    COUNT(t.id) FILTER (WHERE bc.category = 'done' AND t.type = $2)::bigint AS done_same_type,
+    COUNT(t.id) FILTER (WHERE bc.category = 'done' AND t.epic_id = $3)::bigint AS done_same_epic
+FROM projects p
+JOIN project_members pm ON pm.project_id = p.id
+JOIN users u ON u.id = pm.user_id AND u.deleted_at IS NULL
+LEFT JOIN active_tickets t ON t.assignee_id = u.id
+    AND t.project_id IN (SELECT id FROM projects WHERE org_id = p.org_id AND deleted_at IS NULL)
+LEFT JOIN active_board_columns bc ON bc.id = t.board_column_id
+WHERE p.id = $1
+GROUP BY u.id, u.display_name
+ORDER BY u.display_name;
@@ -0,0 +1,46 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// ListTrash godoc
+//
+//	@Summary		List deleted items
+//	@ID				listTrash
+//	@Description	Returns the projects, tickets and board columns deleted in the organisation's projects the caller is a member of, most recently deleted first. Each item links to its restore endpoint; parentDeleted items need what holds them restored first
+//	@Tags			trash
+//	@Produce		json
+//	@Param			query	query	domain.TrashSearchModel	false	"Search parameters: orgId (required), type (project, ticket, board_column), projectId, pageNumber, pageSize"
+//	@Success		200	{object}	domain.TrashPagedModel
+//	@Header			200	{string}	Link	"RFC 8288 links to the first, prev, next and last page"
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		403	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/trash [get]
+func (h *Handler) ListTrash(w http.ResponseWriter, r *http.Request) {
+	orgID, err := httpx.QueryUUID(r, "orgId")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	trash, err := h.svc.ListTrash(r.Context(), domain.TrashSearchModel{
+		OrgID:      orgID,
+		Type:       httpx.QueryStrings(r, "type"),
+		ProjectID:  httpx.QueryUUIDs(r, "projectId"),
+		PageNumber: httpx.QueryNumber(r, "pageNumber"),
+		PageSize:   httpx.QueryNumber(r, "pageSize"),
+	})
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OKPage(w, r, trash, trash.PageNumber, trash.PageSize, trash.TotalPages)
+}
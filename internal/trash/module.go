@@ -0,0 +1,25 @@
+package trash
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/trash/handler"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Module struct {
+	h    *handler.Handler
+	auth *httpx.Authenticator
+}
+
+func NewModule(h *handler.Handler, auth *httpx.Authenticator) *Module {
+	return &Module{
+		h:    h,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /trash", m.auth.RequireAuth(m.h.ListTrash, domain.ScopeProjectsRead, domain.ScopeTicketsRead, domain.ScopeBoardsRead))
+}
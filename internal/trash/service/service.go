@@ -0,0 +1,21 @@
+package service
+
+import (
+	"github.com/dimasbaguspm/fluxis/internal/trash/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+type Deps struct {
+	Repo *repository.Queries
+	Org  domain.OrgReader
+}
+
+type Service struct {
+	Deps
+}
+
+var _ domain.TrashReader = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{Deps: d}
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/dimasbaguspm/fluxis/internal/trash/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+)
+
+var (
+	ErrInvalidTrashType = domain.Invalid("type must be project, ticket or board_column").WithCode("invalid_trash_type")
+	ErrNotOrgMember     = domain.Forbidden("you are not a member of this organisation").WithCode("not_a_member")
+)
+
+// ListTrash pages through the projects, tickets and board columns deleted in
+// an organisation the caller belongs to, most recently deleted first. Only
+// what belongs to projects the caller is a member of is listed.
+func (s *Service) ListTrash(ctx context.Context, q domain.TrashSearchModel) (domain.TrashPagedModel, error) {
+	q.ApplyDefaults()
+
+	for _, t := range q.Type {
+		if t != domain.TrashProject && t != domain.TrashTicket && t != domain.TrashBoardColumn {
+			return domain.TrashPagedModel{}, ErrInvalidTrashType
+		}
+	}
+
+	if _, err := s.Org.GetOrgById(ctx, q.OrgID); err != nil {
+		return domain.TrashPagedModel{}, err
+	}
+	member, err := s.Repo.IsOrgMember(ctx, repository.IsOrgMemberParams{
+		OrgID:  q.OrgID,
+		UserID: httpx.MustUserID(ctx),
+	})
+	if err != nil {
+		return domain.TrashPagedModel{}, fmt.Errorf("check org member: %w", err)
+	}
+	if !member {
+		return domain.TrashPagedModel{}, ErrNotOrgMember
+	}
+
+	rows, err := s.Repo.ListTrashPaged(ctx, repository.ListTrashPagedParams{
+		OrgID:   q.OrgID,
+		Column2: q.Type,
+		Column3: q.ProjectID,
+		Limit:   int32(q.PageSize),
+		Offset:  pagination.Offset(q.PageNumber, q.PageSize),
+		Column6: httpx.MemberFilter(ctx),
+	})
+	if err != nil {
+		return domain.TrashPagedModel{}, fmt.Errorf("list trash paged: %w", err)
+	}
+
+	page := pagination.FromRows(rows,
+		func(row repository.ListTrashPagedRow) int64 { return row.TotalCount },
+		func(row repository.ListTrashPagedRow) domain.TrashItemModel {
+			return domain.TrashItemModel{
+				Type:          row.Type,
+				ID:            row.ID,
+				ProjectID:     row.ProjectID,
+				BoardID:       row.BoardID,
+				Key:           row.Key,
+				Name:          row.Name,
+				DeletedAt:     row.DeletedAt.Time,
+				ParentDeleted: row.ParentDeleted,
+			}
+		},
+		q.PageNumber, q.PageSize)
+
+	return domain.TrashPagedModel(page), nil
+}
@@ -0,0 +1,44 @@
+-- name: IsOrgMember :one
+SELECT EXISTS (
+    SELECT 1 FROM org_members WHERE org_id = $1 AND user_id = $2
+);
+
+-- name: ListTrashPaged :many
+-- Projects, tickets and board columns soft-deleted in an organisation, most
+-- recently deleted first. parent_deleted marks items whose project, board or
+-- sprint is deleted as well. Only projects the user $6 is a member of are
+-- searched, every project of the organisation when $6 is NULL.
+WITH trash AS (
+    SELECT 'project'::text AS type, p.id, p.id AS project_id, NULL::uuid AS board_id,
+           p.key::text AS key, p.name::text AS name, p.deleted_at, o.deleted_at IS NOT NULL AS parent_deleted
+    FROM projects p
+    JOIN orgs o ON o.id = p.org_id
+    WHERE p.org_id = $1 AND p.deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'ticket', t.id, t.project_id, NULL, t.key, t.title, t.deleted_at, p.deleted_at IS NOT NULL
+    FROM tickets t
+    JOIN projects p ON p.id = t.project_id
+    WHERE p.org_id = $1 AND t.deleted_at IS NOT NULL
+    UNION ALL
+    SELECT 'board_column', bc.id, s.project_id, bc.board_id, '', bc.name, bc.deleted_at,
+           b.deleted_at IS NOT NULL OR s.deleted_at IS NOT NULL OR p.deleted_at IS NOT NULL
+    FROM board_columns bc
+    JOIN boards b ON b.id = bc.board_id
+    JOIN sprints s ON s.id = b.sprint_id
+    JOIN projects p ON p.id = s.project_id
+    WHERE p.org_id = $1 AND bc.deleted_at IS NOT NULL
+), filtered AS (
+    SELECT type, id, project_id, board_id, key, name, deleted_at, parent_deleted,
+           COUNT(*) OVER () AS total_count
+    FROM trash
+    WHERE (array_length($2::text[], 1) IS NULL OR type = ANY($2::text[]))
+      AND (array_length($3::uuid[], 1) IS NULL OR project_id = ANY($3::uuid[]))
+      AND ($6::uuid IS NULL OR EXISTS (
+          SELECT 1 FROM project_members pm WHERE pm.project_id = trash.project_id AND pm.user_id = $6::uuid
+      ))
+)
+SELECT type, id, project_id, board_id, key, name, deleted_at, parent_deleted, total_count
+FROM filtered
+ORDER BY deleted_at DESC, id
+LIMIT $4
+OFFSET $5;
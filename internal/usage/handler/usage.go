@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// GetCurrentUserUsage godoc
+//
+//	@Summary		Get current user API usage
+//	@ID				getCurrentUserUsage
+//	@Description	Counts the caller's authenticated API requests per UTC day over the last periodDays days, newest first. Days without requests are left out
+//	@Tags			usage
+//	@Produce		json
+//	@Param			periodDays	query		int	false	"Number of days, today included (1-90, default 30)"
+//	@Success		200			{object}	domain.UserUsageModel
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/users/me/usage [get]
+func (h *Handler) GetCurrentUserUsage(w http.ResponseWriter, r *http.Request) {
+	usage, err := h.svc.GetUserUsage(r.Context(), httpx.MustUserID(r.Context()), httpx.QueryNumber(r, "periodDays"))
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OK(w, usage)
+}
+
+// ListUsage godoc
+//
+//	@Summary		List API usage
+//	@ID				listUsage
+//	@Description	Counts every user's authenticated API requests per UTC day over the last periodDays days, the busiest users of the newest day first, to spot integrations that hammer the API. Requires the admin scope
+//	@Tags			usage
+//	@Produce		json
+//	@Param			periodDays	query		int	false	"Number of days, today included (1-90, default 30)"
+//	@Param			pageNumber	query		int	false	"Page number"
+//	@Param			pageSize	query		int	false	"Page size"
+//	@Success		200			{object}	domain.UsagePagedModel
+//	@Header			200			{string}	Link	"RFC 8288 links to the first, prev, next and last page"
+//	@Failure		400			{object}	httpx.ErrBlock
+//	@Failure		401			{object}	httpx.ErrBlock
+//	@Failure		403			{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/admin/usage [get]
+func (h *Handler) ListUsage(w http.ResponseWriter, r *http.Request) {
+	usage, err := h.svc.ListUsage(r.Context(), domain.UsageSearchModel{
+		PeriodDays: httpx.QueryNumber(r, "periodDays"),
+		PageNumber: httpx.QueryNumber(r, "pageNumber"),
+		PageSize:   httpx.QueryNumber(r, "pageSize"),
+	})
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	httpx.OKPage(w, r, usage, usage.PageNumber, usage.PageSize, usage.TotalPages)
+}
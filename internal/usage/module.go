@@ -0,0 +1,45 @@
+package usage
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/usage/handler"
+	"github.com/dimasbaguspm/fluxis/internal/usage/service"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Module struct {
+	h    *handler.Handler
+	svc  *service.Service
+	auth *httpx.Authenticator
+}
+
+// NewModule also has the authenticator count every authenticated request
+// towards its user's usage
+func NewModule(h *handler.Handler, svc *service.Service, auth *httpx.Authenticator) *Module {
+	auth.Observe(svc.Record)
+	return &Module{
+		h:    h,
+		svc:  svc,
+		auth: auth,
+	}
+}
+
+func (m *Module) Routes(mux *http.ServeMux) {
+	mux.HandleFunc("GET /users/me/usage", m.auth.RequireAuth(m.h.GetCurrentUserUsage, domain.ScopeUsersRead))
+	mux.HandleFunc("GET /admin/usage", m.auth.RequireAuth(m.h.ListUsage, domain.ScopeAdmin))
+}
+
+// StartFlusher periodically writes the request counts kept in memory to the
+// usage table
+func (m *Module) StartFlusher(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	slog.Info("[UsageModule]: starting usage flusher", "interval", interval.String())
+	m.svc.StartFlusher(ctx, interval)
+}
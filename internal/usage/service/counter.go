@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/usage/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// flushTimeout bounds the flush that runs on shutdown, after the server's
+// context is gone
+const flushTimeout = 5 * time.Second
+
+var usageDropped = metrics.Default.NewCounter(metrics.Desc{
+	Name: "fluxis_usage_dropped_total",
+	Help: "Counts of a user and day dropped on flush because the database refuses them for good, such as those of a user deleted before the flush.",
+})
+
+// usageAdder is the part of the repository Flush uses
+type usageAdder interface {
+	AddApiUsage(ctx context.Context, arg repository.AddApiUsageParams) error
+}
+
+type usageKey struct {
+	userID pgtype.UUID
+	day    string
+}
+
+// Record counts one request of the user against the current UTC day. It
+// only touches memory; Flush writes the counts out.
+func (s *Service) Record(userID pgtype.UUID) {
+	key := usageKey{userID: userID, day: time.Now().UTC().Format(usageDateLayout)}
+
+	s.mu.Lock()
+	s.pending[key]++
+	s.mu.Unlock()
+}
+
+// Flush adds the pending counts to the usage table. Counts that could not be
+// written are kept for the next flush.
+func (s *Service) Flush(ctx context.Context) error {
+	return s.flush(ctx, s.Repo)
+}
+
+// flush writes the pending counts in one statement. When the database refuses
+// it for good, the counts are written one by one instead, so a single count
+// it refuses, such as one of a user deleted meanwhile, is dropped rather than
+// holding back the others on every flush.
+func (s *Service) flush(ctx context.Context, store usageAdder) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = map[usageKey]int64{}
+	s.mu.Unlock()
+
+	if len(pending) == 0 {
+		return nil
+	}
+
+	err := store.AddApiUsage(ctx, usageParams(pending))
+	if err == nil {
+		return nil
+	}
+	if !permanent(err) {
+		s.requeue(pending)
+		return fmt.Errorf("add api usage: %w", err)
+	}
+
+	failed := map[usageKey]int64{}
+	var firstErr error
+	for key, n := range pending {
+		err := store.AddApiUsage(ctx, usageParams(map[usageKey]int64{key: n}))
+		switch {
+		case err == nil:
+		case permanent(err):
+			usageDropped.With().Inc()
+			slog.Warn("[UsageModule]: dropped usage the database refuses",
+				"userId", uuid.UUID(key.userID.Bytes).String(),
+				"day", key.day,
+				"requests", n,
+				"error", err,
+			)
+		default:
+			failed[key] = n
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if firstErr != nil {
+		s.requeue(failed)
+		return fmt.Errorf("add api usage: %w", firstErr)
+	}
+	return nil
+}
+
+// requeue adds counts that could not be written back for the next flush
+func (s *Service) requeue(counts map[usageKey]int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for key, n := range counts {
+		s.pending[key] += n
+	}
+}
+
+func usageParams(counts map[usageKey]int64) repository.AddApiUsageParams {
+	arg := repository.AddApiUsageParams{}
+	for key, n := range counts {
+		day, _ := time.Parse(usageDateLayout, key.day)
+		arg.Column1 = append(arg.Column1, key.userID)
+		arg.Column2 = append(arg.Column2, pgtype.Date{Time: day, Valid: true})
+		arg.Column3 = append(arg.Column3, n)
+	}
+	return arg
+}
+
+// permanent reports errors that writing the same counts again can not fix
+func permanent(err error) bool {
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return false
+	}
+	// classes 22 and 23 are data exceptions and integrity violations
+	return strings.HasPrefix(pgErr.Code, "22") || strings.HasPrefix(pgErr.Code, "23")
+}
+
+// StartFlusher flushes the counts on every tick until ctx ends, and once more
+// on the way out so a clean shutdown loses nothing
+func (s *Service) StartFlusher(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+			defer cancel()
+			if err := s.Flush(ctx); err != nil {
+				slog.Warn("[UsageModule]: final usage flush failed", "error", err)
+			}
+			return
+		case <-ticker.C:
+			if err := s.Flush(ctx); err != nil {
+				slog.Warn("[UsageModule]: usage flush failed", "error", err)
+			}
+		}
+	}
+}
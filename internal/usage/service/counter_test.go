@@ -0,0 +1,166 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/internal/usage/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// stubAdder refuses any statement touching a deleted user for good, fails
+// statements touching a flaky user as if the connection dropped and keeps
+// the counts of every statement it accepts
+type stubAdder struct {
+	deleted, flaky pgtype.UUID
+	down           bool
+
+	calls   int
+	written map[pgtype.UUID]int64
+}
+
+func (s *stubAdder) AddApiUsage(ctx context.Context, arg repository.AddApiUsageParams) error {
+	s.calls++
+	if s.down {
+		return errors.New("dial tcp: connection refused")
+	}
+	for _, id := range arg.Column1 {
+		switch id {
+		case s.deleted:
+			return &pgconn.PgError{Code: "23503", Message: "violates foreign key constraint"}
+		case s.flaky:
+			return &pgconn.PgError{Code: "08006", Message: "connection failure"}
+		}
+	}
+	if s.written == nil {
+		s.written = map[pgtype.UUID]int64{}
+	}
+	for i, id := range arg.Column1 {
+		s.written[id] += arg.Column3[i]
+	}
+	return nil
+}
+
+func user(b byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{15: b}, Valid: true}
+}
+
+func TestFlush(t *testing.T) {
+	alice, bob, gone, flaky := user(1), user(2), user(3), user(4)
+
+	tests := []struct {
+		name        string
+		store       *stubAdder
+		wantErr     bool
+		wantCalls   int
+		wantWritten map[pgtype.UUID]int64
+		wantPending map[pgtype.UUID]int64
+		wantDropped float64
+	}{
+		{
+			name:        "one statement",
+			store:       &stubAdder{},
+			wantCalls:   1,
+			wantWritten: map[pgtype.UUID]int64{alice: 2, bob: 1},
+		},
+		{
+			name:        "database down keeps everything",
+			store:       &stubAdder{down: true},
+			wantErr:     true,
+			wantCalls:   1,
+			wantPending: map[pgtype.UUID]int64{alice: 2, bob: 1},
+		},
+		{
+			name:        "refused row is dropped",
+			store:       &stubAdder{deleted: gone},
+			wantCalls:   4,
+			wantWritten: map[pgtype.UUID]int64{alice: 2, bob: 1},
+			wantDropped: 1,
+		},
+		{
+			name:        "row failing otherwise is kept",
+			store:       &stubAdder{deleted: gone, flaky: flaky},
+			wantErr:     true,
+			wantCalls:   5,
+			wantWritten: map[pgtype.UUID]int64{alice: 2, bob: 1},
+			wantPending: map[pgtype.UUID]int64{flaky: 1},
+			wantDropped: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(Deps{})
+			s.Record(alice)
+			s.Record(alice)
+			s.Record(bob)
+			if tt.store.deleted.Valid {
+				s.Record(gone)
+			}
+			if tt.store.flaky.Valid {
+				s.Record(flaky)
+			}
+			before := dropped(t)
+
+			err := s.flush(context.Background(), tt.store)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("flush = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.store.calls != tt.wantCalls {
+				t.Errorf("got %d statements, want %d", tt.store.calls, tt.wantCalls)
+			}
+			if !sameCounts(tt.store.written, tt.wantWritten) {
+				t.Errorf("written = %v, want %v", tt.store.written, tt.wantWritten)
+			}
+
+			pending := map[pgtype.UUID]int64{}
+			for key, n := range s.pending {
+				pending[key.userID] += n
+			}
+			if !sameCounts(pending, tt.wantPending) {
+				t.Errorf("pending = %v, want %v", pending, tt.wantPending)
+			}
+			if got := dropped(t) - before; got != tt.wantDropped {
+				t.Errorf("dropped grew by %v, want %v", got, tt.wantDropped)
+			}
+		})
+	}
+}
+
+// dropped reads fluxis_usage_dropped_total off the metrics endpoint
+func dropped(t *testing.T) float64 {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	metrics.Handler(metrics.Default).ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+
+	sc := bufio.NewScanner(rec.Body)
+	for sc.Scan() {
+		if v, ok := strings.CutPrefix(sc.Text(), "fluxis_usage_dropped_total "); ok {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				t.Fatalf("parse %q: %v", sc.Text(), err)
+			}
+			return f
+		}
+	}
+	return 0
+}
+
+func sameCounts(got, want map[pgtype.UUID]int64) bool {
+	if len(got) != len(want) {
+		return false
+	}
+	for id, n := range want {
+		if got[id] != n {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,26 @@
+package service
+
+import (
+	"sync"
+
+	"github.com/dimasbaguspm/fluxis/internal/usage/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+type Deps struct {
+	Repo *repository.Queries
+}
+
+type Service struct {
+	Deps
+
+	// pending holds the requests counted since the last flush
+	mu      sync.Mutex
+	pending map[usageKey]int64
+}
+
+var _ domain.UsageReader = (*Service)(nil)
+
+func New(d Deps) *Service {
+	return &Service{Deps: d, pending: map[usageKey]int64{}}
+}
@@ -0,0 +1,105 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/internal/usage/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	usageDateLayout = "2006-01-02"
+
+	defaultUsagePeriodDays = 30
+	maxUsagePeriodDays     = 90
+)
+
+var ErrInvalidPeriod = domain.Invalid(fmt.Sprintf("periodDays must be between 1 and %d", maxUsagePeriodDays)).WithCode("invalid_period")
+
+// GetUserUsage returns the user's request counts of the last periodDays
+// days, today included. Pending counts are flushed first so the figures are
+// current.
+func (s *Service) GetUserUsage(ctx context.Context, userID pgtype.UUID, periodDays int) (domain.UserUsageModel, error) {
+	since, periodDays, err := usagePeriod(periodDays)
+	if err != nil {
+		return domain.UserUsageModel{}, err
+	}
+	if err := s.Flush(ctx); err != nil {
+		return domain.UserUsageModel{}, err
+	}
+
+	rows, err := s.Repo.ListUserApiUsage(ctx, repository.ListUserApiUsageParams{
+		UserID:  userID,
+		Column2: since,
+	})
+	if err != nil {
+		return domain.UserUsageModel{}, fmt.Errorf("list user api usage: %w", err)
+	}
+
+	days := make([]domain.UsageDayModel, 0, len(rows))
+	for _, row := range rows {
+		days = append(days, domain.UsageDayModel{
+			Date:     row.UsageDate.Time.Format(usageDateLayout),
+			Requests: row.Requests,
+		})
+	}
+	return domain.UserUsageModel{
+		UserID:     userID,
+		PeriodDays: periodDays,
+		Days:       days,
+	}, nil
+}
+
+// ListUsage pages through every user's request counts of the last periodDays
+// days, the busiest users of the newest day first
+func (s *Service) ListUsage(ctx context.Context, q domain.UsageSearchModel) (domain.UsagePagedModel, error) {
+	q.ApplyDefaults()
+
+	since, _, err := usagePeriod(q.PeriodDays)
+	if err != nil {
+		return domain.UsagePagedModel{}, err
+	}
+	if err := s.Flush(ctx); err != nil {
+		return domain.UsagePagedModel{}, err
+	}
+
+	rows, err := s.Repo.ListApiUsage(ctx, repository.ListApiUsageParams{
+		Column1: since,
+		Limit:   int32(q.PageSize),
+		Offset:  pagination.Offset(q.PageNumber, q.PageSize),
+	})
+	if err != nil {
+		return domain.UsagePagedModel{}, fmt.Errorf("list api usage: %w", err)
+	}
+
+	page := pagination.FromRows(rows,
+		func(row repository.ListApiUsageRow) int64 { return row.TotalCount },
+		func(row repository.ListApiUsageRow) domain.UsageModel {
+			return domain.UsageModel{
+				UserID:      row.UserID,
+				Email:       row.Email,
+				DisplayName: row.DisplayName,
+				Date:        row.UsageDate.Time.Format(usageDateLayout),
+				Requests:    row.Requests,
+			}
+		},
+		q.PageNumber, q.PageSize)
+
+	return domain.UsagePagedModel(page), nil
+}
+
+// usagePeriod returns the first UTC day of a period ending today
+func usagePeriod(periodDays int) (pgtype.Date, int, error) {
+	if periodDays == 0 {
+		periodDays = defaultUsagePeriodDays
+	}
+	if periodDays < 1 || periodDays > maxUsagePeriodDays {
+		return pgtype.Date{}, 0, ErrInvalidPeriod
+	}
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	return pgtype.Date{Time: today.AddDate(0, 0, 1-periodDays), Valid: true}, periodDays, nil
+}
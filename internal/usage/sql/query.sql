@@ -0,0 +1,40 @@
+-- name: AddApiUsage :exec
+-- Adds the counted requests, one row per user_id, usage_date and requests element
+INSERT INTO api_usage (user_id, usage_date, requests)
+SELECT user_id, usage_date, requests
+FROM unnest($1::uuid[], $2::date[], $3::bigint[]) AS u (user_id, usage_date, requests)
+ON CONFLICT (user_id, usage_date) DO UPDATE
+SET
+  requests = api_usage.requests + EXCLUDED.requests;
+
+-- name: ListUserApiUsage :many
+SELECT
+  usage_date, requests
+FROM
+  api_usage
+WHERE
+  user_id = $1
+  AND usage_date >= $2::date
+ORDER BY
+  usage_date DESC;
+
+-- name: ListApiUsage :many
+-- Busiest users first within each day, newest day first
+WITH filtered_usage AS (
+  SELECT
+    au.user_id, u.email, u.display_name, au.usage_date, au.requests,
+    COUNT(*) OVER () AS total_count
+  FROM
+    api_usage au
+    JOIN users u ON u.id = au.user_id
+  WHERE
+    au.usage_date >= $1::date
+)
+SELECT
+  user_id, email, display_name, usage_date, requests, total_count
+FROM
+  filtered_usage
+ORDER BY
+  usage_date DESC, requests DESC, user_id
+LIMIT $2
+OFFSET $3;
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/internal/user/service"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// avatarFormField is the multipart field read when the avatar is uploaded as a
+// form instead of a raw request body
+const avatarFormField = "avatar"
+
+// GetUserAvatar godoc
+//
+//	@Summary		Get user avatar
+//	@ID				getUserAvatar
+//	@Description	Returns the user's profile picture, or a generated identicon when they have not uploaded one. Supports If-None-Match and If-Modified-Since.
+//	@Tags			user
+//	@Produce		png
+//	@Produce		jpeg
+//	@Produce		gif
+//	@Param			id	path	string	true	"User ID"
+//	@Success		200	{file}		binary
+//	@Success		304
+//	@Failure		400	{object}	httpx.ErrBlock
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/users/{id}/avatar [get]
+func (h *Handler) GetUserAvatar(w http.ResponseWriter, r *http.Request) {
+	id, err := httpx.PathUUID(r, "id")
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	avatar, err := h.svc.GetUserAvatar(r.Context(), id)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	sum := sha256.Sum256(avatar.Data)
+	w.Header().Set("Content-Type", avatar.ContentType)
+	w.Header().Set("ETag", fmt.Sprintf(`"%x"`, sum[:16]))
+	w.Header().Set("Cache-Control", "private, no-cache")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	http.ServeContent(w, r, "", avatar.UpdatedAt, bytes.NewReader(avatar.Data))
+}
+
+// SetCurrentUserAvatar godoc
+//
+//	@Summary		Upload avatar
+//	@ID				setCurrentUserAvatar
+//	@Description	Replaces the authenticated user's profile picture. Send the image as the raw request body or as the "avatar" field of a multipart form. PNG, JPEG, GIF and WebP up to 1MB are accepted; the format is detected from the bytes.
+//	@Tags			user
+//	@Accept			png
+//	@Accept			jpeg
+//	@Accept			mpfd
+//	@Produce		json
+//	@Param			avatar	formData	file	false	"Avatar image"
+//	@Success		200		{object}	domain.UserAvatarModel
+//	@Failure		400		{object}	httpx.ErrBlock
+//	@Failure		401		{object}	httpx.ErrBlock
+//	@Failure		413		{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/users/me/avatar [put]
+func (h *Handler) SetCurrentUserAvatar(w http.ResponseWriter, r *http.Request) {
+	data, err := readAvatar(w, r)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	avatar, err := h.svc.SetUserAvatar(r.Context(), httpx.MustUserID(r.Context()), data)
+	if err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+	httpx.OK(w, avatar)
+}
+
+// DeleteCurrentUserAvatar godoc
+//
+//	@Summary		Remove avatar
+//	@ID				deleteCurrentUserAvatar
+//	@Description	Deletes the authenticated user's uploaded picture, the generated identicon is served again afterwards
+//	@Tags			user
+//	@Success		204
+//	@Failure		401	{object}	httpx.ErrBlock
+//	@Failure		404	{object}	httpx.ErrBlock
+//	@Security		BearerAuth
+//	@Router			/users/me/avatar [delete]
+func (h *Handler) DeleteCurrentUserAvatar(w http.ResponseWriter, r *http.Request) {
+	if err := h.svc.DeleteUserAvatar(r.Context(), httpx.MustUserID(r.Context())); err != nil {
+		httpx.Handle(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readAvatar reads the uploaded image from a multipart form or the raw body.
+// The limit leaves room for the multipart envelope, the service checks the
+// image size itself.
+func readAvatar(w http.ResponseWriter, r *http.Request) ([]byte, error) {
+	r.Body = http.MaxBytesReader(w, r.Body, service.MaxAvatarSize+64<<10)
+
+	var src io.Reader = r.Body
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType == "multipart/form-data" {
+		reader, err := r.MultipartReader()
+		if err != nil {
+			return nil, httpx.BadRequest("invalid multipart body")
+		}
+		for {
+			part, err := reader.NextPart()
+			if errors.Is(err, io.EOF) {
+				return nil, httpx.BadRequest(`missing "` + avatarFormField + `" file field`)
+			}
+			if err != nil {
+				return nil, readError(err)
+			}
+			if part.FormName() == avatarFormField {
+				src = part
+				break
+			}
+		}
+	}
+
+	data, err := io.ReadAll(src)
+	if err != nil {
+		return nil, readError(err)
+	}
+	return data, nil
+}
+
+func readError(err error) error {
+	var maxErr *http.MaxBytesError
+	if errors.As(err, &maxErr) {
+		return service.ErrAvatarTooLarge
+	}
+	return httpx.BadRequest("could not read avatar upload")
+}
@@ -9,6 +9,7 @@ import (
 // GetCurrentUser godoc
 //
 //	@Summary		Get current user
+//	@ID				getCurrentUser
 //	@Description	Returns the authenticated user's profile
 //	@Tags			user
 //	@Produce		json
@@ -13,21 +13,26 @@ import (
 )
 
 type Module struct {
-	h          *handler.Handler
-	userCache  *usercache.UserCache
-	bus        pubsub.Bus
+	h         *handler.Handler
+	userCache *usercache.UserCache
+	bus       pubsub.Bus
+	auth      *httpx.Authenticator
 }
 
-func NewModule(h *handler.Handler, c *usercache.UserCache, bus pubsub.Bus) *Module {
+func NewModule(h *handler.Handler, c *usercache.UserCache, bus pubsub.Bus, auth *httpx.Authenticator) *Module {
 	return &Module{
 		h:         h,
 		userCache: c,
 		bus:       bus,
+		auth:      auth,
 	}
 }
 
 func (m *Module) Routes(mux *http.ServeMux) {
-	mux.HandleFunc("GET /users/me", httpx.RequireAuth(m.h.GetCurrentUser))
+	mux.HandleFunc("GET /users/me", m.auth.RequireAuth(m.h.GetCurrentUser, domain.ScopeUsersRead))
+	mux.HandleFunc("PUT /users/me/avatar", m.auth.RequireAuth(m.h.SetCurrentUserAvatar, domain.ScopeUsersWrite))
+	mux.HandleFunc("DELETE /users/me/avatar", m.auth.RequireAuth(m.h.DeleteCurrentUserAvatar, domain.ScopeUsersWrite))
+	mux.HandleFunc("GET /users/{id}/avatar", m.auth.RequireAuth(m.h.GetUserAvatar, domain.ScopeUsersRead))
 }
 
 func (m *Module) StartSubscriber(ctx context.Context) {
@@ -39,8 +44,11 @@ func (m *Module) StartSubscriber(ctx context.Context) {
 		}
 
 		switch e.Type {
-		case pubsub.UserCreated, pubsub.UserUpdated, pubsub.UserDeleted:
+		case pubsub.UserCreated:
 			m.userCache.InvalidateSingleUser(ctx, user.ID)
+		case pubsub.UserUpdated, pubsub.UserDeleted:
+			m.userCache.InvalidateSingleUser(ctx, user.ID)
+			m.auth.ForgetUser(user.ID)
 		}
 		return nil
 	}
@@ -3,3 +3,15 @@
 //   sqlc v1.30.0
 
 package repository
+
+import (
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type UserAvatar struct {
+	UserID      pgtype.UUID        `db:"user_id" json:"user_id"`
+	ContentType string             `db:"content_type" json:"content_type"`
+	Data        []byte             `db:"data" json:"data"`
+	CreatedAt   pgtype.Timestamptz `db:"created_at" json:"created_at"`
+	UpdatedAt   pgtype.Timestamptz `db:"updated_at" json:"updated_at"`
+}
@@ -36,17 +36,18 @@ func (q *Queries) CountUsers(ctx context.Context, arg CountUsersParams) (int64,
 
 const createUser = `-- name: CreateUser :one
 INSERT INTO
-    users (email, display_name, password_hash)
+    users (email, display_name, password_hash, id)
 VALUES
-    ($1, $2, $3)
+    ($1, $2, $3, $4)
 RETURNING
     id, email, display_name, password_hash, created_at, updated_at
 `
 
 type CreateUserParams struct {
-	Email        string `db:"email" json:"email"`
-	DisplayName  string `db:"display_name" json:"display_name"`
-	PasswordHash string `db:"password_hash" json:"password_hash"`
+	Email        string      `db:"email" json:"email"`
+	DisplayName  string      `db:"display_name" json:"display_name"`
+	PasswordHash string      `db:"password_hash" json:"password_hash"`
+	ID           pgtype.UUID `db:"id" json:"id"`
 }
 
 type CreateUserRow struct {
@@ -59,7 +60,7 @@ type CreateUserRow struct {
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
-	row := q.db.QueryRow(ctx, createUser, arg.Email, arg.DisplayName, arg.PasswordHash)
+	row := q.db.QueryRow(ctx, createUser, arg.Email, arg.DisplayName, arg.PasswordHash, arg.ID)
 	var i CreateUserRow
 	err := row.Scan(
 		&i.ID,
@@ -86,6 +87,20 @@ func (q *Queries) DeleteUser(ctx context.Context, id pgtype.UUID) error {
 	return err
 }
 
+const deleteUserAvatar = `-- name: DeleteUserAvatar :execrows
+DELETE FROM user_avatars
+WHERE
+    user_id = $1
+`
+
+func (q *Queries) DeleteUserAvatar(ctx context.Context, userID pgtype.UUID) (int64, error) {
+	result, err := q.db.Exec(ctx, deleteUserAvatar, userID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getUser = `-- name: GetUser :one
 SELECT
     id, email, display_name, password_hash, created_at, updated_at
@@ -121,6 +136,28 @@ func (q *Queries) GetUser(ctx context.Context, id pgtype.UUID) (GetUserRow, erro
 	return i, err
 }
 
+const getUserAvatar = `-- name: GetUserAvatar :one
+SELECT
+    user_id, content_type, data, created_at, updated_at
+FROM
+    user_avatars
+WHERE
+    user_id = $1
+`
+
+func (q *Queries) GetUserAvatar(ctx context.Context, userID pgtype.UUID) (UserAvatar, error) {
+	row := q.db.QueryRow(ctx, getUserAvatar, userID)
+	var i UserAvatar
+	err := row.Scan(
+		&i.UserID,
+		&i.ContentType,
+		&i.Data,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const getUserByEmail = `-- name: GetUserByEmail :one
 SELECT
     id, email, display_name, password_hash, created_at, updated_at
@@ -223,8 +260,7 @@ const updateUser = `-- name: UpdateUser :one
 UPDATE users
 SET
     display_name = COALESCE(NULLIF($1, ''), display_name),
-    password_hash = COALESCE(NULLIF($2, ''), password_hash),
-    updated_at = NOW()
+    password_hash = COALESCE(NULLIF($2, ''), password_hash)
 WHERE
     id = $3
     AND deleted_at IS NULL
@@ -260,3 +296,35 @@ func (q *Queries) UpdateUser(ctx context.Context, arg UpdateUserParams) (UpdateU
 	)
 	return i, err
 }
+
+const upsertUserAvatar = `-- name: UpsertUserAvatar :one
+INSERT INTO
+    user_avatars (user_id, content_type, data)
+VALUES
+    ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET
+    content_type = EXCLUDED.content_type,
+    data = EXCLUDED.data
+RETURNING
+    user_id, content_type, data, created_at, updated_at
+`
+
+type UpsertUserAvatarParams struct {
+	UserID      pgtype.UUID `db:"user_id" json:"user_id"`
+	ContentType string      `db:"content_type" json:"content_type"`
+	Data        []byte      `db:"data" json:"data"`
+}
+
+func (q *Queries) UpsertUserAvatar(ctx context.Context, arg UpsertUserAvatarParams) (UserAvatar, error) {
+	row := q.db.QueryRow(ctx, upsertUserAvatar, arg.UserID, arg.ContentType, arg.Data)
+	var i UserAvatar
+	err := row.Scan(
+		&i.UserID,
+		&i.ContentType,
+		&i.Data,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+
+	"github.com/dimasbaguspm/fluxis/internal/user/repository"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/identicon"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// MaxAvatarSize caps an uploaded avatar, chips render it at a few dozen pixels
+// so anything larger is wasted bytes on every board load
+const MaxAvatarSize = 1 << 20 // 1MB
+
+// avatarTypes are the formats browsers render in an <img>. SVG is left out on
+// purpose, it can carry script.
+var avatarTypes = []string{"image/png", "image/jpeg", "image/gif", "image/webp"}
+
+var (
+	ErrAvatarEmpty       = domain.Invalid("avatar image is empty").WithCode("avatar_empty")
+	ErrAvatarTooLarge    = domain.TooLarge("avatar image is too large").WithCode("avatar_too_large")
+	ErrAvatarUnsupported = domain.Invalid("avatar must be a png, jpeg, gif or webp image").WithCode("avatar_unsupported_type")
+	ErrAvatarNotFound    = domain.NotFound("avatar not found")
+)
+
+var _ domain.UserAvatarRead = (*Service)(nil)
+var _ domain.UserAvatarWrite = (*Service)(nil)
+
+// GetUserAvatar returns the uploaded avatar of a user, or an identicon seeded
+// by their ID when they have none.
+func (s *Service) GetUserAvatar(ctx context.Context, userID pgtype.UUID) (domain.UserAvatarModel, error) {
+	avatar, err := s.Repo.GetUserAvatar(ctx, userID)
+	if err == nil {
+		return toAvatarModel(avatar), nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return domain.UserAvatarModel{}, fmt.Errorf("get user avatar: %w", err)
+	}
+
+	user, err := s.GetSingleUserById(ctx, userID)
+	if err != nil {
+		return domain.UserAvatarModel{}, err
+	}
+
+	data, err := identicon.PNG(userID.Bytes[:], identicon.DefaultSize)
+	if err != nil {
+		return domain.UserAvatarModel{}, fmt.Errorf("generate identicon: %w", err)
+	}
+	return domain.UserAvatarModel{
+		UserID:      userID,
+		ContentType: "image/png",
+		Size:        len(data),
+		Generated:   true,
+		Data:        data,
+		// the image only depends on the ID so it never changes after signup
+		UpdatedAt: user.CreatedAt,
+	}, nil
+}
+
+// SetUserAvatar stores data as the user's avatar, replacing any previous one.
+// The format is sniffed from the bytes, the client supplied type is ignored.
+func (s *Service) SetUserAvatar(ctx context.Context, userID pgtype.UUID, data []byte) (domain.UserAvatarModel, error) {
+	if len(data) == 0 {
+		return domain.UserAvatarModel{}, ErrAvatarEmpty
+	}
+	if len(data) > MaxAvatarSize {
+		return domain.UserAvatarModel{}, ErrAvatarTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if !slices.Contains(avatarTypes, contentType) {
+		return domain.UserAvatarModel{}, ErrAvatarUnsupported
+	}
+
+	avatar, err := s.Repo.UpsertUserAvatar(ctx, repository.UpsertUserAvatarParams{
+		UserID:      userID,
+		ContentType: contentType,
+		Data:        data,
+	})
+	if err != nil {
+		return domain.UserAvatarModel{}, fmt.Errorf("upsert user avatar: %w", err)
+	}
+
+	return toAvatarModel(avatar), nil
+}
+
+func (s *Service) DeleteUserAvatar(ctx context.Context, userID pgtype.UUID) error {
+	n, err := s.Repo.DeleteUserAvatar(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("delete user avatar: %w", err)
+	}
+	if n == 0 {
+		return ErrAvatarNotFound
+	}
+	return nil
+}
+
+func toAvatarModel(a repository.UserAvatar) domain.UserAvatarModel {
+	return domain.UserAvatarModel{
+		UserID:      a.UserID,
+		ContentType: a.ContentType,
+		Size:        len(a.Data),
+		Data:        a.Data,
+		UpdatedAt:   a.UpdatedAt.Time,
+	}
+}
@@ -3,10 +3,12 @@ package service
 import (
 	"github.com/dimasbaguspm/fluxis/internal/user/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
 )
 
 type Deps struct {
 	Repo *repository.Queries
+	Bus  pubsub.Publisher
 }
 
 type Service struct {
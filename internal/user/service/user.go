@@ -4,18 +4,21 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/dimasbaguspm/fluxis/internal/user/repository"
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
-	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/dimasbaguspm/fluxis/pkg/pubsub"
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 var (
-	ErrEmailTaken   = httpx.Conflict("email already registerd")
-	ErrUserNotFound = httpx.NotFound("user not found")
+	ErrEmailTaken   = domain.Conflict("email already registerd")
+	ErrUserNotFound = domain.NotFound("user not found")
 )
 
 func (s *Service) GetSingleUserById(ctx context.Context, id pgtype.UUID) (domain.UserModel, error) {
@@ -57,6 +60,7 @@ func (s *Service) GetSingleUserByEmail(ctx context.Context, email string) (domai
 
 func (s *Service) CreateUser(ctx context.Context, p domain.UserCreateModel) (domain.UserModel, error) {
 	user, err := s.Repo.CreateUser(ctx, repository.CreateUserParams{
+		ID:          idgen.New(),
 		Email:       p.Email,
 		DisplayName: p.DisplayName,
 		// hash handled by auth service
@@ -96,6 +100,10 @@ func (s *Service) UpdateUser(ctx context.Context, id pgtype.UUID, p domain.UserU
 		return domain.UserModel{}, fmt.Errorf("update user: %w", err)
 	}
 
+	// subscribers drop what they hold for the user, such as remembered
+	// credentials when the password changed
+	s.publish(ctx, pubsub.UserUpdated, id)
+
 	return domain.UserModel{
 		ID:          user.ID,
 		Email:       user.Email,
@@ -114,5 +122,14 @@ func (s *Service) DeleteUser(ctx context.Context, id pgtype.UUID) error {
 		return fmt.Errorf("delete user: %w", err)
 	}
 
+	s.publish(ctx, pubsub.UserDeleted, id)
 	return nil
 }
+
+// publish announces a change of the user; the payload only carries the ID,
+// never the password hash
+func (s *Service) publish(ctx context.Context, t pubsub.EventType, id pgtype.UUID) {
+	if err := s.Bus.Publish(ctx, t, map[string]string{"id": uuid.UUID(id.Bytes).String()}); err != nil {
+		slog.Warn("[EventBus]: failed to publish event", "type", string(t), "error", err)
+	}
+}
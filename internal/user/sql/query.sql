@@ -46,9 +46,9 @@ WHERE
 
 -- name: CreateUser :one
 INSERT INTO
-    users (email, display_name, password_hash)
+    users (email, display_name, password_hash, id)
 VALUES
-    ($1, $2, $3)
+    ($1, $2, $3, $4)
 RETURNING
     id, email, display_name, password_hash, created_at, updated_at;
 
@@ -56,8 +56,7 @@ RETURNING
 UPDATE users
 SET
     display_name = COALESCE(NULLIF($1, ''), display_name),
-    password_hash = COALESCE(NULLIF($2, ''), password_hash),
-    updated_at = NOW()
+    password_hash = COALESCE(NULLIF($2, ''), password_hash)
 WHERE
     id = $3
     AND deleted_at IS NULL
@@ -70,4 +69,29 @@ SET
     deleted_at = NOW()
 WHERE
     id = $1
-    AND deleted_at IS NULL;
\ No newline at end of file
+    AND deleted_at IS NULL;
+
+-- name: GetUserAvatar :one
+SELECT
+    user_id, content_type, data, created_at, updated_at
+FROM
+    user_avatars
+WHERE
+    user_id = $1;
+
+-- name: UpsertUserAvatar :one
+INSERT INTO
+    user_avatars (user_id, content_type, data)
+VALUES
+    ($1, $2, $3)
+ON CONFLICT (user_id) DO UPDATE
+SET
+    content_type = EXCLUDED.content_type,
+    data = EXCLUDED.data
+RETURNING
+    user_id, content_type, data, created_at, updated_at;
+
+-- name: DeleteUserAvatar :execrows
+DELETE FROM user_avatars
+WHERE
+    user_id = $1;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_projects_status;
+ALTER TABLE projects DROP COLUMN IF EXISTS status;
+DROP TYPE IF EXISTS project_status;
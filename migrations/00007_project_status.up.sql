@@ -0,0 +1,5 @@
+CREATE TYPE project_status AS ENUM ('active', 'paused', 'archived');
+
+ALTER TABLE projects ADD COLUMN status project_status NOT NULL DEFAULT 'active';
+
+CREATE INDEX idx_projects_status ON projects (status);
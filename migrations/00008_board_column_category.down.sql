@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_tickets_due_date;
+DROP INDEX IF EXISTS idx_board_columns_category;
+ALTER TABLE board_columns DROP COLUMN IF EXISTS category;
+DROP TYPE IF EXISTS board_column_category;
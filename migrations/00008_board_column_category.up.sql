@@ -0,0 +1,6 @@
+CREATE TYPE board_column_category AS ENUM ('todo', 'in_progress', 'done');
+
+ALTER TABLE board_columns ADD COLUMN category board_column_category NOT NULL DEFAULT 'todo';
+
+CREATE INDEX idx_board_columns_category ON board_columns (category) WHERE deleted_at IS NULL;
+CREATE INDEX idx_tickets_due_date ON tickets (due_date) WHERE deleted_at IS NULL AND due_date IS NOT NULL;
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS project_ui_states (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    state JSONB NOT NULL DEFAULT '{}'::jsonb,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, project_id),
+    CONSTRAINT project_ui_states_state_size CHECK (octet_length(state::text) <= 16384)
+);
+
+CREATE INDEX idx_project_ui_states_project_id ON project_ui_states(project_id);
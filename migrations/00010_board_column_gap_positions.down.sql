@@ -0,0 +1,8 @@
+UPDATE board_columns
+SET position = ranked.pos
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY board_id ORDER BY position, created_at) - 1 AS pos
+    FROM board_columns
+    WHERE deleted_at IS NULL
+) ranked
+WHERE board_columns.id = ranked.id;
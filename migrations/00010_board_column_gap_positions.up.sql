@@ -0,0 +1,10 @@
+-- Columns are ordered by gap based positions so a single column can move
+-- between two neighbours without rewriting the rest of the board
+UPDATE board_columns
+SET position = ranked.pos
+FROM (
+    SELECT id, (ROW_NUMBER() OVER (PARTITION BY board_id ORDER BY position, created_at) - 1) * 1024 AS pos
+    FROM board_columns
+    WHERE deleted_at IS NULL
+) ranked
+WHERE board_columns.id = ranked.id;
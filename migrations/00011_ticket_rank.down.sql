@@ -0,0 +1,2 @@
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_board_column_rank_key;
+ALTER TABLE tickets DROP COLUMN IF EXISTS rank;
@@ -0,0 +1,15 @@
+-- Tickets are ordered inside a column by a lexicographic rank (see pkg/rank),
+-- the C collation keeps comparisons byte-wise so Postgres sorts the same way Go does
+ALTER TABLE tickets ADD COLUMN rank TEXT COLLATE "C";
+
+UPDATE tickets
+SET rank = ranked.rank
+FROM (
+    SELECT id, lpad((ROW_NUMBER() OVER (PARTITION BY board_column_id ORDER BY ticket_number DESC))::text, 6, '0') || 'i' AS rank
+    FROM tickets
+    WHERE board_column_id IS NOT NULL AND deleted_at IS NULL
+) ranked
+WHERE tickets.id = ranked.id;
+
+-- Deferrable so a rebalance can rewrite a whole column in one statement
+ALTER TABLE tickets ADD CONSTRAINT tickets_board_column_rank_key UNIQUE (board_column_id, rank) DEFERRABLE INITIALLY IMMEDIATE;
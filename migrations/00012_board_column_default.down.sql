@@ -0,0 +1,2 @@
+ALTER TABLE board_columns DROP CONSTRAINT IF EXISTS board_columns_single_default;
+ALTER TABLE board_columns DROP COLUMN IF EXISTS is_default;
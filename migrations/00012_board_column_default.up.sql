@@ -0,0 +1,19 @@
+-- Each board has exactly one default column, the first live column becomes it
+ALTER TABLE board_columns ADD COLUMN is_default BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE board_columns
+SET is_default = true
+FROM (
+    SELECT DISTINCT ON (board_id) id
+    FROM board_columns
+    WHERE deleted_at IS NULL
+    ORDER BY board_id, position, created_at
+) first_column
+WHERE board_columns.id = first_column.id;
+
+-- Same rule as a partial unique index on (board_id) WHERE is_default, but a unique index
+-- cannot be deferred; checking at the end of the statement lets a single UPDATE move the
+-- flag from one column to another
+ALTER TABLE board_columns ADD CONSTRAINT board_columns_single_default
+    EXCLUDE USING btree (board_id WITH =) WHERE (is_default AND deleted_at IS NULL)
+    DEFERRABLE INITIALLY IMMEDIATE;
@@ -0,0 +1,2 @@
+-- Supports case-insensitive exact name lookups and the optional unique name check
+CREATE INDEX idx_projects_name_lower ON projects (lower(name)) WHERE deleted_at IS NULL;
@@ -0,0 +1,10 @@
+CREATE TYPE ticket_priority AS ENUM ('low', 'medium', 'high', 'critical');
+
+ALTER TABLE tickets DROP CONSTRAINT IF EXISTS tickets_priority_fkey;
+UPDATE tickets SET priority = 'medium' WHERE priority NOT IN ('low', 'medium', 'high', 'critical');
+ALTER TABLE tickets ALTER COLUMN priority TYPE ticket_priority USING priority::ticket_priority;
+
+DROP TRIGGER IF EXISTS projects_default_priorities ON projects;
+DROP FUNCTION IF EXISTS projects_default_priorities();
+DROP FUNCTION IF EXISTS create_default_project_priorities(UUID);
+DROP TABLE IF EXISTS project_priorities;
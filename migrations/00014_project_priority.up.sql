@@ -0,0 +1,46 @@
+-- Priority levels are defined per project; tickets reference one by key
+CREATE TABLE IF NOT EXISTS project_priorities (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    key VARCHAR(32) NOT NULL,
+    name VARCHAR(50) NOT NULL,
+    color VARCHAR(7) NOT NULL,
+    position INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT project_priorities_project_key UNIQUE (project_id, key)
+);
+
+-- The old fixed levels become every project's starting scheme
+CREATE OR REPLACE FUNCTION create_default_project_priorities(p_project_id UUID)
+RETURNS VOID AS $$
+BEGIN
+    INSERT INTO project_priorities (project_id, key, name, color, position)
+    VALUES
+        (p_project_id, 'critical', 'Critical', '#dc2626', 1024),
+        (p_project_id, 'high', 'High', '#ea580c', 2048),
+        (p_project_id, 'medium', 'Medium', '#ca8a04', 3072),
+        (p_project_id, 'low', 'Low', '#2563eb', 4096)
+    ON CONFLICT (project_id, key) DO NOTHING;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION projects_default_priorities()
+RETURNS TRIGGER AS $$
+BEGIN
+    PERFORM create_default_project_priorities(NEW.id);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER projects_default_priorities
+    AFTER INSERT ON projects
+    FOR EACH ROW EXECUTE FUNCTION projects_default_priorities();
+
+SELECT create_default_project_priorities(id) FROM projects;
+
+ALTER TABLE tickets ALTER COLUMN priority TYPE VARCHAR(32) USING priority::text;
+ALTER TABLE tickets ADD CONSTRAINT tickets_priority_fkey
+    FOREIGN KEY (project_id, priority) REFERENCES project_priorities (project_id, key);
+
+DROP TYPE IF EXISTS ticket_priority;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_push_subscriptions_user_id;
+
+DROP TABLE IF EXISTS push_subscriptions;
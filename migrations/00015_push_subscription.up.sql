@@ -0,0 +1,15 @@
+-- One row per browser or device a user enabled push notifications on; the
+-- endpoint is issued by the push service and identifies the device
+CREATE TABLE IF NOT EXISTS push_subscriptions (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    endpoint TEXT NOT NULL,
+    p256dh TEXT NOT NULL,
+    auth TEXT NOT NULL,
+    user_agent VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT push_subscriptions_endpoint_key UNIQUE (endpoint)
+);
+
+CREATE INDEX idx_push_subscriptions_user_id ON push_subscriptions (user_id);
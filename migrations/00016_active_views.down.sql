@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS active_board_columns;
+
+DROP VIEW IF EXISTS active_tickets;
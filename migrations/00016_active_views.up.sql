@@ -0,0 +1,28 @@
+-- Live rows together with their parents. Deleting a project, sprint or board
+-- only stamps deleted_at, so reading the base tables directly also returns
+-- rows that hang under a deleted parent; read through these views instead.
+-- Columns are listed explicitly because a view keeps the column list it was
+-- created with, so a new table column has to be added here as well.
+
+CREATE OR REPLACE VIEW active_tickets AS
+SELECT
+    t.id, t.project_id, t.ticket_number, t.key, t.sprint_id, t.board_id, t.board_column_id,
+    t.type, t.priority, t.title, t.description, t.assignee_id, t.reporter_id, t.epic_id,
+    t.parent_id, t.story_points, t.due_date, t.created_at, t.updated_at, t.rank
+FROM
+    tickets t
+    JOIN projects p ON p.id = t.project_id AND p.deleted_at IS NULL
+WHERE
+    t.deleted_at IS NULL;
+
+CREATE OR REPLACE VIEW active_board_columns AS
+SELECT
+    bc.id, bc.board_id, bc.name, bc.position, bc.category, bc.is_default,
+    bc.created_at, bc.updated_at, s.project_id
+FROM
+    board_columns bc
+    JOIN boards b ON b.id = bc.board_id AND b.deleted_at IS NULL
+    JOIN sprints s ON s.id = b.sprint_id AND s.deleted_at IS NULL
+    JOIN projects p ON p.id = s.project_id AND p.deleted_at IS NULL
+WHERE
+    bc.deleted_at IS NULL;
@@ -0,0 +1,39 @@
+ALTER TABLE tickets
+    DROP CONSTRAINT tickets_reporter_id_fkey,
+    ADD CONSTRAINT tickets_reporter_id_fkey
+        FOREIGN KEY (reporter_id) REFERENCES users (id) ON DELETE CASCADE;
+
+ALTER TABLE tickets
+    DROP CONSTRAINT IF EXISTS tickets_epic_not_self,
+    DROP CONSTRAINT IF EXISTS tickets_parent_not_self,
+    DROP CONSTRAINT IF EXISTS tickets_story_points_check,
+    DROP CONSTRAINT IF EXISTS tickets_ticket_number_check,
+    DROP CONSTRAINT IF EXISTS tickets_title_not_blank;
+
+ALTER TABLE ticket_counters
+    DROP CONSTRAINT IF EXISTS ticket_counters_next_number_check;
+
+ALTER TABLE board_columns
+    DROP CONSTRAINT IF EXISTS board_columns_name_not_blank;
+
+ALTER TABLE boards
+    DROP CONSTRAINT IF EXISTS boards_position_check,
+    DROP CONSTRAINT IF EXISTS boards_name_not_blank;
+
+ALTER TABLE sprints
+    DROP CONSTRAINT IF EXISTS sprints_dates_check,
+    DROP CONSTRAINT IF EXISTS sprints_planned_dates_check,
+    DROP CONSTRAINT IF EXISTS sprints_name_not_blank;
+
+ALTER TABLE project_priorities
+    DROP CONSTRAINT IF EXISTS project_priorities_color_check,
+    DROP CONSTRAINT IF EXISTS project_priorities_position_check,
+    DROP CONSTRAINT IF EXISTS project_priorities_key_not_blank;
+
+ALTER TABLE projects
+    DROP CONSTRAINT IF EXISTS projects_key_not_blank,
+    DROP CONSTRAINT IF EXISTS projects_name_not_blank;
+
+ALTER TABLE orgs
+    DROP CONSTRAINT IF EXISTS orgs_slug_not_blank,
+    DROP CONSTRAINT IF EXISTS orgs_name_not_blank;
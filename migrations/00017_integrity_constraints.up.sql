@@ -0,0 +1,53 @@
+-- Enforce in the database what the services already validate, so a missed
+-- check or a direct write can not store a row the API would reject. Status
+-- and category values are already guarded by their enum types.
+
+ALTER TABLE orgs
+    ADD CONSTRAINT orgs_name_not_blank CHECK (name <> ''),
+    ADD CONSTRAINT orgs_slug_not_blank CHECK (slug <> '');
+
+ALTER TABLE projects
+    ADD CONSTRAINT projects_name_not_blank CHECK (name <> ''),
+    ADD CONSTRAINT projects_key_not_blank CHECK (key <> '');
+
+ALTER TABLE project_priorities
+    ADD CONSTRAINT project_priorities_key_not_blank CHECK (key <> ''),
+    ADD CONSTRAINT project_priorities_position_check CHECK (position >= 1),
+    ADD CONSTRAINT project_priorities_color_check CHECK (color ~ '^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6})$');
+
+ALTER TABLE sprints
+    ADD CONSTRAINT sprints_name_not_blank CHECK (name <> '');
+
+-- Older rows were written before the services compared these dates, so the
+-- date checks only apply to new writes
+ALTER TABLE sprints
+    ADD CONSTRAINT sprints_planned_dates_check
+        CHECK (planned_completed_at >= planned_started_at) NOT VALID,
+    ADD CONSTRAINT sprints_dates_check
+        CHECK (completed_at >= started_at) NOT VALID;
+
+ALTER TABLE boards
+    ADD CONSTRAINT boards_name_not_blank CHECK (name <> ''),
+    ADD CONSTRAINT boards_position_check CHECK (position >= 0);
+
+-- Column positions are left unbounded, moving a column in front of the first
+-- one places it below that column's position, which may already be 0
+ALTER TABLE board_columns
+    ADD CONSTRAINT board_columns_name_not_blank CHECK (name <> '');
+
+ALTER TABLE ticket_counters
+    ADD CONSTRAINT ticket_counters_next_number_check CHECK (next_number >= 1);
+
+ALTER TABLE tickets
+    ADD CONSTRAINT tickets_title_not_blank CHECK (title <> ''),
+    ADD CONSTRAINT tickets_ticket_number_check CHECK (ticket_number >= 1),
+    ADD CONSTRAINT tickets_story_points_check CHECK (story_points >= 0),
+    ADD CONSTRAINT tickets_parent_not_self CHECK (parent_id <> id),
+    ADD CONSTRAINT tickets_epic_not_self CHECK (epic_id <> id);
+
+-- Reporters were cascaded, so hard deleting a user silently took every
+-- ticket they ever filed with them; keep the tickets and make the delete fail
+ALTER TABLE tickets
+    DROP CONSTRAINT tickets_reporter_id_fkey,
+    ADD CONSTRAINT tickets_reporter_id_fkey
+        FOREIGN KEY (reporter_id) REFERENCES users (id) ON DELETE RESTRICT;
@@ -0,0 +1,12 @@
+DROP TRIGGER IF EXISTS tickets_set_updated_at ON tickets;
+DROP TRIGGER IF EXISTS push_subscriptions_set_updated_at ON push_subscriptions;
+DROP TRIGGER IF EXISTS board_columns_set_updated_at ON board_columns;
+DROP TRIGGER IF EXISTS boards_set_updated_at ON boards;
+DROP TRIGGER IF EXISTS sprints_set_updated_at ON sprints;
+DROP TRIGGER IF EXISTS project_ui_states_set_updated_at ON project_ui_states;
+DROP TRIGGER IF EXISTS project_priorities_set_updated_at ON project_priorities;
+DROP TRIGGER IF EXISTS projects_set_updated_at ON projects;
+DROP TRIGGER IF EXISTS orgs_set_updated_at ON orgs;
+DROP TRIGGER IF EXISTS users_set_updated_at ON users;
+
+DROP FUNCTION IF EXISTS set_updated_at();
@@ -0,0 +1,55 @@
+-- updated_at is maintained by the database so an UPDATE can not forget it
+CREATE OR REPLACE FUNCTION set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    NEW.updated_at = NOW();
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER users_set_updated_at
+    BEFORE UPDATE ON users
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER orgs_set_updated_at
+    BEFORE UPDATE ON orgs
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER projects_set_updated_at
+    BEFORE UPDATE ON projects
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER project_priorities_set_updated_at
+    BEFORE UPDATE ON project_priorities
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER project_ui_states_set_updated_at
+    BEFORE UPDATE ON project_ui_states
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER sprints_set_updated_at
+    BEFORE UPDATE ON sprints
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER boards_set_updated_at
+    BEFORE UPDATE ON boards
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER board_columns_set_updated_at
+    BEFORE UPDATE ON board_columns
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+CREATE TRIGGER push_subscriptions_set_updated_at
+    BEFORE UPDATE ON push_subscriptions
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- A ticket's rank only orders it inside its column. Reports read updated_at
+-- as the last activity on a ticket, so re-spacing or reordering ranks must
+-- not make a whole column look freshly touched; every other column counts.
+CREATE TRIGGER tickets_set_updated_at
+    BEFORE UPDATE OF
+        project_id, ticket_number, key, sprint_id, board_id, board_column_id, type, priority,
+        title, description, assignee_id, reporter_id, epic_id, parent_id, story_points, due_date,
+        deleted_at
+    ON tickets
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,10 @@
+DROP TRIGGER IF EXISTS changes_assign_seq ON changes;
+DROP TRIGGER IF EXISTS board_columns_record_change ON board_columns;
+DROP TRIGGER IF EXISTS tickets_record_change ON tickets;
+
+DROP FUNCTION IF EXISTS assign_change_seq();
+DROP FUNCTION IF EXISTS record_board_column_change();
+DROP FUNCTION IF EXISTS record_ticket_change();
+
+DROP SEQUENCE IF EXISTS changes_seq;
+DROP TABLE IF EXISTS changes;
@@ -0,0 +1,95 @@
+-- changes records which tickets and board columns were written so clients
+-- that can not hold a stream open can ask what happened since a cursor.
+-- project_id is not a foreign key: a hard deleted project cascades into its
+-- tickets, whose delete rows must still be written.
+CREATE TABLE IF NOT EXISTS changes (
+    id BIGSERIAL PRIMARY KEY,
+    seq BIGINT UNIQUE,
+    project_id UUID NOT NULL,
+    entity VARCHAR(32) NOT NULL,
+    entity_id UUID NOT NULL,
+    op VARCHAR(8) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_changes_project_seq ON changes (project_id, seq) WHERE seq IS NOT NULL;
+CREATE INDEX idx_changes_pending ON changes (id) WHERE seq IS NULL;
+
+-- A bigserial is handed out when a row is inserted, not when it commits, so
+-- a reader could see 5 before a slower transaction commits 4 and skip it for
+-- good once its cursor passed 5. seq is therefore only assigned at commit,
+-- one transaction at a time, which makes it grow in commit order and lets a
+-- reader treat everything up to the highest seq it sees as settled.
+CREATE SEQUENCE IF NOT EXISTS changes_seq;
+
+CREATE OR REPLACE FUNCTION record_ticket_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    r tickets%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    INSERT INTO changes (project_id, entity, entity_id, op)
+    VALUES (r.project_id, 'ticket', r.id, lower(TG_OP));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- A column reaches its project through its board's sprint. When the board is
+-- gone in the same statement, e.g. a cascading delete, the column is dropped
+-- from the log; its tickets still record their own change.
+CREATE OR REPLACE FUNCTION record_board_column_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    r board_columns%ROWTYPE;
+    pid UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    SELECT s.project_id INTO pid
+    FROM boards b
+    JOIN sprints s ON s.id = b.sprint_id
+    WHERE b.id = r.board_id;
+    IF pid IS NOT NULL THEN
+        INSERT INTO changes (project_id, entity, entity_id, op)
+        VALUES (pid, 'board_column', r.id, lower(TG_OP));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Runs at commit. The advisory lock is held until the transaction ends, so
+-- a transaction numbering its rows has committed before the next one starts.
+-- Rows of other uncommitted transactions are invisible here and every
+-- committed row already has a seq, so only this transaction's rows match.
+CREATE OR REPLACE FUNCTION assign_change_seq()
+RETURNS TRIGGER AS $$
+DECLARE
+    pending BIGINT;
+BEGIN
+    PERFORM pg_advisory_xact_lock(hashtext('changes_seq'));
+    FOR pending IN SELECT id FROM changes WHERE seq IS NULL ORDER BY id LOOP
+        UPDATE changes SET seq = nextval('changes_seq') WHERE id = pending;
+    END LOOP;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER tickets_record_change
+    AFTER INSERT OR UPDATE OR DELETE ON tickets
+    FOR EACH ROW EXECUTE FUNCTION record_ticket_change();
+
+CREATE TRIGGER board_columns_record_change
+    AFTER INSERT OR UPDATE OR DELETE ON board_columns
+    FOR EACH ROW EXECUTE FUNCTION record_board_column_change();
+
+CREATE CONSTRAINT TRIGGER changes_assign_seq
+    AFTER INSERT ON changes
+    DEFERRABLE INITIALLY DEFERRED
+    FOR EACH ROW EXECUTE FUNCTION assign_change_seq();
@@ -0,0 +1,7 @@
+DROP TRIGGER IF EXISTS boards_record_change ON boards;
+DROP TRIGGER IF EXISTS sprints_record_change ON sprints;
+DROP TRIGGER IF EXISTS projects_record_change ON projects;
+
+DROP FUNCTION IF EXISTS record_board_change();
+DROP FUNCTION IF EXISTS record_sprint_change();
+DROP FUNCTION IF EXISTS record_project_change();
@@ -0,0 +1,68 @@
+-- The global change feed replicates everything a client shows, so projects,
+-- sprints and boards are recorded alongside tickets and board columns
+CREATE OR REPLACE FUNCTION record_project_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    r projects%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    INSERT INTO changes (project_id, entity, entity_id, op)
+    VALUES (r.id, 'project', r.id, lower(TG_OP));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION record_sprint_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    r sprints%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    INSERT INTO changes (project_id, entity, entity_id, op)
+    VALUES (r.project_id, 'sprint', r.id, lower(TG_OP));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+-- Like board columns, a board deleted together with its sprint is dropped
+-- from the log; the sprint records its own delete
+CREATE OR REPLACE FUNCTION record_board_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    r boards%ROWTYPE;
+    pid UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    SELECT project_id INTO pid FROM sprints WHERE id = r.sprint_id;
+    IF pid IS NOT NULL THEN
+        INSERT INTO changes (project_id, entity, entity_id, op)
+        VALUES (pid, 'board', r.id, lower(TG_OP));
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER projects_record_change
+    AFTER INSERT OR UPDATE OR DELETE ON projects
+    FOR EACH ROW EXECUTE FUNCTION record_project_change();
+
+CREATE TRIGGER sprints_record_change
+    AFTER INSERT OR UPDATE OR DELETE ON sprints
+    FOR EACH ROW EXECUTE FUNCTION record_sprint_change();
+
+CREATE TRIGGER boards_record_change
+    AFTER INSERT OR UPDATE OR DELETE ON boards
+    FOR EACH ROW EXECUTE FUNCTION record_board_change();
+
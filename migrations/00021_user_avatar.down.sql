@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS user_avatars_set_updated_at ON user_avatars;
+
+DROP TABLE IF EXISTS user_avatars;
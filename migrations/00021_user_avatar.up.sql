@@ -0,0 +1,13 @@
+-- An uploaded profile picture, at most one per user. Users without a row are
+-- served a generated identicon instead, so no default image is stored
+CREATE TABLE IF NOT EXISTS user_avatars (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    content_type VARCHAR(100) NOT NULL,
+    data BYTEA NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER user_avatars_set_updated_at
+    BEFORE UPDATE ON user_avatars
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
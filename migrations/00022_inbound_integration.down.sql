@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS inbound_integrations_set_updated_at ON inbound_integrations;
+
+DROP INDEX IF EXISTS idx_inbound_integrations_project_id;
+
+DROP TABLE IF EXISTS inbound_integrations;
@@ -0,0 +1,21 @@
+-- A signed webhook endpoint that turns a third party's JSON payloads into
+-- ticket writes. The secret has to be readable to verify signatures, so it is
+-- stored as is and only shown to the user once, on create. Writes run as
+-- created_by, so removing that user removes the integration too.
+CREATE TABLE IF NOT EXISTS inbound_integrations (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    secret TEXT NOT NULL,
+    mapping JSONB NOT NULL,
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_delivery_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_inbound_integrations_project_id ON inbound_integrations (project_id);
+
+CREATE TRIGGER inbound_integrations_set_updated_at
+    BEFORE UPDATE ON inbound_integrations
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,5 @@
+COMMENT ON TYPE project_status IS NULL;
+
+DROP TRIGGER IF EXISTS projects_status_transition ON projects;
+
+DROP FUNCTION IF EXISTS check_project_status_transition();
@@ -0,0 +1,30 @@
+-- the lifecycle endpoints check transitions too, this keeps a manual UPDATE
+-- or a future code path from skipping a stage; it mirrors
+-- domain.ProjectStatusTransitions
+CREATE OR REPLACE FUNCTION check_project_status_transition()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF NEW.status = OLD.status THEN
+        RETURN NEW;
+    END IF;
+
+    IF (OLD.status, NEW.status) IN (
+        ('active', 'paused'),
+        ('active', 'archived'),
+        ('paused', 'active'),
+        ('paused', 'archived'),
+        ('archived', 'active')
+    ) THEN
+        RETURN NEW;
+    END IF;
+
+    RAISE EXCEPTION 'project status can not move from % to %', OLD.status, NEW.status
+        USING ERRCODE = 'check_violation', CONSTRAINT = 'projects_status_transition';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER projects_status_transition
+    BEFORE UPDATE OF status ON projects
+    FOR EACH ROW EXECUTE FUNCTION check_project_status_transition();
+
+COMMENT ON TYPE project_status IS 'active: work is in progress; paused: work is on hold; archived: finished and kept for reference';
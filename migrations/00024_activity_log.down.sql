@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_activity_log_project_id;
+
+DROP TABLE IF EXISTS activity_log;
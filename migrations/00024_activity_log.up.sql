@@ -0,0 +1,14 @@
+-- activity_log keeps one entry per action someone took on a project. An
+-- action that writes many rows at once, like a reorder or a bulk delete, is a
+-- single entry whose payload lists the affected IDs; the changes table still
+-- holds a row for each of them.
+CREATE TABLE IF NOT EXISTS activity_log (
+    id BIGSERIAL PRIMARY KEY,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    actor_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    action VARCHAR(64) NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}'::jsonb,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_activity_log_project_id ON activity_log (project_id, id DESC);
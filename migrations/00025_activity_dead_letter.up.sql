@@ -0,0 +1,15 @@
+-- Activity entries that could not be written to activity_log after retrying
+-- are kept here with the last error so they can be inspected or replayed.
+-- Nothing references projects or users: a missing project may be why the
+-- write failed. The action and payload are kept as they came, unchecked, as
+-- a malformed payload or an overlong action may be why too.
+CREATE TABLE IF NOT EXISTS activity_dead_letters (
+    id BIGSERIAL PRIMARY KEY,
+    project_id UUID NOT NULL,
+    actor_id UUID,
+    action TEXT NOT NULL,
+    payload BYTEA NOT NULL,
+    error TEXT NOT NULL,
+    attempts INT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,5 @@
+DROP TRIGGER IF EXISTS activity_webhooks_set_updated_at ON activity_webhooks;
+
+DROP INDEX IF EXISTS idx_activity_webhooks_project_id;
+
+DROP TABLE IF EXISTS activity_webhooks;
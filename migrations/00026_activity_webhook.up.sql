@@ -0,0 +1,21 @@
+-- Outbound webhooks mirroring a project's activity log to an external
+-- collector. Each written entry whose action is listed in actions, or any
+-- entry when actions is empty, is posted to url signed with secret; like an
+-- inbound integration's, the secret is only shown to the user on create.
+CREATE TABLE IF NOT EXISTS activity_webhooks (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    secret TEXT NOT NULL,
+    actions TEXT[] NOT NULL DEFAULT '{}',
+    created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    last_delivery_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_activity_webhooks_project_id ON activity_webhooks (project_id);
+
+CREATE TRIGGER activity_webhooks_set_updated_at
+    BEFORE UPDATE ON activity_webhooks
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_board_snapshots_project_id_date;
+
+DROP TABLE IF EXISTS board_snapshots;
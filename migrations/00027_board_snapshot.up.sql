@@ -0,0 +1,20 @@
+-- Daily per column ticket counts of every live board, so historical WIP and
+-- cumulative flow charts read one row per column and day instead of
+-- replaying the change log. A day's rows are rewritten by every snapshot
+-- taken that day, the last one wins. Name, category and position are copied
+-- since columns get renamed and moved after the fact.
+CREATE TABLE IF NOT EXISTS board_snapshots (
+    snapshot_date DATE NOT NULL,
+    board_column_id UUID NOT NULL REFERENCES board_columns(id) ON DELETE CASCADE,
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    category board_column_category NOT NULL,
+    position INT NOT NULL,
+    ticket_count INT NOT NULL,
+    total_estimate BIGINT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (snapshot_date, board_column_id)
+);
+
+CREATE INDEX idx_board_snapshots_project_id_date ON board_snapshots (project_id, snapshot_date);
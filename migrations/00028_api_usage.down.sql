@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_api_usage_usage_date;
+
+DROP TABLE IF EXISTS api_usage;
@@ -0,0 +1,11 @@
+-- Authenticated API requests per user and UTC day. Counts are batched in
+-- memory and added here periodically, so the current day trails by up to one
+-- flush interval.
+CREATE TABLE IF NOT EXISTS api_usage (
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    usage_date DATE NOT NULL,
+    requests BIGINT NOT NULL DEFAULT 0,
+    PRIMARY KEY (user_id, usage_date)
+);
+
+CREATE INDEX idx_api_usage_usage_date ON api_usage (usage_date);
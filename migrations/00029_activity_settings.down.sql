@@ -0,0 +1,3 @@
+DROP TRIGGER IF EXISTS activity_settings_set_updated_at ON activity_settings;
+
+DROP TABLE IF EXISTS activity_settings;
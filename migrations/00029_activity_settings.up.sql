@@ -0,0 +1,15 @@
+-- How much of a project's activity reaches its log, for projects where the
+-- log grows too fast. mode all records every action, selected only those in
+-- actions and none nothing. A project without a row records everything.
+CREATE TABLE IF NOT EXISTS activity_settings (
+    project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    mode VARCHAR(16) NOT NULL DEFAULT 'all' CHECK (mode IN ('all', 'selected', 'none')),
+    actions TEXT[] NOT NULL DEFAULT '{}',
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER activity_settings_set_updated_at
+    BEFORE UPDATE ON activity_settings
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
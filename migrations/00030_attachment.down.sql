@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_attachments_ticket_id;
+
+DROP TABLE IF EXISTS attachments;
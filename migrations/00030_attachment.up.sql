@@ -0,0 +1,17 @@
+-- Files uploaded to tickets. The bytes live in the configured blob store
+-- under storage_key, this table only keeps what describes them. A ticket
+-- removed for good takes its rows along; its blobs are left in the store.
+CREATE TABLE IF NOT EXISTS attachments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    ticket_id UUID NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    storage_key TEXT NOT NULL UNIQUE,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(255) NOT NULL,
+    size BIGINT NOT NULL,
+    -- hex sha256 of the content, lets clients skip downloads they already have
+    checksum CHAR(64) NOT NULL,
+    uploaded_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_attachments_ticket_id ON attachments (ticket_id, created_at);
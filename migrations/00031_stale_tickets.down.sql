@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS stale_tickets;
+
+DROP TRIGGER IF EXISTS ticket_stale_settings_set_updated_at ON ticket_stale_settings;
+
+DROP TABLE IF EXISTS ticket_stale_settings;
@@ -0,0 +1,25 @@
+-- How long a project's tickets may go untouched before they are flagged as
+-- stale. after_days 0 turns the nudges off, as does having no row.
+CREATE TABLE IF NOT EXISTS ticket_stale_settings (
+    project_id UUID PRIMARY KEY REFERENCES projects(id) ON DELETE CASCADE,
+    after_days INT NOT NULL DEFAULT 0 CHECK (after_days >= 0),
+    updated_by UUID REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TRIGGER ticket_stale_settings_set_updated_at
+    BEFORE UPDATE ON ticket_stale_settings
+    FOR EACH ROW EXECUTE FUNCTION set_updated_at();
+
+-- Tickets the stale sweep flagged, so each goes stale and is nudged about
+-- once. Kept apart from tickets so flagging does not write change rows. A
+-- flag older than the ticket's updated_at is outdated: the ticket was touched
+-- since, and the next sweep removes the flag.
+CREATE TABLE IF NOT EXISTS stale_tickets (
+    ticket_id UUID PRIMARY KEY REFERENCES tickets(id) ON DELETE CASCADE,
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    flagged_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_stale_tickets_project_id ON stale_tickets (project_id);
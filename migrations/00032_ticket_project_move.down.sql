@@ -0,0 +1,15 @@
+CREATE OR REPLACE FUNCTION record_ticket_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    r tickets%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    INSERT INTO changes (project_id, entity, entity_id, op)
+    VALUES (r.project_id, 'ticket', r.id, lower(TG_OP));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,21 @@
+-- A ticket moved to another project is recorded as deleted from the old one
+-- as well, so clients syncing only that project drop it
+CREATE OR REPLACE FUNCTION record_ticket_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    r tickets%ROWTYPE;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        r := OLD;
+    ELSE
+        r := NEW;
+    END IF;
+    IF TG_OP = 'UPDATE' AND OLD.project_id <> NEW.project_id THEN
+        INSERT INTO changes (project_id, entity, entity_id, op)
+        VALUES (OLD.project_id, 'ticket', OLD.id, 'delete');
+    END IF;
+    INSERT INTO changes (project_id, entity, entity_id, op)
+    VALUES (r.project_id, 'ticket', r.id, lower(TG_OP));
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,3 @@
+ALTER TABLE board_columns
+    DROP COLUMN IF EXISTS wip_limit,
+    DROP COLUMN IF EXISTS color;
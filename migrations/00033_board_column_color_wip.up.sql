@@ -0,0 +1,8 @@
+-- Optional display color and work-in-progress limit per board column. An
+-- empty color leaves the column uncolored, a NULL wip_limit leaves it
+-- unlimited.
+ALTER TABLE board_columns
+    ADD COLUMN color VARCHAR(7) NOT NULL DEFAULT ''
+        CONSTRAINT board_columns_color_check CHECK (color = '' OR color ~ '^#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6})$'),
+    ADD COLUMN wip_limit INT
+        CONSTRAINT board_columns_wip_limit_check CHECK (wip_limit > 0);
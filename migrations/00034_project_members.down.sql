@@ -0,0 +1,4 @@
+DROP TRIGGER IF EXISTS org_members_leave_projects ON org_members;
+DROP FUNCTION IF EXISTS org_members_leave_projects();
+DROP TABLE IF EXISTS project_members;
+DROP TYPE IF EXISTS project_role;
@@ -0,0 +1,62 @@
+-- Who may work in a project and how. Access used to follow organisation
+-- membership, so every organisation member joins the projects of their
+-- organisation with the role they hold there.
+CREATE TYPE project_role AS ENUM ('admin', 'member', 'viewer');
+
+CREATE TABLE IF NOT EXISTS project_members (
+    project_id UUID NOT NULL REFERENCES projects(id) ON DELETE CASCADE,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    role project_role NOT NULL DEFAULT 'member',
+    joined_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (project_id, user_id)
+);
+
+CREATE INDEX idx_project_members_user_id ON project_members(user_id);
+
+INSERT INTO project_members (project_id, user_id, role, joined_at)
+SELECT p.id, om.user_id, om.role::text::project_role, om.joined_at
+FROM projects p
+JOIN org_members om ON om.org_id = p.org_id;
+
+-- Leaving an organisation also means leaving its projects. A project whose
+-- last admin left hands the admin role to the member who stays behind with
+-- the most standing: organisation admins first, then by project role and
+-- how long they have been in the project. A project nobody stays in has
+-- nobody to hand it to.
+CREATE OR REPLACE FUNCTION org_members_leave_projects()
+RETURNS TRIGGER AS $$
+DECLARE
+    left_project UUID;
+BEGIN
+    FOR left_project IN
+        DELETE FROM project_members pm
+        USING projects p
+        WHERE pm.project_id = p.id AND p.org_id = OLD.org_id AND pm.user_id = OLD.user_id
+        RETURNING CASE WHEN pm.role = 'admin' THEN pm.project_id END
+    LOOP
+        CONTINUE WHEN left_project IS NULL;
+        CONTINUE WHEN EXISTS (
+            SELECT 1 FROM project_members
+            WHERE project_id = left_project AND role = 'admin'
+        );
+
+        UPDATE project_members
+        SET role = 'admin'
+        WHERE project_id = left_project AND user_id = (
+            SELECT pm.user_id
+            FROM project_members pm
+            LEFT JOIN org_members om ON om.org_id = OLD.org_id AND om.user_id = pm.user_id
+            WHERE pm.project_id = left_project
+            ORDER BY om.role = 'admin' DESC NULLS LAST, pm.role, pm.joined_at, pm.user_id
+            LIMIT 1
+        );
+    END LOOP;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER org_members_leave_projects
+    AFTER DELETE ON org_members
+    FOR EACH ROW EXECUTE FUNCTION org_members_leave_projects();
+
+COMMENT ON TYPE project_role IS 'admin: manages the project and its members; member: works on tickets and board columns; viewer: read only';
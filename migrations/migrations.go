@@ -0,0 +1,10 @@
+package migrations
+
+import "embed"
+
+// FS holds the migration files of this directory, the expand set, and the
+// contract set under contract/. They are compiled in so the migrator and the
+// status endpoint see the same versions wherever the binary runs.
+//
+//go:embed *.sql contract
+var FS embed.FS
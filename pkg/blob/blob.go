@@ -0,0 +1,73 @@
+// Package blob stores uploaded files outside the database, on local disk or
+// in an S3 compatible bucket, behind one small interface. Keys are slash
+// separated paths such as "tickets/<id>/<id>"; both drivers accept the same
+// restricted set so a key that works on one works on the other.
+package blob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+const (
+	DriverDisk = "disk"
+	DriverS3   = "s3"
+)
+
+var (
+	ErrNotFound   = errors.New("blob: not found")
+	ErrInvalidKey = errors.New("blob: invalid key")
+)
+
+// Store keeps blobs by key. Put replaces an existing blob, Delete of a
+// missing one is not an error.
+type Store interface {
+	Put(ctx context.Context, key string, body io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
+type Config struct {
+	// Driver is DriverDisk or DriverS3
+	Driver string
+	// Dir is where the disk driver writes, created when missing
+	Dir string
+	S3  S3Config
+}
+
+// New opens the store Driver selects
+func New(cfg Config) (Store, error) {
+	switch cfg.Driver {
+	case DriverDisk:
+		return NewDisk(cfg.Dir)
+	case DriverS3:
+		return NewS3(cfg.S3)
+	}
+	return nil, fmt.Errorf("blob: unknown driver %q, want %s or %s", cfg.Driver, DriverDisk, DriverS3)
+}
+
+// checkKey accepts relative slash separated paths of letters, digits, dots,
+// dashes and underscores, refusing empty segments and dot segments so a key
+// can not escape the disk directory or alias another key
+func checkKey(key string) error {
+	if key == "" || len(key) > 512 {
+		return ErrInvalidKey
+	}
+	for _, seg := range strings.Split(key, "/") {
+		if seg == "" || seg == "." || seg == ".." {
+			return ErrInvalidKey
+		}
+		for _, r := range seg {
+			switch {
+			case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			case r == '.', r == '-', r == '_':
+			default:
+				return ErrInvalidKey
+			}
+		}
+	}
+	return nil
+}
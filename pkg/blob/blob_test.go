@@ -0,0 +1,139 @@
+package blob_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/blob"
+)
+
+// roundTrip runs the behaviour every driver shares
+func roundTrip(t *testing.T, store blob.Store) {
+	t.Helper()
+	ctx := context.Background()
+
+	if err := store.Put(ctx, "tickets/a/b.txt", strings.NewReader("hello"), 5, "text/plain"); err != nil {
+		t.Fatalf("Put: %v", err)
+	}
+	rc, err := store.Get(ctx, "tickets/a/b.txt")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	got, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(got) != "hello" {
+		t.Fatalf("Get = %q, want hello", got)
+	}
+
+	if err := store.Delete(ctx, "tickets/a/b.txt"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Get(ctx, "tickets/a/b.txt"); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("Get after Delete = %v, want ErrNotFound", err)
+	}
+	if err := store.Delete(ctx, "tickets/a/b.txt"); err != nil {
+		t.Fatalf("Delete of a missing blob = %v, want nil", err)
+	}
+
+	for _, key := range []string{"", "../escape", "a//b", "/abs", "a/./b", `a\b`, "a b"} {
+		if err := store.Put(ctx, key, strings.NewReader("x"), 1, ""); !errors.Is(err, blob.ErrInvalidKey) {
+			t.Errorf("Put(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+}
+
+func TestDisk(t *testing.T) {
+	store, err := blob.NewDisk(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip(t, store)
+}
+
+func TestDisk_RejectsShortWrite(t *testing.T) {
+	store, _ := blob.NewDisk(t.TempDir())
+	ctx := context.Background()
+	if err := store.Put(ctx, "short", strings.NewReader("abc"), 10, ""); err == nil {
+		t.Fatal("expected an error when the body is shorter than its size")
+	}
+	if _, err := store.Get(ctx, "short"); !errors.Is(err, blob.ErrNotFound) {
+		t.Fatalf("expected nothing left behind, got %v", err)
+	}
+}
+
+// fakeS3 keeps objects in memory and insists on signed requests
+func fakeS3() *httptest.Server {
+	var mu sync.Mutex
+	objects := map[string]string{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=key/") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		mu.Lock()
+		defer mu.Unlock()
+		switch r.Method {
+		case http.MethodPut:
+			body, _ := io.ReadAll(r.Body)
+			objects[r.URL.Path] = string(body)
+		case http.MethodGet:
+			body, ok := objects[r.URL.Path]
+			if !ok {
+				http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+				return
+			}
+			io.WriteString(w, body)
+		case http.MethodDelete:
+			delete(objects, r.URL.Path)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func TestS3(t *testing.T) {
+	srv := fakeS3()
+	defer srv.Close()
+
+	store, err := blob.NewS3(blob.S3Config{
+		Endpoint:  srv.URL,
+		Region:    "us-east-1",
+		Bucket:    "fluxis",
+		AccessKey: "key",
+		SecretKey: "secret",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	roundTrip(t, store)
+}
+
+func TestS3_ReportsFailures(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+	}))
+	defer srv.Close()
+
+	store, _ := blob.NewS3(blob.S3Config{Endpoint: srv.URL, Region: "r", Bucket: "b", AccessKey: "k", SecretKey: "s"})
+	err := store.Put(context.Background(), "key", strings.NewReader("x"), 1, "")
+	if err == nil || !strings.Contains(err.Error(), "AccessDenied") {
+		t.Fatalf("Put = %v, want an error carrying the S3 response", err)
+	}
+}
+
+func TestNew(t *testing.T) {
+	if _, err := blob.New(blob.Config{Driver: "ftp"}); err == nil {
+		t.Fatal("expected an error for an unknown driver")
+	}
+	if _, err := blob.New(blob.Config{Driver: blob.DriverS3, S3: blob.S3Config{Endpoint: "http://minio:9000"}}); err == nil {
+		t.Fatal("expected an error for s3 without credentials")
+	}
+	if _, err := blob.New(blob.Config{Driver: blob.DriverDisk, Dir: t.TempDir()}); err != nil {
+		t.Fatalf("New disk: %v", err)
+	}
+}
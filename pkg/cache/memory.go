@@ -61,6 +61,17 @@ func (m *MemoryCache) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// Stats reports how many entries are held, expired ones the sweeper has not
+// reached yet included, and how many bytes their values take
+func (m *MemoryCache) Stats() (entries, bytes int) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, entry := range m.cache {
+		bytes += len(entry.value)
+	}
+	return len(m.cache), bytes
+}
+
 func (m *MemoryCache) GetConfig() Config {
 	return m.cfg
 }
@@ -0,0 +1,217 @@
+// Package chaos injects latency and failures into API responses so loading
+// and error states of a client can be exercised against the real backend.
+// It is meant for development only and is never mounted in other stages.
+//
+// A fault comes from, in order of precedence, the request's own X-Chaos-*
+// headers, the first route rule whose prefix matches, or the default fault.
+// Route rules are written as
+//
+//	[METHOD ]PREFIX=LATENCY[:ERROR_RATE[:STATUS]]
+//
+// e.g. "/tickets=800ms:0.2" or "POST /boards=0s:1:409".
+package chaos
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+// Headers a request can set to pick its own fault
+const (
+	HeaderLatency   = "X-Chaos-Latency"
+	HeaderErrorRate = "X-Chaos-Error-Rate"
+	HeaderStatus    = "X-Chaos-Status"
+
+	// HeaderInjected is set on responses that were delayed or failed on
+	// purpose, so they are easy to tell apart in the browser's network tab
+	HeaderInjected = "X-Chaos-Injected"
+)
+
+const defaultStatus = http.StatusServiceUnavailable
+
+// maxLatency keeps a typo in a header from holding a connection for hours
+const maxLatency = time.Minute
+
+// Fault is what a matching request suffers. Latency is applied before the
+// request is served or failed; Jitter adds up to that much on top at random.
+type Fault struct {
+	Latency   time.Duration
+	Jitter    time.Duration
+	ErrorRate float64
+	Status    int
+}
+
+type Config struct {
+	Enabled bool
+	Default Fault
+	// Routes holds rules in the format described in the package comment
+	Routes []string
+	// ExemptPaths are never touched, e.g. health probes
+	ExemptPaths []string
+}
+
+type rule struct {
+	method string
+	prefix string
+	fault  Fault
+}
+
+// New returns the injecting middleware, or a pass-through one while
+// disabled. It fails on a malformed route rule.
+func New(cfg Config) (func(http.Handler) http.Handler, error) {
+	if !cfg.Enabled {
+		return func(next http.Handler) http.Handler { return next }, nil
+	}
+	if err := checkFault(cfg.Default); err != nil {
+		return nil, fmt.Errorf("default fault: %w", err)
+	}
+
+	rules := make([]rule, 0, len(cfg.Routes))
+	for _, entry := range cfg.Routes {
+		r, err := parseRule(entry)
+		if err != nil {
+			return nil, fmt.Errorf("route rule %q: %w", entry, err)
+		}
+		rules = append(rules, r)
+	}
+
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, p := range cfg.ExemptPaths {
+				if r.URL.Path == p {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			fault := cfg.Default
+			for _, rl := range rules {
+				if (rl.method == "" || rl.method == r.Method) && strings.HasPrefix(r.URL.Path, rl.prefix) {
+					fault = rl.fault
+					break
+				}
+			}
+			fault, err := fromHeaders(r, fault)
+			if err != nil {
+				httpx.Handle(w, httpx.BadRequest(err.Error()).WithCode("invalid_chaos_header"))
+				return
+			}
+
+			if delay := fault.delay(); delay > 0 {
+				w.Header().Set(HeaderInjected, "latency")
+				select {
+				case <-time.After(delay):
+				case <-r.Context().Done():
+					return
+				}
+			}
+
+			if fault.ErrorRate > 0 && rand.Float64() < fault.ErrorRate {
+				w.Header().Set(HeaderInjected, "error")
+				status := fault.Status
+				if status == 0 {
+					status = defaultStatus
+				}
+				httpx.ErrorCode(w, status, "failure injected by chaos mode", "chaos_injected")
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}, nil
+}
+
+func (f Fault) delay() time.Duration {
+	d := f.Latency
+	if f.Jitter > 0 {
+		d += rand.N(f.Jitter)
+	}
+	return d
+}
+
+// fromHeaders overrides base with whatever the request asks for itself
+func fromHeaders(r *http.Request, base Fault) (Fault, error) {
+	f := base
+	if v := r.Header.Get(HeaderLatency); v != "" {
+		d, err := time.ParseDuration(v)
+		if err != nil {
+			return Fault{}, fmt.Errorf("%s must be a duration like 500ms", HeaderLatency)
+		}
+		f.Latency, f.Jitter = d, 0
+	}
+	if v := r.Header.Get(HeaderErrorRate); v != "" {
+		rate, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return Fault{}, fmt.Errorf("%s must be a number between 0 and 1", HeaderErrorRate)
+		}
+		f.ErrorRate = rate
+	}
+	if v := r.Header.Get(HeaderStatus); v != "" {
+		status, err := strconv.Atoi(v)
+		if err != nil {
+			return Fault{}, fmt.Errorf("%s must be an HTTP status code", HeaderStatus)
+		}
+		f.Status = status
+	}
+	if err := checkFault(f); err != nil {
+		return Fault{}, err
+	}
+	return f, nil
+}
+
+func parseRule(entry string) (rule, error) {
+	target, spec, ok := strings.Cut(strings.TrimSpace(entry), "=")
+	if !ok {
+		return rule{}, fmt.Errorf("expected PREFIX=LATENCY[:ERROR_RATE[:STATUS]]")
+	}
+
+	var r rule
+	if method, prefix, ok := strings.Cut(target, " "); ok {
+		r.method, target = strings.ToUpper(method), strings.TrimSpace(prefix)
+	}
+	if !strings.HasPrefix(target, "/") {
+		return rule{}, fmt.Errorf("prefix must start with /")
+	}
+	r.prefix = target
+
+	parts := strings.Split(spec, ":")
+	if len(parts) > 3 {
+		return rule{}, fmt.Errorf("expected at most latency, error rate and status")
+	}
+	d, err := time.ParseDuration(parts[0])
+	if err != nil {
+		return rule{}, fmt.Errorf("invalid latency: %w", err)
+	}
+	r.fault.Latency = d
+	if len(parts) > 1 {
+		if r.fault.ErrorRate, err = strconv.ParseFloat(parts[1], 64); err != nil {
+			return rule{}, fmt.Errorf("invalid error rate: %w", err)
+		}
+	}
+	if len(parts) > 2 {
+		if r.fault.Status, err = strconv.Atoi(parts[2]); err != nil {
+			return rule{}, fmt.Errorf("invalid status: %w", err)
+		}
+	}
+	return r, checkFault(r.fault)
+}
+
+func checkFault(f Fault) error {
+	switch {
+	case f.Latency < 0 || f.Jitter < 0:
+		return fmt.Errorf("latency can not be negative")
+	case f.Latency+f.Jitter > maxLatency:
+		return fmt.Errorf("latency can not exceed %s", maxLatency)
+	case f.ErrorRate < 0 || f.ErrorRate > 1:
+		return fmt.Errorf("error rate must be between 0 and 1")
+	case f.Status != 0 && (f.Status < 400 || f.Status > 599):
+		return fmt.Errorf("status must be a 4xx or 5xx code")
+	}
+	return nil
+}
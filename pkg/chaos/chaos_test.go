@@ -0,0 +1,111 @@
+package chaos_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/chaos"
+)
+
+var okHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+})
+
+func serve(t *testing.T, cfg chaos.Config, req *http.Request) *httptest.ResponseRecorder {
+	t.Helper()
+	mw, err := chaos.New(cfg)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	rec := httptest.NewRecorder()
+	mw(okHandler).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestNew_DisabledPassesThrough(t *testing.T) {
+	req := httptest.NewRequest("GET", "/tickets", nil)
+	req.Header.Set(chaos.HeaderErrorRate, "1")
+
+	rec := serve(t, chaos.Config{Default: chaos.Fault{ErrorRate: 1}}, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, want 200 while disabled", rec.Code)
+	}
+}
+
+func TestNew_InjectsFaults(t *testing.T) {
+	cfg := chaos.Config{
+		Enabled:     true,
+		Routes:      []string{"POST /boards=0s:1:409", "/tickets=0s:1"},
+		ExemptPaths: []string{"/health"},
+	}
+
+	tests := []struct {
+		name     string
+		method   string
+		path     string
+		header   map[string]string
+		want     int
+		injected string
+	}{
+		{"no rule matches", "GET", "/orgs", nil, http.StatusOK, ""},
+		{"route rule with default status", "GET", "/tickets/1", nil, http.StatusServiceUnavailable, "error"},
+		{"route rule with its own status", "POST", "/boards", nil, http.StatusConflict, "error"},
+		{"method must match", "GET", "/boards", nil, http.StatusOK, ""},
+		{"header overrides the rule", "GET", "/tickets", map[string]string{chaos.HeaderErrorRate: "0"}, http.StatusOK, ""},
+		{"header picks the status", "GET", "/orgs", map[string]string{chaos.HeaderErrorRate: "1", chaos.HeaderStatus: "500"}, http.StatusInternalServerError, "error"},
+		{"invalid header", "GET", "/orgs", map[string]string{chaos.HeaderErrorRate: "2"}, http.StatusBadRequest, ""},
+		{"exempt path", "GET", "/health", map[string]string{chaos.HeaderErrorRate: "1"}, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			for k, v := range tt.header {
+				req.Header.Set(k, v)
+			}
+			rec := serve(t, cfg, req)
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if got := rec.Header().Get(chaos.HeaderInjected); got != tt.injected {
+				t.Fatalf("%s = %q, want %q", chaos.HeaderInjected, got, tt.injected)
+			}
+		})
+	}
+}
+
+func TestNew_DelaysRequests(t *testing.T) {
+	req := httptest.NewRequest("GET", "/tickets", nil)
+	req.Header.Set(chaos.HeaderLatency, "30ms")
+
+	start := time.Now()
+	rec := serve(t, chaos.Config{Enabled: true}, req)
+	if elapsed := time.Since(start); elapsed < 30*time.Millisecond {
+		t.Fatalf("served after %s, want at least 30ms", elapsed)
+	}
+	if rec.Code != http.StatusOK || rec.Header().Get(chaos.HeaderInjected) != "latency" {
+		t.Fatalf("unexpected response %d %v", rec.Code, rec.Header())
+	}
+}
+
+func TestNew_RejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  chaos.Config
+	}{
+		{"missing spec", chaos.Config{Enabled: true, Routes: []string{"/tickets"}}},
+		{"relative prefix", chaos.Config{Enabled: true, Routes: []string{"tickets=1s"}}},
+		{"bad latency", chaos.Config{Enabled: true, Routes: []string{"/tickets=soon"}}},
+		{"rate above one", chaos.Config{Enabled: true, Routes: []string{"/tickets=0s:1.5"}}},
+		{"success status", chaos.Config{Enabled: true, Routes: []string{"/tickets=0s:1:200"}}},
+		{"huge latency", chaos.Config{Enabled: true, Default: chaos.Fault{Latency: time.Hour}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := chaos.New(tt.cfg); err == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
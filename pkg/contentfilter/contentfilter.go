@@ -0,0 +1,143 @@
+// Package contentfilter screens text users write before it is stored.
+//
+// Services run titles, names and descriptions through a Filter on create and
+// update. A filter can rewrite the text, e.g. drop invisible characters, or
+// reject it with an error that is returned to the client as is. Public
+// instances chain the built-in filters from Config or plug in their own,
+// e.g. a call to a moderation service.
+package contentfilter
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+// Filter inspects one field and returns the text to store
+type Filter interface {
+	Filter(field, text string) (string, error)
+}
+
+// Func adapts a plain function to a Filter
+type Func func(field, text string) (string, error)
+
+func (f Func) Filter(field, text string) (string, error) {
+	return f(field, text)
+}
+
+// Chain runs filters in order, each seeing the previous one's output, and
+// stops at the first rejection
+type Chain []Filter
+
+func (c Chain) Filter(field, text string) (string, error) {
+	for _, f := range c {
+		var err error
+		if text, err = f.Filter(field, text); err != nil {
+			return "", err
+		}
+	}
+	return text, nil
+}
+
+type Config struct {
+	StripControl bool
+	// DenyList rejects text containing any of these words or phrases
+	DenyList []string
+}
+
+// New chains the built-in filters switched on in cfg
+func New(cfg Config) Filter {
+	var c Chain
+	if cfg.StripControl {
+		c = append(c, StripControl())
+	}
+	if len(cfg.DenyList) > 0 {
+		c = append(c, DenyList(cfg.DenyList))
+	}
+	return c
+}
+
+// StripControl drops control characters other than newlines and tabs, and
+// the invisible format characters that can reorder or hide text, such as
+// bidi overrides and zero width spaces. Zero width joiners stay since emoji
+// sequences and several scripts need them.
+func StripControl() Filter {
+	return Func(func(_, text string) (string, error) {
+		if strings.IndexFunc(text, isStripped) < 0 {
+			return text, nil
+		}
+		return strings.Map(func(r rune) rune {
+			if isStripped(r) {
+				return -1
+			}
+			return r
+		}, text), nil
+	})
+}
+
+func isStripped(r rune) bool {
+	switch r {
+	case '\n', '\t', '\u200c', '\u200d':
+		return false
+	}
+	return unicode.IsControl(r) || unicode.Is(unicode.Cf, r)
+}
+
+// DenyList rejects text containing one of terms as a whole word, ignoring
+// case. Matching whole words keeps a short term from blocking every longer
+// word that happens to contain it.
+func DenyList(terms []string) Filter {
+	lowered := make([]string, 0, len(terms))
+	for _, t := range terms {
+		if t = strings.ToLower(strings.TrimSpace(t)); t != "" {
+			lowered = append(lowered, t)
+		}
+	}
+
+	return Func(func(field, text string) (string, error) {
+		haystack := strings.ToLower(text)
+		for _, term := range lowered {
+			if containsWord(haystack, term) {
+				return "", domain.Unprocessable(field + " contains blocked content").
+					WithCode("content_rejected").
+					WithDetails(map[string]string{"field": field})
+			}
+		}
+		return text, nil
+	})
+}
+
+func containsWord(text, word string) bool {
+	for offset := 0; ; {
+		i := strings.Index(text[offset:], word)
+		if i < 0 {
+			return false
+		}
+		start := offset + i
+		end := start + len(word)
+		if isBoundary(text, start, -1) && isBoundary(text, end, 1) {
+			return true
+		}
+		offset = start + 1
+	}
+}
+
+// isBoundary reports whether the rune just before (dir -1) or at (dir 1) the
+// byte offset i ends a word
+func isBoundary(text string, i, dir int) bool {
+	var r rune
+	if dir < 0 {
+		if i == 0 {
+			return true
+		}
+		r, _ = utf8.DecodeLastRuneInString(text[:i])
+	} else {
+		if i >= len(text) {
+			return true
+		}
+		r, _ = utf8.DecodeRuneInString(text[i:])
+	}
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
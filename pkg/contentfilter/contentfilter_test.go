@@ -0,0 +1,81 @@
+package contentfilter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/contentfilter"
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+)
+
+func TestStripControl_KeepsNewlinesAndTabs(t *testing.T) {
+	f := contentfilter.StripControl()
+
+	got, err := f.Filter("description", "a\x00b\u202ec\u200bd\n\te")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := "abcd\n\te"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestStripControl_KeepsJoiners(t *testing.T) {
+	family := "\U0001F468\u200d\U0001F469\u200d\U0001F467"
+
+	got, err := contentfilter.StripControl().Filter("title", family)
+	if err != nil || got != family {
+		t.Fatalf("expected emoji sequence to survive, got %q, %v", got, err)
+	}
+}
+
+func TestDenyList_MatchesWholeWordsOnly(t *testing.T) {
+	f := contentfilter.DenyList([]string{"ass", "free money"})
+
+	tests := []struct {
+		text    string
+		blocked bool
+	}{
+		{"Update the class assignment", false},
+		{"what an ASS", true},
+		{"ass.", true},
+		{"Get FREE money now", true},
+		{"freemoney", false},
+	}
+	for _, tt := range tests {
+		_, err := f.Filter("title", tt.text)
+		if got := err != nil; got != tt.blocked {
+			t.Errorf("%q: blocked = %v, want %v", tt.text, got, tt.blocked)
+		}
+	}
+}
+
+func TestDenyList_RejectsWithField(t *testing.T) {
+	f := contentfilter.DenyList([]string{"spam"})
+
+	_, err := f.Filter("title", "spam")
+	var derr *domain.Error
+	if !errors.As(err, &derr) {
+		t.Fatalf("expected a domain error, got %v", err)
+	}
+	if derr.Kind != domain.KindUnprocessable || derr.Code != "content_rejected" {
+		t.Fatalf("unexpected error: %+v", derr)
+	}
+}
+
+func TestNew_ChainsInOrder(t *testing.T) {
+	f := contentfilter.New(contentfilter.Config{
+		StripControl: true,
+		DenyList:     []string{"spam"},
+	})
+
+	// the zero width space is stripped before the deny list runs
+	if _, err := f.Filter("title", "sp\u200bam"); err == nil {
+		t.Fatalf("expected stripped text to be checked against the deny list")
+	}
+
+	got, err := contentfilter.New(contentfilter.Config{}).Filter("title", "a\x00b")
+	if err != nil || got != "a\x00b" {
+		t.Fatalf("expected an empty config to pass text through, got %q, %v", got, err)
+	}
+}
@@ -3,46 +3,82 @@ package cors
 import (
 	"net/http"
 	"strings"
+	"sync/atomic"
 )
 
 type Config struct {
 	AllowedOrigins string
 	AllowedMethods string
 	AllowedHeaders string
+	// ExposedHeaders are readable by browser scripts on top of the safelisted
+	// response headers, e.g. Link on paged lists
+	ExposedHeaders string
 	AllowedMaxAge  int
 }
 
 func New(cfg Config) func(http.Handler) http.Handler {
-	allowedOrigins := strings.Split(cfg.AllowedOrigins, ",")
-	allowedMethods := strings.Split(cfg.AllowedMethods, ",")
-	allowedHeaders := strings.Split(cfg.AllowedHeaders, ",")
-
-	return func(next http.Handler) http.Handler {
-		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			origin := r.Header.Get("Origin")
-
-			originAllowed := false
-			for _, allowed := range allowedOrigins {
-				if strings.TrimSpace(allowed) == origin || strings.TrimSpace(allowed) == "*" {
-					originAllowed = true
-					break
-				}
-			}
+	return NewPolicy(cfg).Wrap
+}
+
+// Policy is the CORS middleware with settings that can be replaced at runtime
+type Policy struct {
+	rules atomic.Pointer[rules]
+}
+
+type rules struct {
+	cfg            Config
+	allowedOrigins []string
+	allowedMethods []string
+	allowedHeaders []string
+}
 
-			if originAllowed {
-				w.Header().Set("Access-Control-Allow-Origin", origin)
-				w.Header().Set("Access-Control-Allow-Methods", strings.Join(allowedMethods, ", "))
-				w.Header().Set("Access-Control-Allow-Headers", strings.Join(allowedHeaders, ", "))
-				w.Header().Set("Access-Control-Allow-Credentials", "true")
-				w.Header().Set("Access-Control-Max-Age", "3600")
+func NewPolicy(cfg Config) *Policy {
+	p := &Policy{}
+	p.SetConfig(cfg)
+	return p
+}
+
+// SetConfig replaces the policy for every request that starts afterwards
+func (p *Policy) SetConfig(cfg Config) {
+	p.rules.Store(&rules{
+		cfg:            cfg,
+		allowedOrigins: strings.Split(cfg.AllowedOrigins, ","),
+		allowedMethods: strings.Split(cfg.AllowedMethods, ","),
+		allowedHeaders: strings.Split(cfg.AllowedHeaders, ","),
+	})
+}
+
+func (p *Policy) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rules := p.rules.Load()
+		origin := r.Header.Get("Origin")
+
+		originAllowed := false
+		for _, allowed := range rules.allowedOrigins {
+			if strings.TrimSpace(allowed) == origin || strings.TrimSpace(allowed) == "*" {
+				originAllowed = true
+				break
 			}
+		}
 
-			if r.Method == http.MethodOptions {
-				w.WriteHeader(http.StatusNoContent)
-				return
+		if originAllowed {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Set("Access-Control-Allow-Methods", strings.Join(rules.allowedMethods, ", "))
+			w.Header().Set("Access-Control-Allow-Headers", strings.Join(rules.allowedHeaders, ", "))
+			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			w.Header().Set("Access-Control-Max-Age", "3600")
+			if rules.cfg.ExposedHeaders != "" {
+				w.Header().Set("Access-Control-Expose-Headers", rules.cfg.ExposedHeaders)
 			}
+		}
+
+		// only preflights are answered here; a plain OPTIONS, such as a
+		// calendar client probing for WebDAV support, reaches the routes
+		if r.Method == http.MethodOptions && r.Header.Get("Access-Control-Request-Method") != "" {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
 
-			next.ServeHTTP(w, r)
-		})
-	}
+		next.ServeHTTP(w, r)
+	})
 }
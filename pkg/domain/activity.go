@@ -0,0 +1,108 @@
+package domain
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// BulkEventModel is the payload of events for actions that write many rows
+// at once, such as reorders and bulk deletes. IDs lists every row the action
+// touched and ParentID the board or sprint they were reordered in, if any.
+type BulkEventModel struct {
+	ProjectID pgtype.UUID   `json:"projectId"`
+	ActorID   pgtype.UUID   `json:"actorId"`
+	ParentID  pgtype.UUID   `json:"parentId"`
+	IDs       []pgtype.UUID `json:"ids"`
+}
+
+// ActivityModel is one entry of a project's activity log. Action is the
+// event type and Payload what the event carried, e.g. a BulkEventModel.
+type ActivityModel struct {
+	ID        int64           `json:"id"`
+	ProjectID pgtype.UUID     `json:"projectId" swaggertype:"string"`
+	ActorID   pgtype.UUID     `json:"actorId"   swaggertype:"string"`
+	Action    string          `json:"action"    example:"board.boardcolumn.reordered"`
+	Payload   json.RawMessage `json:"payload"   swaggertype:"object"`
+	CreatedAt time.Time       `json:"createdAt"`
+}
+
+type ActivitySearchModel struct {
+	ProjectID  pgtype.UUID
+	PageNumber int `json:"pageNumber" validate:"omitempty,min=1"`
+	PageSize   int `json:"pageSize" validate:"omitempty,min=1"`
+}
+
+func (m *ActivitySearchModel) ApplyDefaults() {
+	m.PageNumber, m.PageSize = pagination.Normalize(m.PageNumber, m.PageSize)
+}
+
+type ActivityPagedModel struct {
+	Items      []ActivityModel `json:"items"`
+	TotalCount int             `json:"totalCount"`
+	TotalPages int             `json:"totalPages"`
+	PageNumber int             `json:"pageNumber"`
+	PageSize   int             `json:"pageSize"`
+	HasMore    bool            `json:"hasMore"`
+}
+
+// ActivityWebhookCreateModel subscribes an endpoint to the project's activity
+// log. Actions filters the entries by action; leaving it empty sends all.
+type ActivityWebhookCreateModel struct {
+	URL     string   `json:"url" validate:"required,url,max=2048" example:"https://audit.example.com/fluxis"`
+	Actions []string `json:"actions,omitempty" validate:"omitempty,dive,oneof=board.board.reordered board.boardcolumn.reordered ticket.ticket.bulk_deleted ticket.ticket.stale ticket.ticket.moved_to_project" example:"ticket.ticket.bulk_deleted"`
+}
+
+// ActivityWebhookModel is an endpoint receiving a project's activity log
+// entries as they are written, each an ActivityModel signed like an inbound
+// delivery. Secret is only filled in the response that created it.
+type ActivityWebhookModel struct {
+	ID             pgtype.UUID `json:"id"`
+	ProjectID      pgtype.UUID `json:"projectId"`
+	URL            string      `json:"url"`
+	Actions        []string    `json:"actions"`
+	Secret         string      `json:"secret,omitempty"`
+	CreatedBy      pgtype.UUID `json:"createdBy"`
+	LastDeliveryAt *time.Time  `json:"lastDeliveryAt"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+const (
+	ActivityModeAll      = "all"
+	ActivityModeSelected = "selected"
+	ActivityModeNone     = "none"
+)
+
+// ActivitySettingsModel is how much of a project's activity reaches its log:
+// every action, only the listed Actions, or none. Webhooks only receive what
+// is logged. UpdatedAt is nil while the project still has the defaults.
+type ActivitySettingsModel struct {
+	ProjectID pgtype.UUID `json:"projectId" swaggertype:"string"`
+	Mode      string      `json:"mode"      example:"selected"`
+	Actions   []string    `json:"actions"   example:"ticket.ticket.bulk_deleted"`
+	UpdatedBy pgtype.UUID `json:"updatedBy" swaggertype:"string"`
+	UpdatedAt *time.Time  `json:"updatedAt"`
+}
+
+// ActivitySettingsUpdateModel replaces a project's activity settings. Actions
+// is required with mode selected and ignored otherwise.
+type ActivitySettingsUpdateModel struct {
+	Mode    string   `json:"mode" validate:"required,oneof=all selected none" example:"selected"`
+	Actions []string `json:"actions,omitempty" validate:"omitempty,dive,oneof=board.board.reordered board.boardcolumn.reordered ticket.ticket.bulk_deleted ticket.ticket.stale ticket.ticket.moved_to_project" example:"ticket.ticket.bulk_deleted"`
+}
+
+type ActivityReader interface {
+	ListProjectActivity(ctx context.Context, q ActivitySearchModel) (ActivityPagedModel, error)
+	ListActivityWebhooks(ctx context.Context, projectID pgtype.UUID) ([]ActivityWebhookModel, error)
+	GetActivitySettings(ctx context.Context, projectID pgtype.UUID) (ActivitySettingsModel, error)
+}
+
+type ActivityWriter interface {
+	CreateActivityWebhook(ctx context.Context, projectID pgtype.UUID, p ActivityWebhookCreateModel) (ActivityWebhookModel, error)
+	DeleteActivityWebhook(ctx context.Context, projectID, id pgtype.UUID) error
+	UpdateActivitySettings(ctx context.Context, projectID pgtype.UUID, p ActivitySettingsUpdateModel) (ActivitySettingsModel, error)
+}
@@ -0,0 +1,65 @@
+package domain
+
+import "time"
+
+// IPRulesModel is the allow/deny list pair enforced by the IP filter. Entries
+// are CIDR blocks or bare addresses.
+type IPRulesModel struct {
+	Allow []string `json:"allow" example:"10.0.0.0/8"`
+	Deny  []string `json:"deny"  example:"10.0.13.0/24"`
+}
+
+// RecordingModel is the state of request recording and the exchanges it has
+// kept, oldest first
+type RecordingModel struct {
+	Enabled   bool                    `json:"enabled"`
+	Active    bool                    `json:"active"`
+	Until     *time.Time              `json:"until,omitempty"`
+	Capacity  int                     `json:"capacity" example:"200"`
+	Exchanges []RecordedExchangeModel `json:"exchanges"`
+}
+
+// RecordedExchangeModel is one sanitized request and its response. Secrets
+// are replaced with "[redacted]" and bodies that are not text are reduced to
+// a note of their size.
+type RecordedExchangeModel struct {
+	ID              uint64              `json:"id"              example:"42"`
+	Time            time.Time           `json:"time"`
+	DurationMs      float64             `json:"durationMs"      example:"12.5"`
+	Method          string              `json:"method"          example:"PATCH"`
+	Path            string              `json:"path"            example:"/tickets/0b7e5c1e-9a4d-4f0e-8c1a-2f3b4c5d6e7f"`
+	Query           string              `json:"query"`
+	Status          int                 `json:"status"          example:"422"`
+	RequestHeaders  map[string][]string `json:"requestHeaders"`
+	RequestBody     string              `json:"requestBody"`
+	ResponseHeaders map[string][]string `json:"responseHeaders"`
+	ResponseBody    string              `json:"responseBody"`
+	Truncated       bool                `json:"truncated"`
+}
+
+// RecordingStartModel opens a recording window of the given length
+type RecordingStartModel struct {
+	DurationSeconds int `json:"durationSeconds" validate:"required,min=1" example:"600"`
+}
+
+// MigrationStatusModel holds one entry per migration phase, expand first
+type MigrationStatusModel struct {
+	Phases []MigrationPhaseModel `json:"phases"`
+}
+
+// MigrationPhaseModel compares the schema version recorded for a phase with
+// its migrations compiled into the running binary. Version is 0 before the
+// first run; dirty means the migration at version failed halfway.
+type MigrationPhaseModel struct {
+	Phase   string           `json:"phase" enums:"expand,contract"`
+	Version uint             `json:"version" example:"31"`
+	Dirty   bool             `json:"dirty"`
+	Latest  uint             `json:"latest"  example:"32"`
+	Pending []MigrationModel `json:"pending"`
+}
+
+// MigrationModel is one migration the database has not applied yet
+type MigrationModel struct {
+	Version uint   `json:"version" example:"32"`
+	Name    string `json:"name"    example:"ticket_project_move"`
+}
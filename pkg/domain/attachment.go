@@ -0,0 +1,41 @@
+package domain
+
+import (
+	"context"
+	"io"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// AttachmentModel describes a file uploaded to a ticket; the content is
+// downloaded separately. Checksum is the hex sha256 of the content.
+type AttachmentModel struct {
+	ID          pgtype.UUID `json:"id"          swaggertype:"string"`
+	TicketID    pgtype.UUID `json:"ticketId"    swaggertype:"string"`
+	Filename    string      `json:"filename"    example:"screenshot.png"`
+	ContentType string      `json:"contentType" example:"image/png"`
+	Size        int64       `json:"size"        example:"20480"`
+	Checksum    string      `json:"checksum"`
+	UploadedBy  pgtype.UUID `json:"uploadedBy"  swaggertype:"string"`
+	CreatedAt   time.Time   `json:"createdAt"`
+}
+
+// AttachmentUploadModel is a file as it was received, before it is stored
+type AttachmentUploadModel struct {
+	Filename    string
+	ContentType string
+	Data        []byte
+}
+
+type AttachmentReader interface {
+	ListAttachments(ctx context.Context, ticketID pgtype.UUID) ([]AttachmentModel, error)
+	// OpenAttachment returns the attachment with its content, which the
+	// caller closes
+	OpenAttachment(ctx context.Context, ticketID, id pgtype.UUID) (AttachmentModel, io.ReadCloser, error)
+}
+
+type AttachmentWriter interface {
+	UploadAttachment(ctx context.Context, ticketID pgtype.UUID, p AttachmentUploadModel) (AttachmentModel, error)
+	DeleteAttachment(ctx context.Context, ticketID, id pgtype.UUID) error
+}
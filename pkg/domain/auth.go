@@ -29,6 +29,9 @@ type AuthRefreshModel struct {
 type AuthTokenClaimModel struct {
 	jwt.RegisteredClaims
 	ID pgtype.UUID `json:"id" validate:"uuid4"`
+	// Scopes is always written, an empty list round-trips as [] and grants
+	// nothing; only tokens from before scopes existed lack the claim
+	Scopes []string `json:"scopes"`
 }
 
 type AuthWrite interface {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -14,6 +15,7 @@ type BoardModel struct {
 	Position  int32       `json:"position"`
 	CreatedAt time.Time   `json:"createdAt"`
 	UpdatedAt time.Time   `json:"updatedAt"`
+	DeletedAt *time.Time  `json:"deletedAt"`
 }
 
 type BoardCreateModel struct {
@@ -33,20 +35,11 @@ type BoardsSearchModel struct {
 	SprintID   []pgtype.UUID `json:"sprintId" validate:"omitempty,dive,uuid4"`
 	Name       string        `json:"name"`
 	PageNumber int           `json:"pageNumber" validate:"omitempty,min=1"`
-	PageSize   int           `json:"pageSize" validate:"omitempty,min=1,max=100"`
+	PageSize   int           `json:"pageSize" validate:"omitempty,min=1"`
 }
 
 func (b *BoardsSearchModel) ApplyDefaults() {
-	const (
-		defaultPageNumber = 1
-		defaultPageSize   = 25
-	)
-	if b.PageNumber == 0 {
-		b.PageNumber = defaultPageNumber
-	}
-	if b.PageSize == 0 {
-		b.PageSize = defaultPageSize
-	}
+	b.PageNumber, b.PageSize = pagination.Normalize(b.PageNumber, b.PageSize)
 }
 
 type BoardsPagedModel struct {
@@ -55,56 +48,71 @@ type BoardsPagedModel struct {
 	TotalPages int          `json:"totalPages"`
 	PageNumber int          `json:"pageNumber"`
 	PageSize   int          `json:"pageSize"`
-}
-
-func (m BoardsPagedModel) Empty(pageNumber, pageSize int) BoardsPagedModel {
-	return BoardsPagedModel{
-		Items:      []BoardModel{},
-		TotalCount: 0,
-		TotalPages: 0,
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-	}
+	HasMore    bool         `json:"hasMore"`
 }
 
 type BoardColumnModel struct {
-	ID        pgtype.UUID `json:"id"`
-	BoardID   pgtype.UUID `json:"boardId"`
-	Name      string      `json:"name" validate:"required,min=1"`
-	Position  int32       `json:"position"`
-	CreatedAt time.Time   `json:"createdAt"`
-	UpdatedAt time.Time   `json:"updatedAt"`
+	ID        pgtype.UUID            `json:"id"`
+	BoardID   pgtype.UUID            `json:"boardId"`
+	Name      string                 `json:"name" validate:"required,min=1"`
+	Position  int32                  `json:"position"`
+	Category  string                 `json:"category" enums:"todo,in_progress,done"`
+	IsDefault bool                   `json:"isDefault"`
+	Color     string                 `json:"color" example:"#22c55e"` // empty when the column has none
+	WIPLimit  *int32                 `json:"wipLimit" example:"5"`    // null when the column is unlimited
+	CreatedAt time.Time              `json:"createdAt"`
+	UpdatedAt time.Time              `json:"updatedAt"`
+	DeletedAt *time.Time             `json:"deletedAt"`
+	Links     *BoardColumnLinksModel `json:"links,omitempty"`
+
+	// populated only when the list is requested with includeCounts=true
+	TicketCount  *int64 `json:"ticketCount,omitempty"`
+	OverdueCount *int64 `json:"overdueCount,omitempty"`
 }
 
 type BoardColumnCreateModel struct {
-	Name string `json:"name" validate:"required,min=1"`
+	Name     string `json:"name" validate:"required,min=1"`
+	Category string `json:"category,omitempty" validate:"omitempty,oneof=todo in_progress done"`
+	Color    string `json:"color,omitempty" validate:"omitempty,hexcolor,max=7"`
+	WIPLimit *int32 `json:"wipLimit,omitempty" validate:"omitnil,min=1"`
 }
 
+// BoardColumnUpdateModel keeps whatever is left out; a wipLimit of 0
+// removes the limit
 type BoardColumnUpdateModel struct {
-	Name string `json:"name,omitempty" validate:"omitempty,min=1"`
+	Name     string `json:"name,omitempty" validate:"omitempty,min=1"`
+	Category string `json:"category,omitempty" validate:"omitempty,oneof=todo in_progress done"`
+	Color    string `json:"color,omitempty" validate:"omitempty,hexcolor,max=7"`
+	WIPLimit *int32 `json:"wipLimit,omitempty" validate:"omitnil,min=0"`
 }
 
 type BoardColumnReorderModel []pgtype.UUID
 
+// BoardColumnPositionModel moves one column right after AfterID; leaving it
+// empty moves the column to the front of the board
+type BoardColumnPositionModel struct {
+	AfterID pgtype.UUID `json:"afterId"`
+}
+
+// BoardColumnMergeModel is the target column after a merge, with the number
+// of tickets that were moved into it
+type BoardColumnMergeModel struct {
+	Target       BoardColumnModel `json:"target"`
+	MovedTickets int64            `json:"movedTickets"`
+}
+
 type BoardColumnsSearchModel struct {
-	ID         []pgtype.UUID `json:"id" validate:"omitempty,dive,uuid4"`
-	BoardID    []pgtype.UUID `json:"boardId" validate:"omitempty,dive,uuid4"`
-	Name       string        `json:"name"`
-	PageNumber int           `json:"pageNumber" validate:"omitempty,min=1"`
-	PageSize   int           `json:"pageSize" validate:"omitempty,min=1,max=100"`
+	ID            []pgtype.UUID `json:"id" validate:"omitempty,dive,uuid4"`
+	BoardID       []pgtype.UUID `json:"boardId" validate:"omitempty,dive,uuid4"`
+	Name          string        `json:"name"`
+	Category      []string      `json:"category" validate:"omitempty,dive,oneof=todo in_progress done"`
+	IncludeCounts bool          `json:"includeCounts"`
+	PageNumber    int           `json:"pageNumber" validate:"omitempty,min=1"`
+	PageSize      int           `json:"pageSize" validate:"omitempty,min=1"`
 }
 
 func (b *BoardColumnsSearchModel) ApplyDefaults() {
-	const (
-		defaultPageNumber = 1
-		defaultPageSize   = 25
-	)
-	if b.PageNumber == 0 {
-		b.PageNumber = defaultPageNumber
-	}
-	if b.PageSize == 0 {
-		b.PageSize = defaultPageSize
-	}
+	b.PageNumber, b.PageSize = pagination.Normalize(b.PageNumber, b.PageSize)
 }
 
 type BoardColumnsPagedModel struct {
@@ -113,16 +121,7 @@ type BoardColumnsPagedModel struct {
 	TotalPages int                `json:"totalPages"`
 	PageNumber int                `json:"pageNumber"`
 	PageSize   int                `json:"pageSize"`
-}
-
-func (m BoardColumnsPagedModel) Empty(pageNumber, pageSize int) BoardColumnsPagedModel {
-	return BoardColumnsPagedModel{
-		Items:      []BoardColumnModel{},
-		TotalCount: 0,
-		TotalPages: 0,
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-	}
+	HasMore    bool               `json:"hasMore"`
 }
 
 type BoardReader interface {
@@ -140,5 +139,9 @@ type BoardWriter interface {
 	CreateBoardColumn(ctx context.Context, boardID pgtype.UUID, b BoardColumnCreateModel) (BoardColumnModel, error)
 	UpdateBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID, b BoardColumnUpdateModel) (BoardColumnModel, error)
 	ReorderBoardColumns(ctx context.Context, boardID pgtype.UUID, reorder BoardColumnReorderModel) ([]BoardColumnModel, error)
-	DeleteBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID) error
+	DeleteBoardColumn(ctx context.Context, boardID, columnID, replacementID pgtype.UUID) error
+	RestoreBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID) (BoardColumnModel, error)
+	MergeBoardColumn(ctx context.Context, boardID, columnID, targetID pgtype.UUID) (BoardColumnMergeModel, error)
+	MoveBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID, p BoardColumnPositionModel) (BoardColumnModel, error)
+	SetDefaultBoardColumn(ctx context.Context, boardID, columnID pgtype.UUID) (BoardColumnModel, error)
 }
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// CalendarModel is a project served to calendar clients as a collection of its
+// dated tickets. ChangedAt moves whenever the project or one of its tickets is
+// written, so clients can tell when to resync.
+type CalendarModel struct {
+	ProjectID   pgtype.UUID
+	Key         string
+	Name        string
+	Description string
+	ChangedAt   time.Time
+}
+
+// CalendarTodoModel is a ticket with a due date seen as a calendar task.
+// Category is the board column category, empty while the ticket is off board.
+type CalendarTodoModel struct {
+	ID          pgtype.UUID
+	ProjectID   pgtype.UUID
+	Key         string
+	Title       string
+	Description string
+	DueDate     time.Time
+	Category    string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Done reports whether the ticket sits in a done column
+func (t CalendarTodoModel) Done() bool {
+	return t.Category == "done"
+}
+
+// CalendarTodoUpdateModel holds what a calendar client may change on a task.
+// Empty text and a zero due date leave the ticket's value as is.
+type CalendarTodoUpdateModel struct {
+	Title       string
+	Description string
+	DueDate     time.Time
+	Done        bool
+}
+
+type CalendarReader interface {
+	ListCalendars(ctx context.Context, userID pgtype.UUID) ([]CalendarModel, error)
+	GetCalendar(ctx context.Context, userID, projectID pgtype.UUID) (CalendarModel, error)
+	ListCalendarTodos(ctx context.Context, projectID pgtype.UUID) ([]CalendarTodoModel, error)
+	GetCalendarTodo(ctx context.Context, projectID, id pgtype.UUID) (CalendarTodoModel, error)
+}
+
+type CalendarWriter interface {
+	UpdateCalendarTodo(ctx context.Context, projectID, id pgtype.UUID, p CalendarTodoUpdateModel) (CalendarTodoModel, error)
+}
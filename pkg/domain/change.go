@@ -0,0 +1,63 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// ProjectChangesSearchModel asks for what changed in a project after Since.
+// Without Since only the current cursor is returned, so a client can load its
+// data and start polling from there.
+type ProjectChangesSearchModel struct {
+	ProjectID pgtype.UUID
+	Since     pgtype.Int8
+	Wait      time.Duration
+}
+
+// ProjectChangesModel lists the tickets and board columns written after the
+// requested cursor. IDs are only pointers: a client refetches them, and one
+// that is gone was deleted. Pass Cursor back as since on the next request;
+// HasMore means a batch limit was hit and it should ask again right away.
+type ProjectChangesModel struct {
+	ProjectID      pgtype.UUID   `json:"projectId"`
+	Cursor         int64         `json:"cursor"`
+	HasMore        bool          `json:"hasMore"`
+	TicketIDs      []pgtype.UUID `json:"ticketIds"`
+	BoardColumnIDs []pgtype.UUID `json:"boardColumnIds"`
+}
+
+// ChangesSearchModel pages through the global feed of a user. Without After
+// only the current cursor is returned.
+type ChangesSearchModel struct {
+	UserID pgtype.UUID
+	After  pgtype.Int8
+	Limit  int
+}
+
+// ChangeModel is one write in the feed. Entity is project, sprint, board,
+// board_column or ticket and Op is insert, update or delete. Seq orders the
+// feed by commit, so replaying entries in order reproduces the server state.
+type ChangeModel struct {
+	Seq       int64       `json:"seq"`
+	ProjectID pgtype.UUID `json:"projectId"`
+	Entity    string      `json:"entity"`
+	EntityID  pgtype.UUID `json:"entityId"`
+	Op        string      `json:"op"`
+	CreatedAt time.Time   `json:"createdAt"`
+}
+
+// ChangeFeedModel is a page of the feed. Cursor is passed back as after;
+// it can move past the last item when the skipped seqs belong to projects
+// the user can not see.
+type ChangeFeedModel struct {
+	Items   []ChangeModel `json:"items"`
+	Cursor  int64         `json:"cursor"`
+	HasMore bool          `json:"hasMore"`
+}
+
+type ChangeReader interface {
+	ListProjectChanges(ctx context.Context, q ProjectChangesSearchModel) (ProjectChangesModel, error)
+	ListChanges(ctx context.Context, q ChangesSearchModel) (ChangeFeedModel, error)
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "encoding/json"
+
+// DevValidateModel asks whether Body would pass an operation's request
+// validation. The spec carries no operation IDs, so OperationID is the method
+// and path as the spec lists them.
+type DevValidateModel struct {
+	OperationID string          `json:"operationId" validate:"required" example:"POST /projects/{id}/priorities"`
+	Body        json.RawMessage `json:"body" swaggertype:"object"`
+}
+
+// DevValidateResultModel is what the operation would say about the body
+// before doing anything. Status and Message are those of the error response
+// a failing body gets; checks the services make against stored data are not
+// run.
+type DevValidateResultModel struct {
+	OperationID string `json:"operationId" example:"POST /projects/{id}/priorities"`
+	Model       string `json:"model,omitempty" example:"domain.ProjectPriorityCreateModel"`
+	Valid       bool   `json:"valid"`
+	Status      int    `json:"status,omitempty" example:"400"`
+	Message     string `json:"message,omitempty" example:"color must be a valid hex color"`
+}
@@ -0,0 +1,104 @@
+package domain
+
+// ErrorKind classifies a domain error without tying it to a transport. The
+// HTTP layer maps each kind to a status code in httpx.Handle; other callers
+// such as workers or importers can switch on it directly.
+type ErrorKind int
+
+const (
+	KindInternal ErrorKind = iota
+	KindInvalid
+	KindUnauthorized
+	KindForbidden
+	KindNotFound
+	KindConflict
+	KindUnprocessable
+	KindTooLarge
+	KindRateLimited
+	KindUnsupported
+	KindLocked
+	KindGone
+)
+
+// Error is returned by services for failures the caller can act on. Message
+// is safe to show to end users, Err is kept for logging only.
+type Error struct {
+	Kind    ErrorKind
+	Message string
+	Code    string // optional machine-readable code e.g. "email_taken"
+	Details any    // optional extra context for the caller, e.g. a suggested value
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+func Invalid(msg string) *Error {
+	return &Error{Kind: KindInvalid, Message: msg}
+}
+
+func Unauthorized(msg string) *Error {
+	return &Error{Kind: KindUnauthorized, Message: msg}
+}
+
+func Forbidden(msg string) *Error {
+	return &Error{Kind: KindForbidden, Message: msg}
+}
+
+func NotFound(msg string) *Error {
+	return &Error{Kind: KindNotFound, Message: msg}
+}
+
+func Conflict(msg string) *Error {
+	return &Error{Kind: KindConflict, Message: msg}
+}
+
+func Unprocessable(msg string) *Error {
+	return &Error{Kind: KindUnprocessable, Message: msg}
+}
+
+func TooLarge(msg string) *Error {
+	return &Error{Kind: KindTooLarge, Message: msg}
+}
+
+func RateLimited(msg string) *Error {
+	return &Error{Kind: KindRateLimited, Message: msg}
+}
+
+func Unsupported(msg string) *Error {
+	return &Error{Kind: KindUnsupported, Message: msg}
+}
+
+// Locked is for a resource another user holds a lock on for now
+func Locked(msg string) *Error {
+	return &Error{Kind: KindLocked, Message: msg}
+}
+
+// Gone is for something the server kept once and no longer does, e.g. changes
+// trimmed from the feed
+func Gone(msg string) *Error {
+	return &Error{Kind: KindGone, Message: msg}
+}
+
+func (e *Error) WithCode(code string) *Error {
+	e.Code = code
+	return e
+}
+
+func (e *Error) WithDetails(details any) *Error {
+	e.Details = details
+	return e
+}
+
+func (e *Error) Wrap(err error) *Error {
+	e.Err = err
+	return e
+}
@@ -0,0 +1,60 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	InboundActionCreate = "create"
+	InboundActionUpdate = "update"
+)
+
+// InboundMappingModel describes how a webhook payload becomes a ticket write.
+// Fields maps a ticket field to a dot path into the payload, e.g.
+// {"title": "issue.title"}; array items are addressed by index, as in
+// "labels.0.name". Defaults fill fields the payload leaves out. An update
+// finds its ticket through KeyPath, which must point at a key like "FLX-12".
+type InboundMappingModel struct {
+	Action   string            `json:"action" validate:"required,oneof=create update" enums:"create,update"`
+	KeyPath  string            `json:"keyPath,omitempty" example:"ticket.key"`
+	Fields   map[string]string `json:"fields"`
+	Defaults map[string]string `json:"defaults,omitempty"`
+}
+
+type InboundIntegrationCreateModel struct {
+	Name    string              `json:"name" validate:"required,min=1,max=255" example:"Support inbox"`
+	Mapping InboundMappingModel `json:"mapping"`
+}
+
+// InboundIntegrationModel is a signed webhook endpoint of a project. Secret
+// is only filled in the response that created it.
+type InboundIntegrationModel struct {
+	ID             pgtype.UUID         `json:"id"`
+	ProjectID      pgtype.UUID         `json:"projectId"`
+	Name           string              `json:"name"`
+	Mapping        InboundMappingModel `json:"mapping"`
+	Secret         string              `json:"secret,omitempty"`
+	CreatedBy      pgtype.UUID         `json:"createdBy"`
+	LastDeliveryAt *time.Time          `json:"lastDeliveryAt"`
+	CreatedAt      time.Time           `json:"createdAt"`
+	UpdatedAt      time.Time           `json:"updatedAt"`
+}
+
+// InboundDeliveryModel reports the ticket a delivery created or updated
+type InboundDeliveryModel struct {
+	Action string      `json:"action" enums:"create,update"`
+	Ticket TicketModel `json:"ticket"`
+}
+
+type InboundIntegrationReader interface {
+	ListInboundIntegrations(ctx context.Context, projectID pgtype.UUID) ([]InboundIntegrationModel, error)
+}
+
+type InboundIntegrationWriter interface {
+	CreateInboundIntegration(ctx context.Context, projectID pgtype.UUID, p InboundIntegrationCreateModel) (InboundIntegrationModel, error)
+	DeleteInboundIntegration(ctx context.Context, projectID, id pgtype.UUID) error
+	ReceiveInbound(ctx context.Context, id pgtype.UUID, signature string, body []byte) (InboundDeliveryModel, error)
+}
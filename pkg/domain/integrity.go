@@ -0,0 +1,48 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// The integrity checks look for rows the API's own writes never leave behind,
+// e.g. after a manual database edit or an interrupted migration
+const (
+	IntegrityOrphanedColumns          = "orphaned_columns"           // live columns under a deleted board, sprint or project
+	IntegrityOrphanedTickets          = "orphaned_tickets"           // live tickets in a deleted column or a column of another board
+	IntegrityDuplicateColumnPositions = "duplicate_column_positions" // boards where live columns share a position
+	IntegrityDuplicateBoardPositions  = "duplicate_board_positions"  // sprints where live boards share a position
+)
+
+const (
+	IntegrityModeCheck = "check"
+	IntegrityModeFix   = "fix"
+)
+
+// IntegrityReportModel lists every check in the order a fix runs them
+type IntegrityReportModel struct {
+	CheckedAt time.Time             `json:"checkedAt"`
+	Mode      string                `json:"mode" enums:"check,fix"`
+	Checks    []IntegrityCheckModel `json:"checks"`
+}
+
+// IntegrityCheckModel is what one check found. IDs holds the first 100
+// affected tickets, columns, boards or sprints depending on the check. Fixed
+// counts the rows a fix changed; fixing orphaned columns orphans their
+// tickets, so the ticket fix can change more rows than were found.
+type IntegrityCheckModel struct {
+	Check string        `json:"check" enums:"orphaned_columns,orphaned_tickets,duplicate_column_positions,duplicate_board_positions"`
+	Count int64         `json:"count"`
+	IDs   []pgtype.UUID `json:"ids" swaggertype:"array,string"`
+	Fixed int64         `json:"fixed"`
+}
+
+type IntegrityReader interface {
+	CheckIntegrity(ctx context.Context) (IntegrityReportModel, error)
+}
+
+type IntegrityWriter interface {
+	FixIntegrity(ctx context.Context) (IntegrityReportModel, error)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"fmt"
+	"unicode/utf8"
+)
+
+// ValidateLength rejects free text longer than max characters. The details
+// tell a client how far over it is so it can trim the text itself; a max of
+// zero or less turns the check off.
+func ValidateLength(field, value string, max int) error {
+	// a string can not hold more characters than bytes
+	if max <= 0 || len(value) <= max {
+		return nil
+	}
+	length := utf8.RuneCountInString(value)
+	if length <= max {
+		return nil
+	}
+	return Unprocessable(fmt.Sprintf("%s must be at most %d characters", field, max)).
+		WithCode("too_long").
+		WithDetails(map[string]any{
+			"field":     field,
+			"maxLength": max,
+			"length":    length,
+			"overBy":    length - max,
+		})
+}
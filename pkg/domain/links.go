@@ -0,0 +1,139 @@
+package domain
+
+import (
+	"encoding/json"
+
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Links are built here from the model itself rather than by the handlers, so
+// every response carrying a model points at the same routes. Targets are
+// relative to the API root and a link is left out when its relation is unset.
+
+type ProjectLinksModel struct {
+	Self    string `json:"self" example:"/projects/3f0c6a5e-2a9f-4f0e-9d56-9b7f1f0c2d11"`
+	Org     string `json:"org,omitempty"`
+	Tickets string `json:"tickets"`
+	Sprints string `json:"sprints"`
+	// Logs is the project's change poll, the closest thing to a history
+	Logs string `json:"logs"`
+}
+
+type TicketLinksModel struct {
+	Self    string `json:"self" example:"/tickets/8b0d7c1e-5a4f-4c61-8f55-0c5b2f8a9e21"`
+	Project string `json:"project"`
+	Sprint  string `json:"sprint,omitempty"`
+	Board   string `json:"board,omitempty"`
+	// Status is the board column the ticket sits in
+	Status string `json:"status,omitempty"`
+	Logs   string `json:"logs"`
+}
+
+type BoardColumnLinksModel struct {
+	Self  string `json:"self" example:"/boards/1c9e2f4a-7b3d-4e8a-a0f1-6d2c5b8e9f30/columns?id=4e5f6a7b-8c9d-4e0f-a1b2-c3d4e5f6a7b8"`
+	Board string `json:"board"`
+}
+
+type TrashItemLinksModel struct {
+	Restore string `json:"restore" example:"/tickets/8b0d7c1e-5a4f-4c61-8f55-0c5b2f8a9e21/restore"`
+}
+
+func linkID(id pgtype.UUID) string {
+	if !id.Valid {
+		return ""
+	}
+	return transformer.UUIDString(id)
+}
+
+func projectLinks(m ProjectModel) *ProjectLinksModel {
+	id := linkID(m.ID)
+	if id == "" {
+		return nil
+	}
+	l := &ProjectLinksModel{
+		Self:    "/projects/" + id,
+		Tickets: "/tickets?projectId=" + id,
+		Sprints: "/sprints?projectId=" + id,
+		Logs:    "/projects/" + id + "/changes",
+	}
+	if org := linkID(m.OrgID); org != "" {
+		l.Org = "/orgs/" + org
+	}
+	return l
+}
+
+func ticketLinks(m TicketModel) *TicketLinksModel {
+	id, project := linkID(m.ID), linkID(m.ProjectID)
+	if id == "" || project == "" {
+		return nil
+	}
+	l := &TicketLinksModel{
+		Self:    "/tickets/" + id,
+		Project: "/projects/" + project,
+		Logs:    "/projects/" + project + "/changes",
+	}
+	if sprint := linkID(m.SprintID); sprint != "" {
+		l.Sprint = "/sprints/" + sprint
+	}
+	if board := linkID(m.BoardID); board != "" {
+		l.Board = "/boards/" + board
+		if column := linkID(m.BoardColumnID); column != "" {
+			l.Status = "/boards/" + board + "/columns?id=" + column
+		}
+	}
+	return l
+}
+
+func boardColumnLinks(m BoardColumnModel) *BoardColumnLinksModel {
+	id, board := linkID(m.ID), linkID(m.BoardID)
+	if id == "" || board == "" {
+		return nil
+	}
+	return &BoardColumnLinksModel{
+		Self:  "/boards/" + board + "/columns?id=" + id,
+		Board: "/boards/" + board,
+	}
+}
+
+func trashItemLinks(m TrashItemModel) *TrashItemLinksModel {
+	id := linkID(m.ID)
+	if id == "" {
+		return nil
+	}
+	switch m.Type {
+	case TrashProject:
+		return &TrashItemLinksModel{Restore: "/projects/" + id + "/restore"}
+	case TrashTicket:
+		return &TrashItemLinksModel{Restore: "/tickets/" + id + "/restore"}
+	case TrashBoardColumn:
+		if board := linkID(m.BoardID); board != "" {
+			return &TrashItemLinksModel{Restore: "/boards/" + board + "/columns/" + id + "/restore"}
+		}
+	}
+	return nil
+}
+
+func (m ProjectModel) MarshalJSON() ([]byte, error) {
+	type plain ProjectModel
+	m.Links = projectLinks(m)
+	return json.Marshal(plain(m))
+}
+
+func (m TicketModel) MarshalJSON() ([]byte, error) {
+	type plain TicketModel
+	m.Links = ticketLinks(m)
+	return json.Marshal(plain(m))
+}
+
+func (m BoardColumnModel) MarshalJSON() ([]byte, error) {
+	type plain BoardColumnModel
+	m.Links = boardColumnLinks(m)
+	return json.Marshal(plain(m))
+}
+
+func (m TrashItemModel) MarshalJSON() ([]byte, error) {
+	type plain TrashItemModel
+	m.Links = trashItemLinks(m)
+	return json.Marshal(plain(m))
+}
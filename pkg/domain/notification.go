@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// PushSubscriptionModel is one device a user receives Web Push notifications
+// on; the encryption keys are never echoed back
+type PushSubscriptionModel struct {
+	ID        pgtype.UUID `json:"id"`
+	Endpoint  string      `json:"endpoint"`
+	UserAgent string      `json:"userAgent"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+// PushSubscriptionCreateModel matches the JSON of a browser PushSubscription,
+// so clients can post subscription.toJSON() as is
+type PushSubscriptionCreateModel struct {
+	Endpoint  string                    `json:"endpoint" validate:"required,url,startswith=https://,max=2048"`
+	Keys      PushSubscriptionKeysModel `json:"keys" validate:"required"`
+	UserAgent string                    `json:"userAgent" validate:"max=255"`
+}
+
+type PushSubscriptionKeysModel struct {
+	P256dh string `json:"p256dh" validate:"required,max=128"`
+	Auth   string `json:"auth" validate:"required,max=64"`
+}
+
+// PushPublicKeyModel carries the VAPID application server key browsers need
+// to subscribe
+type PushPublicKeyModel struct {
+	PublicKey string `json:"publicKey"`
+}
+
+// PushMessageModel is the JSON payload a service worker receives in its push
+// event
+type PushMessageModel struct {
+	Title string `json:"title"`
+	Body  string `json:"body,omitempty"`
+	URL   string `json:"url,omitempty"`
+	Tag   string `json:"tag,omitempty"`
+}
+
+type NotificationReader interface {
+	GetPushPublicKey(ctx context.Context) (PushPublicKeyModel, error)
+	ListPushSubscriptions(ctx context.Context, userID pgtype.UUID) ([]PushSubscriptionModel, error)
+}
+
+type NotificationWriter interface {
+	CreatePushSubscription(ctx context.Context, userID pgtype.UUID, p PushSubscriptionCreateModel) (PushSubscriptionModel, error)
+	DeletePushSubscription(ctx context.Context, userID, id pgtype.UUID) error
+	SendPush(ctx context.Context, userID pgtype.UUID, m PushMessageModel) error
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -27,6 +28,7 @@ type OrganisationPagedModel struct {
 	TotalPages int                 `json:"totalPages"`
 	Page       int                 `json:"page"`
 	PageSize   int                 `json:"pageSize"`
+	HasMore    bool                `json:"hasMore"`
 }
 
 type OrganisationCreateModel struct {
@@ -46,7 +48,7 @@ type OrganisationMemberModel struct {
 }
 
 type OrganisationMemberCreateModel struct {
-	UserId string `json:"userId" validate:"required,uuid4"`
+	UserId string `json:"userId" validate:"required,uuid"`
 	Role   string `json:"role" validate:"required,oneof=admin member viewer"`
 }
 
@@ -59,7 +61,7 @@ type OrganisationMembersSearchModel struct {
 	Email       string        `json:"email"`
 	DisplayName string        `json:"displayName"`
 	PageNumber  int           `json:"pageNumber" validate:"omitempty,min=1"`
-	PageSize    int           `json:"pageSize" validate:"omitempty,min=1,max=100"`
+	PageSize    int           `json:"pageSize" validate:"omitempty,min=1"`
 }
 
 type OrganisationMembersPagedModel struct {
@@ -68,55 +70,37 @@ type OrganisationMembersPagedModel struct {
 	TotalPages int                       `json:"totalPages"`
 	PageNumber int                       `json:"pageNumber"`
 	PageSize   int                       `json:"pageSize"`
+	HasMore    bool                      `json:"hasMore"`
 }
 
 func (m *OrganisationMembersSearchModel) ApplyDefaults() {
-	const (
-		defaultPageNumber = 1
-		defaultPageSize   = 25
-	)
-
-	if m.PageNumber == 0 {
-		m.PageNumber = defaultPageNumber
-	}
-	if m.PageSize == 0 {
-		m.PageSize = defaultPageSize
-	}
-}
-
-func (m *OrganisationMembersPagedModel) Empty(pageNumber, pageSize int) OrganisationMembersPagedModel {
-	return OrganisationMembersPagedModel{
-		Items:      []OrganisationMemberModel{},
-		TotalCount: 0,
-		TotalPages: 0,
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-	}
+	m.PageNumber, m.PageSize = pagination.Normalize(m.PageNumber, m.PageSize)
 }
 
 type Organisations struct {
 	ID         []pgtype.UUID `json:"id" validate:"dive,uuid4"`
 	Name       []string      `json:"name" validate:"dive,min=1"`
 	PageNumber int           `json:"pageNumber" validate:"min=1"`
-	PageSize   int           `json:"pageSize" validate:"min=1,max=100"`
+	PageSize   int           `json:"pageSize" validate:"min=1"`
 	SortBy     string        `json:"sortBy" validate:"oneof=name createdAt updatedAt"`
 	SortOrder  string        `json:"sortOrder" validate:"oneof=asc desc"`
 }
 
+// OrganisationSortFields are the sortBy values SearchOrganisations orders by
+var OrganisationSortFields = []string{"name", "createdAt", "updatedAt"}
+
+// ValidateSort rejects a sort the organisation search cannot honour
+func (o Organisations) ValidateSort() error {
+	return ValidateSort(o.SortBy, o.SortOrder, OrganisationSortFields...)
+}
+
 func (o *Organisations) ApplyDefaults() {
 	const (
-		defaultPageNumber = 1
-		defaultPageSize   = 25
-		defaultSortBy     = "updatedAt"
-		defaultSortOrder  = "desc"
+		defaultSortBy    = "updatedAt"
+		defaultSortOrder = "desc"
 	)
 
-	if o.PageNumber == 0 {
-		o.PageNumber = defaultPageNumber
-	}
-	if o.PageSize == 0 {
-		o.PageSize = defaultPageSize
-	}
+	o.PageNumber, o.PageSize = pagination.Normalize(o.PageNumber, o.PageSize)
 	if o.SortBy == "" {
 		o.SortBy = defaultSortBy
 	}
@@ -125,16 +109,6 @@ func (o *Organisations) ApplyDefaults() {
 	}
 }
 
-func (o *OrganisationPagedModel) Empty(pageNumber, pageSize int) OrganisationPagedModel {
-	return OrganisationPagedModel{
-		Items:      []OrganisationModel{},
-		TotalCount: 0,
-		TotalPages: 0,
-		Page:       pageNumber,
-		PageSize:   pageSize,
-	}
-}
-
 type OrgReader interface {
 	ListOrgs(ctx context.Context, q OrganisationSearchModel) ([]OrganisationModel, error)
 	GetOrgById(ctx context.Context, id pgtype.UUID) (OrganisationModel, error)
@@ -2,20 +2,31 @@ package domain
 
 import (
 	"context"
+	"encoding/json"
 	"time"
 
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type ProjectModel struct {
-	ID          pgtype.UUID `json:"id" validate:"required,uuid4"`
-	OrgID       pgtype.UUID `json:"orgId" validate:"required,uuid4"`
-	Key         string      `json:"key" validate:"required,min=1"`
-	Name        string      `json:"name" validate:"required,min=1"`
-	Description string      `json:"description"`
-	Visibility  string      `json:"visibility" validate:"required,oneof=public private"`
-	CreatedAt   time.Time   `json:"createdAt"`
-	UpdatedAt   time.Time   `json:"updatedAt"`
+	ID          pgtype.UUID        `json:"id" validate:"required,uuid4"`
+	OrgID       pgtype.UUID        `json:"orgId" validate:"required,uuid4"`
+	Key         string             `json:"key" validate:"required,min=1"`
+	Name        string             `json:"name" validate:"required,min=1"`
+	Description string             `json:"description"`
+	Visibility  string             `json:"visibility" validate:"required,oneof=public private"`
+	Status      ProjectStatus      `json:"status"`
+	CreatedAt   time.Time          `json:"createdAt"`
+	UpdatedAt   time.Time          `json:"updatedAt"`
+	DeletedAt   *time.Time         `json:"deletedAt"`
+	Links       *ProjectLinksModel `json:"links,omitempty"`
+
+	// populated only when the list is requested with includeSummary=true
+	OpenTicketCount *int64     `json:"openTicketCount,omitempty"`
+	DoneTicketCount *int64     `json:"doneTicketCount,omitempty"`
+	ColumnCount     *int64     `json:"columnCount,omitempty"`
+	LastActivityAt  *time.Time `json:"lastActivityAt,omitempty"`
 }
 
 type ProjectCreateModel struct {
@@ -23,6 +34,101 @@ type ProjectCreateModel struct {
 	Name        string `json:"name" validate:"required,min=1,max=100"`
 	Description string `json:"description"`
 	Visibility  string `json:"visibility" validate:"required,oneof=public private"`
+	// Status a new project starts in, active when left empty. Existing
+	// projects ignore it and move through the lifecycle endpoints instead.
+	Status ProjectStatus `json:"status,omitempty" validate:"omitempty,oneof=active paused"`
+}
+
+// ProjectBatchCreateModel creates several projects in one organisation at
+// once, e.g. from a provisioning script. Either every project is created or
+// none is.
+type ProjectBatchCreateModel struct {
+	// TemplateID names a project of the same organisation whose priority
+	// levels every new project starts with instead of the defaults
+	TemplateID pgtype.UUID          `json:"templateId" swaggertype:"string"`
+	Projects   []ProjectCreateModel `json:"projects" validate:"required,min=1,max=50,dive"`
+}
+
+const (
+	BatchCreated  = "created"
+	BatchRejected = "rejected"
+	BatchSkipped  = "skipped"
+)
+
+// ProjectBatchItemResultModel reports one project of a batch by its position
+// in the request. Rejected items carry the reason; skipped ones were fine but
+// were not created because another item was rejected.
+type ProjectBatchItemResultModel struct {
+	Index   int           `json:"index"`
+	Status  string        `json:"status" enums:"created,rejected,skipped"`
+	Project *ProjectModel `json:"project,omitempty"`
+	Code    string        `json:"code,omitempty"`
+	Message string        `json:"message,omitempty"`
+}
+
+type ProjectBatchResultModel struct {
+	Results []ProjectBatchItemResultModel `json:"results"`
+}
+
+// ProjectStatus is the lifecycle stage of a project. The column is a
+// project_status enum and a trigger refuses moves that are not listed in
+// ProjectStatusTransitions.
+type ProjectStatus string
+
+const (
+	ProjectStatusActive   ProjectStatus = "active"   // work on the project is in progress
+	ProjectStatusPaused   ProjectStatus = "paused"   // work is on hold and expected to resume
+	ProjectStatusArchived ProjectStatus = "archived" // the project is finished and kept for reference
+)
+
+// ProjectStatusTransitions lists, per status, the statuses a project may move
+// to from it
+var ProjectStatusTransitions = map[ProjectStatus][]ProjectStatus{
+	ProjectStatusActive:   {ProjectStatusPaused, ProjectStatusArchived},
+	ProjectStatusPaused:   {ProjectStatusActive, ProjectStatusArchived},
+	ProjectStatusArchived: {ProjectStatusActive},
+}
+
+var projectStatusDescriptions = map[ProjectStatus]string{
+	ProjectStatusActive:   "Work on the project is in progress",
+	ProjectStatusPaused:   "Work is on hold and expected to resume",
+	ProjectStatusArchived: "The project is finished and kept for reference",
+}
+
+// ProjectStatuses is every status in lifecycle order
+var ProjectStatuses = []ProjectStatus{ProjectStatusActive, ProjectStatusPaused, ProjectStatusArchived}
+
+func (s ProjectStatus) Valid() bool {
+	_, ok := ProjectStatusTransitions[s]
+	return ok
+}
+
+func (s ProjectStatus) CanTransitionTo(to ProjectStatus) bool {
+	for _, allowed := range ProjectStatusTransitions[s] {
+		if allowed == to {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectStatusModel describes a status and where a project can go from it
+type ProjectStatusModel struct {
+	Status      ProjectStatus   `json:"status"`
+	Description string          `json:"description" example:"Work is on hold and expected to resume"`
+	Transitions []ProjectStatus `json:"transitions"`
+}
+
+func ProjectStatusModels() []ProjectStatusModel {
+	out := make([]ProjectStatusModel, 0, len(ProjectStatuses))
+	for _, s := range ProjectStatuses {
+		out = append(out, ProjectStatusModel{
+			Status:      s,
+			Description: projectStatusDescriptions[s],
+			Transitions: append([]ProjectStatus(nil), ProjectStatusTransitions[s]...),
+		})
+	}
+	return out
 }
 
 type ProjectUpdateModel struct {
@@ -35,11 +141,14 @@ type ProjectVisibilityModel struct {
 }
 
 type ProjectsSearchModel struct {
-	ID         []pgtype.UUID `json:"id" validate:"omitempty,dive,uuid4"`
-	OrgID      []pgtype.UUID `json:"orgId" validate:"omitempty,dive,uuid4"`
-	Name       string        `json:"name"`
-	PageNumber int           `json:"pageNumber" validate:"omitempty,min=1"`
-	PageSize   int           `json:"pageSize" validate:"omitempty,min=1,max=100"`
+	ID             []pgtype.UUID `json:"id" validate:"omitempty,dive,uuid4"`
+	OrgID          []pgtype.UUID `json:"orgId" validate:"omitempty,dive,uuid4"`
+	Name           string        `json:"name"`
+	ExactName      bool          `json:"exactName"`
+	IncludeSummary bool          `json:"includeSummary"`
+	IncludeDeleted bool          `json:"includeDeleted"`
+	PageNumber     int           `json:"pageNumber" validate:"omitempty,min=1"`
+	PageSize       int           `json:"pageSize" validate:"omitempty,min=1"`
 }
 
 type ProjectsPagedModel struct {
@@ -48,42 +157,165 @@ type ProjectsPagedModel struct {
 	TotalPages int            `json:"totalPages"`
 	PageNumber int            `json:"pageNumber"`
 	PageSize   int            `json:"pageSize"`
+	HasMore    bool           `json:"hasMore"`
 }
 
 func (m *ProjectsSearchModel) ApplyDefaults() {
-	const (
-		defaultPageNumber = 1
-		defaultPageSize   = 25
-	)
+	m.PageNumber, m.PageSize = pagination.Normalize(m.PageNumber, m.PageSize)
+}
 
-	if m.PageNumber == 0 {
-		m.PageNumber = defaultPageNumber
-	}
-	if m.PageSize == 0 {
-		m.PageSize = defaultPageSize
-	}
+// MaxProjectUIStateBytes caps the stored UI state document per user and project
+const MaxProjectUIStateBytes = 16 << 10
+
+type ProjectUIStateModel struct {
+	ProjectID pgtype.UUID     `json:"projectId"`
+	State     json.RawMessage `json:"state" swaggertype:"object"`
+	UpdatedAt *time.Time      `json:"updatedAt"`
 }
 
-func (m *ProjectsPagedModel) Empty(pageNumber, pageSize int) ProjectsPagedModel {
-	return ProjectsPagedModel{
-		Items:      []ProjectModel{},
-		TotalCount: 0,
-		TotalPages: 0,
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-	}
+type ProjectUIStateUpdateModel struct {
+	State json.RawMessage `json:"state" validate:"required" swaggertype:"object"`
+}
+
+// ProjectPresenceModel lists who has the project open right now. Viewers drop
+// off when no heartbeat arrived within TTLSeconds, so clients should send one
+// well inside that window.
+type ProjectPresenceModel struct {
+	ProjectID  pgtype.UUID          `json:"projectId"  swaggertype:"string"`
+	Viewers    []ProjectViewerModel `json:"viewers"`
+	TTLSeconds int                  `json:"ttlSeconds" example:"30"`
+}
+
+type ProjectViewerModel struct {
+	UserID pgtype.UUID `json:"userId" swaggertype:"string"`
+	// BoardID is the board the viewer reported having open, if any
+	BoardID    pgtype.UUID `json:"boardId"    swaggertype:"string"`
+	Since      time.Time   `json:"since"`
+	LastSeenAt time.Time   `json:"lastSeenAt"`
+}
+
+type ProjectPresenceHeartbeatModel struct {
+	BoardID pgtype.UUID `json:"boardId" swaggertype:"string"`
+}
+
+// ProjectPriorityModel is one level of a project's priority scheme; tickets
+// refer to it by Key and lists are ordered by Position
+type ProjectPriorityModel struct {
+	ID        pgtype.UUID `json:"id"`
+	ProjectID pgtype.UUID `json:"projectId"`
+	Key       string      `json:"key"`
+	Name      string      `json:"name"`
+	Color     string      `json:"color" example:"#dc2626"`
+	Position  int32       `json:"position"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+type ProjectPriorityCreateModel struct {
+	Key      string `json:"key" validate:"required,min=1,max=32,lowercase,alphanum"`
+	Name     string `json:"name" validate:"required,min=1,max=50"`
+	Color    string `json:"color" validate:"required,hexcolor,max=7"`
+	Position int32  `json:"position" validate:"omitempty,min=1"`
+}
+
+type ProjectPriorityUpdateModel struct {
+	Name     string `json:"name,omitempty" validate:"omitempty,min=1,max=50"`
+	Color    string `json:"color,omitempty" validate:"omitempty,hexcolor,max=7"`
+	Position int32  `json:"position,omitempty" validate:"omitempty,min=1"`
+}
+
+// ProjectRole is what a member may do in a project; each role can do
+// everything the roles ranked below it can
+type ProjectRole string
+
+const (
+	ProjectRoleAdmin  ProjectRole = "admin"  // manages the project, its priorities and members
+	ProjectRoleMember ProjectRole = "member" // works on tickets and board columns
+	ProjectRoleViewer ProjectRole = "viewer" // reads only
+)
+
+var projectRoleRanks = map[ProjectRole]int{
+	ProjectRoleViewer: 1,
+	ProjectRoleMember: 2,
+	ProjectRoleAdmin:  3,
+}
+
+func (r ProjectRole) Valid() bool {
+	_, ok := projectRoleRanks[r]
+	return ok
+}
+
+// Grants reports whether a member holding r may do what required allows
+func (r ProjectRole) Grants(required ProjectRole) bool {
+	return projectRoleRanks[r] >= projectRoleRanks[required]
+}
+
+type ProjectMemberModel struct {
+	UserID   pgtype.UUID `json:"userId" swaggertype:"string"`
+	Name     string      `json:"name"`
+	Email    string      `json:"email"`
+	Role     ProjectRole `json:"role" enums:"admin,member,viewer"`
+	JoinedAt time.Time   `json:"joinedAt"`
+}
+
+// ProjectMemberCreateModel adds a member of the project's organisation to
+// the project
+type ProjectMemberCreateModel struct {
+	UserID string      `json:"userId" validate:"required,uuid"`
+	Role   ProjectRole `json:"role" validate:"required,oneof=admin member viewer"`
+}
+
+type ProjectMemberUpdateModel struct {
+	Role ProjectRole `json:"role" validate:"required,oneof=admin member viewer"`
+}
+
+type ProjectMembersSearchModel struct {
+	UserID     []pgtype.UUID `json:"userId"`
+	Role       string        `json:"role" enums:"admin,member,viewer"`
+	PageNumber int           `json:"pageNumber" validate:"omitempty,min=1"`
+	PageSize   int           `json:"pageSize" validate:"omitempty,min=1"`
+}
+
+type ProjectMembersPagedModel struct {
+	Items      []ProjectMemberModel `json:"items"`
+	TotalCount int                  `json:"totalCount"`
+	TotalPages int                  `json:"totalPages"`
+	PageNumber int                  `json:"pageNumber"`
+	PageSize   int                  `json:"pageSize"`
+	HasMore    bool                 `json:"hasMore"`
+}
+
+func (m *ProjectMembersSearchModel) ApplyDefaults() {
+	m.PageNumber, m.PageSize = pagination.Normalize(m.PageNumber, m.PageSize)
 }
 
 type ProjectReader interface {
+	// AuthorizeProject fails unless the caller holds at least role in the
+	// project; a project the caller is not a member of is reported as not found
+	AuthorizeProject(ctx context.Context, projectID pgtype.UUID, role ProjectRole) error
 	GetProjectById(ctx context.Context, id pgtype.UUID) (ProjectModel, error)
 	GetProjectByKey(ctx context.Context, orgId pgtype.UUID, key string) (ProjectModel, error)
 	ListProjectsByOrg(ctx context.Context, orgId pgtype.UUID) ([]ProjectModel, error)
 	ListProjectsByOrgPaged(ctx context.Context, q ProjectsSearchModel) (ProjectsPagedModel, error)
+	ListProjectPriorities(ctx context.Context, projectID pgtype.UUID) ([]ProjectPriorityModel, error)
+	ListProjectMembers(ctx context.Context, projectID pgtype.UUID, q ProjectMembersSearchModel) (ProjectMembersPagedModel, error)
 }
 
 type ProjectWriter interface {
 	CreateProject(ctx context.Context, orgId pgtype.UUID, p ProjectCreateModel) (ProjectModel, error)
+	CreateProjects(ctx context.Context, orgId pgtype.UUID, p ProjectBatchCreateModel) (ProjectBatchResultModel, error)
+	UpsertProject(ctx context.Context, id pgtype.UUID, orgId pgtype.UUID, p ProjectCreateModel) (ProjectModel, bool, error)
 	UpdateProject(ctx context.Context, id pgtype.UUID, p ProjectUpdateModel) (ProjectModel, error)
 	UpdateProjectVisibility(ctx context.Context, id pgtype.UUID, p ProjectVisibilityModel) (ProjectModel, error)
 	DeleteProject(ctx context.Context, id pgtype.UUID) error
+	RestoreProject(ctx context.Context, id pgtype.UUID) (ProjectModel, error)
+	ActivateProject(ctx context.Context, id pgtype.UUID) (ProjectModel, error)
+	PauseProject(ctx context.Context, id pgtype.UUID) (ProjectModel, error)
+	ArchiveProject(ctx context.Context, id pgtype.UUID) (ProjectModel, error)
+	CreateProjectPriority(ctx context.Context, projectID pgtype.UUID, p ProjectPriorityCreateModel) (ProjectPriorityModel, error)
+	UpdateProjectPriority(ctx context.Context, projectID, id pgtype.UUID, p ProjectPriorityUpdateModel) (ProjectPriorityModel, error)
+	DeleteProjectPriority(ctx context.Context, projectID, id pgtype.UUID, replaceWith string) error
+	AddProjectMember(ctx context.Context, projectID pgtype.UUID, p ProjectMemberCreateModel) (ProjectMemberModel, error)
+	UpdateProjectMemberRole(ctx context.Context, projectID, userID pgtype.UUID, p ProjectMemberUpdateModel) (ProjectMemberModel, error)
+	RemoveProjectMember(ctx context.Context, projectID, userID pgtype.UUID) error
 }
@@ -0,0 +1,159 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type DashboardModel struct {
+	ActiveProjects    int64                  `json:"activeProjects"`
+	OpenTickets       int64                  `json:"openTickets"`
+	OverdueTickets    int64                  `json:"overdueTickets"`
+	CompletedTickets  int64                  `json:"completedTickets"`
+	CompletedSince    time.Time              `json:"completedSince"`
+	RecentlyCompleted []DashboardTicketModel `json:"recentlyCompleted"`
+	LatestActivity    []DashboardTicketModel `json:"latestActivity"`
+}
+
+type DashboardTicketModel struct {
+	ID         pgtype.UUID `json:"id"`
+	ProjectID  pgtype.UUID `json:"projectId"`
+	Key        string      `json:"key"`
+	Title      string      `json:"title"`
+	ColumnName string      `json:"columnName"`
+	UpdatedAt  time.Time   `json:"updatedAt"`
+}
+
+type WeeklyReportModel struct {
+	ProjectID     pgtype.UUID               `json:"projectId"`
+	Week          string                    `json:"week" example:"2025-W32"`
+	StartsAt      time.Time                 `json:"startsAt"`
+	EndsAt        time.Time                 `json:"endsAt"`
+	Completed     []WeeklyReportTicketModel `json:"completed"`
+	Created       []WeeklyReportTicketModel `json:"created"`
+	CarriedOver   []WeeklyReportTicketModel `json:"carriedOver"`
+	StatusChanges []WeeklyReportStatusModel `json:"statusChanges"`
+}
+
+type WeeklyReportTicketModel struct {
+	ID        pgtype.UUID `json:"id"`
+	Key       string      `json:"key"`
+	Title     string      `json:"title"`
+	CreatedAt time.Time   `json:"createdAt"`
+	UpdatedAt time.Time   `json:"updatedAt"`
+}
+
+type WeeklyReportStatusModel struct {
+	Type       string      `json:"type" enums:"sprint_started,sprint_completed"`
+	SprintID   pgtype.UUID `json:"sprintId"`
+	SprintName string      `json:"sprintName"`
+	At         time.Time   `json:"at"`
+}
+
+// ProjectStatsModel breaks a project's live tickets down for charts; BoardID
+// is set when the figures only cover that board
+type ProjectStatsModel struct {
+	ProjectID  pgtype.UUID           `json:"projectId"`
+	BoardID    pgtype.UUID           `json:"boardId"`
+	Priorities []PriorityStatModel   `json:"priorities"`
+	Effort     EffortStatModel       `json:"effort"`
+	Columns    []ColumnEffortModel   `json:"columns"`
+	Assignees  []AssigneeEffortModel `json:"assignees"`
+}
+
+type PriorityStatModel struct {
+	Key         string `json:"key"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Position    int32  `json:"position"`
+	OpenTickets int64  `json:"openTickets"`
+	DoneTickets int64  `json:"doneTickets"`
+}
+
+// EffortStatModel sums story point estimates; RemainingEstimate is what a
+// burndown chart plots, everything not yet in a done column
+type EffortStatModel struct {
+	TotalEstimate      int64 `json:"totalEstimate"`
+	CompletedEstimate  int64 `json:"completedEstimate"`
+	RemainingEstimate  int64 `json:"remainingEstimate"`
+	UnestimatedTickets int64 `json:"unestimatedTickets"`
+}
+
+type ColumnEffortModel struct {
+	BoardColumnID pgtype.UUID `json:"boardColumnId"`
+	BoardID       pgtype.UUID `json:"boardId"`
+	Name          string      `json:"name"`
+	Category      string      `json:"category" enums:"todo,in_progress,done"`
+	TicketCount   int64       `json:"ticketCount"`
+	TotalEstimate int64       `json:"totalEstimate"`
+}
+
+// BoardSnapshotsModel holds the daily per column ticket counts of a project,
+// or of one board when BoardID is set, from From through To. Days without a
+// snapshot are left out.
+type BoardSnapshotsModel struct {
+	ProjectID pgtype.UUID             `json:"projectId"`
+	BoardID   pgtype.UUID             `json:"boardId"`
+	From      string                  `json:"from" example:"2025-08-01"`
+	To        string                  `json:"to" example:"2025-08-30"`
+	Days      []BoardSnapshotDayModel `json:"days"`
+}
+
+// BoardSnapshotDayModel is the state of the columns at the last snapshot of
+// a UTC day, in board and column order
+type BoardSnapshotDayModel struct {
+	Date    string              `json:"date" example:"2025-08-01"`
+	Columns []ColumnEffortModel `json:"columns"`
+}
+
+// AssigneeEffortModel groups unassigned tickets under an empty AssigneeID
+type AssigneeEffortModel struct {
+	AssigneeID        pgtype.UUID `json:"assigneeId"`
+	AssigneeName      string      `json:"assigneeName"`
+	TicketCount       int64       `json:"ticketCount"`
+	TotalEstimate     int64       `json:"totalEstimate"`
+	RemainingEstimate int64       `json:"remainingEstimate"`
+}
+
+// PortfolioModel rolls up every active project the caller can reach;
+// completions are compared between the current and the previous period
+type PortfolioModel struct {
+	PeriodDays             int                     `json:"periodDays"`
+	PeriodStartsAt         time.Time               `json:"periodStartsAt"`
+	PreviousPeriodStartsAt time.Time               `json:"previousPeriodStartsAt"`
+	Projects               []PortfolioProjectModel `json:"projects"`
+}
+
+type PortfolioProjectModel struct {
+	ID                  pgtype.UUID              `json:"id"`
+	OrgID               pgtype.UUID              `json:"orgId"`
+	Key                 string                   `json:"key"`
+	Name                string                   `json:"name"`
+	OpenTickets         int64                    `json:"openTickets"`
+	DoneTickets         int64                    `json:"doneTickets"`
+	OverdueTickets      int64                    `json:"overdueTickets"`
+	CompletedThisPeriod int64                    `json:"completedThisPeriod"`
+	CompletedLastPeriod int64                    `json:"completedLastPeriod"`
+	Trend               string                   `json:"trend" enums:"up,down,flat"`
+	Milestone           *PortfolioMilestoneModel `json:"milestone"`
+}
+
+// PortfolioMilestoneModel is the project's active sprint; nil when none runs
+type PortfolioMilestoneModel struct {
+	SprintID     pgtype.UUID `json:"sprintId"`
+	Name         string      `json:"name"`
+	DueAt        *time.Time  `json:"dueAt"`
+	TotalTickets int64       `json:"totalTickets"`
+	DoneTickets  int64       `json:"doneTickets"`
+	Progress     int         `json:"progress" example:"40"`
+}
+
+type ReportReader interface {
+	GetDashboard(ctx context.Context, userID pgtype.UUID) (DashboardModel, error)
+	GetWeeklyReport(ctx context.Context, projectID pgtype.UUID, week string) (WeeklyReportModel, error)
+	GetProjectStats(ctx context.Context, projectID, boardID pgtype.UUID) (ProjectStatsModel, error)
+	GetPortfolio(ctx context.Context, userID pgtype.UUID, periodDays int) (PortfolioModel, error)
+	GetBoardSnapshots(ctx context.Context, projectID, boardID pgtype.UUID, from, to string) (BoardSnapshotsModel, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+	"time"
+)
+
+// PurgeReportModel counts the soft-deleted rows a purge removed for good.
+// Rows that went along with a purged parent, e.g. the tickets of a purged
+// project, are not counted apart. Attachments counts the files whose content
+// was removed with their tickets. Changes counts the change feed entries
+// trimmed. A cutoff stays zero while its retention is off.
+type PurgeReportModel struct {
+	PurgedAt     time.Time `json:"purgedAt"`
+	DeletedUntil time.Time `json:"deletedUntil"`
+	ChangesUntil time.Time `json:"changesUntil"`
+	Orgs         int64     `json:"orgs"`
+	Projects     int64     `json:"projects"`
+	Sprints      int64     `json:"sprints"`
+	Boards       int64     `json:"boards"`
+	BoardColumns int64     `json:"boardColumns"`
+	Tickets      int64     `json:"tickets"`
+	Users        int64     `json:"users"`
+	Attachments  int64     `json:"attachments"`
+	Changes      int64     `json:"changes"`
+}
+
+// Total is the number of rows counted by the report
+func (m PurgeReportModel) Total() int64 {
+	return m.Orgs + m.Projects + m.Sprints + m.Boards + m.BoardColumns + m.Tickets + m.Users + m.Attachments + m.Changes
+}
+
+// LogGrowthModel is a reading of the activity log's size next to its soft
+// caps, a zero cap is not set. It is also the body of a log growth alert.
+type LogGrowthModel struct {
+	CheckedAt time.Time `json:"checkedAt"`
+	Rows      int64     `json:"rows"`
+	Bytes     int64     `json:"bytes"`
+	MaxRows   int64     `json:"maxRows"`
+	MaxBytes  int64     `json:"maxBytes"`
+}
+
+// OverRows reports whether the log holds more rows than its cap allows
+func (m LogGrowthModel) OverRows() bool {
+	return m.MaxRows > 0 && m.Rows > m.MaxRows
+}
+
+// OverBytes reports whether the log takes more space than its cap allows
+func (m LogGrowthModel) OverBytes() bool {
+	return m.MaxBytes > 0 && m.Bytes > m.MaxBytes
+}
+
+type RetentionWriter interface {
+	Purge(ctx context.Context) (PurgeReportModel, error)
+}
@@ -0,0 +1,70 @@
+package domain
+
+import "strings"
+
+// Scopes follow the "<resource>:<action>" convention. A token carrying
+// "<resource>:*" is granted every action on that resource, and "*" grants
+// everything.
+const (
+	ScopeAll = "*"
+
+	// ScopeAdmin is granted to accounts listed in ADMIN_EMAILS and unlocks
+	// instance-wide operations such as listing soft-deleted content.
+	ScopeAdmin = "admin"
+
+	ScopeUsersRead  = "users:read"
+	ScopeUsersWrite = "users:write"
+
+	ScopeOrgsRead  = "orgs:read"
+	ScopeOrgsWrite = "orgs:write"
+
+	ScopeProjectsRead  = "projects:read"
+	ScopeProjectsWrite = "projects:write"
+
+	ScopeSprintsRead  = "sprints:read"
+	ScopeSprintsWrite = "sprints:write"
+
+	ScopeBoardsRead  = "boards:read"
+	ScopeBoardsWrite = "boards:write"
+
+	ScopeTicketsRead  = "tickets:read"
+	ScopeTicketsWrite = "tickets:write"
+
+	ScopeReportsRead = "reports:read"
+
+	ScopeNotificationsRead  = "notifications:read"
+	ScopeNotificationsWrite = "notifications:write"
+)
+
+// DefaultUserScopes are granted to tokens issued through an interactive
+// login. API keys and OAuth clients are expected to carry a narrower subset.
+var DefaultUserScopes = []string{
+	ScopeUsersRead, ScopeUsersWrite,
+	ScopeOrgsRead, ScopeOrgsWrite,
+	ScopeProjectsRead, ScopeProjectsWrite,
+	ScopeSprintsRead, ScopeSprintsWrite,
+	ScopeBoardsRead, ScopeBoardsWrite,
+	ScopeTicketsRead, ScopeTicketsWrite,
+	ScopeReportsRead,
+	ScopeNotificationsRead, ScopeNotificationsWrite,
+}
+
+// HasScopes reports whether the granted scopes satisfy every required scope.
+func HasScopes(granted []string, required ...string) bool {
+	for _, req := range required {
+		if !hasScope(granted, req) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasScope(granted []string, required string) bool {
+	resource, _, _ := strings.Cut(required, ":")
+	for _, g := range granted {
+		if g == ScopeAll || g == required || g == resource+":*" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	SortAsc  = "asc"
+	SortDesc = "desc"
+)
+
+// ValidateSort checks a list request's sortBy against the fields its query
+// knows how to order by. Queries pick the ORDER BY through CASE branches, so
+// an unknown value would silently fall through to no ordering at all.
+func ValidateSort(sortBy, sortOrder string, fields ...string) error {
+	if !slices.Contains(fields, sortBy) {
+		allowed := strings.Join(fields, ", ")
+		return Unprocessable(fmt.Sprintf("sortBy must be one of %s", allowed)).
+			WithCode("unknown_sort").
+			WithDetails(map[string]string{"sortBy": sortBy, "allowed": allowed})
+	}
+	if sortOrder != SortAsc && sortOrder != SortDesc {
+		return Unprocessable("sortOrder must be asc or desc").
+			WithCode("unknown_sort_order").
+			WithDetails(map[string]string{"sortOrder": sortOrder, "allowed": SortAsc + ", " + SortDesc})
+	}
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"time"
 
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -19,6 +20,7 @@ type SprintModel struct {
 	CompletedAt        *time.Time  `json:"completedAt"`
 	CreatedAt          time.Time   `json:"createdAt"`
 	UpdatedAt          time.Time   `json:"updatedAt"`
+	DeletedAt          *time.Time  `json:"deletedAt"`
 }
 
 type SprintCreateModel struct {
@@ -43,20 +45,11 @@ type SprintsSearchModel struct {
 	ProjectID  []pgtype.UUID `json:"projectId" validate:"omitempty,dive,uuid4"`
 	Name       string        `json:"name"`
 	PageNumber int           `json:"pageNumber" validate:"omitempty,min=1"`
-	PageSize   int           `json:"pageSize" validate:"omitempty,min=1,max=100"`
+	PageSize   int           `json:"pageSize" validate:"omitempty,min=1"`
 }
 
 func (s *SprintsSearchModel) ApplyDefaults() {
-	const (
-		defaultPageNumber = 1
-		defaultPageSize   = 25
-	)
-	if s.PageNumber == 0 {
-		s.PageNumber = defaultPageNumber
-	}
-	if s.PageSize == 0 {
-		s.PageSize = defaultPageSize
-	}
+	s.PageNumber, s.PageSize = pagination.Normalize(s.PageNumber, s.PageSize)
 }
 
 type SprintsPagedModel struct {
@@ -65,16 +58,7 @@ type SprintsPagedModel struct {
 	TotalPages int           `json:"totalPages"`
 	PageNumber int           `json:"pageNumber"`
 	PageSize   int           `json:"pageSize"`
-}
-
-func (m SprintsPagedModel) Empty(pageNumber, pageSize int) SprintsPagedModel {
-	return SprintsPagedModel{
-		Items:      []SprintModel{},
-		TotalCount: 0,
-		TotalPages: 0,
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-	}
+	HasMore    bool          `json:"hasMore"`
 }
 
 type SprintReader interface {
@@ -4,29 +4,26 @@ import (
 	"context"
 	"time"
 
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
 type TicketSearchModel struct {
-	ID         []pgtype.UUID `json:"id" validate:"omitempty,dive,uuid4"`
-	ProjectID  []pgtype.UUID `json:"projectId" validate:"omitempty,dive,uuid4"`
-	SprintID   []pgtype.UUID `json:"sprintId" validate:"omitempty,dive,uuid4"`
-	BoardID    []pgtype.UUID `json:"boardId" validate:"omitempty,dive,uuid4"`
-	PageNumber int           `json:"pageNumber" validate:"omitempty,min=1"`
-	PageSize   int           `json:"pageSize" validate:"omitempty,min=1,max=100"`
+	ID             []pgtype.UUID `json:"id" validate:"omitempty,dive,uuid4"`
+	ProjectID      []pgtype.UUID `json:"projectId" validate:"omitempty,dive,uuid4"`
+	SprintID       []pgtype.UUID `json:"sprintId" validate:"omitempty,dive,uuid4"`
+	BoardID        []pgtype.UUID `json:"boardId" validate:"omitempty,dive,uuid4"`
+	BoardColumnID  []pgtype.UUID `json:"boardColumnId" validate:"omitempty,dive,uuid4"`
+	Status         []string      `json:"status" example:"in-progress"`
+	StatusCategory []string      `json:"statusCategory" enums:"todo,in_progress,done"`
+	IncludeDeleted bool          `json:"includeDeleted"`
+	Stale          bool          `json:"stale"`
+	PageNumber     int           `json:"pageNumber" validate:"omitempty,min=1"`
+	PageSize       int           `json:"pageSize" validate:"omitempty,min=1"`
 }
 
 func (t *TicketSearchModel) ApplyDefaults() {
-	const (
-		defaultPageNumber = 1
-		defaultPageSize   = 25
-	)
-	if t.PageNumber == 0 {
-		t.PageNumber = defaultPageNumber
-	}
-	if t.PageSize == 0 {
-		t.PageSize = defaultPageSize
-	}
+	t.PageNumber, t.PageSize = pagination.Normalize(t.PageNumber, t.PageSize)
 }
 
 type TicketsPagedModel struct {
@@ -35,43 +32,37 @@ type TicketsPagedModel struct {
 	TotalPages int           `json:"totalPages"`
 	PageNumber int           `json:"pageNumber"`
 	PageSize   int           `json:"pageSize"`
-}
-
-func (t TicketsPagedModel) Empty(pageNumber, pageSize int) TicketsPagedModel {
-	return TicketsPagedModel{
-		Items:      []TicketModel{},
-		TotalCount: 0,
-		TotalPages: 1,
-		PageNumber: pageNumber,
-		PageSize:   pageSize,
-	}
+	HasMore    bool          `json:"hasMore"`
 }
 
 type TicketModel struct {
-	ID            pgtype.UUID `json:"id" validate:"required,uuid4"`
-	ProjectID     pgtype.UUID `json:"projectId" validate:"required,uuid4"`
-	TicketNumber  int32       `json:"ticketNumber"`
-	Key           string      `json:"key"`
-	Type          string      `json:"type"`
-	Priority      string      `json:"priority"`
-	Title         string      `json:"title"`
-	Description   string      `json:"description"`
-	SprintID      pgtype.UUID `json:"sprintId"`
-	BoardID       pgtype.UUID `json:"boardId"`
-	BoardColumnID pgtype.UUID `json:"boardColumnId"`
-	AssigneeID    pgtype.UUID `json:"assigneeId"`
-	ReporterID    pgtype.UUID `json:"reporterId"`
-	EpicID        pgtype.UUID `json:"epicId"`
-	ParentID      pgtype.UUID `json:"parentId"`
-	StoryPoints   int32       `json:"storyPoints"`
-	DueDate       time.Time   `json:"dueDate"`
-	CreatedAt     time.Time   `json:"createdAt"`
-	UpdatedAt     time.Time   `json:"updatedAt"`
+	ID            pgtype.UUID       `json:"id" validate:"required,uuid4"`
+	ProjectID     pgtype.UUID       `json:"projectId" validate:"required,uuid4"`
+	TicketNumber  int32             `json:"ticketNumber"`
+	Key           string            `json:"key"`
+	Type          string            `json:"type"`
+	Priority      string            `json:"priority"`
+	Title         string            `json:"title"`
+	Description   string            `json:"description"`
+	SprintID      pgtype.UUID       `json:"sprintId"`
+	BoardID       pgtype.UUID       `json:"boardId"`
+	BoardColumnID pgtype.UUID       `json:"boardColumnId"`
+	AssigneeID    pgtype.UUID       `json:"assigneeId"`
+	ReporterID    pgtype.UUID       `json:"reporterId"`
+	EpicID        pgtype.UUID       `json:"epicId"`
+	ParentID      pgtype.UUID       `json:"parentId"`
+	StoryPoints   int32             `json:"storyPoints"`
+	DueDate       time.Time         `json:"dueDate"`
+	Rank          string            `json:"rank"`
+	CreatedAt     time.Time         `json:"createdAt"`
+	UpdatedAt     time.Time         `json:"updatedAt"`
+	DeletedAt     *time.Time        `json:"deletedAt"`
+	Links         *TicketLinksModel `json:"links,omitempty"`
 }
 
 type TicketCreateModel struct {
 	Type        string      `json:"type" validate:"required,oneof=bug story task epic"`
-	Priority    string      `json:"priority" validate:"required,oneof=low medium high critical"`
+	Priority    string      `json:"priority" validate:"required,max=32"`
 	Title       string      `json:"title" validate:"required,min=1,max=255"`
 	Description string      `json:"description"`
 	AssigneeID  pgtype.UUID `json:"assigneeId" validate:"omitempty,uuid4"`
@@ -84,7 +75,7 @@ type TicketUpdateModel struct {
 	Title       string      `json:"title,omitempty" validate:"omitempty,min=1,max=255"`
 	Description string      `json:"description,omitempty"`
 	Type        string      `json:"type,omitempty" validate:"omitempty,oneof=bug story task epic"`
-	Priority    string      `json:"priority,omitempty" validate:"omitempty,oneof=low medium high critical"`
+	Priority    string      `json:"priority,omitempty" validate:"omitempty,max=32"`
 	AssigneeID  pgtype.UUID `json:"assigneeId,omitempty" validate:"omitempty,uuid4"`
 	SprintID    pgtype.UUID `json:"sprintId,omitempty" validate:"omitempty,uuid4"`
 	StoryPoints int32       `json:"storyPoints,omitempty" validate:"omitempty,min=0"`
@@ -96,10 +87,197 @@ type TicketBoardMoveModel struct {
 	BoardColumnID pgtype.UUID `json:"boardColumnId" validate:"required"`
 }
 
+// TicketProjectMoveModel moves a ticket to another project. The ticket lands
+// in BoardColumnID when given, else in the default column of BoardID, else in
+// the project's backlog. Priority replaces a level the project does not have.
+type TicketProjectMoveModel struct {
+	ProjectID     pgtype.UUID `json:"projectId" validate:"required"`
+	BoardID       pgtype.UUID `json:"boardId"`
+	BoardColumnID pgtype.UUID `json:"boardColumnId"`
+	Priority      string      `json:"priority,omitempty" validate:"omitempty,max=32"`
+}
+
+// TicketProjectMovedEventModel is the payload of a ticket moving to another
+// project. Both projects log it, each with its own ID as ProjectID.
+type TicketProjectMovedEventModel struct {
+	BulkEventModel
+	FromProjectID pgtype.UUID `json:"fromProjectId"`
+	ToProjectID   pgtype.UUID `json:"toProjectId"`
+	FromKey       string      `json:"fromKey"`
+	Key           string      `json:"key"`
+}
+
+// TicketPositionModel places a ticket right after AfterID within its current
+// column; leaving it empty moves the ticket to the top of the column
+type TicketPositionModel struct {
+	AfterID pgtype.UUID `json:"afterId"`
+}
+
+const (
+	TicketMutationCreate = "create"
+	TicketMutationUpdate = "update"
+	TicketMutationDelete = "delete"
+
+	SyncApplied  = "applied"
+	SyncConflict = "conflict"
+	SyncRejected = "rejected"
+	SyncFailed   = "failed"
+)
+
+// TicketSyncModel replays mutations an offline client queued, in order
+type TicketSyncModel struct {
+	Mutations []TicketMutationModel `json:"mutations" validate:"required,min=1,max=100,dive"`
+}
+
+// TicketMutationModel is one queued write. BaseVersion is the ticket's
+// updatedAt when the client last saw it; an update or delete only applies
+// while the ticket is still at that version.
+type TicketMutationModel struct {
+	ClientID    string             `json:"clientId" validate:"required,max=64"`
+	Op          string             `json:"op" validate:"required,oneof=create update delete"`
+	ID          pgtype.UUID        `json:"id"`
+	ProjectID   pgtype.UUID        `json:"projectId"`
+	BaseVersion time.Time          `json:"baseVersion"`
+	Create      *TicketCreateModel `json:"create,omitempty"`
+	Update      *TicketUpdateModel `json:"update,omitempty"`
+}
+
+// TicketMutationResultModel reports one mutation by its ClientID. A conflict
+// carries the server's ticket so the client can merge and retry with its
+// updatedAt as the new base version. Rejected mutations will never apply as
+// sent; failed ones hit a server error or another user's edit lock and can
+// be retried as is.
+type TicketMutationResultModel struct {
+	ClientID string       `json:"clientId"`
+	Status   string       `json:"status" enums:"applied,conflict,rejected,failed"`
+	Ticket   *TicketModel `json:"ticket,omitempty"`
+	Code     string       `json:"code,omitempty"`
+	Message  string       `json:"message,omitempty"`
+}
+
+type TicketSyncResultModel struct {
+	Results []TicketMutationResultModel `json:"results"`
+}
+
+// TicketLockModel is the advisory edit lock on a ticket. A client editing the
+// description claims it and renews it well within TTLSeconds; while it is
+// held, updates from anyone but the holder are refused with 423.
+type TicketLockModel struct {
+	TicketID   pgtype.UUID `json:"ticketId"   swaggertype:"string"`
+	Locked     bool        `json:"locked"`
+	HolderID   pgtype.UUID `json:"holderId"   swaggertype:"string"`
+	AcquiredAt *time.Time  `json:"acquiredAt"`
+	ExpiresAt  *time.Time  `json:"expiresAt"`
+	TTLSeconds int         `json:"ttlSeconds" example:"120"`
+}
+
+// TicketBulkDeleteModel takes the ticket search filters. With DryRun set
+// nothing is deleted and the result previews what would be.
+type TicketBulkDeleteModel struct {
+	ID        []pgtype.UUID `json:"id" swaggertype:"array,string"`
+	ProjectID []pgtype.UUID `json:"projectId" swaggertype:"array,string"`
+	SprintID  []pgtype.UUID `json:"sprintId" swaggertype:"array,string"`
+	BoardID   []pgtype.UUID `json:"boardId" swaggertype:"array,string"`
+	DryRun    bool          `json:"dryRun"`
+}
+
+// TicketBulkDeleteResultModel counts the tickets matched by a dry run or
+// deleted otherwise; a dry run also samples the newest of them
+type TicketBulkDeleteResultModel struct {
+	DryRun bool          `json:"dryRun"`
+	Count  int64         `json:"count"`
+	Sample []TicketModel `json:"sample,omitempty"`
+}
+
+const (
+	TicketExportMarkdown = "md"
+	TicketExportText     = "txt"
+)
+
+// TicketExportModel is a ticket rendered as a self-contained document for
+// pasting into docs or emails. Body is Markdown or plain text per Format.
+type TicketExportModel struct {
+	Key    string
+	Format string
+	Body   string
+}
+
+const (
+	TicketGraphEdgeSubtask = "subtask"
+	TicketGraphEdgeEpic    = "epic"
+)
+
+// TicketGraphModel is a project's tickets laid out as a graph. Edges run from
+// a parent or epic to the ticket under it, and a node's Depth is the longest
+// chain of edges leading to it, so nodes of equal depth share a layer.
+type TicketGraphModel struct {
+	ProjectID pgtype.UUID            `json:"projectId" swaggertype:"string"`
+	Nodes     []TicketGraphNodeModel `json:"nodes"`
+	Edges     []TicketGraphEdgeModel `json:"edges"`
+}
+
+// TicketGraphNodeModel is one ticket of the graph. Status is the category of
+// its board column, empty while it sits in the backlog.
+type TicketGraphNodeModel struct {
+	ID         pgtype.UUID `json:"id" swaggertype:"string"`
+	Key        string      `json:"key" example:"FLX-12"`
+	Title      string      `json:"title"`
+	Type       string      `json:"type" example:"task"`
+	Priority   string      `json:"priority" example:"medium"`
+	Status     string      `json:"status" example:"in_progress"`
+	AssigneeID pgtype.UUID `json:"assigneeId" swaggertype:"string"`
+	Depth      int         `json:"depth"`
+}
+
+type TicketGraphEdgeModel struct {
+	Source pgtype.UUID `json:"source" swaggertype:"string"`
+	Target pgtype.UUID `json:"target" swaggertype:"string"`
+	Kind   string      `json:"kind" enums:"subtask,epic" example:"subtask"`
+}
+
+// TicketAssignmentSuggestionsModel ranks the members of a project's
+// organisation as assignees of a ticket, best first
+type TicketAssignmentSuggestionsModel struct {
+	TicketID pgtype.UUID                       `json:"ticketId" swaggertype:"string"`
+	Items    []TicketAssignmentSuggestionModel `json:"items"`
+}
+
+// TicketAssignmentSuggestionModel is one member with the numbers behind
+// their rank. Open counts are their unfinished tickets across the
+// organisation; the done counts are finished tickets of the same type or in
+// the same epic as the ticket. Score rises with the latter and drops with the
+// former.
+type TicketAssignmentSuggestionModel struct {
+	UserID       pgtype.UUID `json:"userId" swaggertype:"string"`
+	DisplayName  string      `json:"displayName"`
+	OpenTickets  int64       `json:"openTickets"`
+	OpenPoints   int64       `json:"openPoints"`
+	DoneSameType int64       `json:"doneSameType"`
+	DoneSameEpic int64       `json:"doneSameEpic"`
+	Score        int64       `json:"score"`
+}
+
+// TicketStaleSettingsModel is how many days a project's tickets may go
+// without activity before they are flagged as stale, 0 while nudges are off.
+// UpdatedAt is nil while the project still has the defaults.
+type TicketStaleSettingsModel struct {
+	ProjectID pgtype.UUID `json:"projectId" swaggertype:"string"`
+	AfterDays int32       `json:"afterDays" example:"14"`
+	UpdatedBy pgtype.UUID `json:"updatedBy" swaggertype:"string"`
+	UpdatedAt *time.Time  `json:"updatedAt"`
+}
+
+// TicketStaleSettingsUpdateModel replaces a project's stale settings, 0
+// turns the nudges off
+type TicketStaleSettingsUpdateModel struct {
+	AfterDays int32 `json:"afterDays" validate:"min=0,max=365" example:"14"`
+}
+
 type TicketReader interface {
 	ListTickets(ctx context.Context, q TicketSearchModel) (TicketsPagedModel, error)
 	GetTicket(ctx context.Context, id pgtype.UUID) (TicketModel, error)
 	GetTicketByKey(ctx context.Context, projectID pgtype.UUID, key string) (TicketModel, error)
+	GetTicketStaleSettings(ctx context.Context, projectID pgtype.UUID) (TicketStaleSettingsModel, error)
 }
 
 type TicketWriter interface {
@@ -108,5 +286,11 @@ type TicketWriter interface {
 	MoveTicketToBoard(ctx context.Context, id pgtype.UUID, p TicketBoardMoveModel) (TicketModel, error)
 	MoveTicketToSprint(ctx context.Context, id pgtype.UUID, sprintID pgtype.UUID) (TicketModel, error)
 	MoveTicketToBoardColumn(ctx context.Context, id pgtype.UUID, p TicketBoardMoveModel) (TicketModel, error)
+	MoveTicketPosition(ctx context.Context, id pgtype.UUID, p TicketPositionModel) (TicketModel, error)
+	MoveTicketToProject(ctx context.Context, id pgtype.UUID, p TicketProjectMoveModel) (TicketModel, error)
 	DeleteTicket(ctx context.Context, id pgtype.UUID) error
+	RestoreTicket(ctx context.Context, id pgtype.UUID) (TicketModel, error)
+	BulkDeleteTickets(ctx context.Context, p TicketBulkDeleteModel) (TicketBulkDeleteResultModel, error)
+	SyncTickets(ctx context.Context, p TicketSyncModel) (TicketSyncResultModel, error)
+	UpdateTicketStaleSettings(ctx context.Context, projectID pgtype.UUID, p TicketStaleSettingsUpdateModel) (TicketStaleSettingsModel, error)
 }
@@ -0,0 +1,57 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	TrashProject     = "project"
+	TrashTicket      = "ticket"
+	TrashBoardColumn = "board_column"
+)
+
+// TrashSearchModel pages through what was soft-deleted in an organisation,
+// optionally narrowed to some types or projects
+type TrashSearchModel struct {
+	OrgID      pgtype.UUID   `json:"orgId" validate:"required"`
+	Type       []string      `json:"type" enums:"project,ticket,board_column"`
+	ProjectID  []pgtype.UUID `json:"projectId" validate:"omitempty,dive,uuid4"`
+	PageNumber int           `json:"pageNumber" validate:"omitempty,min=1"`
+	PageSize   int           `json:"pageSize" validate:"omitempty,min=1"`
+}
+
+func (t *TrashSearchModel) ApplyDefaults() {
+	t.PageNumber, t.PageSize = pagination.Normalize(t.PageNumber, t.PageSize)
+}
+
+// TrashItemModel is one soft-deleted project, ticket or board column. Key is
+// the project or ticket key, BoardID is only set for columns. ParentDeleted
+// means what holds the item is deleted too and has to be restored first.
+type TrashItemModel struct {
+	Type          string               `json:"type" enums:"project,ticket,board_column"`
+	ID            pgtype.UUID          `json:"id" swaggertype:"string"`
+	ProjectID     pgtype.UUID          `json:"projectId" swaggertype:"string"`
+	BoardID       pgtype.UUID          `json:"boardId" swaggertype:"string"`
+	Key           string               `json:"key,omitempty" example:"FLX-12"`
+	Name          string               `json:"name"`
+	DeletedAt     time.Time            `json:"deletedAt"`
+	ParentDeleted bool                 `json:"parentDeleted"`
+	Links         *TrashItemLinksModel `json:"links,omitempty"`
+}
+
+type TrashPagedModel struct {
+	Items      []TrashItemModel `json:"items"`
+	TotalCount int              `json:"totalCount"`
+	TotalPages int              `json:"totalPages"`
+	PageNumber int              `json:"pageNumber"`
+	PageSize   int              `json:"pageSize"`
+	HasMore    bool             `json:"hasMore"`
+}
+
+type TrashReader interface {
+	ListTrash(ctx context.Context, q TrashSearchModel) (TrashPagedModel, error)
+}
@@ -0,0 +1,55 @@
+package domain
+
+import (
+	"context"
+
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// UsageDayModel counts the authenticated API requests of a UTC day
+type UsageDayModel struct {
+	Date     string `json:"date" example:"2025-08-01"`
+	Requests int64  `json:"requests"`
+}
+
+// UserUsageModel lists the days within the last PeriodDays on which the user
+// made requests, newest first
+type UserUsageModel struct {
+	UserID     pgtype.UUID     `json:"userId"`
+	PeriodDays int             `json:"periodDays"`
+	Days       []UsageDayModel `json:"days"`
+}
+
+// UsageModel is one user's request count on one day
+type UsageModel struct {
+	UserID      pgtype.UUID `json:"userId"`
+	Email       string      `json:"email"`
+	DisplayName string      `json:"displayName"`
+	Date        string      `json:"date" example:"2025-08-01"`
+	Requests    int64       `json:"requests"`
+}
+
+type UsageSearchModel struct {
+	PeriodDays int `json:"periodDays" validate:"omitempty,min=1"`
+	PageNumber int `json:"pageNumber" validate:"omitempty,min=1"`
+	PageSize   int `json:"pageSize" validate:"omitempty,min=1"`
+}
+
+func (m *UsageSearchModel) ApplyDefaults() {
+	m.PageNumber, m.PageSize = pagination.Normalize(m.PageNumber, m.PageSize)
+}
+
+type UsagePagedModel struct {
+	Items      []UsageModel `json:"items"`
+	TotalCount int          `json:"totalCount"`
+	TotalPages int          `json:"totalPages"`
+	PageNumber int          `json:"pageNumber"`
+	PageSize   int          `json:"pageSize"`
+	HasMore    bool         `json:"hasMore"`
+}
+
+type UsageReader interface {
+	GetUserUsage(ctx context.Context, userID pgtype.UUID, periodDays int) (UserUsageModel, error)
+	ListUsage(ctx context.Context, q UsageSearchModel) (UsagePagedModel, error)
+}
@@ -51,3 +51,23 @@ type UserWrite interface {
 	UpdateUser(ctx context.Context, id pgtype.UUID, p UserUpdateModel) (UserModel, error)
 	DeleteUser(ctx context.Context, id pgtype.UUID) error
 }
+
+// UserAvatarModel is a user's profile picture. Generated is set when the user
+// never uploaded one and Data holds an identicon drawn from their ID.
+type UserAvatarModel struct {
+	UserID      pgtype.UUID `json:"userId"      swaggertype:"string"`
+	ContentType string      `json:"contentType" example:"image/png"`
+	Size        int         `json:"size"        example:"20480"`
+	Generated   bool        `json:"generated"`
+	Data        []byte      `json:"-"`
+	UpdatedAt   time.Time   `json:"updatedAt"`
+}
+
+type UserAvatarRead interface {
+	GetUserAvatar(ctx context.Context, userID pgtype.UUID) (UserAvatarModel, error)
+}
+
+type UserAvatarWrite interface {
+	SetUserAvatar(ctx context.Context, userID pgtype.UUID, data []byte) (UserAvatarModel, error)
+	DeleteUserAvatar(ctx context.Context, userID pgtype.UUID) error
+}
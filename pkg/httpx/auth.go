@@ -2,23 +2,48 @@ package httpx
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
-var authWrite domain.AuthWrite
+// Authenticator guards routes with bearer token validation. It is built once
+// at startup and handed to every module that registers private routes.
+type Authenticator struct {
+	tokens domain.AuthWrite
 
-func InitAuth(v domain.AuthWrite) {
-	if authWrite != nil {
-		panic("httpx.InitAuth called more than once")
-	}
-	authWrite = v
+	// basic maps a hash of Basic credentials to the access token they were
+	// exchanged for, see RequireBasicAuth
+	basic *basicCache
+
+	observe func(userID pgtype.UUID)
+}
+
+func NewAuthenticator(tokens domain.AuthWrite) *Authenticator {
+	return &Authenticator{tokens: tokens, basic: newBasicCache(basicCacheSize)}
+}
+
+// ForgetUser drops the Basic credentials remembered for the user, so a
+// changed password or a deleted account stops working right away
+func (a *Authenticator) ForgetUser(userID pgtype.UUID) {
+	a.basic.forget(userID)
+}
+
+// Observe calls fn with the user of every request whose credentials are
+// valid, whether or not its scopes then let it through. Set it before the
+// server starts; fn runs on the request path and has to be quick.
+func (a *Authenticator) Observe(fn func(userID pgtype.UUID)) {
+	a.observe = fn
 }
 
-func RequireAuth(next http.HandlerFunc) http.HandlerFunc {
+// RequireAuth validates the bearer token and, when scopes are declared for the
+// route, rejects tokens that were not granted all of them. Identifier path
+// params are validated afterwards via ValidatePathUUIDs.
+func (a *Authenticator) RequireAuth(next http.HandlerFunc, scopes ...string) http.HandlerFunc {
+	next = ValidatePathUUIDs(next)
 	return func(w http.ResponseWriter, r *http.Request) {
 		token, ok := bearerToken(r)
 		if !ok {
@@ -26,22 +51,92 @@ func RequireAuth(next http.HandlerFunc) http.HandlerFunc {
 			return
 		}
 
-		claim, err := authWrite.ValidateAccessToken(r.Context(), token)
+		claim, err := a.tokens.ValidateAccessToken(r.Context(), token)
 		if err != nil {
-			var appErr *AppError
-			if errors.As(err, &appErr) {
+			if appErr, ok := asAppError(err); ok {
 				ErrorCode(w, appErr.Status, appErr.Message, appErr.Code)
 				return
 			}
 			Error(w, http.StatusUnauthorized, "invalid or expired token")
 			return
 		}
+		if a.observe != nil {
+			a.observe(claim.ID)
+		}
+
+		if !domain.HasScopes(claim.Scopes, scopes...) {
+			ErrorCode(w, http.StatusForbidden, "token is missing required scope", "insufficient_scope")
+			return
+		}
 
 		ctx := context.WithValue(r.Context(), keyUserID, claim.ID)
+		ctx = context.WithValue(ctx, keyScopes, claim.Scopes)
 		next(w, r.WithContext(ctx))
 	}
 }
 
+// RequireBasicAuth guards routes used by clients that can only send a
+// username and password, such as calendar apps. The credentials are exchanged
+// for an access token through Login, and that token is reused for the same
+// credentials for a few minutes at most so the password hash is not checked
+// on every request. Path params are not validated; the handler parses its
+// own.
+func (a *Authenticator) RequireBasicAuth(next http.HandlerFunc, realm string, scopes ...string) http.HandlerFunc {
+	challenge := `Basic realm="` + realm + `", charset="UTF-8"`
+	return func(w http.ResponseWriter, r *http.Request) {
+		email, password, ok := r.BasicAuth()
+		if !ok {
+			w.Header().Set("WWW-Authenticate", challenge)
+			Error(w, http.StatusUnauthorized, "missing authorization header")
+			return
+		}
+
+		claim, err := a.basicClaim(r.Context(), email, password)
+		if err != nil {
+			w.Header().Set("WWW-Authenticate", challenge)
+			Error(w, http.StatusUnauthorized, "invalid credentials")
+			return
+		}
+		if a.observe != nil {
+			a.observe(claim.ID)
+		}
+
+		if !domain.HasScopes(claim.Scopes, scopes...) {
+			ErrorCode(w, http.StatusForbidden, "token is missing required scope", "insufficient_scope")
+			return
+		}
+
+		ctx := context.WithValue(r.Context(), keyUserID, claim.ID)
+		ctx = context.WithValue(ctx, keyScopes, claim.Scopes)
+		next(w, r.WithContext(ctx))
+	}
+}
+
+func (a *Authenticator) basicClaim(ctx context.Context, email, password string) (domain.AuthTokenClaimModel, error) {
+	key := newBasicKey(email, password)
+	if token, ok := a.basic.get(key); ok {
+		claim, err := a.tokens.ValidateAccessToken(ctx, token)
+		if err == nil {
+			return claim, nil
+		}
+	}
+
+	auth, err := a.tokens.Login(ctx, domain.AuthLoginModel{Email: email, Password: password})
+	if err != nil {
+		return domain.AuthTokenClaimModel{}, err
+	}
+	claim, err := a.tokens.ValidateAccessToken(ctx, auth.AccessToken)
+	if err != nil {
+		return domain.AuthTokenClaimModel{}, err
+	}
+	var expires time.Time
+	if claim.ExpiresAt != nil {
+		expires = claim.ExpiresAt.Time
+	}
+	a.basic.put(key, claim.ID, auth.AccessToken, expires)
+	return claim, nil
+}
+
 func bearerToken(r *http.Request) (string, bool) {
 	h := r.Header.Get("Authorization")
 	if h == "" {
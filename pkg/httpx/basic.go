@@ -0,0 +1,124 @@
+package httpx
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// basicCacheSize bounds how many credentials RequireBasicAuth remembers,
+	// the least recently used are forgotten first
+	basicCacheSize = 1024
+	// basicCacheTTL bounds how long credentials are trusted without checking
+	// the password again, so a password changed through another instance
+	// stops working here soon after
+	basicCacheTTL = 5 * time.Minute
+)
+
+type basicKey [sha256.Size]byte
+
+func newBasicKey(email, password string) basicKey {
+	return sha256.Sum256([]byte(email + "\x00" + password))
+}
+
+type basicEntry struct {
+	key     basicKey
+	userID  pgtype.UUID
+	token   string
+	expires time.Time
+}
+
+// basicCache maps a hash of Basic credentials to the access token they were
+// exchanged for. It holds at most size entries, drops them once they expire
+// and can drop every entry of a user at once.
+type basicCache struct {
+	mu    sync.Mutex
+	size  int
+	now   func() time.Time
+	order *list.List // of *basicEntry, most recently used first
+	items map[basicKey]*list.Element
+}
+
+func newBasicCache(size int) *basicCache {
+	return &basicCache{
+		size:  size,
+		now:   time.Now,
+		order: list.New(),
+		items: map[basicKey]*list.Element{},
+	}
+}
+
+func (c *basicCache) get(key basicKey) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, ok := c.items[key]
+	if !ok {
+		return "", false
+	}
+	entry := el.Value.(*basicEntry)
+	if !c.now().Before(entry.expires) {
+		c.remove(el)
+		return "", false
+	}
+	c.order.MoveToFront(el)
+	return entry.token, true
+}
+
+// put remembers the token until expires or basicCacheTTL from now, whichever
+// comes first
+func (c *basicCache) put(key basicKey, userID pgtype.UUID, token string, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	if limit := now.Add(basicCacheTTL); expires.IsZero() || expires.After(limit) {
+		expires = limit
+	}
+	if el, ok := c.items[key]; ok {
+		c.remove(el)
+	}
+
+	// a put follows a full login, sweeping here costs little next to it
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if !now.Before(el.Value.(*basicEntry).expires) {
+			c.remove(el)
+		}
+		el = next
+	}
+	for c.order.Len() >= c.size {
+		c.remove(c.order.Back())
+	}
+
+	c.items[key] = c.order.PushFront(&basicEntry{key: key, userID: userID, token: token, expires: expires})
+}
+
+// forget drops every entry of the user
+func (c *basicCache) forget(userID pgtype.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for el := c.order.Front(); el != nil; {
+		next := el.Next()
+		if el.Value.(*basicEntry).userID == userID {
+			c.remove(el)
+		}
+		el = next
+	}
+}
+
+func (c *basicCache) count() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+func (c *basicCache) remove(el *list.Element) {
+	c.order.Remove(el)
+	delete(c.items, el.Value.(*basicEntry).key)
+}
@@ -0,0 +1,158 @@
+package httpx
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func testUser(b byte) pgtype.UUID {
+	return pgtype.UUID{Bytes: [16]byte{15: b}, Valid: true}
+}
+
+func TestBasicCache(t *testing.T) {
+	start := time.Unix(1700000000, 0)
+	alice, bob := testUser(1), testUser(2)
+	k1, k2, k3 := newBasicKey("a@x", "1"), newBasicKey("a@x", "2"), newBasicKey("b@x", "1")
+
+	tests := []struct {
+		name string
+		run  func(c *basicCache, now *time.Time)
+		hits map[basicKey]bool
+	}{
+		{
+			name: "hit before expiry",
+			run: func(c *basicCache, now *time.Time) {
+				c.put(k1, alice, "t1", start.Add(time.Minute))
+				*now = start.Add(59 * time.Second)
+			},
+			hits: map[basicKey]bool{k1: true},
+		},
+		{
+			name: "miss at token expiry",
+			run: func(c *basicCache, now *time.Time) {
+				c.put(k1, alice, "t1", start.Add(time.Minute))
+				*now = start.Add(time.Minute)
+			},
+			hits: map[basicKey]bool{k1: false},
+		},
+		{
+			name: "long lived token capped",
+			run: func(c *basicCache, now *time.Time) {
+				c.put(k1, alice, "t1", start.Add(24*time.Hour))
+				*now = start.Add(basicCacheTTL)
+			},
+			hits: map[basicKey]bool{k1: false},
+		},
+		{
+			name: "least recently used evicted",
+			run: func(c *basicCache, now *time.Time) {
+				c.put(k1, alice, "t1", time.Time{})
+				c.put(k2, alice, "t2", time.Time{})
+				c.get(k1)
+				c.put(k3, bob, "t3", time.Time{})
+			},
+			hits: map[basicKey]bool{k1: true, k2: false, k3: true},
+		},
+		{
+			name: "expired swept before evicting",
+			run: func(c *basicCache, now *time.Time) {
+				c.put(k1, alice, "t1", time.Time{})
+				c.put(k2, alice, "t2", start.Add(time.Second))
+				*now = start.Add(time.Minute)
+				c.put(k3, bob, "t3", time.Time{})
+			},
+			hits: map[basicKey]bool{k1: true, k2: false, k3: true},
+		},
+		{
+			name: "forget drops only the user",
+			run: func(c *basicCache, now *time.Time) {
+				c.put(k1, alice, "t1", time.Time{})
+				c.put(k3, bob, "t3", time.Time{})
+				c.forget(alice)
+			},
+			hits: map[basicKey]bool{k1: false, k3: true},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := start
+			c := newBasicCache(2)
+			c.now = func() time.Time { return now }
+
+			tt.run(c, &now)
+			if c.count() > 2 {
+				t.Fatalf("cache holds %d entries, more than its size", c.count())
+			}
+			for key, want := range tt.hits {
+				if _, ok := c.get(key); ok != want {
+					t.Errorf("get(%x) hit = %v, want %v", key[:4], ok, want)
+				}
+			}
+		})
+	}
+}
+
+// stubTokens logs in one user whose password can change; every access token
+// it issued stays valid
+type stubTokens struct {
+	domain.AuthWrite
+	id       pgtype.UUID
+	password string
+	logins   int
+}
+
+func (s *stubTokens) Login(ctx context.Context, p domain.AuthLoginModel) (domain.AuthModel, error) {
+	s.logins++
+	if p.Password != s.password {
+		return domain.AuthModel{}, errors.New("invalid credentials")
+	}
+	return domain.AuthModel{AccessToken: "token-" + p.Password}, nil
+}
+
+func (s *stubTokens) ValidateAccessToken(ctx context.Context, token string) (domain.AuthTokenClaimModel, error) {
+	return domain.AuthTokenClaimModel{
+		RegisteredClaims: jwt.RegisteredClaims{ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour))},
+		ID:               s.id,
+		Scopes:           domain.DefaultUserScopes,
+	}, nil
+}
+
+func TestRequireBasicAuth_ForgetUser(t *testing.T) {
+	tokens := &stubTokens{id: testUser(1), password: "old"}
+	a := NewAuthenticator(tokens)
+	h := a.RequireBasicAuth(func(w http.ResponseWriter, r *http.Request) {}, "test")
+
+	status := func(password string) int {
+		req := httptest.NewRequest("GET", "/", nil)
+		req.SetBasicAuth("a@x", password)
+		rec := httptest.NewRecorder()
+		h(rec, req)
+		return rec.Code
+	}
+
+	if got := status("old"); got != http.StatusOK {
+		t.Fatalf("first request = %d, want 200", got)
+	}
+	if got := status("old"); got != http.StatusOK || tokens.logins != 1 {
+		t.Fatalf("second request = %d after %d logins, want 200 from the cache", got, tokens.logins)
+	}
+
+	tokens.password = "new"
+	a.ForgetUser(tokens.id)
+
+	if got := status("old"); got != http.StatusUnauthorized {
+		t.Fatalf("old password after the change = %d, want 401", got)
+	}
+	if got := status("new"); got != http.StatusOK {
+		t.Fatalf("new password = %d, want 200", got)
+	}
+}
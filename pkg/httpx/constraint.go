@@ -0,0 +1,51 @@
+package httpx
+
+import (
+	"log/slog"
+	"net/http"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// constraintStatus maps the integrity violation class (SQLSTATE 23xxx) to the
+// status a client gets when the database refuses a write the service let
+// through. Duplicates are conflicts; anything else means the request named a
+// missing record or carried a value the schema does not allow.
+var constraintStatus = map[string]struct {
+	status  int
+	code    string
+	message string
+}{
+	"23505": {http.StatusConflict, "unique_violation", "the resource already exists"},
+	"23P01": {http.StatusConflict, "exclusion_violation", "the resource conflicts with an existing one"},
+	"23503": {http.StatusUnprocessableEntity, "foreign_key_violation", "the resource references a record that does not exist or is still in use"},
+	"23514": {http.StatusUnprocessableEntity, "check_violation", "the request contains a value that is not allowed"},
+	"23502": {http.StatusUnprocessableEntity, "not_null_violation", "the request is missing a required value"},
+}
+
+// constraintError turns an integrity violation into a client error. The
+// constraint and column names are passed on so the client can tell which
+// rule was broken; they name schema objects, never row data.
+func constraintError(pgErr *pgconn.PgError) (*AppError, bool) {
+	c, ok := constraintStatus[pgErr.Code]
+	if !ok {
+		return nil, false
+	}
+
+	// still the client's fault, but a service check is probably missing
+	slog.Warn("database constraint violated", "constraint", pgErr.ConstraintName, "error", pgErr)
+
+	details := map[string]string{}
+	if pgErr.ConstraintName != "" {
+		details["constraint"] = pgErr.ConstraintName
+	}
+	if pgErr.ColumnName != "" {
+		details["column"] = pgErr.ColumnName
+	}
+
+	appErr := &AppError{Status: c.status, Message: c.message, Code: c.code, Err: pgErr}
+	if len(details) > 0 {
+		appErr.Details = details
+	}
+	return appErr, true
+}
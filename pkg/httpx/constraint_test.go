@@ -0,0 +1,63 @@
+package httpx_test
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestHandle_ConstraintViolations(t *testing.T) {
+	cases := []struct {
+		pgErr  *pgconn.PgError
+		status int
+		code   string
+	}{
+		{&pgconn.PgError{Code: "23505", ConstraintName: "projects_key_key"}, http.StatusConflict, "unique_violation"},
+		{&pgconn.PgError{Code: "23P01", ConstraintName: "board_columns_single_default"}, http.StatusConflict, "exclusion_violation"},
+		{&pgconn.PgError{Code: "23503", ConstraintName: "tickets_priority_fkey"}, http.StatusUnprocessableEntity, "foreign_key_violation"},
+		{&pgconn.PgError{Code: "23514", ConstraintName: "tickets_story_points_check"}, http.StatusUnprocessableEntity, "check_violation"},
+		{&pgconn.PgError{Code: "23502", ColumnName: "title"}, http.StatusUnprocessableEntity, "not_null_violation"},
+	}
+
+	for _, c := range cases {
+		w := httptest.NewRecorder()
+		// services wrap repository errors before returning them
+		httpx.Handle(w, fmt.Errorf("create ticket: %w", c.pgErr))
+
+		if w.Code != c.status {
+			t.Errorf("%s: status = %d, want %d", c.pgErr.Code, w.Code, c.status)
+			continue
+		}
+
+		var body struct {
+			Error struct {
+				Code    string            `json:"code"`
+				Details map[string]string `json:"details"`
+			} `json:"error"`
+		}
+		if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body.Error.Code != c.code {
+			t.Errorf("%s: code = %q, want %q", c.pgErr.Code, body.Error.Code, c.code)
+		}
+		if body.Error.Details["constraint"] != c.pgErr.ConstraintName || body.Error.Details["column"] != c.pgErr.ColumnName {
+			t.Errorf("%s: unexpected details %v", c.pgErr.Code, body.Error.Details)
+		}
+	}
+}
+
+func TestHandle_OtherDatabaseErrorsStayInternal(t *testing.T) {
+	w := httptest.NewRecorder()
+	httpx.Handle(w, errors.Join(errors.New("boom"), &pgconn.PgError{Code: "42P01"}))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Fatalf("status = %d, want 500", w.Code)
+	}
+}
@@ -3,6 +3,7 @@ package httpx
 import (
 	"context"
 
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
@@ -10,6 +11,7 @@ type contextKey string
 
 const (
 	keyUserID    contextKey = "user_id"
+	keyScopes    contextKey = "scopes"
 	keyRequestID contextKey = "request_id"
 	keyUserAgent contextKey = "user_agent"
 	keyRemoteIP  contextKey = "remote_ip"
@@ -30,11 +32,22 @@ func UserIDFrom(ctx context.Context) (pgtype.UUID, bool) {
 	return id, ok
 }
 
+func ScopesFrom(ctx context.Context) []string {
+	v, _ := ctx.Value(keyScopes).([]string)
+	return v
+}
+
 func RemoteIPFrom(ctx context.Context) string {
 	v, _ := ctx.Value(keyRemoteIP).(string)
 	return v
 }
 
+// WithUserID attaches a user to ctx outside of RequireAuth, e.g. when a signed
+// webhook acts on behalf of the account that configured it
+func WithUserID(ctx context.Context, id pgtype.UUID) context.Context {
+	return context.WithValue(ctx, keyUserID, id)
+}
+
 func MustUserID(ctx context.Context) pgtype.UUID {
 	id, ok := ctx.Value(keyUserID).(pgtype.UUID)
 	if !ok {
@@ -42,3 +55,14 @@ func MustUserID(ctx context.Context) pgtype.UUID {
 	}
 	return id
 }
+
+// MemberFilter is the user whose project memberships limit what the caller
+// reaches. It is invalid for calls without a user, such as bus subscribers
+// and sweepers, and for tokens with the admin scope, which are not restricted.
+func MemberFilter(ctx context.Context) pgtype.UUID {
+	userID, ok := UserIDFrom(ctx)
+	if !ok || domain.HasScopes(ScopesFrom(ctx), domain.ScopeAdmin) {
+		return pgtype.UUID{}
+	}
+	return userID
+}
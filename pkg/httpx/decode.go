@@ -18,7 +18,13 @@ var validate = validator.New()
 func Decode(r *http.Request, dst any) error {
 	r.Body = http.MaxBytesReader(nil, r.Body, 1<<20) // 1MB
 
-	dec := json.NewDecoder(r.Body)
+	return DecodeReader(r.Body, dst)
+}
+
+// DecodeReader decodes JSON from body the way request bodies are, for
+// payloads that arrive wrapped in another document.
+func DecodeReader(body io.Reader, dst any) error {
+	dec := json.NewDecoder(body)
 	dec.DisallowUnknownFields() // reject unexpected fields
 
 	if err := dec.Decode(dst); err != nil {
@@ -36,7 +42,13 @@ func DecodeAndValidate(r *http.Request, dst any) error {
 		return err
 	}
 
-	if err := validate.Struct(dst); err != nil {
+	return Validate(dst)
+}
+
+// Validate runs struct validation on a value that did not come from a request
+// body, e.g. one assembled from a webhook payload.
+func Validate(v any) error {
+	if err := validate.Struct(v); err != nil {
 		var validationErrs validator.ValidationErrors
 		if errors.As(err, &validationErrs) {
 			return formatValidationErrors(validationErrs)
@@ -92,6 +104,8 @@ func formatValidationErrors(errs validator.ValidationErrors) error {
 			msgs = append(msgs, fmt.Sprintf("%s must contain only digits", field))
 		case "url":
 			msgs = append(msgs, fmt.Sprintf("%s must be a valid URL", field))
+		case "hexcolor":
+			msgs = append(msgs, fmt.Sprintf("%s must be a valid hex color", field))
 		default:
 			msgs = append(msgs, fmt.Sprintf("%s is invalid (%s)", field, e.Tag()))
 		}
@@ -1,15 +1,25 @@
 package httpx
 
 import (
+	"context"
 	"errors"
 	"log/slog"
 	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/jackc/pgx/v5/pgconn"
 )
 
+// StatusClientClosedRequest is the non-standard status nginx popularised for a
+// client that hung up before the response was ready; nobody reads it, it only
+// keeps access logs apart from real failures
+const StatusClientClosedRequest = 499
+
 type AppError struct {
 	Status  int    // HTTP status code
 	Message string // safe to show to the client
 	Code    string // optional machine-readable code e.g. "email_taken"
+	Details any    // optional extra context sent to the client, e.g. a suggested value
 	Err     error  // original error for logging — never sent to client
 }
 
@@ -48,6 +58,10 @@ func TooManyRequests(msg string) *AppError {
 	return &AppError{Status: http.StatusTooManyRequests, Message: msg}
 }
 
+func PayloadTooLarge(msg string) *AppError {
+	return &AppError{Status: http.StatusRequestEntityTooLarge, Message: msg}
+}
+
 func Unprocessable(msg string) *AppError {
 	return &AppError{Status: http.StatusUnprocessableEntity, Message: msg}
 }
@@ -56,24 +70,107 @@ func NotImplemented(msg string) *AppError {
 	return &AppError{Status: http.StatusNotImplemented, Message: msg}
 }
 
+func ServiceUnavailable(msg string) *AppError {
+	return &AppError{Status: http.StatusServiceUnavailable, Message: msg}
+}
+
 func (e *AppError) WithCode(code string) *AppError {
 	e.Code = code
 	return e
 }
 
+func (e *AppError) WithDetails(details any) *AppError {
+	e.Details = details
+	return e
+}
+
 func (e *AppError) Wrap(err error) *AppError {
 	e.Err = err
 	return e
 }
 
+// domainStatus is the single place domain error kinds meet HTTP; services
+// only say what went wrong and never pick a status code
+var domainStatus = map[domain.ErrorKind]int{
+	domain.KindInvalid:       http.StatusBadRequest,
+	domain.KindUnauthorized:  http.StatusUnauthorized,
+	domain.KindForbidden:     http.StatusForbidden,
+	domain.KindNotFound:      http.StatusNotFound,
+	domain.KindConflict:      http.StatusConflict,
+	domain.KindUnprocessable: http.StatusUnprocessableEntity,
+	domain.KindTooLarge:      http.StatusRequestEntityTooLarge,
+	domain.KindRateLimited:   http.StatusTooManyRequests,
+	domain.KindUnsupported:   http.StatusNotImplemented,
+	domain.KindLocked:        http.StatusLocked,
+	domain.KindGone:          http.StatusGone,
+}
+
+// asAppError resolves err to the client facing error it should produce.
+// Domain errors of an unmapped kind are left to the internal error path.
+func asAppError(err error) (*AppError, bool) {
+	var appErr *AppError
+	if errors.As(err, &appErr) {
+		return appErr, true
+	}
+
+	var domainErr *domain.Error
+	if errors.As(err, &domainErr) {
+		status, ok := domainStatus[domainErr.Kind]
+		if !ok {
+			return nil, false
+		}
+		return &AppError{
+			Status:  status,
+			Message: domainErr.Message,
+			Code:    domainErr.Code,
+			Details: domainErr.Details,
+			Err:     domainErr.Err,
+		}, true
+	}
+
+	// a schema constraint caught a write the service let through
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return constraintError(pgErr)
+	}
+	return nil, false
+}
+
 func Handle(w http.ResponseWriter, err error) {
 	if err == nil {
 		return
 	}
 
-	var appErr *AppError
-	if errors.As(err, &appErr) {
-		ErrorCode(w, appErr.Status, appErr.Message, appErr.Code)
+	if appErr, ok := asAppError(err); ok {
+		write(w, appErr.Status, errorEnvelope{Error: &ErrBlock{
+			Message: appErr.Message,
+			Code:    appErr.Code,
+			Details: appErr.Details,
+		}})
+		return
+	}
+
+	// the pool could not reach postgres at all, the health monitor will flip
+	// into degraded mode shortly; answer like it already did
+	var connErr *pgconn.ConnectError
+	if errors.As(err, &connErr) {
+		slog.Error("database unreachable", "error", err)
+		ErrorCode(w, http.StatusServiceUnavailable, "database is unavailable, please retry shortly", "database_unavailable")
+		return
+	}
+
+	// the client disconnected, pgx has already aborted the query and its row
+	// scan through the request context; there is nothing left to report
+	if errors.Is(err, context.Canceled) {
+		slog.Debug("request canceled by client", "error", err)
+		ErrorCode(w, StatusClientClosedRequest, "request canceled", "request_canceled")
+		return
+	}
+
+	// a statement outlived the per-query deadline set on the repositories
+	if errors.Is(err, context.DeadlineExceeded) {
+		slog.Warn("database query timed out", "error", err)
+		ErrorCode(w, http.StatusGatewayTimeout, "the request took too long, please retry", "query_timeout")
 		return
 	}
 
@@ -0,0 +1,52 @@
+package httpx_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/domain"
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+func TestHandle_ContextErrors(t *testing.T) {
+	cases := []struct {
+		name   string
+		err    error
+		status int
+		code   string
+	}{
+		{"canceled", context.Canceled, httpx.StatusClientClosedRequest, "request_canceled"},
+		{"canceled and wrapped", fmt.Errorf("list tickets: %w", context.Canceled), httpx.StatusClientClosedRequest, "request_canceled"},
+		{"canceled while scanning", errors.Join(errors.New("scan row"), context.Canceled), httpx.StatusClientClosedRequest, "request_canceled"},
+		{"deadline", fmt.Errorf("list tickets: %w", context.DeadlineExceeded), http.StatusGatewayTimeout, "query_timeout"},
+		{"domain error first", domain.NotFound("ticket not found").WithCode("ticket_not_found"), http.StatusNotFound, "ticket_not_found"},
+		{"anything else", errors.New("boom"), http.StatusInternalServerError, ""},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			httpx.Handle(w, c.err)
+
+			if w.Code != c.status {
+				t.Fatalf("status = %d, want %d", w.Code, c.status)
+			}
+			var body struct {
+				Error struct {
+					Code string `json:"code"`
+				} `json:"error"`
+			}
+			if err := json.NewDecoder(w.Body).Decode(&body); err != nil {
+				t.Fatal(err)
+			}
+			if body.Error.Code != c.code {
+				t.Fatalf("code = %q, want %q", body.Error.Code, c.code)
+			}
+		})
+	}
+}
@@ -2,7 +2,9 @@ package httpx
 
 import (
 	"net/http"
+	"regexp"
 	"strconv"
+	"strings"
 
 	"github.com/jackc/pgx/v5/pgtype"
 )
@@ -19,11 +21,36 @@ func PathString(r *http.Request, key string) string {
 func PathUUID(r *http.Request, key string) (pgtype.UUID, error) {
 	var id pgtype.UUID
 	if err := id.Scan(r.PathValue(key)); err != nil {
-		return pgtype.UUID{}, BadRequest("invalid " + key)
+		return pgtype.UUID{}, invalidPathParam(key)
 	}
 	return id, nil
 }
 
+var patternWildcard = regexp.MustCompile(`\{(\w+)\}`)
+
+// ValidatePathUUIDs rejects the request with 422 when any identifier wildcard
+// of the matched route pattern ("{id}" or "{somethingId}") is not a UUID,
+// so handlers and services never see malformed identifiers
+func ValidatePathUUIDs(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		for _, m := range patternWildcard.FindAllStringSubmatch(r.Pattern, -1) {
+			key := m[1]
+			if key != "id" && !strings.HasSuffix(key, "Id") {
+				continue
+			}
+			if _, err := PathUUID(r, key); err != nil {
+				Handle(w, err)
+				return
+			}
+		}
+		next(w, r)
+	}
+}
+
+func invalidPathParam(key string) *AppError {
+	return Unprocessable("invalid " + key + ": must be a valid UUID").WithCode("invalid_path_param")
+}
+
 // === QUERY PARAMETERS - SINGLE VALUES ===
 func QueryUUID(r *http.Request, key string) (pgtype.UUID, error) {
 	value := r.URL.Query().Get(key)
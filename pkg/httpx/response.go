@@ -3,13 +3,15 @@ package httpx
 import (
 	"encoding/json"
 	"net/http"
+
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
 )
 
 // Error responses use an envelope with ErrBlock
 // Success responses write data directly (no envelope)
 //
 // Success:  <payload> (written directly)
-// Error:    { "error": { "message": "...", "code": "..." } }
+// Error:    { "error": { "message": "...", "code": "...", "details": {...} } }
 
 type errorEnvelope struct {
 	Error *ErrBlock `json:"error"`
@@ -18,6 +20,7 @@ type errorEnvelope struct {
 type ErrBlock struct {
 	Message string `json:"message"`
 	Code    string `json:"code,omitempty"` // machine-readable e.g. "email_taken"
+	Details any    `json:"details,omitempty" swaggertype:"object"`
 }
 
 func OK(w http.ResponseWriter, data any) {
@@ -28,6 +31,19 @@ func Created(w http.ResponseWriter, data any) {
 	write(w, http.StatusCreated, data)
 }
 
+// OKPage writes a paged list and its Link header, so clients can follow
+// first/prev/next/last without rebuilding the URL themselves
+func OKPage(w http.ResponseWriter, r *http.Request, data any, pageNumber, pageSize, totalPages int) {
+	if links := pagination.Links(r.URL, pageNumber, pageSize, totalPages); links != "" {
+		w.Header().Set("Link", links)
+	}
+	write(w, http.StatusOK, data)
+}
+
+func JSON(w http.ResponseWriter, status int, data any) {
+	write(w, status, data)
+}
+
 func Error(w http.ResponseWriter, status int, message string) {
 	write(w, status, errorEnvelope{Error: &ErrBlock{Message: message}})
 }
@@ -0,0 +1,263 @@
+// Package ical reads and writes the small subset of iCalendar (RFC 5545)
+// needed to exchange tasks as VTODO components.
+//
+// Only the properties Fluxis maps onto a ticket are understood; everything
+// else in a parsed component, including nested VALARMs, is skipped.
+package ical
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Status values of a VTODO
+const (
+	StatusNeedsAction = "NEEDS-ACTION"
+	StatusInProcess   = "IN-PROCESS"
+	StatusCompleted   = "COMPLETED"
+	StatusCancelled   = "CANCELLED"
+)
+
+// ContentType is the media type of a calendar object resource
+const ContentType = "text/calendar; charset=utf-8"
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405Z"
+	maxLineOctets  = 75
+)
+
+var (
+	ErrNoTodo    = errors.New("ical: no VTODO component")
+	ErrMalformed = errors.New("ical: malformed calendar object")
+)
+
+// Todo is a single task. Due is a calendar date; its time of day and zone are
+// ignored when writing and dropped when reading.
+type Todo struct {
+	UID          string
+	Summary      string
+	Description  string
+	Due          time.Time
+	Status       string
+	Created      time.Time
+	LastModified time.Time
+}
+
+// Completed reports whether the task is marked done
+func (t Todo) Completed() bool {
+	return t.Status == StatusCompleted
+}
+
+// Marshal wraps todo in a VCALENDAR object
+func Marshal(prodID string, todo Todo) []byte {
+	var b bytes.Buffer
+	line(&b, "BEGIN", "VCALENDAR")
+	line(&b, "VERSION", "2.0")
+	line(&b, "PRODID", prodID)
+	line(&b, "BEGIN", "VTODO")
+	line(&b, "UID", escape(todo.UID))
+	if !todo.LastModified.IsZero() {
+		line(&b, "DTSTAMP", todo.LastModified.UTC().Format(dateTimeLayout))
+		line(&b, "LAST-MODIFIED", todo.LastModified.UTC().Format(dateTimeLayout))
+	}
+	if !todo.Created.IsZero() {
+		line(&b, "CREATED", todo.Created.UTC().Format(dateTimeLayout))
+	}
+	line(&b, "SUMMARY", escape(todo.Summary))
+	if todo.Description != "" {
+		line(&b, "DESCRIPTION", escape(todo.Description))
+	}
+	if !todo.Due.IsZero() {
+		line(&b, "DUE;VALUE=DATE", todo.Due.Format(dateLayout))
+	}
+	if todo.Status != "" {
+		line(&b, "STATUS", todo.Status)
+	}
+	if todo.Completed() && !todo.LastModified.IsZero() {
+		line(&b, "COMPLETED", todo.LastModified.UTC().Format(dateTimeLayout))
+		line(&b, "PERCENT-COMPLETE", "100")
+	}
+	line(&b, "END", "VTODO")
+	line(&b, "END", "VCALENDAR")
+	return b.Bytes()
+}
+
+// ParseTodo returns the first VTODO of a calendar object. A COMPLETED
+// timestamp or PERCENT-COMPLETE:100 without an explicit STATUS also marks the
+// task as completed, as some clients only set those.
+func ParseTodo(data []byte) (Todo, error) {
+	var (
+		todo   Todo
+		found  bool
+		inTodo bool
+		depth  int
+		done   bool
+	)
+	for _, l := range unfold(data) {
+		if l == "" {
+			continue
+		}
+		name, value, ok := split(l)
+		if !ok {
+			return Todo{}, ErrMalformed
+		}
+
+		switch name {
+		case "BEGIN":
+			if inTodo {
+				depth++
+			} else if strings.EqualFold(value, "VTODO") && !found {
+				inTodo, found = true, true
+			}
+			continue
+		case "END":
+			if inTodo {
+				if depth > 0 {
+					depth--
+				} else {
+					inTodo = false
+				}
+			}
+			continue
+		}
+		if !inTodo || depth > 0 {
+			continue
+		}
+
+		switch name {
+		case "UID":
+			todo.UID = unescape(value)
+		case "SUMMARY":
+			todo.Summary = unescape(value)
+		case "DESCRIPTION":
+			todo.Description = unescape(value)
+		case "STATUS":
+			todo.Status = strings.ToUpper(value)
+		case "COMPLETED":
+			done = true
+		case "PERCENT-COMPLETE":
+			done = done || value == "100"
+		case "DUE":
+			due, err := parseDate(value)
+			if err != nil {
+				return Todo{}, err
+			}
+			todo.Due = due
+		case "CREATED", "LAST-MODIFIED":
+			ts, err := parseDateTime(value)
+			if err != nil {
+				return Todo{}, err
+			}
+			if name == "CREATED" {
+				todo.Created = ts
+			} else {
+				todo.LastModified = ts
+			}
+		}
+	}
+	if !found {
+		return Todo{}, ErrNoTodo
+	}
+	if inTodo {
+		return Todo{}, ErrMalformed
+	}
+	if todo.Status == "" && done {
+		todo.Status = StatusCompleted
+	}
+	return todo, nil
+}
+
+// unfold joins continuation lines, which start with a space or tab, onto the
+// line before them
+func unfold(data []byte) []string {
+	raw := strings.Split(strings.ReplaceAll(string(data), "\r\n", "\n"), "\n")
+	lines := make([]string, 0, len(raw))
+	for _, l := range raw {
+		if (strings.HasPrefix(l, " ") || strings.HasPrefix(l, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += l[1:]
+			continue
+		}
+		lines = append(lines, l)
+	}
+	return lines
+}
+
+// split breaks a content line into its upper-cased name and value, dropping
+// the parameters. Parameter values may be quoted and contain colons.
+func split(l string) (name, value string, ok bool) {
+	quoted := false
+	for i, r := range l {
+		switch r {
+		case '"':
+			quoted = !quoted
+		case ':':
+			if quoted {
+				continue
+			}
+			name, _, _ = strings.Cut(l[:i], ";")
+			if name == "" {
+				return "", "", false
+			}
+			return strings.ToUpper(name), l[i+1:], true
+		}
+	}
+	return "", "", false
+}
+
+// parseDate keeps the calendar date of a DATE or DATE-TIME value
+func parseDate(v string) (time.Time, error) {
+	if len(v) < len(dateLayout) {
+		return time.Time{}, ErrMalformed
+	}
+	d, err := time.Parse(dateLayout, v[:len(dateLayout)])
+	if err != nil {
+		return time.Time{}, ErrMalformed
+	}
+	return d, nil
+}
+
+// parseDateTime reads a DATE-TIME; floating and TZID values are taken as UTC
+// since they only feed informational fields
+func parseDateTime(v string) (time.Time, error) {
+	ts, err := time.Parse("20060102T150405", strings.TrimSuffix(v, "Z"))
+	if err != nil {
+		return time.Time{}, ErrMalformed
+	}
+	return ts, nil
+}
+
+var escaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func escape(s string) string {
+	return escaper.Replace(s)
+}
+
+var unescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func unescape(s string) string {
+	return unescaper.Replace(s)
+}
+
+// line writes a content line folded at 75 octets without splitting a UTF-8
+// sequence
+func line(b *bytes.Buffer, name, value string) {
+	l := name + ":" + value
+	limit := maxLineOctets
+	for len(l) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(l[cut]) {
+			cut--
+		}
+		b.WriteString(l[:cut])
+		b.WriteString("\r\n ")
+		l = l[cut:]
+		// continuation lines lose one octet to the leading space
+		limit = maxLineOctets - 1
+	}
+	b.WriteString(l)
+	b.WriteString("\r\n")
+}
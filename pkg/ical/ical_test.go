@@ -0,0 +1,117 @@
+package ical_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/ical"
+)
+
+func TestMarshal_RoundTrip(t *testing.T) {
+	want := ical.Todo{
+		UID:          "6f1c0c0e-0000-4000-8000-000000000001",
+		Summary:      "Ship release; notes, too",
+		Description:  "line one\nline two with a backslash \\",
+		Due:          time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC),
+		Status:       ical.StatusInProcess,
+		Created:      time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC),
+		LastModified: time.Date(2024, 1, 2, 10, 30, 0, 0, time.UTC),
+	}
+
+	got, err := ical.ParseTodo(ical.Marshal("-//Fluxis//EN", want))
+	if err != nil {
+		t.Fatalf("ParseTodo = %v", err)
+	}
+	if got != want {
+		t.Fatalf("round trip = %+v, want %+v", got, want)
+	}
+}
+
+func TestMarshal_FoldsLongLines(t *testing.T) {
+	todo := ical.Todo{UID: "x", Summary: strings.Repeat("é", 100)}
+	data := ical.Marshal("-//Fluxis//EN", todo)
+
+	for _, l := range strings.Split(string(data), "\r\n") {
+		if len(l) > 75 {
+			t.Fatalf("line of %d octets: %q", len(l), l)
+		}
+	}
+	got, err := ical.ParseTodo(data)
+	if err != nil {
+		t.Fatalf("ParseTodo = %v", err)
+	}
+	if got.Summary != todo.Summary {
+		t.Fatalf("Summary = %q, want %q", got.Summary, todo.Summary)
+	}
+}
+
+func TestParseTodo(t *testing.T) {
+	tests := []struct {
+		name       string
+		data       string
+		wantDue    string
+		wantStatus string
+	}{
+		{
+			name:    "date due",
+			data:    "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:a\nDUE;VALUE=DATE:20240131\nEND:VTODO\nEND:VCALENDAR\n",
+			wantDue: "2024-01-31",
+		},
+		{
+			name:    "zoned due keeps its date",
+			data:    "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:a\nDUE;TZID=\"Europe/Berlin\":20240131T230000\nEND:VTODO\nEND:VCALENDAR\n",
+			wantDue: "2024-01-31",
+		},
+		{
+			name:       "completed timestamp without status",
+			data:       "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:a\nCOMPLETED:20240131T120000Z\nEND:VTODO\nEND:VCALENDAR\n",
+			wantStatus: ical.StatusCompleted,
+		},
+		{
+			name:       "explicit status wins",
+			data:       "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:a\nSTATUS:needs-action\nPERCENT-COMPLETE:100\nEND:VTODO\nEND:VCALENDAR\n",
+			wantStatus: ical.StatusNeedsAction,
+		},
+		{
+			name:       "alarm properties are skipped",
+			data:       "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:a\nBEGIN:VALARM\nSTATUS:COMPLETED\nEND:VALARM\nEND:VTODO\nEND:VCALENDAR\n",
+			wantStatus: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ical.ParseTodo([]byte(tt.data))
+			if err != nil {
+				t.Fatalf("ParseTodo = %v", err)
+			}
+			if tt.wantDue != "" && got.Due.Format(time.DateOnly) != tt.wantDue {
+				t.Errorf("Due = %v, want %s", got.Due, tt.wantDue)
+			}
+			if got.Status != tt.wantStatus {
+				t.Errorf("Status = %q, want %q", got.Status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestParseTodo_Rejects(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want error
+	}{
+		{"event only", "BEGIN:VCALENDAR\nBEGIN:VEVENT\nUID:a\nEND:VEVENT\nEND:VCALENDAR\n", ical.ErrNoTodo},
+		{"unterminated", "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID:a\n", ical.ErrMalformed},
+		{"no colon", "BEGIN:VCALENDAR\nBEGIN:VTODO\nUID\nEND:VTODO\n", ical.ErrMalformed},
+		{"bad due", "BEGIN:VCALENDAR\nBEGIN:VTODO\nDUE:soon\nEND:VTODO\n", ical.ErrMalformed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ical.ParseTodo([]byte(tt.data)); !errors.Is(err, tt.want) {
+				t.Fatalf("ParseTodo = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
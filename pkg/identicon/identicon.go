@@ -0,0 +1,115 @@
+// Package identicon draws a deterministic placeholder avatar from a seed such
+// as a user ID. The same seed always yields the same image, so clients can
+// cache it like any uploaded picture.
+//
+// The image is a 5x5 grid mirrored around its middle column on a light
+// background. Cell colour and pattern both come from a SHA-256 of the seed.
+package identicon
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+)
+
+const grid = 5
+
+// DefaultSize is the edge length in pixels used when the caller has no
+// preference
+const DefaultSize = 128
+
+var background = color.NRGBA{R: 0xf0, G: 0xf0, B: 0xf0, A: 0xff}
+
+// Generate returns a size x size identicon for seed. Sizes below the grid
+// width are raised to it.
+func Generate(seed []byte, size int) *image.NRGBA {
+	if size < grid {
+		size = grid
+	}
+	sum := sha256.Sum256(seed)
+	fg := foreground(sum)
+
+	img := image.NewNRGBA(image.Rect(0, 0, size, size))
+	draw.Draw(img, img.Bounds(), &image.Uniform{background}, image.Point{}, draw.Src)
+
+	// leave a margin of half a cell around the pattern
+	cell := size / (grid + 1)
+	offset := (size - cell*grid) / 2
+	half := (grid + 1) / 2
+	for row := 0; row < grid; row++ {
+		for col := 0; col < half; col++ {
+			// one bit per cell of the left half, mirrored onto the right
+			bit := row*half + col
+			if sum[bit/8]>>(bit%8)&1 == 0 {
+				continue
+			}
+			fill(img, fg, offset+col*cell, offset+row*cell, cell)
+			fill(img, fg, offset+(grid-1-col)*cell, offset+row*cell, cell)
+		}
+	}
+	return img
+}
+
+// PNG encodes the identicon for seed as a PNG.
+func PNG(seed []byte, size int) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, Generate(seed, size)); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// foreground picks a saturated colour from the tail of the hash so it does not
+// correlate with the pattern bits taken from the head
+func foreground(sum [sha256.Size]byte) color.NRGBA {
+	hue := float64(uint16(sum[28])<<8|uint16(sum[29])) / 65536 * 360
+	return hsl(hue, 0.55+float64(sum[30])/255*0.2, 0.45+float64(sum[31])/255*0.1)
+}
+
+func fill(img *image.NRGBA, c color.NRGBA, x, y, cell int) {
+	draw.Draw(img, image.Rect(x, y, x+cell, y+cell), &image.Uniform{c}, image.Point{}, draw.Src)
+}
+
+func hsl(h, s, l float64) color.NRGBA {
+	c := (1 - abs(2*l-1)) * s
+	hp := h / 60
+	x := c * (1 - abs(mod2(hp)-1))
+	var r, g, b float64
+	switch {
+	case hp < 1:
+		r, g = c, x
+	case hp < 2:
+		r, g = x, c
+	case hp < 3:
+		g, b = c, x
+	case hp < 4:
+		g, b = x, c
+	case hp < 5:
+		r, b = x, c
+	default:
+		r, b = c, x
+	}
+	m := l - c/2
+	return color.NRGBA{R: channel(r + m), G: channel(g + m), B: channel(b + m), A: 0xff}
+}
+
+func channel(v float64) uint8 {
+	return uint8(v*255 + 0.5)
+}
+
+func abs(v float64) float64 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+func mod2(v float64) float64 {
+	for v >= 2 {
+		v -= 2
+	}
+	return v
+}
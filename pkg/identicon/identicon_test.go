@@ -0,0 +1,56 @@
+package identicon_test
+
+import (
+	"bytes"
+	"image/png"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/identicon"
+)
+
+func TestGenerate_IsDeterministic(t *testing.T) {
+	a := identicon.Generate([]byte("user-1"), 64)
+	b := identicon.Generate([]byte("user-1"), 64)
+	if !bytes.Equal(a.Pix, b.Pix) {
+		t.Fatal("same seed produced different images")
+	}
+
+	c := identicon.Generate([]byte("user-2"), 64)
+	if bytes.Equal(a.Pix, c.Pix) {
+		t.Fatal("different seeds produced the same image")
+	}
+}
+
+func TestGenerate_IsMirrored(t *testing.T) {
+	img := identicon.Generate([]byte("mirror"), 60)
+	size := img.Bounds().Dx()
+	for y := 0; y < size; y++ {
+		for x := 0; x < size/2; x++ {
+			if img.NRGBAAt(x, y) != img.NRGBAAt(size-1-x, y) {
+				t.Fatalf("pixel (%d,%d) does not mirror (%d,%d)", x, y, size-1-x, y)
+			}
+		}
+	}
+}
+
+func TestGenerate_ClampsSize(t *testing.T) {
+	img := identicon.Generate([]byte("tiny"), 1)
+	if got := img.Bounds().Dx(); got != 5 {
+		t.Fatalf("size = %d, want 5", got)
+	}
+}
+
+func TestPNG_Decodes(t *testing.T) {
+	data, err := identicon.PNG([]byte("user-1"), identicon.DefaultSize)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	if got := img.Bounds().Dx(); got != identicon.DefaultSize {
+		t.Fatalf("width = %d, want %d", got, identicon.DefaultSize)
+	}
+}
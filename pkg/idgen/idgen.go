@@ -0,0 +1,41 @@
+// Package idgen hands out the primary keys of new rows. Keys are UUIDv7 by
+// default: the leading 48 bits are a millisecond timestamp, so rows created
+// together sit together in the primary key index and ordering by id follows
+// creation order, which keyset pagination can lean on.
+package idgen
+
+import (
+	"sync/atomic"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Provider makes one new key per call
+type Provider interface {
+	New() uuid.UUID
+}
+
+// V7 is the default provider. Keys made in the same millisecond by one
+// process still increase, the uuid package keeps a sequence for that.
+type V7 struct{}
+
+func (V7) New() uuid.UUID {
+	return uuid.Must(uuid.NewV7())
+}
+
+var provider atomic.Pointer[Provider]
+
+func init() {
+	SetProvider(V7{})
+}
+
+// SetProvider replaces the provider for every key made afterwards
+func SetProvider(p Provider) {
+	provider.Store(&p)
+}
+
+// New returns a fresh key ready to be bound as a query parameter
+func New() pgtype.UUID {
+	return pgtype.UUID{Bytes: (*provider.Load()).New(), Valid: true}
+}
@@ -0,0 +1,40 @@
+package idgen_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/idgen"
+	"github.com/google/uuid"
+)
+
+func TestNew_TimeOrdered(t *testing.T) {
+	prev := idgen.New()
+	for range 1000 {
+		next := idgen.New()
+		if !next.Valid {
+			t.Fatal("New returned an invalid UUID")
+		}
+		if v := uuid.UUID(next.Bytes).Version(); v != 7 {
+			t.Fatalf("version = %d, want 7", v)
+		}
+		if bytes.Compare(prev.Bytes[:], next.Bytes[:]) >= 0 {
+			t.Fatalf("%x is not after %x", next.Bytes, prev.Bytes)
+		}
+		prev = next
+	}
+}
+
+type fixed uuid.UUID
+
+func (f fixed) New() uuid.UUID { return uuid.UUID(f) }
+
+func TestSetProvider(t *testing.T) {
+	want := uuid.MustParse("0190a6a1-0000-7000-8000-000000000001")
+	idgen.SetProvider(fixed(want))
+	defer idgen.SetProvider(idgen.V7{})
+
+	if got := idgen.New(); uuid.UUID(got.Bytes) != want {
+		t.Fatalf("New = %s, want %s", uuid.UUID(got.Bytes), want)
+	}
+}
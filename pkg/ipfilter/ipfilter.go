@@ -0,0 +1,210 @@
+// Package ipfilter restricts which client addresses may reach the API. It runs
+// in front of authentication so a blocked address never gets to try a token.
+//
+// Deny entries always win. When the allow list is empty every address that is
+// not denied passes, otherwise the address must also match an allow entry.
+// Entries are CIDR blocks or bare addresses.
+package ipfilter
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strings"
+	"sync/atomic"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Config struct {
+	Allow []string
+	Deny  []string
+	// Paths limits the filter to these route prefixes, e.g. "/admin/". Empty
+	// filters the whole API.
+	Paths []string
+	// ExemptPaths always pass, e.g. health probes from the load balancer
+	ExemptPaths []string
+	// TrustProxy reads the client address from ProxyHeader. Only enable it
+	// behind a proxy that sets that header, otherwise any client can claim an
+	// allowed address.
+	TrustProxy bool
+	// ProxyHeader is the header the proxy sets, X-Forwarded-For when empty.
+	// No other header is consulted, a client could set one the proxy leaves
+	// alone.
+	ProxyHeader string
+}
+
+// Rules is one allow/deny list pair as the operator wrote it
+type Rules struct {
+	Allow []string
+	Deny  []string
+}
+
+// RuleSet is a parsed Rules, ready to match addresses
+type RuleSet struct {
+	rules Rules
+	allow []netip.Prefix
+	deny  []netip.Prefix
+}
+
+// Filter is the middleware. Its rules can be replaced at runtime without a
+// restart, in flight requests keep the set they started with.
+type Filter struct {
+	cfg   Config
+	rules atomic.Pointer[RuleSet]
+}
+
+func New(cfg Config) (*Filter, error) {
+	rs, err := Parse(Rules{Allow: cfg.Allow, Deny: cfg.Deny})
+	if err != nil {
+		return nil, err
+	}
+
+	f := &Filter{cfg: cfg}
+	f.rules.Store(rs)
+	return f, nil
+}
+
+// Parse validates every entry of r and returns the matching RuleSet
+func Parse(r Rules) (*RuleSet, error) {
+	allow, err := parsePrefixes(r.Allow)
+	if err != nil {
+		return nil, fmt.Errorf("allow list: %w", err)
+	}
+	deny, err := parsePrefixes(r.Deny)
+	if err != nil {
+		return nil, fmt.Errorf("deny list: %w", err)
+	}
+
+	rs := &RuleSet{allow: allow, deny: deny}
+	for _, p := range allow {
+		rs.rules.Allow = append(rs.rules.Allow, p.String())
+	}
+	for _, p := range deny {
+		rs.rules.Deny = append(rs.rules.Deny, p.String())
+	}
+	return rs, nil
+}
+
+// Rules returns the normalised entries, bare addresses come back as /32 or
+// /128 blocks
+func (rs *RuleSet) Rules() Rules {
+	return rs.rules
+}
+
+// Permits reports whether addr may reach a filtered route
+func (rs *RuleSet) Permits(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	for _, p := range rs.deny {
+		if p.Contains(addr) {
+			return false
+		}
+	}
+	if len(rs.allow) == 0 {
+		return true
+	}
+	for _, p := range rs.allow {
+		if p.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rules returns the set currently enforced
+func (f *Filter) Rules() *RuleSet {
+	return f.rules.Load()
+}
+
+// SetRules replaces the enforced set for every request that starts afterwards
+func (f *Filter) SetRules(rs *RuleSet) {
+	f.rules.Store(rs)
+}
+
+// Applies reports whether path is subject to the filter
+func (f *Filter) Applies(path string) bool {
+	if hasPrefix(path, f.cfg.ExemptPaths) {
+		return false
+	}
+	return len(f.cfg.Paths) == 0 || hasPrefix(path, f.cfg.Paths)
+}
+
+// ClientIP resolves the address the filter matches r against
+func (f *Filter) ClientIP(r *http.Request) (netip.Addr, bool) {
+	if f.cfg.TrustProxy {
+		header := f.cfg.ProxyHeader
+		if header == "" {
+			header = "X-Forwarded-For"
+		}
+		// the proxy appends the peer it saw, earlier hops are client supplied
+		if values := r.Header.Values(header); len(values) > 0 {
+			hops := strings.Split(strings.Join(values, ","), ",")
+			if ip, err := netip.ParseAddr(strings.TrimSpace(hops[len(hops)-1])); err == nil {
+				return ip.Unmap(), true
+			}
+		}
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip, err := netip.ParseAddr(host)
+	if err != nil {
+		return netip.Addr{}, false
+	}
+	return ip.Unmap(), true
+}
+
+// Wrap rejects requests to filtered routes from addresses the current rules
+// do not permit with 403. An unparsable client address is rejected as well.
+func (f *Filter) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !f.Applies(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip, ok := f.ClientIP(r)
+		if !ok || !f.Rules().Permits(ip) {
+			httpx.Handle(w, httpx.Forbidden("access from this address is not allowed").WithCode("ip_blocked"))
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func parsePrefixes(entries []string) ([]netip.Prefix, error) {
+	out := make([]netip.Prefix, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if strings.Contains(e, "/") {
+			p, err := netip.ParsePrefix(e)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q", e)
+			}
+			out = append(out, p.Masked())
+			continue
+		}
+		ip, err := netip.ParseAddr(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q", e)
+		}
+		ip = ip.Unmap()
+		out = append(out, netip.PrefixFrom(ip, ip.BitLen()))
+	}
+	return out, nil
+}
+
+func hasPrefix(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
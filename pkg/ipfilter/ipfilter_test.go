@@ -0,0 +1,151 @@
+package ipfilter_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/ipfilter"
+)
+
+func TestRuleSet_Permits(t *testing.T) {
+	tests := []struct {
+		name  string
+		rules ipfilter.Rules
+		addr  string
+		want  bool
+	}{
+		{"empty lists allow everyone", ipfilter.Rules{}, "203.0.113.7", true},
+		{"allow list match", ipfilter.Rules{Allow: []string{"10.0.0.0/8"}}, "10.1.2.3", true},
+		{"allow list miss", ipfilter.Rules{Allow: []string{"10.0.0.0/8"}}, "192.168.1.1", false},
+		{"deny list match", ipfilter.Rules{Deny: []string{"192.168.0.0/16"}}, "192.168.1.1", false},
+		{"deny beats allow", ipfilter.Rules{Allow: []string{"10.0.0.0/8"}, Deny: []string{"10.0.0.5"}}, "10.0.0.5", false},
+		{"bare address", ipfilter.Rules{Allow: []string{"203.0.113.7"}}, "203.0.113.7", true},
+		{"ipv4 mapped ipv6", ipfilter.Rules{Allow: []string{"10.0.0.0/8"}}, "::ffff:10.0.0.1", true},
+		{"ipv6 block", ipfilter.Rules{Allow: []string{"2001:db8::/32"}}, "2001:db8::1", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs, err := ipfilter.Parse(tt.rules)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := rs.Permits(netip.MustParseAddr(tt.addr)); got != tt.want {
+				t.Fatalf("Permits(%s) = %v, want %v", tt.addr, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse_RejectsInvalidEntries(t *testing.T) {
+	for _, entry := range []string{"10.0.0.0/33", "not-an-ip", "10.0.0"} {
+		if _, err := ipfilter.Parse(ipfilter.Rules{Deny: []string{entry}}); err == nil {
+			t.Fatalf("expected %q to be rejected", entry)
+		}
+	}
+}
+
+func TestParse_NormalisesEntries(t *testing.T) {
+	rs, err := ipfilter.Parse(ipfilter.Rules{Allow: []string{" 10.1.2.3/8 ", "192.0.2.1", ""}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := rs.Rules().Allow
+	want := []string{"10.0.0.0/8", "192.0.2.1/32"}
+	if len(got) != len(want) || got[0] != want[0] || got[1] != want[1] {
+		t.Fatalf("Allow = %v, want %v", got, want)
+	}
+}
+
+func serve(f *ipfilter.Filter, path, remote string, header http.Header) int {
+	h := f.Wrap(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	req := httptest.NewRequest("GET", path, nil)
+	req.RemoteAddr = remote
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func TestFilter_Paths(t *testing.T) {
+	f, err := ipfilter.New(ipfilter.Config{
+		Allow:       []string{"10.0.0.0/8"},
+		Paths:       []string{"/admin/"},
+		ExemptPaths: []string{"/admin/health"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if code := serve(f, "/admin/ip-rules", "192.0.2.1:4000", nil); code != http.StatusForbidden {
+		t.Fatalf("filtered path: got %d, want 403", code)
+	}
+	if code := serve(f, "/admin/ip-rules", "10.0.0.1:4000", nil); code != http.StatusOK {
+		t.Fatalf("allowed address: got %d, want 200", code)
+	}
+	if code := serve(f, "/projects", "192.0.2.1:4000", nil); code != http.StatusOK {
+		t.Fatalf("unfiltered path: got %d, want 200", code)
+	}
+	if code := serve(f, "/admin/health", "192.0.2.1:4000", nil); code != http.StatusOK {
+		t.Fatalf("exempt path: got %d, want 200", code)
+	}
+}
+
+func TestFilter_ProxyHeaders(t *testing.T) {
+	header := http.Header{"X-Forwarded-For": {"10.0.0.1, 192.0.2.9"}}
+
+	untrusted, _ := ipfilter.New(ipfilter.Config{Allow: []string{"10.0.0.0/8"}})
+	if code := serve(untrusted, "/", "192.0.2.1:4000", header); code != http.StatusForbidden {
+		t.Fatalf("untrusted proxy header: got %d, want 403", code)
+	}
+
+	// only the hop appended by the proxy counts, the first entry is spoofable
+	trusted, _ := ipfilter.New(ipfilter.Config{Allow: []string{"10.0.0.0/8"}, TrustProxy: true})
+	if code := serve(trusted, "/", "127.0.0.1:4000", header); code != http.StatusForbidden {
+		t.Fatalf("spoofed first hop: got %d, want 403", code)
+	}
+
+	header = http.Header{"X-Forwarded-For": {"192.0.2.9, 10.0.0.1"}}
+	if code := serve(trusted, "/", "127.0.0.1:4000", header); code != http.StatusOK {
+		t.Fatalf("proxy appended hop: got %d, want 200", code)
+	}
+
+	// a proxy that only appends X-Forwarded-For passes X-Real-IP through
+	header = http.Header{"X-Real-Ip": {"10.0.0.1"}, "X-Forwarded-For": {"192.0.2.9"}}
+	if code := serve(trusted, "/", "127.0.0.1:4000", header); code != http.StatusForbidden {
+		t.Fatalf("client set X-Real-IP: got %d, want 403", code)
+	}
+
+	realIP, _ := ipfilter.New(ipfilter.Config{Allow: []string{"10.0.0.0/8"}, TrustProxy: true, ProxyHeader: "X-Real-IP"})
+	if code := serve(realIP, "/", "127.0.0.1:4000", header); code != http.StatusOK {
+		t.Fatalf("configured header: got %d, want 200", code)
+	}
+	header = http.Header{"X-Real-Ip": {"192.0.2.9"}, "X-Forwarded-For": {"10.0.0.1"}}
+	if code := serve(realIP, "/", "127.0.0.1:4000", header); code != http.StatusForbidden {
+		t.Fatalf("client set X-Forwarded-For: got %d, want 403", code)
+	}
+}
+
+func TestFilter_SetRules(t *testing.T) {
+	f, _ := ipfilter.New(ipfilter.Config{})
+	if code := serve(f, "/", "192.0.2.1:4000", nil); code != http.StatusOK {
+		t.Fatalf("before update: got %d, want 200", code)
+	}
+
+	rs, err := ipfilter.Parse(ipfilter.Rules{Deny: []string{"192.0.2.0/24"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.SetRules(rs)
+
+	if code := serve(f, "/", "192.0.2.1:4000", nil); code != http.StatusForbidden {
+		t.Fatalf("after update: got %d, want 403", code)
+	}
+}
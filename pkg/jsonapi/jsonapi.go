@@ -0,0 +1,245 @@
+// Package jsonapi renders the API's plain JSON responses as JSON:API
+// documents (https://jsonapi.org/format/1.1/) for clients that ask for them
+// with "Accept: application/vnd.api+json". Handlers keep writing the usual
+// models; Wrap rewrites the body on the way out, so both formats always carry
+// the same data.
+//
+// Only responses are translated. Request bodies stay plain JSON.
+package jsonapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+const MediaType = "application/vnd.api+json"
+
+// Resource describes how one model maps onto a JSON:API resource object
+type Resource struct {
+	Type string
+	// IDField is the JSON field holding the identifier, "id" when empty
+	IDField string
+	// Relationships maps a JSON field holding another resource's id to that
+	// resource's type, e.g. "projectId": "projects". The relationship is named
+	// after the field without its Id suffix.
+	Relationships map[string]string
+	// SelfPrefix builds the self link of each resource as SelfPrefix + id
+	SelfPrefix string
+}
+
+// Wrap serves next as is unless the client negotiated JSON:API, in which case
+// the JSON response is rewritten into a document of r's resources
+func Wrap(res Resource, next http.HandlerFunc) http.HandlerFunc {
+	if res.IDField == "" {
+		res.IDField = "id"
+	}
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept")
+
+		ok, acceptable := negotiate(r.Header.Values("Accept"))
+		if !acceptable {
+			httpx.ErrorCode(w, http.StatusNotAcceptable, "JSON:API media type parameters are not supported", "not_acceptable")
+			return
+		}
+		if !ok {
+			next(w, r)
+			return
+		}
+
+		buf := &bufferWriter{header: w.Header(), status: http.StatusOK}
+		next(buf, r)
+		buf.flushTo(w, r, res)
+	}
+}
+
+// negotiate reports whether the client asked for JSON:API. A client that
+// only lists the media type with parameters can not be served, the spec
+// requires 406 in that case.
+func negotiate(accept []string) (wanted, acceptable bool) {
+	withParams := false
+	for _, value := range accept {
+		for _, part := range strings.Split(value, ",") {
+			mt, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err != nil || mt != MediaType {
+				continue
+			}
+			if len(params) == 0 {
+				return true, true
+			}
+			withParams = true
+		}
+	}
+	return false, !withParams || acceptsOther(accept)
+}
+
+func acceptsOther(accept []string) bool {
+	for _, value := range accept {
+		for _, part := range strings.Split(value, ",") {
+			mt, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+			if err == nil && mt != MediaType {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+type bufferWriter struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func (b *bufferWriter) Header() http.Header         { return b.header }
+func (b *bufferWriter) WriteHeader(status int)      { b.status = status }
+func (b *bufferWriter) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+func (b *bufferWriter) flushTo(w http.ResponseWriter, r *http.Request, res Resource) {
+	mt, _, _ := mime.ParseMediaType(b.header.Get("Content-Type"))
+	if mt != "application/json" || b.body.Len() == 0 {
+		w.WriteHeader(b.status)
+		_, _ = w.Write(b.body.Bytes())
+		return
+	}
+
+	dec := json.NewDecoder(&b.body)
+	dec.UseNumber()
+	var body any
+	if err := dec.Decode(&body); err != nil {
+		httpx.InternalError(w, err)
+		return
+	}
+
+	var doc map[string]any
+	if b.status >= 400 {
+		doc = errorDocument(body, b.status)
+	} else {
+		doc = dataDocument(body, res, r, b.header.Get("Link"))
+	}
+
+	w.Header().Set("Content-Type", MediaType)
+	w.Header().Del("Content-Length")
+	w.WriteHeader(b.status)
+	_ = json.NewEncoder(w).Encode(doc)
+}
+
+func dataDocument(body any, res Resource, r *http.Request, link string) map[string]any {
+	doc := map[string]any{
+		"jsonapi": map[string]any{"version": "1.1"},
+		"links":   map[string]any{"self": r.URL.RequestURI()},
+	}
+
+	switch v := body.(type) {
+	case []any:
+		doc["data"] = resources(v, res)
+	case map[string]any:
+		// paged lists carry their rows under items and the page math beside it
+		if items, ok := v["items"].([]any); ok {
+			doc["data"] = resources(items, res)
+			delete(v, "items")
+			doc["meta"] = v
+			for rel, target := range parseLinks(link) {
+				doc["links"].(map[string]any)[rel] = target
+			}
+			break
+		}
+		doc["data"] = resource(v, res)
+	default:
+		doc["meta"] = map[string]any{"value": v}
+	}
+	return doc
+}
+
+func resources(items []any, res Resource) []any {
+	out := make([]any, 0, len(items))
+	for _, item := range items {
+		if obj, ok := item.(map[string]any); ok {
+			out = append(out, resource(obj, res))
+		}
+	}
+	return out
+}
+
+func resource(obj map[string]any, res Resource) map[string]any {
+	id, _ := obj[res.IDField].(string)
+	delete(obj, res.IDField)
+
+	out := map[string]any{"type": res.Type, "id": id}
+
+	rels := map[string]any{}
+	for field, typ := range res.Relationships {
+		v, present := obj[field]
+		if !present {
+			continue
+		}
+		delete(obj, field)
+		name := strings.TrimSuffix(field, "Id")
+		if target, ok := v.(string); ok && target != "" {
+			rels[name] = map[string]any{"data": map[string]any{"type": typ, "id": target}}
+		} else {
+			rels[name] = map[string]any{"data": nil}
+		}
+	}
+	if len(rels) > 0 {
+		out["relationships"] = rels
+	}
+	// links the model carries itself belong to the resource, not its attributes
+	links, _ := obj["links"].(map[string]any)
+	delete(obj, "links")
+	if links == nil {
+		links = map[string]any{}
+	}
+	if res.SelfPrefix != "" {
+		links["self"] = res.SelfPrefix + id
+	}
+	out["attributes"] = obj
+	if len(links) > 0 {
+		out["links"] = links
+	}
+	return out
+}
+
+func errorDocument(body any, status int) map[string]any {
+	e := map[string]any{"status": strconv.Itoa(status), "title": http.StatusText(status)}
+	if env, ok := body.(map[string]any); ok {
+		if block, ok := env["error"].(map[string]any); ok {
+			if msg, ok := block["message"].(string); ok {
+				e["detail"] = msg
+			}
+			if code, ok := block["code"].(string); ok && code != "" {
+				e["code"] = code
+			}
+			if details, ok := block["details"]; ok {
+				e["meta"] = details
+			}
+		}
+	}
+	return map[string]any{
+		"jsonapi": map[string]any{"version": "1.1"},
+		"errors":  []any{e},
+	}
+}
+
+// parseLinks reads an RFC 8288 header as written by pagination.Links
+func parseLinks(header string) map[string]string {
+	out := map[string]string{}
+	for _, part := range strings.Split(header, ",") {
+		target, params, ok := strings.Cut(strings.TrimSpace(part), ";")
+		if !ok || !strings.HasPrefix(target, "<") || !strings.HasSuffix(target, ">") {
+			continue
+		}
+		for _, p := range strings.Split(params, ";") {
+			k, v, _ := strings.Cut(strings.TrimSpace(p), "=")
+			if k == "rel" {
+				out[strings.Trim(v, `"`)] = strings.Trim(target, "<>")
+			}
+		}
+	}
+	return out
+}
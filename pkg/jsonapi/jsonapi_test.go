@@ -0,0 +1,136 @@
+package jsonapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+	"github.com/dimasbaguspm/fluxis/pkg/jsonapi"
+)
+
+var tickets = jsonapi.Resource{
+	Type:          "tickets",
+	Relationships: map[string]string{"projectId": "projects", "sprintId": "sprints"},
+	SelfPrefix:    "/tickets/",
+}
+
+func serve(t *testing.T, h http.HandlerFunc, accept string) (*httptest.ResponseRecorder, map[string]any) {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/tickets?pageSize=1", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	jsonapi.Wrap(tickets, h)(rec, req)
+
+	var doc map[string]any
+	_ = json.Unmarshal(rec.Body.Bytes(), &doc)
+	return rec, doc
+}
+
+func one(w http.ResponseWriter, r *http.Request) {
+	httpx.OK(w, map[string]any{"id": "t1", "title": "Fix", "projectId": "p1", "sprintId": nil})
+}
+
+func TestWrap_PlainJSONByDefault(t *testing.T) {
+	rec, doc := serve(t, one, "application/json")
+	if rec.Header().Get("Content-Type") != "application/json" || doc["title"] != "Fix" {
+		t.Fatalf("expected the handler's own response, got %s", rec.Body)
+	}
+	if rec.Header().Get("Vary") != "Accept" {
+		t.Fatalf("expected Vary: Accept, got %q", rec.Header().Get("Vary"))
+	}
+}
+
+func TestWrap_Resource(t *testing.T) {
+	rec, doc := serve(t, one, jsonapi.MediaType)
+	if rec.Header().Get("Content-Type") != jsonapi.MediaType {
+		t.Fatalf("unexpected content type %q", rec.Header().Get("Content-Type"))
+	}
+
+	data := doc["data"].(map[string]any)
+	if data["type"] != "tickets" || data["id"] != "t1" {
+		t.Fatalf("unexpected identity %v", data)
+	}
+	attrs := data["attributes"].(map[string]any)
+	if attrs["title"] != "Fix" || attrs["id"] != nil || attrs["projectId"] != nil {
+		t.Fatalf("unexpected attributes %v", attrs)
+	}
+	rels := data["relationships"].(map[string]any)
+	project := rels["project"].(map[string]any)["data"].(map[string]any)
+	if project["type"] != "projects" || project["id"] != "p1" {
+		t.Fatalf("unexpected project relationship %v", project)
+	}
+	if rels["sprint"].(map[string]any)["data"] != nil {
+		t.Fatalf("an unset relationship should have null data, got %v", rels["sprint"])
+	}
+	if data["links"].(map[string]any)["self"] != "/tickets/t1" {
+		t.Fatalf("unexpected self link %v", data["links"])
+	}
+}
+
+func TestWrap_ModelLinks(t *testing.T) {
+	linked := func(w http.ResponseWriter, r *http.Request) {
+		httpx.OK(w, map[string]any{"id": "t1", "links": map[string]any{"self": "/other", "project": "/projects/p1"}})
+	}
+	_, doc := serve(t, linked, jsonapi.MediaType)
+
+	data := doc["data"].(map[string]any)
+	if _, ok := data["attributes"].(map[string]any)["links"]; ok {
+		t.Fatalf("links should not stay an attribute, got %v", data["attributes"])
+	}
+	links := data["links"].(map[string]any)
+	if links["project"] != "/projects/p1" || links["self"] != "/tickets/t1" {
+		t.Fatalf("unexpected resource links %v", links)
+	}
+}
+
+func TestWrap_PagedCollection(t *testing.T) {
+	paged := func(w http.ResponseWriter, r *http.Request) {
+		httpx.OKPage(w, r, map[string]any{
+			"items":      []any{map[string]any{"id": "t1"}},
+			"totalCount": 2, "totalPages": 2, "pageNumber": 1, "pageSize": 1, "hasMore": true,
+		}, 1, 1, 2)
+	}
+
+	_, doc := serve(t, paged, "application/json, "+jsonapi.MediaType)
+	if data := doc["data"].([]any); len(data) != 1 {
+		t.Fatalf("expected one resource, got %v", data)
+	}
+	if meta := doc["meta"].(map[string]any); meta["totalCount"] != float64(2) || meta["hasMore"] != true {
+		t.Fatalf("unexpected meta %v", meta)
+	}
+	links := doc["links"].(map[string]any)
+	if links["next"] != "/tickets?pageNumber=2&pageSize=1" || links["self"] != "/tickets?pageSize=1" {
+		t.Fatalf("unexpected links %v", links)
+	}
+}
+
+func TestWrap_Errors(t *testing.T) {
+	failing := func(w http.ResponseWriter, r *http.Request) {
+		httpx.ErrorCode(w, http.StatusNotFound, "ticket not found", "ticket_not_found")
+	}
+
+	rec, doc := serve(t, failing, jsonapi.MediaType)
+	if rec.Code != http.StatusNotFound {
+		t.Fatalf("status = %d", rec.Code)
+	}
+	e := doc["errors"].([]any)[0].(map[string]any)
+	if e["status"] != "404" || e["code"] != "ticket_not_found" || e["detail"] != "ticket not found" {
+		t.Fatalf("unexpected error object %v", e)
+	}
+}
+
+func TestWrap_MediaTypeParameters(t *testing.T) {
+	rec, _ := serve(t, one, jsonapi.MediaType+`; ext="https://example.com/ext"`)
+	if rec.Code != http.StatusNotAcceptable {
+		t.Fatalf("status = %d, want 406", rec.Code)
+	}
+
+	rec, doc := serve(t, one, jsonapi.MediaType+`; ext="https://example.com/ext", application/json`)
+	if rec.Code != http.StatusOK || doc["title"] != "Fix" {
+		t.Fatalf("expected plain JSON when another type is acceptable, got %d %s", rec.Code, rec.Body)
+	}
+}
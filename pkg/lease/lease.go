@@ -0,0 +1,131 @@
+package lease
+
+import (
+	"sync"
+	"time"
+)
+
+// Config controls how long a lease lasts without being renewed
+type Config struct {
+	TTL time.Duration
+}
+
+// Lease is an exclusive, short-lived claim by Owner on Key
+type Lease struct {
+	Key        string
+	Owner      string
+	AcquiredAt time.Time
+	ExpiresAt  time.Time
+}
+
+// Table hands out leases in memory. It is advisory: nothing stops a caller
+// that never asks, and leases are per instance and gone after a restart.
+type Table struct {
+	mu     sync.Mutex
+	leases map[string]*Lease
+	cfg    Config
+	now    func() time.Time
+	done   chan struct{}
+}
+
+func New(cfg Config) *Table {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 2 * time.Minute
+	}
+	t := &Table{
+		leases: make(map[string]*Lease),
+		cfg:    cfg,
+		now:    time.Now,
+		done:   make(chan struct{}),
+	}
+	go t.sweepLoop()
+	return t
+}
+
+func (t *Table) GetConfig() Config {
+	return t.cfg
+}
+
+// Acquire claims key for owner, or renews the claim when owner already holds
+// it. When someone else holds a live lease that lease is returned with false.
+func (t *Table) Acquire(key, owner string) (Lease, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	l, ok := t.leases[key]
+	if ok && !t.expired(l, now) && l.Owner != owner {
+		return *l, false
+	}
+	if !ok || t.expired(l, now) {
+		l = &Lease{Key: key, Owner: owner, AcquiredAt: now}
+		t.leases[key] = l
+	}
+	l.ExpiresAt = now.Add(t.cfg.TTL)
+	return *l, true
+}
+
+// Get returns the live lease on key, if any
+func (t *Table) Get(key string) (Lease, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.leases[key]
+	if !ok || t.expired(l, t.now()) {
+		return Lease{}, false
+	}
+	return *l, true
+}
+
+// Release drops owner's lease on key. It reports false, leaving the lease in
+// place, when someone else holds it.
+func (t *Table) Release(key, owner string) (Lease, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	l, ok := t.leases[key]
+	if !ok || t.expired(l, t.now()) {
+		delete(t.leases, key)
+		return Lease{}, true
+	}
+	if l.Owner != owner {
+		return *l, false
+	}
+	delete(t.leases, key)
+	return Lease{}, true
+}
+
+func (t *Table) Close() error {
+	close(t.done)
+	return nil
+}
+
+func (t *Table) expired(l *Lease, now time.Time) bool {
+	return !now.Before(l.ExpiresAt)
+}
+
+func (t *Table) sweepLoop() {
+	ticker := time.NewTicker(t.cfg.TTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.sweep()
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *Table) sweep() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for key, l := range t.leases {
+		if t.expired(l, now) {
+			delete(t.leases, key)
+		}
+	}
+}
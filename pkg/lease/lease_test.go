@@ -0,0 +1,77 @@
+package lease
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestTable(ttl time.Duration) (*Table, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// built without the sweep loop so the test drives the clock alone
+	t := &Table{
+		leases: make(map[string]*Lease),
+		cfg:    Config{TTL: ttl},
+		now:    func() time.Time { return now },
+	}
+	return t, &now
+}
+
+func TestTable_AcquireAndRenew(t *testing.T) {
+	tbl, now := newTestTable(time.Minute)
+
+	first, ok := tbl.Acquire("t1", "alice")
+	if !ok || first.Owner != "alice" {
+		t.Fatalf("expected alice to get the lease, got %+v", first)
+	}
+
+	held, ok := tbl.Acquire("t1", "bob")
+	if ok || held.Owner != "alice" {
+		t.Fatalf("expected bob to be refused in favour of alice, got %+v", held)
+	}
+
+	*now = now.Add(40 * time.Second)
+	renewed, ok := tbl.Acquire("t1", "alice")
+	if !ok || !renewed.AcquiredAt.Equal(first.AcquiredAt) || !renewed.ExpiresAt.After(first.ExpiresAt) {
+		t.Fatalf("expected the lease renewed in place, got %+v", renewed)
+	}
+
+	*now = now.Add(time.Minute)
+	if _, ok := tbl.Get("t1"); ok {
+		t.Fatal("expected the lease to have expired")
+	}
+	if l, ok := tbl.Acquire("t1", "bob"); !ok || l.Owner != "bob" {
+		t.Fatalf("expected bob to take over an expired lease, got %+v", l)
+	}
+}
+
+func TestTable_Release(t *testing.T) {
+	tbl, _ := newTestTable(time.Minute)
+
+	tbl.Acquire("t1", "alice")
+	if held, ok := tbl.Release("t1", "bob"); ok || held.Owner != "alice" {
+		t.Fatalf("expected bob not to release alice's lease, got %+v", held)
+	}
+	if _, ok := tbl.Release("t1", "alice"); !ok {
+		t.Fatal("expected alice to release the lease")
+	}
+	if _, ok := tbl.Get("t1"); ok {
+		t.Fatal("expected no lease after release")
+	}
+	if _, ok := tbl.Release("t1", "alice"); !ok {
+		t.Fatal("releasing a free key should succeed")
+	}
+}
+
+func TestTable_Sweep(t *testing.T) {
+	tbl, now := newTestTable(time.Minute)
+
+	tbl.Acquire("t1", "alice")
+	*now = now.Add(2 * time.Minute)
+	tbl.sweep()
+
+	tbl.mu.Lock()
+	defer tbl.mu.Unlock()
+	if len(tbl.leases) != 0 {
+		t.Fatalf("expected expired leases to be dropped, got %d", len(tbl.leases))
+	}
+}
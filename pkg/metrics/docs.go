@@ -0,0 +1,90 @@
+package metrics
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+)
+
+// MetricDoc describes an exported metric for operators writing dashboards and
+// alerts
+type MetricDoc struct {
+	Name   string   `json:"name"`
+	Type   string   `json:"type"`
+	Help   string   `json:"help"`
+	Labels []string `json:"labels"`
+	Rules  []Rule   `json:"rules,omitempty"`
+}
+
+// Docs lists the registered metrics with their suggested rules
+func (r *Registry) Docs() []MetricDoc {
+	metrics := r.all()
+	docs := make([]MetricDoc, 0, len(metrics))
+	for _, m := range metrics {
+		d := m.desc()
+		labels := d.Labels
+		if labels == nil {
+			labels = []string{}
+		}
+		docs = append(docs, MetricDoc{
+			Name:   d.Name,
+			Type:   string(m.kind()),
+			Help:   d.Help,
+			Labels: labels,
+			Rules:  d.Rules,
+		})
+	}
+	return docs
+}
+
+// DocsHandler serves the metric documentation as JSON, or with ?format=rules
+// as a Prometheus rule file holding every suggested recording and alerting
+// rule, ready to be loaded or adapted
+func DocsHandler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		docs := r.Docs()
+		if req.URL.Query().Get("format") == "rules" {
+			w.Header().Set("Content-Type", "application/yaml; charset=utf-8")
+			bw := bufio.NewWriter(w)
+			writeRuleFile(bw, docs)
+			bw.Flush()
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]any{"metrics": docs})
+	})
+}
+
+// writeRuleFile emits YAML by hand; every scalar is written as a JSON string,
+// which YAML reads as a double-quoted scalar, so expressions need no further
+// escaping
+func writeRuleFile(w *bufio.Writer, docs []MetricDoc) {
+	w.WriteString("groups:\n  - name: fluxis\n    rules:\n")
+	for _, d := range docs {
+		for _, rule := range d.Rules {
+			if rule.Record != "" {
+				w.WriteString("      - record: " + quote(rule.Record) + "\n")
+			} else {
+				w.WriteString("      - alert: " + quote(rule.Alert) + "\n")
+			}
+			w.WriteString("        expr: " + quote(rule.Expr) + "\n")
+			if rule.For != "" {
+				w.WriteString("        for: " + quote(rule.For) + "\n")
+			}
+			if rule.Summary != "" {
+				w.WriteString("        annotations:\n          summary: " + quote(rule.Summary) + "\n")
+			}
+		}
+	}
+}
+
+func quote(s string) string {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s)
+	return strings.TrimSuffix(b.String(), "\n")
+}
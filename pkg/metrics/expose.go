@@ -0,0 +1,116 @@
+package metrics
+
+import (
+	"bufio"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	contentTypeText        = "text/plain; version=0.0.4; charset=utf-8"
+	contentTypeOpenMetrics = "application/openmetrics-text; version=1.0.0; charset=utf-8"
+)
+
+// Handler serves the registry for scraping. Exemplars only exist in the
+// OpenMetrics format, which Prometheus asks for once exemplar storage is
+// enabled; other scrapers get the classic text format.
+func Handler(r *Registry) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		openMetrics := strings.Contains(req.Header.Get("Accept"), "application/openmetrics-text")
+		if openMetrics {
+			w.Header().Set("Content-Type", contentTypeOpenMetrics)
+		} else {
+			w.Header().Set("Content-Type", contentTypeText)
+		}
+		w.Header().Set("Cache-Control", "no-store")
+
+		bw := bufio.NewWriter(w)
+		r.write(bw, openMetrics)
+		bw.Flush()
+	})
+}
+
+func (r *Registry) write(w *bufio.Writer, openMetrics bool) {
+	for _, m := range r.all() {
+		d := m.desc()
+		family := d.Name
+		if openMetrics && m.kind() == kindCounter {
+			family = strings.TrimSuffix(family, "_total")
+		}
+		w.WriteString("# HELP " + family + " " + escapeHelp(d.Help, openMetrics) + "\n")
+		w.WriteString("# TYPE " + family + " " + string(m.kind()) + "\n")
+
+		for _, s := range m.snapshot() {
+			switch m.kind() {
+			case kindCounter:
+				writeSample(w, d.Name, d.Labels, s.values, "", "", s.count, s.exemplars[0], openMetrics)
+			case kindGauge:
+				writeSample(w, d.Name, d.Labels, s.values, "", "", s.count, nil, openMetrics)
+			case kindHistogram:
+				bounds := m.(*HistogramVec).buckets
+				for i, c := range s.buckets {
+					le := "+Inf"
+					if i < len(bounds) {
+						le = formatFloat(bounds[i])
+					}
+					writeSample(w, d.Name+"_bucket", d.Labels, s.values, "le", le, c, s.exemplars[i], openMetrics)
+				}
+				writeSample(w, d.Name+"_sum", d.Labels, s.values, "", "", s.sum, nil, openMetrics)
+				writeSample(w, d.Name+"_count", d.Labels, s.values, "", "", s.count, nil, openMetrics)
+			}
+		}
+	}
+	if openMetrics {
+		w.WriteString("# EOF\n")
+	}
+}
+
+func writeSample(w *bufio.Writer, name string, labels, values []string, extraName, extraValue string, v float64, ex *exemplar, openMetrics bool) {
+	w.WriteString(name)
+	pairs := make([]string, 0, len(labels)+1)
+	for i, l := range labels {
+		pairs = append(pairs, l+`="`+escapeLabel(values[i])+`"`)
+	}
+	if extraName != "" {
+		pairs = append(pairs, extraName+`="`+extraValue+`"`)
+	}
+	if len(pairs) > 0 {
+		w.WriteString("{" + strings.Join(pairs, ",") + "}")
+	}
+	w.WriteString(" " + formatFloat(v))
+
+	if openMetrics && ex != nil {
+		keys := make([]string, 0, len(ex.labels))
+		for k := range ex.labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		exPairs := make([]string, 0, len(keys))
+		for _, k := range keys {
+			exPairs = append(exPairs, k+`="`+escapeLabel(ex.labels[k])+`"`)
+		}
+		ts := strconv.FormatFloat(float64(ex.at.UnixMilli())/1000, 'f', 3, 64)
+		w.WriteString(" # {" + strings.Join(exPairs, ",") + "} " + formatFloat(ex.value) + " " + ts)
+	}
+	w.WriteString("\n")
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+var (
+	helpEscaper            = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+	openMetricsHelpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`, `"`, `\"`)
+)
+
+func escapeHelp(s string, openMetrics bool) string {
+	if openMetrics {
+		return openMetricsHelpEscaper.Replace(s)
+	}
+	return helpEscaper.Replace(s)
+}
@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RequestIDHeader is read for the exemplar of a request and set on the
+// response when the client or a proxy did not send one, so a sample seen in
+// a dashboard can be traced to the matching log line
+const RequestIDHeader = "X-Request-Id"
+
+var (
+	httpRequests = Default.NewCounter(Desc{
+		Name:   "fluxis_http_requests_total",
+		Help:   "HTTP requests served, by route pattern, method and status code.",
+		Labels: []string{"route", "method", "code"},
+		Rules: []Rule{
+			{
+				Record: "fluxis:http_error_ratio:rate5m",
+				Expr:   `sum by (route) (rate(fluxis_http_requests_total{code=~"5.."}[5m])) / sum by (route) (rate(fluxis_http_requests_total[5m]))`,
+			},
+			{
+				Alert:   "FluxisHighErrorRate",
+				Expr:    `fluxis:http_error_ratio:rate5m > 0.05 and on (route) sum by (route) (rate(fluxis_http_requests_total[5m])) > 0.1`,
+				For:     "10m",
+				Summary: "More than 5% of requests to a route fail with a server error",
+			},
+		},
+	})
+	httpDuration = Default.NewHistogram(Desc{
+		Name:   "fluxis_http_request_duration_seconds",
+		Help:   "Time to serve HTTP requests, by route pattern and method.",
+		Labels: []string{"route", "method"},
+		Rules: []Rule{
+			{
+				Record: "fluxis:http_request_duration_seconds:p95_5m",
+				Expr:   `histogram_quantile(0.95, sum by (route, le) (rate(fluxis_http_request_duration_seconds_bucket[5m])))`,
+			},
+			{
+				Alert:   "FluxisSlowRoute",
+				Expr:    `fluxis:http_request_duration_seconds:p95_5m > 1`,
+				For:     "15m",
+				Summary: "95th percentile latency of a route is above one second",
+			},
+		},
+	}, DefBuckets)
+)
+
+// Router resolves the pattern a request is served by; http.ServeMux
+// implements it
+type Router interface {
+	Handler(r *http.Request) (h http.Handler, pattern string)
+}
+
+// Instrument counts and times every request by the route pattern that serves
+// it rather than the raw path, which keeps label cardinality bounded. The
+// pattern is looked up before the request is served as middleware further in
+// may replace the request.
+func Instrument(next http.Handler, routes Router) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+
+		route := "unmatched"
+		if _, pattern := routes.Handler(r); pattern != "" {
+			route = pattern
+			if _, path, ok := strings.Cut(pattern, " "); ok {
+				route = path
+			}
+		}
+
+		id := r.Header.Get(RequestIDHeader)
+		if id == "" || len(id) > 64 {
+			id = newRequestID()
+			w.Header().Set(RequestIDHeader, id)
+		}
+
+		rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+		next.ServeHTTP(rec, r)
+
+		ex := Labels{"request_id": id}
+		method := methodLabel(r.Method)
+		httpRequests.With(route, method, strconv.Itoa(rec.status)).AddWithExemplar(1, ex)
+		httpDuration.With(route, method).ObserveWithExemplar(time.Since(start).Seconds(), ex)
+	})
+}
+
+// methodLabel folds methods a client made up into "other", any string is a
+// valid method and each one would be a new series
+func methodLabel(method string) string {
+	switch method {
+	case http.MethodGet, http.MethodPost, http.MethodPut, http.MethodPatch,
+		http.MethodDelete, http.MethodHead, http.MethodOptions:
+		return method
+	}
+	return "other"
+}
+
+func newRequestID() string {
+	b := make([]byte, 8)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+func (s *statusRecorder) WriteHeader(code int) {
+	if !s.wroteHeader {
+		s.status = code
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(code)
+}
+
+func (s *statusRecorder) Write(b []byte) (int, error) {
+	s.wroteHeader = true
+	return s.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer, e.g. to
+// extend the write deadline of a long poll
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
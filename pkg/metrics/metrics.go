@@ -0,0 +1,355 @@
+// Package metrics is a small Prometheus instrumentation library without
+// external dependencies. It covers what Fluxis exports: labelled counters,
+// gauges and histograms, exemplars for the OpenMetrics format, and per metric alerting
+// hints that are served as documentation next to the scrape endpoint.
+//
+// Metrics are registered once, usually as package level variables on Default,
+// and updated through With(labelValues...).
+package metrics
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Labels are exemplar labels, typically a request id tying a sample to a log
+// line. OpenMetrics caps an exemplar's labels at 128 characters.
+type Labels map[string]string
+
+// Rule is a Prometheus recording or alerting rule suggested for a metric.
+// Exactly one of Record or Alert is set.
+type Rule struct {
+	Record  string `json:"record,omitempty"`
+	Alert   string `json:"alert,omitempty"`
+	Expr    string `json:"expr"`
+	For     string `json:"for,omitempty"`
+	Summary string `json:"summary,omitempty"`
+}
+
+// Desc describes a metric. Counter names end in _total.
+type Desc struct {
+	Name   string
+	Help   string
+	Labels []string
+	Rules  []Rule
+}
+
+type kind string
+
+const (
+	kindCounter   kind = "counter"
+	kindGauge     kind = "gauge"
+	kindHistogram kind = "histogram"
+)
+
+type metric interface {
+	desc() Desc
+	kind() kind
+	snapshot() []series
+}
+
+// Registry holds metrics in registration order
+type Registry struct {
+	mu      sync.Mutex
+	metrics []metric
+	names   map[string]bool
+}
+
+func NewRegistry() *Registry {
+	return &Registry{names: make(map[string]bool)}
+}
+
+// Default is the registry the application's metrics live on
+var Default = NewRegistry()
+
+func (r *Registry) register(m metric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	name := m.desc().Name
+	if r.names[name] {
+		panic(fmt.Sprintf("metrics: %s registered twice", name))
+	}
+	r.names[name] = true
+	r.metrics = append(r.metrics, m)
+}
+
+func (r *Registry) all() []metric {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]metric(nil), r.metrics...)
+}
+
+type exemplar struct {
+	labels Labels
+	value  float64
+	at     time.Time
+}
+
+// series is one label combination of a metric at scrape time
+type series struct {
+	values    []string
+	count     float64
+	sum       float64
+	buckets   []float64 // cumulative counts per upper bound
+	exemplars []*exemplar
+}
+
+type vec[T any] struct {
+	d      Desc
+	mu     sync.Mutex
+	series map[string]*T
+	make   func(values []string) *T
+}
+
+func (v *vec[T]) with(values []string) *T {
+	if len(values) != len(v.d.Labels) {
+		panic(fmt.Sprintf("metrics: %s takes %d label values, got %d", v.d.Name, len(v.d.Labels), len(values)))
+	}
+	key := strings.Join(values, "\xff")
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	s, ok := v.series[key]
+	if !ok {
+		s = v.make(append([]string(nil), values...))
+		v.series[key] = s
+	}
+	return s
+}
+
+func (v *vec[T]) each(fn func(*T)) {
+	v.mu.Lock()
+	items := make([]*T, 0, len(v.series))
+	for _, s := range v.series {
+		items = append(items, s)
+	}
+	v.mu.Unlock()
+	for _, s := range items {
+		fn(s)
+	}
+}
+
+// CounterVec is a counter partitioned by labels
+type CounterVec struct {
+	vec[Counter]
+}
+
+// Counter only goes up; rates and ratios are derived from it in PromQL
+type Counter struct {
+	values   []string
+	mu       sync.Mutex
+	value    float64
+	exemplar *exemplar
+}
+
+// NewCounter registers a counter on r
+func (r *Registry) NewCounter(d Desc) *CounterVec {
+	if !strings.HasSuffix(d.Name, "_total") {
+		panic("metrics: counter " + d.Name + " must end in _total")
+	}
+	c := &CounterVec{vec[Counter]{
+		d:      d,
+		series: make(map[string]*Counter),
+		make:   func(values []string) *Counter { return &Counter{values: values} },
+	}}
+	r.register(c)
+	return c
+}
+
+func (c *CounterVec) With(values ...string) *Counter {
+	return c.with(values)
+}
+
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+func (c *Counter) Add(v float64) {
+	c.AddWithExemplar(v, nil)
+}
+
+// AddWithExemplar also keeps ex as the counter's latest exemplar
+func (c *Counter) AddWithExemplar(v float64, ex Labels) {
+	if v < 0 {
+		panic("metrics: counters can not decrease")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.value += v
+	if len(ex) > 0 {
+		c.exemplar = &exemplar{labels: ex, value: v, at: time.Now()}
+	}
+}
+
+func (c *CounterVec) desc() Desc { return c.d }
+func (c *CounterVec) kind() kind { return kindCounter }
+
+func (c *CounterVec) snapshot() []series {
+	var out []series
+	c.each(func(s *Counter) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		out = append(out, series{values: s.values, count: s.value, exemplars: []*exemplar{s.exemplar}})
+	})
+	sortSeries(out)
+	return out
+}
+
+// GaugeVec is a gauge partitioned by labels
+type GaugeVec struct {
+	vec[Gauge]
+}
+
+// Gauge holds the latest reading of something that goes up and down, such as
+// the size of a table
+type Gauge struct {
+	values []string
+	mu     sync.Mutex
+	value  float64
+}
+
+// NewGauge registers a gauge on r
+func (r *Registry) NewGauge(d Desc) *GaugeVec {
+	if strings.HasSuffix(d.Name, "_total") {
+		panic("metrics: gauge " + d.Name + " must not end in _total")
+	}
+	g := &GaugeVec{vec[Gauge]{
+		d:      d,
+		series: make(map[string]*Gauge),
+		make:   func(values []string) *Gauge { return &Gauge{values: values} },
+	}}
+	r.register(g)
+	return g
+}
+
+func (g *GaugeVec) With(values ...string) *Gauge {
+	return g.with(values)
+}
+
+func (g *Gauge) Set(v float64) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.value = v
+}
+
+func (g *GaugeVec) desc() Desc { return g.d }
+func (g *GaugeVec) kind() kind { return kindGauge }
+
+func (g *GaugeVec) snapshot() []series {
+	var out []series
+	g.each(func(s *Gauge) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		out = append(out, series{values: s.values, count: s.value})
+	})
+	sortSeries(out)
+	return out
+}
+
+// DefBuckets suit request latencies in seconds
+var DefBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// HistogramVec is a histogram partitioned by labels
+type HistogramVec struct {
+	vec[Histogram]
+	buckets []float64
+}
+
+// Histogram counts observations into buckets; each bucket keeps the exemplar
+// of the latest observation that fell into it
+type Histogram struct {
+	values    []string
+	mu        sync.Mutex
+	counts    []float64 // per bucket, the last one is +Inf
+	sum       float64
+	exemplars []*exemplar
+	bounds    []float64
+}
+
+// NewHistogram registers a histogram on r with the given upper bounds
+func (r *Registry) NewHistogram(d Desc, buckets []float64) *HistogramVec {
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+	h := &HistogramVec{buckets: bounds}
+	h.vec = vec[Histogram]{
+		d:      d,
+		series: make(map[string]*Histogram),
+		make: func(values []string) *Histogram {
+			return &Histogram{
+				values:    values,
+				counts:    make([]float64, len(bounds)+1),
+				exemplars: make([]*exemplar, len(bounds)+1),
+				bounds:    bounds,
+			}
+		},
+	}
+	r.register(h)
+	return h
+}
+
+func (h *HistogramVec) With(values ...string) *Histogram {
+	return h.with(values)
+}
+
+func (h *Histogram) Observe(v float64) {
+	h.ObserveWithExemplar(v, nil)
+}
+
+func (h *Histogram) ObserveWithExemplar(v float64, ex Labels) {
+	i := sort.SearchFloat64s(h.bounds, v)
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.counts[i]++
+	h.sum += v
+	if len(ex) > 0 {
+		h.exemplars[i] = &exemplar{labels: ex, value: v, at: time.Now()}
+	}
+}
+
+func (h *HistogramVec) desc() Desc { return h.d }
+func (h *HistogramVec) kind() kind { return kindHistogram }
+
+func (h *HistogramVec) snapshot() []series {
+	var out []series
+	h.each(func(s *Histogram) {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		cumulative := make([]float64, len(s.counts))
+		total := 0.0
+		for i, c := range s.counts {
+			total += c
+			cumulative[i] = total
+		}
+		out = append(out, series{
+			values:    s.values,
+			count:     total,
+			sum:       s.sum,
+			buckets:   cumulative,
+			exemplars: append([]*exemplar(nil), s.exemplars...),
+		})
+	})
+	sortSeries(out)
+	return out
+}
+
+func sortSeries(s []series) {
+	sort.Slice(s, func(i, j int) bool {
+		return strings.Join(s[i].values, "\xff") < strings.Join(s[j].values, "\xff")
+	})
+}
+
+func formatFloat(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	}
+	return fmt.Sprint(v)
+}
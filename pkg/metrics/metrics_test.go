@@ -0,0 +1,191 @@
+package metrics_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
+)
+
+func scrape(t *testing.T, h http.Handler, accept string) (string, string) {
+	t.Helper()
+	req := httptest.NewRequest("GET", "/metrics", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	body, _ := io.ReadAll(rec.Body)
+	return rec.Header().Get("Content-Type"), string(body)
+}
+
+func TestHandler_TextFormat(t *testing.T) {
+	r := metrics.NewRegistry()
+	c := r.NewCounter(metrics.Desc{Name: "jobs_total", Help: "Jobs run.", Labels: []string{"queue"}})
+	h := r.NewHistogram(metrics.Desc{Name: "job_seconds", Help: "Job time."}, []float64{1, 0.5})
+	g := r.NewGauge(metrics.Desc{Name: "jobs_waiting", Help: "Jobs queued.", Labels: []string{"queue"}})
+
+	c.With(`a"b`).Add(2)
+	c.With("plain").AddWithExemplar(1, metrics.Labels{"request_id": "abc"})
+	h.With().Observe(0.2)
+	h.With().Observe(0.7)
+	h.With().Observe(3)
+	g.With("plain").Set(5)
+	g.With("plain").Set(2)
+
+	ct, body := scrape(t, metrics.Handler(r), "")
+	if !strings.HasPrefix(ct, "text/plain") {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	for _, want := range []string{
+		"# TYPE jobs_total counter\n",
+		`jobs_total{queue="a\"b"} 2` + "\n",
+		`jobs_total{queue="plain"} 1` + "\n",
+		`job_seconds_bucket{le="0.5"} 1` + "\n",
+		`job_seconds_bucket{le="1"} 2` + "\n",
+		`job_seconds_bucket{le="+Inf"} 3` + "\n",
+		"job_seconds_sum 3.9\n",
+		"job_seconds_count 3\n",
+		"# TYPE jobs_waiting gauge\n",
+		`jobs_waiting{queue="plain"} 2` + "\n",
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("missing %q in\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "request_id") || strings.Contains(body, "# EOF") {
+		t.Errorf("text format must not carry exemplars or EOF:\n%s", body)
+	}
+}
+
+func TestHandler_OpenMetricsExemplars(t *testing.T) {
+	r := metrics.NewRegistry()
+	c := r.NewCounter(metrics.Desc{Name: "jobs_total", Help: "Jobs run."})
+	c.With().AddWithExemplar(1, metrics.Labels{"request_id": "abc"})
+
+	ct, body := scrape(t, metrics.Handler(r), "application/openmetrics-text; version=1.0.0")
+	if !strings.HasPrefix(ct, "application/openmetrics-text") {
+		t.Fatalf("Content-Type = %q", ct)
+	}
+	if !strings.Contains(body, "# TYPE jobs counter\n") {
+		t.Errorf("expected the family name without _total:\n%s", body)
+	}
+	if !strings.Contains(body, `jobs_total 1 # {request_id="abc"} 1 `) {
+		t.Errorf("expected an exemplar on the sample:\n%s", body)
+	}
+	if !strings.HasSuffix(body, "# EOF\n") {
+		t.Errorf("expected the EOF marker:\n%s", body)
+	}
+}
+
+func TestRegistry_Panics(t *testing.T) {
+	tests := []struct {
+		name string
+		fn   func(r *metrics.Registry)
+	}{
+		{"counter without _total", func(r *metrics.Registry) { r.NewCounter(metrics.Desc{Name: "jobs"}) }},
+		{"gauge with _total", func(r *metrics.Registry) { r.NewGauge(metrics.Desc{Name: "jobs_total"}) }},
+		{"registered twice", func(r *metrics.Registry) {
+			r.NewCounter(metrics.Desc{Name: "jobs_total"})
+			r.NewCounter(metrics.Desc{Name: "jobs_total"})
+		}},
+		{"label count", func(r *metrics.Registry) {
+			r.NewCounter(metrics.Desc{Name: "jobs_total", Labels: []string{"queue"}}).With()
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Fatal("expected a panic")
+				}
+			}()
+			tt.fn(metrics.NewRegistry())
+		})
+	}
+}
+
+func TestDocsHandler(t *testing.T) {
+	r := metrics.NewRegistry()
+	r.NewCounter(metrics.Desc{
+		Name:   "jobs_total",
+		Help:   "Jobs run.",
+		Labels: []string{"queue"},
+		Rules: []metrics.Rule{
+			{Alert: "JobsFailing", Expr: `rate(jobs_total{state="failed"}[5m]) > 0`, For: "5m", Summary: "Jobs fail"},
+		},
+	})
+
+	_, body := scrape(t, metrics.DocsHandler(r), "")
+	var docs struct {
+		Metrics []metrics.MetricDoc `json:"metrics"`
+	}
+	if err := json.Unmarshal([]byte(body), &docs); err != nil {
+		t.Fatalf("decode docs: %v", err)
+	}
+	if len(docs.Metrics) != 1 || docs.Metrics[0].Type != "counter" || len(docs.Metrics[0].Rules) != 1 {
+		t.Fatalf("unexpected docs %+v", docs)
+	}
+
+	req := httptest.NewRequest("GET", "/metrics/docs?format=rules", nil)
+	rec := httptest.NewRecorder()
+	metrics.DocsHandler(r).ServeHTTP(rec, req)
+	want := "      - alert: \"JobsFailing\"\n        expr: \"rate(jobs_total{state=\\\"failed\\\"}[5m]) > 0\"\n"
+	if !strings.Contains(rec.Body.String(), want) {
+		t.Fatalf("rule file missing %q:\n%s", want, rec.Body.String())
+	}
+}
+
+func TestInstrument_LabelsByRoute(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /widgets/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	})
+	h := metrics.Instrument(mux, mux)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/widgets/42", nil))
+	if rec.Header().Get(metrics.RequestIDHeader) == "" {
+		t.Fatal("expected a generated request id")
+	}
+
+	rec = httptest.NewRecorder()
+	req := httptest.NewRequest("GET", "/widgets/43", nil)
+	req.Header.Set(metrics.RequestIDHeader, "given")
+	h.ServeHTTP(rec, req)
+	if rec.Header().Get(metrics.RequestIDHeader) != "" {
+		t.Fatal("a request id sent by the client is not echoed")
+	}
+
+	_, body := scrape(t, metrics.Handler(metrics.Default), "application/openmetrics-text")
+	if !strings.Contains(body, `fluxis_http_requests_total{route="/widgets/{id}",method="GET",code="418"} 2 # {request_id="given"}`) {
+		t.Fatalf("expected both requests under the route pattern:\n%s", body)
+	}
+}
+
+func TestInstrument_UnknownMethods(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/gadgets", func(w http.ResponseWriter, r *http.Request) {})
+	h := metrics.Instrument(mux, mux)
+
+	for _, method := range []string{"PATCH", "BREW", "PROPFIND", "X-" + strings.Repeat("A", 40)} {
+		h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(method, "/gadgets", nil))
+	}
+
+	_, body := scrape(t, metrics.Handler(metrics.Default), "text/plain")
+	for _, want := range []string{
+		`fluxis_http_requests_total{route="/gadgets",method="PATCH",code="200"} 1`,
+		`fluxis_http_requests_total{route="/gadgets",method="other",code="200"} 3`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Fatalf("expected %s in:\n%s", want, body)
+		}
+	}
+	if strings.Contains(body, "BREW") || strings.Contains(body, "PROPFIND") {
+		t.Fatalf("a made up method became a label:\n%s", body)
+	}
+}
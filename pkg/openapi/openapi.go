@@ -0,0 +1,432 @@
+// Package openapi turns the Swagger 2.0 document swag generates into
+// OpenAPI 3.0, and encodes either one canonically: keys sorted and indented
+// the same way every time, so a written spec only changes when the API does.
+//
+// The conversion covers what swag emits: body and formData parameters become
+// request bodies, response schemas move under their media types, and
+// definitions become components.
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Version is the OpenAPI version Convert produces
+const Version = "3.0.3"
+
+const (
+	definitionsRef = "#/definitions/"
+	schemasRef     = "#/components/schemas/"
+)
+
+type object = map[string]any
+
+// Convert returns the OpenAPI 3.0 form of a Swagger 2.0 document, encoded
+// canonically
+func Convert(spec []byte) ([]byte, error) {
+	doc, err := decode(spec)
+	if err != nil {
+		return nil, err
+	}
+	if v, _ := doc["swagger"].(string); v != "2.0" {
+		return nil, fmt.Errorf("openapi: expected a swagger 2.0 document, got version %q", v)
+	}
+
+	out := object{
+		"openapi": Version,
+		"info":    doc["info"],
+		"servers": servers(doc),
+	}
+
+	consumes := stringsOf(doc["consumes"])
+	produces := stringsOf(doc["produces"])
+	paths := object{}
+	for path, item := range objectOf(doc["paths"]) {
+		converted := object{}
+		for method, op := range objectOf(item) {
+			if method == "parameters" {
+				converted[method] = op
+				continue
+			}
+			converted[method] = operation(objectOf(op), consumes, produces)
+		}
+		paths[path] = converted
+	}
+	out["paths"] = paths
+
+	components := object{}
+	if defs := objectOf(doc["definitions"]); len(defs) > 0 {
+		components["schemas"] = defs
+	}
+	if sec := objectOf(doc["securityDefinitions"]); len(sec) > 0 {
+		schemes := object{}
+		for name, s := range sec {
+			schemes[name] = securityScheme(objectOf(s))
+		}
+		components["securitySchemes"] = schemes
+	}
+	if len(components) > 0 {
+		out["components"] = components
+	}
+	for _, key := range []string{"security", "tags", "externalDocs"} {
+		if v, ok := doc[key]; ok {
+			out[key] = v
+		}
+	}
+
+	return Canonical(mustEncode(schemas(out)))
+}
+
+// Canonical re-encodes a JSON document with sorted keys and four space
+// indentation
+func Canonical(spec []byte) ([]byte, error) {
+	doc, err := decode(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "    ")
+	if err := enc.Encode(doc); err != nil {
+		return nil, fmt.Errorf("openapi: encode: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// MissingOperationIDs lists the operations of a Swagger 2.0 or OpenAPI 3.0
+// document that have no operationId, as "METHOD /path" in sorted order
+func MissingOperationIDs(spec []byte) ([]string, error) {
+	doc, err := decode(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	var missing []string
+	for path, item := range objectOf(doc["paths"]) {
+		for method, op := range objectOf(item) {
+			if method == "parameters" {
+				continue
+			}
+			if id, _ := objectOf(op)["operationId"].(string); id == "" {
+				missing = append(missing, strings.ToUpper(method)+" "+path)
+			}
+		}
+	}
+	sort.Strings(missing)
+	return missing, nil
+}
+
+func decode(spec []byte) (object, error) {
+	dec := json.NewDecoder(bytes.NewReader(spec))
+	// numbers stay as written instead of passing through float64
+	dec.UseNumber()
+	var doc object
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("openapi: decode: %w", err)
+	}
+	return doc, nil
+}
+
+// mustEncode encodes a document that was decoded from JSON, which can not fail
+func mustEncode(v any) []byte {
+	b, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return b
+}
+
+func servers(doc object) []any {
+	base, _ := doc["basePath"].(string)
+	host, _ := doc["host"].(string)
+	if host == "" {
+		if base == "" {
+			base = "/"
+		}
+		return []any{object{"url": base}}
+	}
+
+	schemes := stringsOf(doc["schemes"])
+	if len(schemes) == 0 {
+		schemes = []string{"https"}
+	}
+	list := make([]any, 0, len(schemes))
+	for _, scheme := range schemes {
+		u := url.URL{Scheme: scheme, Host: host, Path: strings.TrimSuffix(base, "/")}
+		list = append(list, object{"url": u.String()})
+	}
+	return list
+}
+
+func operation(op object, consumes, produces []string) object {
+	if c := stringsOf(op["consumes"]); len(c) > 0 {
+		consumes = c
+	}
+	if p := stringsOf(op["produces"]); len(p) > 0 {
+		produces = p
+	}
+	if len(produces) == 0 {
+		produces = []string{"application/json"}
+	}
+
+	out := object{}
+	for key, v := range op {
+		switch key {
+		case "consumes", "produces", "parameters", "responses":
+		default:
+			out[key] = v
+		}
+	}
+
+	var params []any
+	var form []object
+	for _, p := range objectsOf(op["parameters"]) {
+		switch p["in"] {
+		case "body":
+			out["requestBody"] = bodyRequest(p, consumes)
+		case "formData":
+			form = append(form, p)
+		default:
+			params = append(params, parameter(p))
+		}
+	}
+	if len(params) > 0 {
+		out["parameters"] = params
+	}
+	if len(form) > 0 {
+		out["requestBody"] = formRequest(form, consumes)
+	}
+
+	responses := object{}
+	for code, r := range objectOf(op["responses"]) {
+		responses[code] = response(objectOf(r), produces)
+	}
+	out["responses"] = responses
+	return out
+}
+
+// schemaKeys are the parameter fields that describe its value, which OpenAPI
+// 3.0 moves into the parameter's schema
+var schemaKeys = []string{
+	"type", "format", "items", "enum", "default", "minimum", "maximum",
+	"exclusiveMinimum", "exclusiveMaximum", "minLength", "maxLength",
+	"pattern", "minItems", "maxItems", "uniqueItems", "multipleOf",
+}
+
+func parameter(p object) object {
+	out := object{}
+	schema := object{}
+	for key, v := range p {
+		switch {
+		case key == "collectionFormat":
+		case contains(schemaKeys, key):
+			schema[key] = v
+		default:
+			out[key] = v
+		}
+	}
+	if len(schema) > 0 {
+		out["schema"] = schema
+	}
+
+	// csv is the 2.0 default for arrays; multi repeats the parameter, which
+	// is form style with explode in 3.0
+	if p["type"] == "array" {
+		switch p["collectionFormat"] {
+		case "multi":
+			out["style"], out["explode"] = "form", true
+		case "ssv":
+			out["style"] = "spaceDelimited"
+		case "pipes":
+			out["style"] = "pipeDelimited"
+		default:
+			out["explode"] = false
+		}
+	}
+	if p["in"] == "path" {
+		out["required"] = true
+	}
+	return out
+}
+
+func bodyRequest(p object, consumes []string) object {
+	if len(consumes) == 0 {
+		consumes = []string{"application/json"}
+	}
+	content := object{}
+	for _, mt := range consumes {
+		content[mt] = object{"schema": p["schema"]}
+	}
+	out := object{"content": content}
+	if d, ok := p["description"]; ok {
+		out["description"] = d
+	}
+	if r, ok := p["required"].(bool); ok && r {
+		out["required"] = true
+	}
+	return out
+}
+
+func formRequest(params []object, consumes []string) object {
+	mt := "application/x-www-form-urlencoded"
+	for _, p := range params {
+		if p["type"] == "file" {
+			mt = "multipart/form-data"
+		}
+	}
+	if contains(consumes, "multipart/form-data") {
+		mt = "multipart/form-data"
+	}
+
+	props := object{}
+	var required []any
+	for _, p := range params {
+		name, _ := p["name"].(string)
+		schema := object{}
+		for _, key := range schemaKeys {
+			if v, ok := p[key]; ok {
+				schema[key] = v
+			}
+		}
+		if schema["type"] == "file" {
+			schema["type"], schema["format"] = "string", "binary"
+		}
+		if d, ok := p["description"]; ok {
+			schema["description"] = d
+		}
+		props[name] = schema
+		if r, ok := p["required"].(bool); ok && r {
+			required = append(required, name)
+		}
+	}
+
+	schema := object{"type": "object", "properties": props}
+	if len(required) > 0 {
+		schema["required"] = required
+	}
+	return object{"content": object{mt: object{"schema": schema}}}
+}
+
+func response(r object, produces []string) object {
+	out := object{}
+	for key, v := range r {
+		switch key {
+		case "schema", "examples":
+		case "headers":
+			headers := object{}
+			for name, h := range objectOf(v) {
+				headers[name] = header(objectOf(h))
+			}
+			out[key] = headers
+		default:
+			out[key] = v
+		}
+	}
+	if _, ok := out["description"]; !ok {
+		out["description"] = ""
+	}
+	if schema, ok := r["schema"]; ok {
+		content := object{}
+		for _, mt := range produces {
+			content[mt] = object{"schema": schema}
+		}
+		out["content"] = content
+	}
+	return out
+}
+
+func header(h object) object {
+	out := object{}
+	schema := object{}
+	for key, v := range h {
+		if contains(schemaKeys, key) {
+			schema[key] = v
+		} else if key != "collectionFormat" {
+			out[key] = v
+		}
+	}
+	out["schema"] = schema
+	return out
+}
+
+func securityScheme(s object) object {
+	if s["type"] != "basic" {
+		return s
+	}
+	out := object{"type": "http", "scheme": "basic"}
+	if d, ok := s["description"]; ok {
+		out["description"] = d
+	}
+	return out
+}
+
+// schemas rewrites what differs inside schemas anywhere in the document:
+// references point at components and x-nullable becomes nullable
+func schemas(v any) any {
+	switch v := v.(type) {
+	case object:
+		for key, child := range v {
+			switch key {
+			case "$ref":
+				if ref, ok := child.(string); ok {
+					v[key] = strings.Replace(ref, definitionsRef, schemasRef, 1)
+				}
+			case "x-nullable":
+				delete(v, key)
+				v["nullable"] = child
+			default:
+				v[key] = schemas(child)
+			}
+		}
+		return v
+	case []any:
+		for i, child := range v {
+			v[i] = schemas(child)
+		}
+		return v
+	}
+	return v
+}
+
+func objectOf(v any) object {
+	o, _ := v.(object)
+	return o
+}
+
+func objectsOf(v any) []object {
+	list, _ := v.([]any)
+	out := make([]object, 0, len(list))
+	for _, item := range list {
+		if o, ok := item.(object); ok {
+			out = append(out, o)
+		}
+	}
+	return out
+}
+
+func stringsOf(v any) []string {
+	list, _ := v.([]any)
+	out := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := item.(string); ok {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+func contains(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
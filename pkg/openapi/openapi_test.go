@@ -0,0 +1,174 @@
+package openapi_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"slices"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/openapi"
+)
+
+const swagger = `{
+	"swagger": "2.0",
+	"info": {"title": "fluxis", "version": "1.0"},
+	"schemes": ["http", "https"],
+	"host": "localhost:8080",
+	"basePath": "/",
+	"paths": {
+		"/projects/{projectId}": {
+			"patch": {
+				"operationId": "updateProject",
+				"consumes": ["application/json"],
+				"produces": ["application/json"],
+				"parameters": [
+					{"type": "string", "description": "Project ID", "name": "projectId", "in": "path", "required": true},
+					{"type": "array", "items": {"type": "string"}, "collectionFormat": "csv", "name": "fields", "in": "query"},
+					{"description": "Changes", "name": "body", "in": "body", "required": true, "schema": {"$ref": "#/definitions/domain.ProjectUpdateModel"}}
+				],
+				"responses": {
+					"200": {"description": "OK", "headers": {"ETag": {"type": "string", "description": "Version"}}, "schema": {"$ref": "#/definitions/domain.ProjectModel"}},
+					"204": {"description": "No Content"}
+				}
+			}
+		},
+		"/users/me/avatar": {
+			"put": {
+				"consumes": ["multipart/form-data"],
+				"parameters": [
+					{"type": "file", "description": "Image", "name": "file", "in": "formData", "required": true}
+				],
+				"responses": {"204": {"description": "No Content"}}
+			}
+		}
+	},
+	"definitions": {
+		"domain.ProjectModel": {"type": "object", "properties": {"estimate": {"type": "integer", "x-nullable": true, "maximum": 9007199254740993}}},
+		"domain.ProjectUpdateModel": {"type": "object", "properties": {"owner": {"$ref": "#/definitions/domain.ProjectModel"}}}
+	},
+	"securityDefinitions": {"BearerAuth": {"type": "apiKey", "name": "Authorization", "in": "header"}}
+}`
+
+func convert(t *testing.T) map[string]any {
+	t.Helper()
+	out, err := openapi.Convert([]byte(swagger))
+	if err != nil {
+		t.Fatalf("Convert: %v", err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(out, &doc); err != nil {
+		t.Fatalf("Convert wrote invalid JSON: %v", err)
+	}
+	return doc
+}
+
+// at walks a decoded document, failing the test when a step is missing
+func at(t *testing.T, v any, path ...any) any {
+	t.Helper()
+	for _, step := range path {
+		switch s := step.(type) {
+		case string:
+			m, ok := v.(map[string]any)
+			if !ok || m[s] == nil {
+				t.Fatalf("missing %q in %v", s, path)
+			}
+			v = m[s]
+		case int:
+			l, ok := v.([]any)
+			if !ok || len(l) <= s {
+				t.Fatalf("missing index %d in %v", s, path)
+			}
+			v = l[s]
+		}
+	}
+	return v
+}
+
+func TestConvert(t *testing.T) {
+	doc := convert(t)
+	if doc["openapi"] != openapi.Version || doc["swagger"] != nil {
+		t.Fatalf("expected an OpenAPI %s document, got %v", openapi.Version, doc["openapi"])
+	}
+	if got := at(t, doc, "servers", 1, "url"); got != "https://localhost:8080" {
+		t.Errorf("server url = %v", got)
+	}
+
+	op := at(t, doc, "paths", "/projects/{projectId}", "patch")
+	if got := at(t, op, "parameters", 0, "schema", "type"); got != "string" {
+		t.Errorf("path parameter schema type = %v", got)
+	}
+	if got := at(t, op, "parameters", 1, "explode"); got != false {
+		t.Errorf("csv parameter explode = %v", got)
+	}
+	if got := at(t, op, "requestBody", "content", "application/json", "schema", "$ref"); got != "#/components/schemas/domain.ProjectUpdateModel" {
+		t.Errorf("request body ref = %v", got)
+	}
+	if got := at(t, op, "responses", "200", "content", "application/json", "schema", "$ref"); got != "#/components/schemas/domain.ProjectModel" {
+		t.Errorf("response ref = %v", got)
+	}
+	if got := at(t, op, "responses", "200", "headers", "ETag", "schema", "type"); got != "string" {
+		t.Errorf("header schema type = %v", got)
+	}
+	if r := at(t, op, "responses", "204").(map[string]any); r["content"] != nil {
+		t.Errorf("expected no content for 204, got %v", r["content"])
+	}
+
+	file := at(t, doc, "paths", "/users/me/avatar", "put", "requestBody", "content", "multipart/form-data", "schema")
+	if got := at(t, file, "properties", "file", "format"); got != "binary" {
+		t.Errorf("file format = %v", got)
+	}
+	if got := at(t, file, "required", 0); got != "file" {
+		t.Errorf("form required = %v", got)
+	}
+
+	schema := at(t, doc, "components", "schemas", "domain.ProjectModel", "properties", "estimate").(map[string]any)
+	if schema["nullable"] != true || schema["x-nullable"] != nil {
+		t.Errorf("expected x-nullable to become nullable, got %v", schema)
+	}
+	if got := at(t, doc, "components", "securitySchemes", "BearerAuth", "type"); got != "apiKey" {
+		t.Errorf("security scheme type = %v", got)
+	}
+}
+
+func TestConvert_Deterministic(t *testing.T) {
+	first, err := openapi.Convert([]byte(swagger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for range 10 {
+		again, _ := openapi.Convert([]byte(swagger))
+		if !bytes.Equal(first, again) {
+			t.Fatal("expected the same output on every run")
+		}
+	}
+	if !bytes.Contains(first, []byte("9007199254740993")) {
+		t.Error("expected large numbers to be kept as written")
+	}
+}
+
+func TestConvert_RejectsOtherVersions(t *testing.T) {
+	if _, err := openapi.Convert([]byte(`{"openapi": "3.0.3"}`)); err == nil {
+		t.Fatal("expected an error for a document that is not swagger 2.0")
+	}
+}
+
+func TestCanonical(t *testing.T) {
+	got, err := openapi.Canonical([]byte(`{"b": 1, "a": {"d": "<x>", "c": true}}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "{\n    \"a\": {\n        \"c\": true,\n        \"d\": \"<x>\"\n    },\n    \"b\": 1\n}\n"
+	if string(got) != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMissingOperationIDs(t *testing.T) {
+	got, err := openapi.MissingOperationIDs([]byte(swagger))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"PUT /users/me/avatar"}; !slices.Equal(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,163 @@
+// Package pagination holds the page math shared by every paged list.
+//
+// Paged queries return their rows together with a COUNT(*) OVER () column, so
+// the total rides along on each row. A page with no rows therefore reports a
+// total of zero and zero pages, whether the filter matched nothing or the
+// page number is past the end.
+package pagination
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+const (
+	DefaultPageNumber = 1
+
+	// DefaultPageSize and MaxPageSize are the built-in settings, used until
+	// Configure replaces them
+	DefaultPageSize = 25
+	MaxPageSize     = 100
+)
+
+// Settings are the instance-wide page sizes every search model resolves
+// against. A page size left unset gets DefaultPageSize and a larger one than
+// MaxPageSize is capped to it.
+type Settings struct {
+	DefaultPageSize int
+	MaxPageSize     int
+}
+
+var ErrSettings = errors.New("invalid pagination settings")
+
+var settings atomic.Pointer[Settings]
+
+func init() {
+	settings.Store(&Settings{DefaultPageSize: DefaultPageSize, MaxPageSize: MaxPageSize})
+}
+
+// Configure replaces the instance settings. The default must be at least one
+// and no larger than the maximum.
+func Configure(s Settings) error {
+	if s.DefaultPageSize < 1 || s.MaxPageSize < s.DefaultPageSize {
+		return fmt.Errorf("%w: default page size %d, max page size %d", ErrSettings, s.DefaultPageSize, s.MaxPageSize)
+	}
+	settings.Store(&s)
+	return nil
+}
+
+// Current reports the settings Normalize resolves against
+func Current() Settings {
+	return *settings.Load()
+}
+
+// Page is the shape every paged endpoint responds with
+type Page[T any] struct {
+	Items      []T `json:"items"`
+	TotalCount int `json:"totalCount"`
+	TotalPages int `json:"totalPages"`
+	PageNumber int `json:"pageNumber"`
+	PageSize   int `json:"pageSize"`
+	// HasMore is set when a page follows this one
+	HasMore bool `json:"hasMore"`
+}
+
+// Normalize is the resolver every search model goes through: it fills in the
+// defaults for unset values and caps the page size at the configured maximum
+func Normalize(pageNumber, pageSize int) (int, int) {
+	s := settings.Load()
+	if pageNumber < 1 {
+		pageNumber = DefaultPageNumber
+	}
+	if pageSize < 1 {
+		pageSize = s.DefaultPageSize
+	}
+	if pageSize > s.MaxPageSize {
+		pageSize = s.MaxPageSize
+	}
+	return pageNumber, pageSize
+}
+
+// Offset is the number of rows to skip to reach pageNumber
+func Offset(pageNumber, pageSize int) int32 {
+	pageNumber, pageSize = Normalize(pageNumber, pageSize)
+	return int32((pageNumber - 1) * pageSize)
+}
+
+// TotalPages rounds up, an empty result has no pages
+func TotalPages(totalCount, pageSize int) int {
+	if totalCount <= 0 {
+		return 0
+	}
+	_, pageSize = Normalize(DefaultPageNumber, pageSize)
+	return (totalCount + pageSize - 1) / pageSize
+}
+
+// New builds a page from items already converted to their model. Items is
+// never nil so an empty page encodes as [] rather than null.
+func New[T any](items []T, totalCount int64, pageNumber, pageSize int) Page[T] {
+	pageNumber, pageSize = Normalize(pageNumber, pageSize)
+	if items == nil {
+		items = []T{}
+	}
+	if len(items) == 0 {
+		totalCount = 0
+	}
+	return Page[T]{
+		Items:      items,
+		TotalCount: int(totalCount),
+		TotalPages: TotalPages(int(totalCount), pageSize),
+		PageNumber: pageNumber,
+		PageSize:   pageSize,
+		HasMore:    pageNumber < TotalPages(int(totalCount), pageSize),
+	}
+}
+
+// Empty is the page returned when nothing matched
+func Empty[T any](pageNumber, pageSize int) Page[T] {
+	return New[T](nil, 0, pageNumber, pageSize)
+}
+
+// FromRows converts query rows that carry a window count into a page
+func FromRows[R, T any](rows []R, total func(R) int64, convert func(R) T, pageNumber, pageSize int) Page[T] {
+	if len(rows) == 0 {
+		return Empty[T](pageNumber, pageSize)
+	}
+	items := make([]T, len(rows))
+	for i, row := range rows {
+		items[i] = convert(row)
+	}
+	return New(items, total(rows[0]), pageNumber, pageSize)
+}
+
+// Links renders an RFC 8288 Link header value pointing at the first, prev,
+// next and last page of the list u was served from. The targets are relative
+// references so they stay valid behind a proxy; other query parameters are
+// kept. prev and next are left out at the ends and an empty list has no links.
+func Links(u *url.URL, pageNumber, pageSize, totalPages int) string {
+	if totalPages <= 0 {
+		return ""
+	}
+	pageNumber, pageSize = Normalize(pageNumber, pageSize)
+
+	link := func(page int, rel string) string {
+		q := u.Query()
+		q.Set("pageNumber", strconv.Itoa(page))
+		q.Set("pageSize", strconv.Itoa(pageSize))
+		return fmt.Sprintf(`<%s?%s>; rel="%s"`, u.EscapedPath(), q.Encode(), rel)
+	}
+
+	links := []string{link(1, "first")}
+	if pageNumber > 1 {
+		links = append(links, link(min(pageNumber-1, totalPages), "prev"))
+	}
+	if pageNumber < totalPages {
+		links = append(links, link(pageNumber+1, "next"))
+	}
+	links = append(links, link(totalPages, "last"))
+	return strings.Join(links, ", ")
+}
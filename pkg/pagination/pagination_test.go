@@ -0,0 +1,152 @@
+package pagination_test
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/pagination"
+)
+
+func TestNormalize(t *testing.T) {
+	cases := []struct {
+		name               string
+		pageNumber, size   int
+		wantNumber, wantSz int
+	}{
+		{"defaults", 0, 0, pagination.DefaultPageNumber, pagination.DefaultPageSize},
+		{"negative", -3, -1, pagination.DefaultPageNumber, pagination.DefaultPageSize},
+		{"kept", 4, 10, 4, 10},
+		{"capped", 1, 500, 1, pagination.MaxPageSize},
+	}
+	for _, c := range cases {
+		n, s := pagination.Normalize(c.pageNumber, c.size)
+		if n != c.wantNumber || s != c.wantSz {
+			t.Errorf("%s: got (%d, %d), want (%d, %d)", c.name, n, s, c.wantNumber, c.wantSz)
+		}
+	}
+}
+
+func TestConfigure(t *testing.T) {
+	defer pagination.Configure(pagination.Settings{DefaultPageSize: pagination.DefaultPageSize, MaxPageSize: pagination.MaxPageSize})
+
+	if err := pagination.Configure(pagination.Settings{DefaultPageSize: 50, MaxPageSize: 20}); err == nil {
+		t.Fatal("a default above the maximum should be rejected")
+	}
+	if err := pagination.Configure(pagination.Settings{DefaultPageSize: 0, MaxPageSize: 20}); err == nil {
+		t.Fatal("a zero default should be rejected")
+	}
+
+	if err := pagination.Configure(pagination.Settings{DefaultPageSize: 50, MaxPageSize: 500}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, s := pagination.Normalize(1, 0); s != 50 {
+		t.Errorf("default page size = %d, want 50", s)
+	}
+	if _, s := pagination.Normalize(1, 1000); s != 500 {
+		t.Errorf("capped page size = %d, want 500", s)
+	}
+	if _, s := pagination.Normalize(1, 300); s != 300 {
+		t.Errorf("page size under the maximum = %d, want 300", s)
+	}
+}
+
+func TestOffset(t *testing.T) {
+	if got := pagination.Offset(1, 25); got != 0 {
+		t.Errorf("first page offset = %d", got)
+	}
+	if got := pagination.Offset(3, 10); got != 20 {
+		t.Errorf("third page offset = %d", got)
+	}
+	if got := pagination.Offset(2, 1000); got != pagination.MaxPageSize {
+		t.Errorf("offset should follow the capped page size, got %d", got)
+	}
+}
+
+func TestTotalPages(t *testing.T) {
+	cases := map[[2]int]int{
+		{0, 25}:   0,
+		{1, 25}:   1,
+		{25, 25}:  1,
+		{26, 25}:  2,
+		{5, 2}:    3,
+		{250, 0}:  10,
+		{101, 10}: 11,
+	}
+	for in, want := range cases {
+		if got := pagination.TotalPages(in[0], in[1]); got != want {
+			t.Errorf("TotalPages(%d, %d) = %d, want %d", in[0], in[1], got, want)
+		}
+	}
+}
+
+func TestFromRows(t *testing.T) {
+	type row struct {
+		name  string
+		total int64
+	}
+	rows := []row{{"a", 5}, {"b", 5}}
+
+	page := pagination.FromRows(rows,
+		func(r row) int64 { return r.total },
+		func(r row) string { return r.name },
+		1, 2)
+
+	if len(page.Items) != 2 || page.Items[0] != "a" || page.Items[1] != "b" {
+		t.Fatalf("unexpected items %v", page.Items)
+	}
+	if page.TotalCount != 5 || page.TotalPages != 3 || page.PageNumber != 1 || page.PageSize != 2 || !page.HasMore {
+		t.Fatalf("unexpected page %+v", page)
+	}
+
+	last := pagination.FromRows(rows[:1],
+		func(r row) int64 { return r.total },
+		func(r row) string { return r.name },
+		3, 2)
+	if last.HasMore {
+		t.Fatalf("the last page should not report more, got %+v", last)
+	}
+}
+
+func TestEmptyPage(t *testing.T) {
+	// a page past the end carries no window count, so it reads as empty
+	page := pagination.FromRows([]int64{},
+		func(r int64) int64 { return r },
+		func(r int64) int64 { return r },
+		7, 0)
+
+	if page.TotalCount != 0 || page.TotalPages != 0 {
+		t.Fatalf("expected an empty page, got %+v", page)
+	}
+	if page.PageNumber != 7 || page.PageSize != pagination.DefaultPageSize {
+		t.Fatalf("expected the requested page to be echoed back, got %+v", page)
+	}
+
+	body, err := json.Marshal(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(body) != `{"items":[],"totalCount":0,"totalPages":0,"pageNumber":7,"pageSize":25,"hasMore":false}` {
+		t.Fatalf("unexpected encoding %s", body)
+	}
+}
+
+func TestLinks(t *testing.T) {
+	u, _ := url.Parse("/tickets?projectId=p1&pageNumber=2&pageSize=10")
+
+	cases := []struct {
+		name                   string
+		pageNumber, totalPages int
+		want                   string
+	}{
+		{"empty", 1, 0, ""},
+		{"single page", 1, 1, `</tickets?pageNumber=1&pageSize=10&projectId=p1>; rel="first", </tickets?pageNumber=1&pageSize=10&projectId=p1>; rel="last"`},
+		{"middle", 2, 3, `</tickets?pageNumber=1&pageSize=10&projectId=p1>; rel="first", </tickets?pageNumber=1&pageSize=10&projectId=p1>; rel="prev", </tickets?pageNumber=3&pageSize=10&projectId=p1>; rel="next", </tickets?pageNumber=3&pageSize=10&projectId=p1>; rel="last"`},
+		{"past the end", 9, 3, `</tickets?pageNumber=1&pageSize=10&projectId=p1>; rel="first", </tickets?pageNumber=3&pageSize=10&projectId=p1>; rel="prev", </tickets?pageNumber=3&pageSize=10&projectId=p1>; rel="last"`},
+	}
+	for _, c := range cases {
+		if got := pagination.Links(u, c.pageNumber, 10, c.totalPages); got != c.want {
+			t.Errorf("%s:\n got %s\nwant %s", c.name, got, c.want)
+		}
+	}
+}
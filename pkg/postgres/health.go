@@ -0,0 +1,128 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type HealthConfig struct {
+	Interval   time.Duration // how often the pool is pinged
+	Timeout    time.Duration // ping timeout
+	RetryAfter time.Duration // advertised to clients while degraded
+}
+
+// PoolStatus is a snapshot of the pool. Errors are only logged, they name the
+// database host and user.
+type PoolStatus struct {
+	Healthy       bool      `json:"healthy"`
+	CheckedAt     time.Time `json:"checkedAt"`
+	TotalConns    int32     `json:"totalConns"`
+	IdleConns     int32     `json:"idleConns"`
+	AcquiredConns int32     `json:"acquiredConns"`
+	MaxConns      int32     `json:"maxConns"`
+}
+
+// Monitor pings the pool in the background and flips the API into a degraded
+// mode while the database is unreachable. pgxpool re-dials on demand, so once
+// a ping succeeds again requests flow through without a restart.
+type Monitor struct {
+	pool    *pgxpool.Pool
+	cfg     HealthConfig
+	creds   Credentials
+	healthy atomic.Bool
+
+	mu        sync.RWMutex
+	checkedAt time.Time
+}
+
+// NewMonitor watches pool; creds, when not nil, is invalidated whenever a
+// ping fails authentication so the next dial fetches a fresh password
+func NewMonitor(pool *pgxpool.Pool, cfg HealthConfig, creds Credentials) *Monitor {
+	m := &Monitor{pool: pool, cfg: cfg, creds: creds}
+	m.healthy.Store(true)
+	return m
+}
+
+func (m *Monitor) Start(ctx context.Context) {
+	slog.Info("[Database]: starting pool health monitor", "interval", m.cfg.Interval.String())
+	ticker := time.NewTicker(m.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		m.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (m *Monitor) check(ctx context.Context) {
+	pingCtx, cancel := context.WithTimeout(ctx, m.cfg.Timeout)
+	defer cancel()
+
+	err := m.pool.Ping(pingCtx)
+	if ctx.Err() != nil {
+		return
+	}
+
+	if m.creds != nil && isAuthFailure(err) {
+		slog.Warn("[Database]: password refused, fetching it again", "error", err)
+		m.creds.Invalidate()
+	}
+
+	m.mu.Lock()
+	m.checkedAt = time.Now()
+	m.mu.Unlock()
+
+	wasHealthy := m.healthy.Swap(err == nil)
+	switch {
+	case err != nil && wasHealthy:
+		slog.Error("[Database]: connection lost, entering degraded mode", "error", err)
+		// drop every pooled connection so the next acquire dials a fresh one
+		m.pool.Reset()
+	case err != nil:
+		slog.Warn("[Database]: still unreachable", "error", err)
+	case err == nil && !wasHealthy:
+		slog.Info("[Database]: connection restored")
+	}
+}
+
+// isAuthFailure reports a refused login: invalid_password or
+// invalid_authorization_specification
+func isAuthFailure(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && (pgErr.Code == "28P01" || pgErr.Code == "28000")
+}
+
+func (m *Monitor) Healthy() bool {
+	return m.healthy.Load()
+}
+
+func (m *Monitor) Status() PoolStatus {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	stat := m.pool.Stat()
+	return PoolStatus{
+		Healthy:       m.Healthy(),
+		CheckedAt:     m.checkedAt,
+		TotalConns:    stat.TotalConns(),
+		IdleConns:     stat.IdleConns(),
+		AcquiredConns: stat.AcquiredConns(),
+		MaxConns:      stat.MaxConns(),
+	}
+}
+
+// RetryAfter is how long clients are told to wait while the pool is degraded
+func (m *Monitor) RetryAfter() time.Duration {
+	return m.cfg.RetryAfter
+}
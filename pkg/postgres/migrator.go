@@ -1,44 +1,217 @@
 package postgres
 
 import (
+	"context"
 	"errors"
+	"fmt"
+	"io/fs"
 	"log/slog"
+	"net/url"
 	"os"
-	"path/filepath"
 
+	"github.com/dimasbaguspm/fluxis/migrations"
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/postgres"
-	_ "github.com/golang-migrate/migrate/v4/source/file"
+	"github.com/golang-migrate/migrate/v4/source/iofs"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-func RunMigration(cfg Config) {
-	slog.Info("[Migrator]: trying to migrate tables into DB")
+// MigrationPhase is a set of migrations applied at one point of a rolling
+// deploy, see migrations/contract/README.md
+type MigrationPhase string
 
-	exe, err := os.Executable()
-	if err != nil {
-		slog.Error("[Migrator]: failed to resolve executable path", "error", err)
-		os.Exit(1)
+const (
+	// MigrationExpand holds additive changes the running release copes with,
+	// applied before the next release rolls out
+	MigrationExpand MigrationPhase = "expand"
+	// MigrationContract removes what only the previous release needed,
+	// applied once none of its replicas is left
+	MigrationContract MigrationPhase = "contract"
+)
+
+// MigrationPhases lists every phase in the order they apply
+var MigrationPhases = []MigrationPhase{MigrationExpand, MigrationContract}
+
+// migrationSets maps a phase to its directory in migrations.FS and the table
+// recording its version
+var migrationSets = map[MigrationPhase]struct {
+	dir   string
+	table string
+}{
+	MigrationExpand:   {".", "schema_migrations"},
+	MigrationContract: {"contract", "schema_migrations_contract"},
+}
+
+// MigrationConfig is read from the command line
+type MigrationConfig struct {
+	Phases []MigrationPhase // applied in order on start, none skips migrating
+	Only   bool             // exit once migrated, for a deploy step
+}
+
+func RunMigration(cfg Config, phases []MigrationPhase) {
+	for _, phase := range phases {
+		if err := runMigrationPhase(cfg, phase); err != nil {
+			slog.Error("[Migrator]: unable to migrate the db", "phase", phase, "error", err)
+			os.Exit(1)
+		}
 	}
-	migrationsPath := filepath.Clean(filepath.Join(filepath.Dir(exe), "..", "migrations"))
+}
+
+func runMigrationPhase(cfg Config, phase MigrationPhase) error {
+	slog.Info("[Migrator]: trying to migrate tables into DB", "phase", phase)
 
-	m, err := migrate.New("file://"+migrationsPath, cfg.Primary)
+	available, err := embeddedMigrations(phase)
+	if err != nil {
+		return err
+	}
+	if len(available) == 0 {
+		slog.Info("[Migrator]: no migrations in this phase", "phase", phase)
+		return nil
+	}
 
+	m, err := newMigrate(cfg, phase)
 	if err != nil {
-		slog.Error("[Migrator]: migration failed something odd while lookup the migrations file", "error", err)
-		os.Exit(1)
+		return err
+	}
+	defer m.Close()
+
+	if phase == MigrationContract {
+		// contract migrations assume the expand set is complete
+		if err := requireExpandApplied(cfg); err != nil {
+			return err
+		}
 	}
 
 	err = m.Up()
+	if errors.Is(err, migrate.ErrNoChange) {
+		slog.Info("[Migrator]: migration success without no change!", "phase", phase)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	slog.Info("[Migrator]: success to migrate the latest version!", "phase", phase)
+	return nil
+}
+
+func newMigrate(cfg Config, phase MigrationPhase) (*migrate.Migrate, error) {
+	set := migrationSets[phase]
+	src, err := iofs.New(migrations.FS, set.dir)
+	if err != nil {
+		return nil, fmt.Errorf("open %s migrations: %w", phase, err)
+	}
+
+	dsn, err := url.Parse(cfg.Primary)
+	if err != nil {
+		return nil, fmt.Errorf("parse database url: %w", err)
+	}
+	q := dsn.Query()
+	q.Set("x-migrations-table", set.table)
+	dsn.RawQuery = q.Encode()
 
+	m, err := migrate.NewWithSourceInstance("iofs", src, dsn.String())
+	if err != nil {
+		return nil, fmt.Errorf("open %s migrator: %w", phase, err)
+	}
+	return m, nil
+}
+
+func requireExpandApplied(cfg Config) error {
+	available, err := embeddedMigrations(MigrationExpand)
+	if err != nil {
+		return err
+	}
+	m, err := newMigrate(cfg, MigrationExpand)
 	if err != nil {
-		if errors.Is(err, migrate.ErrNoChange) {
-			slog.Info("[Migrator]: migration success without no change!")
-			return
+		return err
+	}
+	defer m.Close()
+
+	version, dirty, err := m.Version()
+	if err != nil && !errors.Is(err, migrate.ErrNilVersion) {
+		return fmt.Errorf("read expand version: %w", err)
+	}
+	if latest := available[len(available)-1].Version; dirty || version < latest {
+		return fmt.Errorf("expand migrations are pending (at %d of %d, dirty %t), apply them first", version, latest, dirty)
+	}
+	return nil
+}
+
+// Migration is one up migration compiled into the binary
+type Migration struct {
+	Version uint
+	Name    string
+}
+
+// MigrationState compares the version the migrator recorded for a phase with
+// the migrations of that phase compiled into the binary. Version is 0 before
+// the first run; Dirty means the migration at Version failed halfway and
+// needs fixing by hand before the migrator runs again.
+type MigrationState struct {
+	Phase   MigrationPhase
+	Version uint
+	Dirty   bool
+	Latest  uint
+	Pending []Migration
+}
+
+func ReadMigrationState(ctx context.Context, db *pgxpool.Pool, phase MigrationPhase) (MigrationState, error) {
+	var (
+		state   = MigrationState{Phase: phase}
+		version int64
+	)
+	// the table name comes from migrationSets, never from input
+	err := db.QueryRow(ctx, "SELECT version, dirty FROM "+migrationSets[phase].table+" LIMIT 1").Scan(&version, &state.Dirty)
+	var pgErr *pgconn.PgError
+	switch {
+	case errors.Is(err, pgx.ErrNoRows):
+	case errors.As(err, &pgErr) && pgErr.Code == "42P01":
+		// undefined_table: the migrator never ran this phase here
+	case err != nil:
+		return MigrationState{}, fmt.Errorf("read %s schema version: %w", phase, err)
+	default:
+		state.Version = uint(version)
+	}
+
+	available, err := embeddedMigrations(phase)
+	if err != nil {
+		return MigrationState{}, err
+	}
+	state.Pending = []Migration{}
+	for _, m := range available {
+		state.Latest = m.Version
+		if m.Version > state.Version {
+			state.Pending = append(state.Pending, m)
 		}
+	}
+	return state, nil
+}
 
-		slog.Error("[Migrator]: unable to migrate the db", "error", err)
-		os.Exit(1)
+// embeddedMigrations lists the compiled in migrations of a phase in version
+// order
+func embeddedMigrations(phase MigrationPhase) ([]Migration, error) {
+	src, err := iofs.New(migrations.FS, migrationSets[phase].dir)
+	if err != nil {
+		return nil, fmt.Errorf("open %s migrations: %w", phase, err)
 	}
+	defer src.Close()
 
-	slog.Info("[Migrator]: success to migrate the latest version!")
+	var list []Migration
+	version, err := src.First()
+	for err == nil {
+		r, name, readErr := src.ReadUp(version)
+		if readErr != nil {
+			return nil, fmt.Errorf("read %s migration %d: %w", phase, version, readErr)
+		}
+		r.Close()
+		list = append(list, Migration{Version: version, Name: name})
+		version, err = src.Next(version)
+	}
+	if !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("list %s migrations: %w", phase, err)
+	}
+	return list, nil
 }
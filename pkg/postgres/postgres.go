@@ -5,22 +5,70 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Credentials supplies the database password at dial time, so a password
+// rotated in a secret manager reaches new connections without a restart.
+// Invalidate is called once the server refuses the current one.
+type Credentials interface {
+	Password(ctx context.Context) (string, error)
+	Invalidate()
+}
+
 type Config struct {
-	Primary  string
-	MaxConns int
-	MinConns int
+	Primary      string
+	MaxConns     int
+	MinConns     int
+	QueryTimeout time.Duration // per statement deadline, zero disables it
+	Health       HealthConfig
+	Tracer       TracerConfig
+	Credentials  Credentials // optional, overrides the password in Primary
+	Connect      ConnectConfig
+}
+
+// ConnectConfig is how long startup waits for the database, e.g. while a
+// compose stack brings Postgres up next to the API
+type ConnectConfig struct {
+	MaxWait        time.Duration // zero tries once
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
 }
 
 func MustConnect(ctx context.Context, cfg Config) *pgxpool.Pool {
 	slog.Info("[Database]: Attempting to connect the database")
 
 	config, err := pgxpool.ParseConfig(cfg.Primary)
+	if err != nil {
+		slog.Error(fmt.Sprintf("[Database]: Unable to parse the database url, %v", err))
+		os.Exit(1)
+		return nil
+	}
 	config.MinConns = int32(cfg.MinConns)
 	config.MaxConns = int32(cfg.MaxConns)
+	if cfg.Health.Interval > 0 {
+		config.HealthCheckPeriod = cfg.Health.Interval
+	}
+
+	if cfg.Credentials != nil {
+		config.BeforeConnect = func(ctx context.Context, cc *pgx.ConnConfig) error {
+			password, err := cfg.Credentials.Password(ctx)
+			if err != nil {
+				return fmt.Errorf("database password: %w", err)
+			}
+			cc.Password = password
+			return nil
+		}
+	}
+
+	var tracer *SlowQueryTracer
+	if cfg.Tracer.SlowQuery > 0 {
+		tracer = NewSlowQueryTracer(cfg.Tracer)
+		config.ConnConfig.Tracer = tracer
+	}
 
 	conn, err := pgxpool.NewWithConfig(ctx, config)
 	if err != nil {
@@ -29,6 +77,55 @@ func MustConnect(ctx context.Context, cfg Config) *pgxpool.Pool {
 		return nil
 	}
 
+	if err := waitReady(ctx, conn, cfg); err != nil {
+		slog.Error(fmt.Sprintf("[Database]: Unable to connect with db, %v", err))
+		conn.Close()
+		os.Exit(1)
+		return nil
+	}
+
+	if tracer != nil {
+		tracer.attach(conn)
+	}
+
 	slog.Info("[Database]: Connection established")
 	return conn
 }
+
+// pinger is what waitReady needs of the pool
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// waitReady pings until the database answers, doubling the pause between
+// attempts up to MaxBackoff. A refused login is not retried, waiting does
+// not fix a wrong password.
+func waitReady(ctx context.Context, pool pinger, cfg Config) error {
+	deadline := time.Now().Add(cfg.Connect.MaxWait)
+	backoff := max(cfg.Connect.InitialBackoff, 100*time.Millisecond)
+	timeout := cfg.Health.Timeout
+	if timeout <= 0 {
+		timeout = 5 * time.Second
+	}
+	for attempt := 1; ; attempt++ {
+		pingCtx, cancel := context.WithTimeout(ctx, timeout)
+		err := pool.Ping(pingCtx)
+		cancel()
+		if err == nil || isAuthFailure(err) {
+			return err
+		}
+		left := time.Until(deadline)
+		if left <= 0 {
+			return fmt.Errorf("gave up after %d attempts: %w", attempt, err)
+		}
+
+		pause := min(backoff, left)
+		slog.Warn("[Database]: Database not ready, retrying", "attempt", attempt, "retryIn", pause.String(), "error", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(pause):
+		}
+		backoff = min(backoff*2, max(cfg.Connect.MaxBackoff, backoff))
+	}
+}
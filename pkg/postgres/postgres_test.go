@@ -0,0 +1,103 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+var errRefused = errors.New("dial tcp 127.0.0.1:5432: connect: connection refused")
+
+// fakePinger answers each ping with the next queued error, nil once they run
+// out, and records when it was pinged
+type fakePinger struct {
+	errs  []error
+	pings []time.Time
+}
+
+func (p *fakePinger) Ping(ctx context.Context) error {
+	p.pings = append(p.pings, time.Now())
+	if _, ok := ctx.Deadline(); !ok {
+		return errors.New("ping without a timeout")
+	}
+	if len(p.errs) == 0 {
+		return nil
+	}
+	err := p.errs[0]
+	p.errs = p.errs[1:]
+	return err
+}
+
+func TestWaitReady(t *testing.T) {
+	badPassword := &pgconn.PgError{Code: "28P01", Message: "password authentication failed"}
+	retrying := ConnectConfig{MaxWait: time.Second, InitialBackoff: 10 * time.Millisecond, MaxBackoff: 20 * time.Millisecond}
+
+	tests := []struct {
+		name      string
+		connect   ConnectConfig
+		errs      []error
+		wantPings int
+		wantErr   string
+	}{
+		{"ready", retrying, nil, 1, ""},
+		{"ready after retries", retrying, []error{errRefused, errRefused, errRefused}, 4, ""},
+		{"no wait tries once", ConnectConfig{}, []error{errRefused}, 1, "gave up after 1 attempts"},
+		{"wrong password not retried", retrying, []error{badPassword}, 1, "28P01"},
+		{"gives up at max wait", ConnectConfig{MaxWait: 250 * time.Millisecond, MaxBackoff: 100 * time.Millisecond}, []error{errRefused, errRefused, errRefused, errRefused, errRefused}, 4, "gave up after 4 attempts"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &fakePinger{errs: tt.errs}
+			err := waitReady(context.Background(), p, Config{Connect: tt.connect})
+
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("waitReady = %v, want nil", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Fatalf("waitReady = %v, want an error containing %q", err, tt.wantErr)
+			}
+			if len(p.pings) != tt.wantPings {
+				t.Fatalf("got %d pings, want %d", len(p.pings), tt.wantPings)
+			}
+		})
+	}
+}
+
+func TestWaitReady_BackoffDoublesUpToMax(t *testing.T) {
+	p := &fakePinger{errs: []error{errRefused, errRefused, errRefused, errRefused}}
+	cfg := Config{Connect: ConnectConfig{MaxWait: time.Second, InitialBackoff: 100 * time.Millisecond, MaxBackoff: 200 * time.Millisecond}}
+
+	if err := waitReady(context.Background(), p, cfg); err != nil {
+		t.Fatalf("waitReady = %v, want nil", err)
+	}
+
+	// the pauses are at least the backoff, and the later ones stay well
+	// under the 400ms and 800ms they would reach without the cap
+	want := []time.Duration{100 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond, 200 * time.Millisecond}
+	for i, w := range want {
+		got := p.pings[i+1].Sub(p.pings[i])
+		if got < w || i >= 2 && got >= 400*time.Millisecond {
+			t.Errorf("pause %d = %v, want about %v", i+1, got, w)
+		}
+	}
+}
+
+func TestWaitReady_StopsWhenContextEnds(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	p := &fakePinger{errs: []error{errRefused, errRefused, errRefused}}
+	cfg := Config{Connect: ConnectConfig{MaxWait: time.Minute, InitialBackoff: time.Second}}
+
+	start := time.Now()
+	if err := waitReady(ctx, p, cfg); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("waitReady = %v, want the context's error", err)
+	}
+	if elapsed := time.Since(start); elapsed > 500*time.Millisecond {
+		t.Fatalf("waitReady took %v after the context ended", elapsed)
+	}
+}
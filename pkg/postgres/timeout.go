@@ -0,0 +1,72 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// DBTX mirrors the interface sqlc generates in every repository package
+type DBTX interface {
+	Exec(context.Context, string, ...interface{}) (pgconn.CommandTag, error)
+	Query(context.Context, string, ...interface{}) (pgx.Rows, error)
+	QueryRow(context.Context, string, ...interface{}) pgx.Row
+}
+
+// WithQueryTimeout bounds every statement issued through db, so a slow search
+// cannot hold the handler open. A non-positive timeout returns db untouched.
+func WithQueryTimeout(db DBTX, timeout time.Duration) DBTX {
+	if timeout <= 0 {
+		return db
+	}
+	return &timeoutDB{db: db, timeout: timeout}
+}
+
+type timeoutDB struct {
+	db      DBTX
+	timeout time.Duration
+}
+
+func (t *timeoutDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	defer cancel()
+	return t.db.Exec(ctx, sql, args...)
+}
+
+func (t *timeoutDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	rows, err := t.db.Query(ctx, sql, args...)
+	if err != nil {
+		cancel()
+		return nil, err
+	}
+	// the deadline has to outlive Query, rows are read after it returns
+	return &timeoutRows{Rows: rows, cancel: cancel}, nil
+}
+
+func (t *timeoutDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	ctx, cancel := context.WithTimeout(ctx, t.timeout)
+	return &timeoutRow{row: t.db.QueryRow(ctx, sql, args...), cancel: cancel}
+}
+
+type timeoutRows struct {
+	pgx.Rows
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRows) Close() {
+	r.Rows.Close()
+	r.cancel()
+}
+
+type timeoutRow struct {
+	row    pgx.Row
+	cancel context.CancelFunc
+}
+
+func (r *timeoutRow) Scan(dest ...any) error {
+	defer r.cancel()
+	return r.row.Scan(dest...)
+}
@@ -0,0 +1,133 @@
+package postgres_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeDB records the context of the last statement; rows and rows from
+// QueryRow report their context's state when they are read
+type fakeDB struct {
+	ctx      context.Context
+	queryErr error
+}
+
+func (db *fakeDB) Exec(ctx context.Context, sql string, args ...interface{}) (pgconn.CommandTag, error) {
+	db.ctx = ctx
+	return pgconn.NewCommandTag("UPDATE 1"), nil
+}
+
+func (db *fakeDB) Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error) {
+	db.ctx = ctx
+	if db.queryErr != nil {
+		return nil, db.queryErr
+	}
+	return &fakeRows{ctx: ctx}, nil
+}
+
+func (db *fakeDB) QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row {
+	db.ctx = ctx
+	return fakeRow{ctx: ctx}
+}
+
+type fakeRows struct {
+	pgx.Rows
+	ctx context.Context
+}
+
+func (r *fakeRows) Next() bool { return r.ctx.Err() == nil }
+func (r *fakeRows) Close()     {}
+
+type fakeRow struct{ ctx context.Context }
+
+func (r fakeRow) Scan(dest ...any) error { return r.ctx.Err() }
+
+func TestWithQueryTimeout(t *testing.T) {
+	const timeout = time.Minute
+
+	tests := []struct {
+		name string
+		// run issues one statement through db and reports whether its
+		// context was still live while the result was read
+		run func(db postgres.DBTX) (readable bool, err error)
+	}{
+		{"exec", func(db postgres.DBTX) (bool, error) {
+			_, err := db.Exec(context.Background(), "UPDATE t SET a = 1")
+			return true, err
+		}},
+		{"query", func(db postgres.DBTX) (bool, error) {
+			rows, err := db.Query(context.Background(), "SELECT 1")
+			if err != nil {
+				return false, err
+			}
+			defer rows.Close()
+			return rows.Next(), nil
+		}},
+		{"query row", func(db postgres.DBTX) (bool, error) {
+			err := db.QueryRow(context.Background(), "SELECT 1").Scan()
+			return err == nil, nil
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inner := &fakeDB{}
+			start := time.Now()
+
+			readable, err := tt.run(postgres.WithQueryTimeout(inner, timeout))
+			if err != nil {
+				t.Fatalf("statement failed: %v", err)
+			}
+			if !readable {
+				t.Fatal("the deadline ended before the result was read")
+			}
+			deadline, ok := inner.ctx.Deadline()
+			if !ok || deadline.Before(start.Add(timeout)) || deadline.After(time.Now().Add(timeout)) {
+				t.Fatalf("deadline = %v (set %v), want %v from the call", deadline, ok, timeout)
+			}
+			if inner.ctx.Err() == nil {
+				t.Fatal("the statement's context was not released once it was read")
+			}
+		})
+	}
+}
+
+func TestWithQueryTimeout_QueryErrorReleasesContext(t *testing.T) {
+	inner := &fakeDB{queryErr: errors.New("syntax error")}
+
+	if _, err := postgres.WithQueryTimeout(inner, time.Minute).Query(context.Background(), "SELEC 1"); err == nil {
+		t.Fatal("Query = nil, want the error")
+	}
+	if inner.ctx.Err() == nil {
+		t.Fatal("the context of a failed query was not released")
+	}
+}
+
+func TestWithQueryTimeout_KeepsEarlierDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	want, _ := ctx.Deadline()
+	inner := &fakeDB{}
+
+	if _, err := postgres.WithQueryTimeout(inner, time.Hour).Exec(ctx, "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := inner.ctx.Deadline(); !got.Equal(want) {
+		t.Fatalf("deadline = %v, want the caller's %v", got, want)
+	}
+}
+
+func TestWithQueryTimeout_Disabled(t *testing.T) {
+	for _, timeout := range []time.Duration{0, -time.Second} {
+		inner := &fakeDB{}
+		if db := postgres.WithQueryTimeout(inner, timeout); db != postgres.DBTX(inner) {
+			t.Errorf("timeout %v wrapped the db, want it untouched", timeout)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"math/rand/v2"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type TracerConfig struct {
+	SlowQuery         time.Duration // statements at or above this are logged, zero disables the tracer
+	ExplainSampleRate float64       // share of slow statements re-run through EXPLAIN, 0..1
+}
+
+type traceKey struct{}
+
+// explainKey marks the sampling statement itself so it is never traced
+type explainKey struct{}
+
+type traceStart struct {
+	at   time.Time
+	sql  string
+	args []any
+}
+
+// SlowQueryTracer logs statements slower than the configured threshold. Bound
+// parameters are never logged, only their count, since they carry user data.
+type SlowQueryTracer struct {
+	cfg  TracerConfig
+	pool *pgxpool.Pool
+}
+
+func NewSlowQueryTracer(cfg TracerConfig) *SlowQueryTracer {
+	return &SlowQueryTracer{cfg: cfg}
+}
+
+// attach hands the tracer the pool used for EXPLAIN sampling, the pool does
+// not exist yet when the tracer is placed on its config
+func (t *SlowQueryTracer) attach(pool *pgxpool.Pool) {
+	t.pool = pool
+}
+
+func (t *SlowQueryTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryStartData) context.Context {
+	if ctx.Value(explainKey{}) != nil {
+		return ctx
+	}
+	return context.WithValue(ctx, traceKey{}, traceStart{at: time.Now(), sql: data.SQL, args: data.Args})
+}
+
+func (t *SlowQueryTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, data pgx.TraceQueryEndData) {
+	start, ok := ctx.Value(traceKey{}).(traceStart)
+	if !ok {
+		return
+	}
+
+	elapsed := time.Since(start.at)
+	if elapsed < t.cfg.SlowQuery {
+		return
+	}
+
+	// the caller went away mid statement, the plan is not worth sampling
+	if errors.Is(data.Err, context.Canceled) {
+		slog.Info("[Database]: slow query canceled by caller",
+			"duration", elapsed.String(),
+			"sql", compactSQL(start.sql),
+		)
+		return
+	}
+
+	slog.Warn("[Database]: slow query",
+		"duration", elapsed.String(),
+		"sql", compactSQL(start.sql),
+		"args", len(start.args),
+		"rows", data.CommandTag.RowsAffected(),
+		"error", data.Err,
+	)
+
+	if t.pool != nil && t.cfg.ExplainSampleRate > 0 && rand.Float64() < t.cfg.ExplainSampleRate {
+		go t.explain(start.sql, start.args)
+	}
+}
+
+// explain runs a plain EXPLAIN (never ANALYZE) so sampled statements are
+// planned but not executed a second time
+func (t *SlowQueryTracer) explain(sql string, args []any) {
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), explainKey{}, true), 5*time.Second)
+	defer cancel()
+
+	rows, err := t.pool.Query(ctx, "EXPLAIN "+sql, args...)
+	if err != nil {
+		slog.Warn("[Database]: explain failed", "error", err)
+		return
+	}
+	defer rows.Close()
+
+	var plan []string
+	for rows.Next() {
+		var line string
+		if err := rows.Scan(&line); err != nil {
+			slog.Warn("[Database]: explain failed", "error", err)
+			return
+		}
+		plan = append(plan, line)
+	}
+
+	slog.Info("[Database]: slow query plan", "sql", compactSQL(sql), "plan", strings.Join(plan, "\n"))
+}
+
+// compactSQL folds whitespace so a statement fits on one log line
+func compactSQL(sql string) string {
+	return strings.Join(strings.Fields(sql), " ")
+}
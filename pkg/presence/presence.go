@@ -0,0 +1,148 @@
+package presence
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Config controls how long a viewer stays listed without a heartbeat
+type Config struct {
+	TTL time.Duration
+}
+
+// Viewer is one member of a room. Detail is free for the caller, e.g. the
+// board the viewer has open.
+type Viewer struct {
+	Member     string
+	Detail     string
+	Since      time.Time
+	LastSeenAt time.Time
+}
+
+// Tracker keeps ephemeral viewer lists per room in memory. Nothing survives a
+// restart and every instance holds its own lists, which is fine for awareness
+// hints that clients refresh every few seconds anyway.
+type Tracker struct {
+	mu    sync.Mutex
+	rooms map[string]map[string]*Viewer
+	cfg   Config
+	now   func() time.Time
+	done  chan struct{}
+}
+
+func New(cfg Config) *Tracker {
+	if cfg.TTL <= 0 {
+		cfg.TTL = 30 * time.Second
+	}
+	t := &Tracker{
+		rooms: make(map[string]map[string]*Viewer),
+		cfg:   cfg,
+		now:   time.Now,
+		done:  make(chan struct{}),
+	}
+	go t.sweepLoop()
+	return t
+}
+
+func (t *Tracker) GetConfig() Config {
+	return t.cfg
+}
+
+// Touch records a heartbeat from member in room, adding it when it was not
+// listed yet or had expired
+func (t *Tracker) Touch(room, member, detail string) Viewer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	viewers, ok := t.rooms[room]
+	if !ok {
+		viewers = make(map[string]*Viewer)
+		t.rooms[room] = viewers
+	}
+	v, ok := viewers[member]
+	if !ok || t.expired(v, now) {
+		v = &Viewer{Member: member, Since: now}
+		viewers[member] = v
+	}
+	v.Detail = detail
+	v.LastSeenAt = now
+	return *v
+}
+
+// Leave drops member from room right away instead of waiting for the TTL
+func (t *Tracker) Leave(room, member string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	viewers, ok := t.rooms[room]
+	if !ok {
+		return
+	}
+	delete(viewers, member)
+	if len(viewers) == 0 {
+		delete(t.rooms, room)
+	}
+}
+
+// List returns the live viewers of room, longest present first
+func (t *Tracker) List(room string) []Viewer {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	out := make([]Viewer, 0, len(t.rooms[room]))
+	for _, v := range t.rooms[room] {
+		if !t.expired(v, now) {
+			out = append(out, *v)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].Since.Equal(out[j].Since) {
+			return out[i].Member < out[j].Member
+		}
+		return out[i].Since.Before(out[j].Since)
+	})
+	return out
+}
+
+func (t *Tracker) Close() error {
+	close(t.done)
+	return nil
+}
+
+func (t *Tracker) expired(v *Viewer, now time.Time) bool {
+	return now.Sub(v.LastSeenAt) > t.cfg.TTL
+}
+
+func (t *Tracker) sweepLoop() {
+	ticker := time.NewTicker(t.cfg.TTL)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			t.sweep()
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *Tracker) sweep() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for room, viewers := range t.rooms {
+		for member, v := range viewers {
+			if t.expired(v, now) {
+				delete(viewers, member)
+			}
+		}
+		if len(viewers) == 0 {
+			delete(t.rooms, room)
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package presence
+
+import (
+	"testing"
+	"time"
+)
+
+func newTestTracker(ttl time.Duration) (*Tracker, *time.Time) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	// built without the sweep loop so the test drives the clock alone
+	tr := &Tracker{
+		rooms: make(map[string]map[string]*Viewer),
+		cfg:   Config{TTL: ttl},
+		now:   func() time.Time { return now },
+	}
+	return tr, &now
+}
+
+func TestTracker_TouchAndExpire(t *testing.T) {
+	tr, now := newTestTracker(30 * time.Second)
+
+	tr.Touch("p1", "alice", "board-a")
+	*now = now.Add(10 * time.Second)
+	tr.Touch("p1", "bob", "")
+	tr.Touch("p2", "carol", "")
+
+	got := tr.List("p1")
+	if len(got) != 2 || got[0].Member != "alice" || got[1].Member != "bob" {
+		t.Fatalf("expected alice then bob, got %+v", got)
+	}
+	if got[0].Detail != "board-a" {
+		t.Fatalf("expected alice on board-a, got %q", got[0].Detail)
+	}
+
+	*now = now.Add(25 * time.Second)
+	got = tr.List("p1")
+	if len(got) != 1 || got[0].Member != "bob" {
+		t.Fatalf("expected alice to have expired, got %+v", got)
+	}
+
+	// a heartbeat after expiry starts a fresh visit
+	v := tr.Touch("p1", "alice", "")
+	if !v.Since.Equal(*now) {
+		t.Fatalf("expected a new visit, since %v", v.Since)
+	}
+}
+
+func TestTracker_Leave(t *testing.T) {
+	tr, _ := newTestTracker(time.Minute)
+
+	tr.Touch("p1", "alice", "")
+	tr.Leave("p1", "alice")
+	tr.Leave("p1", "nobody")
+
+	if got := tr.List("p1"); len(got) != 0 {
+		t.Fatalf("expected an empty room, got %+v", got)
+	}
+}
+
+func TestTracker_Sweep(t *testing.T) {
+	tr, now := newTestTracker(time.Minute)
+
+	tr.Touch("p1", "alice", "")
+	*now = now.Add(2 * time.Minute)
+	tr.sweep()
+
+	tr.mu.Lock()
+	defer tr.mu.Unlock()
+	if len(tr.rooms) != 0 {
+		t.Fatalf("expected expired rooms to be dropped, got %d", len(tr.rooms))
+	}
+}
@@ -4,10 +4,50 @@ import (
 	"context"
 	"log/slog"
 	"sync"
+
+	"github.com/dimasbaguspm/fluxis/pkg/metrics"
 )
 
 const subscriberBufSize = 64
 
+var (
+	eventsDelivered = metrics.Default.NewCounter(metrics.Desc{
+		Name:   "fluxis_events_delivered_total",
+		Help:   "Events queued to a bus subscriber, by channel.",
+		Labels: []string{"channel"},
+	})
+	eventsDropped = metrics.Default.NewCounter(metrics.Desc{
+		Name:   "fluxis_events_dropped_total",
+		Help:   "Events dropped because a subscriber's queue was full, by channel. Dropped events never reach caches, notifications or waiting change feeds.",
+		Labels: []string{"channel"},
+		Rules: []metrics.Rule{
+			{
+				Record: "fluxis:events_drop_ratio:rate5m",
+				Expr:   "sum by (channel) (rate(fluxis_events_dropped_total[5m])) / (sum by (channel) (rate(fluxis_events_delivered_total[5m])) + sum by (channel) (rate(fluxis_events_dropped_total[5m])))",
+			},
+			{
+				Alert:   "FluxisEventsDropped",
+				Expr:    "fluxis:events_drop_ratio:rate5m > 0.01",
+				For:     "5m",
+				Summary: "A bus subscriber cannot keep up and drops events",
+			},
+		},
+	})
+	eventHandlerErrors = metrics.Default.NewCounter(metrics.Desc{
+		Name:   "fluxis_event_handler_errors_total",
+		Help:   "Bus subscriber handlers that returned an error, by channel.",
+		Labels: []string{"channel"},
+		Rules: []metrics.Rule{
+			{
+				Alert:   "FluxisEventHandlerErrors",
+				Expr:    "sum by (channel) (rate(fluxis_event_handler_errors_total[5m])) > 0",
+				For:     "15m",
+				Summary: "A bus subscriber keeps failing to handle events",
+			},
+		},
+	})
+)
+
 type memoryBus struct {
 	mu   sync.Mutex
 	subs map[string][]chan Event
@@ -31,7 +71,9 @@ func (b *memoryBus) Publish(_ context.Context, et EventType, payload map[string]
 	for _, sub := range subscribers {
 		select {
 		case sub <- e:
+			eventsDelivered.With(ch).Inc()
 		default:
+			eventsDropped.With(ch).Inc()
 			slog.Warn("[PubSub]: subscriber channel full, dropping event",
 				"channel", ch, "type", string(et))
 		}
@@ -65,6 +107,7 @@ func (b *memoryBus) Subscribe(ctx context.Context, channel string, handler func(
 				return
 			}
 			if err := handler(ctx, e); err != nil {
+				eventHandlerErrors.With(channel).Inc()
 				slog.Error("[PubSub]: subscriber handler error",
 					"channel", channel, "type", string(e.Type), "error", err)
 			}
@@ -74,6 +117,21 @@ func (b *memoryBus) Subscribe(ctx context.Context, channel string, handler func(
 	}
 }
 
+// Pending reports how many events wait in subscriber queues, by channel. A
+// queue that stays near subscriberBufSize belongs to a subscriber that is
+// about to drop events.
+func (b *memoryBus) Pending() map[string]int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	pending := make(map[string]int, len(b.subs))
+	for channel, subs := range b.subs {
+		for _, ch := range subs {
+			pending[channel] += len(ch)
+		}
+	}
+	return pending
+}
+
 func (b *memoryBus) Close() error {
 	b.mu.Lock()
 	defer b.mu.Unlock()
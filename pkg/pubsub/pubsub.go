@@ -70,7 +70,20 @@ const (
 	ProjectCreated           EventType = "project.project.created"
 	ProjectUpdated           EventType = "project.project.updated"
 	ProjectDeleted           EventType = "project.project.deleted"
+	ProjectRestored          EventType = "project.project.restored"
 	ProjectVisibilityUpdated EventType = "project.project.visibility_updated"
+
+	ProjectActivated EventType = "project.project.activated"
+	ProjectPaused    EventType = "project.project.paused"
+	ProjectArchived  EventType = "project.project.archived"
+
+	ProjectPriorityCreated EventType = "project.projectpriority.created"
+	ProjectPriorityUpdated EventType = "project.projectpriority.updated"
+	ProjectPriorityDeleted EventType = "project.projectpriority.deleted"
+
+	ProjectMemberAdded   EventType = "project.projectmember.added"
+	ProjectMemberUpdated EventType = "project.projectmember.updated"
+	ProjectMemberRemoved EventType = "project.projectmember.removed"
 )
 
 const (
@@ -89,15 +102,23 @@ const (
 	BoardColumnCreated   EventType = "board.boardcolumn.created"
 	BoardColumnUpdated   EventType = "board.boardcolumn.updated"
 	BoardColumnDeleted   EventType = "board.boardcolumn.deleted"
+	BoardColumnRestored  EventType = "board.boardcolumn.restored"
 	BoardColumnReordered EventType = "board.boardcolumn.reordered"
+	BoardColumnMerged    EventType = "board.boardcolumn.merged"
 )
 
 const (
-	TicketCreated EventType = "ticket.ticket.created"
-	TicketUpdated EventType = "ticket.ticket.updated"
-	TicketDeleted EventType = "ticket.ticket.deleted"
+	TicketCreated  EventType = "ticket.ticket.created"
+	TicketUpdated  EventType = "ticket.ticket.updated"
+	TicketDeleted  EventType = "ticket.ticket.deleted"
+	TicketRestored EventType = "ticket.ticket.restored"
+
+	TicketsBulkDeleted EventType = "ticket.ticket.bulk_deleted"
+	TicketsStale       EventType = "ticket.ticket.stale"
 
 	TicketMovedToBoard       EventType = "ticket.ticket.moved_to_board"
 	TicketMovedToBoardColumn EventType = "ticket.ticket.moved_to_board_column"
 	TicketMovedToSprint      EventType = "ticket.ticket.moved_to_sprint"
+	TicketMovedToProject     EventType = "ticket.ticket.moved_to_project"
+	TicketReordered          EventType = "ticket.ticket.reordered"
 )
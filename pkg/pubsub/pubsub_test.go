@@ -247,3 +247,35 @@ func TestMemory_Subscribe_IsolatedByChannel(t *testing.T) {
 		t.Error("board subscriber should not have received event")
 	}
 }
+
+func TestMemory_Pending_CountsQueuedEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	bus := pubsub.New()
+	defer bus.Close()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	go bus.Subscribe(ctx, pubsub.Channel(pubsub.Ticket), func(context.Context, pubsub.Event) error {
+		select {
+		case started <- struct{}{}:
+		default:
+		}
+		<-release
+		return nil
+	})
+	time.Sleep(10 * time.Millisecond) // let the subscription register
+
+	for range 3 {
+		if err := bus.Publish(ctx, pubsub.TicketCreated, nil); err != nil {
+			t.Fatalf("Publish: %v", err)
+		}
+	}
+	<-started
+
+	pending := bus.(interface{ Pending() map[string]int }).Pending()
+	if got := pending["events:ticket"]; got != 2 {
+		t.Fatalf("Pending = %d, want 2 while the handler holds the first event", got)
+	}
+	close(release)
+}
@@ -0,0 +1,95 @@
+// Package rank generates lexicographic sort keys so an item can be placed
+// between two neighbours without rewriting either of them.
+//
+// A rank is read as a base-36 fraction: "i" sits halfway between "" and the
+// end, "0i" sits before it, "ir" after it. Generated ranks never end in '0',
+// which keeps a slot open in front of every key.
+package rank
+
+import (
+	"errors"
+	"strings"
+)
+
+const alphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(alphabet)
+
+// MaxLength caps how long a rank may grow before the caller has to rebalance
+// its siblings
+const MaxLength = 32
+
+var (
+	ErrExhausted = errors.New("rank: no room left between neighbours")
+	ErrInvalid   = errors.New("rank: invalid neighbours")
+)
+
+// Between returns a rank strictly after prev and strictly before next. An
+// empty prev means the start of the list and an empty next means the end.
+func Between(prev, next string) (string, error) {
+	if !valid(prev) || !valid(next) || (next != "" && prev >= next) {
+		return "", ErrInvalid
+	}
+
+	r, err := midpoint(prev, next)
+	if err != nil {
+		return "", err
+	}
+	if len(r) > MaxLength {
+		return "", ErrExhausted
+	}
+	return r, nil
+}
+
+func midpoint(prev, next string) (string, error) {
+	var b strings.Builder
+	for n := 0; ; n++ {
+		lo := digit(prev, n)
+		hi := base
+		if next != "" {
+			if n >= len(next) {
+				// prev is next padded with zeros, both are the same fraction
+				return "", ErrInvalid
+			}
+			hi = digit(next, n)
+		}
+
+		switch {
+		case lo == hi:
+			b.WriteByte(alphabet[lo])
+		case hi-lo > 1:
+			b.WriteByte(alphabet[(lo+hi)/2])
+			return b.String(), nil
+		default:
+			// adjacent digits, keep lo and find room anywhere after prev's tail
+			b.WriteByte(alphabet[lo])
+			tail := ""
+			if n+1 < len(prev) {
+				tail = prev[n+1:]
+			}
+			rest, err := midpoint(tail, "")
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(rest)
+			return b.String(), nil
+		}
+	}
+}
+
+// digit returns the value of s at n, reading past the end as zero
+func digit(s string, n int) int {
+	if n >= len(s) {
+		return 0
+	}
+	return strings.IndexByte(alphabet, s[n])
+}
+
+func valid(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(alphabet, s[i]) < 0 {
+			return false
+		}
+	}
+	return true
+}
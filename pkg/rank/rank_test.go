@@ -0,0 +1,92 @@
+package rank_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/rank"
+)
+
+func TestBetween_OrdersBetweenNeighbours(t *testing.T) {
+	tests := []struct {
+		prev string
+		next string
+	}{
+		{"", ""},
+		{"", "i"},
+		{"i", ""},
+		{"h", "i"},
+		{"i", "i1"},
+		{"i", "i01"},
+		{"000003i", "000004i"},
+		{"zz", ""},
+		{"", "01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prev+"_"+tt.next, func(t *testing.T) {
+			got, err := rank.Between(tt.prev, tt.next)
+			if err != nil {
+				t.Fatalf("Between(%q, %q) returned error: %v", tt.prev, tt.next, err)
+			}
+			if got <= tt.prev || (tt.next != "" && got >= tt.next) {
+				t.Errorf("Between(%q, %q) = %q, not between neighbours", tt.prev, tt.next, got)
+			}
+			if strings.HasSuffix(got, "0") {
+				t.Errorf("Between(%q, %q) = %q, must not end in 0", tt.prev, tt.next, got)
+			}
+		})
+	}
+}
+
+func TestBetween_RepeatedInsertsStayOrdered(t *testing.T) {
+	// keep inserting right after the first key, the worst case for growth
+	first, err := rank.Between("", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	last, err := rank.Between(first, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := last
+	for i := 0; i < 100; i++ {
+		got, err := rank.Between(first, next)
+		if errors.Is(err, rank.ErrExhausted) {
+			if i < 20 {
+				t.Fatalf("ran out of room after only %d inserts", i)
+			}
+			return
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got <= first || got >= next {
+			t.Fatalf("insert %d: %q is not between %q and %q", i, got, first, next)
+		}
+		next = got
+	}
+}
+
+func TestBetween_RejectsInvalidNeighbours(t *testing.T) {
+	tests := []struct {
+		prev string
+		next string
+	}{
+		{"b", "a"},
+		{"a", "a"},
+		{"a", "a0"},
+		{"A", ""},
+		{"", "a-b"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.prev+"_"+tt.next, func(t *testing.T) {
+			if _, err := rank.Between(tt.prev, tt.next); !errors.Is(err, rank.ErrInvalid) {
+				t.Errorf("Between(%q, %q) error = %v, want ErrInvalid", tt.prev, tt.next, err)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/dimasbaguspm/fluxis/pkg/cache"
@@ -19,26 +20,33 @@ type Config struct {
 	Window      time.Duration
 }
 
+// Middleware counts requests per client IP in fixed windows. Its limits can
+// be replaced at runtime, counts already taken carry over.
 type Middleware struct {
 	c   cache.Cache
 	mu  sync.Mutex
-	cfg Config
+	cfg atomic.Pointer[Config]
 }
 
 func New(cfg Config) *Middleware {
 	c := cache.New(cache.Config{DefaultTTL: cfg.Window * 2})
-	return &Middleware{
-		c:   c,
-		cfg: cfg,
-	}
+	m := &Middleware{c: c}
+	m.cfg.Store(&cfg)
+	return m
+}
+
+// SetConfig replaces the limits for every request that starts afterwards
+func (m *Middleware) SetConfig(cfg Config) {
+	m.cfg.Store(&cfg)
 }
 
 func (m *Middleware) Wrap(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		cfg := m.cfg.Load()
 		ip := m.clientIP(r)
 		now := time.Now()
-		bucket := now.Truncate(m.cfg.Window).Unix()
-		ttl := time.Until(now.Truncate(m.cfg.Window).Add(m.cfg.Window))
+		bucket := now.Truncate(cfg.Window).Unix()
+		ttl := time.Until(now.Truncate(cfg.Window).Add(cfg.Window))
 
 		key := m.rateLimitKey(ip, bucket)
 
@@ -48,7 +56,7 @@ func (m *Middleware) Wrap(next http.Handler) http.Handler {
 		m.setCount(r.Context(), key, count, ttl)
 		m.mu.Unlock()
 
-		if count > uint32(m.cfg.MaxRequests) {
+		if count > uint32(cfg.MaxRequests) {
 			w.Header().Set("Retry-After", strconv.FormatInt(int64(ttl.Seconds()), 10))
 			httpx.Handle(w, httpx.TooManyRequests("rate limit exceeded"))
 			return
@@ -0,0 +1,53 @@
+package readonly
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+type Config struct {
+	Enabled bool
+	// AllowedPrefixes keep accepting mutations, e.g. "/auth/" so visitors can
+	// still sign in to a demo instance
+	AllowedPrefixes []string
+}
+
+// New rejects every mutating request with 403 while read-only mode is on.
+// Safe methods and allowed route prefixes pass through untouched.
+func New(cfg Config) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if !cfg.Enabled {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if isSafeMethod(r.Method) || hasAllowedPrefix(r.URL.Path, cfg.AllowedPrefixes) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			httpx.Handle(w, httpx.Forbidden("this is a read-only demo instance, changes are disabled").WithCode("read_only_mode"))
+		})
+	}
+}
+
+// isSafeMethod also lets the WebDAV read methods used by calendar clients
+// through
+func isSafeMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, "PROPFIND", "REPORT":
+		return true
+	}
+	return false
+}
+
+func hasAllowedPrefix(path string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package readonly_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/readonly"
+)
+
+func TestNew(t *testing.T) {
+	on := readonly.Config{Enabled: true, AllowedPrefixes: []string{"/auth/"}}
+
+	tests := []struct {
+		name   string
+		cfg    readonly.Config
+		method string
+		path   string
+		want   int
+	}{
+		{"off lets writes through", readonly.Config{}, http.MethodPost, "/projects", http.StatusOK},
+		{"get", on, http.MethodGet, "/projects", http.StatusOK},
+		{"head", on, http.MethodHead, "/projects", http.StatusOK},
+		{"options", on, http.MethodOptions, "/projects", http.StatusOK},
+		{"propfind", on, "PROPFIND", "/dav/calendars/", http.StatusOK},
+		{"report", on, "REPORT", "/dav/calendars/", http.StatusOK},
+		{"post", on, http.MethodPost, "/projects", http.StatusForbidden},
+		{"put", on, http.MethodPut, "/dav/calendars/p/t.ics", http.StatusForbidden},
+		{"patch", on, http.MethodPatch, "/tickets/1", http.StatusForbidden},
+		{"delete", on, http.MethodDelete, "/tickets/1", http.StatusForbidden},
+		{"allowed prefix", on, http.MethodPost, "/auth/login", http.StatusOK},
+		{"prefix is not a substring match", on, http.MethodPost, "/projects/auth/", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reached := false
+			h := readonly.New(tt.cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				reached = true
+			}))
+
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.want {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.want)
+			}
+			if reached != (tt.want == http.StatusOK) {
+				t.Fatalf("handler reached = %v", reached)
+			}
+			if tt.want == http.StatusForbidden && !strings.Contains(rec.Body.String(), `"read_only_mode"`) {
+				t.Fatalf("body = %s, want the read_only_mode code", rec.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,251 @@
+// Package recorder keeps a sanitized copy of recent requests and their
+// responses in a ring buffer, so a client/server mismatch can be looked at
+// without a packet capture.
+//
+// Nothing is recorded until a window is started and recording stops on its
+// own when the window closes. Credentials never enter the buffer: sensitive
+// headers, query parameters and JSON fields are replaced with Redacted, and
+// bodies that are not text are summarised by their size.
+package recorder
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Redacted replaces every sensitive value in a recorded exchange
+const Redacted = "[redacted]"
+
+const (
+	defaultCapacity  = 200
+	defaultMaxBody   = 16 << 10
+	defaultMaxWindow = time.Hour
+)
+
+var (
+	ErrDisabled = errors.New("request recording is disabled")
+	ErrWindow   = errors.New("recording window is out of range")
+)
+
+type Config struct {
+	// Enabled allows a window to be started at all, it does not start one
+	Enabled bool
+	// Capacity is the number of exchanges kept, the oldest is dropped first
+	Capacity int
+	// MaxBodyBytes caps what is kept of each request and response body
+	MaxBodyBytes int
+	// MaxWindow is the longest a single window may run
+	MaxWindow time.Duration
+	// ExemptPaths are never recorded, e.g. health probes
+	ExemptPaths []string
+}
+
+// Exchange is one recorded request and the response it got
+type Exchange struct {
+	ID             uint64
+	Time           time.Time
+	Duration       time.Duration
+	Method         string
+	Path           string
+	Query          string
+	Status         int
+	RequestHeader  http.Header
+	RequestBody    string
+	ResponseHeader http.Header
+	ResponseBody   string
+	// Truncated is set when either body was longer than MaxBodyBytes
+	Truncated bool
+}
+
+// Recorder is the middleware and the buffer it fills. When no window is open
+// a request costs one atomic load.
+type Recorder struct {
+	cfg   Config
+	until atomic.Int64
+
+	mu   sync.Mutex
+	buf  []Exchange
+	next int
+	seq  uint64
+}
+
+func New(cfg Config) *Recorder {
+	if cfg.Capacity <= 0 {
+		cfg.Capacity = defaultCapacity
+	}
+	if cfg.MaxBodyBytes <= 0 {
+		cfg.MaxBodyBytes = defaultMaxBody
+	}
+	if cfg.MaxWindow <= 0 {
+		cfg.MaxWindow = defaultMaxWindow
+	}
+	return &Recorder{cfg: cfg, buf: make([]Exchange, 0, cfg.Capacity)}
+}
+
+func (rc *Recorder) Enabled() bool {
+	return rc.cfg.Enabled
+}
+
+func (rc *Recorder) Capacity() int {
+	return rc.cfg.Capacity
+}
+
+// Start opens a window of d from now, replacing any open window. Exchanges
+// recorded earlier are kept.
+func (rc *Recorder) Start(d time.Duration) (time.Time, error) {
+	if !rc.cfg.Enabled {
+		return time.Time{}, ErrDisabled
+	}
+	if d <= 0 || d > rc.cfg.MaxWindow {
+		return time.Time{}, fmt.Errorf("%w: must be positive and at most %s", ErrWindow, rc.cfg.MaxWindow)
+	}
+	until := time.Now().Add(d)
+	rc.until.Store(until.UnixNano())
+	return until, nil
+}
+
+// Stop closes the open window, if any
+func (rc *Recorder) Stop() {
+	rc.until.Store(0)
+}
+
+// Until reports when the open window closes
+func (rc *Recorder) Until() (time.Time, bool) {
+	n := rc.until.Load()
+	if n == 0 || time.Now().UnixNano() >= n {
+		return time.Time{}, false
+	}
+	return time.Unix(0, n), true
+}
+
+// Exchanges returns a copy of the buffer, oldest first
+func (rc *Recorder) Exchanges() []Exchange {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	out := make([]Exchange, 0, len(rc.buf))
+	if len(rc.buf) == rc.cfg.Capacity {
+		out = append(out, rc.buf[rc.next:]...)
+		out = append(out, rc.buf[:rc.next]...)
+		return out
+	}
+	return append(out, rc.buf...)
+}
+
+// Clear drops every recorded exchange, the open window stays open
+func (rc *Recorder) Clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	rc.buf = rc.buf[:0]
+	rc.next = 0
+}
+
+func (rc *Recorder) add(ex Exchange) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+
+	rc.seq++
+	ex.ID = rc.seq
+	if len(rc.buf) < rc.cfg.Capacity {
+		rc.buf = append(rc.buf, ex)
+		return
+	}
+	rc.buf[rc.next] = ex
+	rc.next = (rc.next + 1) % rc.cfg.Capacity
+}
+
+func (rc *Recorder) Wrap(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := rc.Until(); !ok || rc.exempt(r.URL.Path) {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		start := time.Now()
+		reqBody, reqTruncated := rc.peekBody(r)
+
+		cw := &captureWriter{ResponseWriter: w, status: http.StatusOK, limit: rc.cfg.MaxBodyBytes}
+		next.ServeHTTP(cw, r)
+
+		rc.add(Exchange{
+			Time:           start,
+			Duration:       time.Since(start),
+			Method:         r.Method,
+			Path:           r.URL.Path,
+			Query:          redactQuery(r.URL.Query()),
+			Status:         cw.status,
+			RequestHeader:  redactHeader(r.Header),
+			RequestBody:    renderBody(r.Header.Get("Content-Type"), reqBody, reqTruncated),
+			ResponseHeader: redactHeader(cw.Header()),
+			ResponseBody:   renderBody(cw.Header().Get("Content-Type"), cw.body.Bytes(), cw.truncated),
+			Truncated:      reqTruncated || cw.truncated,
+		})
+	})
+}
+
+func (rc *Recorder) exempt(path string) bool {
+	for _, p := range rc.cfg.ExemptPaths {
+		if strings.HasPrefix(path, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// peekBody reads up to MaxBodyBytes of the request body and puts it back in
+// front of the rest, so the handler still sees the whole body
+func (rc *Recorder) peekBody(r *http.Request) ([]byte, bool) {
+	if r.Body == nil || r.Body == http.NoBody {
+		return nil, false
+	}
+	head, _ := io.ReadAll(io.LimitReader(r.Body, int64(rc.cfg.MaxBodyBytes)+1))
+	r.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(head), r.Body), r.Body}
+
+	if len(head) > rc.cfg.MaxBodyBytes {
+		return head[:rc.cfg.MaxBodyBytes], true
+	}
+	return head, false
+}
+
+type captureWriter struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+	limit       int
+	body        bytes.Buffer
+	truncated   bool
+}
+
+func (c *captureWriter) WriteHeader(code int) {
+	if !c.wroteHeader {
+		c.status = code
+		c.wroteHeader = true
+	}
+	c.ResponseWriter.WriteHeader(code)
+}
+
+func (c *captureWriter) Write(b []byte) (int, error) {
+	c.wroteHeader = true
+	keep := b
+	if room := c.limit - c.body.Len(); len(b) > room {
+		keep = b[:max(room, 0)]
+		c.truncated = true
+	}
+	c.body.Write(keep)
+	return c.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer
+func (c *captureWriter) Unwrap() http.ResponseWriter {
+	return c.ResponseWriter
+}
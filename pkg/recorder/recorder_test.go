@@ -0,0 +1,134 @@
+package recorder_test
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/recorder"
+)
+
+var echo = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	b, _ := io.ReadAll(r.Body)
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Set-Cookie", "session=abc")
+	w.WriteHeader(http.StatusCreated)
+	_, _ = w.Write(b)
+})
+
+func send(h http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer secret")
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRecorder_RecordsOnlyInsideWindow(t *testing.T) {
+	rc := recorder.New(recorder.Config{Enabled: true})
+	h := rc.Wrap(echo)
+
+	send(h, "POST", "/tickets", `{}`)
+	if got := len(rc.Exchanges()); got != 0 {
+		t.Fatalf("recorded %d exchanges before a window was started", got)
+	}
+
+	if _, err := rc.Start(time.Minute); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	send(h, "POST", "/tickets", `{}`)
+	rc.Stop()
+	send(h, "POST", "/tickets", `{}`)
+
+	if got := len(rc.Exchanges()); got != 1 {
+		t.Fatalf("recorded %d exchanges, want 1", got)
+	}
+}
+
+func TestRecorder_Sanitizes(t *testing.T) {
+	rc := recorder.New(recorder.Config{Enabled: true})
+	h := rc.Wrap(echo)
+	_, _ = rc.Start(time.Minute)
+
+	rec := send(h, "POST", "/auth/login?token=abc&page=2", `{"email":"a@b.c","password":"hunter2","nested":[{"refreshToken":"x"}]}`)
+	if !strings.Contains(rec.Body.String(), "hunter2") {
+		t.Fatal("the handler must still see the original body")
+	}
+
+	ex := rc.Exchanges()[0]
+	for _, s := range []string{ex.RequestBody, ex.ResponseBody, ex.Query} {
+		if strings.Contains(s, "hunter2") || strings.Contains(s, `"x"`) || strings.Contains(s, "abc") {
+			t.Fatalf("secret kept in %q", s)
+		}
+	}
+	if !strings.Contains(ex.RequestBody, `"email":"a@b.c"`) || !strings.Contains(ex.Query, "page=2") {
+		t.Fatalf("non-secret values lost: %q %q", ex.RequestBody, ex.Query)
+	}
+	if ex.RequestHeader.Get("Authorization") != recorder.Redacted || ex.ResponseHeader.Get("Set-Cookie") != recorder.Redacted {
+		t.Fatalf("headers not redacted: %v %v", ex.RequestHeader, ex.ResponseHeader)
+	}
+	if ex.Status != http.StatusCreated || ex.Method != "POST" || ex.Path != "/auth/login" {
+		t.Fatalf("unexpected exchange %+v", ex)
+	}
+}
+
+func TestRecorder_TruncatesAndDropsJSON(t *testing.T) {
+	rc := recorder.New(recorder.Config{Enabled: true, MaxBodyBytes: 8})
+	h := rc.Wrap(echo)
+	_, _ = rc.Start(time.Minute)
+
+	body := `{"password":"hunter2"}`
+	rec := send(h, "POST", "/tickets", body)
+	if rec.Body.String() != body {
+		t.Fatalf("handler saw %q, want the whole body", rec.Body.String())
+	}
+
+	ex := rc.Exchanges()[0]
+	if !ex.Truncated || strings.Contains(ex.RequestBody, "hunt") {
+		t.Fatalf("truncated JSON must not be kept: %+v", ex)
+	}
+}
+
+func TestRecorder_RingBufferKeepsNewest(t *testing.T) {
+	rc := recorder.New(recorder.Config{Enabled: true, Capacity: 3, ExemptPaths: []string{"/health"}})
+	h := rc.Wrap(echo)
+	_, _ = rc.Start(time.Minute)
+
+	for _, p := range []string{"/a", "/b", "/health", "/c", "/d"} {
+		send(h, "GET", p, "")
+	}
+
+	var got []string
+	for _, ex := range rc.Exchanges() {
+		got = append(got, ex.Path)
+	}
+	if strings.Join(got, ",") != "/b,/c,/d" {
+		t.Fatalf("got %v, want oldest first /b,/c,/d", got)
+	}
+
+	rc.Clear()
+	if len(rc.Exchanges()) != 0 {
+		t.Fatal("Clear kept exchanges")
+	}
+}
+
+func TestRecorder_Start(t *testing.T) {
+	if _, err := recorder.New(recorder.Config{}).Start(time.Minute); !errors.Is(err, recorder.ErrDisabled) {
+		t.Fatalf("err = %v, want ErrDisabled", err)
+	}
+
+	rc := recorder.New(recorder.Config{Enabled: true, MaxWindow: time.Minute})
+	for _, d := range []time.Duration{0, time.Hour} {
+		if _, err := rc.Start(d); !errors.Is(err, recorder.ErrWindow) {
+			t.Fatalf("Start(%s) err = %v, want ErrWindow", d, err)
+		}
+	}
+	if _, ok := rc.Until(); ok {
+		t.Fatal("a rejected Start opened a window")
+	}
+}
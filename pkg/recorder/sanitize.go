@@ -0,0 +1,107 @@
+package recorder
+
+import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// sensitiveHeaders are redacted whatever their value
+var sensitiveHeaders = map[string]bool{
+	"Authorization":       true,
+	"Cookie":              true,
+	"Set-Cookie":          true,
+	"Proxy-Authorization": true,
+	"X-Webhook-Signature": true,
+}
+
+// sensitiveWords mark a JSON field or query parameter as secret when its name
+// contains one of them, case insensitive
+var sensitiveWords = []string{"password", "token", "secret", "signature", "apikey", "p256dh"}
+
+func sensitive(name string) bool {
+	name = strings.ToLower(name)
+	// the auth key of a push subscription
+	if name == "auth" {
+		return true
+	}
+	for _, w := range sensitiveWords {
+		if strings.Contains(name, w) {
+			return true
+		}
+	}
+	return false
+}
+
+func redactHeader(h http.Header) http.Header {
+	out := h.Clone()
+	for k := range out {
+		if sensitiveHeaders[k] || sensitive(k) {
+			out[k] = []string{Redacted}
+		}
+	}
+	return out
+}
+
+func redactQuery(q url.Values) string {
+	for k := range q {
+		if sensitive(k) {
+			q[k] = []string{Redacted}
+		}
+	}
+	return q.Encode()
+}
+
+// renderBody keeps text bodies, with secrets in JSON redacted, and reduces
+// anything else to a note of its size. A truncated JSON body can not be
+// parsed, it is dropped rather than risk keeping a secret.
+func renderBody(contentType string, b []byte, truncated bool) string {
+	if len(b) == 0 {
+		return ""
+	}
+	mt, _, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mt == "application/json" || strings.HasSuffix(mt, "+json"):
+		if truncated {
+			return fmt.Sprintf("[json, %d bytes, truncated]", len(b))
+		}
+		var v any
+		if err := json.Unmarshal(b, &v); err != nil {
+			return fmt.Sprintf("[invalid json, %d bytes]", len(b))
+		}
+		out, _ := json.Marshal(redactJSON(v))
+		return string(out)
+	case strings.HasPrefix(mt, "text/") || mt == "application/xml" || strings.HasSuffix(mt, "+xml"):
+		return string(b)
+	default:
+		return fmt.Sprintf("[%s, %d bytes]", orUnknown(mt), len(b))
+	}
+}
+
+func redactJSON(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if sensitive(k) {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = redactJSON(child)
+		}
+	case []any:
+		for i, child := range t {
+			t[i] = redactJSON(child)
+		}
+	}
+	return v
+}
+
+func orUnknown(mt string) string {
+	if mt == "" {
+		return "unknown"
+	}
+	return mt
+}
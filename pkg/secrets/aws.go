@@ -0,0 +1,93 @@
+package secrets
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/awssig"
+)
+
+type AWSConfig struct {
+	Region string
+	// Endpoint overrides the regional endpoint, e.g. for a VPC endpoint or a
+	// local emulator
+	Endpoint     string
+	SecretID     string // name or ARN of a secret holding a JSON object
+	AccessKey    string
+	SecretKey    string
+	SessionToken string // set with temporary credentials
+}
+
+// AWS reads one secret of AWS Secrets Manager, signing the API call itself
+type AWS struct {
+	cfg    AWSConfig
+	url    string
+	client *http.Client
+	now    func() time.Time
+}
+
+var _ Provider = (*AWS)(nil)
+
+func NewAWS(cfg AWSConfig, client *http.Client) (*AWS, error) {
+	if cfg.Region == "" || cfg.SecretID == "" || cfg.AccessKey == "" || cfg.SecretKey == "" {
+		return nil, errors.New("secrets: aws driver needs a region, secret id, access key and secret key")
+	}
+	endpoint := cfg.Endpoint
+	if endpoint == "" {
+		endpoint = "https://secretsmanager." + cfg.Region + ".amazonaws.com"
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("secrets: aws endpoint %q is not an http(s) URL", endpoint)
+	}
+	return &AWS{cfg: cfg, url: u.String(), client: client, now: time.Now}, nil
+}
+
+func (a *AWS) Fetch(ctx context.Context) (map[string]string, error) {
+	payload, _ := json.Marshal(map[string]string{"SecretId": a.cfg.SecretID})
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.url, bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-amz-json-1.1")
+	req.Header.Set("X-Amz-Target", "secretsmanager.GetSecretValue")
+	if a.cfg.SessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", a.cfg.SessionToken)
+	}
+	awssig.Sign(req, awssig.Credentials{
+		Region:    a.cfg.Region,
+		Service:   "secretsmanager",
+		AccessKey: a.cfg.AccessKey,
+		SecretKey: a.cfg.SecretKey,
+	}, awssig.HexSHA256(payload), a.now())
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: aws: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return nil, fmt.Errorf("secrets: aws %s: %s: %s", a.cfg.SecretID, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var body struct {
+		SecretString string `json:"SecretString"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("secrets: aws %s: decode: %w", a.cfg.SecretID, err)
+	}
+	var doc map[string]any
+	if err := json.Unmarshal([]byte(body.SecretString), &doc); err != nil {
+		return nil, fmt.Errorf("secrets: aws %s: the secret string must be a JSON object: %w", a.cfg.SecretID, err)
+	}
+	return stringValues(doc), nil
+}
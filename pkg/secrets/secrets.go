@@ -0,0 +1,105 @@
+// Package secrets reads configuration secrets from an external manager,
+// HashiCorp Vault or AWS Secrets Manager. Each provider holds one document,
+// a KV secret or a JSON secret string, whose keys are named like the env vars
+// they stand in for, e.g. DB_PASSWORD or JWT_ACCESS_SECRET.
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	DriverVault = "vault"
+	DriverAWS   = "aws"
+)
+
+// Provider fetches the current secret document
+type Provider interface {
+	Fetch(ctx context.Context) (map[string]string, error)
+}
+
+type Config struct {
+	// Driver is vault or aws, empty leaves the manager out
+	Driver  string
+	Vault   VaultConfig
+	AWS     AWSConfig
+	TTL     time.Duration // how long a fetched document is served before the next fetch
+	Timeout time.Duration
+}
+
+// New opens the configured provider behind a cache; it returns nil when no
+// driver is set
+func New(cfg Config) (*Store, error) {
+	client := &http.Client{Timeout: cfg.Timeout}
+	var (
+		p   Provider
+		err error
+	)
+	switch cfg.Driver {
+	case "":
+		return nil, nil
+	case DriverVault:
+		p, err = NewVault(cfg.Vault, client)
+	case DriverAWS:
+		p, err = NewAWS(cfg.AWS, client)
+	default:
+		return nil, fmt.Errorf("secrets: unknown driver %q, use vault or aws", cfg.Driver)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewStore(p, cfg.TTL), nil
+}
+
+// Store caches the provider's document for ttl. A caller that finds a secret
+// refused, e.g. a database rejecting its password, calls Invalidate so the
+// next read fetches the document again and picks up a rotation.
+type Store struct {
+	provider Provider
+	ttl      time.Duration
+	now      func() time.Time
+
+	mu        sync.Mutex
+	doc       map[string]string
+	fetchedAt time.Time
+}
+
+func NewStore(p Provider, ttl time.Duration) *Store {
+	return &Store{provider: p, ttl: ttl, now: time.Now}
+}
+
+// ErrNotFound is returned for a key the document does not hold
+var ErrNotFound = errors.New("secrets: key not found")
+
+// Get returns one key of the document, fetching it first when the cached
+// copy is missing or older than the ttl. A zero ttl keeps the first fetch
+// until Invalidate.
+func (s *Store) Get(ctx context.Context, key string) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.doc == nil || (s.ttl > 0 && s.now().Sub(s.fetchedAt) >= s.ttl) {
+		doc, err := s.provider.Fetch(ctx)
+		if err != nil {
+			return "", err
+		}
+		s.doc, s.fetchedAt = doc, s.now()
+	}
+	v, ok := s.doc[key]
+	if !ok {
+		return "", ErrNotFound
+	}
+	return v, nil
+}
+
+// Invalidate drops the cached document
+func (s *Store) Invalidate() {
+	s.mu.Lock()
+	s.doc = nil
+	s.mu.Unlock()
+}
@@ -0,0 +1,127 @@
+package secrets_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/secrets"
+)
+
+func TestVault(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/kv/data/fluxis/prod" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		if r.Header.Get("X-Vault-Token") != "root" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Write([]byte(`{"data":{"data":{"DB_PASSWORD":"s3cret","DB_PORT":5432},"metadata":{"version":3}}}`))
+	}))
+	defer srv.Close()
+
+	v, err := secrets.NewVault(secrets.VaultConfig{Addr: srv.URL, Token: "root", Mount: "kv", Path: "fluxis/prod"}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := v.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if doc["DB_PASSWORD"] != "s3cret" || doc["DB_PORT"] != "5432" {
+		t.Fatalf("Fetch = %v", doc)
+	}
+
+	bad, _ := secrets.NewVault(secrets.VaultConfig{Addr: srv.URL, Token: "wrong", Mount: "kv", Path: "fluxis/prod"}, srv.Client())
+	if _, err := bad.Fetch(context.Background()); err == nil || !strings.Contains(err.Error(), "403") {
+		t.Fatalf("Fetch with a wrong token = %v, want a 403 error", err)
+	}
+}
+
+func TestAWS(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got := r.Header.Get("X-Amz-Target"); got != "secretsmanager.GetSecretValue" {
+			t.Errorf("X-Amz-Target = %q", got)
+		}
+		auth := r.Header.Get("Authorization")
+		if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=AKID/") || !strings.Contains(auth, "/eu-west-1/secretsmanager/aws4_request") {
+			t.Errorf("Authorization = %q", auth)
+		}
+		var in struct{ SecretId string }
+		json.NewDecoder(r.Body).Decode(&in)
+		if in.SecretId != "fluxis/prod" {
+			t.Errorf("SecretId = %q", in.SecretId)
+		}
+		w.Write([]byte(`{"Name":"fluxis/prod","SecretString":"{\"JWT_ACCESS_SECRET\":\"abc\"}"}`))
+	}))
+	defer srv.Close()
+
+	a, err := secrets.NewAWS(secrets.AWSConfig{
+		Region: "eu-west-1", Endpoint: srv.URL, SecretID: "fluxis/prod", AccessKey: "AKID", SecretKey: "SECRET",
+	}, srv.Client())
+	if err != nil {
+		t.Fatal(err)
+	}
+	doc, err := a.Fetch(context.Background())
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if doc["JWT_ACCESS_SECRET"] != "abc" {
+		t.Fatalf("Fetch = %v", doc)
+	}
+}
+
+type countingProvider struct {
+	calls    atomic.Int32
+	password string
+}
+
+func (p *countingProvider) Fetch(context.Context) (map[string]string, error) {
+	p.calls.Add(1)
+	return map[string]string{"DB_PASSWORD": p.password}, nil
+}
+
+func TestStore_CachesUntilInvalidated(t *testing.T) {
+	p := &countingProvider{password: "one"}
+	store := secrets.NewStore(p, time.Hour)
+	ctx := context.Background()
+
+	for range 3 {
+		if v, _ := store.Get(ctx, "DB_PASSWORD"); v != "one" {
+			t.Fatalf("Get = %q, want one", v)
+		}
+	}
+	if p.calls.Load() != 1 {
+		t.Fatalf("fetched %d times, want 1", p.calls.Load())
+	}
+	if _, err := store.Get(ctx, "MISSING"); !errors.Is(err, secrets.ErrNotFound) {
+		t.Fatalf("Get of a missing key = %v, want ErrNotFound", err)
+	}
+
+	// a rotated password is only picked up once the old one is refused
+	p.password = "two"
+	store.Invalidate()
+	if v, _ := store.Get(ctx, "DB_PASSWORD"); v != "two" {
+		t.Fatalf("Get after Invalidate = %q, want two", v)
+	}
+	if p.calls.Load() != 2 {
+		t.Fatalf("fetched %d times, want 2", p.calls.Load())
+	}
+}
+
+func TestNew_WithoutDriver(t *testing.T) {
+	store, err := secrets.New(secrets.Config{})
+	if store != nil || err != nil {
+		t.Fatalf("New = %v, %v, want nil, nil", store, err)
+	}
+	if _, err := secrets.New(secrets.Config{Driver: "gcp"}); err == nil {
+		t.Fatal("New with an unknown driver should fail")
+	}
+}
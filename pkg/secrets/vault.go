@@ -0,0 +1,86 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type VaultConfig struct {
+	Addr  string // e.g. https://vault.internal:8200
+	Token string
+	Mount string // KV version 2 mount, secret by default
+	Path  string // secret path under the mount, e.g. fluxis/production
+}
+
+// Vault reads one secret of a KV version 2 engine over Vault's HTTP API
+type Vault struct {
+	cfg    VaultConfig
+	url    string
+	client *http.Client
+}
+
+var _ Provider = (*Vault)(nil)
+
+func NewVault(cfg VaultConfig, client *http.Client) (*Vault, error) {
+	if cfg.Token == "" || cfg.Path == "" {
+		return nil, errors.New("secrets: vault driver needs a token and a secret path")
+	}
+	u, err := url.Parse(cfg.Addr)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("secrets: vault address %q is not an http(s) URL", cfg.Addr)
+	}
+	if cfg.Mount == "" {
+		cfg.Mount = "secret"
+	}
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/v1/" + strings.Trim(cfg.Mount, "/") + "/data/" + strings.Trim(cfg.Path, "/")
+	return &Vault{cfg: cfg, url: u.String(), client: client}, nil
+}
+
+func (v *Vault) Fetch(ctx context.Context) (map[string]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, v.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("X-Vault-Token", v.cfg.Token)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("secrets: vault: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<10))
+		return nil, fmt.Errorf("secrets: vault %s: %s: %s", v.cfg.Path, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	var body struct {
+		Data struct {
+			Data map[string]any `json:"data"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("secrets: vault %s: decode: %w", v.cfg.Path, err)
+	}
+	return stringValues(body.Data.Data), nil
+}
+
+// stringValues keeps strings as they are and renders other JSON values, so
+// a number stored unquoted still reads as its digits
+func stringValues(in map[string]any) map[string]string {
+	out := make(map[string]string, len(in))
+	for k, v := range in {
+		if s, ok := v.(string); ok {
+			out[k] = s
+			continue
+		}
+		b, _ := json.Marshal(v)
+		out[k] = string(b)
+	}
+	return out
+}
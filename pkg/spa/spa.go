@@ -0,0 +1,66 @@
+package spa
+
+import (
+	"errors"
+	"io/fs"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/dimasbaguspm/fluxis/pkg/httpx"
+)
+
+const indexFile = "index.html"
+
+// Handler serves a built single page application. Known files are served as
+// is, fingerprinted assets are cached forever, and any other extension-less
+// path falls back to index.html so client-side routing keeps working on a
+// hard refresh. Paths under /api never fall back, a mistyped API route gets a
+// JSON 404 rather than the page.
+func Handler(dist fs.FS) http.Handler {
+	files := http.FileServer(http.FS(dist))
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			httpx.Handle(w, httpx.NotImplemented("endpoint is not implemented"))
+			return
+		}
+
+		name := strings.TrimPrefix(path.Clean(r.URL.Path), "/")
+		if name == "" {
+			name = indexFile
+		}
+		if name == "api" || strings.HasPrefix(name, "api/") {
+			httpx.Handle(w, httpx.NotFound("endpoint not found"))
+			return
+		}
+
+		if _, err := fs.Stat(dist, name); err != nil {
+			if !errors.Is(err, fs.ErrNotExist) || path.Ext(name) != "" {
+				http.NotFound(w, r)
+				return
+			}
+			serveIndex(w, r, dist)
+			return
+		}
+
+		if name == indexFile {
+			serveIndex(w, r, dist)
+			return
+		}
+
+		if strings.HasPrefix(name, "assets/") {
+			// vite fingerprints everything under assets/
+			w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		} else {
+			w.Header().Set("Cache-Control", "public, max-age=3600")
+		}
+		files.ServeHTTP(w, r)
+	})
+}
+
+func serveIndex(w http.ResponseWriter, r *http.Request, dist fs.FS) {
+	// index.html references the current asset hashes, never let it go stale
+	w.Header().Set("Cache-Control", "no-cache")
+	http.ServeFileFS(w, r, dist, indexFile)
+}
@@ -0,0 +1,55 @@
+package spa_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/dimasbaguspm/fluxis/pkg/spa"
+)
+
+func TestHandler(t *testing.T) {
+	dist := fstest.MapFS{
+		"index.html":           {Data: []byte("<!doctype html>")},
+		"assets/app-1a2b3c.js": {Data: []byte("console.log(1)")},
+		"favicon.svg":          {Data: []byte("<svg/>")},
+	}
+	h := spa.Handler(dist)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		wantStatus  int
+		wantType    string
+		wantCaching string
+	}{
+		{"root", "GET", "/", http.StatusOK, "text/html", "no-cache"},
+		{"client route", "GET", "/projects/abc/board", http.StatusOK, "text/html", "no-cache"},
+		{"asset", "GET", "/assets/app-1a2b3c.js", http.StatusOK, "javascript", "immutable"},
+		{"public file", "GET", "/favicon.svg", http.StatusOK, "image/svg+xml", "max-age=3600"},
+		{"missing file", "GET", "/missing.png", http.StatusNotFound, "text/plain", ""},
+		{"mistyped api route", "GET", "/api/projectz", http.StatusNotFound, "application/json", ""},
+		{"api root", "GET", "/api", http.StatusNotFound, "application/json", ""},
+		{"write", "POST", "/projects", http.StatusNotImplemented, "application/json", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, httptest.NewRequest(tt.method, tt.path, nil))
+
+			if rec.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d", rec.Code, tt.wantStatus)
+			}
+			if got := rec.Header().Get("Content-Type"); !strings.Contains(got, tt.wantType) {
+				t.Errorf("Content-Type = %q, want one containing %q", got, tt.wantType)
+			}
+			if got := rec.Header().Get("Cache-Control"); !strings.Contains(got, tt.wantCaching) {
+				t.Errorf("Cache-Control = %q, want one containing %q", got, tt.wantCaching)
+			}
+		})
+	}
+}
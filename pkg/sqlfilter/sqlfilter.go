@@ -0,0 +1,136 @@
+// Package sqlfilter composes parameterized WHERE clauses for list queries.
+//
+// Search models carry optional filters, mostly slices where an empty slice
+// means "no filter". Writing each one by hand as
+// `(array_length($n::uuid[], 1) IS NULL OR col = ANY($n::uuid[]))` is easy
+// to get wrong once a query grows past a few positional parameters, so a
+// condition here is only added when its filter is set and placeholders are
+// numbered for the caller.
+//
+// Column names and raw SQL are written by the caller and never come from
+// user input; only values are sent as parameters.
+package sqlfilter
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Cond is a single condition. Its SQL marks parameters with ? which the
+// builder rewrites to $n, so a condition must not contain a literal ?.
+type Cond struct {
+	sql  string
+	args []any
+}
+
+// IsZero reports whether the condition was skipped
+func (c Cond) IsZero() bool {
+	return c.sql == ""
+}
+
+// Raw is a condition that is always applied
+func Raw(sql string, args ...any) Cond {
+	return Cond{sql: sql, args: args}
+}
+
+// If keeps c only when ok is true
+func If(ok bool, c Cond) Cond {
+	if !ok {
+		return Cond{}
+	}
+	return c
+}
+
+// In matches rows whose column is one of values; an empty slice skips it
+func In[T any](column string, values []T) Cond {
+	if len(values) == 0 {
+		return Cond{}
+	}
+	return Raw(column+" = ANY(?)", values)
+}
+
+// Contains matches rows whose column contains any of terms, case
+// insensitively; an empty slice skips it
+func Contains(column string, terms []string) Cond {
+	if len(terms) == 0 {
+		return Cond{}
+	}
+	patterns := make([]string, len(terms))
+	for i, t := range terms {
+		patterns[i] = "%" + escapeLike(t) + "%"
+	}
+	return Raw(column+" ILIKE ANY(?)", patterns)
+}
+
+// Builder accumulates conditions joined with AND along with their arguments
+type Builder struct {
+	conds []string
+	args  []any
+}
+
+// New starts a builder. Args already bound by the surrounding query go
+// first so that conditions are numbered after them.
+func New(args ...any) *Builder {
+	return &Builder{args: args}
+}
+
+// And adds every condition that was not skipped
+func (b *Builder) And(conds ...Cond) *Builder {
+	for _, c := range conds {
+		if c.IsZero() {
+			continue
+		}
+		b.conds = append(b.conds, b.bind(c))
+	}
+	return b
+}
+
+// Arg binds a value outside the WHERE clause, e.g. LIMIT, and returns its
+// placeholder
+func (b *Builder) Arg(v any) string {
+	b.args = append(b.args, v)
+	return "$" + strconv.Itoa(len(b.args))
+}
+
+// Where renders the clause including the keyword, or "" with no conditions
+func (b *Builder) Where() string {
+	if len(b.conds) == 0 {
+		return ""
+	}
+	return "WHERE " + strings.Join(b.conds, " AND ")
+}
+
+// Args returns the arguments in placeholder order
+func (b *Builder) Args() []any {
+	return b.args
+}
+
+func (b *Builder) bind(c Cond) string {
+	var sb strings.Builder
+	sql, args := c.sql, c.args
+	for {
+		i := strings.IndexByte(sql, '?')
+		if i < 0 {
+			break
+		}
+		if len(args) == 0 {
+			panic("sqlfilter: more placeholders than arguments in " + c.sql)
+		}
+		sb.WriteString(sql[:i])
+		sb.WriteString(b.Arg(args[0]))
+		sql, args = sql[i+1:], args[1:]
+	}
+	if len(args) != 0 {
+		panic("sqlfilter: more arguments than placeholders in " + c.sql)
+	}
+	sb.WriteString(sql)
+
+	// keeps an OR inside a raw condition from leaking into the AND chain
+	return "(" + sb.String() + ")"
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,93 @@
+package sqlfilter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/sqlfilter"
+)
+
+func TestBuilder_SkipsUnsetFilters(t *testing.T) {
+	f := sqlfilter.New().And(
+		sqlfilter.In("id", []string(nil)),
+		sqlfilter.In("project_id", []int{}),
+		sqlfilter.Contains("name", nil),
+		sqlfilter.If(false, sqlfilter.Raw("deleted_at IS NULL")),
+	)
+
+	if f.Where() != "" {
+		t.Fatalf("expected no clause, got %q", f.Where())
+	}
+	if len(f.Args()) != 0 {
+		t.Fatalf("expected no args, got %v", f.Args())
+	}
+}
+
+func TestBuilder_NumbersPlaceholders(t *testing.T) {
+	f := sqlfilter.New().And(
+		sqlfilter.If(true, sqlfilter.Raw("deleted_at IS NULL")),
+		sqlfilter.In("project_id", []int{1, 2}),
+		sqlfilter.In("id", []int(nil)),
+		sqlfilter.In("sprint_id", []int{3}),
+	)
+	limit := f.Arg(25)
+
+	want := "WHERE (deleted_at IS NULL) AND (project_id = ANY($1)) AND (sprint_id = ANY($2))"
+	if f.Where() != want {
+		t.Fatalf("got %q, want %q", f.Where(), want)
+	}
+	if limit != "$3" {
+		t.Fatalf("expected limit to bind after the filters, got %s", limit)
+	}
+	if !reflect.DeepEqual(f.Args(), []any{[]int{1, 2}, []int{3}, 25}) {
+		t.Fatalf("unexpected args %v", f.Args())
+	}
+}
+
+func TestBuilder_ContinuesAfterBoundArgs(t *testing.T) {
+	f := sqlfilter.New("org").And(
+		sqlfilter.Raw("org_id = $1"),
+		sqlfilter.Raw("created_at BETWEEN ? AND ?", "a", "b"),
+	)
+
+	want := "WHERE (org_id = $1) AND (created_at BETWEEN $2 AND $3)"
+	if f.Where() != want {
+		t.Fatalf("got %q, want %q", f.Where(), want)
+	}
+	if len(f.Args()) != 3 {
+		t.Fatalf("expected 3 args, got %v", f.Args())
+	}
+}
+
+func TestBuilder_GroupsRawOr(t *testing.T) {
+	f := sqlfilter.New().And(
+		sqlfilter.Raw("assignee_id = ? OR reporter_id = ?", 1, 1),
+		sqlfilter.Raw("deleted_at IS NULL"),
+	)
+
+	want := "WHERE (assignee_id = $1 OR reporter_id = $2) AND (deleted_at IS NULL)"
+	if f.Where() != want {
+		t.Fatalf("got %q, want %q", f.Where(), want)
+	}
+}
+
+func TestContains_EscapesWildcards(t *testing.T) {
+	f := sqlfilter.New().And(sqlfilter.Contains("name", []string{"acme", "50%_off"}))
+
+	if f.Where() != "WHERE (name ILIKE ANY($1))" {
+		t.Fatalf("unexpected clause %q", f.Where())
+	}
+	want := []string{"%acme%", `%50\%\_off%`}
+	if !reflect.DeepEqual(f.Args()[0], want) {
+		t.Fatalf("got %v, want %v", f.Args()[0], want)
+	}
+}
+
+func TestBuilder_PanicsOnPlaceholderMismatch(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected a panic for a missing argument")
+		}
+	}()
+	sqlfilter.New().And(sqlfilter.Raw("a = ? AND b = ?", 1))
+}
@@ -0,0 +1,12 @@
+package transformer
+
+import "github.com/jackc/pgx/v5/pgtype"
+
+// Int32Ptr returns nil for a NULL integer so optional numbers encode as JSON null
+func Int32Ptr(n pgtype.Int4) *int32 {
+	if !n.Valid {
+		return nil
+	}
+	v := n.Int32
+	return &v
+}
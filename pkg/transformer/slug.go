@@ -1,22 +1,85 @@
 package transformer
 
 import (
-	"regexp"
+	"strconv"
 	"strings"
-)
-
-func CreateSlug(s string) string {
-	s = strings.ToLower(s)
+	"unicode"
 
-	reg, _ := regexp.Compile("[^a-z0-9\\s-]+")
-	s = reg.ReplaceAllString(s, "")
+	"golang.org/x/text/unicode/norm"
+)
 
-	s = strings.ReplaceAll(s, " ", "-")
+// foldLetters spells out Latin letters that do not decompose into a base
+// letter plus accents
+var foldLetters = map[rune]string{
+	'ß': "ss",
+	'æ': "ae",
+	'œ': "oe",
+	'ø': "o",
+	'đ': "d",
+	'ð': "d",
+	'ł': "l",
+	'þ': "th",
+	'ı': "i",
+}
 
-	reg2, _ := regexp.Compile("-+")
-	s = reg2.ReplaceAllString(s, "-")
+// CreateSlug lowercases s and joins its words with dashes. Accented Latin
+// letters are folded to ASCII ("Café Ünïcode" becomes "cafe-unicode") while
+// letters of other scripts are kept, so "Тестовая команда" or "開発チーム"
+// still give a usable slug. Other punctuation and symbols such as emoji are
+// dropped; a name made only of them gives "" and the caller picks a fallback.
+func CreateSlug(s string) string {
+	var b strings.Builder
+	gap, script := false, false
+	for _, r := range norm.NFKC.String(strings.ToLower(s)) {
+		var word string
+		switch {
+		case unicode.Is(unicode.Latin, r):
+			word, script = foldLatin(r), false
+		case unicode.Is(unicode.Mn, r):
+			// marks stay on the non-Latin letter they belong to, e.g. kana
+			// voicing or Thai vowels, and go with anything else like the
+			// variation selector of an emoji
+			if !script {
+				continue
+			}
+			word = string(r)
+		case unicode.IsLetter(r) || unicode.IsNumber(r):
+			word, script = string(r), unicode.IsLetter(r)
+		case unicode.IsSpace(r) || unicode.Is(unicode.Pd, r):
+			gap, script = true, false
+			continue
+		default:
+			script = false
+			continue
+		}
+		if word == "" {
+			continue
+		}
+		if gap && b.Len() > 0 {
+			b.WriteByte('-')
+		}
+		gap = false
+		b.WriteString(word)
+	}
+	return b.String()
+}
 
-	s = strings.Trim(s, "-")
+// foldLatin spells a Latin letter in ASCII by dropping its accents
+func foldLatin(r rune) string {
+	if f, ok := foldLetters[r]; ok {
+		return f
+	}
+	var b strings.Builder
+	for _, d := range norm.NFD.String(string(r)) {
+		if d < unicode.MaxASCII && (unicode.IsLetter(d) || unicode.IsNumber(d)) {
+			b.WriteRune(d)
+		}
+	}
+	return b.String()
+}
 
-	return s
+// SuffixSlug returns the n-th alternative for a slug that is taken: "acme"
+// becomes "acme-2", "acme-3" and so on
+func SuffixSlug(slug string, n int) string {
+	return slug + "-" + strconv.Itoa(n)
 }
@@ -0,0 +1,43 @@
+package transformer_test
+
+import (
+	"testing"
+
+	"github.com/dimasbaguspm/fluxis/pkg/transformer"
+)
+
+func TestCreateSlug(t *testing.T) {
+	cases := []struct {
+		name, in, want string
+	}{
+		{"ascii", "Acme Corp", "acme-corp"},
+		{"dashes and spaces collapse", "  Acme -- Corp  ", "acme-corp"},
+		{"punctuation dropped", "O'Brien & Sons, Inc.", "obrien-sons-inc"},
+		{"latin accents folded", "Café Ünïcode", "cafe-unicode"},
+		{"latin letters spelled out", "Straße Øresund", "strasse-oresund"},
+		{"indonesian", "Tim Pengembangan Produk", "tim-pengembangan-produk"},
+		{"cyrillic", "Тестовая Команда", "тестовая-команда"},
+		{"greek", "Ομάδα Ανάπτυξης", "ομάδα-ανάπτυξης"},
+		{"japanese", "開発チーム", "開発チーム"},
+		{"kana keep their voicing marks", "デザイン", "デザイン"},
+		{"korean", "개발 팀", "개발-팀"},
+		{"thai", "ทีมพัฒนา", "ทีมพัฒนา"},
+		{"arabic", "فريق التطوير", "فريق-التطوير"},
+		{"emoji between words", "Team 🚀 Rocket", "team-rocket"},
+		{"emoji only", "🚀🔥", ""},
+		{"emoji variation selector", "Team ❤️ Rocket", "team-rocket"},
+		{"keycap emoji", "Squad 1️⃣", "squad-1"},
+		{"fullwidth digits", "Team ２", "team-2"},
+	}
+	for _, c := range cases {
+		if got := transformer.CreateSlug(c.in); got != c.want {
+			t.Errorf("%s: CreateSlug(%q) = %q, want %q", c.name, c.in, got, c.want)
+		}
+	}
+}
+
+func TestSuffixSlug(t *testing.T) {
+	if got := transformer.SuffixSlug("acme", 2); got != "acme-2" {
+		t.Fatalf("got %q, want acme-2", got)
+	}
+}
@@ -0,0 +1,16 @@
+package transformer
+
+import (
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// TimePtr returns nil for a NULL timestamp so optional times encode as JSON null
+func TimePtr(ts pgtype.Timestamptz) *time.Time {
+	if !ts.Valid {
+		return nil
+	}
+	t := ts.Time
+	return &t
+}
@@ -0,0 +1,142 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrAddressNotAllowed is returned for an endpoint on a loopback, private,
+// link-local, multicast or unspecified address outside the allowed nets
+var ErrAddressNotAllowed = errors.New("webhook: endpoint address not allowed")
+
+// Sender posts signed JSON deliveries to webhook endpoints. Endpoints are set
+// by users, so the sender only reaches public addresses and those in its
+// allowed nets, checked on every dial so a name can not be pointed at an
+// internal address later, and it never follows redirects.
+type Sender struct {
+	client  *http.Client
+	allowed []netip.Prefix
+}
+
+// NewSender returns a Sender giving up on an endpoint after timeout. allowed
+// lists private blocks it may still deliver to, e.g. a self-hosted receiver.
+func NewSender(timeout time.Duration, allowed ...netip.Prefix) *Sender {
+	s := &Sender{allowed: allowed}
+
+	dialer := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			ap, err := netip.ParseAddrPort(address)
+			if err != nil || !s.permits(ap.Addr()) {
+				return fmt.Errorf("%w: %s", ErrAddressNotAllowed, address)
+			}
+			return nil
+		},
+	}
+	s.client = &http.Client{
+		Timeout: timeout,
+		// a proxy would be dialled instead of the endpoint and skip the check
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: timeout,
+			MaxIdleConns:        16,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+	return s
+}
+
+// ParseNets reads CIDR blocks or bare addresses for NewSender
+func ParseNets(entries []string) ([]netip.Prefix, error) {
+	out := make([]netip.Prefix, 0, len(entries))
+	for _, e := range entries {
+		e = strings.TrimSpace(e)
+		if e == "" {
+			continue
+		}
+		if strings.Contains(e, "/") {
+			p, err := netip.ParsePrefix(e)
+			if err != nil {
+				return nil, fmt.Errorf("invalid CIDR %q", e)
+			}
+			out = append(out, p.Masked())
+			continue
+		}
+		ip, err := netip.ParseAddr(e)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address %q", e)
+		}
+		ip = ip.Unmap()
+		out = append(out, netip.PrefixFrom(ip, ip.BitLen()))
+	}
+	return out, nil
+}
+
+// CheckEndpoint rejects what Send would refuse without resolving the host: a
+// malformed or non-http(s) URL, localhost, or an address literal the sender
+// may not reach. A name resolving to such an address is refused on dial.
+func (s *Sender) CheckEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return fmt.Errorf("webhook: invalid endpoint %q", endpoint)
+	}
+
+	host := strings.ToLower(strings.TrimSuffix(u.Hostname(), "."))
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, host)
+	}
+	if ip, err := netip.ParseAddr(host); err == nil && !s.permits(ip) {
+		return fmt.Errorf("%w: %s", ErrAddressNotAllowed, host)
+	}
+	return nil
+}
+
+// Send posts body to endpoint signed with secret. Any response outside 2xx,
+// a redirect included, is an error.
+func (s *Sender) Send(ctx context.Context, endpoint, secret string, body []byte) error {
+	if err := s.CheckEndpoint(endpoint); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("webhook: build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(SignatureHeader, Sign(secret, time.Now(), body))
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("webhook: send: %w", err)
+	}
+	defer res.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(res.Body, 1<<16))
+
+	if res.StatusCode < 200 || res.StatusCode > 299 {
+		return fmt.Errorf("webhook: endpoint responded %d", res.StatusCode)
+	}
+	return nil
+}
+
+func (s *Sender) permits(ip netip.Addr) bool {
+	ip = ip.Unmap()
+	for _, p := range s.allowed {
+		if p.Contains(ip) {
+			return true
+		}
+	}
+	return ip.IsGlobalUnicast() && !ip.IsPrivate()
+}
@@ -0,0 +1,123 @@
+package webhook_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+)
+
+// loopback lets the tests reach their httptest receivers
+var loopback = netip.MustParsePrefix("127.0.0.0/8")
+
+func TestSender_Send(t *testing.T) {
+	body := []byte(`{"action":"ticket.ticket.bulk_deleted"}`)
+
+	var verifyErr error
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, _ := io.ReadAll(r.Body)
+		verifyErr = webhook.Verify(secret, r.Header.Get(webhook.SignatureHeader), got, time.Now(), webhook.DefaultTolerance)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	if err := webhook.NewSender(time.Second, loopback).Send(context.Background(), srv.URL, secret, body); err != nil {
+		t.Fatalf("Send = %v, want nil", err)
+	}
+	if verifyErr != nil {
+		t.Fatalf("receiver could not verify the delivery: %v", verifyErr)
+	}
+}
+
+func TestSender_SendFails(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	s := webhook.NewSender(time.Second, loopback)
+	for _, endpoint := range []string{srv.URL, "ftp://example.com/hook", "not a url"} {
+		if err := s.Send(context.Background(), endpoint, secret, []byte(`{}`)); err == nil {
+			t.Errorf("Send(%q) = nil, want an error", endpoint)
+		}
+	}
+}
+
+func TestSender_RefusesInternalAddresses(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+	redirect := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Redirect(w, r, srv.URL, http.StatusTemporaryRedirect)
+	}))
+	defer redirect.Close()
+
+	tests := []struct {
+		name     string
+		sender   *webhook.Sender
+		endpoint string
+		wantErr  error
+	}{
+		{"loopback", webhook.NewSender(time.Second), srv.URL, webhook.ErrAddressNotAllowed},
+		{"localhost", webhook.NewSender(time.Second), "http://localhost:6060/debug/pprof/", webhook.ErrAddressNotAllowed},
+		{"metadata service", webhook.NewSender(time.Second), "http://169.254.169.254/latest/meta-data/", webhook.ErrAddressNotAllowed},
+		{"private", webhook.NewSender(time.Second), "https://10.0.0.5/hook", webhook.ErrAddressNotAllowed},
+		{"unspecified", webhook.NewSender(time.Second), "http://0.0.0.0:8080/", webhook.ErrAddressNotAllowed},
+		{"mapped loopback", webhook.NewSender(time.Second), "http://[::ffff:127.0.0.1]/", webhook.ErrAddressNotAllowed},
+		{"redirect not followed", webhook.NewSender(time.Second, loopback), redirect.URL, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hits = 0
+			err := tt.sender.Send(context.Background(), tt.endpoint, secret, []byte(`{}`))
+			if err == nil {
+				t.Fatal("Send = nil, want an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Send = %v, want %v", err, tt.wantErr)
+			}
+			if hits != 0 {
+				t.Fatalf("the internal receiver was reached %d times", hits)
+			}
+		})
+	}
+}
+
+func TestSender_CheckEndpoint(t *testing.T) {
+	s := webhook.NewSender(time.Second, netip.MustParsePrefix("10.1.0.0/16"))
+
+	tests := []struct {
+		endpoint string
+		ok       bool
+	}{
+		{"https://audit.example.com/fluxis", true},
+		{"http://93.184.215.14/hook", true},
+		{"https://10.1.2.3/hook", true},
+		{"https://10.2.0.1/hook", false},
+		{"http://192.168.1.1/", false},
+		{"http://[fe80::1]/", false},
+		{"http://[::1]/", false},
+		{"http://LOCALHOST./", false},
+		{"http://api.localhost/", false},
+		{"ftp://example.com/hook", false},
+		{"file:///etc/passwd", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.endpoint, func(t *testing.T) {
+			if err := s.CheckEndpoint(tt.endpoint); (err == nil) != tt.ok {
+				t.Fatalf("CheckEndpoint = %v, want ok %v", err, tt.ok)
+			}
+		})
+	}
+}
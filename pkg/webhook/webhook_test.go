@@ -0,0 +1,79 @@
+package webhook_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/dimasbaguspm/fluxis/pkg/webhook"
+)
+
+const secret = "whsec_test"
+
+func TestVerify_AcceptsOwnSignature(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"title":"hello"}`)
+
+	header := webhook.Sign(secret, now, body)
+	if err := webhook.Verify(secret, header, body, now.Add(time.Minute), webhook.DefaultTolerance); err != nil {
+		t.Fatalf("Verify = %v, want nil", err)
+	}
+}
+
+func TestVerify_Rejects(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{"title":"hello"}`)
+	valid := webhook.Sign(secret, now, body)
+
+	tests := []struct {
+		name   string
+		secret string
+		header string
+		body   string
+		now    time.Time
+		want   error
+	}{
+		{"missing header", secret, "", string(body), now, webhook.ErrMissingSignature},
+		{"no digest", secret, "t=1700000000", string(body), now, webhook.ErrMalformedSignature},
+		{"bad hex", secret, "t=1700000000,v1=zz", string(body), now, webhook.ErrMalformedSignature},
+		{"bad timestamp", secret, "t=soon,v1=00", string(body), now, webhook.ErrMalformedSignature},
+		{"tampered body", secret, valid, `{"title":"bye"}`, now, webhook.ErrInvalidSignature},
+		{"wrong secret", "whsec_other", valid, string(body), now, webhook.ErrInvalidSignature},
+		{"replayed late", secret, valid, string(body), now.Add(10 * time.Minute), webhook.ErrExpiredSignature},
+		{"from the future", secret, valid, string(body), now.Add(-10 * time.Minute), webhook.ErrExpiredSignature},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := webhook.Verify(tt.secret, tt.header, []byte(tt.body), tt.now, webhook.DefaultTolerance)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("Verify = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestVerify_AcceptsAnyOfSeveralDigests(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	body := []byte(`{}`)
+
+	old := webhook.Sign("whsec_old", now, body)
+	current := webhook.Sign(secret, now, body)
+	_, currentDigest, _ := strings.Cut(current, ",")
+
+	if err := webhook.Verify(secret, old+","+currentDigest, body, now, webhook.DefaultTolerance); err != nil {
+		t.Fatalf("Verify = %v, want nil", err)
+	}
+}
+
+func TestNewSecret_IsRandom(t *testing.T) {
+	a, err := webhook.NewSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := webhook.NewSecret()
+	if a == b || !strings.HasPrefix(a, "whsec_") {
+		t.Fatalf("unexpected secrets %q and %q", a, b)
+	}
+}
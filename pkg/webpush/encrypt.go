@@ -0,0 +1,98 @@
+package webpush
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"fmt"
+)
+
+// encrypt seals payload for the subscription as a single aes128gcm record.
+// The content key is derived from an ephemeral ECDH exchange with the user
+// agent's p256dh key and mixed with its auth secret, see RFC 8291 section 3.
+func encrypt(sub Subscription, payload []byte) ([]byte, error) {
+	if len(payload) > MaxPayload {
+		return nil, ErrPayloadTooLarge
+	}
+
+	rawUA, err := decode(sub.P256dh)
+	if err != nil {
+		return nil, fmt.Errorf("%w: p256dh: %v", ErrInvalidKey, err)
+	}
+	uaPublic, err := ecdh.P256().NewPublicKey(rawUA)
+	if err != nil {
+		return nil, fmt.Errorf("%w: p256dh: %v", ErrInvalidKey, err)
+	}
+	authSecret, err := decode(sub.Auth)
+	if err != nil || len(authSecret) == 0 {
+		return nil, fmt.Errorf("%w: auth secret", ErrInvalidKey)
+	}
+
+	asPrivate, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: generate key: %w", err)
+	}
+	shared, err := asPrivate.ECDH(uaPublic)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: ecdh: %w", err)
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("webpush: salt: %w", err)
+	}
+
+	asPublic := asPrivate.PublicKey().Bytes()
+	cek, nonce, err := deriveKeys(shared, authSecret, salt, uaPublic.Bytes(), asPublic)
+	if err != nil {
+		return nil, err
+	}
+
+	block, err := aes.NewCipher(cek)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: cipher: %w", err)
+	}
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("webpush: gcm: %w", err)
+	}
+
+	// 0x02 marks the last (and only) record, no further padding is added
+	plaintext := append(append(make([]byte, 0, len(payload)+1), payload...), 0x02)
+
+	out := make([]byte, 0, headerLength+len(plaintext)+tagLength)
+	out = append(out, salt...)
+	out = binary.BigEndian.AppendUint32(out, recordSize)
+	out = append(out, byte(len(asPublic)))
+	out = append(out, asPublic...)
+	return gcm.Seal(out, nonce, plaintext, nil), nil
+}
+
+// deriveKeys returns the content encryption key and nonce for one message
+func deriveKeys(shared, authSecret, salt, uaPublic, asPublic []byte) ([]byte, []byte, error) {
+	keyInfo := append([]byte("WebPush: info\x00"), uaPublic...)
+	keyInfo = append(keyInfo, asPublic...)
+
+	ikm, err := hkdf.Key(sha256.New, shared, authSecret, string(keyInfo), 32)
+	if err != nil {
+		return nil, nil, fmt.Errorf("webpush: derive ikm: %w", err)
+	}
+
+	prk, err := hkdf.Extract(sha256.New, ikm, salt)
+	if err != nil {
+		return nil, nil, fmt.Errorf("webpush: extract prk: %w", err)
+	}
+	cek, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: aes128gcm\x00", 16)
+	if err != nil {
+		return nil, nil, fmt.Errorf("webpush: derive cek: %w", err)
+	}
+	nonce, err := hkdf.Expand(sha256.New, prk, "Content-Encoding: nonce\x00", 12)
+	if err != nil {
+		return nil, nil, fmt.Errorf("webpush: derive nonce: %w", err)
+	}
+	return cek, nonce, nil
+}
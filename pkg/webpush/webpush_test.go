@@ -0,0 +1,247 @@
+package webpush
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func b64(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+// TestDeriveKeys_RFC8291Vector checks the key schedule against the worked
+// example in RFC 8291 appendix A
+func TestDeriveKeys_RFC8291Vector(t *testing.T) {
+	cek, nonce, err := deriveKeys(
+		b64(t, "kyrL1jIIOHEzg3sM2ZWRHDRB62YACZhhSlknJ672kSs"),
+		b64(t, "BTBZMqHH6r4Tts7J_aSIgg"),
+		b64(t, "DGv6ra1nlYgDCS1FRnbzlw"),
+		b64(t, "BCVxsr7N_eNgVRqvHtD0zTZsEc6-VV-JvLexhqUzORcxaOzi6-AYWXvTBHm4bjyPjs7Vd8pZGH6SRpkNtoIAiw4"),
+		b64(t, "BP4z9KsN6nGRTbVYI_c7VJSPQTBtkgcy27mlmlMoZIIgDll6e3vCYLocInmYWAmS6TlzAC8wEqKK6PBru3jl7A8"),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := base64.RawURLEncoding.EncodeToString(cek); got != "oIhVW04MRdy2XN9CiKLxTg" {
+		t.Errorf("cek = %s", got)
+	}
+	if got := base64.RawURLEncoding.EncodeToString(nonce); got != "4h_95klXJ5E_qnoN" {
+		t.Errorf("nonce = %s", got)
+	}
+}
+
+// userAgent plays the browser side: it owns the p256dh key pair and can open
+// what the sender sealed
+type userAgent struct {
+	key  *ecdh.PrivateKey
+	auth []byte
+}
+
+func newUserAgent(t *testing.T) userAgent {
+	t.Helper()
+	key, err := ecdh.P256().GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	auth := make([]byte, 16)
+	rand.Read(auth)
+	return userAgent{key: key, auth: auth}
+}
+
+func (ua userAgent) subscription(endpoint string) Subscription {
+	return Subscription{
+		Endpoint: endpoint,
+		P256dh:   base64.RawURLEncoding.EncodeToString(ua.key.PublicKey().Bytes()),
+		Auth:     base64.RawURLEncoding.EncodeToString(ua.auth),
+	}
+}
+
+func (ua userAgent) decrypt(t *testing.T, body []byte) []byte {
+	t.Helper()
+	if len(body) < headerLength {
+		t.Fatalf("body too short: %d bytes", len(body))
+	}
+	salt := body[:saltLength]
+	if rs := binary.BigEndian.Uint32(body[saltLength:]); rs != recordSize {
+		t.Fatalf("record size = %d", rs)
+	}
+	idLen := int(body[saltLength+4])
+	asPublic := body[saltLength+5 : saltLength+5+idLen]
+
+	asKey, err := ecdh.P256().NewPublicKey(asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shared, err := ua.key.ECDH(asKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cek, nonce, err := deriveKeys(shared, ua.auth, salt, ua.key.PublicKey().Bytes(), asPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	block, _ := aes.NewCipher(cek)
+	gcm, _ := cipher.NewGCM(block)
+	plain, err := gcm.Open(nil, nonce, body[saltLength+5+idLen:], nil)
+	if err != nil {
+		t.Fatalf("open record: %v", err)
+	}
+	if plain[len(plain)-1] != 0x02 {
+		t.Fatalf("expected last record delimiter, got %x", plain[len(plain)-1])
+	}
+	return plain[:len(plain)-1]
+}
+
+func TestEncrypt_RoundTrip(t *testing.T) {
+	ua := newUserAgent(t)
+	payload := []byte(`{"title":"PRJ-1 was assigned to you"}`)
+
+	body, err := encrypt(ua.subscription("https://push.example.com/x"), payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ua.decrypt(t, body); !bytes.Equal(got, payload) {
+		t.Fatalf("decrypted %q, want %q", got, payload)
+	}
+}
+
+func TestEncrypt_RejectsOversizedPayload(t *testing.T) {
+	ua := newUserAgent(t)
+	sub := ua.subscription("https://push.example.com/x")
+
+	if _, err := encrypt(sub, make([]byte, MaxPayload)); err != nil {
+		t.Fatalf("payload of MaxPayload bytes should fit: %v", err)
+	}
+	if _, err := encrypt(sub, make([]byte, MaxPayload+1)); !errors.Is(err, ErrPayloadTooLarge) {
+		t.Fatalf("expected ErrPayloadTooLarge, got %v", err)
+	}
+}
+
+func TestEncrypt_RejectsInvalidKeys(t *testing.T) {
+	sub := Subscription{Endpoint: "https://push.example.com/x", P256dh: "not-a-key", Auth: "BTBZMqHH6r4Tts7J_aSIgg"}
+	if _, err := encrypt(sub, []byte("hi")); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+}
+
+func newTestSender(t *testing.T, srv *httptest.Server) *Sender {
+	t.Helper()
+	key, err := GenerateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := New(Config{PrivateKey: key, Subject: "mailto:ops@fluxis.test", TTL: time.Hour})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.client = srv.Client()
+	return s
+}
+
+func TestSend_SignsAndEncrypts(t *testing.T) {
+	ua := newUserAgent(t)
+	payload := []byte("hello")
+
+	var got *http.Request
+	var body []byte
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r
+		body, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer srv.Close()
+
+	s := newTestSender(t, srv)
+	if err := s.Send(context.Background(), ua.subscription(srv.URL+"/push/abc"), payload); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Header.Get("Content-Encoding") != "aes128gcm" {
+		t.Errorf("Content-Encoding = %q", got.Header.Get("Content-Encoding"))
+	}
+	if got.Header.Get("TTL") != "3600" {
+		t.Errorf("TTL = %q", got.Header.Get("TTL"))
+	}
+	if plain := ua.decrypt(t, body); !bytes.Equal(plain, payload) {
+		t.Errorf("decrypted %q, want %q", plain, payload)
+	}
+
+	// Authorization: vapid t=<jwt>, k=<public key>
+	auth, ok := strings.CutPrefix(got.Header.Get("Authorization"), "vapid t=")
+	if !ok {
+		t.Fatalf("unexpected Authorization %q", got.Header.Get("Authorization"))
+	}
+	token, k, ok := strings.Cut(auth, ", k=")
+	if !ok || k != s.PublicKey() {
+		t.Fatalf("expected k=%s in Authorization, got %q", s.PublicKey(), auth)
+	}
+
+	pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), b64(t, k))
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims := jwt.MapClaims{}
+	if _, err := jwt.ParseWithClaims(token, claims, func(*jwt.Token) (any, error) { return pub, nil },
+		jwt.WithValidMethods([]string{"ES256"}), jwt.WithAudience(srv.URL)); err != nil {
+		t.Fatalf("vapid token does not verify: %v", err)
+	}
+	if claims["sub"] != "mailto:ops@fluxis.test" {
+		t.Errorf("sub = %v", claims["sub"])
+	}
+}
+
+func TestSend_ReportsGoneSubscriptions(t *testing.T) {
+	for _, status := range []int{http.StatusNotFound, http.StatusGone} {
+		srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		s := newTestSender(t, srv)
+		err := s.Send(context.Background(), newUserAgent(t).subscription(srv.URL), []byte("hi"))
+		srv.Close()
+
+		if !errors.Is(err, ErrGone) {
+			t.Errorf("status %d: expected ErrGone, got %v", status, err)
+		}
+	}
+}
+
+func TestSend_RejectsPlainHTTPEndpoint(t *testing.T) {
+	key, _ := GenerateKey()
+	s, err := New(Config{PrivateKey: key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Send(context.Background(), newUserAgent(t).subscription("http://push.example.com/x"), []byte("hi")); err == nil {
+		t.Fatal("expected an error for a non-https endpoint")
+	}
+}
+
+func TestNew_RejectsInvalidKey(t *testing.T) {
+	if _, err := New(Config{PrivateKey: "c2hvcnQ"}); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build embedweb
+
+package web
+
+import (
+	"embed"
+	"io/fs"
+)
+
+// dist is populated by `yarn build` before compiling with -tags embedweb
+//
+//go:embed all:dist
+var dist embed.FS
+
+// Dist returns the built frontend bundled into the binary
+func Dist() (fs.FS, bool) {
+	sub, err := fs.Sub(dist, "dist")
+	if err != nil {
+		return nil, false
+	}
+	return sub, true
+}
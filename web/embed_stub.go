@@ -0,0 +1,10 @@
+//go:build !embedweb
+
+package web
+
+import "io/fs"
+
+// Dist reports no bundled frontend; build with -tags embedweb to embed web/dist
+func Dist() (fs.FS, bool) {
+	return nil, false
+}